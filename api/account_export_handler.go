@@ -0,0 +1,218 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultStatsExportDays is the length of the period covered by a statistics export when none is given
+	DefaultStatsExportDays = 30
+
+	// MaxStatsExportDays caps the length of the period covered by a statistics export
+	MaxStatsExportDays = 366
+
+	// MaxSyncStatsExportRecipes is the most recipes a user can have for their statistics to be exported within the request;
+	// exports for users with more are built in the background
+	MaxSyncStatsExportRecipes = 100
+)
+
+type AccountExportHandler struct {
+	Exports    *services.AccountExportService
+	Usage      *services.UsageService
+	StatsStore store.RecipeStatsStore
+	UserStore  store.UserStore
+}
+
+func NewAccountExportHandler(exports *services.AccountExportService, usage *services.UsageService, statsStore store.RecipeStatsStore, userStore store.UserStore) *AccountExportHandler {
+	return &AccountExportHandler{
+		Exports:    exports,
+		Usage:      usage,
+		StatsStore: statsStore,
+		UserStore:  userStore,
+	}
+}
+
+// RequestExport godoc
+// @Summary Request an export of my data
+// @Description Queues a zip archive of everything the authenticated user has stored: profile and preferences, recipes with their uploaded photos, reviews, cooking history, pantry and shopping lists. The archive is built in the background; poll GET /users/me/export/{id} for progress and a download link. If an export is already queued or running it is returned instead of starting another. Counts towards the daily export quota.
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} store.AccountExport "Export already in progress"
+// @Success 202 {object} store.AccountExport "Export queued"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 429 {object} map[string]string "Daily export quota exceeded"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/export [post]
+func (h *AccountExportHandler) RequestExport(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	if !checkUsageQuota(c, h.Usage, services.UsageExport) {
+		return
+	}
+
+	export, created, err := h.Exports.Request(userID)
+	if err != nil {
+		log.Printf("Failed to request account export: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, export)
+}
+
+// GetExport godoc
+// @Summary Get the status of a data export
+// @Description Returns an export's status (queued, running, done or failed) and progress as a percentage. Once done, the response includes a signed download_url valid until download_expires_at; poll again for a fresh link until the archive itself is deleted at expires_at.
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Export ID"
+// @Success 200 {object} store.AccountExport "Export status"
+// @Failure 400 {object} map[string]string "Invalid export ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Export not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/export/{id} [get]
+func (h *AccountExportHandler) GetExport(c *gin.Context) {
+	exportID, ok := parseIDParam(c, "id", "export ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	export, err := h.Exports.Get(exportID, userID)
+	if err != nil {
+		log.Printf("Failed to get account export: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if export == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "export not found"})
+		return
+	}
+
+	// Download links are signed per request, so they must not be cached
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, export)
+}
+
+// ExportStats godoc
+// @Summary Export my recipe statistics
+// @Description Returns a CSV with one row per recipe of the authenticated user: views (not counting the author's own), new favorites, new reviews and their average rating between from and to, alongside all-time favorites, reviews and average rating. Dates are UTC days in YYYY-MM-DD form; the period defaults to the last 30 days and may cover at most 366. Users with more than 100 recipes get 202 with an export to poll at GET /users/me/export/{id} instead, whose download_url serves the CSV once done; if a statistics export is already queued or running it is returned with 200. Counts towards the daily export quota.
+// @Tags Users
+// @Produce text/csv,json
+// @Security BearerAuth
+// @Param from query string false "First day of the period (YYYY-MM-DD)"
+// @Param to query string false "Last day of the period (YYYY-MM-DD), today by default"
+// @Success 200 {string} string "Recipe statistics CSV, or the statistics export already in progress"
+// @Success 202 {object} store.AccountExport "Export queued"
+// @Failure 400 {object} map[string]string "Invalid period"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 429 {object} map[string]string "Daily export quota exceeded"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/stats/export [get]
+func (h *AccountExportHandler) ExportStats(c *gin.Context) {
+	from, to, ok := parseStatsPeriod(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	count, err := h.StatsStore.CountUserRecipes(userID)
+	if err != nil {
+		log.Printf("Failed to count user recipes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if !checkUsageQuota(c, h.Usage, services.UsageExport) {
+		return
+	}
+
+	if count > MaxSyncStatsExportRecipes {
+		export, created, err := h.Exports.RequestStats(userID, from, to)
+		if err != nil {
+			log.Printf("Failed to request recipe stats export: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+
+		status := http.StatusOK
+		if created {
+			status = http.StatusAccepted
+		}
+		c.JSON(status, export)
+		return
+	}
+
+	filename := fmt.Sprintf("recipe-stats-%s-%s.csv", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	export := newCSVExport(c, filename, services.RecipeStatsCSVHeader)
+	err = h.StatsStore.EachRecipeStats(userID, from, to, func(stats *store.RecipeStats) error {
+		return export.Write(services.RecipeStatsCSVRecord(stats, from, to))
+	})
+	export.Close(err)
+}
+
+// parseStatsPeriod parses the from and to query parameters of a statistics export as UTC days
+// It writes a 400 response and returns false if either is invalid or the period is empty, in the future or too long
+func parseStatsPeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	to := today
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be a date in YYYY-MM-DD form"})
+			return time.Time{}, time.Time{}, false
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(DefaultStatsExportDays - 1))
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be a date in YYYY-MM-DD form"})
+			return time.Time{}, time.Time{}, false
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return time.Time{}, time.Time{}, false
+	}
+	if to.After(today) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must not be in the future"})
+		return time.Time{}, time.Time{}, false
+	}
+	if to.Sub(from) >= MaxStatsExportDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("the period may cover at most %d days", MaxStatsExportDays)})
+		return time.Time{}, time.Time{}, false
+	}
+
+	return from, to, true
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -36,6 +37,8 @@ type registeredUserRequest struct {
 	FirstName      string `json:"first_name"`
 	LastName       string `json:"last_name"`
 	ProfilePicture string `json:"profile_picture"`
+	InviteToken    string `json:"invite_token"`
+	ReferralCode   string `json:"referral_code"`
 }
 
 type AuthHandler struct {
@@ -43,6 +46,8 @@ type AuthHandler struct {
 	RefreshTokenStore      store.RefreshTokenStore
 	PasswordResetStore     store.PasswordResetStore
 	EmailVerificationStore store.EmailVerificationStore
+	InvitationStore        store.InvitationStore
+	ReferralStore          store.ReferralStore
 	EmailService           *services.EmailService
 	JWTService             *services.JWTService
 }
@@ -52,6 +57,8 @@ func NewAuthHandler(
 	refreshTokenStore store.RefreshTokenStore,
 	passwordResetStore store.PasswordResetStore,
 	emailVerificationStore store.EmailVerificationStore,
+	invitationStore store.InvitationStore,
+	referralStore store.ReferralStore,
 	emailService *services.EmailService,
 	jwtService *services.JWTService,
 ) *AuthHandler {
@@ -60,6 +67,8 @@ func NewAuthHandler(
 		RefreshTokenStore:      refreshTokenStore,
 		PasswordResetStore:     passwordResetStore,
 		EmailVerificationStore: emailVerificationStore,
+		InvitationStore:        invitationStore,
+		ReferralStore:          referralStore,
 		EmailService:           emailService,
 		JWTService:             jwtService,
 	}
@@ -67,7 +76,7 @@ func NewAuthHandler(
 
 // RegisterUser godoc
 // @Summary Register a new user
-// @Description Register a new user with the provided information
+// @Description Register a new user with the provided information. Pass the invite_token from an invitation link, or another user's referral_code, to attribute the account to the user who referred it.
 // @Tags Authentication
 // @Accept json
 // @Produce json
@@ -131,6 +140,38 @@ func (h *AuthHandler) RegisterUser(c *gin.Context) {
 		return
 	}
 
+	// Resolve the invitation the user signed up through, if any
+	var invitation *store.Invitation
+	if req.InviteToken != "" {
+		invitation, err = h.InvitationStore.GetInvitationByToken(req.InviteToken)
+		if err != nil {
+			log.Printf("Failed to get invitation: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+			return
+		}
+		if invitation == nil || invitation.Status != store.InvitationStatusSent || time.Now().After(invitation.ExpiresAt) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired invitation"})
+			return
+		}
+	}
+
+	// Resolve the referrer from a referral code; an invitation takes precedence
+	var referrerID int64
+	if invitation != nil {
+		referrerID = invitation.InviterID
+	} else if strings.TrimSpace(req.ReferralCode) != "" {
+		referrerID, err = h.ReferralStore.GetUserIDByReferralCode(req.ReferralCode)
+		if err != nil {
+			log.Printf("Failed to get referrer: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+			return
+		}
+		if referrerID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid referral code"})
+			return
+		}
+	}
+
 	// Create user model
 	user := &store.User{
 		UserID:         uuid.New().String(),
@@ -147,62 +188,55 @@ func (h *AuthHandler) RegisterUser(c *gin.Context) {
 		return
 	}
 
-	// Get database connection from the store
-	db := h.UserStore.(interface{ DB() *sql.DB }).DB()
+	ipAddress := c.ClientIP()
+	userAgent := c.Request.UserAgent()
+	fmt.Printf("User %s signed up from IP: %s, User-Agent: %s\n", user.Username, ipAddress, userAgent)
 
-	// Start a transaction for atomic operations
-	tx, err := db.Begin()
-	if err != nil {
-		log.Printf("Failed to begin transaction: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
-		return
-	}
+	// Create the user, record how they joined, and issue tokens as one unit of work
+	var accessToken string
+	var refreshToken *store.RefreshToken
+	err = store.WithTx(c.Request.Context(), h.UserStore.DB(), func(tx *sql.Tx) error {
+		if err := h.UserStore.CreateUserWithTransaction(user, tx); err != nil {
+			return err
+		}
 
-	// Defer a function to handle transaction completion based on success or failure
-	defer func() {
-		if err != nil {
-			// If we encountered an error, roll back the transaction
-			if txErr := tx.Rollback(); txErr != nil {
-				log.Printf("Failed to rollback transaction: %v", txErr)
+		// Mark the invitation accepted and attribute the new account to the inviter
+		if invitation != nil {
+			if err := h.InvitationStore.AcceptInvitationWithTransaction(invitation.ID, user.UserID, tx); err != nil {
+				return fmt.Errorf("failed to accept invitation: %w", err)
 			}
 		}
-	}()
 
-	// Insert user into DB within the transaction
-	err = h.UserStore.CreateUserWithTransaction(user, tx)
-	if err != nil {
-		log.Printf("Failed to create user: %v", err)
-		if strings.Contains(err.Error(), "duplicate key") {
-			if strings.Contains(err.Error(), "users_username_key") {
-				c.JSON(http.StatusConflict, gin.H{"error": "username already exists"})
-			} else if strings.Contains(err.Error(), "users_email_key") {
-				c.JSON(http.StatusConflict, gin.H{"error": "email already exists"})
-			} else {
-				c.JSON(http.StatusConflict, gin.H{"error": "username or email already exists"})
+		// Record the referral so the referrer can track the new account
+		if referrerID != 0 {
+			source := store.ReferralSourceCode
+			if invitation != nil {
+				source = store.ReferralSourceInvitation
+			}
+			if err := h.ReferralStore.CreateReferralWithTransaction(referrerID, user.UserID, source, tx); err != nil {
+				return fmt.Errorf("failed to create referral: %w", err)
 			}
-			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not create user"})
-		return
-	}
-
-	// Generate JWT tokens
-	ipAddress := c.ClientIP()
-	userAgent := c.Request.UserAgent()
-	fmt.Printf("User %s signed up from IP: %s, User-Agent: %s\n", user.Username, ipAddress, userAgent)
 
-	// Generate tokens within the transaction
-	accessToken, refreshToken, err := h.JWTService.GenerateTokenPairWithTransaction(user, ipAddress, userAgent, tx)
+		var err error
+		accessToken, refreshToken, err = h.JWTService.GenerateTokenPairWithTransaction(user, ipAddress, userAgent, tx)
+		return err
+	})
 	if err != nil {
-		log.Printf("Failed to generate token pair: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate auth tokens"})
-		return
-	}
-
-	// Commit the transaction since both user creation and token generation succeeded
-	if err = tx.Commit(); err != nil {
-		log.Printf("Failed to commit transaction: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+		log.Printf("Failed to register user: %v", err)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			// Only accepting the invitation reports a missing row: it expired or was used concurrently
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired invitation"})
+		case errors.Is(err, store.ErrConflict) && store.ConstraintName(err) == "users_username_key":
+			c.JSON(http.StatusConflict, gin.H{"error": "username already exists"})
+		case errors.Is(err, store.ErrConflict) && store.ConstraintName(err) == "users_email_key":
+			c.JSON(http.StatusConflict, gin.H{"error": "email already exists"})
+		case errors.Is(err, store.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": "username or email already exists"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not create user"})
+		}
 		return
 	}
 
@@ -250,10 +284,7 @@ func (h *AuthHandler) RegisterUser(c *gin.Context) {
 	// Return success with tokens
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "user created successfully",
-		"tokens": gin.H{
-			"access_token":  accessToken,
-			"refresh_token": refreshToken.Token,
-		},
+		"tokens":  sessionTokens(c, h.JWTService, accessToken, refreshToken.Token),
 		"user": gin.H{
 			"user_id":         user.UserID,
 			"username":        user.Username,
@@ -264,6 +295,7 @@ func (h *AuthHandler) RegisterUser(c *gin.Context) {
 			"profile_picture": user.ProfilePicture,
 			"email_verified":  user.EmailVerified,
 			"created_at":      user.CreatedAt,
+			"_links":          currentUserLinks(user.Username),
 		},
 	})
 }
@@ -278,6 +310,7 @@ func (h *AuthHandler) RegisterUser(c *gin.Context) {
 // @Success 200 {object} map[string]interface{} "Login successful with user info and tokens"
 // @Failure 400 {object} map[string]string "Invalid request"
 // @Failure 401 {object} map[string]string "Invalid credentials"
+// @Failure 403 {object} map[string]string "Account disabled pending email verification"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /auth/login [post]
 func (h *AuthHandler) LoginUser(c *gin.Context) {
@@ -311,6 +344,11 @@ func (h *AuthHandler) LoginUser(c *gin.Context) {
 		return
 	}
 
+	if user.Disabled {
+		c.JSON(http.StatusForbidden, gin.H{"error": "account disabled because its email address was never verified; verify it to reactivate the account"})
+		return
+	}
+
 	// Update last_login timestamp
 	err = h.UserStore.UpdateLastLogin(user.UserID)
 	if err != nil {
@@ -334,10 +372,7 @@ func (h *AuthHandler) LoginUser(c *gin.Context) {
 	// Return success
 	c.JSON(http.StatusOK, gin.H{
 		"message": "login successful",
-		"tokens": gin.H{
-			"access_token":  accessToken,
-			"refresh_token": refreshToken.Token,
-		},
+		"tokens":  sessionTokens(c, h.JWTService, accessToken, refreshToken.Token),
 		"user": gin.H{
 			"user_id":         user.UserID,
 			"username":        user.Username,
@@ -347,8 +382,11 @@ func (h *AuthHandler) LoginUser(c *gin.Context) {
 			"last_name":       user.LastName,
 			"profile_picture": user.ProfilePicture,
 			"email_verified":  user.EmailVerified,
+			"verified_chef":   user.VerifiedChef,
+			"timezone":        user.Timezone,
 			"created_at":      user.CreatedAt,
 			"last_login":      user.LastLogin,
+			"_links":          currentUserLinks(user.Username),
 		},
 	})
 }
@@ -373,9 +411,23 @@ func (h *AuthHandler) LogoutUser(c *gin.Context) {
 		AccessToken  string `json:"access_token"` // Optional, but helps with explicit blacklisting
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "missing refresh token"})
-		return
+	// In cookie mode the tokens come as cookies and the body may be empty
+	if c.Request.ContentLength != 0 || !h.JWTService.CookieMode() {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "missing refresh token"})
+			return
+		}
+	}
+
+	if h.JWTService.CookieMode() {
+		// The auth middleware has already checked the CSRF token for cookie sessions
+		if req.RefreshToken == "" {
+			req.RefreshToken = h.JWTService.RefreshTokenCookie(c.Request)
+		}
+		if req.AccessToken == "" {
+			req.AccessToken = h.JWTService.AccessTokenCookie(c.Request)
+		}
+		h.JWTService.ClearSessionCookies(c.Writer)
 	}
 
 	refreshTokenString := req.RefreshToken
@@ -431,12 +483,23 @@ func (h *AuthHandler) RefreshAccessToken(c *gin.Context) {
 		RefreshToken string `json:"refresh_token"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing refresh token"})
-		return
+	// In cookie mode the refresh token comes as a cookie and the body may be empty
+	if c.Request.ContentLength != 0 || !h.JWTService.CookieMode() {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing refresh token"})
+			return
+		}
 	}
 
 	refreshTokenString := req.RefreshToken
+	if refreshTokenString == "" && h.JWTService.CookieMode() {
+		refreshTokenString = h.JWTService.RefreshTokenCookie(c.Request)
+		// Browsers send the cookie with cross-site requests too, so they must prove they come from our frontend
+		if refreshTokenString != "" && !h.JWTService.ValidCSRF(c.Request) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "invalid CSRF token"})
+			return
+		}
+	}
 	if refreshTokenString == "" {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing refresh token"})
 		return
@@ -453,10 +516,7 @@ func (h *AuthHandler) RefreshAccessToken(c *gin.Context) {
 	// Return new access token and new refresh token
 	c.JSON(http.StatusOK, gin.H{
 		"message": "token refreshed",
-		"tokens": gin.H{
-			"access_token":  newAccessToken,
-			"refresh_token": newRefreshToken.Token,
-		},
+		"tokens":  sessionTokens(c, h.JWTService, newAccessToken, newRefreshToken.Token),
 	})
 }
 
@@ -500,11 +560,14 @@ func (h *AuthHandler) GetAuthenticatedUser(c *gin.Context) {
 			"username":        user.Username,
 			"email":           user.Email, // Email is kept as the user is viewing their own profile
 			"email_verified":  user.EmailVerified,
+			"verified_chef":   user.VerifiedChef,
 			"bio":             user.Bio,
 			"first_name":      user.FirstName,
 			"last_name":       user.LastName,
 			"profile_picture": user.ProfilePicture,
+			"timezone":        user.Timezone,
 			"created_at":      user.CreatedAt,
+			"_links":          currentUserLinks(user.Username),
 		},
 	})
 }
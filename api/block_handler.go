@@ -0,0 +1,158 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+type BlockHandler struct {
+	BlockStore store.BlockStore
+	UserStore  store.UserStore
+}
+
+func NewBlockHandler(blockStore store.BlockStore, userStore store.UserStore) *BlockHandler {
+	return &BlockHandler{
+		BlockStore: blockStore,
+		UserStore:  userStore,
+	}
+}
+
+type blockUserRequest struct {
+	Username string `json:"username"`
+}
+
+// resolveUsername returns the internal ID of the user with a username
+// It writes an error response and returns false if the user does not exist
+func (h *BlockHandler) resolveUsername(c *gin.Context, username string) (int64, bool) {
+	id, err := h.UserStore.GetUserInternalIDByUsername(username)
+	if err != nil {
+		log.Printf("Failed to look up user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return 0, false
+	}
+	if id == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return 0, false
+	}
+	return id, true
+}
+
+// BlockUser godoc
+// @Summary Block a user
+// @Description Adds a user to the authenticated user's block list. Blocked users cannot start conversations with or message the blocker, and vice versa.
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param request body blockUserRequest true "Username to block"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "User blocked"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/blocks [post]
+func (h *BlockHandler) BlockUser(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req blockUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	username := strings.TrimSpace(req.Username)
+	if username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "username is required"})
+		return
+	}
+
+	blockedID, ok := h.resolveUsername(c, username)
+	if !ok {
+		return
+	}
+	if blockedID == userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "you cannot block yourself"})
+		return
+	}
+
+	if err := h.BlockStore.BlockUser(userID, blockedID); err != nil {
+		log.Printf("Failed to block user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to block user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user blocked"})
+}
+
+// UnblockUser godoc
+// @Summary Unblock a user
+// @Description Removes a user from the authenticated user's block list
+// @Tags Messages
+// @Produce json
+// @Param username path string true "Username to unblock"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "User unblocked"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "User not found or not blocked"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/blocks/{username} [delete]
+func (h *BlockHandler) UnblockUser(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	blockedID, ok := h.resolveUsername(c, c.Param("username"))
+	if !ok {
+		return
+	}
+
+	err := h.BlockStore.UnblockUser(userID, blockedID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user is not blocked"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to unblock user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to unblock user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user unblocked"})
+}
+
+// GetBlockedUsers godoc
+// @Summary List blocked users
+// @Description Returns the users on the authenticated user's block list, most recently blocked first
+// @Tags Messages
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Blocked users"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/blocks [get]
+func (h *BlockHandler) GetBlockedUsers(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	users, err := h.BlockStore.GetBlockedUsers(userID)
+	if err != nil {
+		log.Printf("Failed to get blocked users: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"blocked_users": users,
+	})
+}
@@ -0,0 +1,308 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// MaxChefCredentialsLength caps the credentials text of a verified chef application
+	MaxChefCredentialsLength = 2000
+
+	// MaxChefReviewNoteLength caps the note an admin leaves when reviewing an application
+	MaxChefReviewNoteLength = 1000
+
+	// DefaultChefApplicationsPageSize is the number of applications returned per page when no limit is given
+	DefaultChefApplicationsPageSize = 20
+
+	// MaxChefApplicationsPageSize caps the number of applications returned per page
+	MaxChefApplicationsPageSize = 100
+)
+
+type ChefApplicationHandler struct {
+	ChefApplicationStore store.ChefApplicationStore
+	NotificationStore    store.NotificationStore
+	UserStore            store.UserStore
+}
+
+func NewChefApplicationHandler(chefApplicationStore store.ChefApplicationStore, notificationStore store.NotificationStore, userStore store.UserStore) *ChefApplicationHandler {
+	return &ChefApplicationHandler{
+		ChefApplicationStore: chefApplicationStore,
+		NotificationStore:    notificationStore,
+		UserStore:            userStore,
+	}
+}
+
+type chefApplicationRequest struct {
+	Credentials  string `json:"credentials"`
+	PortfolioURL string `json:"portfolio_url"`
+}
+
+type reviewChefApplicationRequest struct {
+	Status string `json:"status"`
+	Note   string `json:"note"`
+}
+
+// ApplyForVerification godoc
+// @Summary Apply to become a verified chef
+// @Description Submits credentials (training, professional experience, publications) and an optional portfolio link for admin review. Only one application can be pending at a time.
+// @Tags Verified Chefs
+// @Accept json
+// @Produce json
+// @Param request body chefApplicationRequest true "Credentials and optional portfolio URL"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Application submitted"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Already verified or application pending"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/chef-application [post]
+func (h *ChefApplicationHandler) ApplyForVerification(c *gin.Context) {
+	userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req chefApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	credentials := strings.TrimSpace(req.Credentials)
+	if credentials == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "credentials are required"})
+		return
+	}
+	if len(credentials) > MaxChefCredentialsLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("credentials must be at most %d characters", MaxChefCredentialsLength)})
+		return
+	}
+
+	var portfolioURL *string
+	if url := strings.TrimSpace(req.PortfolioURL); url != "" {
+		if !utils.IsValidURL(url) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid portfolio URL"})
+			return
+		}
+		portfolioURL = &url
+	}
+
+	user, err := h.UserStore.GetUserByID(userID)
+	if err != nil {
+		log.Printf("Failed to get user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+		return
+	}
+	if user.VerifiedChef {
+		c.JSON(http.StatusConflict, gin.H{"error": "you are already a verified chef"})
+		return
+	}
+
+	internalID, err := h.UserStore.GetUserInternalID(userID)
+	if err != nil {
+		log.Printf("Failed to resolve user ID %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	application, err := h.ChefApplicationStore.CreateChefApplication(internalID, credentials, portfolioURL)
+	if err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": "you already have an application awaiting review"})
+			return
+		}
+		log.Printf("Failed to create chef application: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to submit application"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":     "application submitted",
+		"application": application,
+	})
+}
+
+// GetMyVerification godoc
+// @Summary Get my verified chef status
+// @Description Returns whether the authenticated user is a verified chef and their most recent application, if any
+// @Tags Verified Chefs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Verification status and latest application"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/chef-application [get]
+func (h *ChefApplicationHandler) GetMyVerification(c *gin.Context) {
+	userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.UserStore.GetUserByID(userID)
+	if err != nil {
+		log.Printf("Failed to get user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+		return
+	}
+
+	internalID, err := h.UserStore.GetUserInternalID(userID)
+	if err != nil {
+		log.Printf("Failed to resolve user ID %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	application, err := h.ChefApplicationStore.GetLatestChefApplication(internalID)
+	if err != nil {
+		log.Printf("Failed to get chef application: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"verified_chef": user.VerifiedChef,
+		"application":   application,
+	})
+}
+
+// ListChefApplications godoc
+// @Summary List verified chef applications
+// @Description Returns the review queue of verified chef applications with a status (default pending), oldest first. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param status query string false "Application status: pending, approved, or rejected (default pending)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Applications per page (default 20, max 100)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Applications with pagination"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/chef-applications [get]
+func (h *ChefApplicationHandler) ListChefApplications(c *gin.Context) {
+	status := c.DefaultQuery("status", store.ChefApplicationPending)
+	if !store.IsValidChefApplicationStatus(status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be pending, approved, or rejected"})
+		return
+	}
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultChefApplicationsPageSize, MaxChefApplicationsPageSize)
+	if !ok {
+		return
+	}
+
+	applications, total, err := h.ChefApplicationStore.GetChefApplications(status, page, limit)
+	if err != nil {
+		log.Printf("Failed to get chef applications: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"applications": applications,
+		"pagination":   newPagination(page, limit, total),
+	})
+}
+
+// ReviewChefApplication godoc
+// @Summary Approve or reject a verified chef application
+// @Description Records the decision on a pending application with an optional note. Approving it marks the applicant as a verified chef. The applicant is notified in-app. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Application ID"
+// @Param request body reviewChefApplicationRequest true "Decision (approved or rejected) and optional note"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Application reviewed"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Application not found or already reviewed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/chef-applications/{id} [put]
+func (h *ChefApplicationHandler) ReviewChefApplication(c *gin.Context) {
+	applicationID, ok := parseIDParam(c, "id", "application ID")
+	if !ok {
+		return
+	}
+
+	reviewerID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req reviewChefApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Status != store.ChefApplicationApproved && req.Status != store.ChefApplicationRejected {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be approved or rejected"})
+		return
+	}
+
+	var note *string
+	if trimmed := strings.TrimSpace(req.Note); trimmed != "" {
+		if len(trimmed) > MaxChefReviewNoteLength {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("note must be at most %d characters", MaxChefReviewNoteLength)})
+			return
+		}
+		note = &trimmed
+	}
+
+	application, err := h.ChefApplicationStore.ReviewChefApplication(applicationID, reviewerID, req.Status, note)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "application not found or already reviewed"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to review chef application: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to review application"})
+		return
+	}
+
+	message := "Your verified chef application was not approved"
+	if application.Status == store.ChefApplicationApproved {
+		message = "Your verified chef application was approved"
+	}
+	if note != nil {
+		message += ": " + *note
+	}
+
+	notification := &store.Notification{
+		UserID:  application.UserID,
+		Type:    store.NotificationTypeChefApplication,
+		Message: message,
+	}
+	if err := h.NotificationStore.CreateNotification(notification); err != nil {
+		log.Printf("Failed to notify chef applicant: %v", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "application reviewed",
+		"application": application,
+	})
+}
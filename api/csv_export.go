@@ -0,0 +1,174 @@
+package api
+
+import (
+	"encoding/csv"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// csvContentType is the media type of CSV exports
+	csvContentType = "text/csv"
+
+	// MaxRecipeExportRows caps the number of recipes in a single CSV export
+	MaxRecipeExportRows = 10000
+
+	// csvFlushEvery is how many rows are written between flushes to the client
+	csvFlushEvery = 100
+)
+
+// wantsCSV reports whether the client asked for CSV, with format=csv or an Accept header preferring text/csv
+// It writes a 400 response and returns false as its second value if format is neither json nor csv
+func wantsCSV(c *gin.Context) (bool, bool) {
+	switch strings.ToLower(c.Query("format")) {
+	case "csv":
+		return true, true
+	case "json":
+		return false, true
+	case "":
+		return c.NegotiateFormat(gin.MIMEJSON, csvContentType) == csvContentType, true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or csv"})
+		return false, false
+	}
+}
+
+// csvExport streams CSV rows to the client as a file download
+type csvExport struct {
+	c      *gin.Context
+	writer *csv.Writer
+	rows   int
+}
+
+// newCSVExport starts a CSV download named filename and writes its header row
+func newCSVExport(c *gin.Context, filename string, header []string) *csvExport {
+	c.Header("Content-Type", csvContentType+"; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	export := &csvExport{c: c, writer: csv.NewWriter(c.Writer)}
+	export.writer.Write(header)
+	return export
+}
+
+// Write adds a row, flushing to the client every csvFlushEvery rows
+func (e *csvExport) Write(record []string) error {
+	for i, value := range record {
+		record[i] = utils.CSVCell(value)
+	}
+	if err := e.writer.Write(record); err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%csvFlushEvery == 0 {
+		e.writer.Flush()
+		e.c.Writer.Flush()
+	}
+	return e.writer.Error()
+}
+
+// Close flushes the remaining rows, reporting err as a 500 if nothing has reached the client yet
+// Once rows have been sent the status is fixed, so a later failure can only be logged
+func (e *csvExport) Close(err error) {
+	if err != nil && !e.c.Writer.Written() {
+		log.Printf("Failed to write CSV export: %v", err)
+		e.c.Writer.Header().Del("Content-Disposition")
+		e.c.Writer.Header().Del("Content-Type")
+		e.c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	e.writer.Flush()
+	if err == nil {
+		err = e.writer.Error()
+	}
+	if err != nil {
+		log.Printf("Failed to write CSV export after %d rows: %v", e.rows, err)
+	}
+}
+
+func csvInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func csvFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+func csvTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
+
+// recipeCSVHeader lists the columns written by recipeCSVRecord
+var recipeCSVHeader = []string{
+	"id", "title", "description", "category", "status", "difficulty_level",
+	"serving_size", "prep_time", "cook_time", "total_time", "dietary_labels",
+	"estimated_cost_per_serving", "currency", "made_count", "published_at", "updated_at",
+}
+
+// recipeCSVRecord converts a recipe to a CSV row; dietary labels are separated by semicolons
+func recipeCSVRecord(recipe *store.Recipe, currency string) []string {
+	category := ""
+	if recipe.CategoryName != nil {
+		category = *recipe.CategoryName
+	}
+
+	return []string{
+		strconv.FormatInt(recipe.ID, 10),
+		recipe.Title,
+		recipe.Description,
+		category,
+		string(recipe.Status),
+		string(recipe.DifficultyLevel),
+		csvInt(recipe.ServingSize),
+		csvInt(recipe.PrepTime),
+		csvInt(recipe.CookTime),
+		csvInt(recipe.TotalTime),
+		strings.Join(recipe.DietaryLabels, ";"),
+		csvFloat(recipe.EstimatedCostPerServing),
+		currency,
+		csvInt(recipe.MadeCount),
+		csvTime(recipe.PublishedAt),
+		csvTime(&recipe.UpdatedAt),
+	}
+}
+
+// exportRecipesCSV streams every recipe matching opts, up to MaxRecipeExportRows, as a CSV download
+// Exports require sign-in, so every one counts towards the user's daily export quota
+func exportRecipesCSV(c *gin.Context, recipeStore store.RecipeStore, usage *services.UsageService, opts store.RecipeListOptions, filename string) {
+	if c.GetString("user_id") == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "sign in to export recipes as CSV"})
+		return
+	}
+	if !checkUsageQuota(c, usage, services.UsageExport) {
+		return
+	}
+
+	opts.Page = 1
+	opts.Limit = MaxRecipeExportRows
+
+	currency := priceCurrency()
+	export := newCSVExport(c, filename, recipeCSVHeader)
+	err := recipeStore.EachRecipe(c.Request.Context(), opts, func(recipe *store.Recipe) error {
+		return export.Write(recipeCSVRecord(recipe, currency))
+	})
+	export.Close(err)
+}
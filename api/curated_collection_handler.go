@@ -0,0 +1,389 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// MaxCollectionRecipes caps how many recipes a curated collection can hold
+const MaxCollectionRecipes = 200
+
+var collectionSlugRegex = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+type CuratedCollectionHandler struct {
+	CuratedCollectionStore store.CuratedCollectionStore
+}
+
+func NewCuratedCollectionHandler(curatedCollectionStore store.CuratedCollectionStore) *CuratedCollectionHandler {
+	return &CuratedCollectionHandler{
+		CuratedCollectionStore: curatedCollectionStore,
+	}
+}
+
+type curatedCollectionRequest struct {
+	Slug           string     `json:"slug"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	BannerImageURL string     `json:"banner_image_url"`
+	DisplayOrder   int        `json:"display_order"`
+	StartsAt       *time.Time `json:"starts_at,omitempty"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+}
+
+type setCollectionRecipesRequest struct {
+	RecipeIDs []int64 `json:"recipe_ids"`
+}
+
+// ListCollections godoc
+// @Summary List curated collections
+// @Description Returns the admin-curated collections currently inside their publish window, in display order
+// @Tags Collections
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Curated collections"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /collections [get]
+func (h *CuratedCollectionHandler) ListCollections(c *gin.Context) {
+	collections, err := h.CuratedCollectionStore.ListActiveCollections()
+	if err != nil {
+		log.Printf("Failed to list curated collections: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"collections": collections,
+	})
+}
+
+// GetCollection godoc
+// @Summary Get a curated collection
+// @Description Returns an active curated collection with its published recipes in order
+// @Tags Collections
+// @Produce json
+// @Param slug path string true "Collection slug"
+// @Success 200 {object} map[string]interface{} "Curated collection"
+// @Failure 404 {object} map[string]string "Collection not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /collections/{slug} [get]
+func (h *CuratedCollectionHandler) GetCollection(c *gin.Context) {
+	collection, err := h.CuratedCollectionStore.GetActiveCollectionBySlug(c.Param("slug"))
+	if err != nil {
+		log.Printf("Failed to get curated collection: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if collection == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"collection": collection,
+	})
+}
+
+// AdminListCollections godoc
+// @Summary List all curated collections
+// @Description Returns every curated collection, including scheduled and expired ones. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Curated collections"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/collections [get]
+func (h *CuratedCollectionHandler) AdminListCollections(c *gin.Context) {
+	collections, err := h.CuratedCollectionStore.ListCollections()
+	if err != nil {
+		log.Printf("Failed to list curated collections: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"collections": collections,
+	})
+}
+
+// AdminGetCollection godoc
+// @Summary Get any curated collection
+// @Description Returns a curated collection with all its recipes, whatever its publish window or recipe status. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Curated collection"
+// @Failure 400 {object} map[string]string "Invalid collection ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Collection not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/collections/{id} [get]
+func (h *CuratedCollectionHandler) AdminGetCollection(c *gin.Context) {
+	collectionID, ok := parseIDParam(c, "id", "collection ID")
+	if !ok {
+		return
+	}
+
+	collection, err := h.CuratedCollectionStore.GetCollection(collectionID)
+	if err != nil {
+		log.Printf("Failed to get curated collection: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if collection == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"collection": collection,
+	})
+}
+
+// CreateCollection godoc
+// @Summary Create a curated collection
+// @Description Creates a curated collection with an optional banner image and publish window. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body curatedCollectionRequest true "Collection details"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Collection created"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 409 {object} map[string]string "Slug already in use"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/collections [post]
+func (h *CuratedCollectionHandler) CreateCollection(c *gin.Context) {
+	collection, ok := bindCuratedCollection(c)
+	if !ok {
+		return
+	}
+
+	if err := h.CuratedCollectionStore.CreateCollection(collection); err != nil {
+		respondCollectionWriteError(c, err, "failed to create collection")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "collection created",
+		"collection": collection,
+	})
+}
+
+// UpdateCollection godoc
+// @Summary Update a curated collection
+// @Description Replaces a curated collection's details. Its recipes are managed separately. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param request body curatedCollectionRequest true "Collection details"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Collection updated"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Collection not found"
+// @Failure 409 {object} map[string]string "Slug already in use"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/collections/{id} [put]
+func (h *CuratedCollectionHandler) UpdateCollection(c *gin.Context) {
+	collectionID, ok := parseIDParam(c, "id", "collection ID")
+	if !ok {
+		return
+	}
+
+	collection, ok := bindCuratedCollection(c)
+	if !ok {
+		return
+	}
+	collection.ID = collectionID
+
+	err := h.CuratedCollectionStore.UpdateCollection(collection)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+		return
+	}
+	if err != nil {
+		respondCollectionWriteError(c, err, "failed to update collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "collection updated",
+		"collection": collection,
+	})
+}
+
+// DeleteCollection godoc
+// @Summary Delete a curated collection
+// @Description Deletes a curated collection. The recipes in it are unaffected. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Collection deleted"
+// @Failure 400 {object} map[string]string "Invalid collection ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Collection not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/collections/{id} [delete]
+func (h *CuratedCollectionHandler) DeleteCollection(c *gin.Context) {
+	collectionID, ok := parseIDParam(c, "id", "collection ID")
+	if !ok {
+		return
+	}
+
+	err := h.CuratedCollectionStore.DeleteCollection(collectionID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to delete curated collection: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete collection"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "collection deleted"})
+}
+
+// SetCollectionRecipes godoc
+// @Summary Set a curated collection's recipes
+// @Description Replaces the recipes in a curated collection with recipe_ids, in that order. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param request body setCollectionRecipesRequest true "Recipe IDs in display order"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Collection recipes updated"
+// @Failure 400 {object} map[string]string "Invalid request or unknown recipe"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Collection not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/collections/{id}/recipes [put]
+func (h *CuratedCollectionHandler) SetCollectionRecipes(c *gin.Context) {
+	collectionID, ok := parseIDParam(c, "id", "collection ID")
+	if !ok {
+		return
+	}
+
+	var req setCollectionRecipesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(req.RecipeIDs) > MaxCollectionRecipes {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a collection can have at most 200 recipes"})
+		return
+	}
+	seen := make(map[int64]bool, len(req.RecipeIDs))
+	for _, id := range req.RecipeIDs {
+		if seen[id] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "recipe_ids must not contain duplicates"})
+			return
+		}
+		seen[id] = true
+	}
+
+	err := h.CuratedCollectionStore.SetCollectionRecipes(collectionID, req.RecipeIDs)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to set collection recipes: %v", err)
+		if errors.Is(err, store.ErrForeignKey) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "recipe not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update collection recipes"})
+		return
+	}
+
+	collection, err := h.CuratedCollectionStore.GetCollection(collectionID)
+	if err != nil {
+		log.Printf("Failed to get curated collection: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "collection recipes updated",
+		"collection": collection,
+	})
+}
+
+// bindCuratedCollection parses and validates a collection request body
+// It writes a 400 response and returns false if the body is invalid
+func bindCuratedCollection(c *gin.Context) (*store.CuratedCollection, bool) {
+	var req curatedCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if len(slug) > 100 || !collectionSlugRegex.MatchString(slug) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "slug must be lowercase letters, digits, and hyphens (at most 100 characters)"})
+		return nil, false
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" || len(title) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "title must be between 1 and 255 characters"})
+		return nil, false
+	}
+
+	collection := &store.CuratedCollection{
+		Slug:         slug,
+		Title:        title,
+		Description:  strings.TrimSpace(req.Description),
+		DisplayOrder: req.DisplayOrder,
+		StartsAt:     req.StartsAt,
+		EndsAt:       req.EndsAt,
+	}
+
+	if banner := strings.TrimSpace(req.BannerImageURL); banner != "" {
+		if len(banner) > 255 || !utils.IsValidURL(banner) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid banner image URL"})
+			return nil, false
+		}
+		collection.BannerImageURL = &banner
+	}
+
+	if req.StartsAt != nil && req.EndsAt != nil && !req.StartsAt.Before(*req.EndsAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "starts_at must be before ends_at"})
+		return nil, false
+	}
+
+	return collection, true
+}
+
+// respondCollectionWriteError maps collection constraint violations to client errors
+func respondCollectionWriteError(c *gin.Context, err error, message string) {
+	log.Printf("Failed to save curated collection: %v", err)
+	if errors.Is(err, store.ErrConflict) {
+		c.JSON(http.StatusConflict, gin.H{"error": "a collection with this slug already exists"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/gin-gonic/gin"
+)
+
+type DevEmailHandler struct {
+	EmailService *services.EmailService
+}
+
+func NewDevEmailHandler(emailService *services.EmailService) *DevEmailHandler {
+	return &DevEmailHandler{
+		EmailService: emailService,
+	}
+}
+
+// ListDevEmails godoc
+// @Summary List dry-run emails
+// @Description Returns emails captured in dry-run mode (EMAIL_MODE=dry-run), newest first, optionally only those sent to one address. Only available in dry-run mode. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param to query string false "Only emails addressed to this recipient"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Captured emails"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Router /dev/emails [get]
+func (h *DevEmailHandler) ListDevEmails(c *gin.Context) {
+	emails := h.EmailService.Sandbox().List(strings.TrimSpace(c.Query("to")))
+
+	c.JSON(http.StatusOK, gin.H{
+		"emails": emails,
+		"count":  len(emails),
+	})
+}
+
+// GetDevEmail godoc
+// @Summary Get a dry-run email
+// @Description Returns one email captured in dry-run mode, including its rendered HTML. Only available in dry-run mode. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Email ID"
+// @Security BearerAuth
+// @Success 200 {object} services.SandboxEmail "Captured email"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Email not found"
+// @Router /dev/emails/{id} [get]
+func (h *DevEmailHandler) GetDevEmail(c *gin.Context) {
+	email := h.EmailService.Sandbox().Get(c.Param("id"))
+	if email == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "email not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, email)
+}
+
+// ClearDevEmails godoc
+// @Summary Clear dry-run emails
+// @Description Removes every email captured in dry-run mode. Only available in dry-run mode. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Emails cleared"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Router /dev/emails [delete]
+func (h *DevEmailHandler) ClearDevEmails(c *gin.Context) {
+	h.EmailService.Sandbox().Clear()
+	c.JSON(http.StatusOK, gin.H{"message": "emails cleared"})
+}
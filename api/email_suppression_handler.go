@@ -0,0 +1,249 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultEmailSuppressionsPageSize is the number of suppressions returned per page when no limit is given
+	DefaultEmailSuppressionsPageSize = 50
+
+	// MaxEmailSuppressionsPageSize caps how many suppressions can be requested per page
+	MaxEmailSuppressionsPageSize = 200
+
+	// MaxEmailSuppressionNoteLength caps the note an admin leaves on a manual suppression
+	MaxEmailSuppressionNoteLength = 500
+
+	// maxEmailEventBytes caps the size of a webhook event from the email provider
+	maxEmailEventBytes = 1 << 20
+)
+
+type EmailSuppressionHandler struct {
+	EmailSuppressionStore store.EmailSuppressionStore
+	UserStore             store.UserStore
+
+	// ResendWebhookVerifier is nil when RESEND_WEBHOOK_SECRET is not set
+	ResendWebhookVerifier *services.ResendWebhookVerifier
+}
+
+func NewEmailSuppressionHandler(emailSuppressionStore store.EmailSuppressionStore, userStore store.UserStore, resendWebhookVerifier *services.ResendWebhookVerifier) *EmailSuppressionHandler {
+	return &EmailSuppressionHandler{
+		EmailSuppressionStore: emailSuppressionStore,
+		UserStore:             userStore,
+		ResendWebhookVerifier: resendWebhookVerifier,
+	}
+}
+
+type addEmailSuppressionRequest struct {
+	Email string `json:"email"`
+	Note  string `json:"note"`
+}
+
+// ListEmailSuppressions godoc
+// @Summary List suppressed email addresses
+// @Description Returns the addresses no email is sent to, newest first: hard bounces and spam complaints reported by the email provider, and manual opt-outs. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param q query string false "Only addresses containing this text"
+// @Param reason query string false "Only suppressions for this reason: bounce, complaint, or manual"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Suppressions per page (default 50, max 200)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Suppressions with pagination"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/email-suppressions [get]
+func (h *EmailSuppressionHandler) ListEmailSuppressions(c *gin.Context) {
+	reason := c.Query("reason")
+	if reason != "" && !store.IsValidEmailSuppressionReason(reason) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason must be bounce, complaint, or manual"})
+		return
+	}
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultEmailSuppressionsPageSize, MaxEmailSuppressionsPageSize)
+	if !ok {
+		return
+	}
+
+	suppressions, total, err := h.EmailSuppressionStore.GetEmailSuppressions(store.EmailSuppressionListOptions{
+		Query:  strings.TrimSpace(c.Query("q")),
+		Reason: reason,
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		log.Printf("Failed to get email suppressions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"suppressions": suppressions,
+		"pagination":   newPagination(page, limit, total),
+	})
+}
+
+// AddEmailSuppression godoc
+// @Summary Suppress an email address
+// @Description Opts an address out of every email, with an optional note. An address that is already suppressed keeps its existing entry, which is returned with 200. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body addEmailSuppressionRequest true "Address and optional note"
+// @Security BearerAuth
+// @Success 201 {object} store.EmailSuppression "Address suppressed"
+// @Success 200 {object} store.EmailSuppression "Address was already suppressed"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/email-suppressions [post]
+func (h *EmailSuppressionHandler) AddEmailSuppression(c *gin.Context) {
+	adminID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req addEmailSuppressionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if !utils.IsValidEmail(email) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid email"})
+		return
+	}
+
+	suppression := &store.EmailSuppression{
+		Email:     email,
+		Reason:    store.EmailSuppressionManual,
+		CreatedBy: &adminID,
+	}
+	if note := strings.TrimSpace(req.Note); note != "" {
+		if len(note) > MaxEmailSuppressionNoteLength {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("note must be at most %d characters", MaxEmailSuppressionNoteLength)})
+			return
+		}
+		suppression.Note = &note
+	}
+
+	added, err := h.EmailSuppressionStore.AddEmailSuppression(suppression)
+	if err != nil {
+		log.Printf("Failed to add email suppression: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to suppress email"})
+		return
+	}
+
+	status := http.StatusOK
+	if added {
+		status = http.StatusCreated
+	}
+	c.JSON(status, suppression)
+}
+
+// DeleteEmailSuppression godoc
+// @Summary Remove an email suppression
+// @Description Takes an address off the suppression list so emails are sent to it again, for example once a bounced mailbox has been fixed. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Suppression ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Suppression removed"
+// @Failure 400 {object} map[string]string "Invalid suppression ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Suppression not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/email-suppressions/{id} [delete]
+func (h *EmailSuppressionHandler) DeleteEmailSuppression(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "suppression ID")
+	if !ok {
+		return
+	}
+
+	err := h.EmailSuppressionStore.DeleteEmailSuppression(id)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "suppression not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to delete email suppression: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove suppression"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Suppression removed"})
+}
+
+// HandleResendWebhook godoc
+// @Summary Receive email events from Resend
+// @Description Webhook for Resend delivery events, signed with RESEND_WEBHOOK_SECRET. Recipients of bounced emails and of emails marked as spam are added to the suppression list; other events are acknowledged and ignored. Only registered when RESEND_WEBHOOK_SECRET is set.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]string "Event received"
+// @Failure 400 {object} map[string]string "Invalid event"
+// @Failure 401 {object} map[string]string "Invalid signature"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /email-events/resend [post]
+func (h *EmailSuppressionHandler) HandleResendWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxEmailEventBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "could not read event"})
+		return
+	}
+
+	event, err := h.ResendWebhookVerifier.Verify(c.Request.Header, body, time.Now())
+	if errors.Is(err, services.ErrResendWebhookSignature) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid signature"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event"})
+		return
+	}
+
+	reason := event.SuppressionReason()
+	if reason == "" {
+		c.JSON(http.StatusOK, gin.H{"message": "event ignored"})
+		return
+	}
+
+	note := fmt.Sprintf("%s for email %s", event.Type, event.Data.EmailID)
+	for _, recipient := range event.Data.To {
+		email := strings.ToLower(strings.TrimSpace(recipient))
+		if !utils.IsValidEmail(email) {
+			continue
+		}
+
+		// A failure is answered with 500 so Resend delivers the event again
+		suppression := &store.EmailSuppression{Email: email, Reason: reason, Note: &note}
+		if _, err := h.EmailSuppressionStore.AddEmailSuppression(suppression); err != nil {
+			log.Printf("Failed to suppress %s after %s: %v", email, event.Type, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "event received"})
+}
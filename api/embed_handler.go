@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// EmbedCacheTTL is how long browsers and CDNs may cache a recipe embed
+	EmbedCacheTTL = 5 * time.Minute
+
+	// EmbedPhotoURLTTL is how long the photo link in an embed stays valid; it outlives the cached embed
+	EmbedPhotoURLTTL = time.Hour
+)
+
+//go:embed templates/recipe_embed.html
+var embedTemplateFS embed.FS
+
+var recipeEmbedTemplate = template.Must(template.New("recipe_embed.html").Funcs(template.FuncMap{
+	"minutes": func(m *int) string { return formatDuration(*m * 60) },
+	"rating":  func(r *float64) string { return strconv.FormatFloat(*r, 'f', 1, 64) },
+}).ParseFS(embedTemplateFS, "templates/recipe_embed.html"))
+
+// recipeEmbed is the compact view of a recipe shown on partner sites
+type recipeEmbed struct {
+	ID            int64    `json:"id"`
+	Title         string   `json:"title"`
+	Author        string   `json:"author"`
+	PhotoURL      *string  `json:"photo_url"`
+	TotalTime     *int     `json:"total_time"`
+	AverageRating *float64 `json:"average_rating"`
+	ReviewCount   int      `json:"review_count"`
+	URL           string   `json:"url"`
+}
+
+type EmbedHandler struct {
+	RecipeStore      store.RecipeStore
+	RecipeMediaStore store.RecipeMediaStore
+	ReviewStore      store.ReviewStore
+	Storage          services.Storage
+}
+
+func NewEmbedHandler(recipeStore store.RecipeStore, recipeMediaStore store.RecipeMediaStore, reviewStore store.ReviewStore, storage services.Storage) *EmbedHandler {
+	return &EmbedHandler{
+		RecipeStore:      recipeStore,
+		RecipeMediaStore: recipeMediaStore,
+		ReviewStore:      reviewStore,
+		Storage:          storage,
+	}
+}
+
+// GetRecipeEmbed godoc
+// @Summary Recipe embed widget
+// @Description Returns a compact card for a published recipe, with its title, author, photo, total time, rating and a link back, for partner sites to show in an iframe (HTML, the default) or to render themselves (format=json). Embeds are anonymous and cached for 5 minutes. When EMBED_ALLOWED_ORIGINS is set, only those sites may frame the HTML or fetch the JSON; other origins get 403.
+// @Tags Recipes
+// @Produce html,json
+// @Param id path int true "Recipe ID"
+// @Param format query string false "html (default) or json"
+// @Success 200 {object} map[string]interface{} "Embed card"
+// @Failure 400 {object} map[string]string "Invalid recipe ID or format"
+// @Failure 403 {object} map[string]string "Origin not allowed"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /embed/recipes/{id} [get]
+func (h *EmbedHandler) GetRecipeEmbed(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", "html")
+	if format != "html" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be html or json"})
+		return
+	}
+
+	recipe, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	// Embeds are shown to anyone visiting a partner site, so drafts are never embedded
+	if recipe == nil || !canViewRecipe(recipe, 0) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	summary, err := h.ReviewStore.GetRecipeRatingSummary(recipeID, ratingSummaryOptions())
+	if err != nil {
+		log.Printf("Failed to fetch recipe rating summary: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	photos, err := h.RecipeMediaStore.GetRecipePhotos(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe photos: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	view := recipeEmbed{
+		ID:            recipe.ID,
+		Title:         recipe.Title,
+		Author:        recipe.AuthorUsername,
+		PhotoURL:      h.embedPhotoURL(photos),
+		TotalTime:     recipe.TotalTime,
+		AverageRating: summary.AverageRating,
+		ReviewCount:   summary.ReviewCount,
+		URL:           recipePageURL(recipeID),
+	}
+	if summary.WeightedAverageRating != nil {
+		view.AverageRating = summary.WeightedAverageRating
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(EmbedCacheTTL.Seconds())))
+
+	if format == "json" {
+		c.JSON(http.StatusOK, view)
+		return
+	}
+
+	var page bytes.Buffer
+	if err := recipeEmbedTemplate.Execute(&page, view); err != nil {
+		log.Printf("Failed to render recipe embed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// embedPhotoURL returns a link to the photo shown in an embed: the primary photo, otherwise the first one
+// Uploaded photos are served from the CDN or signed for EmbedPhotoURLTTL; if signing fails the embed is shown without a photo
+func (h *EmbedHandler) embedPhotoURL(photos []*store.RecipePhoto) *string {
+	var chosen *store.RecipePhoto
+	for _, photo := range photos {
+		if photo.IsPrimary {
+			chosen = photo
+			break
+		}
+		if chosen == nil {
+			chosen = photo
+		}
+	}
+	if chosen == nil {
+		return nil
+	}
+
+	if chosen.StorageKey == nil {
+		return &chosen.PhotoURL
+	}
+
+	signedURL, err := photoURL(h.Storage, *chosen.StorageKey, EmbedPhotoURLTTL)
+	if err != nil {
+		log.Printf("Failed to sign photo URL for %s: %v", *chosen.StorageKey, err)
+		return nil
+	}
+	return &signedURL
+}
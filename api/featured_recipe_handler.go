@@ -0,0 +1,175 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+type FeaturedRecipeHandler struct {
+	FeaturedRecipeStore store.FeaturedRecipeStore
+	RecipeStore         store.RecipeStore
+	UserStore           store.UserStore
+}
+
+func NewFeaturedRecipeHandler(featuredRecipeStore store.FeaturedRecipeStore, recipeStore store.RecipeStore, userStore store.UserStore) *FeaturedRecipeHandler {
+	return &FeaturedRecipeHandler{
+		FeaturedRecipeStore: featuredRecipeStore,
+		RecipeStore:         recipeStore,
+		UserStore:           userStore,
+	}
+}
+
+type featureRecipeRequest struct {
+	DisplayOrder int        `json:"display_order"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+}
+
+// GetFeaturedRecipes godoc
+// @Summary Featured recipes
+// @Description Returns the recipes currently featured on the homepage, in display order
+// @Tags Recipes
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Featured recipes"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/featured [get]
+func (h *FeaturedRecipeHandler) GetFeaturedRecipes(c *gin.Context) {
+	featured, err := h.FeaturedRecipeStore.GetFeaturedRecipes(false)
+	if err != nil {
+		log.Printf("Failed to get featured recipes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"featured": featured,
+	})
+}
+
+// AdminGetFeaturedRecipes godoc
+// @Summary List all featured recipes
+// @Description Returns every featured recipe, including expired entries and recipes no longer published. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Featured recipes"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/featured-recipes [get]
+func (h *FeaturedRecipeHandler) AdminGetFeaturedRecipes(c *gin.Context) {
+	featured, err := h.FeaturedRecipeStore.GetFeaturedRecipes(true)
+	if err != nil {
+		log.Printf("Failed to get featured recipes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"featured": featured,
+	})
+}
+
+// FeatureRecipe godoc
+// @Summary Feature a recipe
+// @Description Features a published recipe on the homepage, or updates its display order and expiry if already featured. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param recipe_id path int true "Recipe ID"
+// @Param request body featureRecipeRequest false "Display order and optional expiry"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Recipe featured"
+// @Failure 400 {object} map[string]string "Invalid request or recipe not published"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/featured-recipes/{recipe_id} [put]
+func (h *FeaturedRecipeHandler) FeatureRecipe(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "recipe_id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	adminID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req featureRecipeRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_at must be in the future"})
+		return
+	}
+
+	recipe, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if recipe == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	if recipe.Status != store.StatusPublished {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "only published recipes can be featured"})
+		return
+	}
+
+	if err := h.FeaturedRecipeStore.FeatureRecipe(recipeID, req.DisplayOrder, req.ExpiresAt, adminID); err != nil {
+		log.Printf("Failed to feature recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to feature recipe"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "recipe featured"})
+}
+
+// UnfeatureRecipe godoc
+// @Summary Unfeature a recipe
+// @Description Removes a recipe from the homepage featured list. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param recipe_id path int true "Recipe ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Recipe unfeatured"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Recipe is not featured"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/featured-recipes/{recipe_id} [delete]
+func (h *FeaturedRecipeHandler) UnfeatureRecipe(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "recipe_id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	err := h.FeaturedRecipeStore.UnfeatureRecipe(recipeID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe is not featured"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to unfeature recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to unfeature recipe"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "recipe unfeatured"})
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+// getAuthenticatedUserID returns the user ID set by JWTAuthMiddleware
+// It writes an error response and returns false if the ID is missing
+func getAuthenticatedUserID(c *gin.Context) (string, bool) {
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return "", false
+	}
+
+	userID, ok := userIDValue.(string)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user ID"})
+		return "", false
+	}
+
+	return userID, true
+}
+
+// getAuthenticatedInternalUserID resolves the numeric users.id for the authenticated user
+// Recipe-related tables reference users by this ID rather than the public user_id
+func getAuthenticatedInternalUserID(c *gin.Context, userStore store.UserStore) (int64, bool) {
+	userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return 0, false
+	}
+
+	internalID, err := userStore.GetUserInternalID(userID)
+	if err != nil {
+		log.Printf("Failed to resolve user ID %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return 0, false
+	}
+
+	if internalID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+		return 0, false
+	}
+
+	return internalID, true
+}
+
+// getOptionalInternalUserID resolves the numeric users.id for a request that may be anonymous
+// It returns 0 when no user was set by OptionalJWTAuthMiddleware
+func getOptionalInternalUserID(c *gin.Context, userStore store.UserStore) (int64, error) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		return 0, nil
+	}
+
+	return userStore.GetUserInternalID(userID)
+}
+
+// canViewRecipe reports whether a viewer may see a recipe
+// Published recipes are public; anything else is visible only to its author
+func canViewRecipe(recipe *store.Recipe, viewerID int64) bool {
+	if recipe == nil {
+		return false
+	}
+
+	return recipe.Status == store.StatusPublished || (viewerID != 0 && recipe.UserID == viewerID)
+}
+
+// parseIDParam parses a positive integer path parameter
+// It writes a 400 response naming the label and returns false if the parameter is invalid
+func parseIDParam(c *gin.Context, param string, label string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(param), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + label})
+		return 0, false
+	}
+
+	return id, true
+}
+
+// parseLimitQuery parses the optional limit query parameter
+// It writes a 400 response and returns false if the value is not a positive integer
+func parseLimitQuery(c *gin.Context, defaultLimit, maxLimit int) (int, bool) {
+	limitParam := c.Query("limit")
+	if limitParam == "" {
+		return defaultLimit, true
+	}
+
+	limit, err := strconv.Atoi(limitParam)
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+		return 0, false
+	}
+
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	return limit, true
+}
+
+// parsePageQuery parses the optional page query parameter, defaulting to the first page
+// It writes a 400 response and returns false if the value is not a positive integer
+func parsePageQuery(c *gin.Context) (int, bool) {
+	pageParam := c.Query("page")
+	if pageParam == "" {
+		return 1, true
+	}
+
+	page, err := strconv.Atoi(pageParam)
+	if err != nil || page <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "page must be a positive integer"})
+		return 0, false
+	}
+
+	return page, true
+}
+
+// sessionTokens returns the tokens object of a sign-in response
+// In cookie mode the tokens are set as HttpOnly cookies instead, and only the CSRF token is returned
+func sessionTokens(c *gin.Context, jwtService *services.JWTService, accessToken string, refreshToken string) gin.H {
+	if jwtService.CookieMode() {
+		return gin.H{"csrf_token": jwtService.SetSessionCookies(c.Writer, accessToken, refreshToken)}
+	}
+	return gin.H{
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// icalContentType is the media type of iCalendar feeds
+	icalContentType = "text/calendar"
+
+	// icalLineOctets is the longest a content line may be before it must be folded (RFC 5545 section 3.1)
+	icalLineOctets = 75
+)
+
+// icalEscaper escapes TEXT property values (RFC 5545 section 3.3.11)
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// icalWriter builds an iCalendar document line by line
+type icalWriter struct {
+	buf bytes.Buffer
+}
+
+// Line writes a property whose value is already in iCalendar form, folding it if it is too long
+func (w *icalWriter) Line(name, value string) {
+	line := name + ":" + value
+
+	// Fold on character boundaries so multi-byte characters are never split across lines
+	// Continuation lines start with a space, which counts towards their length
+	limit := icalLineOctets
+	for len(line) > limit {
+		cut := limit
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.buf.WriteString(line[:cut])
+		w.buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icalLineOctets - 1
+	}
+	w.buf.WriteString(line)
+	w.buf.WriteString("\r\n")
+}
+
+// Text writes a property with a TEXT value, escaping it
+func (w *icalWriter) Text(name, value string) {
+	w.Line(name, icalEscaper.Replace(value))
+}
+
+// UTC writes a property with a DATE-TIME value in UTC
+func (w *icalWriter) UTC(name string, t time.Time) {
+	w.Line(name, t.UTC().Format("20060102T150405Z"))
+}
+
+// Bytes returns the document written so far
+func (w *icalWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
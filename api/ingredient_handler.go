@@ -0,0 +1,321 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultSuggestionLimit is the number of suggestions returned when no limit is given
+	DefaultSuggestionLimit = 10
+
+	// MaxSuggestionLimit caps how many suggestions a client can request
+	MaxSuggestionLimit = 25
+)
+
+type IngredientHandler struct {
+	IngredientStore store.IngredientStore
+}
+
+func NewIngredientHandler(ingredientStore store.IngredientStore) *IngredientHandler {
+	return &IngredientHandler{
+		IngredientStore: ingredientStore,
+	}
+}
+
+// SuggestIngredients godoc
+// @Summary Suggest ingredients
+// @Description Returns canonical ingredients matching the query, ranked by how many recipes use them
+// @Tags Ingredients
+// @Produce json
+// @Param q query string true "Partial ingredient name"
+// @Param limit query int false "Maximum number of suggestions (default 10, max 25)"
+// @Success 200 {object} map[string]interface{} "Matching ingredients"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /ingredients/suggest [get]
+func (h *IngredientHandler) SuggestIngredients(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter q is required"})
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultSuggestionLimit, MaxSuggestionLimit)
+	if !ok {
+		return
+	}
+
+	ingredients, err := h.IngredientStore.SuggestIngredients(query, limit)
+	if err != nil {
+		log.Printf("Failed to suggest ingredients: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ingredients": ingredients,
+	})
+}
+
+type setIngredientPriceRequest struct {
+	PricePerUnit *float64 `json:"price_per_unit"`
+	PriceUnit    *string  `json:"price_unit"`
+	Source       string   `json:"source"`
+}
+
+// SetIngredientPrice godoc
+// @Summary Set an ingredient price
+// @Description Sets the price of a catalog ingredient per unit, used to estimate recipe costs. Send a null price_per_unit to clear it. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Ingredient ID"
+// @Param request body setIngredientPriceRequest true "Price information"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Ingredient price updated"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Ingredient not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/ingredients/{id}/price [put]
+func (h *IngredientHandler) SetIngredientPrice(c *gin.Context) {
+	ingredientID, ok := parseIDParam(c, "id", "ingredient ID")
+	if !ok {
+		return
+	}
+
+	var req setIngredientPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	source := strings.TrimSpace(req.Source)
+	if source == "" {
+		source = store.PriceSourceAdmin
+	}
+	if source != store.PriceSourceAdmin && source != store.PriceSourceAPI {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "source must be admin or api"})
+		return
+	}
+
+	if req.PricePerUnit == nil {
+		// Clearing a price also clears its unit
+		req.PriceUnit = nil
+	} else {
+		if *req.PricePerUnit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "price_per_unit cannot be negative"})
+			return
+		}
+		if req.PriceUnit == nil || strings.TrimSpace(*req.PriceUnit) == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "price_unit is required when setting a price"})
+			return
+		}
+		if len(strings.TrimSpace(*req.PriceUnit)) > 50 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "price_unit must be at most 50 characters"})
+			return
+		}
+	}
+
+	price, err := h.IngredientStore.SetIngredientPrice(ingredientID, req.PricePerUnit, req.PriceUnit, source)
+	if err != nil {
+		log.Printf("Failed to set ingredient price: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to set ingredient price"})
+		return
+	}
+
+	if price == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "ingredient not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ingredient price updated",
+		"price":   price,
+	})
+}
+
+// ConvertUnits godoc
+// @Summary Convert a quantity between units
+// @Description Converts a quantity between cooking units of volume (ml, l, tsp, tbsp, fl oz, cup, pint, quart, gallon) and weight (mg, g, kg, oz, lb), e.g. 1 cup of flour to grams. Converting between volume and weight uses the ingredient's density: an admin override if set, else the catalog density, else the density of water, reported as the fallback.
+// @Tags Ingredients
+// @Produce json
+// @Param quantity query number true "Quantity to convert"
+// @Param from query string true "Unit of the quantity"
+// @Param to query string true "Unit to convert to"
+// @Param ingredient query string false "Catalog ingredient name, used to convert between volume and weight"
+// @Success 200 {object} map[string]interface{} "Converted quantity"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /units/convert [get]
+func (h *IngredientHandler) ConvertUnits(c *gin.Context) {
+	quantity, err := strconv.ParseFloat(c.Query("quantity"), 64)
+	if err != nil || quantity <= 0 || math.IsInf(quantity, 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "quantity must be a positive number"})
+		return
+	}
+
+	from, ok := utils.ParseUnit(c.Query("from"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be one of: " + strings.Join(utils.UnitNames(), ", ")})
+		return
+	}
+	to, ok := utils.ParseUnit(c.Query("to"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be one of: " + strings.Join(utils.UnitNames(), ", ")})
+		return
+	}
+
+	response := gin.H{
+		"quantity": quantity,
+		"from":     from.Name,
+		"to":       to.Name,
+	}
+
+	density := 0.0
+	if from.Kind != to.Kind {
+		ingredient := strings.TrimSpace(c.Query("ingredient"))
+		var known *store.IngredientDensity
+		if ingredient != "" {
+			known, err = h.IngredientStore.GetIngredientDensity(ingredient)
+			if err != nil {
+				log.Printf("Failed to get ingredient density: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+		}
+
+		var source string
+		density, source = known.Effective()
+		if known != nil {
+			ingredient = known.Name
+		}
+		response["ingredient"] = ingredient
+		response["density_g_per_ml"] = density
+		response["density_source"] = source
+	}
+
+	// Four significant digits is more precision than any kitchen scale or measuring cup offers
+	result, _ := strconv.ParseFloat(strconv.FormatFloat(utils.ConvertQuantity(quantity, from, to, density), 'g', 4, 64), 64)
+	response["result"] = result
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetDensityOverrides godoc
+// @Summary List ingredient density overrides
+// @Description Returns every ingredient whose density has been overridden by an admin, with its catalog density for comparison. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Density overrides"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/ingredients/densities [get]
+func (h *IngredientHandler) GetDensityOverrides(c *gin.Context) {
+	densities, err := h.IngredientStore.GetDensityOverrides()
+	if err != nil {
+		log.Printf("Failed to get density overrides: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"densities": densities,
+	})
+}
+
+type setDensityOverrideRequest struct {
+	DensityGPerML float64 `json:"density_g_per_ml" binding:"required,gt=0,lte=10"`
+}
+
+// SetDensityOverride godoc
+// @Summary Override an ingredient density
+// @Description Sets the density, in grams per millilitre, used to convert an ingredient between volume and weight in place of its catalog density. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Ingredient ID"
+// @Param request body setDensityOverrideRequest true "Density"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Density override set"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Ingredient not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/ingredients/{id}/density [put]
+func (h *IngredientHandler) SetDensityOverride(c *gin.Context) {
+	ingredientID, ok := parseIDParam(c, "id", "ingredient ID")
+	if !ok {
+		return
+	}
+
+	var req setDensityOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "density_g_per_ml must be a number greater than 0 and at most 10"})
+		return
+	}
+
+	density, err := h.IngredientStore.SetDensityOverride(ingredientID, req.DensityGPerML)
+	if err != nil {
+		log.Printf("Failed to set density override: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to set density override"})
+		return
+	}
+
+	if density == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "ingredient not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "density override set",
+		"density": density,
+	})
+}
+
+// DeleteDensityOverride godoc
+// @Summary Remove an ingredient density override
+// @Description Removes an ingredient's density override, so conversions use its catalog density again. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Ingredient ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Density override removed"
+// @Failure 400 {object} map[string]string "Invalid ingredient ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Ingredient has no density override"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/ingredients/{id}/density [delete]
+func (h *IngredientHandler) DeleteDensityOverride(c *gin.Context) {
+	ingredientID, ok := parseIDParam(c, "id", "ingredient ID")
+	if !ok {
+		return
+	}
+
+	err := h.IngredientStore.DeleteDensityOverride(ingredientID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "ingredient has no density override"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to delete density override: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove density override"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "density override removed"})
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// InvitationExpiry is how long an invitation's signup link stays valid (14 days)
+	InvitationExpiry = 14 * 24 * time.Hour
+
+	// MaxInvitationMessageLength caps the personal message included in an invitation email
+	MaxInvitationMessageLength = 500
+)
+
+type InvitationHandler struct {
+	InvitationStore store.InvitationStore
+	UserStore       store.UserStore
+	EmailService    *services.EmailService
+	QuotaService    *services.QuotaService
+}
+
+func NewInvitationHandler(invitationStore store.InvitationStore, userStore store.UserStore, emailService *services.EmailService, quotaService *services.QuotaService) *InvitationHandler {
+	return &InvitationHandler{
+		InvitationStore: invitationStore,
+		UserStore:       userStore,
+		EmailService:    emailService,
+		QuotaService:    quotaService,
+	}
+}
+
+type createInvitationRequest struct {
+	Email   string `json:"email"`
+	Message string `json:"message"`
+}
+
+// CreateInvitation godoc
+// @Summary Invite a friend
+// @Description Emails an invitation to join ChefShare with a signup link valid for 14 days. Accounts created through the link are attributed to the inviter. Limited to a configurable number of invitations per day.
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param request body createInvitationRequest true "Invitee email and optional personal message"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Invitation sent"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Email already registered"
+// @Failure 429 {object} map[string]interface{} "Daily invitation limit reached"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 503 {object} map[string]string "Email service unavailable"
+// @Router /invitations [post]
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req createInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if !utils.IsValidEmail(email) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid email"})
+		return
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if len(message) > MaxInvitationMessageLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "message must be at most 500 characters"})
+		return
+	}
+
+	if h.EmailService == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "email service unavailable"})
+		return
+	}
+
+	inviter, err := h.UserStore.GetUserByID(userID)
+	if err != nil {
+		log.Printf("Failed to get inviter: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if inviter == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+		return
+	}
+
+	existing, err := h.UserStore.GetUserByEmail(email)
+	if err != nil {
+		log.Printf("Failed to look up invitee: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if existing != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "a user with this email already exists"})
+		return
+	}
+
+	inviterID, err := h.UserStore.GetUserInternalID(userID)
+	if err != nil {
+		log.Printf("Failed to resolve user ID %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if !checkQuota(c, h.QuotaService.CheckInvitationCreation(inviterID)) {
+		return
+	}
+
+	invitation, err := h.InvitationStore.CreateInvitation(inviterID, email, InvitationExpiry)
+	if err != nil {
+		log.Printf("Failed to create invitation: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create invitation"})
+		return
+	}
+
+	inviterName := strings.TrimSpace(inviter.FirstName + " " + inviter.LastName)
+	if inviterName == "" {
+		inviterName = inviter.Username
+	}
+
+	emailID, err := h.EmailService.SendInvitationEmail(email, inviterName, message, invitation.Token)
+	if err != nil {
+		// Don't leave an invitation marked as sent, or count it against the quota
+		if err := h.InvitationStore.DeleteInvitation(invitation.ID); err != nil {
+			log.Printf("Failed to delete unsent invitation: %v", err)
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send invitation email"})
+		return
+	}
+	log.Printf("Invitation email sent to %s with ID: %s", email, emailID)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "invitation sent",
+		"invitation": invitation,
+	})
+}
+
+// GetInvitations godoc
+// @Summary List my invitations
+// @Description Returns the invitations the authenticated user has sent with their status (sent or accepted), newest first
+// @Tags Invitations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Invitations"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /invitations [get]
+func (h *InvitationHandler) GetInvitations(c *gin.Context) {
+	inviterID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.InvitationStore.GetInvitationsByInviter(inviterID)
+	if err != nil {
+		log.Printf("Failed to get invitations: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invitations": invitations,
+	})
+}
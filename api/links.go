@@ -0,0 +1,23 @@
+package api
+
+import (
+	"github.com/dapoadedire/chefshare_be/links"
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// addRecipeLinks fills in the _links section of each recipe
+func addRecipeLinks(recipes ...*store.Recipe) {
+	builder := links.NewBuilderFromEnv()
+	for _, recipe := range recipes {
+		username := recipe.AuthorUsername
+		if username == "" && recipe.Author != nil {
+			username = recipe.Author.Username
+		}
+		recipe.Links = builder.Recipe(recipe.ID, username)
+	}
+}
+
+// currentUserLinks returns the _links section of the signed-in user's own account
+func currentUserLinks(username string) links.Links {
+	return links.NewBuilderFromEnv().CurrentUser(username)
+}
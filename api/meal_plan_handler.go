@@ -0,0 +1,619 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/links"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// mealPlanFeedHistoryDays is how far back a calendar feed includes planned meals
+	mealPlanFeedHistoryDays = 90
+
+	// mealPlanDefaultReminder is how long before a meal the reminder fires when its recipe has no prep or cook time
+	mealPlanDefaultReminder = 30 * time.Minute
+
+	// mealPlanEventDuration is how long a planned meal's calendar event lasts
+	mealPlanEventDuration = time.Hour
+)
+
+// mealTimes is when each meal starts on the planned day, in the calendar's own time zone
+var mealTimes = map[string]time.Duration{
+	store.MealBreakfast: 8 * time.Hour,
+	store.MealLunch:     12*time.Hour + 30*time.Minute,
+	store.MealSnack:     15*time.Hour + 30*time.Minute,
+	store.MealDinner:    19 * time.Hour,
+}
+
+type MealPlanHandler struct {
+	MealPlanStore store.MealPlanStore
+	RecipeStore   store.RecipeStore
+	UserStore     store.UserStore
+}
+
+func NewMealPlanHandler(mealPlanStore store.MealPlanStore, recipeStore store.RecipeStore, userStore store.UserStore) *MealPlanHandler {
+	return &MealPlanHandler{
+		MealPlanStore: mealPlanStore,
+		RecipeStore:   recipeStore,
+		UserStore:     userStore,
+	}
+}
+
+type createMealPlanRequest struct {
+	Name string `json:"name"`
+}
+
+type addMealPlanEntryRequest struct {
+	RecipeID   int64   `json:"recipe_id"`
+	PlannedFor string  `json:"planned_for" example:"2026-10-15"`
+	Meal       string  `json:"meal" example:"dinner"`
+	Servings   *int    `json:"servings,omitempty"`
+	Note       *string `json:"note,omitempty"`
+}
+
+// loadMealPlan fetches a meal plan owned by the user, writing a 404 if it is missing or belongs to someone else
+func (h *MealPlanHandler) loadMealPlan(c *gin.Context, userID int64) (*store.MealPlan, bool) {
+	planID, ok := parseIDParam(c, "id", "meal plan ID")
+	if !ok {
+		return nil, false
+	}
+
+	plan, err := h.MealPlanStore.GetMealPlan(planID)
+	if err != nil {
+		log.Printf("Failed to get meal plan: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, false
+	}
+
+	if plan == nil || plan.UserID != userID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "meal plan not found"})
+		return nil, false
+	}
+
+	return plan, true
+}
+
+// userToday returns the current time in a user's time zone, so dates begin and end at their midnight rather than UTC's
+func (h *MealPlanHandler) userToday(userID int64) (time.Time, error) {
+	timezone, err := h.UserStore.GetUserTimezone(userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().In(utils.LocationOrUTC(timezone)), nil
+}
+
+// parseDateQuery parses an optional YYYY-MM-DD query parameter
+// It writes a 400 response and returns false if the parameter is present but invalid
+func parseDateQuery(c *gin.Context, param string) (string, bool) {
+	value := c.Query(param)
+	if value == "" {
+		return "", true
+	}
+	if _, err := time.Parse(time.DateOnly, value); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": param + " must be a date in YYYY-MM-DD format"})
+		return "", false
+	}
+	return value, true
+}
+
+// CreateMealPlan godoc
+// @Summary Create a meal plan
+// @Description Creates a new meal plan owned by the authenticated user
+// @Tags Meal Plans
+// @Accept json
+// @Produce json
+// @Param request body createMealPlanRequest true "Meal plan name"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Meal plan created"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /meal-plans [post]
+func (h *MealPlanHandler) CreateMealPlan(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req createMealPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+	if len(name) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name must be at most 255 characters"})
+		return
+	}
+
+	plan := &store.MealPlan{UserID: userID, Name: name}
+	if err := h.MealPlanStore.CreateMealPlan(plan); err != nil {
+		log.Printf("Failed to create meal plan: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create meal plan"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":   "meal plan created",
+		"meal_plan": plan,
+	})
+}
+
+// GetMealPlans godoc
+// @Summary List meal plans
+// @Description Returns the authenticated user's meal plans, most recently changed first
+// @Tags Meal Plans
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Meal plans"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /meal-plans [get]
+func (h *MealPlanHandler) GetMealPlans(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	plans, err := h.MealPlanStore.GetMealPlansForUser(userID)
+	if err != nil {
+		log.Printf("Failed to get meal plans: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"meal_plans": plans,
+	})
+}
+
+// GetMealPlan godoc
+// @Summary Get a meal plan
+// @Description Returns a meal plan with its planned meals in date order, optionally limited to a date range, and today's date in the user's time zone
+// @Tags Meal Plans
+// @Produce json
+// @Param id path int true "Meal plan ID"
+// @Param from query string false "Earliest date to include (YYYY-MM-DD)"
+// @Param to query string false "Latest date to include (YYYY-MM-DD)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Meal plan with entries"
+// @Failure 400 {object} map[string]string "Invalid meal plan ID or date"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Meal plan not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /meal-plans/{id} [get]
+func (h *MealPlanHandler) GetMealPlan(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	plan, ok := h.loadMealPlan(c, userID)
+	if !ok {
+		return
+	}
+
+	from, ok := parseDateQuery(c, "from")
+	if !ok {
+		return
+	}
+	to, ok := parseDateQuery(c, "to")
+	if !ok {
+		return
+	}
+
+	entries, err := h.MealPlanStore.GetMealPlanEntries(plan.ID, from, to)
+	if err != nil {
+		log.Printf("Failed to get meal plan entries: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	today, err := h.userToday(userID)
+	if err != nil {
+		log.Printf("Failed to get user timezone: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"meal_plan": plan,
+		"entries":   entries,
+		"today":     today.Format(time.DateOnly),
+	})
+}
+
+// DeleteMealPlan godoc
+// @Summary Delete a meal plan
+// @Description Deletes a meal plan and its planned meals; subscribed calendars stop updating
+// @Tags Meal Plans
+// @Produce json
+// @Param id path int true "Meal plan ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Meal plan deleted"
+// @Failure 400 {object} map[string]string "Invalid meal plan ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Meal plan not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /meal-plans/{id} [delete]
+func (h *MealPlanHandler) DeleteMealPlan(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	plan, ok := h.loadMealPlan(c, userID)
+	if !ok {
+		return
+	}
+
+	if err := h.MealPlanStore.DeleteMealPlan(plan.ID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "meal plan not found"})
+			return
+		}
+		log.Printf("Failed to delete meal plan: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete meal plan"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "meal plan deleted"})
+}
+
+// AddMealPlanEntry godoc
+// @Summary Plan a meal
+// @Description Plans a recipe for breakfast, lunch, dinner or a snack on a day. The recipe must be published or your own.
+// @Tags Meal Plans
+// @Accept json
+// @Produce json
+// @Param id path int true "Meal plan ID"
+// @Param request body addMealPlanEntryRequest true "Planned meal"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Meal planned"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Meal plan or recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /meal-plans/{id}/entries [post]
+func (h *MealPlanHandler) AddMealPlanEntry(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	plan, ok := h.loadMealPlan(c, userID)
+	if !ok {
+		return
+	}
+
+	var req addMealPlanEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.RecipeID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "recipe_id is required"})
+		return
+	}
+	if _, err := time.Parse(time.DateOnly, req.PlannedFor); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "planned_for must be a date in YYYY-MM-DD format"})
+		return
+	}
+	meal := strings.ToLower(strings.TrimSpace(req.Meal))
+	if !slices.Contains(store.Meals, meal) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "meal must be one of: " + strings.Join(store.Meals, ", ")})
+		return
+	}
+	if req.Servings != nil && *req.Servings <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "servings must be positive"})
+		return
+	}
+	if req.Note != nil {
+		note := strings.TrimSpace(*req.Note)
+		if len(note) > 255 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "note must be at most 255 characters"})
+			return
+		}
+		req.Note = &note
+	}
+
+	recipe, err := h.RecipeStore.GetRecipeByID(req.RecipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if !canViewRecipe(recipe, userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	entry := &store.MealPlanEntry{
+		PlanID:     plan.ID,
+		RecipeID:   recipe.ID,
+		PlannedFor: req.PlannedFor,
+		Meal:       meal,
+		Servings:   req.Servings,
+		Note:       req.Note,
+	}
+	if err := h.MealPlanStore.AddMealPlanEntry(entry); err != nil {
+		log.Printf("Failed to add meal plan entry: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to plan meal"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "meal planned",
+		"entry":   entry,
+	})
+}
+
+// DeleteMealPlanEntry godoc
+// @Summary Remove a planned meal
+// @Description Removes a planned meal from a meal plan
+// @Tags Meal Plans
+// @Produce json
+// @Param id path int true "Meal plan ID"
+// @Param entry_id path int true "Entry ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Planned meal removed"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Meal plan or entry not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /meal-plans/{id}/entries/{entry_id} [delete]
+func (h *MealPlanHandler) DeleteMealPlanEntry(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	plan, ok := h.loadMealPlan(c, userID)
+	if !ok {
+		return
+	}
+
+	entryID, ok := parseIDParam(c, "entry_id", "entry ID")
+	if !ok {
+		return
+	}
+
+	if err := h.MealPlanStore.DeleteMealPlanEntry(plan.ID, entryID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "planned meal not found"})
+			return
+		}
+		log.Printf("Failed to delete meal plan entry: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove planned meal"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "planned meal removed"})
+}
+
+// CreateICalLink godoc
+// @Summary Create a calendar subscription link
+// @Description Creates a secret link calendar apps such as Google Calendar and Apple Calendar can subscribe to without signing in. Any earlier link stops working.
+// @Tags Meal Plans
+// @Produce json
+// @Param id path int true "Meal plan ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Subscription link created"
+// @Failure 400 {object} map[string]string "Invalid meal plan ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Meal plan not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /meal-plans/{id}/ical-link [post]
+func (h *MealPlanHandler) CreateICalLink(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	plan, ok := h.loadMealPlan(c, userID)
+	if !ok {
+		return
+	}
+
+	token, err := h.MealPlanStore.CreateICalToken(plan.ID)
+	if err != nil {
+		log.Printf("Failed to create calendar token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create calendar link"})
+		return
+	}
+
+	icalURL := links.NewBuilderFromEnv().URL("/meal-plans/%d/ical?token=%s", plan.ID, token)
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "calendar link created",
+		"ical_token": token,
+		"ical_url":   icalURL,
+		"webcal_url": "webcal" + strings.TrimPrefix(strings.TrimPrefix(icalURL, "https"), "http"),
+	})
+}
+
+// RevokeICalLink godoc
+// @Summary Revoke a calendar subscription link
+// @Description Stops a meal plan's calendar subscription link from working; subscribed calendars stop updating
+// @Tags Meal Plans
+// @Produce json
+// @Param id path int true "Meal plan ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Subscription link revoked"
+// @Failure 400 {object} map[string]string "Invalid meal plan ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Meal plan not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /meal-plans/{id}/ical-link [delete]
+func (h *MealPlanHandler) RevokeICalLink(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	plan, ok := h.loadMealPlan(c, userID)
+	if !ok {
+		return
+	}
+
+	if err := h.MealPlanStore.RevokeICalToken(plan.ID); err != nil {
+		log.Printf("Failed to revoke calendar token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke calendar link"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "calendar link revoked"})
+}
+
+// GetMealPlanICal godoc
+// @Summary Meal plan calendar feed
+// @Description Returns a meal plan as an iCalendar feed with one event per planned meal from the last 90 days onwards. Each event carries the recipe title and link and a reminder to start cooking, set from the recipe's prep and cook time. Calendar apps subscribe with the token from POST /meal-plans/{id}/ical-link; the owner can also fetch the feed with their access token.
+// @Tags Meal Plans
+// @Produce text/calendar
+// @Param id path int true "Meal plan ID"
+// @Param token query string false "Calendar subscription token"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 400 {object} map[string]string "Invalid meal plan ID"
+// @Failure 404 {object} map[string]string "Meal plan not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /meal-plans/{id}/ical [get]
+func (h *MealPlanHandler) GetMealPlanICal(c *gin.Context) {
+	planID, ok := parseIDParam(c, "id", "meal plan ID")
+	if !ok {
+		return
+	}
+
+	plan, err := h.MealPlanStore.GetMealPlan(planID)
+	if err != nil {
+		log.Printf("Failed to get meal plan: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	allowed := false
+	if plan != nil {
+		if token := c.Query("token"); token != "" {
+			allowed = plan.ICalToken != nil && subtle.ConstantTimeCompare([]byte(token), []byte(*plan.ICalToken)) == 1
+		} else {
+			viewerID, err := getOptionalInternalUserID(c, h.UserStore)
+			if err != nil {
+				log.Printf("Failed to resolve viewer: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				return
+			}
+			allowed = viewerID != 0 && viewerID == plan.UserID
+		}
+	}
+	if !allowed {
+		c.JSON(http.StatusNotFound, gin.H{"error": "meal plan not found"})
+		return
+	}
+
+	timezone, err := h.UserStore.GetUserTimezone(plan.UserID)
+	if err != nil {
+		log.Printf("Failed to get user timezone: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	from := time.Now().In(utils.LocationOrUTC(timezone)).AddDate(0, 0, -mealPlanFeedHistoryDays).Format(time.DateOnly)
+	entries, err := h.MealPlanStore.GetMealPlanEntries(plan.ID, from, "")
+	if err != nil {
+		log.Printf("Failed to get meal plan entries: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=900")
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="meal-plan-%d.ics"`, plan.ID))
+	c.Data(http.StatusOK, icalContentType+"; charset=utf-8", mealPlanICal(plan, entries, timezone))
+}
+
+// mealPlanICal renders a meal plan as an iCalendar document
+// Meal times are floating, so each subscriber sees breakfast in the morning in their own time zone.
+// The owner's time zone, if set, is suggested to calendar apps that need one for floating times.
+func mealPlanICal(plan *store.MealPlan, entries []*store.MealPlanEntry, timezone string) []byte {
+	w := &icalWriter{}
+	w.Line("BEGIN", "VCALENDAR")
+	w.Line("VERSION", "2.0")
+	w.Line("PRODID", "-//ChefShare//Meal Plans//EN")
+	w.Line("CALSCALE", "GREGORIAN")
+	w.Line("METHOD", "PUBLISH")
+	w.Text("X-WR-CALNAME", plan.Name)
+	if timezone != "" {
+		w.Line("X-WR-TIMEZONE", timezone)
+	}
+	w.Line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	w.Line("X-PUBLISHED-TTL", "PT1H")
+
+	for _, entry := range entries {
+		day, err := time.Parse(time.DateOnly, entry.PlannedFor)
+		if err != nil {
+			continue
+		}
+		start := day.Add(mealTimes[entry.Meal])
+		link := recipePageURL(entry.RecipeID)
+
+		description := []string{}
+		if entry.Servings != nil {
+			description = append(description, fmt.Sprintf("Servings: %d", *entry.Servings))
+		}
+		if entry.Note != nil && *entry.Note != "" {
+			description = append(description, *entry.Note)
+		}
+		description = append(description, link)
+
+		minutes := 0
+		if entry.PrepTime != nil {
+			minutes += *entry.PrepTime
+		}
+		if entry.CookTime != nil {
+			minutes += *entry.CookTime
+		}
+		reminder := mealPlanDefaultReminder
+		if minutes > 0 {
+			reminder = time.Duration(minutes) * time.Minute
+		}
+
+		w.Line("BEGIN", "VEVENT")
+		w.Line("UID", fmt.Sprintf("meal-plan-entry-%d@chefshare", entry.ID))
+		w.UTC("DTSTAMP", entry.CreatedAt)
+		w.Line("DTSTART", start.Format("20060102T150405"))
+		w.Line("DTEND", start.Add(mealPlanEventDuration).Format("20060102T150405"))
+		w.Text("SUMMARY", fmt.Sprintf("%s: %s", mealTitle(entry.Meal), entry.RecipeTitle))
+		w.Text("DESCRIPTION", strings.Join(description, "\n"))
+		w.Line("URL", link)
+		w.Line("BEGIN", "VALARM")
+		w.Line("ACTION", "DISPLAY")
+		w.Text("DESCRIPTION", "Start preparing "+entry.RecipeTitle)
+		w.Line("TRIGGER", fmt.Sprintf("-PT%dM", int(reminder.Minutes())))
+		w.Line("END", "VALARM")
+		w.Line("END", "VEVENT")
+	}
+
+	w.Line("END", "VCALENDAR")
+	return w.Bytes()
+}
+
+// mealTitle capitalizes a meal name for display
+func mealTitle(meal string) string {
+	if meal == "" {
+		return meal
+	}
+	return strings.ToUpper(meal[:1]) + meal[1:]
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler struct {
+	Storage services.Storage
+}
+
+func NewMediaHandler(storage services.Storage) *MediaHandler {
+	return &MediaHandler{
+		Storage: storage,
+	}
+}
+
+// ServeMedia godoc
+// @Summary Serve a stored file
+// @Description Serves a file from local storage through a signed, expiring URL returned by other endpoints. Only used with the local storage backend; other backends link to their own signed URLs.
+// @Tags Media
+// @Produce octet-stream
+// @Param key path string true "Storage key"
+// @Param expires query int true "Expiry as a Unix timestamp"
+// @Param signature query string true "URL signature"
+// @Success 200 {file} file "File contents"
+// @Failure 403 {object} map[string]string "Invalid or expired signature"
+// @Failure 404 {object} map[string]string "File not found"
+// @Router /media/{key} [get]
+func (h *MediaHandler) ServeMedia(c *gin.Context) {
+	local, ok := h.Storage.(*services.LocalStorage)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if err := local.Verify(key, c.Query("expires"), c.Query("signature")); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	path, err := local.Path(key)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return
+	}
+
+	// Signed links are per-viewer and expire, so keep them out of shared caches
+	c.Header("Cache-Control", "private, max-age=300")
+	c.File(path)
+}
@@ -0,0 +1,350 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// MaxMessageLength caps the length of a direct message
+	MaxMessageLength = 2000
+
+	// DefaultConversationsPageSize is the number of conversations returned per page when no limit is given
+	DefaultConversationsPageSize = 20
+
+	// MaxConversationsPageSize caps the number of conversations returned per page
+	MaxConversationsPageSize = 50
+
+	// DefaultMessagesPageSize is the number of messages returned per page when no limit is given
+	DefaultMessagesPageSize = 50
+
+	// MaxMessagesPageSize caps the number of messages returned per page
+	MaxMessagesPageSize = 100
+)
+
+type MessageHandler struct {
+	MessageStore store.MessageStore
+	BlockStore   store.BlockStore
+	UserStore    store.UserStore
+}
+
+func NewMessageHandler(messageStore store.MessageStore, blockStore store.BlockStore, userStore store.UserStore) *MessageHandler {
+	return &MessageHandler{
+		MessageStore: messageStore,
+		BlockStore:   blockStore,
+		UserStore:    userStore,
+	}
+}
+
+type startConversationRequest struct {
+	Username string `json:"username"`
+	Message  string `json:"message"`
+}
+
+type sendMessageRequest struct {
+	Body string `json:"body"`
+}
+
+// validateMessageBody trims a message and checks its length
+// It writes a 400 response and returns false if the message is empty or too long
+func validateMessageBody(c *gin.Context, body string) (string, bool) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "message is required"})
+		return "", false
+	}
+	if len([]rune(body)) > MaxMessageLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("message must be at most %d characters", MaxMessageLength)})
+		return "", false
+	}
+	return body, true
+}
+
+// checkNotBlocked writes a 403 response and returns false if either user has blocked the other
+func (h *MessageHandler) checkNotBlocked(c *gin.Context, userID int64, otherID int64) bool {
+	blocked, err := h.BlockStore.IsBlockedEitherWay(userID, otherID)
+	if err != nil {
+		log.Printf("Failed to check block list: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return false
+	}
+	if blocked {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you cannot message this user"})
+		return false
+	}
+	return true
+}
+
+// StartConversation godoc
+// @Summary Start a conversation
+// @Description Opens the one-to-one conversation with another user, creating it if needed, and optionally sends a first message. Not allowed if either user has blocked the other.
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param request body startConversationRequest true "Recipient username and optional first message"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Existing conversation"
+// @Success 201 {object} map[string]interface{} "Conversation created"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Blocked"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /conversations [post]
+func (h *MessageHandler) StartConversation(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req startConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	username := strings.TrimSpace(req.Username)
+	if username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "username is required"})
+		return
+	}
+
+	body := ""
+	if strings.TrimSpace(req.Message) != "" {
+		if body, ok = validateMessageBody(c, req.Message); !ok {
+			return
+		}
+	}
+
+	otherID, err := h.UserStore.GetUserInternalIDByUsername(username)
+	if err != nil {
+		log.Printf("Failed to look up recipient: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if otherID == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+	if otherID == userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "you cannot message yourself"})
+		return
+	}
+
+	if !h.checkNotBlocked(c, userID, otherID) {
+		return
+	}
+
+	conversationID, created, err := h.MessageStore.GetOrCreateConversation(userID, otherID)
+	if err != nil {
+		log.Printf("Failed to get or create conversation: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start conversation"})
+		return
+	}
+
+	var message *store.Message
+	if body != "" {
+		message, err = h.MessageStore.CreateMessage(conversationID, userID, body)
+		if err != nil {
+			log.Printf("Failed to send message: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send message"})
+			return
+		}
+	}
+
+	conversation, err := h.MessageStore.GetConversation(conversationID, userID)
+	if err != nil || conversation == nil {
+		log.Printf("Failed to get conversation: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
+	c.JSON(status, gin.H{
+		"conversation": conversation,
+		"message":      message,
+	})
+}
+
+// GetConversations godoc
+// @Summary List my conversations
+// @Description Returns the authenticated user's conversations, most recently active first, each with the latest message and its unread count, plus the total unread count
+// @Tags Messages
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Conversations per page (default 20, max 50)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Conversations with pagination"
+// @Failure 400 {object} map[string]string "Invalid pagination"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /conversations [get]
+func (h *MessageHandler) GetConversations(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultConversationsPageSize, MaxConversationsPageSize)
+	if !ok {
+		return
+	}
+
+	conversations, total, err := h.MessageStore.GetConversations(userID, page, limit)
+	if err != nil {
+		log.Printf("Failed to get conversations: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	unreadCount, err := h.MessageStore.CountUnreadMessages(userID)
+	if err != nil {
+		log.Printf("Failed to count unread messages: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"conversations": conversations,
+		"unread_count":  unreadCount,
+		"pagination":    newPagination(page, limit, total),
+	})
+}
+
+// loadConversation fetches a conversation the authenticated user takes part in
+// It writes an error response and returns false if the conversation is missing or belongs to other users
+func (h *MessageHandler) loadConversation(c *gin.Context) (*store.Conversation, int64, bool) {
+	conversationID, ok := parseIDParam(c, "id", "conversation ID")
+	if !ok {
+		return nil, 0, false
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return nil, 0, false
+	}
+
+	conversation, err := h.MessageStore.GetConversation(conversationID, userID)
+	if err != nil {
+		log.Printf("Failed to get conversation: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, 0, false
+	}
+	if conversation == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "conversation not found"})
+		return nil, 0, false
+	}
+
+	return conversation, userID, true
+}
+
+// GetMessages godoc
+// @Summary List messages in a conversation
+// @Description Returns a page of messages in one of the authenticated user's conversations, newest first, and marks the conversation as read
+// @Tags Messages
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Messages per page (default 50, max 100)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Messages with pagination"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Conversation not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /conversations/{id}/messages [get]
+func (h *MessageHandler) GetMessages(c *gin.Context) {
+	conversation, userID, ok := h.loadConversation(c)
+	if !ok {
+		return
+	}
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultMessagesPageSize, MaxMessagesPageSize)
+	if !ok {
+		return
+	}
+
+	messages, total, err := h.MessageStore.GetMessages(conversation.ID, page, limit)
+	if err != nil {
+		log.Printf("Failed to get messages: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if err := h.MessageStore.MarkConversationRead(conversation.ID, userID); err != nil {
+		log.Printf("Failed to mark conversation read: %v", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"messages":   messages,
+		"pagination": newPagination(page, limit, total),
+	})
+}
+
+// SendMessage godoc
+// @Summary Send a message
+// @Description Sends a message in one of the authenticated user's conversations. Not allowed if either user has blocked the other.
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param request body sendMessageRequest true "Message body"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Message sent"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Blocked"
+// @Failure 404 {object} map[string]string "Conversation not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /conversations/{id}/messages [post]
+func (h *MessageHandler) SendMessage(c *gin.Context) {
+	conversation, userID, ok := h.loadConversation(c)
+	if !ok {
+		return
+	}
+
+	var req sendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	body, ok := validateMessageBody(c, req.Body)
+	if !ok {
+		return
+	}
+
+	if !h.checkNotBlocked(c, userID, conversation.OtherUserID) {
+		return
+	}
+
+	message, err := h.MessageStore.CreateMessage(conversation.ID, userID, body)
+	if err != nil {
+		log.Printf("Failed to send message: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send message"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": message,
+	})
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultMetricsDays is how many days of metrics are returned when no range is given
+	DefaultMetricsDays = 30
+
+	// MaxMetricsDays caps the length of a metrics range
+	MaxMetricsDays = 366
+)
+
+type MetricsHandler struct {
+	MetricsStore store.MetricsStore
+	HTTPMetrics  *services.HTTPMetrics
+	Retention    *services.RetentionPurger
+
+	// MetricsToken is the bearer token Prometheus scrapes with, from METRICS_TOKEN; the endpoint is off without it
+	MetricsToken string
+}
+
+func NewMetricsHandler(metricsStore store.MetricsStore, httpMetrics *services.HTTPMetrics, retention *services.RetentionPurger, metricsToken string) *MetricsHandler {
+	return &MetricsHandler{
+		MetricsStore: metricsStore,
+		HTTPMetrics:  httpMetrics,
+		Retention:    retention,
+		MetricsToken: metricsToken,
+	}
+}
+
+// GetDailyMetrics godoc
+// @Summary Daily site metrics
+// @Description Returns signups, recipe creations, publishes, reviews and email sends per UTC day, with totals for the range. Days are rolled up nightly, so the current day is not included until it is over. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param from query string false "First day, YYYY-MM-DD (default 30 days before to)"
+// @Param to query string false "Last day, YYYY-MM-DD (default yesterday)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Daily metrics and totals"
+// @Failure 400 {object} map[string]string "Invalid date range"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/metrics/daily [get]
+func (h *MetricsHandler) GetDailyMetrics(c *gin.Context) {
+	to := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be a date in YYYY-MM-DD format"})
+			return
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, 1-DefaultMetricsDays)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be a date in YYYY-MM-DD format"})
+			return
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return
+	}
+	if to.Sub(from) >= MaxMetricsDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date range cannot exceed 366 days"})
+		return
+	}
+
+	days, err := h.MetricsStore.GetDailyMetrics(from, to)
+	if err != nil {
+		log.Printf("Failed to get daily metrics: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	var signups, recipesCreated, recipesPublished, reviews, emailsSent int
+	for _, day := range days {
+		signups += day.Signups
+		recipesCreated += day.RecipesCreated
+		recipesPublished += day.RecipesPublished
+		reviews += day.Reviews
+		emailsSent += day.EmailsSent
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"from": from.Format(time.DateOnly),
+		"to":   to.Format(time.DateOnly),
+		"days": days,
+		"totals": gin.H{
+			"signups":           signups,
+			"recipes_created":   recipesCreated,
+			"recipes_published": recipesPublished,
+			"reviews":           reviews,
+			"emails_sent":       emailsSent,
+		},
+	})
+}
+
+// GetRouteMetrics godoc
+// @Summary Request metrics for Prometheus
+// @Description Returns request counts (http_requests_total) and durations (http_request_duration_seconds), and rows deleted by data retention policies (retention_rows_purged_total), since the server started, in the Prometheus text format. Requests are labelled with the route template they matched, such as /api/v1/recipes/:id, rather than the raw path; requests matching no route share the route label "unmatched". Authenticate with "Authorization: Bearer" and the METRICS_TOKEN value. Only registered when METRICS_TOKEN is set.
+// @Tags Admin
+// @Produce plain
+// @Success 200 {string} string "Metrics in the Prometheus text format"
+// @Failure 401 {object} map[string]string "Missing or wrong metrics token"
+// @Router /metrics [get]
+func (h *MetricsHandler) GetRouteMetrics(c *gin.Context) {
+	if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), []byte("Bearer "+h.MetricsToken)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := h.HTTPMetrics.WritePrometheus(c.Writer); err != nil {
+		log.Printf("Failed to write request metrics: %v", err)
+		return
+	}
+	if err := h.Retention.WritePrometheus(c.Writer); err != nil {
+		log.Printf("Failed to write retention metrics: %v", err)
+	}
+}
+
+// GetRetentionPolicies godoc
+// @Summary Data retention policies
+// @Description Lists how long the recipe change feed (audit_log), daily recipe view counts (view_events) and records of sent emails (email_outbox) are kept, with the rows each policy has purged since the server started. A retention_days of 0 keeps records forever. Windows are set with RETENTION_AUDIT_LOG_DAYS, RETENTION_VIEW_EVENTS_DAYS and RETENTION_EMAIL_OUTBOX_DAYS. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Retention policies"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Router /admin/retention [get]
+func (h *MetricsHandler) GetRetentionPolicies(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"policies": h.Retention.Status()})
+}
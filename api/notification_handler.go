@@ -0,0 +1,238 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultNotificationsLimit is the number of notifications returned when no limit is given
+	DefaultNotificationsLimit = 50
+
+	// MaxNotificationsLimit caps the number of notifications returned at once
+	MaxNotificationsLimit = 100
+)
+
+type notificationPreferencesRequest struct {
+	Preferences []store.NotificationPreference `json:"preferences" binding:"required"`
+}
+
+type NotificationHandler struct {
+	NotificationStore store.NotificationStore
+	UserStore         store.UserStore
+}
+
+func NewNotificationHandler(notificationStore store.NotificationStore, userStore store.UserStore) *NotificationHandler {
+	return &NotificationHandler{
+		NotificationStore: notificationStore,
+		UserStore:         userStore,
+	}
+}
+
+// GetNotifications godoc
+// @Summary List my notifications
+// @Description Returns the authenticated user's in-app notifications, most recently updated first, with the number still unread. A notification batching several events, such as favorites of one recipe, has their number in event_count and is updated as more arrive.
+// @Tags Notifications
+// @Produce json
+// @Param unread query bool false "Only return unread notifications"
+// @Param limit query int false "Maximum number of notifications (default 50, max 100)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Notifications and unread count"
+// @Failure 400 {object} map[string]string "Invalid limit"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultNotificationsLimit, MaxNotificationsLimit)
+	if !ok {
+		return
+	}
+
+	unreadOnly := c.Query("unread") == "true"
+
+	notifications, err := h.NotificationStore.GetNotifications(userID, unreadOnly, limit)
+	if err != nil {
+		log.Printf("Failed to get notifications: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	unreadCount, err := h.NotificationStore.CountUnreadNotifications(userID)
+	if err != nil {
+		log.Printf("Failed to count unread notifications: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": notifications,
+		"unread_count":  unreadCount,
+	})
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification read
+// @Description Marks one of the authenticated user's notifications as read
+// @Tags Notifications
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Notification marked read"
+// @Failure 400 {object} map[string]string "Invalid notification ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Notification not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/notifications/{id}/read [put]
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	notificationID, ok := parseIDParam(c, "id", "notification ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	err := h.NotificationStore.MarkNotificationRead(notificationID, userID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "notification not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to mark notification read: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "notification marked read"})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications read
+// @Description Marks every unread notification of the authenticated user as read
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Number of notifications marked read"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/notifications/read-all [put]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	updated, err := h.NotificationStore.MarkAllNotificationsRead(userID)
+	if err != nil {
+		log.Printf("Failed to mark notifications read: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "notifications marked read",
+		"updated": updated,
+	})
+}
+
+// GetNotificationPreferences godoc
+// @Summary Get my notification preferences
+// @Description Returns how each type of notification is batched and emailed for the authenticated user. While a notification's batch window is open, more events of the same kind about the same thing update it instead of adding another; emailed notifications go out in one digest once their window closes.
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Notification preferences"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/notification-preferences [get]
+func (h *NotificationHandler) GetNotificationPreferences(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.NotificationStore.GetNotificationPreferences(userID)
+	if err != nil {
+		log.Printf("Failed to get notification preferences: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preferences":              prefs,
+		"max_batch_window_minutes": store.MaxNotificationBatchWindowMinutes,
+	})
+}
+
+// UpdateNotificationPreferences godoc
+// @Summary Update my notification preferences
+// @Description Sets the batch window (0 to 1440 minutes, 0 notifies about every event separately) and email setting of the given notification types. Types left out keep their current preferences. Changes apply to batches opened afterwards.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param request body notificationPreferencesRequest true "Preferences by notification type"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Updated notification preferences"
+// @Failure 400 {object} map[string]string "Unknown notification type or invalid batch window"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/notification-preferences [put]
+func (h *NotificationHandler) UpdateNotificationPreferences(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req notificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, pref := range req.Preferences {
+		if _, ok := store.DefaultNotificationPreference(pref.Type); !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown notification type %q", pref.Type)})
+			return
+		}
+		if seen[pref.Type] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("notification type %q is listed more than once", pref.Type)})
+			return
+		}
+		seen[pref.Type] = true
+		if pref.BatchWindowMinutes < 0 || pref.BatchWindowMinutes > store.MaxNotificationBatchWindowMinutes {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("batch_window_minutes must be between 0 and %d", store.MaxNotificationBatchWindowMinutes)})
+			return
+		}
+	}
+
+	if err := h.NotificationStore.SaveNotificationPreferences(userID, req.Preferences); err != nil {
+		log.Printf("Failed to save notification preferences: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	prefs, err := h.NotificationStore.GetNotificationPreferences(userID)
+	if err != nil {
+		log.Printf("Failed to get notification preferences: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "notification preferences updated",
+		"preferences": prefs,
+	})
+}
@@ -0,0 +1,694 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PendingIdentityLinkExpiry is how long the owner of an account has to confirm linking a provider identity (24 hours)
+const PendingIdentityLinkExpiry = 24 * time.Hour
+
+type oauthCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+type confirmIdentityLinkRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type OAuthHandler struct {
+	UserStore     store.UserStore
+	IdentityStore store.IdentityStore
+	EmailService  *services.EmailService
+	JWTService    *services.JWTService
+	OAuthService  *services.OAuthService
+}
+
+func NewOAuthHandler(
+	userStore store.UserStore,
+	identityStore store.IdentityStore,
+	emailService *services.EmailService,
+	jwtService *services.JWTService,
+	oauthService *services.OAuthService,
+) *OAuthHandler {
+	return &OAuthHandler{
+		UserStore:     userStore,
+		IdentityStore: identityStore,
+		EmailService:  emailService,
+		JWTService:    jwtService,
+		OAuthService:  oauthService,
+	}
+}
+
+// GetOAuthProviders godoc
+// @Summary List OAuth providers
+// @Description Returns the OAuth providers users can sign in with
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Enabled providers"
+// @Router /auth/oauth/providers [get]
+func (h *OAuthHandler) GetOAuthProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"providers": h.OAuthService.Providers()})
+}
+
+// StartOAuthLogin godoc
+// @Summary Start signing in with an OAuth provider
+// @Description Starts an authorization request and returns the provider page to send the user to. The provider redirects back to the frontend with a code and state, which the frontend posts to the callback endpoint. The state expires after 10 minutes.
+// @Tags Authentication
+// @Produce json
+// @Param provider path string true "Provider" Enums(google, github)
+// @Success 200 {object} map[string]interface{} "Authorization URL"
+// @Failure 404 {object} map[string]string "Provider not enabled"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auth/oauth/{provider}/start [post]
+func (h *OAuthHandler) StartOAuthLogin(c *gin.Context) {
+	h.startAuthorization(c, store.OAuthIntentLogin, nil)
+}
+
+// StartIdentityLink godoc
+// @Summary Start linking an OAuth identity
+// @Description Starts an authorization request that links the identity the user signs in with at the provider to the authenticated account. Complete it through the callback endpoint while signed in to the same account.
+// @Tags Users
+// @Produce json
+// @Param provider path string true "Provider" Enums(google, github)
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Authorization URL"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Provider not enabled"
+// @Failure 409 {object} map[string]string "An identity at this provider is already linked"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/identities/{provider} [post]
+func (h *OAuthHandler) StartIdentityLink(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	identities, err := h.IdentityStore.GetIdentitiesForUser(userID)
+	if err != nil {
+		log.Printf("Failed to get identities: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	for _, identity := range identities {
+		if identity.Provider == c.Param("provider") {
+			c.JSON(http.StatusConflict, gin.H{"error": "an account at this provider is already linked; unlink it first to link a different one"})
+			return
+		}
+	}
+
+	h.startAuthorization(c, store.OAuthIntentLink, &userID)
+}
+
+func (h *OAuthHandler) startAuthorization(c *gin.Context, intent string, userID *int64) {
+	provider := c.Param("provider")
+	if !h.OAuthService.Enabled(provider) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "oauth provider not enabled"})
+		return
+	}
+
+	verifier, err := services.NewCodeVerifier()
+	if err != nil {
+		log.Printf("Failed to generate code verifier: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	state := &store.OAuthState{
+		Provider:     provider,
+		Intent:       intent,
+		UserID:       userID,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(h.OAuthService.StateTTL()),
+	}
+	if err := h.IdentityStore.CreateOAuthState(state); err != nil {
+		log.Printf("Failed to create oauth state: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	authURL, err := h.OAuthService.AuthCodeURL(provider, state.State, verifier)
+	if err != nil {
+		log.Printf("Failed to build authorization URL: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"authorization_url": authURL,
+		"state":             state.State,
+		"expires_at":        state.ExpiresAt,
+	})
+}
+
+// OAuthCallback godoc
+// @Summary Complete an OAuth authorization request
+// @Description Exchanges the code the provider returned for the user's identity. Requests started to link an identity must be completed while signed in to the account that started them, and return the linked identity. Sign-in requests log in the account the identity is linked to, or create a new account (201) when no account uses the provider's email. When an account already uses the email, nothing is linked: if the provider verified the email, its owner is emailed a link to confirm (202), otherwise the request is refused so the owner can sign in and link the provider themselves.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider" Enums(google, github)
+// @Param request body oauthCallbackRequest true "Code and state returned by the provider"
+// @Success 200 {object} map[string]interface{} "Signed in, or identity linked"
+// @Success 201 {object} map[string]interface{} "Account created"
+// @Success 202 {object} map[string]string "Confirmation email sent to the account using the provider's email"
+// @Failure 400 {object} map[string]string "Invalid request, invalid or expired state, or the provider shared no email"
+// @Failure 403 {object} map[string]string "Linking must be completed by the account that started it, or account disabled"
+// @Failure 404 {object} map[string]string "Provider not enabled"
+// @Failure 409 {object} map[string]string "Identity or email already belongs to another account"
+// @Failure 502 {object} map[string]string "The provider rejected the code"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auth/oauth/{provider}/callback [post]
+func (h *OAuthHandler) OAuthCallback(c *gin.Context) {
+	provider := c.Param("provider")
+	if !h.OAuthService.Enabled(provider) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "oauth provider not enabled"})
+		return
+	}
+
+	var req oauthCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Each state completes at most one request, whatever happens next
+	state, err := h.IdentityStore.ConsumeOAuthState(req.State, provider)
+	if err != nil {
+		log.Printf("Failed to consume oauth state: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if state == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired state; start signing in again"})
+		return
+	}
+
+	// Without this check, someone could start linking on their own account and trick a victim into
+	// completing it, so that the victim's provider identity signs in to the attacker's account
+	viewerID := int64(0)
+	if state.Intent == store.OAuthIntentLink {
+		viewerID, err = getOptionalInternalUserID(c, h.UserStore)
+		if err != nil {
+			log.Printf("Failed to resolve user: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		if state.UserID == nil || viewerID != *state.UserID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "sign in to the account that started linking to complete it"})
+			return
+		}
+	}
+
+	profile, err := h.OAuthService.Exchange(c.Request.Context(), provider, req.Code, state.CodeVerifier)
+	if err != nil {
+		log.Printf("Failed to complete %s sign-in: %v", provider, err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "could not sign in with the provider; try again"})
+		return
+	}
+
+	if state.Intent == store.OAuthIntentLink {
+		h.linkIdentity(c, viewerID, provider, profile)
+		return
+	}
+
+	identity, err := h.IdentityStore.GetIdentity(provider, profile.Subject)
+	if err != nil {
+		log.Printf("Failed to get identity: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if identity != nil {
+		h.signInWithIdentity(c, identity)
+		return
+	}
+
+	if profile.Email == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s did not share an email address; sign up with a password or make your email visible to Chefshare", services.OAuthProviderName(provider))})
+		return
+	}
+
+	existing, err := h.UserStore.GetUserByEmail(profile.Email)
+	if err != nil {
+		log.Printf("Failed to look up user by email: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if existing != nil {
+		h.requestIdentityLink(c, existing, provider, profile)
+		return
+	}
+
+	h.createOAuthUser(c, provider, profile)
+}
+
+// linkIdentity links the identity to the signed-in account that started the request
+func (h *OAuthHandler) linkIdentity(c *gin.Context, userID int64, provider string, profile *services.OAuthProfile) {
+	identity := &store.UserIdentity{
+		UserID:   userID,
+		Provider: provider,
+		Subject:  profile.Subject,
+	}
+	if profile.Email != "" {
+		identity.Email = &profile.Email
+	}
+
+	if err := h.IdentityStore.CreateIdentity(identity); err != nil {
+		if errors.Is(err, store.ErrConflict) && store.ConstraintName(err) == "uq_user_identities_provider_subject" {
+			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("this %s account is already linked to a Chefshare account", services.OAuthProviderName(provider))})
+			return
+		}
+		if errors.Is(err, store.ErrConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": "an account at this provider is already linked; unlink it first to link a different one"})
+			return
+		}
+		log.Printf("Failed to link identity: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to link account"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "account linked",
+		"identity": identity,
+	})
+}
+
+// signInWithIdentity logs in the account an identity is linked to
+func (h *OAuthHandler) signInWithIdentity(c *gin.Context, identity *store.UserIdentity) {
+	user, err := h.UserStore.GetUserByID(identity.UserPublicID)
+	if err != nil || user == nil {
+		log.Printf("Failed to get user for identity %d: %v", identity.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if err := h.IdentityStore.TouchIdentity(identity.ID); err != nil {
+		log.Printf("Failed to update identity: %v", err)
+	}
+
+	h.respondWithSession(c, http.StatusOK, "login successful", user)
+}
+
+// requestIdentityLink emails the owner of the account using the provider's email to confirm linking the identity
+// Linking straight away would let anyone who registers the address at a provider take over the account
+func (h *OAuthHandler) requestIdentityLink(c *gin.Context, user *store.User, provider string, profile *services.OAuthProfile) {
+	providerName := services.OAuthProviderName(provider)
+
+	// An email the provider has not verified proves nothing about who owns the account
+	if !profile.EmailVerified {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("an account with this email already exists; sign in with your password and link %s from your account settings", providerName)})
+		return
+	}
+
+	userID, err := h.UserStore.GetUserInternalID(user.UserID)
+	if err != nil {
+		log.Printf("Failed to resolve user ID %s: %v", user.UserID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	link := &store.PendingIdentityLink{
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   profile.Subject,
+		Email:     profile.Email,
+		ExpiresAt: time.Now().Add(PendingIdentityLinkExpiry),
+	}
+	if err := h.IdentityStore.CreatePendingLink(link); err != nil {
+		log.Printf("Failed to create pending identity link: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if h.EmailService != nil {
+		go func() {
+			name := user.FirstName
+			if name == "" {
+				name = user.Username
+			}
+			emailID, err := h.EmailService.SendIdentityLinkEmail(user.Email, name, providerName, link.Token, link.ExpiresAt)
+			if err != nil {
+				log.Printf("Failed to send identity link email to %s: %v", user.Email, err)
+			} else {
+				log.Printf("Identity link email sent to %s with ID: %s", user.Email, emailID)
+			}
+		}()
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": fmt.Sprintf("an account with this email already exists; we sent a link to %s to confirm signing in with %s", user.Email, providerName),
+	})
+}
+
+// createOAuthUser creates an account for an identity whose email no account uses, and logs it in
+func (h *OAuthHandler) createOAuthUser(c *gin.Context, provider string, profile *services.OAuthProfile) {
+	username, err := h.oauthUsername(profile)
+	if err != nil {
+		log.Printf("Failed to choose a username: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not create user"})
+		return
+	}
+
+	user := &store.User{
+		UserID:        uuid.New().String(),
+		Username:      username,
+		Email:         profile.Email,
+		FirstName:     profile.FirstName,
+		LastName:      profile.LastName,
+		EmailVerified: profile.EmailVerified,
+	}
+	if len(profile.Picture) <= 255 {
+		user.ProfilePicture = profile.Picture
+	}
+	identity := &store.UserIdentity{
+		Provider: provider,
+		Subject:  profile.Subject,
+		Email:    &profile.Email,
+	}
+
+	var accessToken string
+	var refreshToken *store.RefreshToken
+	err = store.WithTx(c.Request.Context(), h.UserStore.DB(), func(tx *sql.Tx) error {
+		if err := h.IdentityStore.CreateOAuthUserWithTransaction(user, identity, tx); err != nil {
+			return err
+		}
+
+		var err error
+		accessToken, refreshToken, err = h.JWTService.GenerateTokenPairWithTransaction(user, c.ClientIP(), c.Request.UserAgent(), tx)
+		return err
+	})
+	if err != nil {
+		log.Printf("Failed to create user for %s identity: %v", provider, err)
+		if errors.Is(err, store.ErrConflict) {
+			// Another request signed up with the same email or identity at the same moment
+			c.JSON(http.StatusConflict, gin.H{"error": "an account with this email already exists; try signing in again"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not create user"})
+		return
+	}
+
+	if h.EmailService != nil {
+		go func() {
+			name := user.FirstName
+			if name == "" {
+				name = user.Username
+			}
+			emailID, err := h.EmailService.SendWelcomeEmail(user.Email, name)
+			if err != nil {
+				log.Printf("Failed to send welcome email to %s: %v", user.Email, err)
+			} else {
+				log.Printf("Welcome email sent to %s with ID: %s", user.Email, emailID)
+			}
+		}()
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "user created successfully",
+		"tokens":  sessionTokens(c, h.JWTService, accessToken, refreshToken.Token),
+		"user":    oauthUserResponse(user),
+	})
+}
+
+// oauthUsername picks an unused username based on the provider's username or the email's local part
+func (h *OAuthHandler) oauthUsername(profile *services.OAuthProfile) (string, error) {
+	base := profile.Username
+	if base == "" {
+		base, _, _ = strings.Cut(profile.Email, "@")
+	}
+
+	// Keep letters and digits, with single underscores between runs of them
+	var b strings.Builder
+	for _, r := range base {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "_"):
+			b.WriteByte('_')
+		}
+	}
+	base = strings.TrimSuffix(b.String(), "_")
+	// Leave room for the suffix added when the name is taken
+	if len(base) > 15 {
+		base = strings.TrimSuffix(base[:15], "_")
+	}
+	if len(base) < 3 {
+		base = "chef"
+	}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		candidate := base
+		if attempt > 0 || utils.IsReservedUsername(candidate) {
+			candidate = fmt.Sprintf("%s_%04d", base, rand.IntN(10000))
+		}
+		if !utils.IsValidUsername(candidate) {
+			continue
+		}
+
+		taken, err := h.UserStore.IsUsernameTaken(candidate, "")
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("no free username found for %q", base)
+}
+
+// ConfirmIdentityLink godoc
+// @Summary Confirm linking an OAuth identity
+// @Description Links the provider identity described in a confirmation email to the account it was sent to, and signs in. Following the link proves the owner controls the account's email, so the email is marked verified.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body confirmIdentityLinkRequest true "Token from the confirmation email"
+// @Success 200 {object} map[string]interface{} "Identity linked and signed in"
+// @Failure 400 {object} map[string]string "Invalid or expired token"
+// @Failure 403 {object} map[string]string "Account disabled"
+// @Failure 409 {object} map[string]string "Identity already linked to an account"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auth/oauth/link/confirm [post]
+func (h *OAuthHandler) ConfirmIdentityLink(c *gin.Context) {
+	var req confirmIdentityLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	link, err := h.IdentityStore.ConsumePendingLink(req.Token)
+	if err != nil {
+		log.Printf("Failed to consume pending identity link: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if link == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired link"})
+		return
+	}
+
+	identity := &store.UserIdentity{
+		UserID:   link.UserID,
+		Provider: link.Provider,
+		Subject:  link.Subject,
+		Email:    &link.Email,
+	}
+	if err := h.IdentityStore.CreateIdentity(identity); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": "this account is already linked; sign in with it instead"})
+			return
+		}
+		log.Printf("Failed to link identity: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to link account"})
+		return
+	}
+
+	if err := h.UserStore.SetEmailVerified(link.UserPublicID, true); err != nil {
+		log.Printf("Failed to mark email verified for %s: %v", link.UserPublicID, err)
+	}
+
+	user, err := h.UserStore.GetUserByID(link.UserPublicID)
+	if err != nil || user == nil {
+		log.Printf("Failed to get user %s: %v", link.UserPublicID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	h.respondWithSession(c, http.StatusOK, "account linked", user)
+}
+
+// respondWithSession issues tokens for a user signing in and writes them with the user's profile, as login does
+func (h *OAuthHandler) respondWithSession(c *gin.Context, status int, message string, user *store.User) {
+	if user.Disabled {
+		c.JSON(http.StatusForbidden, gin.H{"error": "account disabled because its email address was never verified; verify it to reactivate the account"})
+		return
+	}
+
+	if err := h.UserStore.UpdateLastLogin(user.UserID); err != nil {
+		log.Printf("Failed to update last_login: %v", err)
+	}
+
+	accessToken, refreshToken, err := h.JWTService.GenerateTokenPair(user, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		log.Printf("Failed to generate token pair: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate auth tokens"})
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"message": message,
+		"tokens":  sessionTokens(c, h.JWTService, accessToken, refreshToken.Token),
+		"user":    oauthUserResponse(user),
+	})
+}
+
+func oauthUserResponse(user *store.User) gin.H {
+	return gin.H{
+		"user_id":         user.UserID,
+		"username":        user.Username,
+		"email":           user.Email,
+		"bio":             user.Bio,
+		"first_name":      user.FirstName,
+		"last_name":       user.LastName,
+		"profile_picture": user.ProfilePicture,
+		"email_verified":  user.EmailVerified,
+		"verified_chef":   user.VerifiedChef,
+		"timezone":        user.Timezone,
+		"created_at":      user.CreatedAt,
+		"last_login":      user.LastLogin,
+		"_links":          currentUserLinks(user.Username),
+	}
+}
+
+// GetMyIdentities godoc
+// @Summary List linked sign-in methods
+// @Description Returns the OAuth identities linked to the authenticated account, whether it has a password, and the providers that can still be linked
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Linked identities"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/identities [get]
+func (h *OAuthHandler) GetMyIdentities(c *gin.Context) {
+	user, identities, ok := h.getSignInMethods(c)
+	if !ok {
+		return
+	}
+
+	available := []string{}
+	for _, provider := range h.OAuthService.Providers() {
+		linked := false
+		for _, identity := range identities {
+			linked = linked || identity.Provider == provider
+		}
+		if !linked {
+			available = append(available, provider)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"has_password":        user.HasPassword,
+		"identities":          identities,
+		"available_providers": available,
+	})
+}
+
+// UnlinkIdentity godoc
+// @Summary Unlink an OAuth identity
+// @Description Removes the authenticated account's identity at a provider. The last way to sign in cannot be removed: set a password or link another provider first.
+// @Tags Users
+// @Produce json
+// @Param provider path string true "Provider" Enums(google, github)
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Identity unlinked"
+// @Failure 400 {object} map[string]string "Identity is the only way to sign in"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "No identity linked at this provider"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/identities/{provider} [delete]
+func (h *OAuthHandler) UnlinkIdentity(c *gin.Context) {
+	user, identities, ok := h.getSignInMethods(c)
+	if !ok {
+		return
+	}
+
+	provider := c.Param("provider")
+	var linked *store.UserIdentity
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			linked = identity
+		}
+	}
+	if linked == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no account linked at this provider"})
+		return
+	}
+
+	if !user.HasPassword && len(identities) == 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "this is the only way to sign in to your account; set a password or link another provider first"})
+		return
+	}
+
+	if err := h.IdentityStore.DeleteIdentity(linked.UserID, provider); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "no account linked at this provider"})
+			return
+		}
+		log.Printf("Failed to unlink identity: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to unlink account"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "account unlinked"})
+}
+
+// getSignInMethods loads the authenticated user and their linked identities
+// It writes an error response and returns false if either cannot be loaded
+func (h *OAuthHandler) getSignInMethods(c *gin.Context) (*store.User, []*store.UserIdentity, bool) {
+	userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	user, err := h.UserStore.GetUserByID(userID)
+	if err != nil {
+		log.Printf("Failed to get user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, nil, false
+	}
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+		return nil, nil, false
+	}
+
+	internalID, err := h.UserStore.GetUserInternalID(userID)
+	if err != nil {
+		log.Printf("Failed to resolve user ID %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, nil, false
+	}
+
+	identities, err := h.IdentityStore.GetIdentitiesForUser(internalID)
+	if err != nil {
+		log.Printf("Failed to get identities: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, nil, false
+	}
+
+	return user, identities, true
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// MaxPreferredCuisines caps how many cuisines a user can pick during onboarding
+	MaxPreferredCuisines = 10
+
+	// MaxCuisineLength caps the length of a single cuisine name
+	MaxCuisineLength = 50
+
+	// DefaultRecommendationLimit is the number of recommendations returned when no limit is given
+	DefaultRecommendationLimit = 20
+
+	// MaxRecommendationLimit caps how many recommendations a client can request
+	MaxRecommendationLimit = 50
+)
+
+type onboardingRequest struct {
+	Cuisines            []string `json:"cuisines"`
+	DietaryRestrictions []string `json:"dietary_restrictions"`
+	SkillLevel          *string  `json:"skill_level"`
+}
+
+// SaveOnboarding godoc
+// @Summary Save onboarding preferences
+// @Description Captures the authenticated user's cuisines of interest, dietary restrictions, and skill level. Replaces any previous answers; the preferences tailor recipe recommendations.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body onboardingRequest true "Onboarding answers"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Preferences saved"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/onboarding [post]
+func (h *UserHandler) SaveOnboarding(c *gin.Context) {
+	userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req onboardingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	prefs, errMsg := validateOnboarding(&req)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+
+	err := h.UserStore.SaveUserPreferences(userID, prefs)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to save onboarding preferences: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "preferences saved",
+		"preferences": prefs,
+	})
+}
+
+// GetOnboarding godoc
+// @Summary Get onboarding preferences
+// @Description Returns the authenticated user's onboarding preferences. onboarded_at is null until onboarding has been completed.
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Preferences"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/onboarding [get]
+func (h *UserHandler) GetOnboarding(c *gin.Context) {
+	userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.UserStore.GetUserPreferences(userID)
+	if err != nil {
+		log.Printf("Failed to get onboarding preferences: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if prefs == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preferences":            prefs,
+		"available_restrictions": store.DietaryRestrictions,
+	})
+}
+
+// validateOnboarding normalizes onboarding answers
+// Cuisines and dietary restrictions are trimmed, lowercased, and de-duplicated; it returns a non-empty error message if validation fails
+func validateOnboarding(req *onboardingRequest) (*store.UserPreferences, string) {
+	if len(req.Cuisines) > MaxPreferredCuisines {
+		return nil, fmt.Sprintf("at most %d cuisines can be selected", MaxPreferredCuisines)
+	}
+
+	prefs := &store.UserPreferences{
+		Cuisines: []string{},
+	}
+
+	seen := make(map[string]bool)
+	for i, cuisine := range req.Cuisines {
+		cuisine = strings.ToLower(strings.TrimSpace(cuisine))
+		if cuisine == "" {
+			return nil, fmt.Sprintf("cuisines[%d] is required", i)
+		}
+		if len(cuisine) > MaxCuisineLength {
+			return nil, fmt.Sprintf("cuisines[%d] must be at most %d characters", i, MaxCuisineLength)
+		}
+		if !seen[cuisine] {
+			seen[cuisine] = true
+			prefs.Cuisines = append(prefs.Cuisines, cuisine)
+		}
+	}
+
+	restrictions, errMsg := normalizeDietaryLabels(req.DietaryRestrictions, "dietary_restrictions")
+	if errMsg != "" {
+		return nil, errMsg
+	}
+	prefs.DietaryRestrictions = restrictions
+
+	if req.SkillLevel != nil {
+		skillLevel := strings.ToLower(strings.TrimSpace(*req.SkillLevel))
+		if !store.IsValidSkillLevel(skillLevel) {
+			return nil, "skill_level must be beginner, intermediate, or advanced"
+		}
+		prefs.SkillLevel = &skillLevel
+	}
+
+	return prefs, ""
+}
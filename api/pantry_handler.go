@@ -0,0 +1,184 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultCookableLimit is the number of cookable recipes returned when no limit is given
+	DefaultCookableLimit = 20
+
+	// MaxCookableLimit caps how many cookable recipes a client can request
+	MaxCookableLimit = 50
+)
+
+type PantryHandler struct {
+	PantryStore store.PantryStore
+	UserStore   store.UserStore
+}
+
+func NewPantryHandler(pantryStore store.PantryStore, userStore store.UserStore) *PantryHandler {
+	return &PantryHandler{
+		PantryStore: pantryStore,
+		UserStore:   userStore,
+	}
+}
+
+type addPantryItemRequest struct {
+	Name string `json:"name"`
+}
+
+// GetPantry godoc
+// @Summary List pantry items
+// @Description Returns the ingredients in the authenticated user's pantry
+// @Tags Pantry
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Pantry items"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/pantry [get]
+func (h *PantryHandler) GetPantry(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	items, err := h.PantryStore.GetPantryItems(userID)
+	if err != nil {
+		log.Printf("Failed to get pantry items: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+	})
+}
+
+// AddPantryItem godoc
+// @Summary Add a pantry item
+// @Description Adds an ingredient to the authenticated user's pantry
+// @Tags Pantry
+// @Accept json
+// @Produce json
+// @Param request body addPantryItemRequest true "Ingredient name"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Pantry item added"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/pantry [post]
+func (h *PantryHandler) AddPantryItem(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req addPantryItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+	if len(name) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name must be at most 255 characters"})
+		return
+	}
+
+	item, err := h.PantryStore.AddPantryItem(userID, name)
+	if err != nil {
+		log.Printf("Failed to add pantry item: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add pantry item"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "pantry item added",
+		"item":    item,
+	})
+}
+
+// RemovePantryItem godoc
+// @Summary Remove a pantry item
+// @Description Removes an ingredient from the authenticated user's pantry
+// @Tags Pantry
+// @Produce json
+// @Param ingredient_id path int true "Ingredient ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Pantry item removed"
+// @Failure 400 {object} map[string]string "Invalid ingredient ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Pantry item not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/pantry/{ingredient_id} [delete]
+func (h *PantryHandler) RemovePantryItem(c *gin.Context) {
+	ingredientID, ok := parseIDParam(c, "ingredient_id", "ingredient ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	err := h.PantryStore.RemovePantryItem(userID, ingredientID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "pantry item not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to remove pantry item: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove pantry item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "pantry item removed"})
+}
+
+// GetCookableRecipes godoc
+// @Summary Recipes I can cook
+// @Description Returns published recipes ranked by how many of their ingredients are in the authenticated user's pantry, with the missing ingredients listed
+// @Tags Pantry
+// @Produce json
+// @Param limit query int false "Maximum number of recipes (default 20, max 50)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Cookable recipes"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/cookable [get]
+func (h *PantryHandler) GetCookableRecipes(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultCookableLimit, MaxCookableLimit)
+	if !ok {
+		return
+	}
+
+	recipes, err := h.PantryStore.GetCookableRecipes(userID, limit)
+	if err != nil {
+		log.Printf("Failed to get cookable recipes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"recipes": recipes,
+	})
+}
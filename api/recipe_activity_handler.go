@@ -0,0 +1,120 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultRecipeActivityPageSize is the number of activity entries per page when no limit is given
+	DefaultRecipeActivityPageSize = 20
+
+	// MaxRecipeActivityPageSize caps how many activity entries a client can request per page
+	MaxRecipeActivityPageSize = 100
+)
+
+// GetRecipeActivity godoc
+// @Summary Recipe change feed
+// @Description Returns who changed what on one of the authenticated user's recipes and when, newest first. Updates list each changed field with its value before and after; drafts archived automatically have no actor.
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Entries per page (default 20, max 100)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Activity and pagination"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/activity [get]
+func (h *RecipeHandler) GetRecipeActivity(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultRecipeActivityPageSize, MaxRecipeActivityPageSize)
+	if !ok {
+		return
+	}
+
+	if _, ok := h.loadOwnedRecipe(c, recipeID); !ok {
+		return
+	}
+
+	activity, total, err := h.RecipeActivityStore.GetRecipeActivity(recipeID, page, limit)
+	if err != nil {
+		log.Printf("Failed to get recipe activity: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"activity":   activity,
+		"pagination": newPagination(page, limit, total),
+	})
+}
+
+// recordRecipeActivity adds a change the recipe's author just saved to its activity feed
+// The change itself has been saved already, so a failure is only logged
+func (h *RecipeHandler) recordRecipeActivity(recipe *store.Recipe, action string, changes map[string]interface{}) {
+	authorID := recipe.UserID
+	err := h.RecipeActivityStore.AddRecipeActivity(&store.RecipeActivity{
+		RecipeID: recipe.ID,
+		UserID:   &authorID,
+		Action:   action,
+		Changes:  changes,
+	})
+	if err != nil {
+		log.Printf("Failed to record %s activity for recipe %d: %v", action, recipe.ID, err)
+	}
+}
+
+// recipeActivityFields are the recipe fields whose changes are listed in update activity
+var recipeActivityFields = []string{
+	"title", "description", "category_id", "status", "difficulty_level",
+	"serving_size", "prep_time", "cook_time", "total_time", "dietary_labels",
+}
+
+// recipeFieldChanges compares the given fields of two versions of a recipe by their JSON values, omitted fields being null
+// It returns a store.RecipeFieldChange for each field that differs, keyed by field name
+func recipeFieldChanges(before, after *store.Recipe, fields []string) map[string]interface{} {
+	beforeValues := recipeJSONFields(before)
+	afterValues := recipeJSONFields(after)
+
+	changes := map[string]interface{}{}
+	for _, field := range fields {
+		from, ok := beforeValues[field]
+		if !ok {
+			from = json.RawMessage("null")
+		}
+		to, ok := afterValues[field]
+		if !ok {
+			to = json.RawMessage("null")
+		}
+		if !bytes.Equal(from, to) {
+			changes[field] = store.RecipeFieldChange{From: from, To: to}
+		}
+	}
+	return changes
+}
+
+// recipeJSONFields returns a recipe's fields as they appear in responses
+func recipeJSONFields(recipe *store.Recipe) map[string]json.RawMessage {
+	fields := map[string]json.RawMessage{}
+	if data, err := json.Marshal(recipe); err == nil {
+		_ = json.Unmarshal(data, &fields)
+	}
+	return fields
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+type setAllergenOverridesRequest struct {
+	// Overrides maps an allergen to whether the recipe contains it, e.g. {"gluten": false}
+	Overrides map[string]bool `json:"overrides"`
+}
+
+// SetRecipeAllergenOverrides godoc
+// @Summary Override inferred recipe allergens
+// @Description Replaces the allergen overrides of a recipe owned by the authenticated user. Allergens (dairy, gluten, nuts) are inferred from the ingredient catalog; true marks an allergen the catalog missed, false removes one the recipe does not contain (e.g. gluten-free flour). An empty object clears all overrides.
+// @Tags Recipes
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param request body setAllergenOverridesRequest true "Allergen overrides"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Allergen overrides updated"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/allergens [put]
+func (h *RecipeHandler) SetRecipeAllergenOverrides(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	var req setAllergenOverridesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	overrides := make(map[string]bool, len(req.Overrides))
+	for allergen, contains := range req.Overrides {
+		allergen = strings.ToLower(strings.TrimSpace(allergen))
+		if !store.IsValidAllergen(allergen) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "overrides must be chosen from: " + strings.Join(store.Allergens, ", ")})
+			return
+		}
+		overrides[allergen] = contains
+	}
+
+	before, err := h.RecipeAllergenStore.GetRecipeAllergens(recipeID)
+	if err != nil {
+		log.Printf("Failed to get recipe allergens: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	err = h.RecipeAllergenStore.SetRecipeAllergenOverrides(recipeID, overrides)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to set recipe allergen overrides: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update allergen overrides"})
+		return
+	}
+
+	from, _ := json.Marshal(before.Overrides)
+	to, _ := json.Marshal(overrides)
+	if string(from) != string(to) {
+		h.recordRecipeActivity(recipe, store.RecipeActivityUpdated, map[string]interface{}{
+			"allergen_overrides": store.RecipeFieldChange{From: from, To: to},
+		})
+	}
+
+	allergens, ok := h.loadRecipeAllergens(c, recipe)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "allergen overrides updated",
+		"allergens": allergens,
+	})
+}
+
+// loadRecipeAllergens returns a recipe's allergens with warnings for those that contradict its dietary labels
+// or, for signed-in viewers, their own dietary restrictions. It writes an error response and returns false on failure
+func (h *RecipeHandler) loadRecipeAllergens(c *gin.Context, recipe *store.Recipe) (*store.RecipeAllergens, bool) {
+	allergens, err := h.RecipeAllergenStore.GetRecipeAllergens(recipe.ID)
+	if err != nil {
+		log.Printf("Failed to get recipe allergens: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, false
+	}
+
+	var restrictions []string
+	if userID := c.GetString("user_id"); userID != "" {
+		prefs, err := h.UserStore.GetUserPreferences(userID)
+		if err != nil {
+			log.Printf("Failed to get user preferences: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return nil, false
+		}
+		if prefs != nil {
+			restrictions = prefs.DietaryRestrictions
+		}
+	}
+
+	for _, allergen := range allergens.Contains {
+		restriction := store.AllergenRestrictions[allergen]
+		if slices.Contains(recipe.DietaryLabels, restriction) {
+			allergens.Warnings = append(allergens.Warnings, fmt.Sprintf("labeled %s but contains %s", restriction, allergen))
+		}
+		if slices.Contains(restrictions, restriction) {
+			allergens.Warnings = append(allergens.Warnings, fmt.Sprintf("contains %s, which conflicts with your %s restriction", allergen, restriction))
+		}
+	}
+
+	return allergens, true
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultCookHistoryPageSize is the number of history entries per page when no limit is given
+	DefaultCookHistoryPageSize = 20
+
+	// MaxCookHistoryPageSize caps how many history entries a client can request per page
+	MaxCookHistoryPageSize = 100
+
+	// MaxCookNoteLength caps the length of a note attached to a cook
+	MaxCookNoteLength = 1000
+)
+
+type RecipeCookHandler struct {
+	RecipeCookStore store.RecipeCookStore
+	RecipeStore     store.RecipeStore
+	UserStore       store.UserStore
+}
+
+func NewRecipeCookHandler(recipeCookStore store.RecipeCookStore, recipeStore store.RecipeStore, userStore store.UserStore) *RecipeCookHandler {
+	return &RecipeCookHandler{
+		RecipeCookStore: recipeCookStore,
+		RecipeStore:     recipeStore,
+		UserStore:       userStore,
+	}
+}
+
+type recordCookRequest struct {
+	PhotoURL string `json:"photo_url"`
+	Note     string `json:"note"`
+}
+
+// RecordRecipeCook godoc
+// @Summary I made this
+// @Description Records that the authenticated user cooked a recipe, with an optional photo and note. Each call adds a new entry to the user's cooking history.
+// @Tags Cooking
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param request body recordCookRequest false "Optional photo and note"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Cook recorded"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/made [post]
+func (h *RecipeCookHandler) RecordRecipeCook(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	// The body is optional; an empty request just records the cook
+	var req recordCookRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	cook := &store.RecipeCook{RecipeID: recipeID}
+
+	if photoURL := strings.TrimSpace(req.PhotoURL); photoURL != "" {
+		if len(photoURL) > 255 || !utils.IsValidURL(photoURL) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid photo URL"})
+			return
+		}
+		cook.PhotoURL = &photoURL
+	}
+
+	if note := strings.TrimSpace(req.Note); note != "" {
+		if len(note) > MaxCookNoteLength {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "note must be at most 1000 characters"})
+			return
+		}
+		cook.Note = &note
+	}
+
+	recipe, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if !canViewRecipe(recipe, userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	if err := h.RecipeCookStore.RecordRecipeCook(userID, cook); err != nil {
+		log.Printf("Failed to record recipe cook: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record cook"})
+		return
+	}
+	cook.RecipeTitle = recipe.Title
+
+	madeCount, err := h.RecipeCookStore.CountRecipeCooks(recipeID)
+	if err != nil {
+		log.Printf("Failed to count recipe cooks: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "cook recorded",
+		"cook":       cook,
+		"made_count": madeCount,
+	})
+}
+
+// GetMyCookingHistory godoc
+// @Summary My cooking history
+// @Description Returns the recipes the authenticated user has cooked, most recent first
+// @Tags Cooking
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Entries per page (default 20, max 100)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Cooking history and pagination"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/cooked [get]
+func (h *RecipeCookHandler) GetMyCookingHistory(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultCookHistoryPageSize, MaxCookHistoryPageSize)
+	if !ok {
+		return
+	}
+
+	cooks, total, err := h.RecipeCookStore.GetUserCooks(userID, page, limit)
+	if err != nil {
+		log.Printf("Failed to get cooking history: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cooks":      cooks,
+		"pagination": newPagination(page, limit, total),
+	})
+}
+
+// GetMyCookingStats godoc
+// @Summary My cooking stats
+// @Description Returns totals, daily streaks, and weekly and monthly cook counts for the authenticated user
+// @Tags Cooking
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Cooking stats"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/cooked/stats [get]
+func (h *RecipeCookHandler) GetMyCookingStats(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	stats, err := h.RecipeCookStore.GetCookingStats(userID)
+	if err != nil {
+		log.Printf("Failed to get cooking stats: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"stats": stats,
+	})
+}
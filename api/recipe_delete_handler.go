@@ -0,0 +1,140 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultRecipeDeleteConfirmThreshold is how many favorites and reviews together make a recipe's delete need confirming
+	DefaultRecipeDeleteConfirmThreshold = 10
+
+	// RecipeDeleteConfirmationTTL is how long the author has to confirm a delete
+	RecipeDeleteConfirmationTTL = 10 * time.Minute
+)
+
+// recipeDeleteConfirmThreshold returns how many favorites and reviews together make a recipe's delete need confirming
+// RECIPE_DELETE_CONFIRM_THRESHOLD overrides the default; 0 deletes every recipe straight away
+func recipeDeleteConfirmThreshold() int {
+	if threshold := os.Getenv("RECIPE_DELETE_CONFIRM_THRESHOLD"); threshold != "" {
+		if value, err := strconv.Atoi(threshold); err == nil && value >= 0 {
+			return value
+		}
+		log.Printf("Ignoring invalid RECIPE_DELETE_CONFIRM_THRESHOLD %q", threshold)
+	}
+	return DefaultRecipeDeleteConfirmThreshold
+}
+
+// DeleteRecipe godoc
+// @Summary Delete a recipe
+// @Description Permanently deletes a recipe owned by the authenticated user, with its photos, reviews and favorites. Recipes with at least RECIPE_DELETE_CONFIRM_THRESHOLD favorites and reviews together (default 10) are deleted in two steps: the first request returns 202 with a confirmation_token, and the delete happens when the request is repeated with that token within 10 minutes. Requesting again replaces the token. Users who favorited the recipe are notified that it was removed.
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param confirmation_token query string false "Token from the first request, for recipes whose delete must be confirmed"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Recipe deleted"
+// @Success 202 {object} map[string]interface{} "Delete must be confirmed with the returned token"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 409 {object} map[string]string "Confirmation token is invalid or expired"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id} [delete]
+func (h *RecipeHandler) DeleteRecipe(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	engagement, err := h.RecipeDeletionStore.GetRecipeEngagement(recipeID)
+	if err != nil {
+		log.Printf("Failed to get recipe engagement: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if threshold := recipeDeleteConfirmThreshold(); threshold > 0 && engagement.Total() >= threshold {
+		token := c.Query("confirmation_token")
+		if token == "" {
+			newToken, expiresAt, err := h.RecipeDeletionStore.CreateDeleteConfirmation(recipeID, RecipeDeleteConfirmationTTL)
+			if err != nil {
+				log.Printf("Failed to create delete confirmation: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete recipe"})
+				return
+			}
+
+			c.JSON(http.StatusAccepted, gin.H{
+				"message":            "This recipe has favorites and reviews. Repeat the request with confirmation_token to delete it.",
+				"confirmation_token": newToken,
+				"expires_at":         expiresAt,
+				"favorite_count":     engagement.FavoriteCount,
+				"review_count":       engagement.ReviewCount,
+			})
+			return
+		}
+
+		confirmed, err := h.RecipeDeletionStore.UseDeleteConfirmation(recipeID, token)
+		if err != nil {
+			log.Printf("Failed to use delete confirmation: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete recipe"})
+			return
+		}
+		if !confirmed {
+			c.JSON(http.StatusConflict, gin.H{"error": "confirmation token is invalid or expired; request the delete again"})
+			return
+		}
+	}
+
+	// Both are lost with the recipe, so read them first
+	favoriterIDs, err := h.RecipeDeletionStore.GetRecipeFavoriterIDs(recipeID)
+	if err != nil {
+		log.Printf("Failed to get recipe favoriters: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	photos, err := h.RecipeMediaStore.GetRecipePhotos(recipeID)
+	if err != nil {
+		log.Printf("Failed to get recipe photos: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	err = h.RecipeStore.DeleteRecipe(recipeID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to delete recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete recipe"})
+		return
+	}
+
+	h.NotificationService.NotifyRecipeRemoved(recipe.UserID, recipe.AuthorUsername, recipe.Title, favoriterIDs)
+
+	// The recipe is gone either way, so a failed file delete only leaves an orphan behind
+	for _, photo := range photos {
+		if photo.StorageKey == nil {
+			continue
+		}
+		if err := h.Storage.Delete(*photo.StorageKey); err != nil {
+			log.Printf("Failed to delete stored photo %s: %v", *photo.StorageKey, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "recipe deleted"})
+}
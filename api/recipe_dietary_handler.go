@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+type setDietaryLabelsRequest struct {
+	DietaryLabels []string `json:"dietary_labels"`
+}
+
+// SetRecipeDietaryLabels godoc
+// @Summary Set recipe dietary labels
+// @Description Replaces the dietary labels (e.g. vegan, gluten_free) declared on a recipe owned by the authenticated user. Users with matching dietary restrictions only see labeled recipes by default.
+// @Tags Recipes
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param request body setDietaryLabelsRequest true "Dietary labels"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Dietary labels updated"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/dietary-labels [put]
+func (h *RecipeHandler) SetRecipeDietaryLabels(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	var req setDietaryLabelsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	labels, errMsg := normalizeDietaryLabels(req.DietaryLabels, "dietary_labels")
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+
+	err := h.RecipeStore.SetRecipeDietaryLabels(recipeID, labels)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to set recipe dietary labels: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update dietary labels"})
+		return
+	}
+
+	updated := *recipe
+	updated.DietaryLabels = labels
+	if changes := recipeFieldChanges(recipe, &updated, []string{"dietary_labels"}); len(changes) > 0 {
+		h.recordRecipeActivity(recipe, store.RecipeActivityUpdated, changes)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "dietary labels updated",
+		"dietary_labels": labels,
+	})
+}
+
+// normalizeDietaryLabels lowercases and de-duplicates dietary values, checking each is supported
+// field names the request field in the error message returned when a value is not supported
+func normalizeDietaryLabels(values []string, field string) ([]string, string) {
+	labels := []string{}
+	seen := make(map[string]bool)
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !store.IsValidDietaryRestriction(value) {
+			return nil, field + " must be chosen from: " + strings.Join(store.DietaryRestrictions, ", ")
+		}
+		if !seen[value] {
+			seen[value] = true
+			labels = append(labels, value)
+		}
+	}
+
+	return labels, ""
+}
+
+// applyDietaryPreferences restricts a listing to recipes compatible with the signed-in user's dietary restrictions
+// It does nothing for anonymous requests, when the request already filters by diet, or when ignore_preferences=true.
+// It returns the restrictions applied, or writes an error response and returns false
+func (h *RecipeHandler) applyDietaryPreferences(c *gin.Context, opts *store.RecipeListOptions) ([]string, bool) {
+	userID := c.GetString("user_id")
+	if userID == "" || len(opts.Diets) > 0 || c.Query("ignore_preferences") == "true" {
+		return []string{}, true
+	}
+
+	prefs, err := h.UserStore.GetUserPreferences(userID)
+	if err != nil {
+		log.Printf("Failed to get user preferences: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, false
+	}
+
+	if prefs == nil {
+		return []string{}, true
+	}
+
+	opts.Diets = prefs.DietaryRestrictions
+	return prefs.DietaryRestrictions, true
+}
@@ -0,0 +1,570 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RecipeFileSchema identifies a ChefShare recipe file
+	RecipeFileSchema = "chefshare.recipe"
+
+	// RecipeFileVersion is the version of the recipe file format written by exports
+	// Imports accept this version and every earlier one; bump it whenever a field changes meaning
+	RecipeFileVersion = 1
+
+	// MaxRecipeFileBytes caps the size of an imported recipe file at 1 MB
+	MaxRecipeFileBytes = 1 << 20
+
+	// MaxRecipeFileIngredients and MaxRecipeFileSteps cap the size of an imported recipe
+	MaxRecipeFileIngredients = 200
+	MaxRecipeFileSteps       = 100
+)
+
+// recipeFile is the ChefShare recipe file format, used to back up recipes and move them between accounts or instances
+// Identifiers from the exporting instance are informational only: categories and tags are matched by name on import,
+// and every other ID is assigned afresh.
+type recipeFile struct {
+	Schema      string                 `json:"schema" example:"chefshare.recipe"`
+	Version     int                    `json:"version" example:"1"`
+	ExportedAt  *time.Time             `json:"exported_at,omitempty"`
+	Source      *recipeFileSource      `json:"source,omitempty"`
+	Recipe      recipeFileRecipe       `json:"recipe"`
+	Ingredients []recipeFileIngredient `json:"ingredients"`
+	Steps       []recipeFileStep       `json:"steps"`
+	Tags        []string               `json:"tags"`
+	Photos      []recipeFilePhoto      `json:"photos"`
+}
+
+// recipeFileSource records where an exported recipe came from
+type recipeFileSource struct {
+	RecipeID    int64      `json:"recipe_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+type recipeFileRecipe struct {
+	Title           string   `json:"title"`
+	Description     string   `json:"description"`
+	Status          string   `json:"status"`
+	DifficultyLevel string   `json:"difficulty_level"`
+	Category        string   `json:"category,omitempty"`
+	ServingSize     *int     `json:"serving_size,omitempty"`
+	PrepTime        *int     `json:"prep_time,omitempty"`
+	CookTime        *int     `json:"cook_time,omitempty"`
+	DietaryLabels   []string `json:"dietary_labels"`
+}
+
+type recipeFileIngredient struct {
+	Name     string   `json:"name"`
+	Quantity *float64 `json:"quantity,omitempty"`
+	Unit     *string  `json:"unit,omitempty"`
+	Image    *string  `json:"image,omitempty"`
+}
+
+type recipeFileStep struct {
+	StepNumber        int               `json:"step_number"`
+	Instruction       string            `json:"instruction"`
+	DurationInMinutes *int              `json:"duration_in_minutes,omitempty"`
+	Timers            []store.StepTimer `json:"timers"`
+}
+
+// recipeFilePhoto is an entry in a recipe file's photo manifest
+// Uploaded photos are exported as short-lived signed links, so only photos linked from elsewhere are restored on import
+type recipeFilePhoto struct {
+	URL       string `json:"url"`
+	IsPrimary bool   `json:"is_primary"`
+	Uploaded  bool   `json:"uploaded"`
+}
+
+// newRecipeFile converts a complete recipe into the recipe file format
+// Photos must already have their URLs signed
+func newRecipeFile(complete *store.CompleteRecipe) *recipeFile {
+	recipe := complete.Recipe
+	exportedAt := time.Now().UTC().Truncate(time.Second)
+
+	file := &recipeFile{
+		Schema:     RecipeFileSchema,
+		Version:    RecipeFileVersion,
+		ExportedAt: &exportedAt,
+		Source: &recipeFileSource{
+			RecipeID:    recipe.ID,
+			CreatedAt:   recipe.CreatedAt,
+			UpdatedAt:   recipe.UpdatedAt,
+			PublishedAt: recipe.PublishedAt,
+		},
+		Recipe: recipeFileRecipe{
+			Title:           recipe.Title,
+			Description:     recipe.Description,
+			Status:          string(recipe.Status),
+			DifficultyLevel: string(recipe.DifficultyLevel),
+			ServingSize:     recipe.ServingSize,
+			PrepTime:        recipe.PrepTime,
+			CookTime:        recipe.CookTime,
+			DietaryLabels:   recipe.DietaryLabels,
+		},
+		Ingredients: make([]recipeFileIngredient, 0, len(complete.Ingredients)),
+		Steps:       make([]recipeFileStep, 0, len(complete.Steps)),
+		Tags:        make([]string, 0, len(complete.Tags)),
+		Photos:      make([]recipeFilePhoto, 0, len(complete.Photos)),
+	}
+	if recipe.CategoryName != nil {
+		file.Recipe.Category = *recipe.CategoryName
+	}
+	if file.Recipe.DietaryLabels == nil {
+		file.Recipe.DietaryLabels = []string{}
+	}
+
+	for _, ingredient := range complete.Ingredients {
+		file.Ingredients = append(file.Ingredients, recipeFileIngredient{
+			Name:     ingredient.Name,
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.Unit,
+			Image:    ingredient.Image,
+		})
+	}
+	for _, step := range complete.Steps {
+		timers := step.Timers
+		if timers == nil {
+			timers = []store.StepTimer{}
+		}
+		file.Steps = append(file.Steps, recipeFileStep{
+			StepNumber:        step.StepNumber,
+			Instruction:       step.Instruction,
+			DurationInMinutes: step.DurationInMinutes,
+			Timers:            timers,
+		})
+	}
+	for _, tag := range complete.Tags {
+		file.Tags = append(file.Tags, tag.Name)
+	}
+	for _, photo := range complete.Photos {
+		file.Photos = append(file.Photos, recipeFilePhoto{
+			URL:       photo.PhotoURL,
+			IsPrimary: photo.IsPrimary,
+			Uploaded:  photo.StorageKey != nil,
+		})
+	}
+
+	return file
+}
+
+// ExportRecipeFile godoc
+// @Summary Export a recipe as a file
+// @Description Downloads a recipe with its ingredients, steps, tags and a manifest of its photos in the versioned ChefShare recipe file format, which POST /recipes/import-file reads back. Uploaded photos are listed with short-lived signed links. Drafts and archived recipes can only be exported by their author. Exports by signed-in users count towards the daily export quota.
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param format query string false "File format; only json is supported"
+// @Success 200 {object} recipeFile "Recipe file"
+// @Failure 400 {object} map[string]string "Invalid recipe ID or format"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 429 {object} map[string]string "Daily export quota exceeded"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/export [get]
+func (h *RecipeHandler) ExportRecipeFile(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	if format := c.Query("format"); format != "" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json"})
+		return
+	}
+
+	viewerID, err := getOptionalInternalUserID(c, h.UserStore)
+	if err != nil {
+		log.Printf("Failed to resolve viewer: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	complete, err := h.RecipeStore.GetCompleteRecipe(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if complete == nil || !canViewRecipe(complete.Recipe, viewerID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	if !checkUsageQuota(c, h.UsageService, services.UsageExport) {
+		return
+	}
+
+	signPhotoURLs(h.Storage, complete.Photos)
+
+	// Photo links are signed per request, so the file must not be cached
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="recipe-%d.json"`, recipeID))
+	c.JSON(http.StatusOK, newRecipeFile(complete))
+}
+
+// ImportRecipeFile godoc
+// @Summary Import a recipe file
+// @Description Creates a recipe owned by the authenticated user from a ChefShare recipe file, sent either as the JSON request body or as a multipart upload in the file field (up to 1 MB). Categories and tags are matched by name; missing tags are created, and an unknown category is left unset. Archived recipes are imported as drafts. Photos linked from elsewhere are restored; uploaded photos cannot be, since their exported links expire. Anything skipped is listed in warnings. Counts towards the daily recipe creation limit and import quota.
+// @Tags Recipes
+// @Accept json
+// @Accept multipart/form-data
+// @Produce json
+// @Param request body recipeFile false "Recipe file"
+// @Param file formData file false "Recipe file"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Recipe imported"
+// @Failure 400 {object} map[string]string "Invalid recipe file"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 413 {object} map[string]string "Recipe file too large"
+// @Failure 429 {object} map[string]interface{} "Daily recipe creation limit or import quota reached"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/import-file [post]
+func (h *RecipeHandler) ImportRecipeFile(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	data, ok := readRecipeFile(c)
+	if !ok {
+		return
+	}
+
+	var file recipeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "recipe file is not valid JSON: " + err.Error()})
+		return
+	}
+	if file.Schema != RecipeFileSchema {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("schema must be %s", RecipeFileSchema)})
+		return
+	}
+	if file.Version < 1 || file.Version > RecipeFileVersion {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported recipe file version %d; versions 1 to %d are supported", file.Version, RecipeFileVersion)})
+		return
+	}
+
+	warnings := []string{}
+
+	req := createRecipeRequest{
+		Title:           file.Recipe.Title,
+		Description:     file.Recipe.Description,
+		Status:          file.Recipe.Status,
+		DifficultyLevel: file.Recipe.DifficultyLevel,
+		ServingSize:     file.Recipe.ServingSize,
+		PrepTime:        file.Recipe.PrepTime,
+		CookTime:        file.Recipe.CookTime,
+		DietaryLabels:   file.Recipe.DietaryLabels,
+	}
+	if req.Status == string(store.StatusArchived) {
+		req.Status = string(store.StatusDraft)
+		warnings = append(warnings, "the recipe was archived and has been imported as a draft")
+	}
+
+	if name := strings.TrimSpace(file.Recipe.Category); name != "" {
+		categoryID, err := h.findCategoryByName(name)
+		if err != nil {
+			log.Printf("Failed to get categories: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		if categoryID == nil {
+			warnings = append(warnings, fmt.Sprintf("category %q does not exist here and was left unset", name))
+		}
+		req.CategoryID = categoryID
+	}
+
+	recipe, errMsg := buildRecipeFromRequest(&req)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	recipe.UserID = userID
+
+	ingredients, errMsg := buildRecipeFileIngredients(file.Ingredients)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	steps, errMsg := buildRecipeFileSteps(file.Steps)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	tagNames, errMsg := normalizeTagNames(file.Tags)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	photos, photoWarnings := buildRecipeFilePhotos(file.Photos)
+	warnings = append(warnings, photoWarnings...)
+
+	if !checkUsageQuota(c, h.UsageService, services.UsageImport) {
+		return
+	}
+	if !checkQuota(c, h.QuotaService.CheckRecipeCreation(userID)) {
+		return
+	}
+
+	if err := h.RecipeStore.CreateCompleteRecipe(recipe, ingredients, steps); err != nil {
+		log.Printf("Failed to import recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create recipe"})
+		return
+	}
+
+	// The recipe exists from here on, so a tag or photo that cannot be saved is reported rather than failing the import
+	tags, tagWarnings := h.attachRecipeFileTags(recipe.ID, tagNames)
+	warnings = append(warnings, tagWarnings...)
+
+	for _, photo := range photos {
+		photo.RecipeID = recipe.ID
+		if err := h.RecipeMediaStore.AddRecipePhoto(photo); err != nil {
+			log.Printf("Failed to import recipe photo: %v", err)
+			warnings = append(warnings, fmt.Sprintf("photo %s could not be saved", photo.PhotoURL))
+		}
+	}
+
+	addRecipeLinks(recipe)
+	c.JSON(http.StatusCreated, gin.H{
+		"message":     "recipe imported",
+		"recipe":      recipe,
+		"ingredients": ingredients,
+		"steps":       steps,
+		"tags":        tags,
+		"photos":      photos,
+		"warnings":    warnings,
+	})
+}
+
+// readRecipeFile reads an imported recipe file from a multipart upload or the raw request body
+// It writes an error response and returns false if the file is missing or too large
+func readRecipeFile(c *gin.Context) ([]byte, bool) {
+	var reader io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+			return nil, false
+		}
+		if fileHeader.Size > MaxRecipeFileBytes {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("recipe file must be at most %d MB", MaxRecipeFileBytes>>20)})
+			return nil, false
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "could not read recipe file"})
+			return nil, false
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, MaxRecipeFileBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "could not read recipe file"})
+		return nil, false
+	}
+	if len(data) > MaxRecipeFileBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("recipe file must be at most %d MB", MaxRecipeFileBytes>>20)})
+		return nil, false
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return nil, false
+	}
+
+	return data, true
+}
+
+// findCategoryByName returns the ID of the category with the given name, ignoring case, or nil if there is none
+func (h *RecipeHandler) findCategoryByName(name string) (*int64, error) {
+	categories, err := h.RecipeTaxonomyStore.GetAllCategories(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		if strings.EqualFold(category.Name, name) {
+			return &category.ID, nil
+		}
+	}
+	return nil, nil
+}
+
+// buildRecipeFileIngredients validates a recipe file's ingredients, numbering their positions in file order
+func buildRecipeFileIngredients(items []recipeFileIngredient) ([]*store.RecipeIngredient, string) {
+	if len(items) > MaxRecipeFileIngredients {
+		return nil, fmt.Sprintf("a recipe can have at most %d ingredients", MaxRecipeFileIngredients)
+	}
+
+	ingredients := make([]*store.RecipeIngredient, 0, len(items))
+	for i, item := range items {
+		name := strings.TrimSpace(item.Name)
+		if name == "" {
+			return nil, fmt.Sprintf("ingredients[%d].name is required", i)
+		}
+		if len(name) > 255 {
+			return nil, fmt.Sprintf("ingredients[%d].name must be at most 255 characters", i)
+		}
+		if item.Quantity != nil && *item.Quantity < 0 {
+			return nil, fmt.Sprintf("ingredients[%d].quantity cannot be negative", i)
+		}
+		if item.Unit != nil && len(*item.Unit) > 50 {
+			return nil, fmt.Sprintf("ingredients[%d].unit must be at most 50 characters", i)
+		}
+		if item.Image != nil && len(*item.Image) > 255 {
+			return nil, fmt.Sprintf("ingredients[%d].image must be at most 255 characters", i)
+		}
+
+		position := i + 1
+		ingredients = append(ingredients, &store.RecipeIngredient{
+			Name:     name,
+			Quantity: item.Quantity,
+			Unit:     item.Unit,
+			Image:    item.Image,
+			Position: &position,
+		})
+	}
+
+	return ingredients, ""
+}
+
+// buildRecipeFileSteps validates a recipe file's steps and renumbers them from 1 in step_number order
+func buildRecipeFileSteps(items []recipeFileStep) ([]*store.RecipeStep, string) {
+	if len(items) > MaxRecipeFileSteps {
+		return nil, fmt.Sprintf("a recipe can have at most %d steps", MaxRecipeFileSteps)
+	}
+
+	steps := make([]*store.RecipeStep, 0, len(items))
+	for i, item := range items {
+		instruction := strings.TrimSpace(item.Instruction)
+		if instruction == "" {
+			return nil, fmt.Sprintf("steps[%d].instruction is required", i)
+		}
+		if item.DurationInMinutes != nil && *item.DurationInMinutes < 0 {
+			return nil, fmt.Sprintf("steps[%d].duration_in_minutes cannot be negative", i)
+		}
+		timers, errMsg := validateStepTimers(item.Timers)
+		if errMsg != "" {
+			return nil, fmt.Sprintf("steps[%d].%s", i, errMsg)
+		}
+
+		steps = append(steps, &store.RecipeStep{
+			StepNumber:        item.StepNumber,
+			Instruction:       instruction,
+			DurationInMinutes: item.DurationInMinutes,
+			Timers:            timers,
+		})
+	}
+
+	slices.SortStableFunc(steps, func(a, b *store.RecipeStep) int { return a.StepNumber - b.StepNumber })
+	for i, step := range steps {
+		step.StepNumber = i + 1
+	}
+
+	return steps, ""
+}
+
+// buildRecipeFilePhotos picks the photos in a recipe file's manifest that can be restored, with a warning for each one skipped
+func buildRecipeFilePhotos(items []recipeFilePhoto) ([]*store.RecipePhoto, []string) {
+	photos := []*store.RecipePhoto{}
+	warnings := []string{}
+	hasPrimary := false
+
+	for i, item := range items {
+		if item.Uploaded {
+			warnings = append(warnings, fmt.Sprintf("photos[%d] was uploaded to the original recipe and must be uploaded again", i))
+			continue
+		}
+		u, err := url.Parse(item.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			warnings = append(warnings, fmt.Sprintf("photos[%d] does not have a valid http or https URL", i))
+			continue
+		}
+		if len(photos) >= MaxPhotosPerRecipe {
+			warnings = append(warnings, fmt.Sprintf("photos[%d] was skipped; a recipe can have at most %d photos", i, MaxPhotosPerRecipe))
+			continue
+		}
+
+		isPrimary := item.IsPrimary && !hasPrimary
+		hasPrimary = hasPrimary || isPrimary
+		photos = append(photos, &store.RecipePhoto{PhotoURL: item.URL, IsPrimary: isPrimary})
+	}
+
+	if !hasPrimary && len(photos) > 0 {
+		photos[0].IsPrimary = true
+	}
+
+	return photos, warnings
+}
+
+// attachRecipeFileTags adds tags to an imported recipe by name, creating any that do not exist yet
+func (h *RecipeHandler) attachRecipeFileTags(recipeID int64, names []string) ([]*store.Tag, []string) {
+	tags := []*store.Tag{}
+	warnings := []string{}
+	if len(names) == 0 {
+		return tags, warnings
+	}
+
+	existing, err := h.RecipeTaxonomyStore.GetAllTags()
+	if err != nil {
+		log.Printf("Failed to get tags: %v", err)
+		return tags, []string{"tags could not be imported"}
+	}
+
+	for _, name := range names {
+		i := slices.IndexFunc(existing, func(tag *store.Tag) bool { return strings.EqualFold(tag.Name, name) })
+		var tag *store.Tag
+		if i >= 0 {
+			tag = existing[i]
+		} else {
+			tag, err = h.RecipeTaxonomyStore.CreateTag(name)
+			if errors.Is(err, store.ErrConflict) {
+				// Created by someone else since the tags were listed
+				tag, err = h.findTagByName(name)
+			}
+			if err != nil || tag == nil {
+				log.Printf("Failed to create tag %q: %v", name, err)
+				warnings = append(warnings, fmt.Sprintf("tag %q could not be added", name))
+				continue
+			}
+		}
+
+		if err := h.RecipeTaxonomyStore.AddRecipeTag(recipeID, tag.ID); err != nil {
+			log.Printf("Failed to add tag %q to recipe: %v", name, err)
+			warnings = append(warnings, fmt.Sprintf("tag %q could not be added", name))
+			continue
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, warnings
+}
+
+// findTagByName returns the tag with the given name, ignoring case, or nil if there is none
+func (h *RecipeHandler) findTagByName(name string) (*store.Tag, error) {
+	tags, err := h.RecipeTaxonomyStore.GetAllTags()
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		if strings.EqualFold(tag.Name, name) {
+			return tag, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+// ForkRecipe godoc
+// @Summary Fork a recipe
+// @Description Copies a recipe the authenticated user can see into a new draft they own, with its ingredients, steps, timers and tags, to adapt as their own. Photos linked from other sites are copied; uploaded photos stay with the original. Counts towards the daily recipe creation limit.
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Recipe forked"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 429 {object} map[string]interface{} "Daily recipe creation limit reached"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/fork [post]
+func (h *RecipeHandler) ForkRecipe(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	original, err := h.RecipeStore.GetCompleteRecipe(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if original == nil || !canViewRecipe(original.Recipe, userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	if !checkQuota(c, h.QuotaService.CheckRecipeCreation(userID)) {
+		return
+	}
+
+	source := original.Recipe
+	recipe := &store.Recipe{
+		Title:           source.Title,
+		Description:     source.Description,
+		UserID:          userID,
+		CategoryID:      source.CategoryID,
+		Status:          store.StatusDraft,
+		DifficultyLevel: source.DifficultyLevel,
+		ServingSize:     source.ServingSize,
+		PrepTime:        source.PrepTime,
+		CookTime:        source.CookTime,
+		TotalTime:       source.TotalTime,
+		DietaryLabels:   source.DietaryLabels,
+	}
+
+	ingredients := make([]*store.RecipeIngredient, 0, len(original.Ingredients))
+	for _, ingredient := range original.Ingredients {
+		ingredients = append(ingredients, &store.RecipeIngredient{
+			IngredientID: ingredient.IngredientID,
+			Name:         ingredient.Name,
+			Image:        ingredient.Image,
+			Quantity:     ingredient.Quantity,
+			Unit:         ingredient.Unit,
+			Position:     ingredient.Position,
+		})
+	}
+
+	steps := make([]*store.RecipeStep, 0, len(original.Steps))
+	for _, step := range original.Steps {
+		steps = append(steps, &store.RecipeStep{
+			StepNumber:        step.StepNumber,
+			Instruction:       step.Instruction,
+			DurationInMinutes: step.DurationInMinutes,
+			Timers:            step.Timers,
+		})
+	}
+
+	if err := h.RecipeStore.CreateCompleteRecipe(recipe, ingredients, steps); err != nil {
+		log.Printf("Failed to fork recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create recipe"})
+		return
+	}
+
+	// The fork exists from here on, so tags and photos that cannot be copied are logged rather than failing the request
+	tags := []*store.Tag{}
+	for _, tag := range original.Tags {
+		if err := h.RecipeTaxonomyStore.AddRecipeTag(recipe.ID, tag.ID); err != nil {
+			log.Printf("Failed to copy tag %d to forked recipe %d: %v", tag.ID, recipe.ID, err)
+			continue
+		}
+		tags = append(tags, tag)
+	}
+
+	// Uploaded files belong to the original recipe and are deleted with it, so only linked photos are copied
+	linked := []*store.RecipePhoto{}
+	hasPrimary := false
+	for _, photo := range original.Photos {
+		if photo.StorageKey != nil {
+			continue
+		}
+		linked = append(linked, &store.RecipePhoto{RecipeID: recipe.ID, PhotoURL: photo.PhotoURL, IsPrimary: photo.IsPrimary})
+		hasPrimary = hasPrimary || photo.IsPrimary
+	}
+	if !hasPrimary && len(linked) > 0 {
+		linked[0].IsPrimary = true
+	}
+
+	photos := []*store.RecipePhoto{}
+	for _, photo := range linked {
+		if err := h.RecipeMediaStore.AddRecipePhoto(photo); err != nil {
+			log.Printf("Failed to copy photo to forked recipe %d: %v", recipe.ID, err)
+			continue
+		}
+		photos = append(photos, photo)
+	}
+
+	addRecipeLinks(recipe)
+	c.JSON(http.StatusCreated, gin.H{
+		"message":     "recipe forked",
+		"recipe":      recipe,
+		"ingredients": ingredients,
+		"steps":       steps,
+		"tags":        tags,
+		"photos":      photos,
+	})
+}
@@ -0,0 +1,1036 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/middleware"
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultRecipePageSize is the number of recipes per page when no limit is given
+	DefaultRecipePageSize = 20
+
+	// MaxRecipePageSize caps how many recipes a client can request per page
+	MaxRecipePageSize = 100
+
+	// MaxRecipeQueryLength and MaxRecipeTagFilters bound the q and tag filters on an author's own recipes
+	MaxRecipeQueryLength = 200
+	MaxRecipeTagFilters  = 10
+
+	// RecentReviewWindow is how far back reviews count as recent when a rating trend is computed
+	RecentReviewWindow = 30 * 24 * time.Hour
+
+	// DefaultReviewPageSize is the number of reviews per page when no limit is given
+	DefaultReviewPageSize = 20
+
+	// MaxReviewPageSize caps how many reviews a client can request per page
+	MaxReviewPageSize = 100
+)
+
+type RecipeHandler struct {
+	RecipeStore         store.RecipeStore
+	RecipeMediaStore    store.RecipeMediaStore
+	RecipeTaxonomyStore store.RecipeTaxonomyStore
+	ReviewStore         store.ReviewStore
+	UserStore           store.UserStore
+	IngredientStore     store.IngredientStore
+	RecipeNoteStore     store.RecipeNoteStore
+	RecipeActivityStore store.RecipeActivityStore
+	RecipePreviewStore  store.RecipePreviewStore
+	RecipeDeletionStore store.RecipeDeletionStore
+	RecipeAllergenStore store.RecipeAllergenStore
+	QuotaService        *services.QuotaService
+	UsageService        *services.UsageService
+	RecipeViews         *services.RecipeViewService
+	NotificationService *services.NotificationService
+	Storage             services.Storage
+}
+
+func NewRecipeHandler(
+	recipeStore store.RecipeStore,
+	recipeMediaStore store.RecipeMediaStore,
+	recipeTaxonomyStore store.RecipeTaxonomyStore,
+	reviewStore store.ReviewStore,
+	userStore store.UserStore,
+	ingredientStore store.IngredientStore,
+	recipeNoteStore store.RecipeNoteStore,
+	recipeActivityStore store.RecipeActivityStore,
+	recipePreviewStore store.RecipePreviewStore,
+	recipeDeletionStore store.RecipeDeletionStore,
+	recipeAllergenStore store.RecipeAllergenStore,
+	quotaService *services.QuotaService,
+	usageService *services.UsageService,
+	recipeViews *services.RecipeViewService,
+	notificationService *services.NotificationService,
+	storage services.Storage,
+) *RecipeHandler {
+	return &RecipeHandler{
+		RecipeStore:         recipeStore,
+		RecipeMediaStore:    recipeMediaStore,
+		RecipeTaxonomyStore: recipeTaxonomyStore,
+		ReviewStore:         reviewStore,
+		UserStore:           userStore,
+		IngredientStore:     ingredientStore,
+		RecipeNoteStore:     recipeNoteStore,
+		RecipeActivityStore: recipeActivityStore,
+		RecipePreviewStore:  recipePreviewStore,
+		RecipeDeletionStore: recipeDeletionStore,
+		RecipeAllergenStore: recipeAllergenStore,
+		QuotaService:        quotaService,
+		UsageService:        usageService,
+		RecipeViews:         recipeViews,
+		NotificationService: notificationService,
+		Storage:             storage,
+	}
+}
+
+// Pagination describes the page of results returned by a list endpoint
+type Pagination struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+func newPagination(page, limit, total int) Pagination {
+	return Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}
+}
+
+// ratingSummaryOptions returns how recipe ratings are aggregated
+// VERIFIED_REVIEW_WEIGHT counts reviews by users who cooked the recipe that many times in the weighted average; it is off by default
+func ratingSummaryOptions() store.RatingSummaryOptions {
+	opts := store.RatingSummaryOptions{
+		RecentSince:    time.Now().Add(-RecentReviewWindow),
+		VerifiedWeight: 1,
+	}
+	if weight := os.Getenv("VERIFIED_REVIEW_WEIGHT"); weight != "" {
+		if value, err := strconv.ParseFloat(weight, 64); err == nil && value >= 1 {
+			opts.VerifiedWeight = value
+		} else {
+			log.Printf("Ignoring invalid VERIFIED_REVIEW_WEIGHT %q", weight)
+		}
+	}
+	return opts
+}
+
+// priceCurrency returns the currency ingredient prices are recorded in
+func priceCurrency() string {
+	if currency := os.Getenv("PRICE_CURRENCY"); currency != "" {
+		return currency
+	}
+	return "USD"
+}
+
+type createRecipeRequest struct {
+	Title           string `json:"title"`
+	Description     string `json:"description"`
+	CategoryID      *int64 `json:"category_id,omitempty"`
+	Status          string `json:"status"`
+	DifficultyLevel string `json:"difficulty_level"`
+	ServingSize     *int   `json:"serving_size,omitempty"`
+	PrepTime        *int   `json:"prep_time,omitempty"`
+	CookTime        *int   `json:"cook_time,omitempty"`
+
+	DietaryLabels []string `json:"dietary_labels,omitempty"`
+}
+
+type createReviewRequest struct {
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment"`
+}
+
+// CreateRecipe godoc
+// @Summary Create a recipe
+// @Description Create a new recipe owned by the authenticated user. Limited to a configurable number of recipes per day.
+// @Tags Recipes
+// @Accept json
+// @Produce json
+// @Param request body createRecipeRequest true "Recipe information"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Recipe created successfully"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 429 {object} map[string]interface{} "Daily recipe creation limit reached"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes [post]
+func (h *RecipeHandler) CreateRecipe(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req createRecipeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recipe, errMsg := buildRecipeFromRequest(&req)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	recipe.UserID = userID
+
+	// Enforce the per-user daily creation quota
+	if !checkQuota(c, h.QuotaService.CheckRecipeCreation(userID)) {
+		return
+	}
+
+	if err := h.RecipeStore.CreateRecipe(recipe); err != nil {
+		log.Printf("Failed to create recipe: %v", err)
+		if errors.Is(err, store.ErrForeignKey) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "category not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create recipe"})
+		return
+	}
+
+	addRecipeLinks(recipe)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "recipe created successfully",
+		"recipe":  recipe,
+	})
+}
+
+// GetRecipes godoc
+// @Summary List recipes
+// @Description Returns a page of published recipes with optional filters. Each recipe includes its estimated cost per serving when ingredient prices are known. Facets count the matching recipes per difficulty, category, and max_total_time limit, each ignoring its own filter. With format=csv or Accept: text/csv, all matching recipes (up to 10,000) are downloaded as CSV instead; CSV exports require sign-in.
+// @Tags Recipes
+// @Produce json
+// @Produce text/csv
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Recipes per page (default 20, max 100)"
+// @Param category_id query int false "Only recipes in this category"
+// @Param difficulty query string false "Only recipes of this difficulty (easy, medium, hard)"
+// @Param lang query string false "Only recipes written in this language, as an ISO 639-1 code (e.g. en, fr, es)"
+// @Param max_cost query number false "Only recipes whose estimated cost per serving is at most this amount"
+// @Param max_total_time query int false "Only recipes with a total time of at most this many minutes"
+// @Param min_serving_size query int false "Only recipes serving at least this many people"
+// @Param max_serving_size query int false "Only recipes serving at most this many people"
+// @Param min_rating query number false "Only recipes with an average review rating of at least this (1-5)"
+// @Param diet query string false "Comma-separated dietary labels every recipe must carry (e.g. vegan,gluten_free); defaults to the signed-in user's dietary restrictions"
+// @Param ignore_preferences query bool false "Do not apply the signed-in user's dietary restrictions"
+// @Param sort query string false "Sort order: newest (default), oldest, title, cost, updated"
+// @Param format query string false "Response format: json (default) or csv; CSV exports every matching recipe rather than one page"
+// @Success 200 {object} map[string]interface{} "Recipes, pagination, and facets"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Sign-in required for CSV exports"
+// @Failure 429 {object} map[string]string "Daily export quota reached"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes [get]
+func (h *RecipeHandler) GetRecipes(c *gin.Context) {
+	opts, ok := parseRecipeListOptions(c)
+	if !ok {
+		return
+	}
+
+	asCSV, ok := wantsCSV(c)
+	if !ok {
+		return
+	}
+
+	appliedDiets, ok := h.applyDietaryPreferences(c, &opts)
+	if !ok {
+		return
+	}
+
+	if asCSV {
+		exportRecipesCSV(c, h.RecipeStore, h.UsageService, opts, "recipes.csv")
+		return
+	}
+
+	recipes, total, err := h.RecipeStore.GetRecipes(c.Request.Context(), opts)
+	if err != nil {
+		log.Printf("Failed to list recipes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	signRecipePhotos(h.Storage, recipes)
+	addRecipeLinks(recipes...)
+
+	facets, err := h.RecipeStore.GetRecipeFacets(c.Request.Context(), opts)
+	if err != nil {
+		log.Printf("Failed to count recipe facets: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"recipes":                      recipes,
+		"pagination":                   newPagination(opts.Page, opts.Limit, total),
+		"facets":                       facets,
+		"currency":                     priceCurrency(),
+		"dietary_restrictions_applied": appliedDiets,
+	})
+}
+
+// GetMyRecipes godoc
+// @Summary List my recipes
+// @Description Returns a page of the authenticated user's own recipes, including drafts and archived recipes, optionally filtered by status, text and tags. Text matches like search does, or as part of the title. Accepts the same filters as the public recipe list. With format=csv or Accept: text/csv, all matching recipes are downloaded as CSV instead.
+// @Tags Recipes
+// @Produce json
+// @Produce text/csv
+// @Param status query string false "Only recipes with this status (draft, published, archived)"
+// @Param q query string false "Only recipes matching this text in their title or description"
+// @Param tag query string false "Only recipes with all of these comma-separated tags"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Recipes per page (default 20, max 100)"
+// @Param category_id query int false "Only recipes in this category"
+// @Param difficulty query string false "Only recipes of this difficulty (easy, medium, hard)"
+// @Param lang query string false "Only recipes written in this language, as an ISO 639-1 code (e.g. en, fr, es)"
+// @Param sort query string false "Sort order: updated (default), newest, oldest, title, cost"
+// @Param format query string false "Response format: json (default) or csv; CSV exports every matching recipe rather than one page"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Recipes and pagination"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 429 {object} map[string]string "Daily export quota reached"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/recipes [get]
+func (h *RecipeHandler) GetMyRecipes(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	opts, ok := parseRecipeListOptions(c)
+	if !ok {
+		return
+	}
+	opts.AuthorID = &userID
+
+	// Drafts have no publish date, so list recently edited recipes first unless asked otherwise
+	if c.Query("sort") == "" {
+		opts.Sort = store.SortUpdated
+	}
+
+	if statusParam := c.Query("status"); statusParam != "" {
+		status := store.RecipeStatus(statusParam)
+		if !store.IsValidRecipeStatus(status) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "status must be draft, published, or archived"})
+			return
+		}
+		opts.Status = &status
+	}
+
+	opts.Query = strings.TrimSpace(c.Query("q"))
+	if len(opts.Query) > MaxRecipeQueryLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("q must be at most %d characters", MaxRecipeQueryLength)})
+		return
+	}
+
+	for _, tag := range strings.Split(c.Query("tag"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			opts.Tags = append(opts.Tags, tag)
+		}
+	}
+	if len(opts.Tags) > MaxRecipeTagFilters {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("at most %d tags can be filtered on", MaxRecipeTagFilters)})
+		return
+	}
+
+	asCSV, ok := wantsCSV(c)
+	if !ok {
+		return
+	}
+	if asCSV {
+		exportRecipesCSV(c, h.RecipeStore, h.UsageService, opts, "my-recipes.csv")
+		return
+	}
+
+	recipes, total, err := h.RecipeStore.GetRecipes(c.Request.Context(), opts)
+	if err != nil {
+		log.Printf("Failed to list user recipes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	signRecipePhotos(h.Storage, recipes)
+	addRecipeLinks(recipes...)
+
+	c.JSON(http.StatusOK, gin.H{
+		"recipes":    recipes,
+		"pagination": newPagination(opts.Page, opts.Limit, total),
+		"currency":   priceCurrency(),
+	})
+}
+
+// GetUserRecipes godoc
+// @Summary List a user's recipes
+// @Description Returns a page of the published recipes by the user with the given username, matched case-insensitively. Accepts the same filters and sort orders as the public recipe list.
+// @Tags Recipes
+// @Produce json
+// @Param username path string true "Username"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Recipes per page (default 20, max 100)"
+// @Param category_id query int false "Only recipes in this category"
+// @Param difficulty query string false "Only recipes of this difficulty (easy, medium, hard)"
+// @Param lang query string false "Only recipes written in this language, as an ISO 639-1 code (e.g. en, fr, es)"
+// @Param sort query string false "Sort order: newest (default), oldest, title, cost"
+// @Success 200 {object} map[string]interface{} "Recipes and pagination"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/{username}/recipes [get]
+func (h *RecipeHandler) GetUserRecipes(c *gin.Context) {
+	opts, ok := parseRecipeListOptions(c)
+	if !ok {
+		return
+	}
+
+	authorID, err := h.UserStore.GetUserInternalIDByUsername(c.Param("username"))
+	if err != nil {
+		log.Printf("Failed to look up user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if authorID == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+
+	// Listing by author includes unpublished recipes unless a status is given
+	published := store.StatusPublished
+	opts.AuthorID = &authorID
+	opts.Status = &published
+
+	recipes, total, err := h.RecipeStore.GetRecipes(c.Request.Context(), opts)
+	if err != nil {
+		log.Printf("Failed to list user recipes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	signRecipePhotos(h.Storage, recipes)
+	addRecipeLinks(recipes...)
+
+	c.JSON(http.StatusOK, gin.H{
+		"recipes":    recipes,
+		"pagination": newPagination(opts.Page, opts.Limit, total),
+		"currency":   priceCurrency(),
+	})
+}
+
+// GetRandomRecipe godoc
+// @Summary Random recipe
+// @Description Returns a random published recipe, honoring the same filters as the list endpoint
+// @Tags Recipes
+// @Produce json
+// @Param category_id query int false "Only recipes in this category"
+// @Param difficulty query string false "Only recipes of this difficulty (easy, medium, hard)"
+// @Param lang query string false "Only recipes written in this language, as an ISO 639-1 code (e.g. en, fr, es)"
+// @Param max_cost query number false "Only recipes whose estimated cost per serving is at most this amount"
+// @Param max_total_time query int false "Only recipes with a total time of at most this many minutes"
+// @Param min_serving_size query int false "Only recipes serving at least this many people"
+// @Param max_serving_size query int false "Only recipes serving at most this many people"
+// @Param min_rating query number false "Only recipes with an average review rating of at least this (1-5)"
+// @Param diet query string false "Comma-separated dietary labels every recipe must carry (e.g. vegan,gluten_free); defaults to the signed-in user's dietary restrictions"
+// @Param ignore_preferences query bool false "Do not apply the signed-in user's dietary restrictions"
+// @Success 200 {object} map[string]interface{} "Random recipe"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 404 {object} map[string]string "No recipe matches the filters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/random [get]
+func (h *RecipeHandler) GetRandomRecipe(c *gin.Context) {
+	var opts store.RecipeListOptions
+	if !parseRecipeFilters(c, &opts) {
+		return
+	}
+
+	appliedDiets, ok := h.applyDietaryPreferences(c, &opts)
+	if !ok {
+		return
+	}
+
+	recipe, err := h.RecipeStore.GetRandomRecipe(c.Request.Context(), opts)
+	if err != nil {
+		log.Printf("Failed to get random recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if recipe == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no recipe matches the filters"})
+		return
+	}
+	signRecipePhotos(h.Storage, []*store.Recipe{recipe})
+	addRecipeLinks(recipe)
+
+	c.JSON(http.StatusOK, gin.H{
+		"recipe":                       recipe,
+		"currency":                     priceCurrency(),
+		"dietary_restrictions_applied": appliedDiets,
+	})
+}
+
+// GetRecipe godoc
+// @Summary Get a recipe
+// @Description Returns a recipe with its ingredients, steps, photos, tags, reviews, estimated cost, and allergens inferred from the ingredient catalog and corrected by the author. Allergen warnings flag conflicts with the recipe's dietary labels and, for signed-in users, their own dietary restrictions. Signed-in users also get their private note. Drafts are only visible to their author.
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param formatted query bool false "Add locale-formatted quantities (e.g. \"1½ cup\") based on Accept-Language"
+// @Param Accept-Language header string false "Locale used when formatted=true"
+// @Success 200 {object} map[string]interface{} "Recipe details"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id} [get]
+func (h *RecipeHandler) GetRecipe(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	viewerID, err := getOptionalInternalUserID(c, h.UserStore)
+	if err != nil {
+		log.Printf("Failed to resolve viewer: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	complete, err := h.RecipeStore.GetCompleteRecipe(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if complete == nil || !canViewRecipe(complete.Recipe, viewerID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	cost, err := h.IngredientStore.GetRecipeCost(recipeID)
+	if err != nil {
+		log.Printf("Failed to estimate recipe cost: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	complete.EstimatedCost = cost
+	complete.Recipe.EstimatedCostPerServing = cost.CostPerServing
+
+	allergens, ok := h.loadRecipeAllergens(c, complete.Recipe)
+	if !ok {
+		return
+	}
+	complete.Allergens = allergens
+
+	signPhotoURLs(h.Storage, complete.Photos)
+	addRecipeLinks(complete.Recipe)
+
+	// Private notes are only ever returned to the user who wrote them
+	if viewerID != 0 {
+		note, err := h.RecipeNoteStore.GetRecipeNote(viewerID, recipeID)
+		if err != nil {
+			log.Printf("Failed to get recipe note: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		complete.PersonalNote = note
+	}
+
+	if c.Query("formatted") == "true" {
+		locale := utils.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+		formatIngredientQuantities(complete.Ingredients, locale)
+		c.Header("Content-Language", locale)
+	}
+
+	// Authors looking at their own recipes would inflate their statistics
+	if complete.Recipe.UserID != viewerID {
+		h.RecipeViews.Record(recipeID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"recipe":   complete,
+		"currency": priceCurrency(),
+	})
+}
+
+// GetCategories godoc
+// @Summary List categories
+// @Description Returns every category by name with its number of published recipes, optionally with a representative photo
+// @Tags Recipes
+// @Produce json
+// @Param include_photos query bool false "Include a primary photo from each category's most recently published recipe"
+// @Success 200 {object} map[string]interface{} "Categories with recipe counts"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /categories [get]
+func (h *RecipeHandler) GetCategories(c *gin.Context) {
+	includePhotos := c.Query("include_photos") == "true"
+
+	categories, err := h.RecipeTaxonomyStore.GetAllCategories(includePhotos)
+	if err != nil {
+		log.Printf("Failed to get categories: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if categories == nil {
+		categories = []*store.Category{}
+	}
+	if includePhotos {
+		photos := make([]*store.RecipePhoto, 0, len(categories))
+		for _, category := range categories {
+			if category.Photo != nil {
+				photos = append(photos, category.Photo)
+			}
+		}
+		signPhotoURLs(h.Storage, photos)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+// AddRecipeReview godoc
+// @Summary Review a recipe
+// @Description Add a rating and optional comment to a recipe. Authors cannot review their own recipes. Users mentioned with @username in the comment are notified in-app and by email. Limited to a configurable number of reviews per hour.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param request body createReviewRequest true "Review information"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Review created successfully"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Recipe is the user's own"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 409 {object} map[string]string "Recipe already reviewed"
+// @Failure 429 {object} map[string]interface{} "Hourly review limit reached"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/reviews [post]
+func (h *RecipeHandler) AddRecipeReview(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req createReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Rating < 1 || req.Rating > 5 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rating must be between 1 and 5"})
+		return
+	}
+	req.Comment = strings.TrimSpace(req.Comment)
+
+	recipe, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if !canViewRecipe(recipe, userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	if recipe.UserID == userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you cannot review your own recipe"})
+		return
+	}
+
+	// Enforce the per-user hourly review quota
+	if !checkQuota(c, h.QuotaService.CheckReviewCreation(userID)) {
+		return
+	}
+
+	review, err := h.ReviewStore.AddRecipeReview(recipeID, userID, req.Rating, req.Comment)
+	if err != nil {
+		log.Printf("Failed to add review: %v", err)
+		if errors.Is(err, store.ErrConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": "you have already reviewed this recipe"})
+			return
+		}
+		if errors.Is(err, store.ErrInvalidInput) && store.ConstraintName(err) == "chk_reviews_not_own_recipe" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "you cannot review your own recipe"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add review"})
+		return
+	}
+
+	// Notify mentioned users; the review is already saved, so a failure here is only logged
+	author := &store.MentionedUser{ID: userID, Username: c.GetString("username")}
+	mentions, err := h.NotificationService.NotifyReviewMentions(author, recipe, review)
+	if err != nil {
+		log.Printf("Failed to notify review mentions: %v", err)
+		mentions = []*store.MentionedUser{}
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "review created successfully",
+		"review":   review,
+		"mentions": mentions,
+	})
+}
+
+// GetRecipeReviews godoc
+// @Summary List reviews
+// @Description Returns a recipe's reviews, newest first. Reviews by users who recorded cooking the recipe are flagged with cooked_it; pass verified=true to list only those. Drafts are only visible to their author.
+// @Tags Reviews
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param verified query bool false "Only reviews by users who cooked the recipe"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Reviews per page (default 20, max 100)"
+// @Success 200 {object} map[string]interface{} "Reviews with pagination"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/reviews [get]
+func (h *RecipeHandler) GetRecipeReviews(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultReviewPageSize, MaxReviewPageSize)
+	if !ok {
+		return
+	}
+
+	viewerID, err := getOptionalInternalUserID(c, h.UserStore)
+	if err != nil {
+		log.Printf("Failed to resolve viewer: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	recipe, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if recipe == nil || !canViewRecipe(recipe, viewerID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	reviews, total, err := h.ReviewStore.GetRecipeReviews(recipeID, store.ReviewListOptions{
+		VerifiedOnly: c.Query("verified") == "true",
+		Page:         page,
+		Limit:        limit,
+	})
+	if err != nil {
+		log.Printf("Failed to fetch reviews: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reviews":    reviews,
+		"pagination": newPagination(page, limit, total),
+	})
+}
+
+// GetRecipeReviewSummary godoc
+// @Summary Review summary
+// @Description Returns the number of reviews with each rating from 1 to 5, the average rating, and the average of the last 30 days with a trend (up, down or steady) against earlier reviews. The trend is null until both periods have at least three reviews. Reviews by users who recorded cooking the recipe are counted as verified, and when VERIFIED_REVIEW_WEIGHT is set they weigh that much more in weighted_average_rating. Drafts are only visible to their author.
+// @Tags Reviews
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Success 200 {object} store.RecipeRatingSummary "Review summary"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/reviews/summary [get]
+func (h *RecipeHandler) GetRecipeReviewSummary(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	viewerID, err := getOptionalInternalUserID(c, h.UserStore)
+	if err != nil {
+		log.Printf("Failed to resolve viewer: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	recipe, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if recipe == nil || !canViewRecipe(recipe, viewerID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	summary, err := h.ReviewStore.GetRecipeRatingSummary(recipeID, ratingSummaryOptions())
+	if err != nil {
+		log.Printf("Failed to fetch review summary: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// checkQuota writes a 429 response when a quota check fails
+// It returns true if the request may proceed
+func checkQuota(c *gin.Context, err error) bool {
+	if err == nil {
+		return true
+	}
+
+	var quotaErr *services.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		retryAfter := quotaErr.Window
+		if quotaErr.RetryAfter > 0 {
+			retryAfter = quotaErr.RetryAfter
+		}
+		resetsAt := time.Now().Add(retryAfter)
+		middleware.SetRateLimitHeaders(c, quotaErr.Limit, 0, &resetsAt)
+		c.Header("Retry-After", formatRetryAfter(retryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": quotaErr.Error(),
+			"limit": quotaErr.Limit,
+		})
+		return false
+	}
+
+	log.Printf("Failed to check quota: %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	return false
+}
+
+// formatIngredientQuantities sets a display string for each ingredient quantity, keeping the raw value alongside
+func formatIngredientQuantities(ingredients []*store.RecipeIngredient, locale string) {
+	for _, ingredient := range ingredients {
+		if ingredient.Quantity == nil {
+			continue
+		}
+		formatted := utils.FormatQuantity(*ingredient.Quantity, ingredient.Unit, locale)
+		ingredient.FormattedQuantity = &formatted
+	}
+}
+
+// parseRecipeListOptions reads recipe listing filters from the query string
+// It writes a 400 response and returns false if any filter is invalid
+func parseRecipeListOptions(c *gin.Context) (store.RecipeListOptions, bool) {
+	opts := store.RecipeListOptions{Sort: store.SortNewest}
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return opts, false
+	}
+	opts.Page = page
+
+	limit, ok := parseLimitQuery(c, DefaultRecipePageSize, MaxRecipePageSize)
+	if !ok {
+		return opts, false
+	}
+	opts.Limit = limit
+
+	if !parseRecipeFilters(c, &opts) {
+		return opts, false
+	}
+
+	if sort := c.Query("sort"); sort != "" {
+		if !store.IsValidRecipeSort(sort) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be newest, oldest, title, cost, or updated"})
+			return opts, false
+		}
+		opts.Sort = sort
+	}
+
+	return opts, true
+}
+
+// parseRecipeFilters reads the recipe filters shared by the list and random endpoints into opts
+// It writes a 400 response and returns false if any filter is invalid
+func parseRecipeFilters(c *gin.Context, opts *store.RecipeListOptions) bool {
+	if categoryParam := c.Query("category_id"); categoryParam != "" {
+		categoryID, err := strconv.ParseInt(categoryParam, 10, 64)
+		if err != nil || categoryID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category_id"})
+			return false
+		}
+		opts.CategoryID = &categoryID
+	}
+
+	if difficultyParam := c.Query("difficulty"); difficultyParam != "" {
+		difficulty := store.DifficultyLevel(difficultyParam)
+		if !store.IsValidDifficultyLevel(difficulty) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "difficulty must be easy, medium, or hard"})
+			return false
+		}
+		opts.Difficulty = &difficulty
+	}
+
+	if maxCostParam := c.Query("max_cost"); maxCostParam != "" {
+		maxCost, err := strconv.ParseFloat(maxCostParam, 64)
+		if err != nil || maxCost < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "max_cost must be a non-negative number"})
+			return false
+		}
+		opts.MaxCost = &maxCost
+	}
+
+	if maxTotalTimeParam := c.Query("max_total_time"); maxTotalTimeParam != "" {
+		maxTotalTime, err := strconv.Atoi(maxTotalTimeParam)
+		if err != nil || maxTotalTime <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "max_total_time must be a positive number of minutes"})
+			return false
+		}
+		opts.MaxTotalTime = &maxTotalTime
+	}
+
+	for _, bound := range []struct {
+		param string
+		dest  **int
+	}{
+		{"min_serving_size", &opts.MinServingSize},
+		{"max_serving_size", &opts.MaxServingSize},
+	} {
+		if value := c.Query(bound.param); value != "" {
+			servings, err := strconv.Atoi(value)
+			if err != nil || servings <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": bound.param + " must be a positive integer"})
+				return false
+			}
+			*bound.dest = &servings
+		}
+	}
+
+	if opts.MinServingSize != nil && opts.MaxServingSize != nil && *opts.MinServingSize > *opts.MaxServingSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_serving_size cannot be greater than max_serving_size"})
+		return false
+	}
+
+	if dietParam := c.Query("diet"); dietParam != "" {
+		diets, errMsg := normalizeDietaryLabels(strings.Split(dietParam, ","), "diet")
+		if errMsg != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+			return false
+		}
+		opts.Diets = diets
+	}
+
+	if minRatingParam := c.Query("min_rating"); minRatingParam != "" {
+		minRating, err := strconv.ParseFloat(minRatingParam, 64)
+		if err != nil || minRating < 1 || minRating > 5 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_rating must be between 1 and 5"})
+			return false
+		}
+		opts.MinRating = &minRating
+	}
+
+	if langParam := c.Query("lang"); langParam != "" {
+		lang := strings.ToLower(strings.TrimSpace(langParam))
+		if !utils.IsSupportedLanguage(lang) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "lang must be one of " + strings.Join(utils.SupportedLanguages, ", ")})
+			return false
+		}
+		opts.Language = lang
+	}
+
+	return true
+}
+
+// formatRetryAfter renders a duration as a Retry-After header value in seconds
+func formatRetryAfter(d time.Duration) string {
+	return strconv.Itoa(int(d.Seconds()))
+}
+
+// buildRecipeFromRequest validates a create request and converts it to a Recipe
+// It returns a non-empty error message if validation fails
+func buildRecipeFromRequest(req *createRecipeRequest) (*store.Recipe, string) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, "title is required"
+	}
+	if len(title) > 255 {
+		return nil, "title must be at most 255 characters"
+	}
+
+	status := store.RecipeStatus(strings.TrimSpace(req.Status))
+	if status == "" {
+		status = store.StatusDraft
+	}
+	if status != store.StatusDraft && status != store.StatusPublished {
+		return nil, "status must be draft or published"
+	}
+
+	difficulty := store.DifficultyLevel(strings.TrimSpace(req.DifficultyLevel))
+	if difficulty == "" {
+		difficulty = store.DifficultyEasy
+	}
+	if !store.IsValidDifficultyLevel(difficulty) {
+		return nil, "difficulty_level must be easy, medium, or hard"
+	}
+
+	if req.ServingSize != nil && *req.ServingSize <= 0 {
+		return nil, "serving_size must be positive"
+	}
+	if (req.PrepTime != nil && *req.PrepTime < 0) || (req.CookTime != nil && *req.CookTime < 0) {
+		return nil, "prep_time and cook_time cannot be negative"
+	}
+
+	dietaryLabels, errMsg := normalizeDietaryLabels(req.DietaryLabels, "dietary_labels")
+	if errMsg != "" {
+		return nil, errMsg
+	}
+
+	recipe := &store.Recipe{
+		Title:           title,
+		Description:     strings.TrimSpace(req.Description),
+		CategoryID:      req.CategoryID,
+		Status:          status,
+		DifficultyLevel: difficulty,
+		ServingSize:     req.ServingSize,
+		PrepTime:        req.PrepTime,
+		CookTime:        req.CookTime,
+		DietaryLabels:   dietaryLabels,
+	}
+
+	// Derive total time from its parts when both are known
+	if req.PrepTime != nil && req.CookTime != nil {
+		total := *req.PrepTime + *req.CookTime
+		recipe.TotalTime = &total
+	}
+
+	if status == store.StatusPublished {
+		now := time.Now()
+		recipe.PublishedAt = &now
+	}
+
+	return recipe, ""
+}
@@ -0,0 +1,79 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dapoadedire/chefshare_be/api"
+	mockstore "github.com/dapoadedire/chefshare_be/mocks/store"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/testkit"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetRecipeRejectsInvalidID(t *testing.T) {
+	handler := &api.RecipeHandler{}
+
+	c, w := testkit.NewContext(t, http.MethodGet, "/api/v1/recipes/abc", nil)
+	testkit.WithParams(c, "id", "abc")
+	handler.GetRecipe(c)
+
+	testkit.AssertStatus(t, w, http.StatusBadRequest)
+}
+
+func TestGetRecipeHidesDrafts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recipes := mockstore.NewMockRecipeStore(ctrl)
+	handler := &api.RecipeHandler{RecipeStore: recipes}
+
+	recipes.EXPECT().GetCompleteRecipe(int64(42)).Return(&store.CompleteRecipe{
+		Recipe: &store.Recipe{ID: 42, UserID: 7, Status: store.StatusDraft},
+	}, nil)
+
+	c, w := testkit.NewContext(t, http.MethodGet, "/api/v1/recipes/42", nil)
+	testkit.WithParams(c, "id", "42")
+	handler.GetRecipe(c)
+
+	testkit.AssertStatus(t, w, http.StatusNotFound)
+}
+
+func TestSetRecipeAllergenOverridesHidesOtherUsersRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	users := mockstore.NewMockUserStore(ctrl)
+	recipes := mockstore.NewMockRecipeStore(ctrl)
+	handler := &api.RecipeHandler{RecipeStore: recipes, UserStore: users}
+
+	users.EXPECT().GetUserInternalID(testkit.DefaultUser.UserID).Return(int64(7), nil)
+	recipes.EXPECT().GetRecipeByID(int64(42)).Return(&store.Recipe{ID: 42, UserID: 8}, nil)
+
+	body := map[string]interface{}{"overrides": map[string]bool{"gluten": false}}
+	c, w := testkit.NewAuthenticatedContext(t, http.MethodPut, "/api/v1/recipes/42/allergens", body, testkit.DefaultUser)
+	testkit.WithParams(c, "id", "42")
+	handler.SetRecipeAllergenOverrides(c)
+
+	testkit.AssertStatus(t, w, http.StatusNotFound)
+}
+
+func TestSetRecipeAllergenOverridesRejectsUnknownAllergens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	users := mockstore.NewMockUserStore(ctrl)
+	recipes := mockstore.NewMockRecipeStore(ctrl)
+	allergens := mockstore.NewMockRecipeAllergenStore(ctrl)
+	handler := &api.RecipeHandler{RecipeStore: recipes, UserStore: users, RecipeAllergenStore: allergens}
+
+	users.EXPECT().GetUserInternalID(testkit.DefaultUser.UserID).Return(int64(7), nil)
+	recipes.EXPECT().GetRecipeByID(int64(42)).Return(&store.Recipe{ID: 42, UserID: 7}, nil)
+
+	body := map[string]interface{}{"overrides": map[string]bool{"shellfish": true}}
+	c, w := testkit.NewAuthenticatedContext(t, http.MethodPut, "/api/v1/recipes/42/allergens", body, testkit.DefaultUser)
+	testkit.WithParams(c, "id", "42")
+	handler.SetRecipeAllergenOverrides(c)
+
+	testkit.AssertStatus(t, w, http.StatusBadRequest)
+
+	var resp map[string]string
+	testkit.DecodeJSON(t, w, &resp)
+	if resp["error"] == "" {
+		t.Fatalf("expected an error message, got %v", resp)
+	}
+}
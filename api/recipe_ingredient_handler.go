@@ -0,0 +1,75 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+type reorderIngredientsRequest struct {
+	IngredientIDs []int64 `json:"ingredient_ids"`
+}
+
+// ReorderRecipeIngredients godoc
+// @Summary Reorder recipe ingredients
+// @Description Rewrites the positions of all ingredients of a recipe owned by the authenticated user in one transaction. ingredient_ids must list every ingredient of the recipe exactly once, in the new order.
+// @Tags Recipes
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param request body reorderIngredientsRequest true "Recipe ingredient IDs in their new order"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Ingredients reordered"
+// @Failure 400 {object} map[string]string "Invalid request or ingredient IDs do not match the recipe"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/ingredients/order [put]
+func (h *RecipeHandler) ReorderRecipeIngredients(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	var req reorderIngredientsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(req.IngredientIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ingredient_ids is required"})
+		return
+	}
+
+	err := h.RecipeStore.ReorderRecipeIngredients(recipeID, req.IngredientIDs)
+	if err == store.ErrReorderMismatch {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ingredient_ids must list every ingredient of the recipe exactly once"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to reorder recipe ingredients: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reorder ingredients"})
+		return
+	}
+	h.recordRecipeActivity(recipe, store.RecipeActivityIngredientsReordered, nil)
+
+	ingredients, err := h.RecipeStore.GetRecipeIngredients(recipeID)
+	if err != nil {
+		log.Printf("Failed to get recipe ingredients: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "ingredients reordered",
+		"ingredients": ingredients,
+	})
+}
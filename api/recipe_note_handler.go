@@ -0,0 +1,185 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+// MaxRecipeNoteLength caps the length of a private recipe note
+const MaxRecipeNoteLength = 2000
+
+type RecipeNoteHandler struct {
+	RecipeNoteStore store.RecipeNoteStore
+	RecipeStore     store.RecipeStore
+	UserStore       store.UserStore
+}
+
+func NewRecipeNoteHandler(recipeNoteStore store.RecipeNoteStore, recipeStore store.RecipeStore, userStore store.UserStore) *RecipeNoteHandler {
+	return &RecipeNoteHandler{
+		RecipeNoteStore: recipeNoteStore,
+		RecipeStore:     recipeStore,
+		UserStore:       userStore,
+	}
+}
+
+type saveRecipeNoteRequest struct {
+	Note string `json:"note"`
+}
+
+// GetRecipeNote godoc
+// @Summary Get my note on a recipe
+// @Description Returns the authenticated user's private note on a recipe
+// @Tags Recipe Notes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Recipe note"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe or note not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/note [get]
+func (h *RecipeNoteHandler) GetRecipeNote(c *gin.Context) {
+	recipeID, userID, ok := h.resolveViewableRecipe(c)
+	if !ok {
+		return
+	}
+
+	note, err := h.RecipeNoteStore.GetRecipeNote(userID, recipeID)
+	if err != nil {
+		log.Printf("Failed to get recipe note: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if note == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "note not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"note": note,
+	})
+}
+
+// SaveRecipeNote godoc
+// @Summary Save my note on a recipe
+// @Description Creates or replaces the authenticated user's private note on a recipe they can view
+// @Tags Recipe Notes
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param request body saveRecipeNoteRequest true "Note text"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Note saved"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/note [put]
+func (h *RecipeNoteHandler) SaveRecipeNote(c *gin.Context) {
+	recipeID, userID, ok := h.resolveViewableRecipe(c)
+	if !ok {
+		return
+	}
+
+	var req saveRecipeNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	text := strings.TrimSpace(req.Note)
+	if text == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "note is required"})
+		return
+	}
+	if len(text) > MaxRecipeNoteLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "note must be at most 2000 characters"})
+		return
+	}
+
+	note, err := h.RecipeNoteStore.SaveRecipeNote(userID, recipeID, text)
+	if err != nil {
+		log.Printf("Failed to save recipe note: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save note"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "note saved",
+		"note":    note,
+	})
+}
+
+// DeleteRecipeNote godoc
+// @Summary Delete my note on a recipe
+// @Description Removes the authenticated user's private note on a recipe
+// @Tags Recipe Notes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Note deleted"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Note not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/note [delete]
+func (h *RecipeNoteHandler) DeleteRecipeNote(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	// Notes stay deletable even if the recipe has since been unpublished
+	err := h.RecipeNoteStore.DeleteRecipeNote(userID, recipeID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "note not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to delete recipe note: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete note"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "note deleted"})
+}
+
+// resolveViewableRecipe parses the recipe ID and checks the authenticated user can view the recipe
+// It writes an error response and returns false if the recipe is missing or not visible
+func (h *RecipeNoteHandler) resolveViewableRecipe(c *gin.Context) (int64, int64, bool) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return 0, 0, false
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return 0, 0, false
+	}
+
+	recipe, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return 0, 0, false
+	}
+
+	if !canViewRecipe(recipe, userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return 0, 0, false
+	}
+
+	return recipeID, userID, true
+}
@@ -0,0 +1,387 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// mergePatchContentType is the media type of a JSON Merge Patch (RFC 7396) document
+	mergePatchContentType = "application/merge-patch+json"
+
+	// MaxRecipePatchBytes caps the size of a recipe merge patch at 64 KB
+	MaxRecipePatchBytes = 64 << 10
+)
+
+// recipePatchFields lists the recipe fields a merge patch may change
+var recipePatchFields = map[string]bool{
+	"title":            true,
+	"description":      true,
+	"category_id":      true,
+	"status":           true,
+	"difficulty_level": true,
+	"serving_size":     true,
+	"prep_time":        true,
+	"cook_time":        true,
+	"dietary_labels":   true,
+}
+
+// PatchRecipe godoc
+// @Summary Partially update a recipe
+// @Description Applies a JSON Merge Patch (RFC 7396) to one of the authenticated user's recipes. To avoid overwriting someone else's changes, send the version the edit is based on, either as a version field in the patch or as the recipe's ETag in If-Match; if the recipe has changed since, nothing is saved and 409 is returned with the current recipe. Omitted fields are left unchanged and fields set to null are cleared: category_id, serving_size, prep_time and cook_time become unset, description becomes empty and dietary_labels becomes an empty list. title, status and difficulty_level cannot be null. Total time is recalculated when prep_time or cook_time changes, and the recipe is stamped as published the first time its status becomes published.
+// @Tags Recipes
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param If-Match header string false "ETag of the recipe version the patch is based on, e.g. \"3\""
+// @Param request body object true "Merge patch with any of title, description, category_id, status, difficulty_level, serving_size, prep_time, cook_time, dietary_labels, and optionally the version it is based on"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Recipe updated successfully"
+// @Failure 400 {object} map[string]string "Invalid patch"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 409 {object} map[string]interface{} "Recipe changed since the given version"
+// @Failure 413 {object} map[string]string "Patch too large"
+// @Failure 415 {object} map[string]string "Unsupported content type"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id} [patch]
+func (h *RecipeHandler) PatchRecipe(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	if contentType := c.ContentType(); contentType != mergePatchContentType && contentType != "application/json" {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "content type must be " + mergePatchContentType + " or application/json"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxRecipePatchBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("patch must be at most %d KB", MaxRecipePatchBytes>>10)})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+		return
+	}
+
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "request body must be a JSON object"})
+		return
+	}
+
+	expectedVersion, ok := parseExpectedRecipeVersion(c, patch)
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	if expectedVersion != nil && *expectedVersion != recipe.Version {
+		respondRecipeVersionConflict(c, recipe)
+		return
+	}
+
+	// An empty patch changes nothing, so leave updated_at alone
+	if len(patch) == 0 {
+		addRecipeLinks(recipe)
+		c.Header("ETag", recipeETag(recipe))
+		c.JSON(http.StatusOK, gin.H{
+			"message": "recipe updated successfully",
+			"recipe":  recipe,
+		})
+		return
+	}
+
+	before := *recipe
+	if errMsg := applyRecipePatch(recipe, patch); errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+
+	if err := h.RecipeStore.UpdateRecipe(recipe); err != nil {
+		log.Printf("Failed to update recipe: %v", err)
+		switch {
+		case errors.Is(err, store.ErrVersionMismatch):
+			// Another edit landed between loading the recipe and saving it
+			current, err := h.RecipeStore.GetRecipeByID(recipeID)
+			if err != nil || current == nil {
+				c.JSON(http.StatusConflict, gin.H{"error": "recipe was changed by another edit; reload it and reapply your changes"})
+				return
+			}
+			respondRecipeVersionConflict(c, current)
+		case errors.Is(err, store.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		case errors.Is(err, store.ErrForeignKey):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "category not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update recipe"})
+		}
+		return
+	}
+
+	// Reload to pick up the category name and new updated_at
+	updated, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil || updated == nil {
+		log.Printf("Failed to reload recipe %d after update: %v", recipeID, err)
+		updated = recipe
+	}
+	if changes := recipeFieldChanges(&before, updated, recipeActivityFields); len(changes) > 0 {
+		h.recordRecipeActivity(updated, store.RecipeActivityUpdated, changes)
+	}
+	addRecipeLinks(updated)
+	c.Header("ETag", recipeETag(updated))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "recipe updated successfully",
+		"recipe":  updated,
+	})
+}
+
+// recipeETag is the entity tag of a recipe's current version
+// Only responses that are the recipe row itself carry it: ingredients, steps, photos and notes change without bumping the version.
+func recipeETag(recipe *store.Recipe) string {
+	return `"` + strconv.Itoa(recipe.Version) + `"`
+}
+
+// parseExpectedRecipeVersion reads the recipe version an edit is based on from the If-Match header, or else from
+// a version field in the patch, which is removed so it is not applied. Returns nil when neither is sent (or If-Match is *),
+// in which case the edit is applied to whatever version is current
+// It writes a 400 response and returns false if the version is invalid
+func parseExpectedRecipeVersion(c *gin.Context, patch map[string]json.RawMessage) (*int, bool) {
+	raw, inPatch := patch["version"]
+	delete(patch, "version")
+
+	if ifMatch := strings.TrimSpace(c.GetHeader("If-Match")); ifMatch != "" {
+		if ifMatch == "*" {
+			return nil, true
+		}
+		// If-Match uses strong comparison, which a weak tag never satisfies
+		if strings.HasPrefix(ifMatch, "W/") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "If-Match must be a strong recipe ETag such as \"3\""})
+			return nil, false
+		}
+		version, err := strconv.Atoi(strings.Trim(ifMatch, `"`))
+		if err != nil || version <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "If-Match must be a recipe ETag such as \"3\""})
+			return nil, false
+		}
+		return &version, true
+	}
+
+	if !inPatch {
+		return nil, true
+	}
+	var version int
+	if json.Unmarshal(raw, &version) != nil || version <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "version must be a positive integer"})
+		return nil, false
+	}
+	return &version, true
+}
+
+// respondRecipeVersionConflict rejects an edit based on an outdated version, returning the current recipe to merge with
+func respondRecipeVersionConflict(c *gin.Context, current *store.Recipe) {
+	addRecipeLinks(current)
+	c.Header("ETag", recipeETag(current))
+	c.JSON(http.StatusConflict, gin.H{
+		"error":  fmt.Sprintf("recipe was changed since you loaded it and is now at version %d; reload it and reapply your changes", current.Version),
+		"recipe": current,
+	})
+}
+
+// RestoreRecipe godoc
+// @Summary Restore an archived recipe
+// @Description Turns an archived recipe owned by the authenticated user back into a draft, including drafts archived automatically after going unedited for too long. Publish it again by setting its status.
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Recipe restored"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 409 {object} map[string]string "Recipe is not archived"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/restore [post]
+func (h *RecipeHandler) RestoreRecipe(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	if recipe.Status != store.StatusArchived {
+		c.JSON(http.StatusConflict, gin.H{"error": "recipe is not archived"})
+		return
+	}
+
+	err := h.RecipeStore.RestoreRecipe(recipeID)
+	if errors.Is(err, store.ErrNotFound) {
+		// Restored or deleted by a concurrent request
+		c.JSON(http.StatusConflict, gin.H{"error": "recipe is not archived"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to restore recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to restore recipe"})
+		return
+	}
+
+	restored, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil || restored == nil {
+		log.Printf("Failed to reload recipe %d after restore: %v", recipeID, err)
+		recipe.Status = store.StatusDraft
+		recipe.ArchivedAt = nil
+		restored = recipe
+	}
+	h.recordRecipeActivity(restored, store.RecipeActivityRestored, nil)
+	addRecipeLinks(restored)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "recipe restored as a draft",
+		"recipe":  restored,
+	})
+}
+
+// applyRecipePatch merges patch into recipe, validating each field it sets
+// It returns a non-empty error message if the patch is invalid, leaving recipe partially updated
+func applyRecipePatch(recipe *store.Recipe, patch map[string]json.RawMessage) string {
+	fields := make([]string, 0, len(patch))
+	for field := range patch {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if !recipePatchFields[field] {
+			return fmt.Sprintf("%s cannot be patched", field)
+		}
+	}
+
+	if raw, ok := patch["title"]; ok {
+		var title string
+		if isJSONNull(raw) || json.Unmarshal(raw, &title) != nil || strings.TrimSpace(title) == "" {
+			return "title must be a non-empty string"
+		}
+		title = strings.TrimSpace(title)
+		if len(title) > 255 {
+			return "title must be at most 255 characters"
+		}
+		recipe.Title = title
+	}
+
+	if raw, ok := patch["description"]; ok {
+		var description *string
+		if json.Unmarshal(raw, &description) != nil {
+			return "description must be a string or null"
+		}
+		recipe.Description = ""
+		if description != nil {
+			recipe.Description = strings.TrimSpace(*description)
+		}
+	}
+
+	if raw, ok := patch["category_id"]; ok {
+		var categoryID *int64
+		if json.Unmarshal(raw, &categoryID) != nil || (categoryID != nil && *categoryID <= 0) {
+			return "category_id must be a positive integer or null"
+		}
+		recipe.CategoryID = categoryID
+	}
+
+	if raw, ok := patch["status"]; ok {
+		var status string
+		if isJSONNull(raw) || json.Unmarshal(raw, &status) != nil || !store.IsValidRecipeStatus(store.RecipeStatus(strings.TrimSpace(status))) {
+			return "status must be draft, published, or archived"
+		}
+		recipe.Status = store.RecipeStatus(strings.TrimSpace(status))
+	}
+
+	if raw, ok := patch["difficulty_level"]; ok {
+		var difficulty string
+		if isJSONNull(raw) || json.Unmarshal(raw, &difficulty) != nil || !store.IsValidDifficultyLevel(store.DifficultyLevel(strings.TrimSpace(difficulty))) {
+			return "difficulty_level must be easy, medium, or hard"
+		}
+		recipe.DifficultyLevel = store.DifficultyLevel(strings.TrimSpace(difficulty))
+	}
+
+	if raw, ok := patch["serving_size"]; ok {
+		var servingSize *int
+		if json.Unmarshal(raw, &servingSize) != nil || (servingSize != nil && *servingSize <= 0) {
+			return "serving_size must be a positive integer or null"
+		}
+		recipe.ServingSize = servingSize
+	}
+
+	_, prepPatched := patch["prep_time"]
+	_, cookPatched := patch["cook_time"]
+	if prepPatched {
+		var prepTime *int
+		if json.Unmarshal(patch["prep_time"], &prepTime) != nil || (prepTime != nil && *prepTime < 0) {
+			return "prep_time must be a non-negative integer or null"
+		}
+		recipe.PrepTime = prepTime
+	}
+	if cookPatched {
+		var cookTime *int
+		if json.Unmarshal(patch["cook_time"], &cookTime) != nil || (cookTime != nil && *cookTime < 0) {
+			return "cook_time must be a non-negative integer or null"
+		}
+		recipe.CookTime = cookTime
+	}
+	if prepPatched || cookPatched {
+		// Total time is derived from its parts, so it is only known when both are
+		recipe.TotalTime = nil
+		if recipe.PrepTime != nil && recipe.CookTime != nil {
+			total := *recipe.PrepTime + *recipe.CookTime
+			recipe.TotalTime = &total
+		}
+	}
+
+	if raw, ok := patch["dietary_labels"]; ok {
+		var values []string
+		if json.Unmarshal(raw, &values) != nil {
+			return "dietary_labels must be a list of strings or null"
+		}
+		labels, errMsg := normalizeDietaryLabels(values, "dietary_labels")
+		if errMsg != "" {
+			return errMsg
+		}
+		recipe.DietaryLabels = labels
+	}
+
+	if recipe.Status == store.StatusPublished && recipe.PublishedAt == nil {
+		now := time.Now()
+		recipe.PublishedAt = &now
+	}
+
+	return ""
+}
+
+// isJSONNull reports whether raw is the JSON literal null
+func isJSONNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
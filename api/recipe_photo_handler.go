@@ -0,0 +1,505 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxRecipePhotoBytes caps the size of an uploaded recipe photo at 5 MB
+	MaxRecipePhotoBytes = 5 << 20
+
+	// MaxPhotosPerRecipe caps how many photos a recipe can have
+	MaxPhotosPerRecipe = 10
+)
+
+// recipePhotoExtensions maps the accepted photo content types to file extensions
+var recipePhotoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// recipePhotoVariants are the resized versions returned for uploaded photos when the storage backend can transform images
+var recipePhotoVariants = map[string]services.ImageTransform{
+	"thumbnail": {Width: 200, Height: 200, Crop: "fill", Quality: "auto", Format: "auto"},
+	"card":      {Width: 600, Height: 400, Crop: "fill", Quality: "auto", Format: "auto"},
+	"large":     {Width: 1600, Crop: "limit", Quality: "auto", Format: "auto"},
+}
+
+// UploadRecipePhoto godoc
+// @Summary Upload a recipe photo
+// @Description Uploads a JPEG, PNG or WebP photo (up to 5 MB) to a recipe owned by the authenticated user. The first photo becomes the primary photo. Photo URLs in responses are short-lived signed links, or CDN links when CDN_BASE_URL is set; with the Cloudinary backend each photo also has thumbnail, card and large variants.
+// @Tags Recipes
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param photo formData file true "Photo file"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Photo uploaded"
+// @Failure 400 {object} map[string]string "Invalid or missing photo"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 413 {object} map[string]string "Photo too large"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/photos [post]
+func (h *RecipeHandler) UploadRecipePhoto(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	upload, ok := openRecipePhotoUpload(c)
+	if !ok {
+		return
+	}
+	defer upload.file.Close()
+
+	photos, err := h.RecipeMediaStore.GetRecipePhotos(recipeID)
+	if err != nil {
+		log.Printf("Failed to get recipe photos: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if len(photos) >= MaxPhotosPerRecipe {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("a recipe can have at most %d photos", MaxPhotosPerRecipe)})
+		return
+	}
+
+	key, ok := h.storeRecipePhotoUpload(c, recipeID, upload)
+	if !ok {
+		return
+	}
+
+	photo := &store.RecipePhoto{
+		RecipeID:   recipeID,
+		IsPrimary:  len(photos) == 0,
+		StorageKey: &key,
+	}
+	if err := h.RecipeMediaStore.AddRecipePhoto(photo); err != nil {
+		log.Printf("Failed to add recipe photo: %v", err)
+		if err := h.Storage.Delete(key); err != nil {
+			log.Printf("Failed to clean up stored photo %s: %v", key, err)
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to upload photo"})
+		return
+	}
+	h.recordRecipeActivity(recipe, store.RecipeActivityPhotoAdded, map[string]interface{}{"photo_id": photo.ID})
+
+	signPhotoURLs(h.Storage, []*store.RecipePhoto{photo})
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "photo uploaded",
+		"photo":   photo,
+	})
+}
+
+type reorderPhotosRequest struct {
+	PhotoIDs []int64 `json:"photo_ids"`
+}
+
+// ReorderRecipePhotos godoc
+// @Summary Reorder recipe photos
+// @Description Rewrites the gallery positions of all photos of a recipe owned by the authenticated user in one transaction. photo_ids must list every photo of the recipe exactly once, in the new order. Photos are always returned in this order; the primary photo keeps its primary status wherever it is placed.
+// @Tags Recipes
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param request body reorderPhotosRequest true "Photo IDs in their new order"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Photos reordered"
+// @Failure 400 {object} map[string]string "Invalid request or photo IDs do not match the recipe"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/photos/order [put]
+func (h *RecipeHandler) ReorderRecipePhotos(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	var req reorderPhotosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(req.PhotoIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "photo_ids is required"})
+		return
+	}
+
+	err := h.RecipeMediaStore.ReorderRecipePhotos(recipeID, req.PhotoIDs)
+	if err == store.ErrReorderMismatch {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "photo_ids must list every photo of the recipe exactly once"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to reorder recipe photos: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reorder photos"})
+		return
+	}
+	h.recordRecipeActivity(recipe, store.RecipeActivityPhotosReordered, nil)
+
+	photos, err := h.RecipeMediaStore.GetRecipePhotos(recipeID)
+	if err != nil {
+		log.Printf("Failed to get recipe photos: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	signPhotoURLs(h.Storage, photos)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "photos reordered",
+		"photos":  photos,
+	})
+}
+
+// ReplaceRecipePhoto godoc
+// @Summary Replace a recipe photo
+// @Description Replaces the file behind one of the authenticated user's recipe photos with a new JPEG, PNG or WebP upload (up to 5 MB), keeping the photo's ID and primary status. The new file gets a new URL, so caches and CDNs never serve the old image; the old file is deleted.
+// @Tags Recipes
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param photo_id path int true "Photo ID"
+// @Param photo formData file true "Photo file"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Photo replaced"
+// @Failure 400 {object} map[string]string "Invalid ID or missing photo"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe or photo not found"
+// @Failure 413 {object} map[string]string "Photo too large"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/photos/{photo_id} [put]
+func (h *RecipeHandler) ReplaceRecipePhoto(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	photoID, ok := parseIDParam(c, "photo_id", "photo ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	photo, err := h.RecipeMediaStore.GetRecipePhotoByID(photoID)
+	if err != nil {
+		log.Printf("Failed to get recipe photo: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if photo == nil || photo.RecipeID != recipeID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "photo not found"})
+		return
+	}
+
+	upload, ok := openRecipePhotoUpload(c)
+	if !ok {
+		return
+	}
+	defer upload.file.Close()
+
+	// A fresh key rather than overwriting the old file gives the photo a new URL
+	key, ok := h.storeRecipePhotoUpload(c, recipeID, upload)
+	if !ok {
+		return
+	}
+
+	err = h.RecipeMediaStore.ReplaceRecipePhotoFile(photoID, recipeID, key)
+	if err != nil {
+		if err := h.Storage.Delete(key); err != nil {
+			log.Printf("Failed to clean up stored photo %s: %v", key, err)
+		}
+		if errors.Is(err, store.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "photo not found"})
+			return
+		}
+		log.Printf("Failed to replace recipe photo: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to replace photo"})
+		return
+	}
+	h.recordRecipeActivity(recipe, store.RecipeActivityPhotoReplaced, map[string]interface{}{"photo_id": photoID})
+
+	// The row points at the new file either way, so a failed delete only leaves an orphan behind
+	if photo.StorageKey != nil {
+		if err := h.Storage.Delete(*photo.StorageKey); err != nil {
+			log.Printf("Failed to delete stored photo %s: %v", *photo.StorageKey, err)
+		}
+	}
+
+	photo.StorageKey = &key
+	photo.PhotoURL = ""
+	signPhotoURLs(h.Storage, []*store.RecipePhoto{photo})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "photo replaced",
+		"photo":   photo,
+	})
+}
+
+// recipePhotoUpload is an uploaded photo file whose contents have been checked
+type recipePhotoUpload struct {
+	file        multipart.File
+	size        int64
+	contentType string
+	extension   string
+}
+
+// openRecipePhotoUpload opens the photo file of a multipart request and checks its size and type
+// It writes an error response and returns false if the file is missing, too large or not a supported image.
+// The caller must close the file.
+func openRecipePhotoUpload(c *gin.Context) (*recipePhotoUpload, bool) {
+	fileHeader, err := c.FormFile("photo")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "photo file is required"})
+		return nil, false
+	}
+	if fileHeader.Size > MaxRecipePhotoBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("photo must be at most %d MB", MaxRecipePhotoBytes>>20)})
+		return nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "could not read photo"})
+		return nil, false
+	}
+
+	// Trust the file's contents rather than the client-supplied content type
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		file.Close()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "could not read photo"})
+		return nil, false
+	}
+	contentType := http.DetectContentType(head[:n])
+	extension, ok := recipePhotoExtensions[contentType]
+	if !ok {
+		file.Close()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "photo must be a JPEG, PNG or WebP image"})
+		return nil, false
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		log.Printf("Failed to rewind uploaded photo: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, false
+	}
+
+	return &recipePhotoUpload{file: file, size: fileHeader.Size, contentType: contentType, extension: extension}, true
+}
+
+// storeRecipePhotoUpload puts an uploaded photo in storage under a new key and returns the key
+// It writes an error response and returns false if storing fails
+func (h *RecipeHandler) storeRecipePhotoUpload(c *gin.Context, recipeID int64, upload *recipePhotoUpload) (string, bool) {
+	key := fmt.Sprintf("recipes/%d/%s%s", recipeID, uuid.NewString(), upload.extension)
+	if err := h.Storage.Put(key, upload.file, upload.size, upload.contentType); err != nil {
+		log.Printf("Failed to store recipe photo: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to upload photo"})
+		return "", false
+	}
+	return key, true
+}
+
+// GetRecipePhotos godoc
+// @Summary List a recipe's photos
+// @Description Returns a recipe's photos in the order set by their author. Uploaded photos have short-lived signed URLs, or CDN URLs when CDN_BASE_URL is set. Drafts and archived recipes are only visible to their author.
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Success 200 {object} map[string]interface{} "Recipe photos"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/photos [get]
+func (h *RecipeHandler) GetRecipePhotos(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	viewerID, err := getOptionalInternalUserID(c, h.UserStore)
+	if err != nil {
+		log.Printf("Failed to resolve viewer: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	recipe, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if !canViewRecipe(recipe, viewerID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	photos, err := h.RecipeMediaStore.GetRecipePhotos(recipeID)
+	if err != nil {
+		log.Printf("Failed to get recipe photos: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if photos == nil {
+		photos = []*store.RecipePhoto{}
+	}
+	signPhotoURLs(h.Storage, photos)
+
+	c.JSON(http.StatusOK, gin.H{
+		"photos": photos,
+	})
+}
+
+// DeleteRecipePhoto godoc
+// @Summary Delete a recipe photo
+// @Description Removes a photo from a recipe owned by the authenticated user and deletes the uploaded file
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param photo_id path int true "Photo ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Photo deleted"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe or photo not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/photos/{photo_id} [delete]
+func (h *RecipeHandler) DeleteRecipePhoto(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	photoID, ok := parseIDParam(c, "photo_id", "photo ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	photo, err := h.RecipeMediaStore.GetRecipePhotoByID(photoID)
+	if err != nil {
+		log.Printf("Failed to get recipe photo: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if photo == nil || photo.RecipeID != recipeID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "photo not found"})
+		return
+	}
+
+	err = h.RecipeMediaStore.DeleteRecipePhoto(photoID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "photo not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to delete recipe photo: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete photo"})
+		return
+	}
+	h.recordRecipeActivity(recipe, store.RecipeActivityPhotoRemoved, map[string]interface{}{"photo_id": photoID})
+
+	// The row is gone either way, so a failed file delete only leaves an orphan behind
+	if photo.StorageKey != nil {
+		if err := h.Storage.Delete(*photo.StorageKey); err != nil {
+			log.Printf("Failed to delete stored photo %s: %v", *photo.StorageKey, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "photo deleted"})
+}
+
+// photoCDNBaseURL returns the CDN that serves uploaded photos, from CDN_BASE_URL, or "" to serve them from storage
+// The CDN must serve each file in the storage backend at CDN_BASE_URL/<storage key>
+func photoCDNBaseURL() string {
+	return strings.TrimRight(os.Getenv("CDN_BASE_URL"), "/")
+}
+
+// photoURL returns the link to an uploaded photo: its CDN URL when a CDN is configured, otherwise a signed link valid for ttl
+// CDN URLs do not expire; they change whenever a photo is replaced, since every upload gets a new key.
+// Backends that transform images, such as Cloudinary, deliver through their own CDN and always sign.
+func photoURL(storage services.Storage, key string, ttl time.Duration) (string, error) {
+	_, canTransform := storage.(services.ImageTransformer)
+	if base := photoCDNBaseURL(); base != "" && !canTransform {
+		return base + "/" + key, nil
+	}
+	return storage.SignedURL(key, ttl)
+}
+
+// signPhotoURLs replaces the URL of each uploaded photo with a short-lived signed link, or its CDN URL,
+// and adds resized variants when the storage backend supports them. Photos added by external URL are left unchanged
+func signPhotoURLs(storage services.Storage, photos []*store.RecipePhoto) {
+	transformer, canTransform := storage.(services.ImageTransformer)
+	for _, photo := range photos {
+		if photo.StorageKey == nil {
+			continue
+		}
+
+		signedURL, err := photoURL(storage, *photo.StorageKey, 0)
+		if err != nil {
+			log.Printf("Failed to sign photo URL for %s: %v", *photo.StorageKey, err)
+			continue
+		}
+		photo.PhotoURL = signedURL
+
+		if !canTransform {
+			continue
+		}
+		photo.Variants = make(map[string]string, len(recipePhotoVariants))
+		for name, transform := range recipePhotoVariants {
+			variantURL, err := transformer.TransformedURL(*photo.StorageKey, transform)
+			if err != nil {
+				log.Printf("Failed to build %s variant for %s: %v", name, *photo.StorageKey, err)
+				continue
+			}
+			photo.Variants[name] = variantURL
+		}
+	}
+}
+
+// signRecipePhotos signs the primary photo of each recipe in a listing
+func signRecipePhotos(storage services.Storage, recipes []*store.Recipe) {
+	photos := make([]*store.RecipePhoto, 0, len(recipes))
+	for _, recipe := range recipes {
+		if recipe.PrimaryPhoto != nil {
+			photos = append(photos, recipe.PrimaryPhoto)
+		}
+	}
+	signPhotoURLs(storage, photos)
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPreviewLinkHours is how long a preview link works when no expiry is given
+	DefaultPreviewLinkHours = 72
+
+	// MaxPreviewLinkHours caps how long a preview link can work
+	MaxPreviewLinkHours = 30 * 24
+
+	// MaxPreviewLinksPerRecipe caps how many working preview links a recipe can have
+	MaxPreviewLinksPerRecipe = 20
+)
+
+type createPreviewLinkRequest struct {
+	ExpiresInHours *int `json:"expires_in_hours"`
+}
+
+// CreateRecipePreviewLink godoc
+// @Summary Create a draft preview link
+// @Description Creates a temporary link to one of the authenticated user's drafts that lets anyone holding it read the recipe without signing in, for example to ask editors or friends for feedback before publishing. The token is shown once. Links expire after expires_in_hours (default 72, max 720) or when revoked.
+// @Tags Recipes
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param request body createPreviewLinkRequest false "Link expiry"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Preview link with its token and URL"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 409 {object} map[string]string "Recipe is not a draft"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/preview-links [post]
+func (h *RecipeHandler) CreateRecipePreviewLink(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	// The body is optional, so an empty one keeps the default expiry
+	var req createPreviewLinkRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	hours := DefaultPreviewLinkHours
+	if req.ExpiresInHours != nil {
+		hours = *req.ExpiresInHours
+		if hours <= 0 || hours > MaxPreviewLinkHours {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("expires_in_hours must be between 1 and %d", MaxPreviewLinkHours)})
+			return
+		}
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	// Published recipes are public already, and archived ones are hidden on purpose
+	if recipe.Status != store.StatusDraft {
+		c.JSON(http.StatusConflict, gin.H{"error": "only drafts can be previewed"})
+		return
+	}
+
+	existing, err := h.RecipePreviewStore.GetPreviewLinks(recipeID)
+	if err != nil {
+		log.Printf("Failed to get preview links: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if len(existing) >= MaxPreviewLinksPerRecipe {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("a recipe can have at most %d active preview links", MaxPreviewLinksPerRecipe)})
+		return
+	}
+
+	link, token, err := h.RecipePreviewStore.CreatePreviewLink(recipeID, time.Duration(hours)*time.Hour)
+	if err != nil {
+		log.Printf("Failed to create preview link: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create preview link"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Preview link created; store it now, it will not be shown again",
+		"token":   token,
+		"url":     recipePreviewURL(token),
+		"link":    link,
+	})
+}
+
+// GetRecipePreviewLinks godoc
+// @Summary List draft preview links
+// @Description Returns the preview links of one of the authenticated user's recipes that have not expired or been revoked, with how often each was viewed. Tokens are not shown.
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Preview links"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/preview-links [get]
+func (h *RecipeHandler) GetRecipePreviewLinks(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	if _, ok := h.loadOwnedRecipe(c, recipeID); !ok {
+		return
+	}
+
+	links, err := h.RecipePreviewStore.GetPreviewLinks(recipeID)
+	if err != nil {
+		log.Printf("Failed to get preview links: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get preview links"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preview_links": links})
+}
+
+// RevokeRecipePreviewLink godoc
+// @Summary Revoke a draft preview link
+// @Description Stops one of a recipe's preview links from working. Only the recipe's author can revoke its links.
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param link_id path int true "Preview link ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Preview link revoked"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe or preview link not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/preview-links/{link_id} [delete]
+func (h *RecipeHandler) RevokeRecipePreviewLink(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	linkID, ok := parseIDParam(c, "link_id", "preview link ID")
+	if !ok {
+		return
+	}
+
+	if _, ok := h.loadOwnedRecipe(c, recipeID); !ok {
+		return
+	}
+
+	err := h.RecipePreviewStore.RevokePreviewLink(linkID, recipeID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "preview link not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to revoke preview link: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke preview link"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Preview link revoked"})
+}
+
+// GetRecipePreview godoc
+// @Summary View a recipe through a preview link
+// @Description Returns the recipe a preview link points to, read-only, with its ingredients, steps, photos, tags and estimated cost. No sign-in is needed; the link must not have expired or been revoked.
+// @Tags Recipes
+// @Produce json
+// @Param token path string true "Preview link token"
+// @Success 200 {object} map[string]interface{} "Recipe details and link expiry"
+// @Failure 404 {object} map[string]string "Preview link not found or expired"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /previews/{token} [get]
+func (h *RecipeHandler) GetRecipePreview(c *gin.Context) {
+	link, err := h.RecipePreviewStore.UsePreviewLink(c.Param("token"))
+	if err != nil {
+		log.Printf("Failed to use preview link: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if link == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "preview link not found or expired"})
+		return
+	}
+
+	complete, err := h.RecipeStore.GetCompleteRecipe(link.RecipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if complete == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "preview link not found or expired"})
+		return
+	}
+
+	cost, err := h.IngredientStore.GetRecipeCost(link.RecipeID)
+	if err != nil {
+		log.Printf("Failed to estimate recipe cost: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	complete.EstimatedCost = cost
+	complete.Recipe.EstimatedCostPerServing = cost.CostPerServing
+	signPhotoURLs(h.Storage, complete.Photos)
+
+	// Previews of unpublished recipes must not end up in shared caches or search engines
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("X-Robots-Tag", "noindex")
+	c.JSON(http.StatusOK, gin.H{
+		"recipe":             complete,
+		"currency":           priceCurrency(),
+		"preview_expires_at": link.ExpiresAt,
+	})
+}
+
+// recipePreviewURL returns the frontend page that shows a recipe through a preview link
+func recipePreviewURL(token string) string {
+	return frontendBaseURL() + "/preview/" + token
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/qrcode"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed templates/recipe_print.html
+var printTemplateFS embed.FS
+
+var recipePrintTemplate = template.Must(template.New("recipe_print.html").Funcs(template.FuncMap{
+	"minutes": func(m *int) string { return formatDuration(*m * 60) },
+	"seconds": formatDuration,
+}).ParseFS(printTemplateFS, "templates/recipe_print.html"))
+
+// recipePrintView is the data rendered by the print template
+type recipePrintView struct {
+	Recipe      *store.Recipe
+	Ingredients []printIngredient
+	Steps       []*store.RecipeStep
+	URL         string
+	QRCode      template.HTML
+	Lang        string
+}
+
+type printIngredient struct {
+	Quantity string
+	Name     string
+}
+
+// PrintRecipe godoc
+// @Summary Printable recipe
+// @Description Returns a minimal HTML page with a recipe's ingredients and steps, laid out for printing, and a QR code linking back to the recipe. Quantities are localized from Accept-Language. Drafts are only visible to their author.
+// @Tags Recipes
+// @Produce html
+// @Param id path int true "Recipe ID"
+// @Success 200 {string} string "Printable HTML page"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/print [get]
+func (h *RecipeHandler) PrintRecipe(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	viewerID, err := getOptionalInternalUserID(c, h.UserStore)
+	if err != nil {
+		log.Printf("Failed to resolve viewer: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	complete, err := h.RecipeStore.GetCompleteRecipe(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if complete == nil || !canViewRecipe(complete.Recipe, viewerID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	locale := utils.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	formatIngredientQuantities(complete.Ingredients, locale)
+
+	view := recipePrintView{
+		Recipe:      complete.Recipe,
+		Ingredients: make([]printIngredient, 0, len(complete.Ingredients)),
+		Steps:       complete.Steps,
+		URL:         recipePageURL(recipeID),
+		Lang:        locale,
+	}
+	for _, ingredient := range complete.Ingredients {
+		item := printIngredient{Name: ingredient.Name}
+		if ingredient.FormattedQuantity != nil {
+			item.Quantity = *ingredient.FormattedQuantity
+		} else if ingredient.Unit != nil {
+			item.Quantity = *ingredient.Unit
+		}
+		view.Ingredients = append(view.Ingredients, item)
+	}
+
+	// The page is still useful without the code, so a link too long to encode is only logged
+	if code, err := qrcode.Encode(view.URL); err != nil {
+		log.Printf("Failed to encode QR code for %s: %v", view.URL, err)
+	} else {
+		view.QRCode = template.HTML(code.SVG(4))
+	}
+
+	var page bytes.Buffer
+	if err := recipePrintTemplate.Execute(&page, view); err != nil {
+		log.Printf("Failed to render printable recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Header("Content-Language", locale)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// recipePageURL returns the link to a recipe on the frontend
+func recipePageURL(recipeID int64) string {
+	return fmt.Sprintf("%s/recipes/%d", frontendBaseURL(), recipeID)
+}
+
+// frontendBaseURL returns FRONTEND_URL without a trailing slash
+func frontendBaseURL() string {
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000"
+	}
+	return strings.TrimRight(frontendURL, "/")
+}
+
+// formatDuration renders seconds as a short duration such as "1 h 15 min", "45 min" or "30 s"
+func formatDuration(seconds int) string {
+	if seconds < 60 {
+		return strconv.Itoa(seconds) + " s"
+	}
+
+	minutes := seconds / 60
+	if minutes < 60 {
+		return strconv.Itoa(minutes) + " min"
+	}
+	if minutes%60 == 0 {
+		return strconv.Itoa(minutes/60) + " h"
+	}
+	return fmt.Sprintf("%d h %d min", minutes/60, minutes%60)
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// MaxTimersPerStep caps how many timers a single step can define
+	MaxTimersPerStep = 10
+
+	// MaxTimerDurationSeconds caps a single timer at 24 hours
+	MaxTimerDurationSeconds = 24 * 60 * 60
+
+	// MaxTimerLabelLength caps the length of a timer label
+	MaxTimerLabelLength = 100
+)
+
+type recipeStepRequest struct {
+	StepNumber        int               `json:"step_number"`
+	Instruction       string            `json:"instruction"`
+	DurationInMinutes *int              `json:"duration_in_minutes,omitempty"`
+	Timers            []store.StepTimer `json:"timers"`
+}
+
+// AddRecipeStep godoc
+// @Summary Add a recipe step
+// @Description Adds a step to a recipe owned by the authenticated user. Steps can carry labeled timers for cooking mode.
+// @Tags Recipes
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param request body recipeStepRequest true "Step information"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Step added"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/steps [post]
+func (h *RecipeHandler) AddRecipeStep(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	step, ok := bindRecipeStep(c)
+	if !ok {
+		return
+	}
+	step.RecipeID = recipeID
+
+	if err := h.RecipeStore.AddRecipeStep(step); err != nil {
+		log.Printf("Failed to add recipe step: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add step"})
+		return
+	}
+	h.recordRecipeActivity(recipe, store.RecipeActivityStepAdded, map[string]interface{}{"step_id": step.ID, "step_number": step.StepNumber})
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "step added",
+		"step":    step,
+	})
+}
+
+// UpdateRecipeStep godoc
+// @Summary Update a recipe step
+// @Description Replaces a step's number, instruction, duration, and timers on a recipe owned by the authenticated user
+// @Tags Recipes
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param step_id path int true "Step ID"
+// @Param request body recipeStepRequest true "Step information"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Step updated"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe or step not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/steps/{step_id} [put]
+func (h *RecipeHandler) UpdateRecipeStep(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	stepID, ok := parseIDParam(c, "step_id", "step ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	step, ok := bindRecipeStep(c)
+	if !ok {
+		return
+	}
+	step.ID = stepID
+	step.RecipeID = recipeID
+
+	err := h.RecipeStore.UpdateRecipeStep(step)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "step not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to update recipe step: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update step"})
+		return
+	}
+	h.recordRecipeActivity(recipe, store.RecipeActivityStepUpdated, map[string]interface{}{"step_id": step.ID, "step_number": step.StepNumber})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "step updated",
+		"step":    step,
+	})
+}
+
+// loadOwnedRecipe fetches a recipe the authenticated user owns
+// It writes an error response and returns false if the recipe is missing or owned by someone else
+func (h *RecipeHandler) loadOwnedRecipe(c *gin.Context, recipeID int64) (*store.Recipe, bool) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return nil, false
+	}
+
+	recipe, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, false
+	}
+
+	// Hide other users' recipes rather than revealing that they exist
+	if recipe == nil || recipe.UserID != userID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return nil, false
+	}
+
+	return recipe, true
+}
+
+// bindRecipeStep parses and validates a step request body
+// It writes a 400 response and returns false if the body is invalid
+func bindRecipeStep(c *gin.Context) (*store.RecipeStep, bool) {
+	var req recipeStepRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	instruction := strings.TrimSpace(req.Instruction)
+	if instruction == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "instruction is required"})
+		return nil, false
+	}
+	if req.StepNumber <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "step_number must be positive"})
+		return nil, false
+	}
+	if req.DurationInMinutes != nil && *req.DurationInMinutes < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "duration_in_minutes cannot be negative"})
+		return nil, false
+	}
+
+	timers, errMsg := validateStepTimers(req.Timers)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return nil, false
+	}
+
+	return &store.RecipeStep{
+		StepNumber:        req.StepNumber,
+		Instruction:       instruction,
+		DurationInMinutes: req.DurationInMinutes,
+		Timers:            timers,
+	}, true
+}
+
+// validateStepTimers checks each timer has a label and a sensible duration
+// It returns the timers with trimmed labels, or a non-empty error message if validation fails
+func validateStepTimers(timers []store.StepTimer) ([]store.StepTimer, string) {
+	if len(timers) > MaxTimersPerStep {
+		return nil, fmt.Sprintf("a step can have at most %d timers", MaxTimersPerStep)
+	}
+
+	validated := make([]store.StepTimer, 0, len(timers))
+	for i, timer := range timers {
+		label := strings.TrimSpace(timer.Label)
+		if label == "" {
+			return nil, fmt.Sprintf("timers[%d].label is required", i)
+		}
+		if len(label) > MaxTimerLabelLength {
+			return nil, fmt.Sprintf("timers[%d].label must be at most %d characters", i, MaxTimerLabelLength)
+		}
+		if timer.DurationSeconds <= 0 || timer.DurationSeconds > MaxTimerDurationSeconds {
+			return nil, fmt.Sprintf("timers[%d].duration_seconds must be between 1 and %d", i, MaxTimerDurationSeconds)
+		}
+
+		validated = append(validated, store.StepTimer{Label: label, DurationSeconds: timer.DurationSeconds})
+	}
+
+	return validated, ""
+}
+
+type reorderStepsRequest struct {
+	StepIDs []int64 `json:"step_ids"`
+}
+
+// ReorderRecipeSteps godoc
+// @Summary Reorder recipe steps
+// @Description Renumbers all steps of a recipe owned by the authenticated user in one transaction. step_ids must list every step of the recipe exactly once, in the new order.
+// @Tags Recipes
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param request body reorderStepsRequest true "Step IDs in their new order"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Steps reordered"
+// @Failure 400 {object} map[string]string "Invalid request or step IDs do not match the recipe"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/steps/order [put]
+func (h *RecipeHandler) ReorderRecipeSteps(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	var req reorderStepsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(req.StepIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "step_ids is required"})
+		return
+	}
+
+	err := h.RecipeStore.ReorderRecipeSteps(recipeID, req.StepIDs)
+	if err == store.ErrReorderMismatch {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "step_ids must list every step of the recipe exactly once"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to reorder recipe steps: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reorder steps"})
+		return
+	}
+	h.recordRecipeActivity(recipe, store.RecipeActivityStepsReordered, nil)
+
+	steps, err := h.RecipeStore.GetRecipeSteps(recipeID)
+	if err != nil {
+		log.Printf("Failed to get recipe steps: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "steps reordered",
+		"steps":   steps,
+	})
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+// MaxRecipeTags caps how many tags a recipe can carry
+const MaxRecipeTags = 30
+
+type setRecipeTagsRequest struct {
+	Tags []string `json:"tags" binding:"required"`
+}
+
+// SetRecipeTags godoc
+// @Summary Set recipe tags
+// @Description Replaces the tags of a recipe owned by the authenticated user with the given names in one step: tags not listed are removed, new ones added, and tags that do not exist yet are created. Names match existing tags ignoring case. An empty list removes every tag.
+// @Tags Recipes
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param request body setRecipeTagsRequest true "The recipe's complete set of tag names (at most 30)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "The recipe's tags, with those added and removed"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/tags [put]
+func (h *RecipeHandler) SetRecipeTags(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	var req setRecipeTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	names, errMsg := normalizeTagNames(req.Tags)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+
+	update, err := h.RecipeTaxonomyStore.SetRecipeTags(recipeID, names)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to set recipe tags: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update tags"})
+		return
+	}
+
+	if len(update.Added) > 0 || len(update.Removed) > 0 {
+		h.recordRecipeActivity(recipe, store.RecipeActivityUpdated, map[string]interface{}{
+			"tags": recipeTagsChange(update),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "tags updated",
+		"tags":    update.Tags,
+		"added":   update.Added,
+		"removed": update.Removed,
+	})
+}
+
+// recipeTagsChange lists a recipe's tag names, sorted, before and after an update, as a field change
+func recipeTagsChange(update *store.RecipeTagsUpdate) store.RecipeFieldChange {
+	before, after := []string{}, []string{}
+	for _, tag := range update.Tags {
+		after = append(after, tag.Name)
+		if !slices.Contains(update.Added, tag) {
+			before = append(before, tag.Name)
+		}
+	}
+	for _, tag := range update.Removed {
+		before = append(before, tag.Name)
+	}
+	slices.Sort(before)
+	slices.Sort(after)
+
+	// Marshalling a []string cannot fail
+	from, _ := json.Marshal(before)
+	to, _ := json.Marshal(after)
+	return store.RecipeFieldChange{From: from, To: to}
+}
+
+// normalizeTagNames trims tag names and drops duplicates, ignoring case
+func normalizeTagNames(names []string) ([]string, string) {
+	if len(names) > MaxRecipeTags {
+		return nil, fmt.Sprintf("a recipe can have at most %d tags", MaxRecipeTags)
+	}
+
+	tags := []string{}
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Sprintf("tags[%d] cannot be empty", i)
+		}
+		if len(name) > 100 {
+			return nil, fmt.Sprintf("tags[%d] must be at most 100 characters", i)
+		}
+		if !slices.ContainsFunc(tags, func(tag string) bool { return strings.EqualFold(tag, name) }) {
+			tags = append(tags, name)
+		}
+	}
+
+	return tags, ""
+}
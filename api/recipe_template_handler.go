@@ -0,0 +1,430 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// MaxTemplateIngredients caps how many ingredients a template can pre-fill
+	MaxTemplateIngredients = 100
+
+	// MaxTemplateSteps caps how many steps a template can pre-fill
+	MaxTemplateSteps = 100
+)
+
+type RecipeTemplateHandler struct {
+	RecipeTemplateStore store.RecipeTemplateStore
+	RecipeStore         store.RecipeStore
+	UserStore           store.UserStore
+	QuotaService        *services.QuotaService
+}
+
+func NewRecipeTemplateHandler(
+	recipeTemplateStore store.RecipeTemplateStore,
+	recipeStore store.RecipeStore,
+	userStore store.UserStore,
+	quotaService *services.QuotaService,
+) *RecipeTemplateHandler {
+	return &RecipeTemplateHandler{
+		RecipeTemplateStore: recipeTemplateStore,
+		RecipeStore:         recipeStore,
+		UserStore:           userStore,
+		QuotaService:        quotaService,
+	}
+}
+
+type recipeTemplateRequest struct {
+	Name            string                      `json:"name"`
+	Description     string                      `json:"description"`
+	CategoryID      *int64                      `json:"category_id,omitempty"`
+	DifficultyLevel string                      `json:"difficulty_level"`
+	Ingredients     []*store.TemplateIngredient `json:"ingredients"`
+	Steps           []*store.TemplateStep       `json:"steps"`
+}
+
+type createFromTemplateRequest struct {
+	Title string `json:"title"`
+}
+
+// ListRecipeTemplates godoc
+// @Summary List recipe templates
+// @Description Returns the starter templates users can create recipes from
+// @Tags Recipe Templates
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Recipe templates"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipe-templates [get]
+func (h *RecipeTemplateHandler) ListRecipeTemplates(c *gin.Context) {
+	templates, err := h.RecipeTemplateStore.ListTemplates()
+	if err != nil {
+		log.Printf("Failed to list recipe templates: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"templates": templates,
+	})
+}
+
+// GetRecipeTemplate godoc
+// @Summary Get a recipe template
+// @Description Returns a starter template with the ingredients and steps it pre-fills
+// @Tags Recipe Templates
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} map[string]interface{} "Recipe template"
+// @Failure 400 {object} map[string]string "Invalid template ID"
+// @Failure 404 {object} map[string]string "Template not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipe-templates/{id} [get]
+func (h *RecipeTemplateHandler) GetRecipeTemplate(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id", "template ID")
+	if !ok {
+		return
+	}
+
+	template, err := h.RecipeTemplateStore.GetTemplate(templateID)
+	if err != nil {
+		log.Printf("Failed to get recipe template: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if template == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"template": template,
+	})
+}
+
+// CreateRecipeTemplate godoc
+// @Summary Create a recipe template
+// @Description Creates a starter template. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body recipeTemplateRequest true "Template content"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Template created"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 409 {object} map[string]string "Template name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/recipe-templates [post]
+func (h *RecipeTemplateHandler) CreateRecipeTemplate(c *gin.Context) {
+	template, ok := bindRecipeTemplate(c)
+	if !ok {
+		return
+	}
+
+	if err := h.RecipeTemplateStore.CreateTemplate(template); err != nil {
+		respondTemplateWriteError(c, err, "failed to create template")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "template created",
+		"template": template,
+	})
+}
+
+// UpdateRecipeTemplate godoc
+// @Summary Update a recipe template
+// @Description Replaces a starter template's content. Recipes already created from it are unaffected. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param request body recipeTemplateRequest true "Template content"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Template updated"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Template not found"
+// @Failure 409 {object} map[string]string "Template name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/recipe-templates/{id} [put]
+func (h *RecipeTemplateHandler) UpdateRecipeTemplate(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id", "template ID")
+	if !ok {
+		return
+	}
+
+	template, ok := bindRecipeTemplate(c)
+	if !ok {
+		return
+	}
+	template.ID = templateID
+
+	err := h.RecipeTemplateStore.UpdateTemplate(template)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		return
+	}
+	if err != nil {
+		respondTemplateWriteError(c, err, "failed to update template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "template updated",
+		"template": template,
+	})
+}
+
+// DeleteRecipeTemplate godoc
+// @Summary Delete a recipe template
+// @Description Deletes a starter template. Recipes already created from it are unaffected. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Template ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Template deleted"
+// @Failure 400 {object} map[string]string "Invalid template ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Template not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/recipe-templates/{id} [delete]
+func (h *RecipeTemplateHandler) DeleteRecipeTemplate(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id", "template ID")
+	if !ok {
+		return
+	}
+
+	err := h.RecipeTemplateStore.DeleteTemplate(templateID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to delete recipe template: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete template"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "template deleted"})
+}
+
+// CreateRecipeFromTemplate godoc
+// @Summary Create a recipe from a template
+// @Description Creates a draft recipe owned by the authenticated user, pre-filled with the template's ingredients and steps. Counts towards the daily recipe creation quota.
+// @Tags Recipe Templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param request body createFromTemplateRequest false "Optional title; defaults to the template name"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Recipe created from template"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Template not found"
+// @Failure 429 {object} map[string]interface{} "Daily recipe creation limit reached"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/from-template/{id} [post]
+func (h *RecipeTemplateHandler) CreateRecipeFromTemplate(c *gin.Context) {
+	templateID, ok := parseIDParam(c, "id", "template ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	// The body is optional; without one the recipe is titled after the template
+	var req createFromTemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if len(title) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "title must be at most 255 characters"})
+		return
+	}
+
+	template, err := h.RecipeTemplateStore.GetTemplate(templateID)
+	if err != nil {
+		log.Printf("Failed to get recipe template: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if template == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		return
+	}
+
+	if title == "" {
+		title = template.Name
+	}
+
+	// Enforce the per-user daily creation quota
+	if !checkQuota(c, h.QuotaService.CheckRecipeCreation(userID)) {
+		return
+	}
+
+	recipe := &store.Recipe{
+		Title:           title,
+		Description:     template.Description,
+		UserID:          userID,
+		CategoryID:      template.CategoryID,
+		Status:          store.StatusDraft,
+		DifficultyLevel: template.DifficultyLevel,
+	}
+
+	ingredients := make([]*store.RecipeIngredient, 0, len(template.Ingredients))
+	for i, item := range template.Ingredients {
+		position := i + 1
+		ingredients = append(ingredients, &store.RecipeIngredient{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Unit:     item.Unit,
+			Position: &position,
+		})
+	}
+
+	steps := make([]*store.RecipeStep, 0, len(template.Steps))
+	for i, item := range template.Steps {
+		steps = append(steps, &store.RecipeStep{
+			StepNumber:        i + 1,
+			Instruction:       item.Instruction,
+			DurationInMinutes: item.DurationInMinutes,
+			Timers:            item.Timers,
+		})
+	}
+
+	if err := h.RecipeStore.CreateCompleteRecipe(recipe, ingredients, steps); err != nil {
+		log.Printf("Failed to create recipe from template: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create recipe"})
+		return
+	}
+
+	addRecipeLinks(recipe)
+	c.JSON(http.StatusCreated, gin.H{
+		"message":     "recipe created from template",
+		"recipe":      recipe,
+		"ingredients": ingredients,
+		"steps":       steps,
+	})
+}
+
+// bindRecipeTemplate parses and validates a template request body
+// It writes a 400 response and returns false if the body is invalid
+func bindRecipeTemplate(c *gin.Context) (*store.RecipeTemplate, bool) {
+	var req recipeTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	template, errMsg := buildTemplateFromRequest(&req)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return nil, false
+	}
+
+	return template, true
+}
+
+// buildTemplateFromRequest validates a template request and converts it to a RecipeTemplate
+// It returns a non-empty error message if validation fails
+func buildTemplateFromRequest(req *recipeTemplateRequest) (*store.RecipeTemplate, string) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, "name is required"
+	}
+	if len(name) > 255 {
+		return nil, "name must be at most 255 characters"
+	}
+
+	difficulty := store.DifficultyLevel(strings.TrimSpace(req.DifficultyLevel))
+	if difficulty == "" {
+		difficulty = store.DifficultyEasy
+	}
+	if !store.IsValidDifficultyLevel(difficulty) {
+		return nil, "difficulty_level must be easy, medium, or hard"
+	}
+
+	if len(req.Ingredients) > MaxTemplateIngredients {
+		return nil, fmt.Sprintf("a template can have at most %d ingredients", MaxTemplateIngredients)
+	}
+	for i, ingredient := range req.Ingredients {
+		if ingredient == nil {
+			return nil, fmt.Sprintf("ingredients[%d] is required", i)
+		}
+		ingredient.Name = strings.TrimSpace(ingredient.Name)
+		if ingredient.Name == "" || len(ingredient.Name) > 255 {
+			return nil, fmt.Sprintf("ingredients[%d].name must be between 1 and 255 characters", i)
+		}
+		if ingredient.Quantity != nil && *ingredient.Quantity < 0 {
+			return nil, fmt.Sprintf("ingredients[%d].quantity cannot be negative", i)
+		}
+		if ingredient.Unit != nil && len(*ingredient.Unit) > 50 {
+			return nil, fmt.Sprintf("ingredients[%d].unit must be at most 50 characters", i)
+		}
+	}
+
+	if len(req.Steps) > MaxTemplateSteps {
+		return nil, fmt.Sprintf("a template can have at most %d steps", MaxTemplateSteps)
+	}
+	for i, step := range req.Steps {
+		if step == nil {
+			return nil, fmt.Sprintf("steps[%d] is required", i)
+		}
+		step.Instruction = strings.TrimSpace(step.Instruction)
+		if step.Instruction == "" {
+			return nil, fmt.Sprintf("steps[%d].instruction is required", i)
+		}
+		if step.DurationInMinutes != nil && *step.DurationInMinutes < 0 {
+			return nil, fmt.Sprintf("steps[%d].duration_in_minutes cannot be negative", i)
+		}
+
+		timers, errMsg := validateStepTimers(step.Timers)
+		if errMsg != "" {
+			return nil, fmt.Sprintf("steps[%d]: %s", i, errMsg)
+		}
+		step.Timers = timers
+	}
+
+	return &store.RecipeTemplate{
+		Name:            name,
+		Description:     strings.TrimSpace(req.Description),
+		CategoryID:      req.CategoryID,
+		DifficultyLevel: difficulty,
+		Ingredients:     req.Ingredients,
+		Steps:           req.Steps,
+	}, ""
+}
+
+// respondTemplateWriteError maps template constraint violations to client errors
+func respondTemplateWriteError(c *gin.Context, err error, message string) {
+	log.Printf("Failed to save recipe template: %v", err)
+	switch {
+	case errors.Is(err, store.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": "a template with this name already exists"})
+	case errors.Is(err, store.ErrForeignKey):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "category not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
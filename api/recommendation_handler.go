@@ -0,0 +1,69 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRecommendations godoc
+// @Summary Recommended recipes
+// @Description Returns published recipes tailored to the authenticated user's onboarding preferences: difficulty suited to their skill level, compatible with their dietary restrictions, preferred cuisines first, and nothing they wrote or have already cooked
+// @Tags Recipes
+// @Produce json
+// @Param limit query int false "Maximum number of recipes (default 20, max 50)"
+// @Param ignore_preferences query bool false "Do not exclude recipes that conflict with the user's dietary restrictions"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Recommended recipes"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/recommendations [get]
+func (h *RecipeHandler) GetRecommendations(c *gin.Context) {
+	userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	internalID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultRecommendationLimit, MaxRecommendationLimit)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.UserStore.GetUserPreferences(userID)
+	if err != nil {
+		log.Printf("Failed to get user preferences: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if prefs == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+		return
+	}
+
+	if c.Query("ignore_preferences") == "true" {
+		prefs.DietaryRestrictions = []string{}
+	}
+
+	recipes, err := h.RecipeStore.GetRecommendedRecipes(internalID, prefs, limit)
+	if err != nil {
+		log.Printf("Failed to get recommended recipes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	signRecipePhotos(h.Storage, recipes)
+	addRecipeLinks(recipes...)
+
+	c.JSON(http.StatusOK, gin.H{
+		"recipes":     recipes,
+		"preferences": prefs,
+		"currency":    priceCurrency(),
+	})
+}
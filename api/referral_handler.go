@@ -0,0 +1,59 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+type ReferralHandler struct {
+	ReferralStore store.ReferralStore
+	UserStore     store.UserStore
+}
+
+func NewReferralHandler(referralStore store.ReferralStore, userStore store.UserStore) *ReferralHandler {
+	return &ReferralHandler{
+		ReferralStore: referralStore,
+		UserStore:     userStore,
+	}
+}
+
+// GetMyReferrals godoc
+// @Summary Get my referrals
+// @Description Returns the authenticated user's referral code along with the users who signed up through it or through one of their invitations. Counts show how many referred users signed up and how many have published their first recipe.
+// @Tags Invitations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Referral code and referred users"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/referrals [get]
+func (h *ReferralHandler) GetMyReferrals(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	code, err := h.ReferralStore.GetOrCreateReferralCode(userID)
+	if err != nil {
+		log.Printf("Failed to get referral code: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	summary, err := h.ReferralStore.GetReferralSummary(userID)
+	if err != nil {
+		log.Printf("Failed to get referrals: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"referral_code":      code,
+		"signed_up":          summary.SignedUp,
+		"published_a_recipe": summary.PublishedARecipe,
+		"referrals":          summary.Referrals,
+	})
+}
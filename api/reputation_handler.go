@@ -0,0 +1,370 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultLeaderboardLimit is the number of users returned when no limit is given
+	DefaultLeaderboardLimit = 20
+
+	// MaxLeaderboardLimit caps the number of users returned by the leaderboard
+	MaxLeaderboardLimit = 100
+
+	// RecentReputationEvents is the number of ledger entries returned with a user's reputation
+	RecentReputationEvents = 20
+)
+
+type ReputationHandler struct {
+	ReputationStore     store.ReputationStore
+	RecipeStore         store.RecipeStore
+	ReviewStore         store.ReviewStore
+	UserStore           store.UserStore
+	UsageService        *services.UsageService
+	NotificationService *services.NotificationService
+	Storage             services.Storage
+}
+
+func NewReputationHandler(reputationStore store.ReputationStore, recipeStore store.RecipeStore, reviewStore store.ReviewStore, userStore store.UserStore, usageService *services.UsageService, notificationService *services.NotificationService, storage services.Storage) *ReputationHandler {
+	return &ReputationHandler{
+		ReputationStore:     reputationStore,
+		RecipeStore:         recipeStore,
+		ReviewStore:         reviewStore,
+		UserStore:           userStore,
+		UsageService:        usageService,
+		NotificationService: notificationService,
+		Storage:             storage,
+	}
+}
+
+// loadVisibleRecipe fetches a recipe the user is allowed to see
+// It writes an error response and returns false if the recipe is missing or hidden
+func (h *ReputationHandler) loadVisibleRecipe(c *gin.Context, recipeID int64, userID int64) (*store.Recipe, bool) {
+	recipe, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, false
+	}
+
+	if !canViewRecipe(recipe, userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return nil, false
+	}
+
+	return recipe, true
+}
+
+// FavoriteRecipe godoc
+// @Summary Favorite a recipe
+// @Description Adds a recipe to the authenticated user's favorites. The recipe's author earns reputation and is notified unless they favorite their own recipe.
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Recipe favorited"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/favorite [post]
+func (h *ReputationHandler) FavoriteRecipe(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadVisibleRecipe(c, recipeID, userID)
+	if !ok {
+		return
+	}
+
+	added, err := h.ReputationStore.AddFavorite(userID, recipeID)
+	if err != nil {
+		log.Printf("Failed to favorite recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to favorite recipe"})
+		return
+	}
+	if added {
+		h.NotificationService.NotifyFavorite(userID, c.GetString("username"), recipe)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "recipe favorited"})
+}
+
+// UnfavoriteRecipe godoc
+// @Summary Remove a recipe from favorites
+// @Description Removes a recipe from the authenticated user's favorites, taking back the reputation its author earned for it
+// @Tags Recipes
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Favorite removed"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe is not a favorite"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/favorite [delete]
+func (h *ReputationHandler) UnfavoriteRecipe(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	err := h.ReputationStore.RemoveFavorite(userID, recipeID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe is not a favorite"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to unfavorite recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove favorite"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "favorite removed"})
+}
+
+// MarkReviewHelpful godoc
+// @Summary Mark a review helpful
+// @Description Records that the authenticated user found a review helpful, earning its author reputation and notifying them. Users cannot vote for their own reviews.
+// @Tags Reviews
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param review_id path int true "Review ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Review marked helpful"
+// @Failure 400 {object} map[string]string "Invalid ID or own review"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe or review not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/reviews/{review_id}/helpful [post]
+func (h *ReputationHandler) MarkReviewHelpful(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	reviewID, ok := parseIDParam(c, "review_id", "review ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	recipe, ok := h.loadVisibleRecipe(c, recipeID, userID)
+	if !ok {
+		return
+	}
+
+	review, err := h.ReviewStore.GetRecipeReviewByID(reviewID)
+	if err != nil {
+		log.Printf("Failed to fetch review: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if review == nil || review.RecipeID != recipeID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+	if review.UserID == userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "you cannot mark your own review as helpful"})
+		return
+	}
+
+	added, err := h.ReputationStore.AddReviewHelpfulVote(userID, reviewID)
+	if err != nil {
+		log.Printf("Failed to mark review helpful: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to mark review helpful"})
+		return
+	}
+	if added {
+		h.NotificationService.NotifyReviewHelpful(userID, c.GetString("username"), recipe, review)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "review marked helpful"})
+}
+
+// UnmarkReviewHelpful godoc
+// @Summary Withdraw a helpful vote
+// @Description Withdraws the authenticated user's helpful vote on a review, taking back the reputation its author earned for it
+// @Tags Reviews
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param review_id path int true "Review ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Helpful vote withdrawn"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "No helpful vote for this review"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/reviews/{review_id}/helpful [delete]
+func (h *ReputationHandler) UnmarkReviewHelpful(c *gin.Context) {
+	if _, ok := parseIDParam(c, "id", "recipe ID"); !ok {
+		return
+	}
+
+	reviewID, ok := parseIDParam(c, "review_id", "review ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	err := h.ReputationStore.RemoveReviewHelpfulVote(userID, reviewID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "you have not marked this review helpful"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to withdraw helpful vote: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to withdraw helpful vote"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "helpful vote withdrawn"})
+}
+
+// GetMyReputation godoc
+// @Summary Get my reputation
+// @Description Returns the authenticated user's reputation score, rank among all users, and most recent ledger entries. Points are earned for publishing recipes (10), receiving favorites (2), and reviews marked helpful (1).
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Score, rank, and recent ledger entries"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/reputation [get]
+func (h *ReputationHandler) GetMyReputation(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	reputation, err := h.ReputationStore.GetUserReputation(userID)
+	if err != nil {
+		log.Printf("Failed to get reputation: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	events, err := h.ReputationStore.GetReputationEvents(userID, RecentReputationEvents)
+	if err != nil {
+		log.Printf("Failed to get reputation events: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"score":  reputation.Score,
+		"rank":   reputation.Rank,
+		"events": events,
+	})
+}
+
+// GetMyFavorites godoc
+// @Summary List my favorite recipes
+// @Description Returns a page of the published recipes the authenticated user has favorited. Accepts the same filters as the public recipe list. With format=csv or Accept: text/csv, all matching recipes are downloaded as CSV instead.
+// @Tags Recipes
+// @Produce json
+// @Produce text/csv
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Recipes per page (default 20, max 100)"
+// @Param category_id query int false "Only recipes in this category"
+// @Param difficulty query string false "Only recipes of this difficulty (easy, medium, hard)"
+// @Param lang query string false "Only recipes written in this language, as an ISO 639-1 code (e.g. en, fr, es)"
+// @Param sort query string false "Sort order: newest (default), oldest, title, cost, updated"
+// @Param format query string false "Response format: json (default) or csv; CSV exports every matching recipe rather than one page"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Recipes and pagination"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 429 {object} map[string]string "Daily export quota reached"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/favorites [get]
+func (h *ReputationHandler) GetMyFavorites(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	opts, ok := parseRecipeListOptions(c)
+	if !ok {
+		return
+	}
+	opts.FavoritedBy = &userID
+
+	asCSV, ok := wantsCSV(c)
+	if !ok {
+		return
+	}
+	if asCSV {
+		exportRecipesCSV(c, h.RecipeStore, h.UsageService, opts, "favorites.csv")
+		return
+	}
+
+	recipes, total, err := h.RecipeStore.GetRecipes(c.Request.Context(), opts)
+	if err != nil {
+		log.Printf("Failed to list favorite recipes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	signRecipePhotos(h.Storage, recipes)
+	addRecipeLinks(recipes...)
+
+	c.JSON(http.StatusOK, gin.H{
+		"recipes":    recipes,
+		"pagination": newPagination(opts.Page, opts.Limit, total),
+		"currency":   priceCurrency(),
+	})
+}
+
+// GetLeaderboard godoc
+// @Summary Reputation leaderboard
+// @Description Returns the users with the highest reputation. Users with the same score share a rank.
+// @Tags Users
+// @Produce json
+// @Param limit query int false "Maximum number of users (default 20, max 100)"
+// @Success 200 {object} map[string]interface{} "Leaderboard"
+// @Failure 400 {object} map[string]string "Invalid limit"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /leaderboard [get]
+func (h *ReputationHandler) GetLeaderboard(c *gin.Context) {
+	limit, ok := parseLimitQuery(c, DefaultLeaderboardLimit, MaxLeaderboardLimit)
+	if !ok {
+		return
+	}
+
+	leaderboard, err := h.ReputationStore.GetLeaderboard(limit)
+	if err != nil {
+		log.Printf("Failed to get leaderboard: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"leaderboard": leaderboard,
+	})
+}
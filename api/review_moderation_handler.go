@@ -0,0 +1,198 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultReviewModerationJobsPageSize is the number of jobs returned per page when no limit is given
+	DefaultReviewModerationJobsPageSize = 20
+
+	// MaxReviewModerationJobsPageSize caps how many jobs can be requested per page
+	MaxReviewModerationJobsPageSize = 100
+
+	// MinReviewModerationPatternLength keeps a short pattern from matching most reviews by accident
+	MinReviewModerationPatternLength = 3
+
+	// MaxReviewModerationPatternLength caps the text a job matches review comments against
+	MaxReviewModerationPatternLength = 200
+)
+
+type ReviewModerationHandler struct {
+	Moderation      *services.ReviewModerationService
+	ModerationStore store.ReviewModerationStore
+	UserStore       store.UserStore
+}
+
+func NewReviewModerationHandler(moderation *services.ReviewModerationService, moderationStore store.ReviewModerationStore, userStore store.UserStore) *ReviewModerationHandler {
+	return &ReviewModerationHandler{
+		Moderation:      moderation,
+		ModerationStore: moderationStore,
+		UserStore:       userStore,
+	}
+}
+
+type createReviewModerationJobRequest struct {
+	// Action is hide or delete
+	Action string `json:"action"`
+
+	// UserID only matches reviews written by this user
+	UserID string `json:"user_id"`
+
+	// Pattern only matches reviews whose comment contains this text, ignoring case
+	Pattern string `json:"pattern"`
+}
+
+// CreateReviewModerationJob godoc
+// @Summary Hide or delete reviews in bulk
+// @Description Queues a job that hides or deletes every review written by user_id, every review whose comment contains pattern (ignoring case, matched literally), or, when both are given, every review matching both. Hidden reviews are left out of review lists and ratings but kept; deleted reviews lose their helpful votes and author responses too. The job runs in the background; poll GET /admin/reviews/moderation-jobs/{id} for progress. Admin only.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body createReviewModerationJobRequest true "Action and the reviews it applies to"
+// @Security BearerAuth
+// @Success 202 {object} store.ReviewModerationJob "Job queued"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/reviews/moderation-jobs [post]
+func (h *ReviewModerationHandler) CreateReviewModerationJob(c *gin.Context) {
+	adminID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req createReviewModerationJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	action := strings.ToLower(strings.TrimSpace(req.Action))
+	if !store.IsValidReviewModerationAction(action) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "action must be hide or delete"})
+		return
+	}
+
+	userID := strings.TrimSpace(req.UserID)
+	pattern := strings.TrimSpace(req.Pattern)
+	if userID == "" && pattern == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id or pattern is required"})
+		return
+	}
+
+	job := &store.ReviewModerationJob{Action: action, CreatedBy: &adminID}
+
+	if pattern != "" {
+		length := utf8.RuneCountInString(pattern)
+		if length < MinReviewModerationPatternLength || length > MaxReviewModerationPatternLength {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("pattern must be between %d and %d characters", MinReviewModerationPatternLength, MaxReviewModerationPatternLength)})
+			return
+		}
+		job.Pattern = &pattern
+	}
+
+	if userID != "" {
+		internalID, err := h.UserStore.GetUserInternalID(userID)
+		if err != nil {
+			log.Printf("Failed to resolve user ID %s: %v", userID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		if internalID == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		job.UserID = &internalID
+	}
+
+	if err := h.Moderation.Request(job); err != nil {
+		log.Printf("Failed to queue review moderation job: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to queue review moderation job"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// ListReviewModerationJobs godoc
+// @Summary List bulk review moderation jobs
+// @Description Returns review moderation jobs, newest first, with their status and progress. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Jobs per page (default 20, max 100)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Jobs with pagination"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/reviews/moderation-jobs [get]
+func (h *ReviewModerationHandler) ListReviewModerationJobs(c *gin.Context) {
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultReviewModerationJobsPageSize, MaxReviewModerationJobsPageSize)
+	if !ok {
+		return
+	}
+
+	jobs, total, err := h.ModerationStore.GetReviewModerationJobs(page, limit)
+	if err != nil {
+		log.Printf("Failed to get review moderation jobs: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":       jobs,
+		"pagination": newPagination(page, limit, total),
+	})
+}
+
+// GetReviewModerationJob godoc
+// @Summary Get the status of a bulk review moderation job
+// @Description Returns a job's status (queued, running, done or failed), how many reviews it matched when it started, how many it has hidden or deleted so far, and its progress as a percentage. Admin only.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Job ID"
+// @Security BearerAuth
+// @Success 200 {object} store.ReviewModerationJob "Job status"
+// @Failure 400 {object} map[string]string "Invalid job ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Job not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/reviews/moderation-jobs/{id} [get]
+func (h *ReviewModerationHandler) GetReviewModerationJob(c *gin.Context) {
+	jobID, ok := parseIDParam(c, "id", "job ID")
+	if !ok {
+		return
+	}
+
+	job, err := h.ModerationStore.GetReviewModerationJob(jobID)
+	if err != nil {
+		log.Printf("Failed to get review moderation job: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if job == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+// MaxReviewAuthorResponseLength caps the response a recipe's author pins to a review
+const MaxReviewAuthorResponseLength = 2000
+
+type reviewAuthorResponseRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// SetReviewAuthorResponse godoc
+// @Summary Respond to a review as the recipe's author
+// @Description Pins the recipe author's response to a review of their recipe, shown as author_response wherever the review is listed. A review has at most one author response; setting it again replaces the earlier one. Authors cannot respond to their own reviews.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param review_id path int true "Review ID"
+// @Param request body reviewAuthorResponseRequest true "Response text"
+// @Security BearerAuth
+// @Success 200 {object} store.ReviewAuthorResponse "Author response"
+// @Failure 400 {object} map[string]string "Invalid request or own review"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe or review not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/reviews/{review_id}/response [put]
+func (h *RecipeHandler) SetReviewAuthorResponse(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	reviewID, ok := parseIDParam(c, "review_id", "review ID")
+	if !ok {
+		return
+	}
+
+	var req reviewAuthorResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "body is required"})
+		return
+	}
+	if len(body) > MaxReviewAuthorResponseLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("body must be at most %d characters", MaxReviewAuthorResponseLength)})
+		return
+	}
+
+	recipe, ok := h.loadOwnedRecipe(c, recipeID)
+	if !ok {
+		return
+	}
+
+	review, ok := h.loadRecipeReview(c, recipeID, reviewID)
+	if !ok {
+		return
+	}
+	if review.UserID == recipe.UserID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "you cannot respond to your own review"})
+		return
+	}
+
+	response, err := h.ReviewStore.SetReviewAuthorResponse(reviewID, recipe.UserID, body)
+	if errors.Is(err, store.ErrForeignKey) {
+		// The review was deleted in the meantime
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to set review author response: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save response"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteReviewAuthorResponse godoc
+// @Summary Remove an author response
+// @Description Removes the response the recipe's author pinned to a review of their recipe
+// @Tags Reviews
+// @Produce json
+// @Param id path int true "Recipe ID"
+// @Param review_id path int true "Review ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Response removed"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Recipe, review or response not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/reviews/{review_id}/response [delete]
+func (h *RecipeHandler) DeleteReviewAuthorResponse(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	reviewID, ok := parseIDParam(c, "review_id", "review ID")
+	if !ok {
+		return
+	}
+
+	if _, ok := h.loadOwnedRecipe(c, recipeID); !ok {
+		return
+	}
+
+	if _, ok := h.loadRecipeReview(c, recipeID, reviewID); !ok {
+		return
+	}
+
+	err := h.ReviewStore.DeleteReviewAuthorResponse(reviewID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review has no author response"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to delete review author response: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove response"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Response removed"})
+}
+
+// loadRecipeReview fetches a review and checks it belongs to the recipe
+// It writes a 404 or 500 response and returns false otherwise
+func (h *RecipeHandler) loadRecipeReview(c *gin.Context, recipeID, reviewID int64) (*store.RecipeReview, bool) {
+	review, err := h.ReviewStore.GetRecipeReviewByID(reviewID)
+	if err != nil {
+		log.Printf("Failed to fetch review: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, false
+	}
+
+	if review == nil || review.RecipeID != recipeID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+		return nil, false
+	}
+
+	return review, true
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	SearchStore   store.SearchStore
+	SearchIndexer *services.SearchIndexer
+	Storage       services.Storage
+}
+
+func NewSearchHandler(searchStore store.SearchStore, searchIndexer *services.SearchIndexer, storage services.Storage) *SearchHandler {
+	return &SearchHandler{
+		SearchStore:   searchStore,
+		SearchIndexer: searchIndexer,
+		Storage:       storage,
+	}
+}
+
+// Suggest godoc
+// @Summary Search suggestions
+// @Description Returns a ranked mix of published recipe titles, tags, and chef usernames containing the query, for typeahead
+// @Tags Search
+// @Produce json
+// @Param q query string true "Partial recipe title, tag, or username"
+// @Param limit query int false "Maximum number of suggestions (default 10, max 25)"
+// @Success 200 {object} map[string]interface{} "Matching suggestions"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /search/suggest [get]
+func (h *SearchHandler) Suggest(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter q is required"})
+		return
+	}
+
+	limit, ok := parseLimitQuery(c, DefaultSuggestionLimit, MaxSuggestionLimit)
+	if !ok {
+		return
+	}
+
+	suggestions, err := h.SearchStore.Suggest(query, limit)
+	if err != nil {
+		log.Printf("Failed to get search suggestions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"suggestions": suggestions,
+	})
+}
+
+// SearchRecipes godoc
+// @Summary Search recipes
+// @Description Full-text search over published recipe titles and descriptions, best matches first, using the configured search engine. Each result carries an HTML-escaped title and description snippet with matched terms wrapped in <mark> tags. Supports the same filters as the list endpoint, and returns the same facets.
+// @Tags Search
+// @Produce json
+// @Param q query string true "Search query"
+// @Param category_id query int false "Only recipes in this category"
+// @Param difficulty query string false "Only recipes of this difficulty (easy, medium, hard)"
+// @Param lang query string false "Only recipes written in this language, as an ISO 639-1 code (e.g. en, fr, es)"
+// @Param max_cost query number false "Only recipes whose estimated cost per serving is at most this amount"
+// @Param max_total_time query int false "Only recipes with a total time of at most this many minutes"
+// @Param min_serving_size query int false "Only recipes serving at least this many people"
+// @Param max_serving_size query int false "Only recipes serving at most this many people"
+// @Param min_rating query number false "Only recipes with an average review rating of at least this (1-5)"
+// @Param diet query string false "Comma-separated dietary labels every recipe must carry (e.g. vegan,gluten_free)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Results per page (default 20, max 100)"
+// @Success 200 {object} map[string]interface{} "Matching recipes with highlights, and facets"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /search/recipes [get]
+func (h *SearchHandler) SearchRecipes(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter q is required"})
+		return
+	}
+
+	opts, ok := parseRecipeListOptions(c)
+	if !ok {
+		return
+	}
+
+	results, total, err := h.SearchIndexer.Index().SearchRecipes(c.Request.Context(), query, opts)
+	if err != nil {
+		log.Printf("Failed to search recipes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	recipes := make([]*store.Recipe, len(results))
+	for i, result := range results {
+		recipes[i] = result.Recipe
+	}
+	signRecipePhotos(h.Storage, recipes)
+	addRecipeLinks(recipes...)
+
+	facets, err := h.SearchIndexer.Index().SearchFacets(c.Request.Context(), query, opts)
+	if err != nil {
+		log.Printf("Failed to count search facets: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results":    results,
+		"pagination": newPagination(opts.Page, opts.Limit, total),
+		"facets":     facets,
+		"currency":   priceCurrency(),
+	})
+}
+
+// ReindexSearch godoc
+// @Summary Rebuild the search index
+// @Description Clears the search index and re-indexes every published recipe in the background. Admin only.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} map[string]interface{} "Reindex started"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 409 {object} map[string]string "A reindex is already in progress"
+// @Router /admin/search/reindex [post]
+func (h *SearchHandler) ReindexSearch(c *gin.Context) {
+	if err := h.SearchIndexer.StartReindex(); err == services.ErrReindexInProgress {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "reindex started",
+		"engine":  h.SearchIndexer.Index().Engine(),
+	})
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+type ShareImageHandler struct {
+	RecipeStore      store.RecipeStore
+	RecipeMediaStore store.RecipeMediaStore
+	ReviewStore      store.ReviewStore
+	UserStore        store.UserStore
+	ShareImages      *services.ShareImageService
+}
+
+func NewShareImageHandler(recipeStore store.RecipeStore, recipeMediaStore store.RecipeMediaStore, reviewStore store.ReviewStore, userStore store.UserStore, shareImages *services.ShareImageService) *ShareImageHandler {
+	return &ShareImageHandler{
+		RecipeStore:      recipeStore,
+		RecipeMediaStore: recipeMediaStore,
+		ReviewStore:      reviewStore,
+		UserStore:        userStore,
+		ShareImages:      shareImages,
+	}
+}
+
+// GetRecipeOGImage godoc
+// @Summary Recipe share image
+// @Description Returns a 1200x630 PNG for social sharing, showing the recipe's primary photo with its title and rating overlaid. Images are rendered on first request and cached in storage until the title, rating or photo changes. Drafts are only visible to their author.
+// @Tags Recipes
+// @Produce png
+// @Param id path int true "Recipe ID"
+// @Success 200 {file} file "PNG image"
+// @Failure 400 {object} map[string]string "Invalid recipe ID"
+// @Failure 404 {object} map[string]string "Recipe not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /recipes/{id}/og-image.png [get]
+func (h *ShareImageHandler) GetRecipeOGImage(c *gin.Context) {
+	recipeID, ok := parseIDParam(c, "id", "recipe ID")
+	if !ok {
+		return
+	}
+
+	viewerID, err := getOptionalInternalUserID(c, h.UserStore)
+	if err != nil {
+		log.Printf("Failed to resolve viewer: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	recipe, err := h.RecipeStore.GetRecipeByID(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if recipe == nil || !canViewRecipe(recipe, viewerID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "recipe not found"})
+		return
+	}
+
+	summary, err := h.ReviewStore.GetRecipeRatingSummary(recipeID, ratingSummaryOptions())
+	if err != nil {
+		log.Printf("Failed to fetch recipe rating summary: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	photos, err := h.RecipeMediaStore.GetRecipePhotos(recipeID)
+	if err != nil {
+		log.Printf("Failed to fetch recipe photos: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	// Show the weighted rating when verified reviews are configured to count more
+	rating := summary.AverageRating
+	if summary.WeightedAverageRating != nil {
+		rating = summary.WeightedAverageRating
+	}
+
+	image, err := h.ShareImages.Image(services.ShareCard{
+		RecipeID:      recipeID,
+		Title:         recipe.Title,
+		AverageRating: rating,
+		ReviewCount:   summary.ReviewCount,
+		PhotoKey:      sharePhotoKey(photos),
+	})
+	if err != nil {
+		log.Printf("Failed to render share image: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+// sharePhotoKey picks the photo shown in a share image: the primary photo if it was uploaded, otherwise the first uploaded one
+// Photos only referenced by an external URL are skipped, since they cannot be read from storage
+func sharePhotoKey(photos []*store.RecipePhoto) *string {
+	var first *string
+	for _, photo := range photos {
+		if photo.StorageKey == nil {
+			continue
+		}
+		if photo.IsPrimary {
+			return photo.StorageKey
+		}
+		if first == nil {
+			first = photo.StorageKey
+		}
+	}
+	return first
+}
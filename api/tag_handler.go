@@ -0,0 +1,133 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPopularTagsLimit is the number of tags returned when no limit is given
+	DefaultPopularTagsLimit = 20
+
+	// MaxPopularTagsLimit caps the number of tags returned
+	MaxPopularTagsLimit = 100
+
+	// MaxPopularTagsDays is the longest time window, in days, popular tags can be counted over
+	MaxPopularTagsDays = 365
+
+	// PopularTagsCacheTTL is how long a popular tags result is reused before it is recounted
+	PopularTagsCacheTTL = time.Minute
+)
+
+type TagHandler struct {
+	RecipeTaxonomyStore store.RecipeTaxonomyStore
+
+	mu    sync.Mutex
+	cache map[string]popularTagsEntry
+}
+
+type popularTagsEntry struct {
+	tags      []*store.PopularTag
+	expiresAt time.Time
+}
+
+func NewTagHandler(recipeTaxonomyStore store.RecipeTaxonomyStore) *TagHandler {
+	return &TagHandler{
+		RecipeTaxonomyStore: recipeTaxonomyStore,
+		cache:               make(map[string]popularTagsEntry),
+	}
+}
+
+// GetPopularTags godoc
+// @Summary Popular tags
+// @Description Returns the tags used by the most published recipes with their recipe counts, optionally counting only recipes published in the last given number of days. Results are cached for a minute.
+// @Tags Recipes
+// @Produce json
+// @Param days query int false "Only count recipes published in the last this many days (max 365)"
+// @Param limit query int false "Maximum number of tags (default 20, max 100)"
+// @Success 200 {object} map[string]interface{} "Popular tags"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /tags/popular [get]
+func (h *TagHandler) GetPopularTags(c *gin.Context) {
+	limit, ok := parseLimitQuery(c, DefaultPopularTagsLimit, MaxPopularTagsLimit)
+	if !ok {
+		return
+	}
+
+	days := 0
+	if daysParam := c.Query("days"); daysParam != "" {
+		var err error
+		days, err = strconv.Atoi(daysParam)
+		if err != nil || days <= 0 || days > MaxPopularTagsDays {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("days must be between 1 and %d", MaxPopularTagsDays)})
+			return
+		}
+	}
+
+	key := fmt.Sprintf("%d:%d", days, limit)
+	tags, cached := h.cachedPopularTags(key)
+	if !cached {
+		var since *time.Time
+		if days > 0 {
+			t := time.Now().AddDate(0, 0, -days)
+			since = &t
+		}
+
+		var err error
+		tags, err = h.RecipeTaxonomyStore.GetPopularTags(since, limit)
+		if err != nil {
+			log.Printf("Failed to get popular tags: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		h.cachePopularTags(key, tags)
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(PopularTagsCacheTTL.Seconds())))
+	response := gin.H{"tags": tags}
+	if days > 0 {
+		response["days"] = days
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// InvalidatePopularTags drops every cached result so the next request recounts
+func (h *TagHandler) InvalidatePopularTags() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	clear(h.cache)
+}
+
+// cachedPopularTags returns an unexpired result for key
+func (h *TagHandler) cachedPopularTags(key string) ([]*store.PopularTag, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entry, ok := h.cache[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.tags, true
+}
+
+// cachePopularTags stores a result for key, dropping expired entries so the cache stays small
+func (h *TagHandler) cachePopularTags(key string, tags []*store.PopularTag) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range h.cache {
+		if now.After(entry.expiresAt) {
+			delete(h.cache, k)
+		}
+	}
+	h.cache[key] = popularTagsEntry{tags: tags, expiresAt: now.Add(PopularTagsCacheTTL)}
+}
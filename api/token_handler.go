@@ -0,0 +1,373 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// MaxAPIKeysPerUser caps how many active API keys a user can hold
+	MaxAPIKeysPerUser = 10
+
+	// MaxAPIKeyNameLength caps the length of an API key's label
+	MaxAPIKeyNameLength = 100
+
+	// ImpersonationTokenDuration is how long an admin impersonation token stays valid
+	ImpersonationTokenDuration = 10 * time.Minute
+)
+
+// TokenHandler mints scope-restricted access tokens for API keys and admin impersonation
+type TokenHandler struct {
+	APIKeyStore store.APIKeyStore
+	UserStore   store.UserStore
+	JWTService  *services.JWTService
+}
+
+func NewTokenHandler(apiKeyStore store.APIKeyStore, userStore store.UserStore, jwtService *services.JWTService) *TokenHandler {
+	return &TokenHandler{
+		APIKeyStore: apiKeyStore,
+		UserStore:   userStore,
+		JWTService:  jwtService,
+	}
+}
+
+type createAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type exchangeAPIKeyRequest struct {
+	APIKey string `json:"api_key"`
+}
+
+type impersonateRequest struct {
+	Scopes []string `json:"scopes"`
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Creates a personal API key limited to the given scopes. The key is returned once and cannot be shown again. Scopes must be ones the caller's role allows. Only available from a signed-in session, not from API-key or impersonation tokens.
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param request body createAPIKeyRequest true "Key name and scopes"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "API key created"
+// @Failure 400 {object} map[string]string "Invalid request or scopes"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/api-keys [post]
+func (h *TokenHandler) CreateAPIKey(c *gin.Context) {
+	userID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	internalID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req createAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+	if len(name) > MaxAPIKeyNameLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("name must be at most %d characters", MaxAPIKeyNameLength)})
+		return
+	}
+
+	role, err := h.UserStore.GetUserRole(userID)
+	if err != nil {
+		log.Printf("Failed to get user role: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	scopes, ok := validateRequestedScopes(c, req.Scopes, services.ScopesForRole(role))
+	if !ok {
+		return
+	}
+
+	existing, err := h.APIKeyStore.GetAPIKeys(internalID)
+	if err != nil {
+		log.Printf("Failed to get API keys: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if len(existing) >= MaxAPIKeysPerUser {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("you can have at most %d active API keys", MaxAPIKeysPerUser)})
+		return
+	}
+
+	apiKey, key, err := h.APIKeyStore.CreateAPIKey(internalID, name, scopes)
+	if err != nil {
+		log.Printf("Failed to create API key: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create API key"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "API key created; store it now, it will not be shown again",
+		"api_key": key,
+		"key":     apiKey,
+	})
+}
+
+// GetAPIKeys godoc
+// @Summary List API keys
+// @Description Returns the authenticated user's active API keys. Only the first characters of each key are shown.
+// @Tags API Keys
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "API keys"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/api-keys [get]
+func (h *TokenHandler) GetAPIKeys(c *gin.Context) {
+	internalID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	apiKeys, err := h.APIKeyStore.GetAPIKeys(internalID)
+	if err != nil {
+		log.Printf("Failed to get API keys: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get API keys"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"api_keys": apiKeys})
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Revokes one of the authenticated user's API keys. Tokens already exchanged for the key stay valid until they expire.
+// @Tags API Keys
+// @Produce json
+// @Param id path int true "API key ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "API key revoked"
+// @Failure 400 {object} map[string]string "Invalid API key ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 404 {object} map[string]string "API key not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/api-keys/{id} [delete]
+func (h *TokenHandler) RevokeAPIKey(c *gin.Context) {
+	keyID, ok := parseIDParam(c, "id", "API key ID")
+	if !ok {
+		return
+	}
+
+	internalID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	err := h.APIKeyStore.RevokeAPIKey(keyID, internalID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to revoke API key: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke API key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
+
+// ExchangeAPIKey godoc
+// @Summary Exchange an API key for an access token
+// @Description Returns a short-lived access token limited to the key's scopes. Scopes the owner's role no longer allows are dropped.
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param request body exchangeAPIKeyRequest true "API key"
+// @Success 200 {object} map[string]interface{} "Scoped access token"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Invalid or revoked API key"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auth/token/api-key [post]
+func (h *TokenHandler) ExchangeAPIKey(c *gin.Context) {
+	var req exchangeAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	key := strings.TrimSpace(req.APIKey)
+	if !strings.HasPrefix(key, store.APIKeyPrefix) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid API key"})
+		return
+	}
+
+	apiKey, err := h.APIKeyStore.UseAPIKey(key)
+	if err != nil {
+		log.Printf("Failed to look up API key: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if apiKey == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid API key"})
+		return
+	}
+
+	user, err := h.UserStore.GetUserByID(apiKey.PublicID)
+	if err != nil {
+		log.Printf("Failed to get API key owner: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if user == nil || user.Disabled {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid API key"})
+		return
+	}
+
+	accessToken, scopes, expiresAt, err := h.JWTService.GenerateScopedAccessToken(user, services.ScopedTokenOptions{
+		Scopes:   apiKey.Scopes,
+		APIKeyID: apiKey.ID,
+	})
+	if err != nil {
+		log.Printf("Failed to mint API key token: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "API key has no usable scopes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_at":   expiresAt,
+		"scopes":       scopes,
+	})
+}
+
+// ImpersonateUser godoc
+// @Summary Impersonate a user
+// @Description Mints a short-lived access token acting as another user, for support and debugging. The token records the admin who minted it, can only carry non-admin scopes (all of them by default), and cannot manage API keys or credentials. Administrators cannot be impersonated.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param user_id path string true "Public user ID"
+// @Param request body impersonateRequest false "Optional subset of scopes"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Impersonation token"
+// @Failure 400 {object} map[string]string "Invalid scopes"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Admin access required or target is an admin"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/users/{user_id}/impersonate [post]
+func (h *TokenHandler) ImpersonateUser(c *gin.Context) {
+	adminID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional; without one the token gets every non-admin scope
+	var req impersonateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	requested := req.Scopes
+	if len(requested) == 0 {
+		requested = services.UserScopes
+	}
+	scopes, ok := validateRequestedScopes(c, requested, services.UserScopes)
+	if !ok {
+		return
+	}
+
+	targetID := c.Param("user_id")
+	if targetID == adminID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "you cannot impersonate yourself"})
+		return
+	}
+
+	user, err := h.UserStore.GetUserByID(targetID)
+	if err != nil {
+		log.Printf("Failed to get user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+
+	role, err := h.UserStore.GetUserRole(targetID)
+	if err != nil {
+		log.Printf("Failed to get user role: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if role == store.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "administrators cannot be impersonated"})
+		return
+	}
+
+	accessToken, scopes, expiresAt, err := h.JWTService.GenerateScopedAccessToken(user, services.ScopedTokenOptions{
+		Scopes:         scopes,
+		Duration:       ImpersonationTokenDuration,
+		ImpersonatorID: adminID,
+	})
+	if err != nil {
+		log.Printf("Failed to mint impersonation token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create impersonation token"})
+		return
+	}
+
+	log.Printf("Admin %s started impersonating user %s with scopes %v", adminID, targetID, scopes)
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_at":   expiresAt,
+		"scopes":       scopes,
+		"user_id":      user.UserID,
+		"username":     user.Username,
+	})
+}
+
+// validateRequestedScopes checks that at least one scope was requested and that all are allowed
+// It writes a 400 response and returns false if validation fails
+func validateRequestedScopes(c *gin.Context, requested, allowed []string) ([]string, bool) {
+	if len(requested) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one scope is required"})
+		return nil, false
+	}
+
+	scopes, denied := services.RestrictScopes(requested, allowed)
+	if len(denied) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":          fmt.Sprintf("scope not allowed: %s", strings.Join(denied, ", ")),
+			"allowed_scopes": allowed,
+		})
+		return nil, false
+	}
+
+	return scopes, true
+}
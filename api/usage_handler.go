@@ -0,0 +1,183 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/middleware"
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultUsageDays and MaxUsageDays bound how many days of usage are returned
+	DefaultUsageDays = 30
+	MaxUsageDays     = 90
+)
+
+type UsageHandler struct {
+	UsageStore   store.UsageStore
+	Usage        *services.UsageService
+	Quota        *services.QuotaService
+	UserStore    store.UserStore
+	PublicAccess *middleware.PublicAccess
+}
+
+func NewUsageHandler(usageStore store.UsageStore, usage *services.UsageService, quota *services.QuotaService, userStore store.UserStore, publicAccess *middleware.PublicAccess) *UsageHandler {
+	return &UsageHandler{
+		UsageStore:   usageStore,
+		Usage:        usage,
+		Quota:        quota,
+		UserStore:    userStore,
+		PublicAccess: publicAccess,
+	}
+}
+
+// GetMyUsage godoc
+// @Summary Get my API usage
+// @Description Returns the authenticated user's daily request counts for the last days (UTC), broken down by metric and by the API key used, along with their progress against any daily quotas. The request metric counts every authenticated request; export and import count heavy operations such as CSV exports.
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Number of days to return (default 30, max 90)"
+// @Success 200 {object} map[string]interface{} "Daily usage and quotas"
+// @Failure 400 {object} map[string]string "Invalid days"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/usage [get]
+func (h *UsageHandler) GetMyUsage(c *gin.Context) {
+	days := DefaultUsageDays
+	if value := c.Query("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a positive integer"})
+			return
+		}
+		days = min(parsed, MaxUsageDays)
+	}
+
+	publicID, ok := getAuthenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	// Include this instance's counts that are not yet flushed; a failure only makes the numbers slightly stale
+	if err := h.Usage.Flush(); err != nil {
+		log.Printf("Failed to flush API usage: %v", err)
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -(days - 1))
+	usage, err := h.UsageStore.GetUsage(userID, since)
+	if err != nil {
+		log.Printf("Failed to get usage: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	quotas, err := h.Usage.Quotas(publicID)
+	if err != nil {
+		log.Printf("Failed to get usage quotas: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"days":   days,
+		"since":  since.Format(time.DateOnly),
+		"usage":  usage,
+		"quotas": quotas,
+	})
+}
+
+// GetLimits godoc
+// @Summary Get my rate limits and quotas
+// @Description Describes the rate limits and quotas that apply to the caller, with what remains of each, so API clients can throttle themselves. Anonymous callers get the limits keyed by their IP address, including the rate limit on anonymous reads of public endpoints; signed-in callers, including API keys, also get the limits keyed by their email address, their rolling creation quotas and their daily usage quotas. Quotas that are disabled are left out. Quota-checked endpoints also return X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (Unix seconds) headers, and X-RateLimit-Warning once 80% of a limit is used. Limits with a burst allowance accept burst more requests beyond the limit before refusing any.
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Rate limits and quotas"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /limits [get]
+func (h *UsageHandler) GetLimits(c *gin.Context) {
+	rateLimits := gin.H{
+		"password_reset_per_ip": middleware.PasswordResetIPRateLimit(c.ClientIP()),
+	}
+
+	publicID := c.GetString("user_id")
+	if publicID == "" {
+		if status := h.PublicAccess.Status(c.ClientIP()); status != nil {
+			rateLimits["anonymous_reads_per_ip"] = status
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"authenticated": false,
+			"rate_limits":   rateLimits,
+		})
+		return
+	}
+
+	user, err := h.UserStore.GetUserByID(publicID)
+	if err != nil {
+		log.Printf("Failed to get user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+		return
+	}
+	rateLimits["emails_per_address"] = middleware.EmailRateLimit(user.Email)
+
+	userID, err := h.UserStore.GetUserInternalID(publicID)
+	if err != nil {
+		log.Printf("Failed to resolve user ID %s: %v", publicID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	creationQuotas, err := h.Quota.Quotas(userID)
+	if err != nil {
+		log.Printf("Failed to get creation quotas: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	dailyQuotas, err := h.Usage.Quotas(publicID)
+	if err != nil {
+		log.Printf("Failed to get usage quotas: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"authenticated":   true,
+		"rate_limits":     rateLimits,
+		"creation_quotas": creationQuotas,
+		"daily_quotas":    dailyQuotas,
+	})
+}
+
+// checkUsageQuota records a metered operation for the signed-in user, writing a 429 response if their daily quota is used up
+// Anonymous requests are not metered. It returns true if the request may proceed
+func checkUsageQuota(c *gin.Context, usage *services.UsageService, metric string) bool {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		return true
+	}
+
+	quota, err := usage.CheckDailyQuota(userID, c.GetInt64("api_key_id"), metric)
+	if !checkQuota(c, err) {
+		return false
+	}
+	if quota != nil {
+		middleware.SetRateLimitHeaders(c, quota.Limit, quota.Remaining, &quota.ResetsAt)
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"database/sql"
 	"log"
 	"net/http"
 	"strings"
@@ -32,16 +33,17 @@ type UpdateUserRequest struct {
 	LastName        *string `json:"last_name,omitempty"`
 	Bio             *string `json:"bio,omitempty"`
 	ProfilePicture  *string `json:"profile_picture,omitempty"`
+	Timezone        *string `json:"timezone,omitempty" example:"Europe/Paris"`
 }
 
 type UpdatePasswordRequest struct {
-	CurrentPassword string `json:"current_password" binding:"required"`
+	CurrentPassword string `json:"current_password"`
 	Password        string `json:"password" binding:"required"`
 }
 
 // UpdateUser godoc
 // @Summary Update user profile
-// @Description Update the authenticated user's profile information
+// @Description Update the authenticated user's profile information. timezone is an IANA time zone name such as Europe/Paris, used for meal plan dates and digest email times; an empty string resets it to UTC
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -163,6 +165,18 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		changes["bio"] = strings.TrimSpace(*req.Bio)
 	}
 
+	if req.Timezone != nil {
+		timezone := strings.TrimSpace(*req.Timezone)
+		if timezone == "" {
+			changes["timezone"] = nil
+		} else if !utils.IsValidTimezone(timezone) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "timezone must be an IANA time zone name such as Europe/Paris"})
+			return
+		} else {
+			changes["timezone"] = timezone
+		}
+	}
+
 	// If no changes to update
 	if len(changes) <= 1 { // Only updated_at is present
 		c.JSON(http.StatusOK, gin.H{
@@ -171,12 +185,15 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 				"user_id":         user.UserID,
 				"username":        user.Username,
 				"email":           user.Email,
+				"verified_chef":   user.VerifiedChef,
 				"bio":             user.Bio,
 				"first_name":      user.FirstName,
 				"last_name":       user.LastName,
 				"profile_picture": user.ProfilePicture,
+				"timezone":        user.Timezone,
 				"created_at":      user.CreatedAt,
 				"updated_at":      user.UpdatedAt,
+				"_links":          currentUserLinks(user.Username),
 			},
 		})
 		return
@@ -197,19 +214,22 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 			"user_id":         updatedUser.UserID,
 			"username":        updatedUser.Username,
 			"email":           updatedUser.Email,
+			"verified_chef":   updatedUser.VerifiedChef,
 			"bio":             updatedUser.Bio,
 			"first_name":      updatedUser.FirstName,
 			"last_name":       updatedUser.LastName,
 			"profile_picture": updatedUser.ProfilePicture,
+			"timezone":        updatedUser.Timezone,
 			"created_at":      updatedUser.CreatedAt,
 			"updated_at":      updatedUser.UpdatedAt,
+			"_links":          currentUserLinks(updatedUser.Username),
 		},
 	})
 }
 
 // UpdatePassword godoc
 // @Summary Update user password
-// @Description Update the authenticated user's password
+// @Description Update the authenticated user's password. Accounts created by signing in with an OAuth provider have no password until they set one here, and leave current_password empty to do so.
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -256,10 +276,12 @@ func (h *UserHandler) UpdatePassword(c *gin.Context) {
 		return
 	}
 
-	// Verify current password
-	if err := user.PasswordHash.CheckPassword(req.CurrentPassword); err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid current password"})
-		return
+	// Verify current password; accounts created through an OAuth provider have none yet and set their first one here
+	if user.HasPassword {
+		if err := user.PasswordHash.CheckPassword(req.CurrentPassword); err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid current password"})
+			return
+		}
 	}
 
 	// Validate new password strength
@@ -269,53 +291,29 @@ func (h *UserHandler) UpdatePassword(c *gin.Context) {
 	}
 
 	// Check that new password is different from the current one
-	if req.CurrentPassword == req.Password {
+	if user.HasPassword && req.CurrentPassword == req.Password {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "new password must be different from current password"})
 		return
 	}
 
-	// Start a transaction for password update and token revocation
-	db := h.UserStore.DB()
-	tx, err := db.Begin()
-	if err != nil {
-		log.Printf("Failed to begin transaction: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
-		return
-	}
-	
-	// Defer a function to handle transaction completion based on success or failure
-	defer func() {
+	// Update the password and revoke every session together, so old sessions cannot outlive the old password
+	err = store.WithTx(c.Request.Context(), h.UserStore.DB(), func(tx *sql.Tx) error {
+		if err := h.UserStore.UpdatePasswordWithTransaction(userID, req.Password, tx); err != nil {
+			return err
+		}
+
+		revokedCount, err := h.JWTService.RevokeAllUserRefreshTokensWithTransaction(userID, tx)
 		if err != nil {
-			// If we encountered an error, roll back the transaction
-			if txErr := tx.Rollback(); txErr != nil {
-				log.Printf("Failed to rollback transaction: %v", txErr)
-			}
+			return err
 		}
-	}()
-	
-	// Update the password
-	err = h.UserStore.UpdatePassword(userID, req.Password)
+		log.Printf("Revoked %d refresh tokens for user %s after password change", revokedCount, userID)
+		return nil
+	})
 	if err != nil {
 		log.Printf("Failed to update password: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update password"})
 		return
 	}
-	
-	// Revoke all refresh tokens for this user to invalidate all sessions
-	revokedCount, err := h.JWTService.RevokeAllUserRefreshTokens(userID)
-	if err != nil {
-		log.Printf("Failed to revoke refresh tokens: %v", err)
-		// Continue with the password change even if token revocation fails
-	} else {
-		log.Printf("Revoked %d refresh tokens for user %s after password change", revokedCount, userID)
-	}
-	
-	// Commit the transaction
-	if err = tx.Commit(); err != nil {
-		log.Printf("Failed to commit transaction: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
-		return
-	}
 
 	// Send password changed email notification if email service is available
 	if h.EmailService != nil {
@@ -0,0 +1,526 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// MaxWebhooksPerUser caps how many webhook endpoints a user can register
+	MaxWebhooksPerUser = 10
+
+	// MaxWebhookURLLength caps the length of a webhook endpoint's URL
+	MaxWebhookURLLength = 2048
+
+	// MaxWebhookDescriptionLength caps the length of a webhook endpoint's description
+	MaxWebhookDescriptionLength = 200
+)
+
+// webhookDeliveryStatuses are the statuses deliveries can be filtered by
+var webhookDeliveryStatuses = []string{store.WebhookDeliveryPending, store.WebhookDeliverySucceeded, store.WebhookDeliveryFailed}
+
+// WebhookHandler manages the webhook endpoints users register to hear about changes to their recipes
+type WebhookHandler struct {
+	WebhookStore   store.WebhookStore
+	WebhookService *services.WebhookService
+	UserStore      store.UserStore
+}
+
+func NewWebhookHandler(webhookStore store.WebhookStore, webhookService *services.WebhookService, userStore store.UserStore) *WebhookHandler {
+	return &WebhookHandler{
+		WebhookStore:   webhookStore,
+		WebhookService: webhookService,
+		UserStore:      userStore,
+	}
+}
+
+type createWebhookRequest struct {
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Events      []string `json:"events"`
+}
+
+type updateWebhookRequest struct {
+	URL         *string   `json:"url,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Events      *[]string `json:"events,omitempty"`
+	Enabled     *bool     `json:"enabled,omitempty"`
+}
+
+// validateWebhookFields checks an endpoint's URL, description and events, writing a 400 if any is invalid
+func (h *WebhookHandler) validateWebhookFields(c *gin.Context, endpoint *store.WebhookEndpoint) bool {
+	if len(endpoint.URL) > MaxWebhookURLLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("url must be at most %d characters", MaxWebhookURLLength)})
+		return false
+	}
+	webhookURL, err := h.WebhookService.ValidateURL(endpoint.URL)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	endpoint.URL = webhookURL
+
+	endpoint.Description = strings.TrimSpace(endpoint.Description)
+	if len(endpoint.Description) > MaxWebhookDescriptionLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("description must be at most %d characters", MaxWebhookDescriptionLength)})
+		return false
+	}
+
+	events, err := services.NormalizeEvents(endpoint.Events)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "events": services.WebhookEvents})
+		return false
+	}
+	endpoint.Events = events
+
+	return true
+}
+
+// loadWebhook fetches one of the user's endpoints from the id path parameter, writing a 404 if it is missing
+func (h *WebhookHandler) loadWebhook(c *gin.Context, userID int64) (*store.WebhookEndpoint, bool) {
+	endpointID, ok := parseIDParam(c, "id", "webhook ID")
+	if !ok {
+		return nil, false
+	}
+
+	endpoint, err := h.WebhookStore.GetWebhookEndpoint(endpointID, userID)
+	if err != nil {
+		log.Printf("Failed to get webhook endpoint: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return nil, false
+	}
+	if endpoint == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+		return nil, false
+	}
+
+	return endpoint, true
+}
+
+// CreateWebhook godoc
+// @Summary Register a webhook endpoint
+// @Description Registers an https URL to be sent recipe.created, recipe.updated and recipe.deleted events for the authenticated user's recipes. Leave events empty to receive all of them. Each delivery is signed with the endpoint's secret, which is returned once and cannot be shown again. Only available from a signed-in session, not from API-key or impersonation tokens.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param request body createWebhookRequest true "Endpoint URL, description and events"
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "Webhook created"
+// @Failure 400 {object} map[string]string "Invalid URL or events, or too many webhooks"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	var req createWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endpoint := &store.WebhookEndpoint{
+		UserID:      userID,
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      req.Events,
+	}
+	if !h.validateWebhookFields(c, endpoint) {
+		return
+	}
+
+	existing, err := h.WebhookStore.GetWebhookEndpoints(userID)
+	if err != nil {
+		log.Printf("Failed to get webhook endpoints: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if len(existing) >= MaxWebhooksPerUser {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("you can have at most %d webhooks", MaxWebhooksPerUser)})
+		return
+	}
+
+	secret, err := h.WebhookService.NewSecret()
+	if err != nil {
+		log.Printf("Failed to create webhook secret: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	endpoint.Secret = secret
+
+	if err := h.WebhookStore.CreateWebhookEndpoint(endpoint); err != nil {
+		log.Printf("Failed to create webhook endpoint: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create webhook"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Webhook created; store the secret now, it will not be shown again",
+		"secret":  secret,
+		"webhook": endpoint,
+	})
+}
+
+// GetWebhooks godoc
+// @Summary List webhook endpoints
+// @Description Returns the authenticated user's webhook endpoints, including whether each is enabled and why it was disabled. Secrets are never shown.
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Webhooks and the events they can subscribe to"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/webhooks [get]
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	endpoints, err := h.WebhookStore.GetWebhookEndpoints(userID)
+	if err != nil {
+		log.Printf("Failed to get webhook endpoints: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get webhooks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": endpoints, "events": services.WebhookEvents})
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook endpoint
+// @Description Returns one of the authenticated user's webhook endpoints
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Security BearerAuth
+// @Success 200 {object} store.WebhookEndpoint "Webhook"
+// @Failure 400 {object} map[string]string "Invalid webhook ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 404 {object} map[string]string "Webhook not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	endpoint, ok := h.loadWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook endpoint
+// @Description Changes an endpoint's URL, description or event filter, or enables or disables it. Only the fields sent are changed. Re-enabling an endpoint that was disabled after repeated failures resets its failure count; deliveries that failed while it was disabled are not resent automatically, but can be redelivered from the delivery log.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param request body updateWebhookRequest true "Fields to change"
+// @Security BearerAuth
+// @Success 200 {object} store.WebhookEndpoint "Updated webhook"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 404 {object} map[string]string "Webhook not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/webhooks/{id} [patch]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	endpoint, ok := h.loadWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	var req updateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.URL != nil {
+		endpoint.URL = *req.URL
+	}
+	if req.Description != nil {
+		endpoint.Description = *req.Description
+	}
+	if req.Events != nil {
+		endpoint.Events = *req.Events
+	}
+	if req.Enabled != nil {
+		endpoint.Enabled = *req.Enabled
+	}
+	if !h.validateWebhookFields(c, endpoint) {
+		return
+	}
+
+	err := h.WebhookStore.UpdateWebhookEndpoint(endpoint)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to update webhook endpoint: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update webhook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook endpoint
+// @Description Deletes one of the authenticated user's webhook endpoints along with its delivery log. Pending deliveries are not sent.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]string "Webhook deleted"
+// @Failure 400 {object} map[string]string "Invalid webhook ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 404 {object} map[string]string "Webhook not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	endpointID, ok := parseIDParam(c, "id", "webhook ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	err := h.WebhookStore.DeleteWebhookEndpoint(endpointID, userID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to delete webhook endpoint: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete webhook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted"})
+}
+
+// RotateWebhookSecret godoc
+// @Summary Rotate a webhook endpoint's secret
+// @Description Generates a new signing secret, returned once. For 24 hours deliveries carry signatures from both the new and the previous secret, so receivers can switch over without rejecting events.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "New secret"
+// @Failure 400 {object} map[string]string "Invalid webhook ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 404 {object} map[string]string "Webhook not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/webhooks/{id}/rotate-secret [post]
+func (h *WebhookHandler) RotateWebhookSecret(c *gin.Context) {
+	endpointID, ok := parseIDParam(c, "id", "webhook ID")
+	if !ok {
+		return
+	}
+
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	endpoint, err := h.WebhookService.RotateSecret(endpointID, userID)
+	if err != nil {
+		log.Printf("Failed to rotate webhook secret: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to rotate webhook secret"})
+		return
+	}
+	if endpoint == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Secret rotated; store it now, it will not be shown again",
+		"secret":  endpoint.Secret,
+		"webhook": endpoint,
+	})
+}
+
+// PingWebhook godoc
+// @Summary Send a test event to a webhook endpoint
+// @Description Queues a ping event to the endpoint, whatever events it subscribes to. Check the delivery log for the response.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Security BearerAuth
+// @Success 202 {object} store.WebhookDelivery "Test delivery queued"
+// @Failure 400 {object} map[string]string "Invalid webhook ID or webhook disabled"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 404 {object} map[string]string "Webhook not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/webhooks/{id}/ping [post]
+func (h *WebhookHandler) PingWebhook(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	endpoint, ok := h.loadWebhook(c, userID)
+	if !ok {
+		return
+	}
+	if !endpoint.Enabled {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "webhook is disabled; enable it first"})
+		return
+	}
+
+	delivery, err := h.WebhookService.Ping(endpoint)
+	if err != nil {
+		log.Printf("Failed to queue webhook ping: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary List a webhook endpoint's deliveries
+// @Description Returns the endpoint's delivery log, newest first: the event sent, how many attempts were made, and the response status, the first 1KB of the response body, or the connection error from the latest attempt.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param status query string false "Only deliveries with this status: pending, succeeded or failed"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Deliveries per page (default 20, max 100)"
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Deliveries and pagination"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 404 {object} map[string]string "Webhook not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	endpoint, ok := h.loadWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	status := c.Query("status")
+	if status != "" && !slices.Contains(webhookDeliveryStatuses, status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be pending, succeeded or failed"})
+		return
+	}
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+	limit, ok := parseLimitQuery(c, 20, 100)
+	if !ok {
+		return
+	}
+
+	deliveries, total, err := h.WebhookStore.GetWebhookDeliveries(endpoint.ID, status, page, limit)
+	if err != nil {
+		log.Printf("Failed to get webhook deliveries: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get webhook deliveries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"pagination": newPagination(page, limit, total),
+	})
+}
+
+// RedeliverWebhook godoc
+// @Summary Redeliver a webhook event
+// @Description Queues the event from an earlier delivery to be sent again with its original payload, so receivers can recognise the repeat by its event ID. The new delivery records which one it repeats.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param delivery_id path int true "Delivery ID"
+// @Security BearerAuth
+// @Success 202 {object} store.WebhookDelivery "Redelivery queued"
+// @Failure 400 {object} map[string]string "Invalid ID or webhook disabled"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Restricted token"
+// @Failure 404 {object} map[string]string "Webhook or delivery not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) RedeliverWebhook(c *gin.Context) {
+	userID, ok := getAuthenticatedInternalUserID(c, h.UserStore)
+	if !ok {
+		return
+	}
+
+	endpoint, ok := h.loadWebhook(c, userID)
+	if !ok {
+		return
+	}
+
+	deliveryID, ok := parseIDParam(c, "delivery_id", "delivery ID")
+	if !ok {
+		return
+	}
+
+	if !endpoint.Enabled {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "webhook is disabled; enable it first"})
+		return
+	}
+
+	delivery, err := h.WebhookStore.GetWebhookDelivery(deliveryID, endpoint.ID)
+	if err != nil {
+		log.Printf("Failed to get webhook delivery: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if delivery == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "delivery not found"})
+		return
+	}
+
+	redelivery, err := h.WebhookService.Redeliver(delivery)
+	if err != nil {
+		log.Printf("Failed to queue webhook redelivery: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, redelivery)
+}
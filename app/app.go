@@ -3,23 +3,61 @@ package app
 import (
 	"database/sql"
 	"log"
+	"os"
 
 	"github.com/dapoadedire/chefshare_be/api"
+	"github.com/dapoadedire/chefshare_be/middleware"
 	"github.com/dapoadedire/chefshare_be/migrations"
 	"github.com/dapoadedire/chefshare_be/services"
 	"github.com/dapoadedire/chefshare_be/store"
 )
 
 type Application struct {
-	DB                  *sql.DB
-	AuthHandler         *api.AuthHandler
-	UserHandler         *api.UserHandler
-	EmailService        *services.EmailService
-	UserStore           store.UserStore
-	PasswordResetStore  store.PasswordResetStore
-	RefreshTokenStore   store.RefreshTokenStore
-	TokenBlacklistStore store.TokenBlacklistStore
-	JWTService          *services.JWTService
+	DB                      *sql.DB
+	AuthHandler             *api.AuthHandler
+	OAuthHandler            *api.OAuthHandler
+	UserHandler             *api.UserHandler
+	RecipeHandler           *api.RecipeHandler
+	IngredientHandler       *api.IngredientHandler
+	PantryHandler           *api.PantryHandler
+	ShoppingListHandler     *api.ShoppingListHandler
+	MealPlanHandler         *api.MealPlanHandler
+	RecipeNoteHandler       *api.RecipeNoteHandler
+	RecipeCookHandler       *api.RecipeCookHandler
+	RecipeTemplateHandler   *api.RecipeTemplateHandler
+	CollectionHandler       *api.CuratedCollectionHandler
+	FeaturedRecipeHandler   *api.FeaturedRecipeHandler
+	SearchHandler           *api.SearchHandler
+	InvitationHandler       *api.InvitationHandler
+	ReferralHandler         *api.ReferralHandler
+	ReputationHandler       *api.ReputationHandler
+	NotificationHandler     *api.NotificationHandler
+	MessageHandler          *api.MessageHandler
+	BlockHandler            *api.BlockHandler
+	ChefApplicationHandler  *api.ChefApplicationHandler
+	MediaHandler            *api.MediaHandler
+	TokenHandler            *api.TokenHandler
+	DevEmailHandler         *api.DevEmailHandler
+	TagHandler              *api.TagHandler
+	ShareImageHandler       *api.ShareImageHandler
+	EmbedHandler            *api.EmbedHandler
+	UsageHandler            *api.UsageHandler
+	MetricsHandler          *api.MetricsHandler
+	AccountExportHandler    *api.AccountExportHandler
+	WebhookHandler          *api.WebhookHandler
+	EmailSuppressionHandler *api.EmailSuppressionHandler
+	ReviewModerationHandler *api.ReviewModerationHandler
+	EmailService            *services.EmailService
+	UserStore               store.UserStore
+	RecipeStore             store.RecipeStore
+	PasswordResetStore      store.PasswordResetStore
+	RefreshTokenStore       store.RefreshTokenStore
+	TokenBlacklistStore     store.TokenBlacklistStore
+	JWTService              *services.JWTService
+	UsageService            *services.UsageService
+	URLSigner               *services.URLSigner
+	HTTPMetrics             *services.HTTPMetrics
+	PublicAccess            *middleware.PublicAccess
 }
 
 func NewApplication() (*Application, error) {
@@ -34,8 +72,14 @@ func NewApplication() (*Application, error) {
 		panic(err)
 	}
 
+	// Site-wide daily metrics, also fed by the email service's send log
+	metricsStore := store.NewPostgresMetricsStore(pgDB)
+
+	// Addresses that bounced, complained or were opted out, checked before every email
+	emailSuppressionStore := store.NewPostgresEmailSuppressionStore(pgDB)
+
 	// Initialize email service
-	emailService, err := services.NewEmailService()
+	emailService, err := services.NewEmailService(metricsStore, emailSuppressionStore)
 	if err != nil {
 		log.Printf("Warning: Email service could not be initialized: %v", err)
 		// Continue without email service
@@ -46,32 +90,219 @@ func NewApplication() (*Application, error) {
 	refreshTokenStore := store.NewPostgresRefreshTokenStore(pgDB)
 	emailVerificationStore := store.NewPostgresEmailVerificationStore(pgDB)
 	tokenBlacklistStore := store.NewPostgresTokenBlacklistStore(pgDB)
+	// Recipe changes are published so derived caches and indexes are refreshed in one place
+	recipeEvents := services.NewRecipeEvents()
+	postgresRecipeStore := store.NewPostgresRecipeStore(pgDB)
+	recipeStore := services.NewRecipeEventStore(postgresRecipeStore, postgresRecipeStore, recipeEvents)
+	ingredientStore := store.NewPostgresIngredientStore(pgDB)
+	pantryStore := store.NewPostgresPantryStore(pgDB)
+	shoppingListStore := store.NewPostgresShoppingListStore(pgDB)
+	mealPlanStore := store.NewPostgresMealPlanStore(pgDB)
+	recipeNoteStore := store.NewPostgresRecipeNoteStore(pgDB)
+	recipeCookStore := store.NewPostgresRecipeCookStore(pgDB)
+	recipeActivityStore := store.NewPostgresRecipeActivityStore(pgDB)
+	recipePreviewStore := store.NewPostgresRecipePreviewStore(pgDB)
+	recipeDeletionStore := store.NewPostgresRecipeDeletionStore(pgDB)
+	recipeAllergenStore := store.NewPostgresRecipeAllergenStore(pgDB)
+	recipeStatsStore := store.NewPostgresRecipeStatsStore(pgDB)
+	recipeTemplateStore := store.NewPostgresRecipeTemplateStore(pgDB)
+	curatedCollectionStore := store.NewPostgresCuratedCollectionStore(pgDB)
+	featuredRecipeStore := store.NewPostgresFeaturedRecipeStore(pgDB)
+	searchStore := store.NewPostgresSearchStore(pgDB)
+	invitationStore := store.NewPostgresInvitationStore(pgDB)
+	referralStore := store.NewPostgresReferralStore(pgDB)
+	reputationStore := store.NewPostgresReputationStore(pgDB)
+	mentionStore := store.NewPostgresMentionStore(pgDB)
+	notificationStore := store.NewPostgresNotificationStore(pgDB)
+	messageStore := store.NewPostgresMessageStore(pgDB)
+	blockStore := store.NewPostgresBlockStore(pgDB)
+	chefApplicationStore := store.NewPostgresChefApplicationStore(pgDB)
+	apiKeyStore := store.NewPostgresAPIKeyStore(pgDB)
+	usageStore := store.NewPostgresUsageStore(pgDB)
+	accountExportStore := store.NewPostgresAccountExportStore(pgDB)
+	reviewModerationStore := store.NewPostgresReviewModerationStore(pgDB)
+	webhookStore := store.NewPostgresWebhookStore(pgDB)
+	identityStore := store.NewPostgresIdentityStore(pgDB)
 
 	// Initialize JWT service with default configuration
 	jwtConfig := services.DefaultJWTConfig()
 	jwtService := services.NewJWTService(jwtConfig, refreshTokenStore, userStore, tokenBlacklistStore)
 
+	// Initialize sign-in with the OAuth providers that have credentials configured
+	oauthService := services.NewOAuthService(services.DefaultOAuthConfig())
+
+	// Initialize signing for short-lived private media URLs
+	urlSigner := services.NewURLSigner(services.DefaultURLSignerConfig())
+
+	// Initialize file storage, falling back to local disk if the configured backend is unavailable
+	storage, err := services.NewStorageFromEnv(urlSigner)
+	if err != nil {
+		log.Printf("Warning: storage backend could not be initialized, using local disk: %v", err)
+		storage, err = services.NewLocalStorage(services.DefaultLocalStorageConfig(), urlSigner)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Initialize per-user creation quotas
+	quotaService := services.NewQuotaService(services.DefaultQuotaConfig(), recipeStore, postgresRecipeStore, invitationStore)
+
+	// Initialize per-user API usage metering and daily quotas for heavy operations
+	usageService := services.NewUsageService(services.DefaultUsageConfig(), usageStore)
+	usageService.Start()
+
+	// Count recipe views for authors' statistics, flushing them to the database in batches
+	recipeViewService := services.NewRecipeViewService(services.DefaultRecipeViewConfig(), recipeStatsStore)
+	recipeViewService.Start()
+
+	// Roll up each day's activity nightly for admin analytics
+	services.NewMetricsRollup(services.DefaultMetricsRollupConfig(), metricsStore).Start()
+
+	// Warn, disable and purge accounts that never verify their email, if configured
+	services.NewUnverifiedAccounts(services.DefaultUnverifiedAccountConfig(), userStore, emailVerificationStore, emailService).Start()
+
+	// Warn about and archive drafts their authors have stopped working on
+	services.NewStaleDrafts(services.DefaultStaleDraftConfig(), recipeStore, emailService).Start()
+
+	// Purge change feed entries, view counts and email records older than their retention windows
+	retentionPurger := services.NewRetentionPurger(services.DefaultRetentionConfig(), store.NewPostgresRetentionStore(pgDB))
+	retentionPurger.Start()
+
+	// Build account data exports in the background and keep their archives in file storage
+	accountExportService := services.NewAccountExportService(services.DefaultAccountExportConfig(), accountExportStore, storage, userStore, recipeStore, postgresRecipeStore, postgresRecipeStore, recipeCookStore, pantryStore, shoppingListStore, recipeStatsStore)
+	accountExportService.Start()
+
+	// Hide or delete reviews in bulk in the background, for cleaning up spam waves
+	reviewModerationService := services.NewReviewModerationService(services.DefaultReviewModerationConfig(), reviewModerationStore)
+	reviewModerationService.Start()
+
+	// Send recipe events to the webhook endpoints users register, retrying failed deliveries in the background
+	webhookService := services.NewWebhookService(services.DefaultWebhookConfig(), webhookStore, postgresRecipeStore)
+	webhookService.Start()
+
+	// Copy photos that recipes link to on other sites into file storage in the background
+	services.NewPhotoImporter(services.DefaultPhotoImportConfig(), postgresRecipeStore, storage).Start()
+
+	// Initialize in-app and email notifications for @mentions
+	notificationService := services.NewNotificationService(notificationStore, mentionStore, emailService)
+
+	// Email batched notifications in one digest per user once their batch windows close
+	services.NewNotificationDigest(services.DefaultNotificationDigestConfig(), notificationStore, emailService).Start()
+
+	// Initialize the search engine, falling back to PostgreSQL if the configured one is unavailable
+	searchIndex, err := services.NewSearchIndexFromEnv(searchStore)
+	if err != nil {
+		log.Printf("Warning: search engine could not be initialized, using postgres: %v", err)
+		searchIndex = services.NewPostgresSearchIndex(searchStore)
+	}
+	searchIndexer := services.NewSearchIndexer(services.DefaultSearchIndexerConfig(), searchIndex, searchStore)
+	searchIndexer.Start()
+
 	// This will be fully removed in a future update
 	authHandler := api.NewAuthHandler(
 		userStore,
 		refreshTokenStore,
 		passwordResetStore,
 		emailVerificationStore,
+		invitationStore,
+		referralStore,
 		emailService,
 		jwtService,
 	)
+	oauthHandler := api.NewOAuthHandler(userStore, identityStore, emailService, jwtService, oauthService)
 	userHandler := api.NewUserHandler(userStore, emailService, jwtService)
+	recipeHandler := api.NewRecipeHandler(recipeStore, postgresRecipeStore, recipeStore, postgresRecipeStore, userStore, ingredientStore, recipeNoteStore, recipeActivityStore, recipePreviewStore, recipeDeletionStore, recipeAllergenStore, quotaService, usageService, recipeViewService, notificationService, storage)
+	ingredientHandler := api.NewIngredientHandler(ingredientStore)
+	pantryHandler := api.NewPantryHandler(pantryStore, userStore)
+	shoppingListHandler := api.NewShoppingListHandler(shoppingListStore, userStore)
+	mealPlanHandler := api.NewMealPlanHandler(mealPlanStore, recipeStore, userStore)
+	recipeNoteHandler := api.NewRecipeNoteHandler(recipeNoteStore, recipeStore, userStore)
+	recipeCookHandler := api.NewRecipeCookHandler(recipeCookStore, recipeStore, userStore)
+	recipeTemplateHandler := api.NewRecipeTemplateHandler(recipeTemplateStore, recipeStore, userStore, quotaService)
+	collectionHandler := api.NewCuratedCollectionHandler(curatedCollectionStore)
+	featuredRecipeHandler := api.NewFeaturedRecipeHandler(featuredRecipeStore, recipeStore, userStore)
+	searchHandler := api.NewSearchHandler(searchStore, searchIndexer, storage)
+	invitationHandler := api.NewInvitationHandler(invitationStore, userStore, emailService, quotaService)
+	referralHandler := api.NewReferralHandler(referralStore, userStore)
+	reputationHandler := api.NewReputationHandler(reputationStore, recipeStore, postgresRecipeStore, userStore, usageService, notificationService, storage)
+	notificationHandler := api.NewNotificationHandler(notificationStore, userStore)
+	messageHandler := api.NewMessageHandler(messageStore, blockStore, userStore)
+	blockHandler := api.NewBlockHandler(blockStore, userStore)
+	chefApplicationHandler := api.NewChefApplicationHandler(chefApplicationStore, notificationStore, userStore)
+	tokenHandler := api.NewTokenHandler(apiKeyStore, userStore, jwtService)
+	mediaHandler := api.NewMediaHandler(storage)
+	devEmailHandler := api.NewDevEmailHandler(emailService)
+	tagHandler := api.NewTagHandler(recipeStore)
+	// Anonymous reads of public endpoints, limited per IP or turned off with PUBLIC_READ_ACCESS=false
+	publicAccess := middleware.NewPublicAccess(middleware.DefaultPublicAccessConfig())
+	usageHandler := api.NewUsageHandler(usageStore, usageService, quotaService, userStore, publicAccess)
+	// Request counts and durations per route, scraped by Prometheus with METRICS_TOKEN
+	httpMetrics := services.NewHTTPMetrics()
+	metricsHandler := api.NewMetricsHandler(metricsStore, httpMetrics, retentionPurger, os.Getenv("METRICS_TOKEN"))
+	accountExportHandler := api.NewAccountExportHandler(accountExportService, usageService, recipeStatsStore, userStore)
+	webhookHandler := api.NewWebhookHandler(webhookStore, webhookService, userStore)
+
+	// Bounces and complaints reported by Resend are only received with a signing secret to check them against
+	resendWebhookVerifier, err := services.NewResendWebhookVerifierFromEnv()
+	if err != nil {
+		log.Printf("Warning: Resend webhooks disabled: %v", err)
+	}
+	emailSuppressionHandler := api.NewEmailSuppressionHandler(emailSuppressionStore, userStore, resendWebhookVerifier)
+	reviewModerationHandler := api.NewReviewModerationHandler(reviewModerationService, reviewModerationStore, userStore)
+	shareImageHandler := api.NewShareImageHandler(recipeStore, postgresRecipeStore, postgresRecipeStore, userStore, services.NewShareImageService(storage))
+	embedHandler := api.NewEmbedHandler(recipeStore, postgresRecipeStore, postgresRecipeStore, storage)
+
+	// Share images are keyed by their content and need no invalidation
+	recipeEvents.Subscribe("search index", func(services.RecipeEvent) { searchIndexer.Notify() })
+	recipeEvents.Subscribe("popular tags", func(services.RecipeEvent) { tagHandler.InvalidatePopularTags() })
+	recipeEvents.Subscribe("webhooks", webhookService.HandleRecipeEvent)
 
 	app := &Application{
-		DB:                  pgDB,
-		AuthHandler:         authHandler,
-		UserHandler:         userHandler,
-		EmailService:        emailService,
-		UserStore:           userStore,
-		PasswordResetStore:  passwordResetStore,
-		RefreshTokenStore:   refreshTokenStore,
-		TokenBlacklistStore: tokenBlacklistStore,
-		JWTService:          jwtService,
+		DB:                      pgDB,
+		AuthHandler:             authHandler,
+		OAuthHandler:            oauthHandler,
+		UserHandler:             userHandler,
+		RecipeHandler:           recipeHandler,
+		IngredientHandler:       ingredientHandler,
+		PantryHandler:           pantryHandler,
+		ShoppingListHandler:     shoppingListHandler,
+		MealPlanHandler:         mealPlanHandler,
+		RecipeNoteHandler:       recipeNoteHandler,
+		RecipeCookHandler:       recipeCookHandler,
+		RecipeTemplateHandler:   recipeTemplateHandler,
+		CollectionHandler:       collectionHandler,
+		FeaturedRecipeHandler:   featuredRecipeHandler,
+		SearchHandler:           searchHandler,
+		InvitationHandler:       invitationHandler,
+		ReferralHandler:         referralHandler,
+		ReputationHandler:       reputationHandler,
+		NotificationHandler:     notificationHandler,
+		MessageHandler:          messageHandler,
+		BlockHandler:            blockHandler,
+		ChefApplicationHandler:  chefApplicationHandler,
+		TokenHandler:            tokenHandler,
+		MediaHandler:            mediaHandler,
+		DevEmailHandler:         devEmailHandler,
+		TagHandler:              tagHandler,
+		ShareImageHandler:       shareImageHandler,
+		EmbedHandler:            embedHandler,
+		UsageHandler:            usageHandler,
+		MetricsHandler:          metricsHandler,
+		AccountExportHandler:    accountExportHandler,
+		WebhookHandler:          webhookHandler,
+		EmailSuppressionHandler: emailSuppressionHandler,
+		ReviewModerationHandler: reviewModerationHandler,
+		EmailService:            emailService,
+		UserStore:               userStore,
+		RecipeStore:             recipeStore,
+		PasswordResetStore:      passwordResetStore,
+		RefreshTokenStore:       refreshTokenStore,
+		TokenBlacklistStore:     tokenBlacklistStore,
+		JWTService:              jwtService,
+		UsageService:            usageService,
+		URLSigner:               urlSigner,
+		HTTPMetrics:             httpMetrics,
+		PublicAccess:            publicAccess,
 	}
 
 	return app, nil
@@ -0,0 +1,513 @@
+// Command loadgen fills a development database with synthetic users, recipes, reviews and likes
+// so changes to recipe listing and search can be benchmarked against realistic volumes.
+//
+// Usage:
+//
+//	go run ./cmd/loadgen -users 20000 -recipes 100000 -reviews 1000000
+//	go run ./cmd/loadgen -purge
+//
+// Every generated account has a username starting with "loadgen_" and cannot log in.
+// -purge deletes those accounts, which cascades to everything they created.
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/migrations"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/google/uuid"
+)
+
+// usernamePrefix marks generated accounts so they can be purged without touching real data
+const usernamePrefix = "loadgen_"
+
+// unusablePasswordHash is not a valid bcrypt hash, so generated accounts can never sign in
+const unusablePasswordHash = "!loadgen"
+
+// maxParams keeps each multi-row INSERT under Postgres' limit of 65535 bind parameters
+const maxParams = 60000
+
+// history is how far back generated timestamps are spread
+const history = 2 * 365 * 24 * time.Hour
+
+type config struct {
+	users         int
+	recipes       int
+	reviews       int
+	likes         int
+	maxTags       int
+	publishedRate float64
+	batchSize     int
+	seed          int64
+	purge         bool
+}
+
+var categoryNames = []string{
+	"Breakfast", "Lunch", "Dinner", "Dessert", "Snack", "Soup", "Salad",
+	"Baking", "Drinks", "Side Dish", "Appetizer", "Sauce",
+}
+
+var tagNames = []string{
+	"quick", "easy", "healthy", "comfort-food", "spicy", "vegan", "vegetarian",
+	"gluten-free", "one-pot", "meal-prep", "kid-friendly", "budget", "grilling",
+	"slow-cooker", "holiday", "high-protein", "low-carb", "dairy-free", "party", "weeknight",
+}
+
+var titleAdjectives = []string{
+	"Classic", "Spicy", "Creamy", "Crispy", "Smoky", "Zesty", "Garlicky", "Rustic",
+	"Easy", "Hearty", "Light", "Honey", "Lemon", "Herbed", "Roasted", "Grandma's",
+}
+
+var titleIngredients = []string{
+	"Chicken", "Beef", "Salmon", "Tofu", "Mushroom", "Chickpea", "Shrimp", "Pork",
+	"Lentil", "Sweet Potato", "Spinach", "Tomato", "Eggplant", "Coconut", "Black Bean", "Pumpkin",
+}
+
+var titleDishes = []string{
+	"Curry", "Stew", "Pasta", "Tacos", "Stir-Fry", "Soup", "Salad", "Risotto",
+	"Burgers", "Casserole", "Skewers", "Pie", "Bowl", "Fried Rice", "Noodles", "Flatbread",
+}
+
+var reviewComments = []string{
+	"Turned out great, will make again.",
+	"Family loved it.",
+	"A bit bland for my taste, added more spice.",
+	"Took longer than the recipe says.",
+	"Perfect weeknight dinner.",
+	"Didn't work for me, the sauce was too thin.",
+	"Easy to follow and delicious.",
+	"Halved the sugar and it was still sweet enough.",
+	"",
+}
+
+func main() {
+	var cfg config
+	flag.IntVar(&cfg.users, "users", 10000, "number of users to create")
+	flag.IntVar(&cfg.recipes, "recipes", 100000, "number of recipes to create")
+	flag.IntVar(&cfg.reviews, "reviews", 1000000, "number of reviews to attempt (duplicates per user and recipe are skipped)")
+	flag.IntVar(&cfg.likes, "likes", 500000, "number of likes to attempt (duplicates per user and recipe are skipped)")
+	flag.IntVar(&cfg.maxTags, "max-tags", 4, "maximum tags per recipe")
+	flag.Float64Var(&cfg.publishedRate, "published-rate", 0.85, "fraction of recipes that are published")
+	flag.IntVar(&cfg.batchSize, "batch", 1000, "rows per INSERT statement")
+	flag.Int64Var(&cfg.seed, "seed", time.Now().UnixNano(), "random seed, for reproducible data sets")
+	flag.BoolVar(&cfg.purge, "purge", false, "delete all previously generated data and exit")
+	flag.Parse()
+
+	db, err := store.Open()
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	if cfg.purge {
+		result, err := db.Exec(`DELETE FROM users WHERE username LIKE $1`, usernamePrefix+"%")
+		if err != nil {
+			log.Fatalf("Failed to purge generated data: %v", err)
+		}
+		deleted, _ := result.RowsAffected()
+		log.Printf("Deleted %d generated users and everything they created", deleted)
+		return
+	}
+
+	if err := store.MigrateFS(db, migrations.FS, "."); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	g := &generator{
+		db:   db,
+		cfg:  cfg,
+		rand: rand.New(rand.NewSource(cfg.seed)),
+		now:  time.Now(),
+		run:  uuid.NewString()[:8],
+	}
+
+	log.Printf("Generating data with seed %d", cfg.seed)
+	start := time.Now()
+	if err := g.generate(); err != nil {
+		log.Fatalf("Load generation failed: %v", err)
+	}
+	log.Printf("Done in %s", time.Since(start).Round(time.Second))
+}
+
+type generator struct {
+	db   *sql.DB
+	cfg  config
+	rand *rand.Rand
+	now  time.Time
+	run  string
+
+	userIDs     []int64
+	categoryIDs []int64
+	tagIDs      []int64
+
+	// Published recipes are the only ones that receive reviews and likes
+	publishedIDs     []int64
+	publishedTimes   []time.Time
+	publishedAuthors []int64
+}
+
+func (g *generator) generate() error {
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{"categories and tags", g.seedLookups},
+		{"users", g.createUsers},
+		{"recipes", g.createRecipes},
+		{"reviews", g.createReviews},
+		{"likes", g.createLikes},
+	}
+
+	for _, step := range steps {
+		start := time.Now()
+		if err := step.fn(); err != nil {
+			return fmt.Errorf("%s: %w", step.name, err)
+		}
+		log.Printf("Generated %s in %s", step.name, time.Since(start).Round(time.Millisecond))
+	}
+
+	return nil
+}
+
+// seedLookups makes sure the category and tag vocabularies exist and loads their IDs
+func (g *generator) seedLookups() error {
+	var err error
+	g.categoryIDs, err = g.ensureNames("categories", categoryNames)
+	if err != nil {
+		return err
+	}
+	g.tagIDs, err = g.ensureNames("tags", tagNames)
+	return err
+}
+
+func (g *generator) ensureNames(table string, names []string) ([]int64, error) {
+	for _, name := range names {
+		query := fmt.Sprintf(`INSERT INTO %s (name) VALUES ($1) ON CONFLICT (name) DO NOTHING`, table)
+		if _, err := g.db.Exec(query, name); err != nil {
+			return nil, fmt.Errorf("failed to insert %s: %w", table, err)
+		}
+	}
+
+	rows, err := g.db.Query(fmt.Sprintf(`SELECT id FROM %s WHERE name = ANY($1)`, table), names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (g *generator) createUsers() error {
+	columns := []string{"user_id", "username", "email", "password_hash", "email_verified", "created_at"}
+
+	return g.inBatches(g.cfg.users, len(columns), func(offset, count int) error {
+		rows := make([][]interface{}, 0, count)
+		for i := offset; i < offset+count; i++ {
+			username := fmt.Sprintf("%s%s_%d", usernamePrefix, g.run, i)
+			rows = append(rows, []interface{}{
+				uuid.NewString(),
+				username,
+				username + "@loadgen.invalid",
+				unusablePasswordHash,
+				g.rand.Float64() < 0.9,
+				g.pastTime(g.now.Add(-history)),
+			})
+		}
+
+		ids, err := g.insert("users", columns, rows, "RETURNING id")
+		g.userIDs = append(g.userIDs, ids...)
+		return err
+	})
+}
+
+func (g *generator) createRecipes() error {
+	if len(g.userIDs) == 0 {
+		return fmt.Errorf("no users to author recipes")
+	}
+
+	columns := []string{
+		"title", "description", "user_id", "category_id", "created_at", "updated_at", "published_at",
+		"status", "difficulty_level", "serving_size", "prep_time", "cook_time", "total_time",
+	}
+
+	// Authorship is Zipf-distributed: a few prolific authors write most recipes
+	authors := rand.NewZipf(g.rand, 1.2, 1, uint64(len(g.userIDs)-1))
+
+	return g.inBatches(g.cfg.recipes, len(columns), func(offset, count int) error {
+		rows := make([][]interface{}, 0, count)
+		times := make([]time.Time, 0, count)
+		authorIDs := make([]int64, 0, count)
+		published := make([]bool, 0, count)
+		for i := 0; i < count; i++ {
+			createdAt := g.pastTime(g.now.Add(-history))
+			status, publishedAt := g.recipeStatus(createdAt)
+			prepTime := g.minutes(20, 5, 120)
+			cookTime := g.minutes(35, 0, 480)
+			authorID := g.userIDs[authors.Uint64()]
+
+			rows = append(rows, []interface{}{
+				g.recipeTitle(),
+				g.recipeDescription(),
+				authorID,
+				g.categoryIDs[g.rand.Intn(len(g.categoryIDs))],
+				createdAt,
+				createdAt,
+				publishedAt,
+				string(status),
+				[]string{"easy", "medium", "hard"}[g.pick(55, 35, 10)],
+				[]int{1, 2, 4, 6, 8, 12}[g.pick(5, 25, 40, 18, 9, 3)],
+				prepTime,
+				cookTime,
+				prepTime + cookTime,
+			})
+			times = append(times, createdAt)
+			authorIDs = append(authorIDs, authorID)
+			published = append(published, status == store.StatusPublished)
+		}
+
+		ids, err := g.insert("recipes", columns, rows, "RETURNING id")
+		if err != nil {
+			return err
+		}
+
+		for i, id := range ids {
+			if published[i] {
+				g.publishedIDs = append(g.publishedIDs, id)
+				g.publishedTimes = append(g.publishedTimes, times[i])
+				g.publishedAuthors = append(g.publishedAuthors, authorIDs[i])
+			}
+		}
+
+		return g.tagRecipes(ids)
+	})
+}
+
+func (g *generator) tagRecipes(recipeIDs []int64) error {
+	rows := [][]interface{}{}
+	for _, recipeID := range recipeIDs {
+		for _, i := range g.rand.Perm(len(g.tagIDs))[:g.rand.Intn(g.cfg.maxTags+1)] {
+			rows = append(rows, []interface{}{recipeID, g.tagIDs[i]})
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	_, err := g.insert("recipe_tags", []string{"recipe_id", "tag_id"}, rows, "ON CONFLICT DO NOTHING")
+	return err
+}
+
+func (g *generator) createReviews() error {
+	if len(g.publishedIDs) == 0 {
+		return nil
+	}
+
+	columns := []string{"recipe_id", "user_id", "rating", "comment", "created_at"}
+
+	// Attention is Zipf-distributed too: popular recipes collect most reviews
+	popularity := rand.NewZipf(g.rand, 1.1, 1, uint64(len(g.publishedIDs)-1))
+
+	return g.inBatches(g.cfg.reviews, len(columns), func(offset, count int) error {
+		rows := make([][]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			recipe := popularity.Uint64()
+			userID := g.userIDs[g.rand.Intn(len(g.userIDs))]
+
+			// The database rejects reviews of one's own recipe
+			if userID == g.publishedAuthors[recipe] {
+				continue
+			}
+
+			rows = append(rows, []interface{}{
+				g.publishedIDs[recipe],
+				userID,
+				g.pick(5, 7, 15, 33, 40) + 1,
+				reviewComments[g.rand.Intn(len(reviewComments))],
+				g.pastTime(g.publishedTimes[recipe]),
+			})
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		_, err := g.insert("reviews", columns, rows, "ON CONFLICT DO NOTHING")
+		return err
+	})
+}
+
+func (g *generator) createLikes() error {
+	if len(g.publishedIDs) == 0 {
+		return nil
+	}
+
+	columns := []string{"user_id", "recipe_id", "created_at"}
+	popularity := rand.NewZipf(g.rand, 1.1, 1, uint64(len(g.publishedIDs)-1))
+
+	return g.inBatches(g.cfg.likes, len(columns), func(offset, count int) error {
+		rows := make([][]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			recipe := popularity.Uint64()
+			rows = append(rows, []interface{}{
+				g.userIDs[g.rand.Intn(len(g.userIDs))],
+				g.publishedIDs[recipe],
+				g.pastTime(g.publishedTimes[recipe]),
+			})
+		}
+
+		_, err := g.insert("likes", columns, rows, "ON CONFLICT DO NOTHING")
+		return err
+	})
+}
+
+// inBatches calls fn for consecutive slices of total rows, logging progress as it goes
+func (g *generator) inBatches(total, columns int, fn func(offset, count int) error) error {
+	size := g.cfg.batchSize
+	if size*columns > maxParams {
+		size = maxParams / columns
+	}
+
+	lastLogged := 0
+	for offset := 0; offset < total; offset += size {
+		count := size
+		if offset+count > total {
+			count = total - offset
+		}
+		if err := fn(offset, count); err != nil {
+			return err
+		}
+
+		done := offset + count
+		if done-lastLogged >= 50000 || done == total {
+			log.Printf("  %d/%d", done, total)
+			lastLogged = done
+		}
+	}
+
+	return nil
+}
+
+// insert writes rows with one multi-row INSERT and returns the IDs when suffix contains RETURNING id
+func (g *generator) insert(table string, columns []string, rows [][]interface{}, suffix string) ([]int64, error) {
+	args := make([]interface{}, 0, len(rows)*len(columns))
+	values := make([]string, 0, len(rows))
+	for _, row := range rows {
+		placeholders := make([]string, len(row))
+		for i, value := range row {
+			args = append(args, value)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		values = append(values, "("+strings.Join(placeholders, ", ")+")")
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES %s %s`, table, strings.Join(columns, ", "), strings.Join(values, ", "), suffix)
+
+	if !strings.Contains(suffix, "RETURNING") {
+		if _, err := g.db.Exec(query, args...); err != nil {
+			return nil, fmt.Errorf("failed to insert %s: %w", table, err)
+		}
+		return nil, nil
+	}
+
+	result, err := g.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert %s: %w", table, err)
+	}
+	defer result.Close()
+
+	ids := make([]int64, 0, len(rows))
+	for result.Next() {
+		var id int64
+		if err := result.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan %s ID: %w", table, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, result.Err()
+}
+
+// recipeStatus picks a status and the matching published_at for a recipe created at createdAt
+func (g *generator) recipeStatus(createdAt time.Time) (store.RecipeStatus, *time.Time) {
+	roll := g.rand.Float64()
+	switch {
+	case roll < g.cfg.publishedRate:
+		publishedAt := createdAt.Add(time.Duration(g.rand.Int63n(int64(72 * time.Hour))))
+		if publishedAt.After(g.now) {
+			publishedAt = g.now
+		}
+		return store.StatusPublished, &publishedAt
+	case roll < g.cfg.publishedRate+(1-g.cfg.publishedRate)*0.8:
+		return store.StatusDraft, nil
+	default:
+		return store.StatusArchived, nil
+	}
+}
+
+func (g *generator) recipeTitle() string {
+	return fmt.Sprintf("%s %s %s",
+		titleAdjectives[g.rand.Intn(len(titleAdjectives))],
+		titleIngredients[g.rand.Intn(len(titleIngredients))],
+		titleDishes[g.rand.Intn(len(titleDishes))],
+	)
+}
+
+func (g *generator) recipeDescription() string {
+	return fmt.Sprintf("A %s %s %s that is perfect for %s.",
+		strings.ToLower(titleAdjectives[g.rand.Intn(len(titleAdjectives))]),
+		strings.ToLower(titleIngredients[g.rand.Intn(len(titleIngredients))]),
+		strings.ToLower(titleDishes[g.rand.Intn(len(titleDishes))]),
+		strings.ToLower(categoryNames[g.rand.Intn(len(categoryNames))]),
+	)
+}
+
+// pastTime returns a uniformly random time between after and now
+func (g *generator) pastTime(after time.Time) time.Time {
+	span := g.now.Sub(after)
+	if span <= 0 {
+		return g.now
+	}
+	return after.Add(time.Duration(g.rand.Int63n(int64(span))))
+}
+
+// minutes draws a right-skewed duration around mean, clamped to [min, max]
+func (g *generator) minutes(mean float64, min, max int) int {
+	value := int(math.Round(g.rand.ExpFloat64() * mean))
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+// pick returns an index into weights chosen with probability proportional to its weight
+func (g *generator) pick(weights ...float64) int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+
+	roll := g.rand.Float64() * total
+	for i, w := range weights {
+		if roll < w {
+			return i
+		}
+		roll -= w
+	}
+	return len(weights) - 1
+}
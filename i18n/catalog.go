@@ -0,0 +1,153 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// DefaultLanguage is used when a request asks for no supported language, and for messages with no translation
+const DefaultLanguage = "en"
+
+// Catalog holds the API's error messages in each supported language, keyed by error code
+// Codes are stable identifiers clients can rely on; messages are for people and may change or be reworded.
+// Each English message is also the one handlers write, which is how a response is matched to its code.
+type Catalog struct {
+	messages map[string]map[string]string
+	codes    map[string]string
+	matcher  language.Matcher
+	tags     []language.Tag
+}
+
+// NewCatalog loads the message catalogs in locales, one JSON file of code to message per language
+// Every language must only use codes the English catalog defines, and English messages must be unique
+func NewCatalog() (*Catalog, error) {
+	files, err := localesFS.ReadDir("locales")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message catalogs: %w", err)
+	}
+
+	catalog := &Catalog{
+		messages: map[string]map[string]string{},
+		codes:    map[string]string{},
+	}
+
+	// English comes first so the matcher falls back to it
+	languages := []string{DefaultLanguage}
+	for _, file := range files {
+		lang := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		data, err := localesFS.ReadFile(path.Join("locales", file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s catalog: %w", lang, err)
+		}
+
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse %s catalog: %w", lang, err)
+		}
+		catalog.messages[lang] = messages
+		if lang != DefaultLanguage {
+			languages = append(languages, lang)
+		}
+	}
+
+	english, ok := catalog.messages[DefaultLanguage]
+	if !ok {
+		return nil, fmt.Errorf("missing %s catalog", DefaultLanguage)
+	}
+	for code, message := range english {
+		if other, ok := catalog.codes[message]; ok {
+			return nil, fmt.Errorf("%s catalog uses %q for both %s and %s", DefaultLanguage, message, other, code)
+		}
+		catalog.codes[message] = code
+	}
+	for _, lang := range languages[1:] {
+		for code := range catalog.messages[lang] {
+			if _, ok := english[code]; !ok {
+				return nil, fmt.Errorf("%s catalog has unknown code %s", lang, code)
+			}
+		}
+	}
+
+	for _, lang := range languages {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("invalid catalog language %s: %w", lang, err)
+		}
+		catalog.tags = append(catalog.tags, tag)
+	}
+	catalog.matcher = language.NewMatcher(catalog.tags)
+
+	return catalog, nil
+}
+
+// Negotiate picks the supported language that best matches an Accept-Language header
+// A missing or unparseable header, or one naming only unsupported languages, gets English
+func (c *Catalog) Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLanguage
+	}
+
+	_, index, confidence := c.matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+	return c.tags[index].String()
+}
+
+// Code returns the error code for an English message written by a handler
+func (c *Catalog) Code(message string) (string, bool) {
+	code, ok := c.codes[message]
+	return code, ok
+}
+
+// Message returns the message for code in lang
+func (c *Catalog) Message(lang string, code string) (string, bool) {
+	message, ok := c.messages[lang][code]
+	return message, ok
+}
+
+// StatusCode returns the generic error code for an HTTP status, for messages the catalog does not cover
+func StatusCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "bad_request"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusMethodNotAllowed:
+		return "method_not_allowed"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusGone:
+		return "gone"
+	case http.StatusRequestEntityTooLarge:
+		return "payload_too_large"
+	case http.StatusUnsupportedMediaType:
+		return "unsupported_media_type"
+	case http.StatusUnprocessableEntity:
+		return "unprocessable_entity"
+	case http.StatusTooManyRequests:
+		return "too_many_requests"
+	case http.StatusServiceUnavailable:
+		return "service_unavailable"
+	case http.StatusGatewayTimeout:
+		return "request_timeout"
+	}
+	if status >= 500 {
+		return "internal_error"
+	}
+	return "error"
+}
@@ -0,0 +1,74 @@
+// Package links builds the hypermedia links API responses carry in their _links section
+// Every URL is built from one configured base, so clients can follow links instead of assembling paths,
+// and a move to a new host or API version only changes the base.
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// APIPrefix is the path the current API version is served under
+const APIPrefix = "/api/v1"
+
+// DefaultBaseURL is used when API_BASE_URL is not set
+const DefaultBaseURL = "http://localhost:8080"
+
+// Link is a link to a related resource; Method is set when following it takes something other than GET
+type Link struct {
+	Href   string `json:"href"`
+	Method string `json:"method,omitempty"`
+}
+
+// Links maps relation names such as self or author to links
+type Links map[string]Link
+
+// Builder builds absolute links to API resources
+type Builder struct {
+	base string
+}
+
+// NewBuilder creates a Builder for an API served at baseURL (scheme and host, optionally with a path prefix)
+func NewBuilder(baseURL string) *Builder {
+	return &Builder{base: strings.TrimRight(baseURL, "/") + APIPrefix}
+}
+
+// NewBuilderFromEnv creates a Builder for the base URL in API_BASE_URL
+func NewBuilderFromEnv() *Builder {
+	baseURL := os.Getenv("API_BASE_URL")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return NewBuilder(baseURL)
+}
+
+// URL returns the absolute URL of an API path such as /recipes/1
+func (b *Builder) URL(format string, args ...any) string {
+	return b.base + fmt.Sprintf(format, args...)
+}
+
+// Recipe returns the links of a recipe
+// The author link is left out when the author's username is not known
+func (b *Builder) Recipe(recipeID int64, authorUsername string) Links {
+	links := Links{
+		"self":    {Href: b.URL("/recipes/%d", recipeID)},
+		"reviews": {Href: b.URL("/recipes/%d/reviews", recipeID)},
+		"photos":  {Href: b.URL("/recipes/%d/photos", recipeID)},
+		"fork":    {Href: b.URL("/recipes/%d/fork", recipeID), Method: "POST"},
+	}
+	if authorUsername != "" {
+		links["author"] = Link{Href: b.URL("/users/%s/recipes", url.PathEscape(authorUsername))}
+	}
+	return links
+}
+
+// CurrentUser returns the links of the signed-in user's own account
+func (b *Builder) CurrentUser(username string) Links {
+	return Links{
+		"self":    {Href: b.URL("/auth/me")},
+		"recipes": {Href: b.URL("/users/%s/recipes", url.PathEscape(username))},
+		"drafts":  {Href: b.URL("/users/me/recipes")},
+	}
+}
@@ -1,21 +1,19 @@
 package main
 
 import (
-	"fmt"
 	"log"
 	"net/http"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/dapoadedire/chefshare_be/app"
-	"github.com/dapoadedire/chefshare_be/docs" // Import swagger docs
+	"github.com/dapoadedire/chefshare_be/i18n"
+	"github.com/dapoadedire/chefshare_be/middleware"
 	"github.com/dapoadedire/chefshare_be/routes"
+	"github.com/dapoadedire/chefshare_be/services"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
-	swaggerfiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 // @title ChefShare API
@@ -40,24 +38,39 @@ func main() {
 	}
 	gin.SetMode(ginMode)
 	
-	// Set up Swagger host dynamically based on environment
+	// Set up the documented Swagger host from the environment
 	setupSwaggerInfo()
 
-	// Create router
-	router := gin.Default()
+	// Create router; logging and recovery are added explicitly below
+	router := gin.New()
+
+	// Error messages in English, French and Spanish, chosen by Accept-Language
+	messageCatalog, err := i18n.NewCatalog()
+	if err != nil {
+		log.Fatalf("Failed to load message catalogs: %v", err)
+	}
 
 	// Set up middleware
-	router.Use(gin.Logger())
-	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
+	// Access logs, with the route template each request matched
+	router.Use(middleware.RouteLogger())
+	// Request and response bodies of the routes in DEBUG_BODY_LOG_ROUTES, with secrets redacted
+	router.Use(middleware.BodyLogging(middleware.DefaultBodyLogConfig()))
+	router.Use(middleware.LocalizeErrors(messageCatalog))
+	router.Use(middleware.Recovery(services.NewErrorReporterFromEnv()))
 
 	// CORS configuration using gin-contrib/cors
+	// In cookie mode (AUTH_COOKIE_MODE) the frontend sends credentials and the CSRF header cross-origin
+	authCookieMode := services.DefaultJWTConfig().CookieMode
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "https://chefshare-2025.vercel.app"}, // Frontend origin with fallbacks
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "X-Requested-With"},
-		ExposeHeaders:    []string{"Content-Length", "Content-Type"},
-		AllowCredentials: false, // No longer needed as we don't use cookies
+		AllowMethods:     []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "X-Requested-With", middleware.RequestIDHeader, "X-CSRF-Token"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Type", "Content-Disposition", middleware.RequestIDHeader},
+		AllowCredentials: authCookieMode, // Only needed when tokens are stored in cookies
 		MaxAge:           12 * time.Hour,
+		// Partner sites may also fetch recipe embeds, see EMBED_ALLOWED_ORIGINS
+		AllowOriginWithContextFunc: middleware.AllowEmbedOrigin,
 	}))
 
 	// Initialize application
@@ -70,12 +83,12 @@ func main() {
 	// Set up routes
 	router = routes.SetupRoutes(router, application)
 
-	// Set up Swagger
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler,
-		ginSwagger.URL("/swagger/doc.json"),
-		ginSwagger.DefaultModelsExpandDepth(-1),
-		ginSwagger.DocExpansion("list"),
-		ginSwagger.DeepLinking(true)))
+	// Answer OPTIONS with the allowed methods and other unsupported methods with 405 and an Allow header
+	router.HandleMethodNotAllowed = true
+	router.NoMethod(middleware.MethodNotAllowed())
+
+	// Set up Swagger, unless SWAGGER_ENABLED is false
+	registerSwagger(router)
 
 	// Get port from environment or use default
 	port := os.Getenv("PORT")
@@ -83,18 +96,16 @@ func main() {
 		port = "8080"
 	}
 
-	// Create server
+	// Create server; serve sets the address based on TLS_MODE
 	server := &http.Server{
-		Addr:         ":" + port,
-		Handler:      router,
+		Handler:      middleware.HeadAsGet(router),
 		IdleTimeout:  time.Minute,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 20 * time.Second,
 	}
 
 	// Start server
-	log.Printf("Starting server on port %s...\n", port)
-	err = server.ListenAndServe()
+	err = serve(server, port)
 	if err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
@@ -102,31 +113,3 @@ func main() {
 	// Close the database connection
 
 }
-
-// setupSwaggerInfo configures swagger info dynamically based on environment
-// Call this function at the beginning of your main function, before initializing the router
-func setupSwaggerInfo() {
-	// Check if running in production
-	isProd := false
-	if ginMode := os.Getenv("GIN_MODE"); ginMode == "release" {
-		isProd = true
-	}
-	
-	// Alternatively, check for deployment provider-specific environment variables
-	if os.Getenv("RENDER") != "" || strings.HasSuffix(os.Getenv("HOSTNAME"), ".render.com") {
-		isProd = true
-	}
-	
-	// Set the appropriate host and scheme based on environment
-	if isProd {
-		// Production settings
-		docs.SwaggerInfo.Host = "chefshare-be.onrender.com"
-		docs.SwaggerInfo.Schemes = []string{"https"}
-		fmt.Println("Swagger configured for production environment")
-	} else {
-		// Development settings
-		docs.SwaggerInfo.Host = "localhost:8080"
-		docs.SwaggerInfo.Schemes = []string{"http"}
-		fmt.Println("Swagger configured for development environment")
-	}
-}
@@ -9,26 +9,15 @@ import (
 )
 
 // JWTAuthMiddleware creates a middleware for JWT authentication
-// It extracts the bearer token from the Authorization header,
+// It extracts the bearer token from the Authorization header, or in cookie mode the access token cookie,
 // validates it using the JWT service, and sets user details in the context
 func JWTAuthMiddleware(jwtService *services.JWTService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Only check Authorization header
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		accessToken, ok := requestAccessToken(c, jwtService)
+		if !ok {
 			return
 		}
 		
-		// Check for Bearer prefix
-		parts := strings.SplitN(authHeader, " ", 2)
-		if !(len(parts) == 2 && parts[0] == "Bearer") {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
-			return
-		}
-		
-		accessToken := parts[1]
-		
 		// Validate the token
 		claims, err := jwtService.ValidateAccessToken(accessToken)
 		if err != nil {
@@ -40,12 +29,63 @@ func JWTAuthMiddleware(jwtService *services.JWTService) gin.HandlerFunc {
 		c.Set("user_id", claims.UserID)
 		c.Set("username", claims.Username)
 		c.Set("email", claims.Email)
+		setScopeClaims(c, claims)
 		
 		// Continue processing the request
 		c.Next()
 	}
 }
 
+// OptionalJWTAuthMiddleware sets user details in the context when a valid bearer token is present
+// Unlike JWTAuthMiddleware it never rejects the request, so public routes can tailor responses for signed-in users
+func OptionalJWTAuthMiddleware(jwtService *services.JWTService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accessToken := ""
+		if parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2); len(parts) == 2 && parts[0] == "Bearer" {
+			accessToken = parts[1]
+		} else if jwtService.CookieMode() && jwtService.ValidCSRF(c.Request) {
+			accessToken = jwtService.AccessTokenCookie(c.Request)
+		}
+
+		if accessToken != "" {
+			if claims, err := jwtService.ValidateAccessToken(accessToken); err == nil {
+				c.Set("user_id", claims.UserID)
+				c.Set("username", claims.Username)
+				c.Set("email", claims.Email)
+				setScopeClaims(c, claims)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// requestAccessToken returns the access token a request is authenticated with: the bearer token from the
+// Authorization header or, in cookie mode and without one, the access token cookie
+// Requests using the cookie must pass the CSRF check. It aborts with 401 or 403 and returns false otherwise.
+func requestAccessToken(c *gin.Context, jwtService *services.JWTService) (string, bool) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" && jwtService.CookieMode() {
+		if accessToken := jwtService.AccessTokenCookie(c.Request); accessToken != "" {
+			if !jwtService.ValidCSRF(c.Request) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "invalid CSRF token"})
+				return "", false
+			}
+			return accessToken, true
+		}
+	}
 
+	if authHeader == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return "", false
+	}
 
+	// Check for Bearer prefix
+	parts := strings.SplitN(authHeader, " ", 2)
+	if !(len(parts) == 2 && parts[0] == "Bearer") {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
+		return "", false
+	}
 
+	return parts[1], true
+}
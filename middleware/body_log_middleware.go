@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// redacted replaces sensitive values in logged bodies
+const redacted = "[REDACTED]"
+
+// sensitiveFieldParts mark a JSON field, form field or query parameter as sensitive when its name contains one of them
+var sensitiveFieldParts = []string{"password", "token", "secret", "otp", "api_key", "apikey", "authorization", "cookie", "email", "hash"}
+
+// sensitiveRequestFields are only sensitive in requests; in responses "code" is the machine-readable error code
+var sensitiveRequestFields = []string{"code"}
+
+// emailPattern finds email addresses inside otherwise harmless text
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// BodyLogConfig selects the routes whose request and response bodies are logged for debugging
+type BodyLogConfig struct {
+	// Routes are route patterns such as /api/v1/recipes/:id, optionally preceded by a method ("POST /api/v1/auth/login")
+	// or ending in * to match every route under a prefix. No routes disables body logging.
+	Routes []string
+
+	// MaxBodyBytes is the largest body logged; larger bodies are only logged by size
+	MaxBodyBytes int
+}
+
+// DefaultBodyLogConfig returns the body logging configuration from the comma-separated DEBUG_BODY_LOG_ROUTES
+// and DEBUG_BODY_LOG_MAX_BYTES; body logging is off unless routes are given
+func DefaultBodyLogConfig() BodyLogConfig {
+	cfg := BodyLogConfig{MaxBodyBytes: 4096}
+	for _, route := range strings.Split(os.Getenv("DEBUG_BODY_LOG_ROUTES"), ",") {
+		if route = strings.TrimSpace(route); route != "" {
+			cfg.Routes = append(cfg.Routes, route)
+		}
+	}
+	if value, err := strconv.Atoi(os.Getenv("DEBUG_BODY_LOG_MAX_BYTES")); err == nil && value > 0 {
+		cfg.MaxBodyBytes = value
+	}
+	return cfg
+}
+
+// matches reports whether a request to a route with the given method and pattern is logged
+func (cfg BodyLogConfig) matches(method, fullPath string) bool {
+	if fullPath == "" {
+		return false
+	}
+	for _, route := range cfg.Routes {
+		pattern := route
+		if routeMethod, path, ok := strings.Cut(route, " "); ok {
+			if !strings.EqualFold(routeMethod, method) {
+				continue
+			}
+			pattern = strings.TrimSpace(path)
+		}
+
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(fullPath, prefix) {
+				return true
+			}
+		} else if pattern == fullPath {
+			return true
+		}
+	}
+	return false
+}
+
+// BodyLogging logs the request and response bodies of the configured routes as structured JSON on stderr,
+// with passwords, tokens, secrets and email addresses redacted
+// Only JSON, form and text bodies are logged; others, and bodies over the size limit, are logged by size alone.
+// It must run before LocalizeErrors so the logged response is the one the client receives.
+func BodyLogging(cfg BodyLogConfig) gin.HandlerFunc {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+
+	return func(c *gin.Context) {
+		if len(cfg.Routes) == 0 || !cfg.matches(c.Request.Method, c.FullPath()) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+
+		// Read up to one byte past the limit, then hand the handler the whole body back
+		var requestBody []byte
+		if c.Request.Body != nil {
+			requestBody, _ = io.ReadAll(io.LimitReader(c.Request.Body, int64(cfg.MaxBodyBytes)+1))
+			c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(requestBody), c.Request.Body), c.Request.Body}
+		}
+
+		bw := &bodyLogWriter{ResponseWriter: c.Writer, limit: cfg.MaxBodyBytes}
+		c.Writer = bw
+
+		c.Next()
+
+		logger.Info("request body logged",
+			"request_id", c.GetString("request_id"),
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"path", c.Request.URL.Path,
+			"query", redactQuery(c.Request.URL.RawQuery, true),
+			"status", bw.Status(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"request_body", redactBody(requestBody, c.GetHeader("Content-Type"), cfg.MaxBodyBytes, true),
+			"response_body", redactBody(bw.body.Bytes(), bw.Header().Get("Content-Type"), cfg.MaxBodyBytes, false),
+		)
+	}
+}
+
+// readCloser reads from a replacement reader and closes the original body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// bodyLogWriter keeps a copy of the start of a response while writing it through
+type bodyLogWriter struct {
+	gin.ResponseWriter
+
+	limit int
+	body  bytes.Buffer
+}
+
+func (w *bodyLogWriter) Write(data []byte) (int, error) {
+	if room := w.limit + 1 - w.body.Len(); room > 0 {
+		w.body.Write(data[:min(len(data), room)])
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyLogWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// redactBody returns a body ready to log: JSON and form bodies decoded and text bodies as is, with sensitive values redacted,
+// or a note of its size and type if it is too large or of another type
+func redactBody(body []byte, contentType string, limit int, request bool) any {
+	if len(body) == 0 {
+		return nil
+	}
+	if len(body) > limit {
+		return "[" + strconv.Itoa(limit) + "+ bytes, not logged]"
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var value any
+		if err := json.Unmarshal(body, &value); err != nil {
+			return "[invalid JSON, " + strconv.Itoa(len(body)) + " bytes, not logged]"
+		}
+		return redactValue(value, request)
+	case mediaType == "application/x-www-form-urlencoded":
+		return redactQuery(string(body), request)
+	case strings.HasPrefix(mediaType, "text/"):
+		return emailPattern.ReplaceAllString(string(body), redacted)
+	default:
+		return "[" + mediaType + ", " + strconv.Itoa(len(body)) + " bytes, not logged]"
+	}
+}
+
+// redactValue redacts sensitive fields and email addresses throughout a decoded JSON value
+func redactValue(value any, request bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if isSensitiveField(key, request) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(field, request)
+			}
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item, request)
+		}
+		return v
+	case string:
+		return emailPattern.ReplaceAllString(v, redacted)
+	default:
+		return v
+	}
+}
+
+// redactQuery redacts sensitive parameters and email addresses in a query string or form body
+func redactQuery(raw string, request bool) any {
+	if raw == "" {
+		return nil
+	}
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return "[invalid query, not logged]"
+	}
+	for key, list := range values {
+		for i, value := range list {
+			if isSensitiveField(key, request) {
+				list[i] = redacted
+			} else {
+				list[i] = emailPattern.ReplaceAllString(value, redacted)
+			}
+		}
+	}
+	return values
+}
+
+func isSensitiveField(name string, request bool) bool {
+	name = strings.ToLower(name)
+	for _, part := range sensitiveFieldParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	if request {
+		for _, field := range sensitiveRequestFields {
+			if name == field {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmbedPathPrefix is where the recipe embeds partner sites load are served
+const EmbedPathPrefix = "/api/v1/embed/"
+
+// embedAllowedOrigins returns the origins allowed to embed recipes, from the comma-separated EMBED_ALLOWED_ORIGINS
+// An empty list lets any site embed them
+func embedAllowedOrigins() []string {
+	var origins []string
+	for _, origin := range strings.Split(os.Getenv("EMBED_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// embedOriginAllowed reports whether a site may embed recipes
+func embedOriginAllowed(origin string) bool {
+	origins := embedAllowedOrigins()
+	if len(origins) == 0 {
+		return true
+	}
+	for _, allowed := range origins {
+		if strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowEmbedOrigin lets the CORS middleware accept requests from partner sites to embed routes,
+// on top of the frontend origins it allows everywhere
+func AllowEmbedOrigin(c *gin.Context, origin string) bool {
+	return strings.HasPrefix(c.Request.URL.Path, EmbedPathPrefix) && embedOriginAllowed(origin)
+}
+
+// EmbedOrigins restricts which sites may frame or fetch embeds
+// Framing is limited with a frame-ancestors policy, and fetches from other origins are refused with 403
+func EmbedOrigins() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if origin := c.GetHeader("Origin"); origin != "" && !embedOriginAllowed(origin) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "origin is not allowed to embed recipes"})
+			c.Abort()
+			return
+		}
+
+		ancestors := "*"
+		if origins := embedAllowedOrigins(); len(origins) > 0 {
+			ancestors = strings.Join(origins, " ")
+		}
+		c.Header("Content-Security-Policy", "frame-ancestors "+ancestors)
+		c.Header("Vary", "Origin")
+
+		c.Next()
+	}
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// LocalizeErrors gives every JSON error response a stable machine-readable code and translates its message
+// into the language the request's Accept-Language header prefers
+// A response with a "code" field keeps it; otherwise the code is looked up from the English message, falling
+// back to a generic code for the status. Messages the catalog does not cover are left in English.
+// It must run before Recovery so panics are answered in the requester's language too.
+func LocalizeErrors(catalog *i18n.Catalog) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		original := c.Writer
+		ew := &errorWriter{ResponseWriter: original}
+		c.Writer = ew
+
+		c.Next()
+
+		c.Writer = original
+		if !ew.buffering {
+			return
+		}
+
+		body, lang := localizeError(catalog, catalog.Negotiate(c.GetHeader("Accept-Language")), original.Status(), ew.body.Bytes())
+		original.Header().Add("Vary", "Accept-Language")
+		if lang != "" {
+			original.Header().Set("Content-Language", lang)
+		}
+		original.Write(body)
+	}
+}
+
+// localizeError adds a code to an error body and translates its message, returning the new body and the message's language
+// Bodies that are not a JSON object with a string "error" field are returned unchanged with no language
+func localizeError(catalog *i18n.Catalog, lang string, status int, raw []byte) ([]byte, string) {
+	var body map[string]any
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return raw, ""
+	}
+	message, ok := body["error"].(string)
+	if !ok {
+		return raw, ""
+	}
+
+	code, _ := body["code"].(string)
+	if code == "" {
+		code, ok = catalog.Code(message)
+		if !ok {
+			code = i18n.StatusCode(status)
+		}
+	}
+	body["code"] = code
+
+	messageLang := i18n.DefaultLanguage
+	if translated, ok := catalog.Message(lang, code); ok {
+		if english, _ := catalog.Message(i18n.DefaultLanguage, code); english == message || message == "" {
+			body["error"] = translated
+			messageLang = lang
+		}
+	}
+
+	localized, err := json.Marshal(body)
+	if err != nil {
+		return raw, ""
+	}
+	return localized, messageLang
+}
+
+// errorWriter holds back JSON error responses so LocalizeErrors can rewrite them, passing everything else straight through
+// Whether to hold a response back is decided at its first write, once its status and content type are set.
+type errorWriter struct {
+	gin.ResponseWriter
+
+	decided   bool
+	buffering bool
+	body      bytes.Buffer
+}
+
+func (w *errorWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.decided = true
+		w.buffering = w.Status() >= 400 && strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")
+	}
+	if w.buffering {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *errorWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *errorWriter) Written() bool {
+	return w.buffering || w.ResponseWriter.Written()
+}
+
+// Flush is a no-op while an error response is held back, since its body is not final yet
+func (w *errorWriter) Flush() {
+	if w.buffering {
+		return
+	}
+	w.ResponseWriter.Flush()
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// methodOrder is the order methods are listed in Allow headers
+var methodOrder = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// HeadAsGet serves HEAD requests with the matching GET route
+// The router only sees a GET; net/http still knows the request was HEAD and drops the body
+// while keeping the headers, including Content-Length, that the GET response would have sent.
+func HeadAsGet(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			r = r.Clone(r.Context())
+			r.Method = http.MethodGet
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// MethodNotAllowed answers requests for a path that exists under other methods
+// It is meant for the router's NoMethod hook with HandleMethodNotAllowed enabled, which sets the Allow
+// header before calling it. OPTIONS gets 204 listing the allowed methods; any other method gets 405.
+// CORS preflight requests are answered by the CORS middleware before this runs.
+func MethodNotAllowed() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Allow", strings.Join(allowedMethods(c.Writer.Header().Get("Allow")), ", "))
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusMethodNotAllowed, gin.H{"error": "method not allowed"})
+	}
+}
+
+// allowedMethods expands the router's Allow header with HEAD for GET routes and OPTIONS for every route
+func allowedMethods(header string) []string {
+	registered := map[string]bool{http.MethodOptions: true}
+	for _, method := range strings.Split(header, ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			registered[method] = true
+		}
+	}
+	if registered[http.MethodGet] {
+		registered[http.MethodHead] = true
+	}
+
+	allowed := make([]string, 0, len(registered))
+	for _, method := range methodOrder {
+		if registered[method] {
+			allowed = append(allowed, method)
+			delete(registered, method)
+		}
+	}
+	// Keep any method outside the usual set, such as a custom one, at the end
+	for method := range registered {
+		allowed = append(allowed, method)
+	}
+	return allowed
+}
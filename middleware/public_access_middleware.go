@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublicAccessConfig controls anonymous access to the public read endpoints, such as published recipes and search
+type PublicAccessConfig struct {
+	// Enabled lets anonymous callers read; when off, every read endpoint requires signing in
+	Enabled bool
+
+	// RequestsPerMinute caps anonymous requests per IP address; 0 disables the limit
+	RequestsPerMinute int
+
+	// CacheMaxAge lets browsers and shared caches keep successful anonymous responses this long; 0 disables caching
+	CacheMaxAge time.Duration
+}
+
+// DefaultPublicAccessConfig returns the anonymous access settings from the environment
+// Anonymous reads are allowed by default, at up to 60 requests a minute per IP, cached for 60 seconds
+func DefaultPublicAccessConfig() PublicAccessConfig {
+	requestsPerMinute := 60
+	if value, err := strconv.Atoi(os.Getenv("ANONYMOUS_RATE_LIMIT_PER_MINUTE")); err == nil && value >= 0 {
+		requestsPerMinute = value
+	}
+
+	return PublicAccessConfig{
+		Enabled:           os.Getenv("PUBLIC_READ_ACCESS") != "false",
+		RequestsPerMinute: requestsPerMinute,
+		CacheMaxAge:       secondsFromEnv("ANONYMOUS_CACHE_SECONDS", 60),
+	}
+}
+
+// PublicAccess guards the public read endpoints, applying PublicAccessConfig to anonymous requests
+// Signed-in requests pass through untouched, so it must run after OptionalJWTAuthMiddleware.
+type PublicAccess struct {
+	config  PublicAccessConfig
+	limiter *RateLimiter
+}
+
+// NewPublicAccess creates the guard for the public read endpoints
+func NewPublicAccess(config PublicAccessConfig) *PublicAccess {
+	access := &PublicAccess{config: config}
+	if config.RequestsPerMinute > 0 {
+		access.limiter = NewRateLimiter(time.Minute, config.RequestsPerMinute)
+	}
+	return access
+}
+
+// Status returns an IP address's standing against the anonymous rate limit, or nil if anonymous reads are off or unlimited
+func (p *PublicAccess) Status(ip string) *RateLimitStatus {
+	if !p.config.Enabled || p.limiter == nil {
+		return nil
+	}
+	status := p.limiter.Status(ip)
+	return &status
+}
+
+// Handler refuses anonymous requests when public access is off, and otherwise rate limits them by IP address
+// and marks their successful responses as cacheable. Handlers that set their own Cache-Control keep it.
+func (p *PublicAccess) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("user_id") != "" {
+			c.Next()
+			return
+		}
+
+		if !p.config.Enabled {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+			return
+		}
+
+		if p.limiter != nil {
+			status, allowed := p.limiter.Take(c.ClientIP())
+			SetRateLimitStatusHeaders(c, status)
+			if !allowed {
+				if status.ResetsAt != nil {
+					c.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(*status.ResetsAt).Seconds()))))
+				}
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+					"error": "too many requests, sign in for higher limits or try again later",
+				})
+				return
+			}
+		}
+
+		if p.config.CacheMaxAge > 0 && (c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead) {
+			c.Writer = &publicCacheWriter{
+				ResponseWriter: c.Writer,
+				cacheControl:   fmt.Sprintf("public, max-age=%d", int(p.config.CacheMaxAge.Seconds())),
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// publicCacheWriter adds a Cache-Control header to successful responses that do not set one,
+// so errors and signed-in responses are never cached
+// Responses also vary by Accept and Accept-Language, which pick between JSON and CSV and localize quantities.
+type publicCacheWriter struct {
+	gin.ResponseWriter
+	cacheControl string
+}
+
+func (w *publicCacheWriter) WriteHeader(code int) {
+	if code == http.StatusOK && w.Header().Get("Cache-Control") == "" {
+		w.Header().Set("Cache-Control", w.cacheControl)
+		w.Header().Add("Vary", "Authorization, Cookie, Accept, Accept-Language")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
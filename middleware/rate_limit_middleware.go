@@ -1,28 +1,43 @@
 package middleware
 
 import (
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// RateLimitWarnPercent is the share of a limit, in percent, from which responses carry an X-RateLimit-Warning header
+const RateLimitWarnPercent = 80
+
 // Simple in-memory rate limiter
+// Up to maxRequests per window are the soft limit clients are told about; burst more are still allowed,
+// with a warning, before requests are refused
 type RateLimiter struct {
 	limits       map[string][]time.Time
 	mu           sync.Mutex
 	windowLength time.Duration
 	maxRequests  int
+	burst        int
 }
 
 // NewRateLimiter creates a new rate limiter with the given window length and max requests
 func NewRateLimiter(windowLength time.Duration, maxRequests int) *RateLimiter {
+	return NewBurstRateLimiter(windowLength, maxRequests, 0)
+}
+
+// NewBurstRateLimiter creates a rate limiter that allows burst requests per window beyond maxRequests before refusing any
+func NewBurstRateLimiter(windowLength time.Duration, maxRequests int, burst int) *RateLimiter {
 	// Start a background goroutine to periodically clean up old entries
 	limiter := &RateLimiter{
 		limits:       make(map[string][]time.Time),
 		windowLength: windowLength,
 		maxRequests:  maxRequests,
+		burst:        burst,
 	}
 
 	// Start cleanup routine
@@ -60,17 +75,17 @@ func (rl *RateLimiter) cleanup() {
 
 // Allow checks if a new request is allowed and updates the rate limiter
 func (rl *RateLimiter) Allow(key string) bool {
+	_, allowed := rl.Take(key)
+	return allowed
+}
+
+// Take counts a request if it is allowed, returning whether it was along with the key's standing afterwards
+func (rl *RateLimiter) Take(key string) (RateLimitStatus, bool) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
 	now := time.Now()
 
-	// Initialize if this is the first request for this key
-	if _, exists := rl.limits[key]; !exists {
-		rl.limits[key] = []time.Time{now}
-		return true
-	}
-
 	// Filter out timestamps outside the window
 	var validTimes []time.Time
 	for _, t := range rl.limits[key] {
@@ -79,21 +94,93 @@ func (rl *RateLimiter) Allow(key string) bool {
 		}
 	}
 
-	// Check if we're over the limit
-	if len(validTimes) >= rl.maxRequests {
-		rl.limits[key] = validTimes
-		return false
+	// Requests beyond the soft limit use up the burst allowance; past that they are refused
+	allowed := len(validTimes) < rl.maxRequests+rl.burst
+	if allowed {
+		validTimes = append(validTimes, now)
+	}
+	rl.limits[key] = validTimes
+
+	return rl.status(validTimes, now), allowed
+}
+
+// RateLimitStatus describes a key's standing against a rate limiter
+// ResetsAt is when the oldest counted request leaves the window, freeing a slot, or nil if nothing is counted.
+// Burst is how many requests beyond Limit are still allowed per window, and BurstRemaining how many of them are left.
+type RateLimitStatus struct {
+	Limit          int        `json:"limit"`
+	Remaining      int        `json:"remaining"`
+	Burst          int        `json:"burst"`
+	BurstRemaining int        `json:"burst_remaining"`
+	WindowSeconds  int        `json:"window_seconds"`
+	ResetsAt       *time.Time `json:"resets_at"`
+}
+
+// Status returns a key's standing without counting a request
+func (rl *RateLimiter) Status(key string) RateLimitStatus {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	return rl.status(rl.limits[key], time.Now())
+}
+
+// status describes the standing of a key that made requests at times; the caller must hold rl.mu
+func (rl *RateLimiter) status(times []time.Time, now time.Time) RateLimitStatus {
+	status := RateLimitStatus{
+		Limit:         rl.maxRequests,
+		Burst:         rl.burst,
+		WindowSeconds: int(rl.windowLength.Seconds()),
+	}
+
+	used := 0
+	for _, t := range times {
+		if now.Sub(t) > rl.windowLength {
+			continue
+		}
+		if used == 0 {
+			resetsAt := t.Add(rl.windowLength)
+			status.ResetsAt = &resetsAt
+		}
+		used++
+	}
+	status.Remaining = max(rl.maxRequests-used, 0)
+	status.BurstRemaining = min(max(rl.maxRequests+rl.burst-used, 0), rl.burst)
+
+	return status
+}
+
+// SetRateLimitHeaders writes the X-RateLimit-* headers so clients can throttle themselves
+// X-RateLimit-Reset is a Unix timestamp in seconds and is omitted when resetsAt is nil. Once RateLimitWarnPercent
+// of the limit is used, X-RateLimit-Warning says so, giving well-behaved clients the chance to back off before a 429.
+func SetRateLimitHeaders(c *gin.Context, limit, remaining int, resetsAt *time.Time) {
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	if resetsAt != nil {
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(resetsAt.Unix(), 10))
+	}
+	if limit > 0 && (limit-remaining)*100 >= limit*RateLimitWarnPercent {
+		c.Header("X-RateLimit-Warning", fmt.Sprintf("%d of %d requests used; slow down to avoid being rate limited", limit-remaining, limit))
+	}
+}
+
+// SetRateLimitStatusHeaders writes the X-RateLimit-* headers for a rate limiter status
+// Limiters with a burst allowance also get X-RateLimit-Burst-Remaining, and a warning once the burst is being used.
+func SetRateLimitStatusHeaders(c *gin.Context, status RateLimitStatus) {
+	SetRateLimitHeaders(c, status.Limit, status.Remaining, status.ResetsAt)
+	if status.Burst == 0 {
+		return
 	}
 
-	// Add the current request time and allow
-	rl.limits[key] = append(validTimes, now)
-	return true
+	c.Header("X-RateLimit-Burst-Remaining", strconv.Itoa(status.BurstRemaining))
+	if status.BurstRemaining < status.Burst {
+		c.Header("X-RateLimit-Warning", fmt.Sprintf("limit of %d requests exceeded; %d burst requests left before being rate limited", status.Limit, status.BurstRemaining))
+	}
 }
 
 // Global rate limiters for password reset endpoints
 var (
-	// IP-based limiter: 5 requests per IP per 10 minutes
-	ipLimiter = NewRateLimiter(10*time.Minute, 5)
+	// IP-based limiter: 5 requests per IP per 10 minutes, with a burst of 2 more for shared addresses
+	ipLimiter = NewBurstRateLimiter(10*time.Minute, 5, 2)
 
 	// Email-based limiter: 3 requests per email per hour
 	emailLimiter = NewRateLimiter(60*time.Minute, 3)
@@ -106,7 +193,12 @@ func PasswordResetRateLimitMiddleware() gin.HandlerFunc {
 		clientIP := c.ClientIP()
 
 		// Apply IP-based rate limiting
-		if !ipLimiter.Allow(clientIP) {
+		status, allowed := ipLimiter.Take(clientIP)
+		SetRateLimitStatusHeaders(c, status)
+		if !allowed {
+			if status.ResetsAt != nil {
+				c.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(*status.ResetsAt).Seconds()))))
+			}
 			// Return a 429 Too Many Requests response
 			c.JSON(http.StatusTooManyRequests, gin.H{
 				"error": "too many password reset attempts, please try again later",
@@ -120,6 +212,16 @@ func PasswordResetRateLimitMiddleware() gin.HandlerFunc {
 	}
 }
 
+// PasswordResetIPRateLimit returns an IP address's standing against the password reset rate limit
+func PasswordResetIPRateLimit(ip string) RateLimitStatus {
+	return ipLimiter.Status(ip)
+}
+
+// EmailRateLimit returns an email address's standing against the rate limit on password reset and verification emails
+func EmailRateLimit(email string) RateLimitStatus {
+	return emailLimiter.Status(email)
+}
+
 // TrackEmailRateLimiting tracks email-based rate limiting
 // This should be called explicitly from within handler functions after parsing the email
 func TrackEmailRateLimiting(email string) bool {
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+	"syscall"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery replaces gin.Recovery: it turns a panic into a 500 with the usual error body plus the request ID,
+// logs the stack as structured JSON and forwards the panic to reporter
+// It must run after RequestID so the response, log entry and report share the same ID
+func Recovery(reporter services.ErrorReporter) gin.HandlerFunc {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			requestID := c.GetString("request_id")
+			message := fmt.Sprint(recovered)
+			stack := string(debug.Stack())
+
+			// A client that went away is not a server bug; there is nobody left to respond to
+			if isBrokenConnection(recovered) {
+				logger.Warn("client connection lost", "error", message, "request_id", requestID, "path", c.Request.URL.Path)
+				c.Error(fmt.Errorf("%s", message))
+				c.Abort()
+				return
+			}
+
+			logger.Error("panic recovered",
+				"error", message,
+				"request_id", requestID,
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"user_id", c.GetString("user_id"),
+				"stack", stack,
+			)
+
+			reporter.Report(services.ErrorReport{
+				Message:   message,
+				Stack:     stack,
+				RequestID: requestID,
+				Method:    c.Request.Method,
+				Path:      c.Request.URL.Path,
+				UserID:    c.GetString("user_id"),
+			})
+
+			// A response that has already started, such as a 504 sent at the deadline, cannot be replaced
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error":      "internal server error",
+				"request_id": requestID,
+			})
+		}()
+
+		c.Next()
+	}
+}
+
+// isBrokenConnection reports whether a panic was caused by writing to a connection the client closed
+func isBrokenConnection(recovered interface{}) bool {
+	err, ok := recovered.(error)
+	if !ok {
+		return false
+	}
+
+	if errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		message := strings.ToLower(opErr.Error())
+		return strings.Contains(message, "broken pipe") || strings.Contains(message, "connection reset by peer")
+	}
+	return false
+}
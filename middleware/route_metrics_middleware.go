@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/gin-gonic/gin"
+)
+
+// UnmatchedRoute labels requests that matched no route, so unknown paths share one label
+const UnmatchedRoute = "unmatched"
+
+// routeKey is where RouteLogger keeps the route template for its log formatter
+const routeKey = "route"
+
+// RouteTemplate returns the route template a request matched, such as /api/v1/recipes/:id,
+// or UnmatchedRoute for 404s and 405s
+func RouteTemplate(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return UnmatchedRoute
+}
+
+// routeMethod returns the request method, or OTHER for methods no route uses, which clients could otherwise make up freely
+func routeMethod(c *gin.Context) string {
+	for _, method := range methodOrder {
+		if c.Request.Method == method {
+			return method
+		}
+	}
+	return "OTHER"
+}
+
+// RouteMetrics counts every request and its duration by method, route template and status
+func RouteMetrics(metrics *services.HTTPMetrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		metrics.Observe(routeMethod(c), RouteTemplate(c), c.Writer.Status(), time.Since(start))
+	}
+}
+
+// RouteLogger logs each request like gin.Logger, followed by the route template it matched,
+// so access logs can be grouped by endpoint as well as searched by path
+func RouteLogger() gin.HandlerFunc {
+	logger := gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v route=%s\n%s",
+			param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+			param.StatusCode,
+			param.Latency,
+			param.ClientIP,
+			param.Method,
+			param.Path,
+			param.Keys[routeKey],
+			param.ErrorMessage,
+		)
+	})
+
+	return func(c *gin.Context) {
+		c.Set(routeKey, RouteTemplate(c))
+		logger(c)
+	}
+}
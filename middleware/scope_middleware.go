@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/gin-gonic/gin"
+)
+
+// setScopeClaims exposes the token's role, scopes and restriction details to later handlers
+func setScopeClaims(c *gin.Context, claims *services.CustomClaims) {
+	c.Set("role", claims.Role)
+	c.Set("scopes", claims.Scopes)
+	c.Set("restricted_token", claims.IsRestricted())
+	if claims.APIKeyID != 0 {
+		c.Set("api_key_id", claims.APIKeyID)
+	}
+	if claims.ImpersonatorID != "" {
+		c.Set("impersonator_id", claims.ImpersonatorID)
+	}
+}
+
+// RequireScopes rejects requests whose access token lacks any of the given scopes
+// It must run after JWTAuthMiddleware, which sets the token's scopes in the context
+func RequireScopes(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted := c.GetStringSlice("scopes")
+		for _, scope := range scopes {
+			if !services.HasScope(granted, scope) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error":          "insufficient scope",
+					"required_scope": scope,
+				})
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// RequireReadWriteScopes requires readScope for GET and HEAD requests and writeScope for everything else
+// It lets one route group serve read-only tokens without opening its write routes to them
+func RequireReadWriteScopes(readScope, writeScope string) gin.HandlerFunc {
+	read := RequireScopes(readScope)
+	write := RequireScopes(writeScope)
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			read(c)
+			return
+		}
+		write(c)
+	}
+}
+
+// RequireUnrestrictedToken rejects API-key and impersonation tokens
+// Use it on routes that manage credentials, so a restricted token cannot mint broader access
+func RequireUnrestrictedToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("restricted_token") {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "this action requires a signed-in session"})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,271 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeoutConfig holds the request time limits applied to route groups
+type TimeoutConfig struct {
+	// Read applies to GET and HEAD requests
+	Read time.Duration
+
+	// Write applies to every other method
+	Write time.Duration
+
+	// Long applies to uploads, imports, exports and other heavy routes regardless of method
+	Long time.Duration
+}
+
+// DefaultTimeoutConfig returns the request time limits from the environment with sensible defaults
+func DefaultTimeoutConfig() TimeoutConfig {
+	return TimeoutConfig{
+		Read:  secondsFromEnv("REQUEST_TIMEOUT_READ_SECONDS", 5),
+		Write: secondsFromEnv("REQUEST_TIMEOUT_WRITE_SECONDS", 10),
+		Long:  secondsFromEnv("REQUEST_TIMEOUT_LONG_SECONDS", 15),
+	}
+}
+
+// Standard returns middleware applying the Read limit to reads and the Write limit to everything else
+func (cfg TimeoutConfig) Standard() gin.HandlerFunc {
+	read := Timeout(cfg.Read)
+	write := Timeout(cfg.Write)
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			read(c)
+			return
+		}
+		write(c)
+	}
+}
+
+// Extended returns middleware applying the Long limit
+func (cfg TimeoutConfig) Extended() gin.HandlerFunc {
+	return Timeout(cfg.Long)
+}
+
+// Timeout cancels the request context after d and answers 504 if the handler has not finished by then
+// The handler's response is buffered until it completes, so a timed-out request never mixes a partial
+// response with the 504. A handler that calls Flush, such as a CSV export, switches the response to
+// streaming: the headers and anything buffered are sent at once and later writes go straight to the
+// client, so a timeout after that point cuts the stream short instead of answering 504.
+// A zero or negative d disables the limit.
+// Store queries given the request context, such as listings, search and CSV exports, are cancelled at the same deadline.
+// Apply it once per route group: a nested Timeout cannot extend an outer one.
+func Timeout(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if d <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		original := c.Writer
+		tw := &timeoutWriter{ResponseWriter: original, header: original.Header().Clone()}
+		c.Writer = tw
+
+		done := make(chan struct{})
+		panicked := make(chan interface{}, 1)
+		// Re-panic on the request goroutine so the recovery middleware handles it
+		repanic := func() {
+			select {
+			case p := <-panicked:
+				panic(p)
+			default:
+			}
+		}
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicked <- p
+				}
+				close(done)
+			}()
+			c.Next()
+		}()
+
+		select {
+		case <-done:
+			c.Writer = original
+			repanic()
+			tw.flush()
+
+		case <-ctx.Done():
+			if tw.timeOut() {
+				// Part of the response has already been sent, so there is no room for a 504
+				<-done
+				c.Writer = original
+				c.Abort()
+				repanic()
+				return
+			}
+			original.Header().Set("Content-Type", "application/json; charset=utf-8")
+			original.WriteHeader(http.StatusGatewayTimeout)
+			original.Write([]byte(`{"error":"request timed out","request_id":` + strconv.Quote(c.GetString("request_id")) + `}`))
+			original.Flush()
+
+			// The Gin context is reused once this middleware returns, so wait for the handler to
+			// notice the cancelled context and finish before handing it back
+			<-done
+			c.Writer = original
+			c.Abort()
+			repanic()
+		}
+	}
+}
+
+// timeoutWriter buffers a handler's response so it can be discarded if the request times out
+// Like Gin's own writer, the status can change until the first body write. After the first Flush
+// it passes writes through to the underlying writer.
+type timeoutWriter struct {
+	gin.ResponseWriter
+
+	mu        sync.Mutex
+	header    http.Header
+	body      bytes.Buffer
+	status    int
+	written   bool
+	streaming bool
+	timedOut  bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if code > 0 && !w.written && !w.streaming && !w.timedOut {
+		w.status = code
+	}
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.written = true
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if w.streaming {
+		return w.ResponseWriter.Write(data)
+	}
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *timeoutWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.streaming {
+		return w.ResponseWriter.Status()
+	}
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *timeoutWriter) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.streaming {
+		return w.ResponseWriter.Size()
+	}
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *timeoutWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.written || w.streaming
+}
+
+// Flush sends the headers and the buffered body to the client and switches to streaming
+func (w *timeoutWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return
+	}
+	if !w.streaming {
+		w.commit()
+		w.body.Reset()
+		w.streaming = true
+	}
+	w.ResponseWriter.Flush()
+}
+
+// timeOut stops further writes and reports whether part of the response has already been sent
+func (w *timeoutWriter) timeOut() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timedOut = true
+	return w.streaming
+}
+
+// flush copies the buffered response to the underlying writer once the handler finishes
+func (w *timeoutWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.streaming {
+		w.commit()
+	}
+}
+
+// commit copies the headers, status and buffered body to the underlying writer
+// The caller must hold w.mu
+func (w *timeoutWriter) commit() {
+	dst := w.ResponseWriter.Header()
+	for key := range dst {
+		if _, ok := w.header[key]; !ok {
+			dst.Del(key)
+		}
+	}
+	for key, values := range w.header {
+		dst[key] = values
+	}
+
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if !w.written {
+		return
+	}
+	if w.body.Len() > 0 {
+		w.ResponseWriter.Write(w.body.Bytes())
+	} else {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// secondsFromEnv reads a duration in whole seconds from the environment
+func secondsFromEnv(key string, defaultSeconds int) time.Duration {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return time.Duration(value) * time.Second
+	}
+	return time.Duration(defaultSeconds) * time.Second
+}
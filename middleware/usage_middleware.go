@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/dapoadedire/chefshare_be/services"
+	"github.com/gin-gonic/gin"
+)
+
+// MeterUsage counts every authenticated request towards its user's API usage
+// It is applied to the whole router, before authentication runs, so it records the request once the
+// handler chain has finished and the user and API key are known. Anonymous requests are not counted.
+func MeterUsage(usage *services.UsageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if userID := c.GetString("user_id"); userID != "" {
+			usage.Record(userID, c.GetInt64("api_key_id"), services.UsageRequest)
+		}
+	}
+}
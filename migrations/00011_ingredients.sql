@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Canonical ingredient catalog shared across recipes
+CREATE TABLE IF NOT EXISTS ingredients (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    name VARCHAR(255) UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- Supports prefix matching for autocomplete
+CREATE INDEX IF NOT EXISTS idx_ingredients_name_pattern ON ingredients (name text_pattern_ops);
+
+ALTER TABLE recipe_ingredients
+    ADD COLUMN IF NOT EXISTS ingredient_id BIGINT,
+    ADD CONSTRAINT fk_recipe_ingredients_ingredients FOREIGN KEY (ingredient_id) REFERENCES ingredients(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_recipe_ingredients_ingredient_id ON recipe_ingredients(ingredient_id);
+
+-- Backfill the catalog from existing recipe ingredients
+INSERT INTO ingredients (name)
+SELECT DISTINCT LOWER(TRIM(name)) FROM recipe_ingredients
+WHERE TRIM(name) <> ''
+ON CONFLICT (name) DO NOTHING;
+
+UPDATE recipe_ingredients ri
+SET ingredient_id = i.id
+FROM ingredients i
+WHERE i.name = LOWER(TRIM(ri.name));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipe_ingredients DROP CONSTRAINT IF EXISTS fk_recipe_ingredients_ingredients;
+ALTER TABLE recipe_ingredients DROP COLUMN IF EXISTS ingredient_id;
+DROP TABLE IF EXISTS ingredients;
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS shopping_lists (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    share_token VARCHAR(64) UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_shopping_lists_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_shopping_lists_user_id ON shopping_lists(user_id);
+
+-- Users the owner has shared the list with, directly or through the share link
+CREATE TABLE IF NOT EXISTS shopping_list_members (
+    list_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (list_id, user_id),
+    CONSTRAINT fk_shopping_list_members_lists FOREIGN KEY (list_id) REFERENCES shopping_lists(id) ON DELETE CASCADE,
+    CONSTRAINT fk_shopping_list_members_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_shopping_list_members_user_id ON shopping_list_members(user_id);
+
+CREATE TABLE IF NOT EXISTS shopping_list_items (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    list_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    quantity DOUBLE PRECISION,
+    unit VARCHAR(50),
+    checked BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by BIGINT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_shopping_list_items_lists FOREIGN KEY (list_id) REFERENCES shopping_lists(id) ON DELETE CASCADE,
+    CONSTRAINT fk_shopping_list_items_users FOREIGN KEY (updated_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_shopping_list_items_list_id ON shopping_list_items(list_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shopping_list_items;
+DROP TABLE IF EXISTS shopping_list_members;
+DROP TABLE IF EXISTS shopping_lists;
+-- +goose StatementEnd
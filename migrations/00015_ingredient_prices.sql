@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Optional price data used to estimate recipe costs
+-- Prices are stored in the platform currency, per price_unit (e.g. "g", "cup", "piece")
+ALTER TABLE ingredients
+    ADD COLUMN IF NOT EXISTS price_per_unit NUMERIC(12, 4),
+    ADD COLUMN IF NOT EXISTS price_unit VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS price_source VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS price_updated_at TIMESTAMPTZ;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ingredients
+    DROP COLUMN IF EXISTS price_per_unit,
+    DROP COLUMN IF EXISTS price_unit,
+    DROP COLUMN IF EXISTS price_source,
+    DROP COLUMN IF EXISTS price_updated_at;
+-- +goose StatementEnd
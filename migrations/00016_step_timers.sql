@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Labeled timers a cooking-mode client can start for each step
+-- Stored as a JSON array of {"label": string, "duration_seconds": int}
+ALTER TABLE recipe_steps
+    ADD COLUMN IF NOT EXISTS timers JSONB NOT NULL DEFAULT '[]';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipe_steps DROP COLUMN IF EXISTS timers;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Each time a user reports having cooked a recipe
+CREATE TABLE IF NOT EXISTS recipe_cooks (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    user_id BIGINT NOT NULL,
+    recipe_id BIGINT NOT NULL,
+    photo_url VARCHAR(255),
+    note TEXT,
+    cooked_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_recipe_cooks_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_recipe_cooks_recipes FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_recipe_cooks_recipe_id ON recipe_cooks(recipe_id);
+CREATE INDEX IF NOT EXISTS idx_recipe_cooks_user_cooked_at ON recipe_cooks(user_id, cooked_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS recipe_cooks;
+-- +goose StatementEnd
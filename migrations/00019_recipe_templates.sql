@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Starter templates that pre-fill a new recipe's ingredients and step structure
+-- ingredients: JSON array of {"name", "quantity", "unit"}
+-- steps: JSON array of {"instruction", "duration_in_minutes", "timers"}
+CREATE TABLE IF NOT EXISTS recipe_templates (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    name VARCHAR(255) UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    category_id BIGINT,
+    difficulty_level VARCHAR(20) NOT NULL DEFAULT 'easy',
+    ingredients JSONB NOT NULL DEFAULT '[]',
+    steps JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_recipe_templates_categories FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE SET NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS recipe_templates;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Admin-curated collections such as seasonal or holiday roundups
+-- A collection is public while NOW() falls inside its optional publish window
+CREATE TABLE IF NOT EXISTS curated_collections (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    slug VARCHAR(100) UNIQUE NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    banner_image_url VARCHAR(255),
+    display_order INTEGER NOT NULL DEFAULT 0,
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT chk_curated_collections_window CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at)
+);
+
+CREATE TABLE IF NOT EXISTS curated_collection_recipes (
+    collection_id BIGINT NOT NULL,
+    recipe_id BIGINT NOT NULL,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (collection_id, recipe_id),
+    CONSTRAINT fk_curated_collection_recipes_collections FOREIGN KEY (collection_id) REFERENCES curated_collections(id) ON DELETE CASCADE,
+    CONSTRAINT fk_curated_collection_recipes_recipes FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS curated_collection_recipes;
+DROP TABLE IF EXISTS curated_collections;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Recipes admins feature on the homepage, shown by display_order until they expire
+CREATE TABLE IF NOT EXISTS featured_recipes (
+    recipe_id BIGINT PRIMARY KEY,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ,
+    featured_by BIGINT,
+    featured_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_featured_recipes_recipes FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_featured_recipes_users FOREIGN KEY (featured_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS featured_recipes;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Support the time, servings, and rating filters on the published recipe listing
+CREATE INDEX IF NOT EXISTS idx_recipes_published_total_time ON recipes(total_time) WHERE status = 'published';
+CREATE INDEX IF NOT EXISTS idx_recipes_published_serving_size ON recipes(serving_size) WHERE status = 'published';
+
+-- Lets average ratings be computed from the index alone
+CREATE INDEX IF NOT EXISTS idx_reviews_recipe_id_rating ON reviews(recipe_id, rating);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_reviews_recipe_id_rating;
+DROP INDEX IF EXISTS idx_recipes_published_serving_size;
+DROP INDEX IF EXISTS idx_recipes_published_total_time;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Trigram indexes let substring matches in search suggestions avoid full table scans
+CREATE INDEX IF NOT EXISTS idx_recipes_published_title_trgm ON recipes USING GIN (LOWER(title) gin_trgm_ops) WHERE status = 'published';
+CREATE INDEX IF NOT EXISTS idx_tags_name_trgm ON tags USING GIN (LOWER(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users USING GIN (LOWER(username) gin_trgm_ops);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_username_trgm;
+DROP INDEX IF EXISTS idx_tags_name_trgm;
+DROP INDEX IF EXISTS idx_recipes_published_title_trgm;
+-- +goose StatementEnd
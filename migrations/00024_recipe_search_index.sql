@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Full-text index over published recipe titles and descriptions
+-- The expression must match recipeSearchDocument in store/search_store.go for the index to be used
+CREATE INDEX IF NOT EXISTS idx_recipes_published_search ON recipes
+    USING GIN (to_tsvector('english', title || ' ' || COALESCE(description, '')))
+    WHERE status = 'published';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_recipes_published_search;
+-- +goose StatementEnd
@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Recipes whose search documents need to be re-synced to an external search engine
+-- Rows are written by triggers so every change is captured, and removed once the indexer has synced them
+CREATE TABLE IF NOT EXISTS search_outbox (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    recipe_id BIGINT NOT NULL,
+    queued_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE OR REPLACE FUNCTION queue_recipe_search_sync() RETURNS TRIGGER AS $$
+DECLARE
+    changed RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    IF TG_TABLE_NAME = 'recipes' THEN
+        INSERT INTO search_outbox (recipe_id) VALUES (changed.id);
+    ELSE
+        INSERT INTO search_outbox (recipe_id) VALUES (changed.recipe_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Recipe fields, ingredients (estimated cost), and reviews (average rating) all feed the search document
+CREATE TRIGGER trg_recipes_search_sync
+    AFTER INSERT OR UPDATE OR DELETE ON recipes
+    FOR EACH ROW EXECUTE FUNCTION queue_recipe_search_sync();
+
+CREATE TRIGGER trg_recipe_ingredients_search_sync
+    AFTER INSERT OR UPDATE OR DELETE ON recipe_ingredients
+    FOR EACH ROW EXECUTE FUNCTION queue_recipe_search_sync();
+
+CREATE TRIGGER trg_reviews_search_sync
+    AFTER INSERT OR UPDATE OR DELETE ON reviews
+    FOR EACH ROW EXECUTE FUNCTION queue_recipe_search_sync();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_reviews_search_sync ON reviews;
+DROP TRIGGER IF EXISTS trg_recipe_ingredients_search_sync ON recipe_ingredients;
+DROP TRIGGER IF EXISTS trg_recipes_search_sync ON recipes;
+DROP FUNCTION IF EXISTS queue_recipe_search_sync();
+DROP TABLE IF EXISTS search_outbox;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Preferences captured during onboarding, used to tailor recommendations
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS preferred_cuisines JSONB NOT NULL DEFAULT '[]'::JSONB,
+    ADD COLUMN IF NOT EXISTS dietary_restrictions JSONB NOT NULL DEFAULT '[]'::JSONB,
+    ADD COLUMN IF NOT EXISTS skill_level VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS onboarded_at TIMESTAMPTZ;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS onboarded_at,
+    DROP COLUMN IF EXISTS skill_level,
+    DROP COLUMN IF EXISTS dietary_restrictions,
+    DROP COLUMN IF EXISTS preferred_cuisines;
+-- +goose StatementEnd
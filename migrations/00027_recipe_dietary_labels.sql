@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Dietary labels an author declares a recipe satisfies (e.g. ["vegan", "gluten_free"])
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS dietary_labels JSONB NOT NULL DEFAULT '[]'::JSONB;
+
+-- Supports containment (@>) filters on the labels
+CREATE INDEX IF NOT EXISTS idx_recipes_dietary_labels ON recipes USING GIN (dietary_labels jsonb_path_ops);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_recipes_dietary_labels;
+ALTER TABLE recipes DROP COLUMN IF EXISTS dietary_labels;
+-- +goose StatementEnd
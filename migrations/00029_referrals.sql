@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Shareable code a user hands out so signups can be attributed to them; generated on first use
+ALTER TABLE users ADD COLUMN IF NOT EXISTS referral_code VARCHAR(16) UNIQUE;
+
+-- Accounts created through a referral code or an invitation
+CREATE TABLE IF NOT EXISTS referrals (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    referrer_id BIGINT NOT NULL,
+    referred_user_id BIGINT UNIQUE NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_referrals_referrer FOREIGN KEY (referrer_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_referrals_referred_user FOREIGN KEY (referred_user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_referrals_referrer_id ON referrals(referrer_id);
+
+-- Carry over signups already attributed through invitations
+INSERT INTO referrals (referrer_id, referred_user_id, source, created_at)
+SELECT u.invited_by, u.id, 'invitation', u.created_at
+FROM users u
+WHERE u.invited_by IS NOT NULL
+ON CONFLICT (referred_user_id) DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS referrals;
+ALTER TABLE users DROP COLUMN IF EXISTS referral_code;
+-- +goose StatementEnd
@@ -0,0 +1,141 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Running total of each user's reputation ledger, kept in sync by award_reputation()
+ALTER TABLE users ADD COLUMN IF NOT EXISTS reputation INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_users_reputation ON users(reputation DESC);
+
+-- Reviews other users found helpful
+CREATE TABLE IF NOT EXISTS review_helpful_votes (
+    review_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (review_id, user_id),
+    CONSTRAINT fk_review_helpful_votes_reviews FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE,
+    CONSTRAINT fk_review_helpful_votes_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Every change to a user's reputation; entries are never updated, reversals are recorded as negative points
+CREATE TABLE IF NOT EXISTS reputation_events (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    user_id BIGINT NOT NULL,
+    points INTEGER NOT NULL,
+    reason VARCHAR(40) NOT NULL,
+    recipe_id BIGINT,
+    review_id BIGINT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_reputation_events_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_reputation_events_recipes FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE SET NULL,
+    CONSTRAINT fk_reputation_events_reviews FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reputation_events_user_id ON reputation_events(user_id, created_at DESC);
+
+-- A recipe only earns publishing points once, even if it is unpublished and published again
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reputation_events_recipe_published
+    ON reputation_events(recipe_id) WHERE reason = 'recipe_published';
+
+CREATE OR REPLACE FUNCTION award_reputation(
+    p_user_id BIGINT, p_points INTEGER, p_reason VARCHAR, p_recipe_id BIGINT, p_review_id BIGINT
+) RETURNS VOID AS $$
+BEGIN
+    INSERT INTO reputation_events (user_id, points, reason, recipe_id, review_id)
+    VALUES (p_user_id, p_points, p_reason, p_recipe_id, p_review_id)
+    ON CONFLICT DO NOTHING;
+
+    IF FOUND THEN
+        UPDATE users SET reputation = reputation + p_points WHERE id = p_user_id;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Publishing a recipe: 10 points
+CREATE OR REPLACE FUNCTION award_recipe_published() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.published_at IS NOT NULL AND (TG_OP = 'INSERT' OR OLD.published_at IS NULL) THEN
+        PERFORM award_reputation(NEW.user_id, 10, 'recipe_published', NEW.id, NULL);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_recipes_reputation
+    AFTER INSERT OR UPDATE OF published_at ON recipes
+    FOR EACH ROW EXECUTE FUNCTION award_recipe_published();
+
+-- Receiving a favorite: 2 points to the recipe's author, taken back if the favorite is removed
+CREATE OR REPLACE FUNCTION award_favorite_received() RETURNS TRIGGER AS $$
+DECLARE
+    author_id BIGINT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        SELECT user_id INTO author_id FROM recipes WHERE id = NEW.recipe_id;
+        IF author_id IS NOT NULL AND author_id <> NEW.user_id THEN
+            PERFORM award_reputation(author_id, 2, 'favorite_received', NEW.recipe_id, NULL);
+        END IF;
+    ELSE
+        -- When the recipe itself is being deleted its author keeps the points
+        SELECT user_id INTO author_id FROM recipes WHERE id = OLD.recipe_id;
+        IF author_id IS NOT NULL AND author_id <> OLD.user_id THEN
+            PERFORM award_reputation(author_id, -2, 'favorite_removed', OLD.recipe_id, NULL);
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_likes_reputation
+    AFTER INSERT OR DELETE ON likes
+    FOR EACH ROW EXECUTE FUNCTION award_favorite_received();
+
+-- A review marked helpful: 1 point to the reviewer, taken back if the vote is withdrawn
+CREATE OR REPLACE FUNCTION award_review_helpful() RETURNS TRIGGER AS $$
+DECLARE
+    reviewer_id BIGINT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        SELECT user_id INTO reviewer_id FROM reviews WHERE id = NEW.review_id;
+        IF reviewer_id IS NOT NULL AND reviewer_id <> NEW.user_id THEN
+            PERFORM award_reputation(reviewer_id, 1, 'review_helpful', NULL, NEW.review_id);
+        END IF;
+    ELSE
+        SELECT user_id INTO reviewer_id FROM reviews WHERE id = OLD.review_id;
+        IF reviewer_id IS NOT NULL AND reviewer_id <> OLD.user_id THEN
+            PERFORM award_reputation(reviewer_id, -1, 'review_helpful_removed', NULL, OLD.review_id);
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_review_helpful_votes_reputation
+    AFTER INSERT OR DELETE ON review_helpful_votes
+    FOR EACH ROW EXECUTE FUNCTION award_review_helpful();
+
+-- Credit activity from before the ledger existed
+SELECT award_reputation(r.user_id, 10, 'recipe_published', r.id, NULL)
+FROM recipes r
+WHERE r.published_at IS NOT NULL;
+
+SELECT award_reputation(r.user_id, 2, 'favorite_received', r.id, NULL)
+FROM likes l
+JOIN recipes r ON r.id = l.recipe_id
+WHERE r.user_id <> l.user_id;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_review_helpful_votes_reputation ON review_helpful_votes;
+DROP TRIGGER IF EXISTS trg_likes_reputation ON likes;
+DROP TRIGGER IF EXISTS trg_recipes_reputation ON recipes;
+DROP FUNCTION IF EXISTS award_review_helpful();
+DROP FUNCTION IF EXISTS award_favorite_received();
+DROP FUNCTION IF EXISTS award_recipe_published();
+DROP FUNCTION IF EXISTS award_reputation(BIGINT, INTEGER, VARCHAR, BIGINT, BIGINT);
+DROP TABLE IF EXISTS reputation_events;
+DROP TABLE IF EXISTS review_helpful_votes;
+DROP INDEX IF EXISTS idx_users_reputation;
+ALTER TABLE users DROP COLUMN IF EXISTS reputation;
+-- +goose StatementEnd
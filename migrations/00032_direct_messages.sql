@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Users who have blocked each other cannot start conversations or exchange messages
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker_id BIGINT NOT NULL,
+    blocked_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CONSTRAINT fk_user_blocks_blocker FOREIGN KEY (blocker_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_blocks_blocked FOREIGN KEY (blocked_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_user_blocks_not_self CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked_id ON user_blocks(blocked_id);
+
+-- One-to-one conversations; the pair is stored with the lower user ID first so each pair has a single conversation
+CREATE TABLE IF NOT EXISTS conversations (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    user_low_id BIGINT NOT NULL,
+    user_high_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_message_at TIMESTAMPTZ,
+    CONSTRAINT fk_conversations_user_low FOREIGN KEY (user_low_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_conversations_user_high FOREIGN KEY (user_high_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_conversations_ordered_pair CHECK (user_low_id < user_high_id),
+    CONSTRAINT uq_conversations_pair UNIQUE (user_low_id, user_high_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversations_user_high_id ON conversations(user_high_id);
+
+-- Each participant's read position, used for unread counts
+CREATE TABLE IF NOT EXISTS conversation_participants (
+    conversation_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    last_read_at TIMESTAMPTZ,
+    PRIMARY KEY (conversation_id, user_id),
+    CONSTRAINT fk_conversation_participants_conversations FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_conversation_participants_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_participants_user_id ON conversation_participants(user_id);
+
+CREATE TABLE IF NOT EXISTS messages (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    conversation_id BIGINT NOT NULL,
+    sender_id BIGINT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_messages_conversations FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_messages_senders FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_conversation_id ON messages(conversation_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS conversation_participants;
+DROP TABLE IF EXISTS conversations;
+DROP TABLE IF EXISTS user_blocks;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_verified_chef BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS verified_chef_at TIMESTAMPTZ;
+
+-- Applications to the verified chef program, reviewed by admins
+CREATE TABLE IF NOT EXISTS chef_applications (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    user_id BIGINT NOT NULL,
+    credentials TEXT NOT NULL,
+    portfolio_url VARCHAR(500),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewed_by BIGINT,
+    review_note TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    reviewed_at TIMESTAMPTZ,
+    CONSTRAINT fk_chef_applications_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_chef_applications_reviewers FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT chk_chef_applications_status CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_chef_applications_user_id ON chef_applications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_chef_applications_status ON chef_applications(status, created_at);
+
+-- A user can only have one application awaiting review
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chef_applications_one_pending
+    ON chef_applications(user_id) WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS chef_applications;
+ALTER TABLE users DROP COLUMN IF EXISTS verified_chef_at;
+ALTER TABLE users DROP COLUMN IF EXISTS is_verified_chef;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Uploaded photos are kept in the storage backend and referenced by key; photo_url is only set for external links
+ALTER TABLE recipe_photos ADD COLUMN IF NOT EXISTS storage_key VARCHAR(500);
+ALTER TABLE recipe_photos ALTER COLUMN photo_url DROP NOT NULL;
+ALTER TABLE recipe_photos ADD CONSTRAINT chk_recipe_photos_source
+    CHECK (photo_url IS NOT NULL OR storage_key IS NOT NULL);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM recipe_photos WHERE photo_url IS NULL;
+ALTER TABLE recipe_photos DROP CONSTRAINT IF EXISTS chk_recipe_photos_source;
+ALTER TABLE recipe_photos ALTER COLUMN photo_url SET NOT NULL;
+ALTER TABLE recipe_photos DROP COLUMN IF EXISTS storage_key;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- A review is verified when its author has recorded cooking the recipe; cook_id points at their latest cook
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS cook_id BIGINT;
+ALTER TABLE reviews ADD CONSTRAINT fk_reviews_recipe_cooks
+    FOREIGN KEY (cook_id) REFERENCES recipe_cooks(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_recipe_cooks_user_recipe ON recipe_cooks(user_id, recipe_id, cooked_at DESC);
+
+UPDATE reviews rv
+SET cook_id = (
+    SELECT rc.id
+    FROM recipe_cooks rc
+    WHERE rc.user_id = rv.user_id AND rc.recipe_id = rv.recipe_id
+    ORDER BY rc.cooked_at DESC
+    LIMIT 1
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_recipe_cooks_user_recipe;
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS fk_reviews_recipe_cooks;
+ALTER TABLE reviews DROP COLUMN IF EXISTS cook_id;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Authors cannot review their own recipes; a CHECK cannot see the recipe's owner, so a trigger enforces it
+-- The error is raised as a check violation on a named constraint so the application maps it like any other
+CREATE OR REPLACE FUNCTION prevent_self_review() RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM recipes WHERE id = NEW.recipe_id AND user_id = NEW.user_id) THEN
+        RAISE EXCEPTION 'users cannot review their own recipes'
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'chk_reviews_not_own_recipe';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_reviews_prevent_self_review
+    BEFORE INSERT OR UPDATE OF recipe_id, user_id ON reviews
+    FOR EACH ROW EXECUTE FUNCTION prevent_self_review();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_reviews_prevent_self_review ON reviews;
+DROP FUNCTION IF EXISTS prevent_self_review();
+-- +goose StatementEnd
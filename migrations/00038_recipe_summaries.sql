@@ -0,0 +1,135 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Per-recipe aggregates kept current by triggers, so listings and search read them instead of aggregating per request
+-- Ratings leave out reviews by the recipe's author, matching the review summary
+CREATE TABLE IF NOT EXISTS recipe_summaries (
+    recipe_id BIGINT PRIMARY KEY,
+    rating_sum BIGINT DEFAULT 0 NOT NULL,
+    review_count INTEGER DEFAULT 0 NOT NULL,
+    average_rating FLOAT8 GENERATED ALWAYS AS (rating_sum::FLOAT8 / NULLIF(review_count, 0)) STORED,
+    favorite_count INTEGER DEFAULT 0 NOT NULL,
+    made_count INTEGER DEFAULT 0 NOT NULL,
+    primary_photo_id BIGINT,
+    CONSTRAINT fk_recipe_summaries_recipes FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_recipe_summaries_photos FOREIGN KEY (primary_photo_id) REFERENCES recipe_photos(id) ON DELETE SET NULL
+);
+
+INSERT INTO recipe_summaries (recipe_id, rating_sum, review_count, favorite_count, made_count, primary_photo_id)
+SELECT
+    r.id,
+    (SELECT COALESCE(SUM(rv.rating), 0) FROM reviews rv WHERE rv.recipe_id = r.id AND rv.user_id <> r.user_id),
+    (SELECT COUNT(*) FROM reviews rv WHERE rv.recipe_id = r.id AND rv.user_id <> r.user_id),
+    (SELECT COUNT(*) FROM likes l WHERE l.recipe_id = r.id),
+    (SELECT COUNT(*) FROM recipe_cooks rc WHERE rc.recipe_id = r.id),
+    (SELECT rp.id FROM recipe_photos rp WHERE rp.recipe_id = r.id ORDER BY rp.is_primary DESC, rp.id LIMIT 1)
+FROM recipes r
+ON CONFLICT (recipe_id) DO NOTHING;
+
+CREATE OR REPLACE FUNCTION create_recipe_summary() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO recipe_summaries (recipe_id) VALUES (NEW.id) ON CONFLICT (recipe_id) DO NOTHING;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Reviews adjust the rating totals incrementally; the author's own reviews never count
+CREATE OR REPLACE FUNCTION update_recipe_summary_reviews() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE recipe_summaries s
+        SET rating_sum = s.rating_sum - OLD.rating, review_count = s.review_count - 1
+        FROM recipes r
+        WHERE s.recipe_id = OLD.recipe_id AND r.id = OLD.recipe_id AND r.user_id <> OLD.user_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE recipe_summaries s
+        SET rating_sum = s.rating_sum + NEW.rating, review_count = s.review_count + 1
+        FROM recipes r
+        WHERE s.recipe_id = NEW.recipe_id AND r.id = NEW.recipe_id AND r.user_id <> NEW.user_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Favorites (likes) and cooks are counted by the table that fired the trigger
+CREATE OR REPLACE FUNCTION update_recipe_summary_counts() RETURNS TRIGGER AS $$
+DECLARE
+    changed RECORD;
+    delta INTEGER;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+        delta := -1;
+    ELSE
+        changed := NEW;
+        delta := 1;
+    END IF;
+
+    IF TG_TABLE_NAME = 'likes' THEN
+        UPDATE recipe_summaries SET favorite_count = favorite_count + delta WHERE recipe_id = changed.recipe_id;
+    ELSE
+        UPDATE recipe_summaries SET made_count = made_count + delta WHERE recipe_id = changed.recipe_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The primary photo is the one marked primary, or else the first one added
+CREATE OR REPLACE FUNCTION update_recipe_summary_photo() RETURNS TRIGGER AS $$
+DECLARE
+    changed RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    UPDATE recipe_summaries
+    SET primary_photo_id = (
+        SELECT rp.id FROM recipe_photos rp
+        WHERE rp.recipe_id = changed.recipe_id
+        ORDER BY rp.is_primary DESC, rp.id
+        LIMIT 1
+    )
+    WHERE recipe_id = changed.recipe_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_recipes_create_summary
+    AFTER INSERT ON recipes
+    FOR EACH ROW EXECUTE FUNCTION create_recipe_summary();
+
+CREATE TRIGGER trg_reviews_recipe_summary
+    AFTER INSERT OR UPDATE OF recipe_id, user_id, rating OR DELETE ON reviews
+    FOR EACH ROW EXECUTE FUNCTION update_recipe_summary_reviews();
+
+CREATE TRIGGER trg_likes_recipe_summary
+    AFTER INSERT OR DELETE ON likes
+    FOR EACH ROW EXECUTE FUNCTION update_recipe_summary_counts();
+
+CREATE TRIGGER trg_recipe_cooks_recipe_summary
+    AFTER INSERT OR DELETE ON recipe_cooks
+    FOR EACH ROW EXECUTE FUNCTION update_recipe_summary_counts();
+
+CREATE TRIGGER trg_recipe_photos_recipe_summary
+    AFTER INSERT OR UPDATE OF is_primary OR DELETE ON recipe_photos
+    FOR EACH ROW EXECUTE FUNCTION update_recipe_summary_photo();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_recipe_photos_recipe_summary ON recipe_photos;
+DROP TRIGGER IF EXISTS trg_recipe_cooks_recipe_summary ON recipe_cooks;
+DROP TRIGGER IF EXISTS trg_likes_recipe_summary ON likes;
+DROP TRIGGER IF EXISTS trg_reviews_recipe_summary ON reviews;
+DROP TRIGGER IF EXISTS trg_recipes_create_summary ON recipes;
+DROP FUNCTION IF EXISTS update_recipe_summary_photo();
+DROP FUNCTION IF EXISTS update_recipe_summary_counts();
+DROP FUNCTION IF EXISTS update_recipe_summary_reviews();
+DROP FUNCTION IF EXISTS create_recipe_summary();
+DROP TABLE IF EXISTS recipe_summaries;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Daily request counts per user and API key, rolled up in memory and flushed periodically
+-- api_key_id is 0 for requests made with a sign-in token; it is not a foreign key so usage outlives revoked keys
+CREATE TABLE IF NOT EXISTS api_usage (
+    user_id BIGINT NOT NULL,
+    api_key_id BIGINT DEFAULT 0 NOT NULL,
+    metric VARCHAR(50) NOT NULL,
+    day DATE NOT NULL,
+    request_count INTEGER DEFAULT 0 NOT NULL,
+    PRIMARY KEY (user_id, day, metric, api_key_id),
+    CONSTRAINT fk_api_usage_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS api_usage;
+-- +goose StatementEnd
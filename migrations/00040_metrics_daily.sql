@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- One row per email handed to the email provider, counted by the daily metrics rollup
+CREATE TABLE IF NOT EXISTS email_sends (
+    id BIGSERIAL PRIMARY KEY,
+    sent_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_sends_sent_at ON email_sends(sent_at);
+
+-- Site-wide activity per UTC day, filled in nightly so admin analytics never scan the source tables
+CREATE TABLE IF NOT EXISTS metrics_daily (
+    day DATE PRIMARY KEY,
+    signups INTEGER DEFAULT 0 NOT NULL,
+    recipes_created INTEGER DEFAULT 0 NOT NULL,
+    recipes_published INTEGER DEFAULT 0 NOT NULL,
+    reviews INTEGER DEFAULT 0 NOT NULL,
+    emails_sent INTEGER DEFAULT 0 NOT NULL,
+    computed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at);
+CREATE INDEX IF NOT EXISTS idx_recipes_created_at ON recipes(created_at);
+CREATE INDEX IF NOT EXISTS idx_recipes_published_at ON recipes(published_at) WHERE published_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_reviews_created_at ON reviews(created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_reviews_created_at;
+DROP INDEX IF EXISTS idx_recipes_published_at;
+DROP INDEX IF EXISTS idx_recipes_created_at;
+DROP INDEX IF EXISTS idx_users_created_at;
+DROP TABLE IF EXISTS metrics_daily;
+DROP TABLE IF EXISTS email_sends;
+-- +goose StatementEnd
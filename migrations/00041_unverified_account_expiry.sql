@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Accounts that never verify their email are warned, then disabled, then purged
+-- Verifying the email clears disabled_at, reactivating the account
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS unverified_warnings_sent SMALLINT DEFAULT 0 NOT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS unverified_warned_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_users_unverified_created_at ON users(created_at) WHERE email_verified = false;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_unverified_created_at;
+ALTER TABLE users DROP COLUMN IF EXISTS unverified_warned_at;
+ALTER TABLE users DROP COLUMN IF EXISTS unverified_warnings_sent;
+ALTER TABLE users DROP COLUMN IF EXISTS disabled_at;
+-- +goose StatementEnd
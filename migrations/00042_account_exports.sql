@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Account data exports are built in the background; the archive lives in file storage until expires_at
+CREATE TABLE IF NOT EXISTS account_exports (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    status VARCHAR(20) DEFAULT 'queued' NOT NULL,
+    progress SMALLINT DEFAULT 0 NOT NULL,
+    storage_key TEXT,
+    size_bytes BIGINT,
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    CONSTRAINT fk_account_exports_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_account_exports_status CHECK (status IN ('queued', 'running', 'done', 'failed')),
+    CONSTRAINT chk_account_exports_progress CHECK (progress BETWEEN 0 AND 100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_exports_user_id ON account_exports(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_account_exports_pending ON account_exports(created_at) WHERE status IN ('queued', 'running');
+CREATE INDEX IF NOT EXISTS idx_account_exports_expires_at ON account_exports(expires_at) WHERE storage_key IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS account_exports;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Drafts untouched for long enough are archived after their author has been warned
+-- A warning only counts for the draft as it was; editing the draft after being warned starts over
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS stale_warned_at TIMESTAMPTZ;
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_recipes_draft_updated_at ON recipes(updated_at) WHERE status = 'draft';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_recipes_draft_updated_at;
+ALTER TABLE recipes DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE recipes DROP COLUMN IF EXISTS stale_warned_at;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- A user's plan of what to cook when
+-- ical_token is the secret that lets calendar apps subscribe to the plan without signing in; NULL until requested
+CREATE TABLE IF NOT EXISTS meal_plans (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    ical_token VARCHAR(64) UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_meal_plans_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_meal_plans_user_id ON meal_plans(user_id);
+
+-- One planned meal: a recipe to cook on a day for breakfast, lunch, dinner or a snack
+CREATE TABLE IF NOT EXISTS meal_plan_entries (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    plan_id BIGINT NOT NULL,
+    recipe_id BIGINT NOT NULL,
+    planned_for DATE NOT NULL,
+    meal VARCHAR(20) NOT NULL,
+    servings INTEGER,
+    note VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_meal_plan_entries_plans FOREIGN KEY (plan_id) REFERENCES meal_plans(id) ON DELETE CASCADE,
+    CONSTRAINT fk_meal_plan_entries_recipes FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE,
+    CONSTRAINT chk_meal_plan_entries_meal CHECK (meal IN ('breakfast', 'lunch', 'dinner', 'snack')),
+    CONSTRAINT chk_meal_plan_entries_servings CHECK (servings IS NULL OR servings > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_meal_plan_entries_plan_id_planned_for ON meal_plan_entries(plan_id, planned_for);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS meal_plan_entries;
+DROP TABLE IF EXISTS meal_plans;
+-- +goose StatementEnd
@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Accounts created through an OAuth provider have no password until their owner sets one
+-- Their password_hash holds a random hash nobody knows, so password login fails for them
+ALTER TABLE users ADD COLUMN IF NOT EXISTS has_password BOOLEAN DEFAULT true NOT NULL;
+
+-- Identities at OAuth providers that can sign in to an account
+-- subject is the provider's stable ID for the user; email is what the provider reported when the identity was linked
+CREATE TABLE IF NOT EXISTS user_identities (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    user_id BIGINT NOT NULL,
+    provider VARCHAR(30) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    CONSTRAINT fk_user_identities_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_identities_provider_subject UNIQUE (provider, subject),
+    CONSTRAINT uq_user_identities_user_provider UNIQUE (user_id, provider)
+);
+
+-- Authorization requests sent to a provider and not yet completed
+-- state is echoed back by the provider and used once; user_id is set when an account is linking a new identity
+CREATE TABLE IF NOT EXISTS oauth_states (
+    state VARCHAR(64) PRIMARY KEY,
+    provider VARCHAR(30) NOT NULL,
+    intent VARCHAR(10) NOT NULL,
+    user_id BIGINT,
+    code_verifier VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_oauth_states_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_oauth_states_intent CHECK (intent IN ('login', 'link'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_oauth_states_expires_at ON oauth_states(expires_at);
+
+-- Identities whose provider email matches an existing account, waiting for the account's owner to confirm by email
+CREATE TABLE IF NOT EXISTS pending_identity_links (
+    token VARCHAR(64) PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    provider VARCHAR(30) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_pending_identity_links_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_pending_identity_links_user_id ON pending_identity_links(user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pending_identity_links;
+DROP TABLE IF EXISTS oauth_states;
+DROP TABLE IF EXISTS user_identities;
+ALTER TABLE users DROP COLUMN IF EXISTS has_password;
+-- +goose StatementEnd
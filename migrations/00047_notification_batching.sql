@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Events of the same kind about the same thing, such as favorites of one recipe, are merged into one notification
+-- while its batch is open; event_count is how many were merged and updated_at when the latest arrived
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS batch_key VARCHAR(100);
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS batch_closes_at TIMESTAMPTZ;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS event_count INT DEFAULT 1 NOT NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL;
+
+-- Notifications to include in their owner's digest email once their batch closes
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS email_pending BOOLEAN DEFAULT false NOT NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS emailed_at TIMESTAMPTZ;
+
+UPDATE notifications SET updated_at = created_at;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_open_batches ON notifications(user_id, batch_key)
+    WHERE read_at IS NULL AND batch_key IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_email_pending ON notifications(batch_closes_at)
+    WHERE email_pending;
+
+DROP INDEX IF EXISTS idx_notifications_user_id;
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, updated_at DESC);
+
+-- How each user wants each type of notification batched and emailed; types without a row use the defaults
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id BIGINT NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    batch_window_minutes INT NOT NULL,
+    email BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, type),
+    CONSTRAINT fk_notification_preferences_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_notification_preferences_window CHECK (batch_window_minutes BETWEEN 0 AND 1440)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_preferences;
+DROP INDEX IF EXISTS idx_notifications_user_id;
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at DESC);
+DROP INDEX IF EXISTS idx_notifications_email_pending;
+DROP INDEX IF EXISTS idx_notifications_open_batches;
+ALTER TABLE notifications DROP COLUMN IF EXISTS emailed_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS email_pending;
+ALTER TABLE notifications DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS event_count;
+ALTER TABLE notifications DROP COLUMN IF EXISTS batch_closes_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS batch_key;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Incremented by every edit to a recipe, so an edit based on an older version can be rejected instead of
+-- silently overwriting changes made in the meantime
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS version INT DEFAULT 1 NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE recipes DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Who changed what on a recipe and when, newest last
+-- user_id is NULL for automatic changes such as archiving stale drafts; changes holds the action's details,
+-- e.g. {"title": {"from": "Soup", "to": "Tomato soup"}} for an update
+CREATE TABLE IF NOT EXISTS recipe_activity (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    recipe_id BIGINT NOT NULL,
+    user_id BIGINT,
+    action VARCHAR(30) NOT NULL,
+    changes JSONB DEFAULT '{}'::JSONB NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_recipe_activity_recipes FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_recipe_activity_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_recipe_activity_recipe_id ON recipe_activity(recipe_id, id DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS recipe_activity;
+-- +goose StatementEnd
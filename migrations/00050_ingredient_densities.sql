@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Grams per millilitre, used to convert between volume and weight (1 cup of flour is about 125 g, of sugar 200 g)
+-- Catalog densities are reference values maintained by migrations
+ALTER TABLE ingredients ADD COLUMN IF NOT EXISTS density_g_per_ml NUMERIC(8, 4);
+ALTER TABLE ingredients ADD CONSTRAINT chk_ingredients_density CHECK (density_g_per_ml > 0);
+
+INSERT INTO ingredients (name, density_g_per_ml) VALUES
+    ('water', 1.0),
+    ('milk', 1.03),
+    ('heavy cream', 0.99),
+    ('yogurt', 1.03),
+    ('sour cream', 1.01),
+    ('butter', 0.96),
+    ('olive oil', 0.91),
+    ('vegetable oil', 0.92),
+    ('honey', 1.42),
+    ('maple syrup', 1.32),
+    ('flour', 0.53),
+    ('all-purpose flour', 0.53),
+    ('bread flour', 0.55),
+    ('whole wheat flour', 0.51),
+    ('cornstarch', 0.54),
+    ('sugar', 0.85),
+    ('granulated sugar', 0.85),
+    ('brown sugar', 0.93),
+    ('powdered sugar', 0.51),
+    ('cocoa powder', 0.42),
+    ('salt', 1.22),
+    ('baking soda', 0.93),
+    ('baking powder', 0.81),
+    ('rice', 0.85),
+    ('rolled oats', 0.41)
+ON CONFLICT (name) DO UPDATE SET density_g_per_ml = EXCLUDED.density_g_per_ml;
+
+-- Densities set by admins, which take precedence over the catalog
+CREATE TABLE IF NOT EXISTS ingredient_density_overrides (
+    ingredient_id BIGINT PRIMARY KEY,
+    density_g_per_ml NUMERIC(8, 4) NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_ingredient_density_overrides_ingredients FOREIGN KEY (ingredient_id) REFERENCES ingredients(id) ON DELETE CASCADE,
+    CONSTRAINT chk_ingredient_density_overrides_density CHECK (density_g_per_ml > 0)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ingredient_density_overrides;
+ALTER TABLE ingredients DROP CONSTRAINT IF EXISTS chk_ingredients_density;
+ALTER TABLE ingredients DROP COLUMN IF EXISTS density_g_per_ml;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Temporary links that let anyone holding them read a draft recipe before it is published
+-- Only a SHA-256 hash of each token is stored; links stop working once expired or revoked
+CREATE TABLE IF NOT EXISTS recipe_preview_links (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    recipe_id BIGINT NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    view_count INT DEFAULT 0 NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    last_viewed_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    CONSTRAINT fk_recipe_preview_links_recipes FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_recipe_preview_links_recipe_id ON recipe_preview_links(recipe_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS recipe_preview_links;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- How often each recipe was viewed per UTC day, not counting its author
+-- Views are counted in memory and added here in batches
+CREATE TABLE IF NOT EXISTS recipe_daily_views (
+    recipe_id BIGINT NOT NULL,
+    day DATE NOT NULL,
+    views INT DEFAULT 0 NOT NULL,
+    PRIMARY KEY (recipe_id, day),
+    CONSTRAINT fk_recipe_daily_views_recipes FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE
+);
+
+-- Background exports now also build per-recipe statistics as CSV, covering period_from to period_to
+ALTER TABLE account_exports ADD COLUMN IF NOT EXISTS kind VARCHAR(20) DEFAULT 'account' NOT NULL;
+ALTER TABLE account_exports ADD COLUMN IF NOT EXISTS period_from DATE;
+ALTER TABLE account_exports ADD COLUMN IF NOT EXISTS period_to DATE;
+ALTER TABLE account_exports ADD CONSTRAINT chk_account_exports_kind CHECK (kind IN ('account', 'stats'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE account_exports DROP CONSTRAINT IF EXISTS chk_account_exports_kind;
+ALTER TABLE account_exports DROP COLUMN IF EXISTS period_to;
+ALTER TABLE account_exports DROP COLUMN IF EXISTS period_from;
+ALTER TABLE account_exports DROP COLUMN IF EXISTS kind;
+DROP TABLE IF EXISTS recipe_daily_views;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The language a recipe's title and description are written in, as an ISO 639-1 code, detected whenever
+-- the recipe is saved; NULL when it could not be told, and for recipes not saved since
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS language VARCHAR(8);
+
+CREATE INDEX IF NOT EXISTS idx_recipes_language ON recipes(language) WHERE status = 'published';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_recipes_language;
+ALTER TABLE recipes DROP COLUMN IF EXISTS language;
+-- +goose StatementEnd
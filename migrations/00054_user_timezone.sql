@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The IANA time zone a user is in, such as Europe/Paris; NULL means UTC
+-- It sets where the user's days begin for meal plans and when digest emails may be sent
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The recipe author's pinned response to a review, shown with the review wherever reviews are listed
+-- Keyed by review so each review has at most one; replacing it keeps created_at and moves updated_at
+CREATE TABLE IF NOT EXISTS review_author_responses (
+    review_id BIGINT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fk_review_author_responses_reviews FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE,
+    CONSTRAINT fk_review_author_responses_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS review_author_responses;
+-- +goose StatementEnd
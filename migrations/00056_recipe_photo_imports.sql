@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Photos added by external link are downloaded into our own storage in the background, so recipes
+-- keep their photos when the original host removes them or blocks hotlinking
+-- import_status is 'pending' until the photo is imported or given up on ('failed'), and NULL for uploaded photos
+ALTER TABLE recipe_photos
+    ADD COLUMN IF NOT EXISTS import_status VARCHAR(16),
+    ADD COLUMN IF NOT EXISTS import_attempts INT DEFAULT 0 NOT NULL,
+    ADD COLUMN IF NOT EXISTS import_retry_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS import_error TEXT,
+    ADD CONSTRAINT chk_recipe_photos_import_status CHECK (import_status IN ('pending', 'failed'));
+
+UPDATE recipe_photos SET import_status = 'pending' WHERE storage_key IS NULL AND photo_url IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_recipe_photos_import_pending ON recipe_photos(import_retry_at) WHERE import_status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_recipe_photos_import_pending;
+ALTER TABLE recipe_photos
+    DROP CONSTRAINT IF EXISTS chk_recipe_photos_import_status,
+    DROP COLUMN IF EXISTS import_error,
+    DROP COLUMN IF EXISTS import_retry_at,
+    DROP COLUMN IF EXISTS import_attempts,
+    DROP COLUMN IF EXISTS import_status;
+-- +goose StatementEnd
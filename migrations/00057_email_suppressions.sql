@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Addresses no email is sent to: hard bounces and spam complaints reported by the email provider, and manual opt-outs
+-- One row per address, matched case-insensitively; removing the row lets emails through again
+CREATE TABLE IF NOT EXISTS email_suppressions (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    email VARCHAR(255) NOT NULL,
+    reason VARCHAR(16) NOT NULL,
+    note TEXT,
+    created_by BIGINT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT chk_email_suppressions_reason CHECK (reason IN ('bounce', 'complaint', 'manual')),
+    CONSTRAINT fk_email_suppressions_users FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_suppressions_email ON email_suppressions(LOWER(email));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS email_suppressions;
+-- +goose StatementEnd
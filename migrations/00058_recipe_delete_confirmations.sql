@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Pending deletes of recipes with enough favorites and reviews that the author must confirm them
+-- One row per recipe, replaced whenever the delete is requested again; the token is stored hashed
+CREATE TABLE IF NOT EXISTS recipe_delete_confirmations (
+    recipe_id BIGINT PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_recipe_delete_confirmations_recipes FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS recipe_delete_confirmations;
+-- +goose StatementEnd
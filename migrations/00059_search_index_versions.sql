@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The document version each external search engine was last rebuilt for
+-- A deploy that changes search documents or index settings bumps the version in code, and the first
+-- instance to start claims the new version and rebuilds the index
+CREATE TABLE IF NOT EXISTS search_index_versions (
+    engine VARCHAR(32) PRIMARY KEY,
+    version INT NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS search_index_versions;
+-- +goose StatementEnd
@@ -0,0 +1,82 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Photos are shown in the order their author chose; existing photos keep their primary-first, oldest-first order
+ALTER TABLE recipe_photos ADD COLUMN IF NOT EXISTS position INT;
+
+UPDATE recipe_photos rp
+SET position = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY recipe_id ORDER BY is_primary DESC, id) AS position
+    FROM recipe_photos
+) ordered
+WHERE ordered.id = rp.id;
+
+ALTER TABLE recipe_photos ALTER COLUMN position SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_recipe_photos_recipe_id;
+CREATE INDEX IF NOT EXISTS idx_recipe_photos_recipe_position ON recipe_photos(recipe_id, position, id);
+
+-- Recipes without a primary photo are shown with the first photo of their gallery
+CREATE OR REPLACE FUNCTION update_recipe_summary_photo() RETURNS TRIGGER AS $$
+DECLARE
+    changed RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    UPDATE recipe_summaries
+    SET primary_photo_id = (
+        SELECT rp.id FROM recipe_photos rp
+        WHERE rp.recipe_id = changed.recipe_id
+        ORDER BY rp.is_primary DESC, rp.position, rp.id
+        LIMIT 1
+    )
+    WHERE recipe_id = changed.recipe_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_recipe_photos_recipe_summary ON recipe_photos;
+CREATE TRIGGER trg_recipe_photos_recipe_summary
+    AFTER INSERT OR UPDATE OF is_primary, position OR DELETE ON recipe_photos
+    FOR EACH ROW EXECUTE FUNCTION update_recipe_summary_photo();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_recipe_photos_recipe_summary ON recipe_photos;
+CREATE OR REPLACE FUNCTION update_recipe_summary_photo() RETURNS TRIGGER AS $$
+DECLARE
+    changed RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+    ELSE
+        changed := NEW;
+    END IF;
+
+    UPDATE recipe_summaries
+    SET primary_photo_id = (
+        SELECT rp.id FROM recipe_photos rp
+        WHERE rp.recipe_id = changed.recipe_id
+        ORDER BY rp.is_primary DESC, rp.id
+        LIMIT 1
+    )
+    WHERE recipe_id = changed.recipe_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_recipe_photos_recipe_summary
+    AFTER INSERT OR UPDATE OF is_primary OR DELETE ON recipe_photos
+    FOR EACH ROW EXECUTE FUNCTION update_recipe_summary_photo();
+
+DROP INDEX IF EXISTS idx_recipe_photos_recipe_position;
+CREATE INDEX IF NOT EXISTS idx_recipe_photos_recipe_id ON recipe_photos(recipe_id);
+ALTER TABLE recipe_photos DROP COLUMN IF EXISTS position;
+-- +goose StatementEnd
@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Allergens each catalog ingredient contains (e.g. ["dairy"]), used to infer the allergens of recipes
+-- Like densities, these are reference values maintained by migrations
+ALTER TABLE ingredients ADD COLUMN IF NOT EXISTS allergens JSONB NOT NULL DEFAULT '[]'::JSONB;
+
+INSERT INTO ingredients (name, allergens) VALUES
+    ('milk', '["dairy"]'),
+    ('whole milk', '["dairy"]'),
+    ('buttermilk', '["dairy"]'),
+    ('cream', '["dairy"]'),
+    ('heavy cream', '["dairy"]'),
+    ('whipped cream', '["dairy"]'),
+    ('sour cream', '["dairy"]'),
+    ('yogurt', '["dairy"]'),
+    ('greek yogurt', '["dairy"]'),
+    ('butter', '["dairy"]'),
+    ('ghee', '["dairy"]'),
+    ('cheese', '["dairy"]'),
+    ('cheddar', '["dairy"]'),
+    ('cream cheese', '["dairy"]'),
+    ('feta', '["dairy"]'),
+    ('mozzarella', '["dairy"]'),
+    ('parmesan', '["dairy"]'),
+    ('ricotta', '["dairy"]'),
+    ('condensed milk', '["dairy"]'),
+    ('flour', '["gluten"]'),
+    ('all-purpose flour', '["gluten"]'),
+    ('bread flour', '["gluten"]'),
+    ('whole wheat flour', '["gluten"]'),
+    ('self-raising flour', '["gluten"]'),
+    ('wheat', '["gluten"]'),
+    ('semolina', '["gluten"]'),
+    ('barley', '["gluten"]'),
+    ('rye flour', '["gluten"]'),
+    ('couscous', '["gluten"]'),
+    ('bread', '["gluten"]'),
+    ('breadcrumbs', '["gluten"]'),
+    ('panko', '["gluten"]'),
+    ('pasta', '["gluten"]'),
+    ('spaghetti', '["gluten"]'),
+    ('noodles', '["gluten"]'),
+    ('soy sauce', '["gluten"]'),
+    ('almond', '["nuts"]'),
+    ('almonds', '["nuts"]'),
+    ('almond flour', '["nuts"]'),
+    ('almond milk', '["nuts"]'),
+    ('cashews', '["nuts"]'),
+    ('hazelnuts', '["nuts"]'),
+    ('macadamia nuts', '["nuts"]'),
+    ('peanuts', '["nuts"]'),
+    ('peanut butter', '["nuts"]'),
+    ('pecans', '["nuts"]'),
+    ('pine nuts', '["nuts"]'),
+    ('pistachios', '["nuts"]'),
+    ('walnuts', '["nuts"]'),
+    ('chocolate hazelnut spread', '["dairy", "nuts"]')
+ON CONFLICT (name) DO UPDATE SET allergens = EXCLUDED.allergens;
+
+-- Authors correct inferred allergens per recipe: contains TRUE adds an allergen, FALSE removes one
+-- (e.g. a recipe whose flour is a gluten-free blend the catalog cannot tell apart)
+CREATE TABLE IF NOT EXISTS recipe_allergen_overrides (
+    recipe_id BIGINT NOT NULL,
+    allergen VARCHAR(32) NOT NULL,
+    contains BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (recipe_id, allergen),
+    CONSTRAINT fk_recipe_allergen_overrides_recipes FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS recipe_allergen_overrides;
+ALTER TABLE ingredients DROP COLUMN IF EXISTS allergens;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Lets an author's recipes be read in ID order a page at a time without sorting the whole catalog for each page
+CREATE INDEX IF NOT EXISTS idx_recipes_user_id_id ON recipes(user_id, id);
+DROP INDEX IF EXISTS idx_recipes_user_id;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_recipes_user_id ON recipes(user_id);
+DROP INDEX IF EXISTS idx_recipes_user_id_id;
+-- +goose StatementEnd
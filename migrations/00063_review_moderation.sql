@@ -0,0 +1,99 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Hidden reviews stay in the database but are left out of listings and ratings
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ;
+
+-- Bulk hide or delete actions queued by admins to clean up spam waves, run in batches by a background worker
+-- A job matches reviews by author, by text in the comment, or both; matched is counted when the job starts
+CREATE TABLE IF NOT EXISTS review_moderation_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    action VARCHAR(10) NOT NULL,
+    user_id BIGINT,
+    pattern TEXT,
+    status VARCHAR(20) DEFAULT 'queued' NOT NULL,
+    progress SMALLINT DEFAULT 0 NOT NULL,
+    matched INTEGER DEFAULT 0 NOT NULL,
+    processed INTEGER DEFAULT 0 NOT NULL,
+    error TEXT,
+    created_by BIGINT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    CONSTRAINT fk_review_moderation_jobs_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT fk_review_moderation_jobs_created_by FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT chk_review_moderation_jobs_action CHECK (action IN ('hide', 'delete')),
+    CONSTRAINT chk_review_moderation_jobs_status CHECK (status IN ('queued', 'running', 'done', 'failed')),
+    CONSTRAINT chk_review_moderation_jobs_progress CHECK (progress BETWEEN 0 AND 100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_moderation_jobs_created_at ON review_moderation_jobs(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_review_moderation_jobs_pending ON review_moderation_jobs(created_at) WHERE status IN ('queued', 'running');
+
+-- Hiding or unhiding a review moves it out of or back into the rating totals
+CREATE OR REPLACE FUNCTION update_recipe_summary_reviews() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.hidden_at IS NULL THEN
+        UPDATE recipe_summaries s
+        SET rating_sum = s.rating_sum - OLD.rating, review_count = s.review_count - 1
+        FROM recipes r
+        WHERE s.recipe_id = OLD.recipe_id AND r.id = OLD.recipe_id AND r.user_id <> OLD.user_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.hidden_at IS NULL THEN
+        UPDATE recipe_summaries s
+        SET rating_sum = s.rating_sum + NEW.rating, review_count = s.review_count + 1
+        FROM recipes r
+        WHERE s.recipe_id = NEW.recipe_id AND r.id = NEW.recipe_id AND r.user_id <> NEW.user_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_reviews_recipe_summary ON reviews;
+CREATE TRIGGER trg_reviews_recipe_summary
+    AFTER INSERT OR UPDATE OF recipe_id, user_id, rating, hidden_at OR DELETE ON reviews
+    FOR EACH ROW EXECUTE FUNCTION update_recipe_summary_reviews();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS trg_reviews_recipe_summary ON reviews;
+
+-- Hidden reviews count again once the column is gone, so add them back to the totals
+UPDATE recipe_summaries s
+SET rating_sum = s.rating_sum + hidden.rating_sum, review_count = s.review_count + hidden.review_count
+FROM (
+    SELECT rv.recipe_id, SUM(rv.rating) AS rating_sum, COUNT(*) AS review_count
+    FROM reviews rv
+    JOIN recipes r ON r.id = rv.recipe_id
+    WHERE rv.hidden_at IS NOT NULL AND rv.user_id <> r.user_id
+    GROUP BY rv.recipe_id
+) hidden
+WHERE s.recipe_id = hidden.recipe_id;
+
+CREATE OR REPLACE FUNCTION update_recipe_summary_reviews() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE recipe_summaries s
+        SET rating_sum = s.rating_sum - OLD.rating, review_count = s.review_count - 1
+        FROM recipes r
+        WHERE s.recipe_id = OLD.recipe_id AND r.id = OLD.recipe_id AND r.user_id <> OLD.user_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE recipe_summaries s
+        SET rating_sum = s.rating_sum + NEW.rating, review_count = s.review_count + 1
+        FROM recipes r
+        WHERE s.recipe_id = NEW.recipe_id AND r.id = NEW.recipe_id AND r.user_id <> NEW.user_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_reviews_recipe_summary
+    AFTER INSERT OR UPDATE OF recipe_id, user_id, rating OR DELETE ON reviews
+    FOR EACH ROW EXECUTE FUNCTION update_recipe_summary_reviews();
+
+DROP TABLE IF EXISTS review_moderation_jobs;
+ALTER TABLE reviews DROP COLUMN IF EXISTS hidden_at;
+-- +goose StatementEnd
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cloudinary_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=cloudinary_storage.go -destination=../mocks/services/cloudinary_storage.go -package=mockservices
+//
+
+// Package mockservices is a generated GoMock package.
+package mockservices
+
+import (
+	reflect "reflect"
+
+	services "github.com/dapoadedire/chefshare_be/services"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImageTransformer is a mock of ImageTransformer interface.
+type MockImageTransformer struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageTransformerMockRecorder
+	isgomock struct{}
+}
+
+// MockImageTransformerMockRecorder is the mock recorder for MockImageTransformer.
+type MockImageTransformerMockRecorder struct {
+	mock *MockImageTransformer
+}
+
+// NewMockImageTransformer creates a new mock instance.
+func NewMockImageTransformer(ctrl *gomock.Controller) *MockImageTransformer {
+	mock := &MockImageTransformer{ctrl: ctrl}
+	mock.recorder = &MockImageTransformerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageTransformer) EXPECT() *MockImageTransformerMockRecorder {
+	return m.recorder
+}
+
+// TransformedURL mocks base method.
+func (m *MockImageTransformer) TransformedURL(key string, transform services.ImageTransform) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransformedURL", key, transform)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransformedURL indicates an expected call of TransformedURL.
+func (mr *MockImageTransformerMockRecorder) TransformedURL(key, transform any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformedURL", reflect.TypeOf((*MockImageTransformer)(nil).TransformedURL), key, transform)
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: error_reporter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=error_reporter.go -destination=../mocks/services/error_reporter.go -package=mockservices
+//
+
+// Package mockservices is a generated GoMock package.
+package mockservices
+
+import (
+	reflect "reflect"
+
+	services "github.com/dapoadedire/chefshare_be/services"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockErrorReporter is a mock of ErrorReporter interface.
+type MockErrorReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockErrorReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockErrorReporterMockRecorder is the mock recorder for MockErrorReporter.
+type MockErrorReporterMockRecorder struct {
+	mock *MockErrorReporter
+}
+
+// NewMockErrorReporter creates a new mock instance.
+func NewMockErrorReporter(ctrl *gomock.Controller) *MockErrorReporter {
+	mock := &MockErrorReporter{ctrl: ctrl}
+	mock.recorder = &MockErrorReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockErrorReporter) EXPECT() *MockErrorReporterMockRecorder {
+	return m.recorder
+}
+
+// Report mocks base method.
+func (m *MockErrorReporter) Report(report services.ErrorReport) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Report", report)
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockErrorReporterMockRecorder) Report(report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockErrorReporter)(nil).Report), report)
+}
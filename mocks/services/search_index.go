@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_index.go
+//
+// Generated by this command:
+//
+//	mockgen -source=search_index.go -destination=../mocks/services/search_index.go -package=mockservices
+//
+
+// Package mockservices is a generated GoMock package.
+package mockservices
+
+import (
+	context "context"
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSearchIndex is a mock of SearchIndex interface.
+type MockSearchIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchIndexMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchIndexMockRecorder is the mock recorder for MockSearchIndex.
+type MockSearchIndexMockRecorder struct {
+	mock *MockSearchIndex
+}
+
+// NewMockSearchIndex creates a new mock instance.
+func NewMockSearchIndex(ctrl *gomock.Controller) *MockSearchIndex {
+	mock := &MockSearchIndex{ctrl: ctrl}
+	mock.recorder = &MockSearchIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchIndex) EXPECT() *MockSearchIndexMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockSearchIndex) Clear() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockSearchIndexMockRecorder) Clear() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockSearchIndex)(nil).Clear))
+}
+
+// Engine mocks base method.
+func (m *MockSearchIndex) Engine() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Engine")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Engine indicates an expected call of Engine.
+func (mr *MockSearchIndexMockRecorder) Engine() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Engine", reflect.TypeOf((*MockSearchIndex)(nil).Engine))
+}
+
+// IndexRecipes mocks base method.
+func (m *MockSearchIndex) IndexRecipes(documents []*store.SearchDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexRecipes", documents)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexRecipes indicates an expected call of IndexRecipes.
+func (mr *MockSearchIndexMockRecorder) IndexRecipes(documents any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexRecipes", reflect.TypeOf((*MockSearchIndex)(nil).IndexRecipes), documents)
+}
+
+// RemoveRecipes mocks base method.
+func (m *MockSearchIndex) RemoveRecipes(recipeIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRecipes", recipeIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRecipes indicates an expected call of RemoveRecipes.
+func (mr *MockSearchIndexMockRecorder) RemoveRecipes(recipeIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRecipes", reflect.TypeOf((*MockSearchIndex)(nil).RemoveRecipes), recipeIDs)
+}
+
+// SearchFacets mocks base method.
+func (m *MockSearchIndex) SearchFacets(ctx context.Context, query string, opts store.RecipeListOptions) (*store.RecipeFacets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFacets", ctx, query, opts)
+	ret0, _ := ret[0].(*store.RecipeFacets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFacets indicates an expected call of SearchFacets.
+func (mr *MockSearchIndexMockRecorder) SearchFacets(ctx, query, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFacets", reflect.TypeOf((*MockSearchIndex)(nil).SearchFacets), ctx, query, opts)
+}
+
+// SearchRecipes mocks base method.
+func (m *MockSearchIndex) SearchRecipes(ctx context.Context, query string, opts store.RecipeListOptions) ([]*store.RecipeSearchResult, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchRecipes", ctx, query, opts)
+	ret0, _ := ret[0].([]*store.RecipeSearchResult)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchRecipes indicates an expected call of SearchRecipes.
+func (mr *MockSearchIndexMockRecorder) SearchRecipes(ctx, query, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRecipes", reflect.TypeOf((*MockSearchIndex)(nil).SearchRecipes), ctx, query, opts)
+}
+
+// Setup mocks base method.
+func (m *MockSearchIndex) Setup() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Setup")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Setup indicates an expected call of Setup.
+func (mr *MockSearchIndexMockRecorder) Setup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockSearchIndex)(nil).Setup))
+}
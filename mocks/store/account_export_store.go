@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: account_export_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=account_export_store.go -destination=../mocks/store/account_export_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountExportStore is a mock of AccountExportStore interface.
+type MockAccountExportStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountExportStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountExportStoreMockRecorder is the mock recorder for MockAccountExportStore.
+type MockAccountExportStoreMockRecorder struct {
+	mock *MockAccountExportStore
+}
+
+// NewMockAccountExportStore creates a new mock instance.
+func NewMockAccountExportStore(ctrl *gomock.Controller) *MockAccountExportStore {
+	mock := &MockAccountExportStore{ctrl: ctrl}
+	mock.recorder = &MockAccountExportStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountExportStore) EXPECT() *MockAccountExportStoreMockRecorder {
+	return m.recorder
+}
+
+// ClaimAccountExport mocks base method.
+func (m *MockAccountExportStore) ClaimAccountExport(staleBefore time.Time) (*store.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimAccountExport", staleBefore)
+	ret0, _ := ret[0].(*store.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimAccountExport indicates an expected call of ClaimAccountExport.
+func (mr *MockAccountExportStoreMockRecorder) ClaimAccountExport(staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimAccountExport", reflect.TypeOf((*MockAccountExportStore)(nil).ClaimAccountExport), staleBefore)
+}
+
+// ClearAccountExportArchive mocks base method.
+func (m *MockAccountExportStore) ClearAccountExportArchive(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearAccountExportArchive", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearAccountExportArchive indicates an expected call of ClearAccountExportArchive.
+func (mr *MockAccountExportStoreMockRecorder) ClearAccountExportArchive(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearAccountExportArchive", reflect.TypeOf((*MockAccountExportStore)(nil).ClearAccountExportArchive), id)
+}
+
+// CompleteAccountExport mocks base method.
+func (m *MockAccountExportStore) CompleteAccountExport(id int64, storageKey string, size int64, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAccountExport", id, storageKey, size, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteAccountExport indicates an expected call of CompleteAccountExport.
+func (mr *MockAccountExportStoreMockRecorder) CompleteAccountExport(id, storageKey, size, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAccountExport", reflect.TypeOf((*MockAccountExportStore)(nil).CompleteAccountExport), id, storageKey, size, expiresAt)
+}
+
+// CreateAccountExport mocks base method.
+func (m *MockAccountExportStore) CreateAccountExport(userID int64, kind string, from, to *time.Time) (*store.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountExport", userID, kind, from, to)
+	ret0, _ := ret[0].(*store.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountExport indicates an expected call of CreateAccountExport.
+func (mr *MockAccountExportStoreMockRecorder) CreateAccountExport(userID, kind, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountExport", reflect.TypeOf((*MockAccountExportStore)(nil).CreateAccountExport), userID, kind, from, to)
+}
+
+// FailAccountExport mocks base method.
+func (m *MockAccountExportStore) FailAccountExport(id int64, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailAccountExport", id, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailAccountExport indicates an expected call of FailAccountExport.
+func (mr *MockAccountExportStoreMockRecorder) FailAccountExport(id, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailAccountExport", reflect.TypeOf((*MockAccountExportStore)(nil).FailAccountExport), id, message)
+}
+
+// GetAccountExport mocks base method.
+func (m *MockAccountExportStore) GetAccountExport(id, userID int64) (*store.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountExport", id, userID)
+	ret0, _ := ret[0].(*store.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountExport indicates an expected call of GetAccountExport.
+func (mr *MockAccountExportStoreMockRecorder) GetAccountExport(id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountExport", reflect.TypeOf((*MockAccountExportStore)(nil).GetAccountExport), id, userID)
+}
+
+// GetExpiredAccountExports mocks base method.
+func (m *MockAccountExportStore) GetExpiredAccountExports(now time.Time, limit int) ([]*store.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredAccountExports", now, limit)
+	ret0, _ := ret[0].([]*store.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredAccountExports indicates an expected call of GetExpiredAccountExports.
+func (mr *MockAccountExportStoreMockRecorder) GetExpiredAccountExports(now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredAccountExports", reflect.TypeOf((*MockAccountExportStore)(nil).GetExpiredAccountExports), now, limit)
+}
+
+// GetPendingAccountExport mocks base method.
+func (m *MockAccountExportStore) GetPendingAccountExport(userID int64, kind string) (*store.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingAccountExport", userID, kind)
+	ret0, _ := ret[0].(*store.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingAccountExport indicates an expected call of GetPendingAccountExport.
+func (mr *MockAccountExportStoreMockRecorder) GetPendingAccountExport(userID, kind any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingAccountExport", reflect.TypeOf((*MockAccountExportStore)(nil).GetPendingAccountExport), userID, kind)
+}
+
+// SetAccountExportProgress mocks base method.
+func (m *MockAccountExportStore) SetAccountExportProgress(id int64, progress int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccountExportProgress", id, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAccountExportProgress indicates an expected call of SetAccountExportProgress.
+func (mr *MockAccountExportStoreMockRecorder) SetAccountExportProgress(id, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccountExportProgress", reflect.TypeOf((*MockAccountExportStore)(nil).SetAccountExportProgress), id, progress)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: block_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=block_store.go -destination=../mocks/store/block_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBlockStore is a mock of BlockStore interface.
+type MockBlockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockBlockStoreMockRecorder is the mock recorder for MockBlockStore.
+type MockBlockStoreMockRecorder struct {
+	mock *MockBlockStore
+}
+
+// NewMockBlockStore creates a new mock instance.
+func NewMockBlockStore(ctrl *gomock.Controller) *MockBlockStore {
+	mock := &MockBlockStore{ctrl: ctrl}
+	mock.recorder = &MockBlockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockStore) EXPECT() *MockBlockStoreMockRecorder {
+	return m.recorder
+}
+
+// BlockUser mocks base method.
+func (m *MockBlockStore) BlockUser(blockerID, blockedID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUser", blockerID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUser indicates an expected call of BlockUser.
+func (mr *MockBlockStoreMockRecorder) BlockUser(blockerID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUser", reflect.TypeOf((*MockBlockStore)(nil).BlockUser), blockerID, blockedID)
+}
+
+// GetBlockedUsers mocks base method.
+func (m *MockBlockStore) GetBlockedUsers(blockerID int64) ([]*store.BlockedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedUsers", blockerID)
+	ret0, _ := ret[0].([]*store.BlockedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedUsers indicates an expected call of GetBlockedUsers.
+func (mr *MockBlockStoreMockRecorder) GetBlockedUsers(blockerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedUsers", reflect.TypeOf((*MockBlockStore)(nil).GetBlockedUsers), blockerID)
+}
+
+// IsBlockedEitherWay mocks base method.
+func (m *MockBlockStore) IsBlockedEitherWay(userID, otherID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlockedEitherWay", userID, otherID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlockedEitherWay indicates an expected call of IsBlockedEitherWay.
+func (mr *MockBlockStoreMockRecorder) IsBlockedEitherWay(userID, otherID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlockedEitherWay", reflect.TypeOf((*MockBlockStore)(nil).IsBlockedEitherWay), userID, otherID)
+}
+
+// UnblockUser mocks base method.
+func (m *MockBlockStore) UnblockUser(blockerID, blockedID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnblockUser", blockerID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnblockUser indicates an expected call of UnblockUser.
+func (mr *MockBlockStoreMockRecorder) UnblockUser(blockerID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockUser", reflect.TypeOf((*MockBlockStore)(nil).UnblockUser), blockerID, blockedID)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: chef_application_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=chef_application_store.go -destination=../mocks/store/chef_application_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChefApplicationStore is a mock of ChefApplicationStore interface.
+type MockChefApplicationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockChefApplicationStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockChefApplicationStoreMockRecorder is the mock recorder for MockChefApplicationStore.
+type MockChefApplicationStoreMockRecorder struct {
+	mock *MockChefApplicationStore
+}
+
+// NewMockChefApplicationStore creates a new mock instance.
+func NewMockChefApplicationStore(ctrl *gomock.Controller) *MockChefApplicationStore {
+	mock := &MockChefApplicationStore{ctrl: ctrl}
+	mock.recorder = &MockChefApplicationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChefApplicationStore) EXPECT() *MockChefApplicationStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateChefApplication mocks base method.
+func (m *MockChefApplicationStore) CreateChefApplication(userID int64, credentials string, portfolioURL *string) (*store.ChefApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChefApplication", userID, credentials, portfolioURL)
+	ret0, _ := ret[0].(*store.ChefApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChefApplication indicates an expected call of CreateChefApplication.
+func (mr *MockChefApplicationStoreMockRecorder) CreateChefApplication(userID, credentials, portfolioURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChefApplication", reflect.TypeOf((*MockChefApplicationStore)(nil).CreateChefApplication), userID, credentials, portfolioURL)
+}
+
+// GetChefApplications mocks base method.
+func (m *MockChefApplicationStore) GetChefApplications(status string, page, limit int) ([]*store.ChefApplication, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChefApplications", status, page, limit)
+	ret0, _ := ret[0].([]*store.ChefApplication)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChefApplications indicates an expected call of GetChefApplications.
+func (mr *MockChefApplicationStoreMockRecorder) GetChefApplications(status, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChefApplications", reflect.TypeOf((*MockChefApplicationStore)(nil).GetChefApplications), status, page, limit)
+}
+
+// GetLatestChefApplication mocks base method.
+func (m *MockChefApplicationStore) GetLatestChefApplication(userID int64) (*store.ChefApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestChefApplication", userID)
+	ret0, _ := ret[0].(*store.ChefApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestChefApplication indicates an expected call of GetLatestChefApplication.
+func (mr *MockChefApplicationStoreMockRecorder) GetLatestChefApplication(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestChefApplication", reflect.TypeOf((*MockChefApplicationStore)(nil).GetLatestChefApplication), userID)
+}
+
+// ReviewChefApplication mocks base method.
+func (m *MockChefApplicationStore) ReviewChefApplication(id, reviewerID int64, status string, note *string) (*store.ChefApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewChefApplication", id, reviewerID, status, note)
+	ret0, _ := ret[0].(*store.ChefApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewChefApplication indicates an expected call of ReviewChefApplication.
+func (mr *MockChefApplicationStoreMockRecorder) ReviewChefApplication(id, reviewerID, status, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewChefApplication", reflect.TypeOf((*MockChefApplicationStore)(nil).ReviewChefApplication), id, reviewerID, status, note)
+}
@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: curated_collection_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=curated_collection_store.go -destination=../mocks/store/curated_collection_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCuratedCollectionStore is a mock of CuratedCollectionStore interface.
+type MockCuratedCollectionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCuratedCollectionStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockCuratedCollectionStoreMockRecorder is the mock recorder for MockCuratedCollectionStore.
+type MockCuratedCollectionStoreMockRecorder struct {
+	mock *MockCuratedCollectionStore
+}
+
+// NewMockCuratedCollectionStore creates a new mock instance.
+func NewMockCuratedCollectionStore(ctrl *gomock.Controller) *MockCuratedCollectionStore {
+	mock := &MockCuratedCollectionStore{ctrl: ctrl}
+	mock.recorder = &MockCuratedCollectionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCuratedCollectionStore) EXPECT() *MockCuratedCollectionStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateCollection mocks base method.
+func (m *MockCuratedCollectionStore) CreateCollection(collection *store.CuratedCollection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", collection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockCuratedCollectionStoreMockRecorder) CreateCollection(collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockCuratedCollectionStore)(nil).CreateCollection), collection)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockCuratedCollectionStore) DeleteCollection(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockCuratedCollectionStoreMockRecorder) DeleteCollection(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockCuratedCollectionStore)(nil).DeleteCollection), id)
+}
+
+// GetActiveCollectionBySlug mocks base method.
+func (m *MockCuratedCollectionStore) GetActiveCollectionBySlug(slug string) (*store.CuratedCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveCollectionBySlug", slug)
+	ret0, _ := ret[0].(*store.CuratedCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveCollectionBySlug indicates an expected call of GetActiveCollectionBySlug.
+func (mr *MockCuratedCollectionStoreMockRecorder) GetActiveCollectionBySlug(slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveCollectionBySlug", reflect.TypeOf((*MockCuratedCollectionStore)(nil).GetActiveCollectionBySlug), slug)
+}
+
+// GetCollection mocks base method.
+func (m *MockCuratedCollectionStore) GetCollection(id int64) (*store.CuratedCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollection", id)
+	ret0, _ := ret[0].(*store.CuratedCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollection indicates an expected call of GetCollection.
+func (mr *MockCuratedCollectionStoreMockRecorder) GetCollection(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollection", reflect.TypeOf((*MockCuratedCollectionStore)(nil).GetCollection), id)
+}
+
+// ListActiveCollections mocks base method.
+func (m *MockCuratedCollectionStore) ListActiveCollections() ([]*store.CuratedCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveCollections")
+	ret0, _ := ret[0].([]*store.CuratedCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveCollections indicates an expected call of ListActiveCollections.
+func (mr *MockCuratedCollectionStoreMockRecorder) ListActiveCollections() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveCollections", reflect.TypeOf((*MockCuratedCollectionStore)(nil).ListActiveCollections))
+}
+
+// ListCollections mocks base method.
+func (m *MockCuratedCollectionStore) ListCollections() ([]*store.CuratedCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections")
+	ret0, _ := ret[0].([]*store.CuratedCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockCuratedCollectionStoreMockRecorder) ListCollections() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockCuratedCollectionStore)(nil).ListCollections))
+}
+
+// SetCollectionRecipes mocks base method.
+func (m *MockCuratedCollectionStore) SetCollectionRecipes(collectionID int64, recipeIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCollectionRecipes", collectionID, recipeIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCollectionRecipes indicates an expected call of SetCollectionRecipes.
+func (mr *MockCuratedCollectionStoreMockRecorder) SetCollectionRecipes(collectionID, recipeIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCollectionRecipes", reflect.TypeOf((*MockCuratedCollectionStore)(nil).SetCollectionRecipes), collectionID, recipeIDs)
+}
+
+// UpdateCollection mocks base method.
+func (m *MockCuratedCollectionStore) UpdateCollection(collection *store.CuratedCollection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCollection", collection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCollection indicates an expected call of UpdateCollection.
+func (mr *MockCuratedCollectionStoreMockRecorder) UpdateCollection(collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollection", reflect.TypeOf((*MockCuratedCollectionStore)(nil).UpdateCollection), collection)
+}
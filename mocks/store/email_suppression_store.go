@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: email_suppression_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=email_suppression_store.go -destination=../mocks/store/email_suppression_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmailSuppressionStore is a mock of EmailSuppressionStore interface.
+type MockEmailSuppressionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailSuppressionStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailSuppressionStoreMockRecorder is the mock recorder for MockEmailSuppressionStore.
+type MockEmailSuppressionStoreMockRecorder struct {
+	mock *MockEmailSuppressionStore
+}
+
+// NewMockEmailSuppressionStore creates a new mock instance.
+func NewMockEmailSuppressionStore(ctrl *gomock.Controller) *MockEmailSuppressionStore {
+	mock := &MockEmailSuppressionStore{ctrl: ctrl}
+	mock.recorder = &MockEmailSuppressionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailSuppressionStore) EXPECT() *MockEmailSuppressionStoreMockRecorder {
+	return m.recorder
+}
+
+// AddEmailSuppression mocks base method.
+func (m *MockEmailSuppressionStore) AddEmailSuppression(suppression *store.EmailSuppression) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEmailSuppression", suppression)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEmailSuppression indicates an expected call of AddEmailSuppression.
+func (mr *MockEmailSuppressionStoreMockRecorder) AddEmailSuppression(suppression any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEmailSuppression", reflect.TypeOf((*MockEmailSuppressionStore)(nil).AddEmailSuppression), suppression)
+}
+
+// DeleteEmailSuppression mocks base method.
+func (m *MockEmailSuppressionStore) DeleteEmailSuppression(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmailSuppression", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmailSuppression indicates an expected call of DeleteEmailSuppression.
+func (mr *MockEmailSuppressionStoreMockRecorder) DeleteEmailSuppression(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmailSuppression", reflect.TypeOf((*MockEmailSuppressionStore)(nil).DeleteEmailSuppression), id)
+}
+
+// GetEmailSuppressions mocks base method.
+func (m *MockEmailSuppressionStore) GetEmailSuppressions(opts store.EmailSuppressionListOptions) ([]*store.EmailSuppression, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailSuppressions", opts)
+	ret0, _ := ret[0].([]*store.EmailSuppression)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEmailSuppressions indicates an expected call of GetEmailSuppressions.
+func (mr *MockEmailSuppressionStoreMockRecorder) GetEmailSuppressions(opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailSuppressions", reflect.TypeOf((*MockEmailSuppressionStore)(nil).GetEmailSuppressions), opts)
+}
+
+// GetSuppressedEmails mocks base method.
+func (m *MockEmailSuppressionStore) GetSuppressedEmails(emails []string) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuppressedEmails", emails)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuppressedEmails indicates an expected call of GetSuppressedEmails.
+func (mr *MockEmailSuppressionStoreMockRecorder) GetSuppressedEmails(emails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuppressedEmails", reflect.TypeOf((*MockEmailSuppressionStore)(nil).GetSuppressedEmails), emails)
+}
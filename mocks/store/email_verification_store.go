@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: email_verification_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=email_verification_store.go -destination=../mocks/store/email_verification_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmailVerificationStore is a mock of EmailVerificationStore interface.
+type MockEmailVerificationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailVerificationStoreMockRecorder is the mock recorder for MockEmailVerificationStore.
+type MockEmailVerificationStoreMockRecorder struct {
+	mock *MockEmailVerificationStore
+}
+
+// NewMockEmailVerificationStore creates a new mock instance.
+func NewMockEmailVerificationStore(ctrl *gomock.Controller) *MockEmailVerificationStore {
+	mock := &MockEmailVerificationStore{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationStore) EXPECT() *MockEmailVerificationStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateVerificationToken mocks base method.
+func (m *MockEmailVerificationStore) CreateVerificationToken(userID string, expiryDuration time.Duration) (*store.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVerificationToken", userID, expiryDuration)
+	ret0, _ := ret[0].(*store.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVerificationToken indicates an expected call of CreateVerificationToken.
+func (mr *MockEmailVerificationStoreMockRecorder) CreateVerificationToken(userID, expiryDuration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerificationToken", reflect.TypeOf((*MockEmailVerificationStore)(nil).CreateVerificationToken), userID, expiryDuration)
+}
+
+// DeleteExpiredTokens mocks base method.
+func (m *MockEmailVerificationStore) DeleteExpiredTokens() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredTokens")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredTokens indicates an expected call of DeleteExpiredTokens.
+func (mr *MockEmailVerificationStoreMockRecorder) DeleteExpiredTokens() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredTokens", reflect.TypeOf((*MockEmailVerificationStore)(nil).DeleteExpiredTokens))
+}
+
+// DeleteToken mocks base method.
+func (m *MockEmailVerificationStore) DeleteToken(tokenID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteToken", tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteToken indicates an expected call of DeleteToken.
+func (mr *MockEmailVerificationStoreMockRecorder) DeleteToken(tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockEmailVerificationStore)(nil).DeleteToken), tokenID)
+}
+
+// DeleteUserTokens mocks base method.
+func (m *MockEmailVerificationStore) DeleteUserTokens(userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTokens", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserTokens indicates an expected call of DeleteUserTokens.
+func (mr *MockEmailVerificationStoreMockRecorder) DeleteUserTokens(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTokens", reflect.TypeOf((*MockEmailVerificationStore)(nil).DeleteUserTokens), userID)
+}
+
+// GetVerificationTokenByToken mocks base method.
+func (m *MockEmailVerificationStore) GetVerificationTokenByToken(token string) (*store.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerificationTokenByToken", token)
+	ret0, _ := ret[0].(*store.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerificationTokenByToken indicates an expected call of GetVerificationTokenByToken.
+func (mr *MockEmailVerificationStoreMockRecorder) GetVerificationTokenByToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerificationTokenByToken", reflect.TypeOf((*MockEmailVerificationStore)(nil).GetVerificationTokenByToken), token)
+}
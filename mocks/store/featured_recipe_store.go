@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: featured_recipe_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=featured_recipe_store.go -destination=../mocks/store/featured_recipe_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeaturedRecipeStore is a mock of FeaturedRecipeStore interface.
+type MockFeaturedRecipeStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeaturedRecipeStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockFeaturedRecipeStoreMockRecorder is the mock recorder for MockFeaturedRecipeStore.
+type MockFeaturedRecipeStoreMockRecorder struct {
+	mock *MockFeaturedRecipeStore
+}
+
+// NewMockFeaturedRecipeStore creates a new mock instance.
+func NewMockFeaturedRecipeStore(ctrl *gomock.Controller) *MockFeaturedRecipeStore {
+	mock := &MockFeaturedRecipeStore{ctrl: ctrl}
+	mock.recorder = &MockFeaturedRecipeStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeaturedRecipeStore) EXPECT() *MockFeaturedRecipeStoreMockRecorder {
+	return m.recorder
+}
+
+// FeatureRecipe mocks base method.
+func (m *MockFeaturedRecipeStore) FeatureRecipe(recipeID int64, displayOrder int, expiresAt *time.Time, featuredBy int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeatureRecipe", recipeID, displayOrder, expiresAt, featuredBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FeatureRecipe indicates an expected call of FeatureRecipe.
+func (mr *MockFeaturedRecipeStoreMockRecorder) FeatureRecipe(recipeID, displayOrder, expiresAt, featuredBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeatureRecipe", reflect.TypeOf((*MockFeaturedRecipeStore)(nil).FeatureRecipe), recipeID, displayOrder, expiresAt, featuredBy)
+}
+
+// GetFeaturedRecipes mocks base method.
+func (m *MockFeaturedRecipeStore) GetFeaturedRecipes(includeInactive bool) ([]*store.FeaturedRecipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeaturedRecipes", includeInactive)
+	ret0, _ := ret[0].([]*store.FeaturedRecipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeaturedRecipes indicates an expected call of GetFeaturedRecipes.
+func (mr *MockFeaturedRecipeStoreMockRecorder) GetFeaturedRecipes(includeInactive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeaturedRecipes", reflect.TypeOf((*MockFeaturedRecipeStore)(nil).GetFeaturedRecipes), includeInactive)
+}
+
+// UnfeatureRecipe mocks base method.
+func (m *MockFeaturedRecipeStore) UnfeatureRecipe(recipeID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfeatureRecipe", recipeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfeatureRecipe indicates an expected call of UnfeatureRecipe.
+func (mr *MockFeaturedRecipeStoreMockRecorder) UnfeatureRecipe(recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfeatureRecipe", reflect.TypeOf((*MockFeaturedRecipeStore)(nil).UnfeatureRecipe), recipeID)
+}
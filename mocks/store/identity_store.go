@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: identity_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=identity_store.go -destination=../mocks/store/identity_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	sql "database/sql"
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdentityStore is a mock of IdentityStore interface.
+type MockIdentityStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockIdentityStoreMockRecorder is the mock recorder for MockIdentityStore.
+type MockIdentityStoreMockRecorder struct {
+	mock *MockIdentityStore
+}
+
+// NewMockIdentityStore creates a new mock instance.
+func NewMockIdentityStore(ctrl *gomock.Controller) *MockIdentityStore {
+	mock := &MockIdentityStore{ctrl: ctrl}
+	mock.recorder = &MockIdentityStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityStore) EXPECT() *MockIdentityStoreMockRecorder {
+	return m.recorder
+}
+
+// ConsumeOAuthState mocks base method.
+func (m *MockIdentityStore) ConsumeOAuthState(state, provider string) (*store.OAuthState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeOAuthState", state, provider)
+	ret0, _ := ret[0].(*store.OAuthState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeOAuthState indicates an expected call of ConsumeOAuthState.
+func (mr *MockIdentityStoreMockRecorder) ConsumeOAuthState(state, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOAuthState", reflect.TypeOf((*MockIdentityStore)(nil).ConsumeOAuthState), state, provider)
+}
+
+// ConsumePendingLink mocks base method.
+func (m *MockIdentityStore) ConsumePendingLink(token string) (*store.PendingIdentityLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumePendingLink", token)
+	ret0, _ := ret[0].(*store.PendingIdentityLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumePendingLink indicates an expected call of ConsumePendingLink.
+func (mr *MockIdentityStoreMockRecorder) ConsumePendingLink(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumePendingLink", reflect.TypeOf((*MockIdentityStore)(nil).ConsumePendingLink), token)
+}
+
+// CreateIdentity mocks base method.
+func (m *MockIdentityStore) CreateIdentity(identity *store.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdentity", identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIdentity indicates an expected call of CreateIdentity.
+func (mr *MockIdentityStoreMockRecorder) CreateIdentity(identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdentity", reflect.TypeOf((*MockIdentityStore)(nil).CreateIdentity), identity)
+}
+
+// CreateOAuthState mocks base method.
+func (m *MockIdentityStore) CreateOAuthState(state *store.OAuthState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthState", state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOAuthState indicates an expected call of CreateOAuthState.
+func (mr *MockIdentityStoreMockRecorder) CreateOAuthState(state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthState", reflect.TypeOf((*MockIdentityStore)(nil).CreateOAuthState), state)
+}
+
+// CreateOAuthUserWithTransaction mocks base method.
+func (m *MockIdentityStore) CreateOAuthUserWithTransaction(user *store.User, identity *store.UserIdentity, tx *sql.Tx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthUserWithTransaction", user, identity, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOAuthUserWithTransaction indicates an expected call of CreateOAuthUserWithTransaction.
+func (mr *MockIdentityStoreMockRecorder) CreateOAuthUserWithTransaction(user, identity, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthUserWithTransaction", reflect.TypeOf((*MockIdentityStore)(nil).CreateOAuthUserWithTransaction), user, identity, tx)
+}
+
+// CreatePendingLink mocks base method.
+func (m *MockIdentityStore) CreatePendingLink(link *store.PendingIdentityLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePendingLink", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePendingLink indicates an expected call of CreatePendingLink.
+func (mr *MockIdentityStoreMockRecorder) CreatePendingLink(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePendingLink", reflect.TypeOf((*MockIdentityStore)(nil).CreatePendingLink), link)
+}
+
+// DeleteIdentity mocks base method.
+func (m *MockIdentityStore) DeleteIdentity(userID int64, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdentity", userID, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdentity indicates an expected call of DeleteIdentity.
+func (mr *MockIdentityStoreMockRecorder) DeleteIdentity(userID, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdentity", reflect.TypeOf((*MockIdentityStore)(nil).DeleteIdentity), userID, provider)
+}
+
+// GetIdentitiesForUser mocks base method.
+func (m *MockIdentityStore) GetIdentitiesForUser(userID int64) ([]*store.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentitiesForUser", userID)
+	ret0, _ := ret[0].([]*store.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentitiesForUser indicates an expected call of GetIdentitiesForUser.
+func (mr *MockIdentityStoreMockRecorder) GetIdentitiesForUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentitiesForUser", reflect.TypeOf((*MockIdentityStore)(nil).GetIdentitiesForUser), userID)
+}
+
+// GetIdentity mocks base method.
+func (m *MockIdentityStore) GetIdentity(provider, subject string) (*store.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity", provider, subject)
+	ret0, _ := ret[0].(*store.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockIdentityStoreMockRecorder) GetIdentity(provider, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockIdentityStore)(nil).GetIdentity), provider, subject)
+}
+
+// TouchIdentity mocks base method.
+func (m *MockIdentityStore) TouchIdentity(identityID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchIdentity", identityID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchIdentity indicates an expected call of TouchIdentity.
+func (mr *MockIdentityStoreMockRecorder) TouchIdentity(identityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchIdentity", reflect.TypeOf((*MockIdentityStore)(nil).TouchIdentity), identityID)
+}
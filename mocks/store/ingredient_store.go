@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ingredient_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ingredient_store.go -destination=../mocks/store/ingredient_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIngredientStore is a mock of IngredientStore interface.
+type MockIngredientStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngredientStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockIngredientStoreMockRecorder is the mock recorder for MockIngredientStore.
+type MockIngredientStoreMockRecorder struct {
+	mock *MockIngredientStore
+}
+
+// NewMockIngredientStore creates a new mock instance.
+func NewMockIngredientStore(ctrl *gomock.Controller) *MockIngredientStore {
+	mock := &MockIngredientStore{ctrl: ctrl}
+	mock.recorder = &MockIngredientStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngredientStore) EXPECT() *MockIngredientStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteDensityOverride mocks base method.
+func (m *MockIngredientStore) DeleteDensityOverride(ingredientID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDensityOverride", ingredientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDensityOverride indicates an expected call of DeleteDensityOverride.
+func (mr *MockIngredientStoreMockRecorder) DeleteDensityOverride(ingredientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDensityOverride", reflect.TypeOf((*MockIngredientStore)(nil).DeleteDensityOverride), ingredientID)
+}
+
+// GetDensityOverrides mocks base method.
+func (m *MockIngredientStore) GetDensityOverrides() ([]*store.IngredientDensity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDensityOverrides")
+	ret0, _ := ret[0].([]*store.IngredientDensity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDensityOverrides indicates an expected call of GetDensityOverrides.
+func (mr *MockIngredientStoreMockRecorder) GetDensityOverrides() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDensityOverrides", reflect.TypeOf((*MockIngredientStore)(nil).GetDensityOverrides))
+}
+
+// GetIngredientDensity mocks base method.
+func (m *MockIngredientStore) GetIngredientDensity(name string) (*store.IngredientDensity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngredientDensity", name)
+	ret0, _ := ret[0].(*store.IngredientDensity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngredientDensity indicates an expected call of GetIngredientDensity.
+func (mr *MockIngredientStoreMockRecorder) GetIngredientDensity(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngredientDensity", reflect.TypeOf((*MockIngredientStore)(nil).GetIngredientDensity), name)
+}
+
+// GetRecipeCost mocks base method.
+func (m *MockIngredientStore) GetRecipeCost(recipeID int64) (*store.RecipeCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeCost", recipeID)
+	ret0, _ := ret[0].(*store.RecipeCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeCost indicates an expected call of GetRecipeCost.
+func (mr *MockIngredientStoreMockRecorder) GetRecipeCost(recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeCost", reflect.TypeOf((*MockIngredientStore)(nil).GetRecipeCost), recipeID)
+}
+
+// SetDensityOverride mocks base method.
+func (m *MockIngredientStore) SetDensityOverride(ingredientID int64, density float64) (*store.IngredientDensity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDensityOverride", ingredientID, density)
+	ret0, _ := ret[0].(*store.IngredientDensity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDensityOverride indicates an expected call of SetDensityOverride.
+func (mr *MockIngredientStoreMockRecorder) SetDensityOverride(ingredientID, density any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDensityOverride", reflect.TypeOf((*MockIngredientStore)(nil).SetDensityOverride), ingredientID, density)
+}
+
+// SetIngredientPrice mocks base method.
+func (m *MockIngredientStore) SetIngredientPrice(ingredientID int64, pricePerUnit *float64, priceUnit *string, source string) (*store.IngredientPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIngredientPrice", ingredientID, pricePerUnit, priceUnit, source)
+	ret0, _ := ret[0].(*store.IngredientPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetIngredientPrice indicates an expected call of SetIngredientPrice.
+func (mr *MockIngredientStoreMockRecorder) SetIngredientPrice(ingredientID, pricePerUnit, priceUnit, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIngredientPrice", reflect.TypeOf((*MockIngredientStore)(nil).SetIngredientPrice), ingredientID, pricePerUnit, priceUnit, source)
+}
+
+// SuggestIngredients mocks base method.
+func (m *MockIngredientStore) SuggestIngredients(query string, limit int) ([]*store.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestIngredients", query, limit)
+	ret0, _ := ret[0].([]*store.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestIngredients indicates an expected call of SuggestIngredients.
+func (mr *MockIngredientStoreMockRecorder) SuggestIngredients(query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestIngredients", reflect.TypeOf((*MockIngredientStore)(nil).SuggestIngredients), query, limit)
+}
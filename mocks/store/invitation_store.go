@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invitation_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=invitation_store.go -destination=../mocks/store/invitation_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInvitationStore is a mock of InvitationStore interface.
+type MockInvitationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockInvitationStoreMockRecorder is the mock recorder for MockInvitationStore.
+type MockInvitationStoreMockRecorder struct {
+	mock *MockInvitationStore
+}
+
+// NewMockInvitationStore creates a new mock instance.
+func NewMockInvitationStore(ctrl *gomock.Controller) *MockInvitationStore {
+	mock := &MockInvitationStore{ctrl: ctrl}
+	mock.recorder = &MockInvitationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitationStore) EXPECT() *MockInvitationStoreMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitationWithTransaction mocks base method.
+func (m *MockInvitationStore) AcceptInvitationWithTransaction(invitationID int64, userID string, tx *sql.Tx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitationWithTransaction", invitationID, userID, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitationWithTransaction indicates an expected call of AcceptInvitationWithTransaction.
+func (mr *MockInvitationStoreMockRecorder) AcceptInvitationWithTransaction(invitationID, userID, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitationWithTransaction", reflect.TypeOf((*MockInvitationStore)(nil).AcceptInvitationWithTransaction), invitationID, userID, tx)
+}
+
+// CountInvitationsSince mocks base method.
+func (m *MockInvitationStore) CountInvitationsSince(inviterID int64, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountInvitationsSince", inviterID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountInvitationsSince indicates an expected call of CountInvitationsSince.
+func (mr *MockInvitationStoreMockRecorder) CountInvitationsSince(inviterID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountInvitationsSince", reflect.TypeOf((*MockInvitationStore)(nil).CountInvitationsSince), inviterID, since)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockInvitationStore) CreateInvitation(inviterID int64, email string, expiryDuration time.Duration) (*store.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", inviterID, email, expiryDuration)
+	ret0, _ := ret[0].(*store.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockInvitationStoreMockRecorder) CreateInvitation(inviterID, email, expiryDuration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockInvitationStore)(nil).CreateInvitation), inviterID, email, expiryDuration)
+}
+
+// DeleteInvitation mocks base method.
+func (m *MockInvitationStore) DeleteInvitation(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvitation", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvitation indicates an expected call of DeleteInvitation.
+func (mr *MockInvitationStoreMockRecorder) DeleteInvitation(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvitation", reflect.TypeOf((*MockInvitationStore)(nil).DeleteInvitation), id)
+}
+
+// GetInvitationByToken mocks base method.
+func (m *MockInvitationStore) GetInvitationByToken(token string) (*store.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationByToken", token)
+	ret0, _ := ret[0].(*store.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationByToken indicates an expected call of GetInvitationByToken.
+func (mr *MockInvitationStoreMockRecorder) GetInvitationByToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationByToken", reflect.TypeOf((*MockInvitationStore)(nil).GetInvitationByToken), token)
+}
+
+// GetInvitationsByInviter mocks base method.
+func (m *MockInvitationStore) GetInvitationsByInviter(inviterID int64) ([]*store.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationsByInviter", inviterID)
+	ret0, _ := ret[0].([]*store.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationsByInviter indicates an expected call of GetInvitationsByInviter.
+func (mr *MockInvitationStoreMockRecorder) GetInvitationsByInviter(inviterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationsByInviter", reflect.TypeOf((*MockInvitationStore)(nil).GetInvitationsByInviter), inviterID)
+}
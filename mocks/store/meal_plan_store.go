@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: meal_plan_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=meal_plan_store.go -destination=../mocks/store/meal_plan_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMealPlanStore is a mock of MealPlanStore interface.
+type MockMealPlanStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMealPlanStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockMealPlanStoreMockRecorder is the mock recorder for MockMealPlanStore.
+type MockMealPlanStoreMockRecorder struct {
+	mock *MockMealPlanStore
+}
+
+// NewMockMealPlanStore creates a new mock instance.
+func NewMockMealPlanStore(ctrl *gomock.Controller) *MockMealPlanStore {
+	mock := &MockMealPlanStore{ctrl: ctrl}
+	mock.recorder = &MockMealPlanStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMealPlanStore) EXPECT() *MockMealPlanStoreMockRecorder {
+	return m.recorder
+}
+
+// AddMealPlanEntry mocks base method.
+func (m *MockMealPlanStore) AddMealPlanEntry(entry *store.MealPlanEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMealPlanEntry", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMealPlanEntry indicates an expected call of AddMealPlanEntry.
+func (mr *MockMealPlanStoreMockRecorder) AddMealPlanEntry(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMealPlanEntry", reflect.TypeOf((*MockMealPlanStore)(nil).AddMealPlanEntry), entry)
+}
+
+// CreateICalToken mocks base method.
+func (m *MockMealPlanStore) CreateICalToken(planID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateICalToken", planID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateICalToken indicates an expected call of CreateICalToken.
+func (mr *MockMealPlanStoreMockRecorder) CreateICalToken(planID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateICalToken", reflect.TypeOf((*MockMealPlanStore)(nil).CreateICalToken), planID)
+}
+
+// CreateMealPlan mocks base method.
+func (m *MockMealPlanStore) CreateMealPlan(plan *store.MealPlan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMealPlan", plan)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMealPlan indicates an expected call of CreateMealPlan.
+func (mr *MockMealPlanStoreMockRecorder) CreateMealPlan(plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMealPlan", reflect.TypeOf((*MockMealPlanStore)(nil).CreateMealPlan), plan)
+}
+
+// DeleteMealPlan mocks base method.
+func (m *MockMealPlanStore) DeleteMealPlan(planID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMealPlan", planID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMealPlan indicates an expected call of DeleteMealPlan.
+func (mr *MockMealPlanStoreMockRecorder) DeleteMealPlan(planID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMealPlan", reflect.TypeOf((*MockMealPlanStore)(nil).DeleteMealPlan), planID)
+}
+
+// DeleteMealPlanEntry mocks base method.
+func (m *MockMealPlanStore) DeleteMealPlanEntry(planID, entryID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMealPlanEntry", planID, entryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMealPlanEntry indicates an expected call of DeleteMealPlanEntry.
+func (mr *MockMealPlanStoreMockRecorder) DeleteMealPlanEntry(planID, entryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMealPlanEntry", reflect.TypeOf((*MockMealPlanStore)(nil).DeleteMealPlanEntry), planID, entryID)
+}
+
+// GetMealPlan mocks base method.
+func (m *MockMealPlanStore) GetMealPlan(planID int64) (*store.MealPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMealPlan", planID)
+	ret0, _ := ret[0].(*store.MealPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMealPlan indicates an expected call of GetMealPlan.
+func (mr *MockMealPlanStoreMockRecorder) GetMealPlan(planID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMealPlan", reflect.TypeOf((*MockMealPlanStore)(nil).GetMealPlan), planID)
+}
+
+// GetMealPlanByICalToken mocks base method.
+func (m *MockMealPlanStore) GetMealPlanByICalToken(token string) (*store.MealPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMealPlanByICalToken", token)
+	ret0, _ := ret[0].(*store.MealPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMealPlanByICalToken indicates an expected call of GetMealPlanByICalToken.
+func (mr *MockMealPlanStoreMockRecorder) GetMealPlanByICalToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMealPlanByICalToken", reflect.TypeOf((*MockMealPlanStore)(nil).GetMealPlanByICalToken), token)
+}
+
+// GetMealPlanEntries mocks base method.
+func (m *MockMealPlanStore) GetMealPlanEntries(planID int64, from, to string) ([]*store.MealPlanEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMealPlanEntries", planID, from, to)
+	ret0, _ := ret[0].([]*store.MealPlanEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMealPlanEntries indicates an expected call of GetMealPlanEntries.
+func (mr *MockMealPlanStoreMockRecorder) GetMealPlanEntries(planID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMealPlanEntries", reflect.TypeOf((*MockMealPlanStore)(nil).GetMealPlanEntries), planID, from, to)
+}
+
+// GetMealPlansForUser mocks base method.
+func (m *MockMealPlanStore) GetMealPlansForUser(userID int64) ([]*store.MealPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMealPlansForUser", userID)
+	ret0, _ := ret[0].([]*store.MealPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMealPlansForUser indicates an expected call of GetMealPlansForUser.
+func (mr *MockMealPlanStoreMockRecorder) GetMealPlansForUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMealPlansForUser", reflect.TypeOf((*MockMealPlanStore)(nil).GetMealPlansForUser), userID)
+}
+
+// RevokeICalToken mocks base method.
+func (m *MockMealPlanStore) RevokeICalToken(planID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeICalToken", planID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeICalToken indicates an expected call of RevokeICalToken.
+func (mr *MockMealPlanStoreMockRecorder) RevokeICalToken(planID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeICalToken", reflect.TypeOf((*MockMealPlanStore)(nil).RevokeICalToken), planID)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mention_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=mention_store.go -destination=../mocks/store/mention_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMentionStore is a mock of MentionStore interface.
+type MockMentionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockMentionStoreMockRecorder is the mock recorder for MockMentionStore.
+type MockMentionStoreMockRecorder struct {
+	mock *MockMentionStore
+}
+
+// NewMockMentionStore creates a new mock instance.
+func NewMockMentionStore(ctrl *gomock.Controller) *MockMentionStore {
+	mock := &MockMentionStore{ctrl: ctrl}
+	mock.recorder = &MockMentionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionStore) EXPECT() *MockMentionStoreMockRecorder {
+	return m.recorder
+}
+
+// GetUsersByUsernames mocks base method.
+func (m *MockMentionStore) GetUsersByUsernames(usernames []string) ([]*store.MentionedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByUsernames", usernames)
+	ret0, _ := ret[0].([]*store.MentionedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByUsernames indicates an expected call of GetUsersByUsernames.
+func (mr *MockMentionStoreMockRecorder) GetUsersByUsernames(usernames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByUsernames", reflect.TypeOf((*MockMentionStore)(nil).GetUsersByUsernames), usernames)
+}
+
+// SaveReviewMentions mocks base method.
+func (m *MockMentionStore) SaveReviewMentions(reviewID int64, userIDs []int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReviewMentions", reviewID, userIDs)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveReviewMentions indicates an expected call of SaveReviewMentions.
+func (mr *MockMentionStoreMockRecorder) SaveReviewMentions(reviewID, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReviewMentions", reflect.TypeOf((*MockMentionStore)(nil).SaveReviewMentions), reviewID, userIDs)
+}
@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: message_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=message_store.go -destination=../mocks/store/message_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMessageStore is a mock of MessageStore interface.
+type MockMessageStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockMessageStoreMockRecorder is the mock recorder for MockMessageStore.
+type MockMessageStoreMockRecorder struct {
+	mock *MockMessageStore
+}
+
+// NewMockMessageStore creates a new mock instance.
+func NewMockMessageStore(ctrl *gomock.Controller) *MockMessageStore {
+	mock := &MockMessageStore{ctrl: ctrl}
+	mock.recorder = &MockMessageStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageStore) EXPECT() *MockMessageStoreMockRecorder {
+	return m.recorder
+}
+
+// CountUnreadMessages mocks base method.
+func (m *MockMessageStore) CountUnreadMessages(userID int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadMessages", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadMessages indicates an expected call of CountUnreadMessages.
+func (mr *MockMessageStoreMockRecorder) CountUnreadMessages(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadMessages", reflect.TypeOf((*MockMessageStore)(nil).CountUnreadMessages), userID)
+}
+
+// CreateMessage mocks base method.
+func (m *MockMessageStore) CreateMessage(conversationID, senderID int64, body string) (*store.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessage", conversationID, senderID, body)
+	ret0, _ := ret[0].(*store.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockMessageStoreMockRecorder) CreateMessage(conversationID, senderID, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockMessageStore)(nil).CreateMessage), conversationID, senderID, body)
+}
+
+// GetConversation mocks base method.
+func (m *MockMessageStore) GetConversation(conversationID, userID int64) (*store.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversation", conversationID, userID)
+	ret0, _ := ret[0].(*store.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversation indicates an expected call of GetConversation.
+func (mr *MockMessageStoreMockRecorder) GetConversation(conversationID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversation", reflect.TypeOf((*MockMessageStore)(nil).GetConversation), conversationID, userID)
+}
+
+// GetConversations mocks base method.
+func (m *MockMessageStore) GetConversations(userID int64, page, limit int) ([]*store.Conversation, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversations", userID, page, limit)
+	ret0, _ := ret[0].([]*store.Conversation)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetConversations indicates an expected call of GetConversations.
+func (mr *MockMessageStoreMockRecorder) GetConversations(userID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversations", reflect.TypeOf((*MockMessageStore)(nil).GetConversations), userID, page, limit)
+}
+
+// GetMessages mocks base method.
+func (m *MockMessageStore) GetMessages(conversationID int64, page, limit int) ([]*store.Message, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessages", conversationID, page, limit)
+	ret0, _ := ret[0].([]*store.Message)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMessages indicates an expected call of GetMessages.
+func (mr *MockMessageStoreMockRecorder) GetMessages(conversationID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockMessageStore)(nil).GetMessages), conversationID, page, limit)
+}
+
+// GetOrCreateConversation mocks base method.
+func (m *MockMessageStore) GetOrCreateConversation(userID, otherID int64) (int64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCreateConversation", userID, otherID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOrCreateConversation indicates an expected call of GetOrCreateConversation.
+func (mr *MockMessageStoreMockRecorder) GetOrCreateConversation(userID, otherID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateConversation", reflect.TypeOf((*MockMessageStore)(nil).GetOrCreateConversation), userID, otherID)
+}
+
+// MarkConversationRead mocks base method.
+func (m *MockMessageStore) MarkConversationRead(conversationID, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkConversationRead", conversationID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkConversationRead indicates an expected call of MarkConversationRead.
+func (mr *MockMessageStoreMockRecorder) MarkConversationRead(conversationID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkConversationRead", reflect.TypeOf((*MockMessageStore)(nil).MarkConversationRead), conversationID, userID)
+}
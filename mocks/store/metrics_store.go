@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: metrics_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=metrics_store.go -destination=../mocks/store/metrics_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMetricsStore is a mock of MetricsStore interface.
+type MockMetricsStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockMetricsStoreMockRecorder is the mock recorder for MockMetricsStore.
+type MockMetricsStoreMockRecorder struct {
+	mock *MockMetricsStore
+}
+
+// NewMockMetricsStore creates a new mock instance.
+func NewMockMetricsStore(ctrl *gomock.Controller) *MockMetricsStore {
+	mock := &MockMetricsStore{ctrl: ctrl}
+	mock.recorder = &MockMetricsStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsStore) EXPECT() *MockMetricsStoreMockRecorder {
+	return m.recorder
+}
+
+// GetDailyMetrics mocks base method.
+func (m *MockMetricsStore) GetDailyMetrics(from, to time.Time) ([]*store.DailyMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyMetrics", from, to)
+	ret0, _ := ret[0].([]*store.DailyMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyMetrics indicates an expected call of GetDailyMetrics.
+func (mr *MockMetricsStoreMockRecorder) GetDailyMetrics(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyMetrics", reflect.TypeOf((*MockMetricsStore)(nil).GetDailyMetrics), from, to)
+}
+
+// GetLastRolledUpDay mocks base method.
+func (m *MockMetricsStore) GetLastRolledUpDay() (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastRolledUpDay")
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastRolledUpDay indicates an expected call of GetLastRolledUpDay.
+func (mr *MockMetricsStoreMockRecorder) GetLastRolledUpDay() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastRolledUpDay", reflect.TypeOf((*MockMetricsStore)(nil).GetLastRolledUpDay))
+}
+
+// RecordEmailSend mocks base method.
+func (m *MockMetricsStore) RecordEmailSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordEmailSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordEmailSend indicates an expected call of RecordEmailSend.
+func (mr *MockMetricsStoreMockRecorder) RecordEmailSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordEmailSend", reflect.TypeOf((*MockMetricsStore)(nil).RecordEmailSend))
+}
+
+// RollupDailyMetrics mocks base method.
+func (m *MockMetricsStore) RollupDailyMetrics(from, to time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollupDailyMetrics", from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollupDailyMetrics indicates an expected call of RollupDailyMetrics.
+func (mr *MockMetricsStoreMockRecorder) RollupDailyMetrics(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollupDailyMetrics", reflect.TypeOf((*MockMetricsStore)(nil).RollupDailyMetrics), from, to)
+}
@@ -0,0 +1,216 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=notification_store.go -destination=../mocks/store/notification_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationStore is a mock of NotificationStore interface.
+type MockNotificationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationStoreMockRecorder is the mock recorder for MockNotificationStore.
+type MockNotificationStoreMockRecorder struct {
+	mock *MockNotificationStore
+}
+
+// NewMockNotificationStore creates a new mock instance.
+func NewMockNotificationStore(ctrl *gomock.Controller) *MockNotificationStore {
+	mock := &MockNotificationStore{ctrl: ctrl}
+	mock.recorder = &MockNotificationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationStore) EXPECT() *MockNotificationStoreMockRecorder {
+	return m.recorder
+}
+
+// AddNotification mocks base method.
+func (m *MockNotificationStore) AddNotification(notification *store.Notification, batch store.NotificationBatch, message func(int) string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNotification", notification, batch, message)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddNotification indicates an expected call of AddNotification.
+func (mr *MockNotificationStoreMockRecorder) AddNotification(notification, batch, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNotification", reflect.TypeOf((*MockNotificationStore)(nil).AddNotification), notification, batch, message)
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *MockNotificationStore) CountUnreadNotifications(userID int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockNotificationStoreMockRecorder) CountUnreadNotifications(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockNotificationStore)(nil).CountUnreadNotifications), userID)
+}
+
+// CreateNotification mocks base method.
+func (m *MockNotificationStore) CreateNotification(notification *store.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockNotificationStoreMockRecorder) CreateNotification(notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockNotificationStore)(nil).CreateNotification), notification)
+}
+
+// CreateNotifications mocks base method.
+func (m *MockNotificationStore) CreateNotifications(notifications []*store.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotifications", notifications)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotifications indicates an expected call of CreateNotifications.
+func (mr *MockNotificationStoreMockRecorder) CreateNotifications(notifications any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotifications", reflect.TypeOf((*MockNotificationStore)(nil).CreateNotifications), notifications)
+}
+
+// GetDueNotificationEmails mocks base method.
+func (m *MockNotificationStore) GetDueNotificationEmails(limit, quietStartHour, quietEndHour int) ([]*store.NotificationEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueNotificationEmails", limit, quietStartHour, quietEndHour)
+	ret0, _ := ret[0].([]*store.NotificationEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueNotificationEmails indicates an expected call of GetDueNotificationEmails.
+func (mr *MockNotificationStoreMockRecorder) GetDueNotificationEmails(limit, quietStartHour, quietEndHour any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueNotificationEmails", reflect.TypeOf((*MockNotificationStore)(nil).GetDueNotificationEmails), limit, quietStartHour, quietEndHour)
+}
+
+// GetNotificationPreference mocks base method.
+func (m *MockNotificationStore) GetNotificationPreference(userID int64, notificationType string) (store.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreference", userID, notificationType)
+	ret0, _ := ret[0].(store.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreference indicates an expected call of GetNotificationPreference.
+func (mr *MockNotificationStoreMockRecorder) GetNotificationPreference(userID, notificationType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreference", reflect.TypeOf((*MockNotificationStore)(nil).GetNotificationPreference), userID, notificationType)
+}
+
+// GetNotificationPreferences mocks base method.
+func (m *MockNotificationStore) GetNotificationPreferences(userID int64) ([]store.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreferences", userID)
+	ret0, _ := ret[0].([]store.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreferences indicates an expected call of GetNotificationPreferences.
+func (mr *MockNotificationStoreMockRecorder) GetNotificationPreferences(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreferences", reflect.TypeOf((*MockNotificationStore)(nil).GetNotificationPreferences), userID)
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotificationStore) GetNotifications(userID int64, unreadOnly bool, limit int) ([]*store.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", userID, unreadOnly, limit)
+	ret0, _ := ret[0].([]*store.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationStoreMockRecorder) GetNotifications(userID, unreadOnly, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotificationStore)(nil).GetNotifications), userID, unreadOnly, limit)
+}
+
+// MarkAllNotificationsRead mocks base method.
+func (m *MockNotificationStore) MarkAllNotificationsRead(userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *MockNotificationStoreMockRecorder) MarkAllNotificationsRead(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*MockNotificationStore)(nil).MarkAllNotificationsRead), userID)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockNotificationStore) MarkNotificationRead(id, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockNotificationStoreMockRecorder) MarkNotificationRead(id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockNotificationStore)(nil).MarkNotificationRead), id, userID)
+}
+
+// MarkNotificationsEmailed mocks base method.
+func (m *MockNotificationStore) MarkNotificationsEmailed(ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationsEmailed", ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationsEmailed indicates an expected call of MarkNotificationsEmailed.
+func (mr *MockNotificationStoreMockRecorder) MarkNotificationsEmailed(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsEmailed", reflect.TypeOf((*MockNotificationStore)(nil).MarkNotificationsEmailed), ids)
+}
+
+// SaveNotificationPreferences mocks base method.
+func (m *MockNotificationStore) SaveNotificationPreferences(userID int64, prefs []store.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNotificationPreferences", userID, prefs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveNotificationPreferences indicates an expected call of SaveNotificationPreferences.
+func (mr *MockNotificationStoreMockRecorder) SaveNotificationPreferences(userID, prefs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotificationPreferences", reflect.TypeOf((*MockNotificationStore)(nil).SaveNotificationPreferences), userID, prefs)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pantry_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pantry_store.go -destination=../mocks/store/pantry_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPantryStore is a mock of PantryStore interface.
+type MockPantryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPantryStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockPantryStoreMockRecorder is the mock recorder for MockPantryStore.
+type MockPantryStoreMockRecorder struct {
+	mock *MockPantryStore
+}
+
+// NewMockPantryStore creates a new mock instance.
+func NewMockPantryStore(ctrl *gomock.Controller) *MockPantryStore {
+	mock := &MockPantryStore{ctrl: ctrl}
+	mock.recorder = &MockPantryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPantryStore) EXPECT() *MockPantryStoreMockRecorder {
+	return m.recorder
+}
+
+// AddPantryItem mocks base method.
+func (m *MockPantryStore) AddPantryItem(userID int64, name string) (*store.PantryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPantryItem", userID, name)
+	ret0, _ := ret[0].(*store.PantryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPantryItem indicates an expected call of AddPantryItem.
+func (mr *MockPantryStoreMockRecorder) AddPantryItem(userID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPantryItem", reflect.TypeOf((*MockPantryStore)(nil).AddPantryItem), userID, name)
+}
+
+// GetCookableRecipes mocks base method.
+func (m *MockPantryStore) GetCookableRecipes(userID int64, limit int) ([]*store.CookableRecipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCookableRecipes", userID, limit)
+	ret0, _ := ret[0].([]*store.CookableRecipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCookableRecipes indicates an expected call of GetCookableRecipes.
+func (mr *MockPantryStoreMockRecorder) GetCookableRecipes(userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCookableRecipes", reflect.TypeOf((*MockPantryStore)(nil).GetCookableRecipes), userID, limit)
+}
+
+// GetPantryItems mocks base method.
+func (m *MockPantryStore) GetPantryItems(userID int64) ([]*store.PantryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPantryItems", userID)
+	ret0, _ := ret[0].([]*store.PantryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPantryItems indicates an expected call of GetPantryItems.
+func (mr *MockPantryStoreMockRecorder) GetPantryItems(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPantryItems", reflect.TypeOf((*MockPantryStore)(nil).GetPantryItems), userID)
+}
+
+// RemovePantryItem mocks base method.
+func (m *MockPantryStore) RemovePantryItem(userID, ingredientID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePantryItem", userID, ingredientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePantryItem indicates an expected call of RemovePantryItem.
+func (mr *MockPantryStoreMockRecorder) RemovePantryItem(userID, ingredientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePantryItem", reflect.TypeOf((*MockPantryStore)(nil).RemovePantryItem), userID, ingredientID)
+}
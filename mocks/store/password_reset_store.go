@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: password_reset_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=password_reset_store.go -destination=../mocks/store/password_reset_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPasswordResetStore is a mock of PasswordResetStore interface.
+type MockPasswordResetStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordResetStoreMockRecorder is the mock recorder for MockPasswordResetStore.
+type MockPasswordResetStoreMockRecorder struct {
+	mock *MockPasswordResetStore
+}
+
+// NewMockPasswordResetStore creates a new mock instance.
+func NewMockPasswordResetStore(ctrl *gomock.Controller) *MockPasswordResetStore {
+	mock := &MockPasswordResetStore{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetStore) EXPECT() *MockPasswordResetStoreMockRecorder {
+	return m.recorder
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockPasswordResetStore) CreatePasswordResetToken(userID string, expiryDuration time.Duration) (*store.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", userID, expiryDuration)
+	ret0, _ := ret[0].(*store.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockPasswordResetStoreMockRecorder) CreatePasswordResetToken(userID, expiryDuration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockPasswordResetStore)(nil).CreatePasswordResetToken), userID, expiryDuration)
+}
+
+// DeleteExpiredTokens mocks base method.
+func (m *MockPasswordResetStore) DeleteExpiredTokens() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredTokens")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredTokens indicates an expected call of DeleteExpiredTokens.
+func (mr *MockPasswordResetStoreMockRecorder) DeleteExpiredTokens() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredTokens", reflect.TypeOf((*MockPasswordResetStore)(nil).DeleteExpiredTokens))
+}
+
+// DeleteUserTokens mocks base method.
+func (m *MockPasswordResetStore) DeleteUserTokens(userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTokens", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserTokens indicates an expected call of DeleteUserTokens.
+func (mr *MockPasswordResetStoreMockRecorder) DeleteUserTokens(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTokens", reflect.TypeOf((*MockPasswordResetStore)(nil).DeleteUserTokens), userID)
+}
+
+// GetPasswordResetTokenByToken mocks base method.
+func (m *MockPasswordResetStore) GetPasswordResetTokenByToken(token string) (*store.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetTokenByToken", token)
+	ret0, _ := ret[0].(*store.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetTokenByToken indicates an expected call of GetPasswordResetTokenByToken.
+func (mr *MockPasswordResetStoreMockRecorder) GetPasswordResetTokenByToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetTokenByToken", reflect.TypeOf((*MockPasswordResetStore)(nil).GetPasswordResetTokenByToken), token)
+}
+
+// MarkTokenAsUsed mocks base method.
+func (m *MockPasswordResetStore) MarkTokenAsUsed(tokenID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkTokenAsUsed", tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkTokenAsUsed indicates an expected call of MarkTokenAsUsed.
+func (mr *MockPasswordResetStoreMockRecorder) MarkTokenAsUsed(tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkTokenAsUsed", reflect.TypeOf((*MockPasswordResetStore)(nil).MarkTokenAsUsed), tokenID)
+}
+
+// ResetPasswordTransaction mocks base method.
+func (m *MockPasswordResetStore) ResetPasswordTransaction(tokenID int64, userID, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPasswordTransaction", tokenID, userID, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPasswordTransaction indicates an expected call of ResetPasswordTransaction.
+func (mr *MockPasswordResetStoreMockRecorder) ResetPasswordTransaction(tokenID, userID, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordTransaction", reflect.TypeOf((*MockPasswordResetStore)(nil).ResetPasswordTransaction), tokenID, userID, newPassword)
+}
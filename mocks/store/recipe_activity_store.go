@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recipe_activity_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=recipe_activity_store.go -destination=../mocks/store/recipe_activity_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecipeActivityStore is a mock of RecipeActivityStore interface.
+type MockRecipeActivityStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeActivityStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipeActivityStoreMockRecorder is the mock recorder for MockRecipeActivityStore.
+type MockRecipeActivityStoreMockRecorder struct {
+	mock *MockRecipeActivityStore
+}
+
+// NewMockRecipeActivityStore creates a new mock instance.
+func NewMockRecipeActivityStore(ctrl *gomock.Controller) *MockRecipeActivityStore {
+	mock := &MockRecipeActivityStore{ctrl: ctrl}
+	mock.recorder = &MockRecipeActivityStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeActivityStore) EXPECT() *MockRecipeActivityStoreMockRecorder {
+	return m.recorder
+}
+
+// AddRecipeActivity mocks base method.
+func (m *MockRecipeActivityStore) AddRecipeActivity(activity *store.RecipeActivity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecipeActivity", activity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecipeActivity indicates an expected call of AddRecipeActivity.
+func (mr *MockRecipeActivityStoreMockRecorder) AddRecipeActivity(activity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecipeActivity", reflect.TypeOf((*MockRecipeActivityStore)(nil).AddRecipeActivity), activity)
+}
+
+// GetRecipeActivity mocks base method.
+func (m *MockRecipeActivityStore) GetRecipeActivity(recipeID int64, page, limit int) ([]*store.RecipeActivity, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeActivity", recipeID, page, limit)
+	ret0, _ := ret[0].([]*store.RecipeActivity)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRecipeActivity indicates an expected call of GetRecipeActivity.
+func (mr *MockRecipeActivityStoreMockRecorder) GetRecipeActivity(recipeID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeActivity", reflect.TypeOf((*MockRecipeActivityStore)(nil).GetRecipeActivity), recipeID, page, limit)
+}
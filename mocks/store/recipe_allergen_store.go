@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recipe_allergen_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=recipe_allergen_store.go -destination=../mocks/store/recipe_allergen_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecipeAllergenStore is a mock of RecipeAllergenStore interface.
+type MockRecipeAllergenStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeAllergenStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipeAllergenStoreMockRecorder is the mock recorder for MockRecipeAllergenStore.
+type MockRecipeAllergenStoreMockRecorder struct {
+	mock *MockRecipeAllergenStore
+}
+
+// NewMockRecipeAllergenStore creates a new mock instance.
+func NewMockRecipeAllergenStore(ctrl *gomock.Controller) *MockRecipeAllergenStore {
+	mock := &MockRecipeAllergenStore{ctrl: ctrl}
+	mock.recorder = &MockRecipeAllergenStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeAllergenStore) EXPECT() *MockRecipeAllergenStoreMockRecorder {
+	return m.recorder
+}
+
+// GetRecipeAllergens mocks base method.
+func (m *MockRecipeAllergenStore) GetRecipeAllergens(recipeID int64) (*store.RecipeAllergens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeAllergens", recipeID)
+	ret0, _ := ret[0].(*store.RecipeAllergens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeAllergens indicates an expected call of GetRecipeAllergens.
+func (mr *MockRecipeAllergenStoreMockRecorder) GetRecipeAllergens(recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeAllergens", reflect.TypeOf((*MockRecipeAllergenStore)(nil).GetRecipeAllergens), recipeID)
+}
+
+// SetRecipeAllergenOverrides mocks base method.
+func (m *MockRecipeAllergenStore) SetRecipeAllergenOverrides(recipeID int64, overrides map[string]bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecipeAllergenOverrides", recipeID, overrides)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecipeAllergenOverrides indicates an expected call of SetRecipeAllergenOverrides.
+func (mr *MockRecipeAllergenStoreMockRecorder) SetRecipeAllergenOverrides(recipeID, overrides any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecipeAllergenOverrides", reflect.TypeOf((*MockRecipeAllergenStore)(nil).SetRecipeAllergenOverrides), recipeID, overrides)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recipe_cook_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=recipe_cook_store.go -destination=../mocks/store/recipe_cook_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecipeCookStore is a mock of RecipeCookStore interface.
+type MockRecipeCookStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeCookStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipeCookStoreMockRecorder is the mock recorder for MockRecipeCookStore.
+type MockRecipeCookStoreMockRecorder struct {
+	mock *MockRecipeCookStore
+}
+
+// NewMockRecipeCookStore creates a new mock instance.
+func NewMockRecipeCookStore(ctrl *gomock.Controller) *MockRecipeCookStore {
+	mock := &MockRecipeCookStore{ctrl: ctrl}
+	mock.recorder = &MockRecipeCookStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeCookStore) EXPECT() *MockRecipeCookStoreMockRecorder {
+	return m.recorder
+}
+
+// CountRecipeCooks mocks base method.
+func (m *MockRecipeCookStore) CountRecipeCooks(recipeID int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecipeCooks", recipeID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecipeCooks indicates an expected call of CountRecipeCooks.
+func (mr *MockRecipeCookStoreMockRecorder) CountRecipeCooks(recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecipeCooks", reflect.TypeOf((*MockRecipeCookStore)(nil).CountRecipeCooks), recipeID)
+}
+
+// GetCookingStats mocks base method.
+func (m *MockRecipeCookStore) GetCookingStats(userID int64) (*store.CookingStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCookingStats", userID)
+	ret0, _ := ret[0].(*store.CookingStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCookingStats indicates an expected call of GetCookingStats.
+func (mr *MockRecipeCookStoreMockRecorder) GetCookingStats(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCookingStats", reflect.TypeOf((*MockRecipeCookStore)(nil).GetCookingStats), userID)
+}
+
+// GetUserCooks mocks base method.
+func (m *MockRecipeCookStore) GetUserCooks(userID int64, page, limit int) ([]*store.RecipeCook, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCooks", userID, page, limit)
+	ret0, _ := ret[0].([]*store.RecipeCook)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserCooks indicates an expected call of GetUserCooks.
+func (mr *MockRecipeCookStoreMockRecorder) GetUserCooks(userID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCooks", reflect.TypeOf((*MockRecipeCookStore)(nil).GetUserCooks), userID, page, limit)
+}
+
+// RecordRecipeCook mocks base method.
+func (m *MockRecipeCookStore) RecordRecipeCook(userID int64, cook *store.RecipeCook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRecipeCook", userID, cook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordRecipeCook indicates an expected call of RecordRecipeCook.
+func (mr *MockRecipeCookStoreMockRecorder) RecordRecipeCook(userID, cook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRecipeCook", reflect.TypeOf((*MockRecipeCookStore)(nil).RecordRecipeCook), userID, cook)
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recipe_deletion_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=recipe_deletion_store.go -destination=../mocks/store/recipe_deletion_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecipeDeletionStore is a mock of RecipeDeletionStore interface.
+type MockRecipeDeletionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeDeletionStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipeDeletionStoreMockRecorder is the mock recorder for MockRecipeDeletionStore.
+type MockRecipeDeletionStoreMockRecorder struct {
+	mock *MockRecipeDeletionStore
+}
+
+// NewMockRecipeDeletionStore creates a new mock instance.
+func NewMockRecipeDeletionStore(ctrl *gomock.Controller) *MockRecipeDeletionStore {
+	mock := &MockRecipeDeletionStore{ctrl: ctrl}
+	mock.recorder = &MockRecipeDeletionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeDeletionStore) EXPECT() *MockRecipeDeletionStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateDeleteConfirmation mocks base method.
+func (m *MockRecipeDeletionStore) CreateDeleteConfirmation(recipeID int64, ttl time.Duration) (string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeleteConfirmation", recipeID, ttl)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateDeleteConfirmation indicates an expected call of CreateDeleteConfirmation.
+func (mr *MockRecipeDeletionStoreMockRecorder) CreateDeleteConfirmation(recipeID, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeleteConfirmation", reflect.TypeOf((*MockRecipeDeletionStore)(nil).CreateDeleteConfirmation), recipeID, ttl)
+}
+
+// GetRecipeEngagement mocks base method.
+func (m *MockRecipeDeletionStore) GetRecipeEngagement(recipeID int64) (*store.RecipeEngagement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeEngagement", recipeID)
+	ret0, _ := ret[0].(*store.RecipeEngagement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeEngagement indicates an expected call of GetRecipeEngagement.
+func (mr *MockRecipeDeletionStoreMockRecorder) GetRecipeEngagement(recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeEngagement", reflect.TypeOf((*MockRecipeDeletionStore)(nil).GetRecipeEngagement), recipeID)
+}
+
+// GetRecipeFavoriterIDs mocks base method.
+func (m *MockRecipeDeletionStore) GetRecipeFavoriterIDs(recipeID int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeFavoriterIDs", recipeID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeFavoriterIDs indicates an expected call of GetRecipeFavoriterIDs.
+func (mr *MockRecipeDeletionStoreMockRecorder) GetRecipeFavoriterIDs(recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeFavoriterIDs", reflect.TypeOf((*MockRecipeDeletionStore)(nil).GetRecipeFavoriterIDs), recipeID)
+}
+
+// UseDeleteConfirmation mocks base method.
+func (m *MockRecipeDeletionStore) UseDeleteConfirmation(recipeID int64, token string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseDeleteConfirmation", recipeID, token)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseDeleteConfirmation indicates an expected call of UseDeleteConfirmation.
+func (mr *MockRecipeDeletionStoreMockRecorder) UseDeleteConfirmation(recipeID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseDeleteConfirmation", reflect.TypeOf((*MockRecipeDeletionStore)(nil).UseDeleteConfirmation), recipeID, token)
+}
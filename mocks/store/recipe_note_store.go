@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recipe_note_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=recipe_note_store.go -destination=../mocks/store/recipe_note_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecipeNoteStore is a mock of RecipeNoteStore interface.
+type MockRecipeNoteStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeNoteStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipeNoteStoreMockRecorder is the mock recorder for MockRecipeNoteStore.
+type MockRecipeNoteStoreMockRecorder struct {
+	mock *MockRecipeNoteStore
+}
+
+// NewMockRecipeNoteStore creates a new mock instance.
+func NewMockRecipeNoteStore(ctrl *gomock.Controller) *MockRecipeNoteStore {
+	mock := &MockRecipeNoteStore{ctrl: ctrl}
+	mock.recorder = &MockRecipeNoteStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeNoteStore) EXPECT() *MockRecipeNoteStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteRecipeNote mocks base method.
+func (m *MockRecipeNoteStore) DeleteRecipeNote(userID, recipeID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeNote", userID, recipeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeNote indicates an expected call of DeleteRecipeNote.
+func (mr *MockRecipeNoteStoreMockRecorder) DeleteRecipeNote(userID, recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeNote", reflect.TypeOf((*MockRecipeNoteStore)(nil).DeleteRecipeNote), userID, recipeID)
+}
+
+// GetRecipeNote mocks base method.
+func (m *MockRecipeNoteStore) GetRecipeNote(userID, recipeID int64) (*store.RecipeNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeNote", userID, recipeID)
+	ret0, _ := ret[0].(*store.RecipeNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeNote indicates an expected call of GetRecipeNote.
+func (mr *MockRecipeNoteStoreMockRecorder) GetRecipeNote(userID, recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeNote", reflect.TypeOf((*MockRecipeNoteStore)(nil).GetRecipeNote), userID, recipeID)
+}
+
+// SaveRecipeNote mocks base method.
+func (m *MockRecipeNoteStore) SaveRecipeNote(userID, recipeID int64, note string) (*store.RecipeNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRecipeNote", userID, recipeID, note)
+	ret0, _ := ret[0].(*store.RecipeNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveRecipeNote indicates an expected call of SaveRecipeNote.
+func (mr *MockRecipeNoteStoreMockRecorder) SaveRecipeNote(userID, recipeID, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRecipeNote", reflect.TypeOf((*MockRecipeNoteStore)(nil).SaveRecipeNote), userID, recipeID, note)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recipe_preview_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=recipe_preview_store.go -destination=../mocks/store/recipe_preview_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecipePreviewStore is a mock of RecipePreviewStore interface.
+type MockRecipePreviewStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipePreviewStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipePreviewStoreMockRecorder is the mock recorder for MockRecipePreviewStore.
+type MockRecipePreviewStoreMockRecorder struct {
+	mock *MockRecipePreviewStore
+}
+
+// NewMockRecipePreviewStore creates a new mock instance.
+func NewMockRecipePreviewStore(ctrl *gomock.Controller) *MockRecipePreviewStore {
+	mock := &MockRecipePreviewStore{ctrl: ctrl}
+	mock.recorder = &MockRecipePreviewStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipePreviewStore) EXPECT() *MockRecipePreviewStoreMockRecorder {
+	return m.recorder
+}
+
+// CreatePreviewLink mocks base method.
+func (m *MockRecipePreviewStore) CreatePreviewLink(recipeID int64, ttl time.Duration) (*store.RecipePreviewLink, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePreviewLink", recipeID, ttl)
+	ret0, _ := ret[0].(*store.RecipePreviewLink)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreatePreviewLink indicates an expected call of CreatePreviewLink.
+func (mr *MockRecipePreviewStoreMockRecorder) CreatePreviewLink(recipeID, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePreviewLink", reflect.TypeOf((*MockRecipePreviewStore)(nil).CreatePreviewLink), recipeID, ttl)
+}
+
+// GetPreviewLinks mocks base method.
+func (m *MockRecipePreviewStore) GetPreviewLinks(recipeID int64) ([]*store.RecipePreviewLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreviewLinks", recipeID)
+	ret0, _ := ret[0].([]*store.RecipePreviewLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreviewLinks indicates an expected call of GetPreviewLinks.
+func (mr *MockRecipePreviewStoreMockRecorder) GetPreviewLinks(recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreviewLinks", reflect.TypeOf((*MockRecipePreviewStore)(nil).GetPreviewLinks), recipeID)
+}
+
+// RevokePreviewLink mocks base method.
+func (m *MockRecipePreviewStore) RevokePreviewLink(id, recipeID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokePreviewLink", id, recipeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokePreviewLink indicates an expected call of RevokePreviewLink.
+func (mr *MockRecipePreviewStoreMockRecorder) RevokePreviewLink(id, recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokePreviewLink", reflect.TypeOf((*MockRecipePreviewStore)(nil).RevokePreviewLink), id, recipeID)
+}
+
+// UsePreviewLink mocks base method.
+func (m *MockRecipePreviewStore) UsePreviewLink(token string) (*store.RecipePreviewLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePreviewLink", token)
+	ret0, _ := ret[0].(*store.RecipePreviewLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePreviewLink indicates an expected call of UsePreviewLink.
+func (mr *MockRecipePreviewStoreMockRecorder) UsePreviewLink(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePreviewLink", reflect.TypeOf((*MockRecipePreviewStore)(nil).UsePreviewLink), token)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recipe_stats_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=recipe_stats_store.go -destination=../mocks/store/recipe_stats_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecipeStatsStore is a mock of RecipeStatsStore interface.
+type MockRecipeStatsStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeStatsStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipeStatsStoreMockRecorder is the mock recorder for MockRecipeStatsStore.
+type MockRecipeStatsStoreMockRecorder struct {
+	mock *MockRecipeStatsStore
+}
+
+// NewMockRecipeStatsStore creates a new mock instance.
+func NewMockRecipeStatsStore(ctrl *gomock.Controller) *MockRecipeStatsStore {
+	mock := &MockRecipeStatsStore{ctrl: ctrl}
+	mock.recorder = &MockRecipeStatsStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeStatsStore) EXPECT() *MockRecipeStatsStoreMockRecorder {
+	return m.recorder
+}
+
+// AddRecipeViews mocks base method.
+func (m *MockRecipeStatsStore) AddRecipeViews(increments []*store.RecipeViewIncrement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecipeViews", increments)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecipeViews indicates an expected call of AddRecipeViews.
+func (mr *MockRecipeStatsStoreMockRecorder) AddRecipeViews(increments any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecipeViews", reflect.TypeOf((*MockRecipeStatsStore)(nil).AddRecipeViews), increments)
+}
+
+// CountUserRecipes mocks base method.
+func (m *MockRecipeStatsStore) CountUserRecipes(userID int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserRecipes", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserRecipes indicates an expected call of CountUserRecipes.
+func (mr *MockRecipeStatsStoreMockRecorder) CountUserRecipes(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserRecipes", reflect.TypeOf((*MockRecipeStatsStore)(nil).CountUserRecipes), userID)
+}
+
+// EachRecipeStats mocks base method.
+func (m *MockRecipeStatsStore) EachRecipeStats(userID int64, from, to time.Time, fn func(*store.RecipeStats) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachRecipeStats", userID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachRecipeStats indicates an expected call of EachRecipeStats.
+func (mr *MockRecipeStatsStoreMockRecorder) EachRecipeStats(userID, from, to, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachRecipeStats", reflect.TypeOf((*MockRecipeStatsStore)(nil).EachRecipeStats), userID, from, to, fn)
+}
@@ -0,0 +1,1003 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recipe_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=recipe_store.go -destination=../mocks/store/recipe_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecipeStore is a mock of RecipeStore interface.
+type MockRecipeStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipeStoreMockRecorder is the mock recorder for MockRecipeStore.
+type MockRecipeStoreMockRecorder struct {
+	mock *MockRecipeStore
+}
+
+// NewMockRecipeStore creates a new mock instance.
+func NewMockRecipeStore(ctrl *gomock.Controller) *MockRecipeStore {
+	mock := &MockRecipeStore{ctrl: ctrl}
+	mock.recorder = &MockRecipeStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeStore) EXPECT() *MockRecipeStoreMockRecorder {
+	return m.recorder
+}
+
+// AddRecipeIngredient mocks base method.
+func (m *MockRecipeStore) AddRecipeIngredient(ingredient *store.RecipeIngredient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecipeIngredient", ingredient)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecipeIngredient indicates an expected call of AddRecipeIngredient.
+func (mr *MockRecipeStoreMockRecorder) AddRecipeIngredient(ingredient any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecipeIngredient", reflect.TypeOf((*MockRecipeStore)(nil).AddRecipeIngredient), ingredient)
+}
+
+// AddRecipeStep mocks base method.
+func (m *MockRecipeStore) AddRecipeStep(step *store.RecipeStep) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecipeStep", step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecipeStep indicates an expected call of AddRecipeStep.
+func (mr *MockRecipeStoreMockRecorder) AddRecipeStep(step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecipeStep", reflect.TypeOf((*MockRecipeStore)(nil).AddRecipeStep), step)
+}
+
+// ArchiveStaleDrafts mocks base method.
+func (m *MockRecipeStore) ArchiveStaleDrafts(untouchedBefore, warnedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveStaleDrafts", untouchedBefore, warnedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveStaleDrafts indicates an expected call of ArchiveStaleDrafts.
+func (mr *MockRecipeStoreMockRecorder) ArchiveStaleDrafts(untouchedBefore, warnedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveStaleDrafts", reflect.TypeOf((*MockRecipeStore)(nil).ArchiveStaleDrafts), untouchedBefore, warnedBefore)
+}
+
+// CountRecipesByUserID mocks base method.
+func (m *MockRecipeStore) CountRecipesByUserID(userID int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecipesByUserID", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecipesByUserID indicates an expected call of CountRecipesByUserID.
+func (mr *MockRecipeStoreMockRecorder) CountRecipesByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecipesByUserID", reflect.TypeOf((*MockRecipeStore)(nil).CountRecipesByUserID), userID)
+}
+
+// CountRecipesCreatedSince mocks base method.
+func (m *MockRecipeStore) CountRecipesCreatedSince(userID int64, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecipesCreatedSince", userID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecipesCreatedSince indicates an expected call of CountRecipesCreatedSince.
+func (mr *MockRecipeStoreMockRecorder) CountRecipesCreatedSince(userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecipesCreatedSince", reflect.TypeOf((*MockRecipeStore)(nil).CountRecipesCreatedSince), userID, since)
+}
+
+// CreateCompleteRecipe mocks base method.
+func (m *MockRecipeStore) CreateCompleteRecipe(recipe *store.Recipe, ingredients []*store.RecipeIngredient, steps []*store.RecipeStep) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCompleteRecipe", recipe, ingredients, steps)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCompleteRecipe indicates an expected call of CreateCompleteRecipe.
+func (mr *MockRecipeStoreMockRecorder) CreateCompleteRecipe(recipe, ingredients, steps any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompleteRecipe", reflect.TypeOf((*MockRecipeStore)(nil).CreateCompleteRecipe), recipe, ingredients, steps)
+}
+
+// CreateRecipe mocks base method.
+func (m *MockRecipeStore) CreateRecipe(recipe *store.Recipe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecipe", recipe)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRecipe indicates an expected call of CreateRecipe.
+func (mr *MockRecipeStoreMockRecorder) CreateRecipe(recipe any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipe", reflect.TypeOf((*MockRecipeStore)(nil).CreateRecipe), recipe)
+}
+
+// DeleteRecipe mocks base method.
+func (m *MockRecipeStore) DeleteRecipe(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipe", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipe indicates an expected call of DeleteRecipe.
+func (mr *MockRecipeStoreMockRecorder) DeleteRecipe(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipe", reflect.TypeOf((*MockRecipeStore)(nil).DeleteRecipe), id)
+}
+
+// DeleteRecipeIngredient mocks base method.
+func (m *MockRecipeStore) DeleteRecipeIngredient(ingredientID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeIngredient", ingredientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeIngredient indicates an expected call of DeleteRecipeIngredient.
+func (mr *MockRecipeStoreMockRecorder) DeleteRecipeIngredient(ingredientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeIngredient", reflect.TypeOf((*MockRecipeStore)(nil).DeleteRecipeIngredient), ingredientID)
+}
+
+// DeleteRecipeStep mocks base method.
+func (m *MockRecipeStore) DeleteRecipeStep(stepID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeStep", stepID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeStep indicates an expected call of DeleteRecipeStep.
+func (mr *MockRecipeStoreMockRecorder) DeleteRecipeStep(stepID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeStep", reflect.TypeOf((*MockRecipeStore)(nil).DeleteRecipeStep), stepID)
+}
+
+// EachRecipe mocks base method.
+func (m *MockRecipeStore) EachRecipe(ctx context.Context, opts store.RecipeListOptions, fn func(*store.Recipe) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachRecipe", ctx, opts, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachRecipe indicates an expected call of EachRecipe.
+func (mr *MockRecipeStoreMockRecorder) EachRecipe(ctx, opts, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachRecipe", reflect.TypeOf((*MockRecipeStore)(nil).EachRecipe), ctx, opts, fn)
+}
+
+// GetCompleteRecipe mocks base method.
+func (m *MockRecipeStore) GetCompleteRecipe(id int64) (*store.CompleteRecipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompleteRecipe", id)
+	ret0, _ := ret[0].(*store.CompleteRecipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompleteRecipe indicates an expected call of GetCompleteRecipe.
+func (mr *MockRecipeStoreMockRecorder) GetCompleteRecipe(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompleteRecipe", reflect.TypeOf((*MockRecipeStore)(nil).GetCompleteRecipe), id)
+}
+
+// GetRandomRecipe mocks base method.
+func (m *MockRecipeStore) GetRandomRecipe(ctx context.Context, opts store.RecipeListOptions) (*store.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRandomRecipe", ctx, opts)
+	ret0, _ := ret[0].(*store.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRandomRecipe indicates an expected call of GetRandomRecipe.
+func (mr *MockRecipeStoreMockRecorder) GetRandomRecipe(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRandomRecipe", reflect.TypeOf((*MockRecipeStore)(nil).GetRandomRecipe), ctx, opts)
+}
+
+// GetRecipeByID mocks base method.
+func (m *MockRecipeStore) GetRecipeByID(id int64) (*store.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeByID", id)
+	ret0, _ := ret[0].(*store.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeByID indicates an expected call of GetRecipeByID.
+func (mr *MockRecipeStoreMockRecorder) GetRecipeByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeByID", reflect.TypeOf((*MockRecipeStore)(nil).GetRecipeByID), id)
+}
+
+// GetRecipeFacets mocks base method.
+func (m *MockRecipeStore) GetRecipeFacets(ctx context.Context, opts store.RecipeListOptions) (*store.RecipeFacets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeFacets", ctx, opts)
+	ret0, _ := ret[0].(*store.RecipeFacets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeFacets indicates an expected call of GetRecipeFacets.
+func (mr *MockRecipeStoreMockRecorder) GetRecipeFacets(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeFacets", reflect.TypeOf((*MockRecipeStore)(nil).GetRecipeFacets), ctx, opts)
+}
+
+// GetRecipeIngredients mocks base method.
+func (m *MockRecipeStore) GetRecipeIngredients(recipeID int64) ([]*store.RecipeIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeIngredients", recipeID)
+	ret0, _ := ret[0].([]*store.RecipeIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeIngredients indicates an expected call of GetRecipeIngredients.
+func (mr *MockRecipeStoreMockRecorder) GetRecipeIngredients(recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeIngredients", reflect.TypeOf((*MockRecipeStore)(nil).GetRecipeIngredients), recipeID)
+}
+
+// GetRecipeSteps mocks base method.
+func (m *MockRecipeStore) GetRecipeSteps(recipeID int64) ([]*store.RecipeStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeSteps", recipeID)
+	ret0, _ := ret[0].([]*store.RecipeStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeSteps indicates an expected call of GetRecipeSteps.
+func (mr *MockRecipeStoreMockRecorder) GetRecipeSteps(recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeSteps", reflect.TypeOf((*MockRecipeStore)(nil).GetRecipeSteps), recipeID)
+}
+
+// GetRecipes mocks base method.
+func (m *MockRecipeStore) GetRecipes(ctx context.Context, opts store.RecipeListOptions) ([]*store.Recipe, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipes", ctx, opts)
+	ret0, _ := ret[0].([]*store.Recipe)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRecipes indicates an expected call of GetRecipes.
+func (mr *MockRecipeStoreMockRecorder) GetRecipes(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipes", reflect.TypeOf((*MockRecipeStore)(nil).GetRecipes), ctx, opts)
+}
+
+// GetRecipesByUserID mocks base method.
+func (m *MockRecipeStore) GetRecipesByUserID(userID, afterID int64, limit int) ([]*store.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipesByUserID", userID, afterID, limit)
+	ret0, _ := ret[0].([]*store.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipesByUserID indicates an expected call of GetRecipesByUserID.
+func (mr *MockRecipeStoreMockRecorder) GetRecipesByUserID(userID, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipesByUserID", reflect.TypeOf((*MockRecipeStore)(nil).GetRecipesByUserID), userID, afterID, limit)
+}
+
+// GetRecommendedRecipes mocks base method.
+func (m *MockRecipeStore) GetRecommendedRecipes(userID int64, prefs *store.UserPreferences, limit int) ([]*store.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecommendedRecipes", userID, prefs, limit)
+	ret0, _ := ret[0].([]*store.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecommendedRecipes indicates an expected call of GetRecommendedRecipes.
+func (mr *MockRecipeStoreMockRecorder) GetRecommendedRecipes(userID, prefs, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendedRecipes", reflect.TypeOf((*MockRecipeStore)(nil).GetRecommendedRecipes), userID, prefs, limit)
+}
+
+// GetStaleDraftsToWarn mocks base method.
+func (m *MockRecipeStore) GetStaleDraftsToWarn(untouchedBefore time.Time, limit int) ([]*store.StaleDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStaleDraftsToWarn", untouchedBefore, limit)
+	ret0, _ := ret[0].([]*store.StaleDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStaleDraftsToWarn indicates an expected call of GetStaleDraftsToWarn.
+func (mr *MockRecipeStoreMockRecorder) GetStaleDraftsToWarn(untouchedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStaleDraftsToWarn", reflect.TypeOf((*MockRecipeStore)(nil).GetStaleDraftsToWarn), untouchedBefore, limit)
+}
+
+// MarkStaleDraftsWarned mocks base method.
+func (m *MockRecipeStore) MarkStaleDraftsWarned(recipeIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkStaleDraftsWarned", recipeIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkStaleDraftsWarned indicates an expected call of MarkStaleDraftsWarned.
+func (mr *MockRecipeStoreMockRecorder) MarkStaleDraftsWarned(recipeIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkStaleDraftsWarned", reflect.TypeOf((*MockRecipeStore)(nil).MarkStaleDraftsWarned), recipeIDs)
+}
+
+// ReorderRecipeIngredients mocks base method.
+func (m *MockRecipeStore) ReorderRecipeIngredients(recipeID int64, ingredientIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderRecipeIngredients", recipeID, ingredientIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderRecipeIngredients indicates an expected call of ReorderRecipeIngredients.
+func (mr *MockRecipeStoreMockRecorder) ReorderRecipeIngredients(recipeID, ingredientIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderRecipeIngredients", reflect.TypeOf((*MockRecipeStore)(nil).ReorderRecipeIngredients), recipeID, ingredientIDs)
+}
+
+// ReorderRecipeSteps mocks base method.
+func (m *MockRecipeStore) ReorderRecipeSteps(recipeID int64, stepIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderRecipeSteps", recipeID, stepIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderRecipeSteps indicates an expected call of ReorderRecipeSteps.
+func (mr *MockRecipeStoreMockRecorder) ReorderRecipeSteps(recipeID, stepIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderRecipeSteps", reflect.TypeOf((*MockRecipeStore)(nil).ReorderRecipeSteps), recipeID, stepIDs)
+}
+
+// RestoreRecipe mocks base method.
+func (m *MockRecipeStore) RestoreRecipe(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRecipe", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreRecipe indicates an expected call of RestoreRecipe.
+func (mr *MockRecipeStoreMockRecorder) RestoreRecipe(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRecipe", reflect.TypeOf((*MockRecipeStore)(nil).RestoreRecipe), id)
+}
+
+// SetRecipeDietaryLabels mocks base method.
+func (m *MockRecipeStore) SetRecipeDietaryLabels(recipeID int64, labels []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecipeDietaryLabels", recipeID, labels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecipeDietaryLabels indicates an expected call of SetRecipeDietaryLabels.
+func (mr *MockRecipeStoreMockRecorder) SetRecipeDietaryLabels(recipeID, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecipeDietaryLabels", reflect.TypeOf((*MockRecipeStore)(nil).SetRecipeDietaryLabels), recipeID, labels)
+}
+
+// UpdateRecipe mocks base method.
+func (m *MockRecipeStore) UpdateRecipe(recipe *store.Recipe) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecipe", recipe)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecipe indicates an expected call of UpdateRecipe.
+func (mr *MockRecipeStoreMockRecorder) UpdateRecipe(recipe any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipe", reflect.TypeOf((*MockRecipeStore)(nil).UpdateRecipe), recipe)
+}
+
+// UpdateRecipeIngredient mocks base method.
+func (m *MockRecipeStore) UpdateRecipeIngredient(ingredient *store.RecipeIngredient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecipeIngredient", ingredient)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecipeIngredient indicates an expected call of UpdateRecipeIngredient.
+func (mr *MockRecipeStoreMockRecorder) UpdateRecipeIngredient(ingredient any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipeIngredient", reflect.TypeOf((*MockRecipeStore)(nil).UpdateRecipeIngredient), ingredient)
+}
+
+// UpdateRecipeStep mocks base method.
+func (m *MockRecipeStore) UpdateRecipeStep(step *store.RecipeStep) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecipeStep", step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecipeStep indicates an expected call of UpdateRecipeStep.
+func (mr *MockRecipeStoreMockRecorder) UpdateRecipeStep(step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipeStep", reflect.TypeOf((*MockRecipeStore)(nil).UpdateRecipeStep), step)
+}
+
+// MockRecipeMediaStore is a mock of RecipeMediaStore interface.
+type MockRecipeMediaStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeMediaStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipeMediaStoreMockRecorder is the mock recorder for MockRecipeMediaStore.
+type MockRecipeMediaStoreMockRecorder struct {
+	mock *MockRecipeMediaStore
+}
+
+// NewMockRecipeMediaStore creates a new mock instance.
+func NewMockRecipeMediaStore(ctrl *gomock.Controller) *MockRecipeMediaStore {
+	mock := &MockRecipeMediaStore{ctrl: ctrl}
+	mock.recorder = &MockRecipeMediaStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeMediaStore) EXPECT() *MockRecipeMediaStoreMockRecorder {
+	return m.recorder
+}
+
+// AddRecipePhoto mocks base method.
+func (m *MockRecipeMediaStore) AddRecipePhoto(photo *store.RecipePhoto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecipePhoto", photo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecipePhoto indicates an expected call of AddRecipePhoto.
+func (mr *MockRecipeMediaStoreMockRecorder) AddRecipePhoto(photo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecipePhoto", reflect.TypeOf((*MockRecipeMediaStore)(nil).AddRecipePhoto), photo)
+}
+
+// ClaimPhotoImports mocks base method.
+func (m *MockRecipeMediaStore) ClaimPhotoImports(limit int, lease time.Duration) ([]*store.PhotoImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPhotoImports", limit, lease)
+	ret0, _ := ret[0].([]*store.PhotoImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPhotoImports indicates an expected call of ClaimPhotoImports.
+func (mr *MockRecipeMediaStoreMockRecorder) ClaimPhotoImports(limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPhotoImports", reflect.TypeOf((*MockRecipeMediaStore)(nil).ClaimPhotoImports), limit, lease)
+}
+
+// CompletePhotoImport mocks base method.
+func (m *MockRecipeMediaStore) CompletePhotoImport(photoID int64, sourceURL, storageKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompletePhotoImport", photoID, sourceURL, storageKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompletePhotoImport indicates an expected call of CompletePhotoImport.
+func (mr *MockRecipeMediaStoreMockRecorder) CompletePhotoImport(photoID, sourceURL, storageKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompletePhotoImport", reflect.TypeOf((*MockRecipeMediaStore)(nil).CompletePhotoImport), photoID, sourceURL, storageKey)
+}
+
+// DeleteRecipePhoto mocks base method.
+func (m *MockRecipeMediaStore) DeleteRecipePhoto(photoID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipePhoto", photoID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipePhoto indicates an expected call of DeleteRecipePhoto.
+func (mr *MockRecipeMediaStoreMockRecorder) DeleteRecipePhoto(photoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipePhoto", reflect.TypeOf((*MockRecipeMediaStore)(nil).DeleteRecipePhoto), photoID)
+}
+
+// FailPhotoImport mocks base method.
+func (m *MockRecipeMediaStore) FailPhotoImport(photoID int64, message string, retryAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailPhotoImport", photoID, message, retryAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailPhotoImport indicates an expected call of FailPhotoImport.
+func (mr *MockRecipeMediaStoreMockRecorder) FailPhotoImport(photoID, message, retryAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailPhotoImport", reflect.TypeOf((*MockRecipeMediaStore)(nil).FailPhotoImport), photoID, message, retryAt)
+}
+
+// GetRecipePhotoByID mocks base method.
+func (m *MockRecipeMediaStore) GetRecipePhotoByID(photoID int64) (*store.RecipePhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipePhotoByID", photoID)
+	ret0, _ := ret[0].(*store.RecipePhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipePhotoByID indicates an expected call of GetRecipePhotoByID.
+func (mr *MockRecipeMediaStoreMockRecorder) GetRecipePhotoByID(photoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipePhotoByID", reflect.TypeOf((*MockRecipeMediaStore)(nil).GetRecipePhotoByID), photoID)
+}
+
+// GetRecipePhotos mocks base method.
+func (m *MockRecipeMediaStore) GetRecipePhotos(recipeID int64) ([]*store.RecipePhoto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipePhotos", recipeID)
+	ret0, _ := ret[0].([]*store.RecipePhoto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipePhotos indicates an expected call of GetRecipePhotos.
+func (mr *MockRecipeMediaStoreMockRecorder) GetRecipePhotos(recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipePhotos", reflect.TypeOf((*MockRecipeMediaStore)(nil).GetRecipePhotos), recipeID)
+}
+
+// ReorderRecipePhotos mocks base method.
+func (m *MockRecipeMediaStore) ReorderRecipePhotos(recipeID int64, photoIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderRecipePhotos", recipeID, photoIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderRecipePhotos indicates an expected call of ReorderRecipePhotos.
+func (mr *MockRecipeMediaStoreMockRecorder) ReorderRecipePhotos(recipeID, photoIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderRecipePhotos", reflect.TypeOf((*MockRecipeMediaStore)(nil).ReorderRecipePhotos), recipeID, photoIDs)
+}
+
+// ReplaceRecipePhotoFile mocks base method.
+func (m *MockRecipeMediaStore) ReplaceRecipePhotoFile(photoID, recipeID int64, storageKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecipePhotoFile", photoID, recipeID, storageKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecipePhotoFile indicates an expected call of ReplaceRecipePhotoFile.
+func (mr *MockRecipeMediaStoreMockRecorder) ReplaceRecipePhotoFile(photoID, recipeID, storageKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecipePhotoFile", reflect.TypeOf((*MockRecipeMediaStore)(nil).ReplaceRecipePhotoFile), photoID, recipeID, storageKey)
+}
+
+// SetPrimaryPhoto mocks base method.
+func (m *MockRecipeMediaStore) SetPrimaryPhoto(photoID, recipeID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrimaryPhoto", photoID, recipeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrimaryPhoto indicates an expected call of SetPrimaryPhoto.
+func (mr *MockRecipeMediaStoreMockRecorder) SetPrimaryPhoto(photoID, recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimaryPhoto", reflect.TypeOf((*MockRecipeMediaStore)(nil).SetPrimaryPhoto), photoID, recipeID)
+}
+
+// MockRecipeTaxonomyStore is a mock of RecipeTaxonomyStore interface.
+type MockRecipeTaxonomyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeTaxonomyStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipeTaxonomyStoreMockRecorder is the mock recorder for MockRecipeTaxonomyStore.
+type MockRecipeTaxonomyStoreMockRecorder struct {
+	mock *MockRecipeTaxonomyStore
+}
+
+// NewMockRecipeTaxonomyStore creates a new mock instance.
+func NewMockRecipeTaxonomyStore(ctrl *gomock.Controller) *MockRecipeTaxonomyStore {
+	mock := &MockRecipeTaxonomyStore{ctrl: ctrl}
+	mock.recorder = &MockRecipeTaxonomyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeTaxonomyStore) EXPECT() *MockRecipeTaxonomyStoreMockRecorder {
+	return m.recorder
+}
+
+// AddRecipeTag mocks base method.
+func (m *MockRecipeTaxonomyStore) AddRecipeTag(recipeID, tagID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecipeTag", recipeID, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecipeTag indicates an expected call of AddRecipeTag.
+func (mr *MockRecipeTaxonomyStoreMockRecorder) AddRecipeTag(recipeID, tagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecipeTag", reflect.TypeOf((*MockRecipeTaxonomyStore)(nil).AddRecipeTag), recipeID, tagID)
+}
+
+// CreateCategory mocks base method.
+func (m *MockRecipeTaxonomyStore) CreateCategory(name string) (*store.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", name)
+	ret0, _ := ret[0].(*store.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockRecipeTaxonomyStoreMockRecorder) CreateCategory(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockRecipeTaxonomyStore)(nil).CreateCategory), name)
+}
+
+// CreateTag mocks base method.
+func (m *MockRecipeTaxonomyStore) CreateTag(name string) (*store.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", name)
+	ret0, _ := ret[0].(*store.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockRecipeTaxonomyStoreMockRecorder) CreateTag(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockRecipeTaxonomyStore)(nil).CreateTag), name)
+}
+
+// GetAllCategories mocks base method.
+func (m *MockRecipeTaxonomyStore) GetAllCategories(includePhotos bool) ([]*store.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCategories", includePhotos)
+	ret0, _ := ret[0].([]*store.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCategories indicates an expected call of GetAllCategories.
+func (mr *MockRecipeTaxonomyStoreMockRecorder) GetAllCategories(includePhotos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockRecipeTaxonomyStore)(nil).GetAllCategories), includePhotos)
+}
+
+// GetAllTags mocks base method.
+func (m *MockRecipeTaxonomyStore) GetAllTags() ([]*store.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTags")
+	ret0, _ := ret[0].([]*store.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTags indicates an expected call of GetAllTags.
+func (mr *MockRecipeTaxonomyStoreMockRecorder) GetAllTags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTags", reflect.TypeOf((*MockRecipeTaxonomyStore)(nil).GetAllTags))
+}
+
+// GetPopularTags mocks base method.
+func (m *MockRecipeTaxonomyStore) GetPopularTags(since *time.Time, limit int) ([]*store.PopularTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPopularTags", since, limit)
+	ret0, _ := ret[0].([]*store.PopularTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPopularTags indicates an expected call of GetPopularTags.
+func (mr *MockRecipeTaxonomyStoreMockRecorder) GetPopularTags(since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularTags", reflect.TypeOf((*MockRecipeTaxonomyStore)(nil).GetPopularTags), since, limit)
+}
+
+// GetRecipeTags mocks base method.
+func (m *MockRecipeTaxonomyStore) GetRecipeTags(recipeID int64) ([]*store.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeTags", recipeID)
+	ret0, _ := ret[0].([]*store.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeTags indicates an expected call of GetRecipeTags.
+func (mr *MockRecipeTaxonomyStoreMockRecorder) GetRecipeTags(recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeTags", reflect.TypeOf((*MockRecipeTaxonomyStore)(nil).GetRecipeTags), recipeID)
+}
+
+// RemoveRecipeTag mocks base method.
+func (m *MockRecipeTaxonomyStore) RemoveRecipeTag(recipeID, tagID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRecipeTag", recipeID, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRecipeTag indicates an expected call of RemoveRecipeTag.
+func (mr *MockRecipeTaxonomyStoreMockRecorder) RemoveRecipeTag(recipeID, tagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRecipeTag", reflect.TypeOf((*MockRecipeTaxonomyStore)(nil).RemoveRecipeTag), recipeID, tagID)
+}
+
+// SetRecipeTags mocks base method.
+func (m *MockRecipeTaxonomyStore) SetRecipeTags(recipeID int64, names []string) (*store.RecipeTagsUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecipeTags", recipeID, names)
+	ret0, _ := ret[0].(*store.RecipeTagsUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRecipeTags indicates an expected call of SetRecipeTags.
+func (mr *MockRecipeTaxonomyStoreMockRecorder) SetRecipeTags(recipeID, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecipeTags", reflect.TypeOf((*MockRecipeTaxonomyStore)(nil).SetRecipeTags), recipeID, names)
+}
+
+// MockReviewStore is a mock of ReviewStore interface.
+type MockReviewStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockReviewStoreMockRecorder is the mock recorder for MockReviewStore.
+type MockReviewStoreMockRecorder struct {
+	mock *MockReviewStore
+}
+
+// NewMockReviewStore creates a new mock instance.
+func NewMockReviewStore(ctrl *gomock.Controller) *MockReviewStore {
+	mock := &MockReviewStore{ctrl: ctrl}
+	mock.recorder = &MockReviewStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewStore) EXPECT() *MockReviewStoreMockRecorder {
+	return m.recorder
+}
+
+// AddRecipeReview mocks base method.
+func (m *MockReviewStore) AddRecipeReview(recipeID, userID int64, rating int, comment string) (*store.RecipeReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecipeReview", recipeID, userID, rating, comment)
+	ret0, _ := ret[0].(*store.RecipeReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRecipeReview indicates an expected call of AddRecipeReview.
+func (mr *MockReviewStoreMockRecorder) AddRecipeReview(recipeID, userID, rating, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecipeReview", reflect.TypeOf((*MockReviewStore)(nil).AddRecipeReview), recipeID, userID, rating, comment)
+}
+
+// CountReviewsCreatedSince mocks base method.
+func (m *MockReviewStore) CountReviewsCreatedSince(userID int64, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReviewsCreatedSince", userID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReviewsCreatedSince indicates an expected call of CountReviewsCreatedSince.
+func (mr *MockReviewStoreMockRecorder) CountReviewsCreatedSince(userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReviewsCreatedSince", reflect.TypeOf((*MockReviewStore)(nil).CountReviewsCreatedSince), userID, since)
+}
+
+// DeleteRecipeReview mocks base method.
+func (m *MockReviewStore) DeleteRecipeReview(reviewID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipeReview", reviewID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecipeReview indicates an expected call of DeleteRecipeReview.
+func (mr *MockReviewStoreMockRecorder) DeleteRecipeReview(reviewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipeReview", reflect.TypeOf((*MockReviewStore)(nil).DeleteRecipeReview), reviewID)
+}
+
+// DeleteReviewAuthorResponse mocks base method.
+func (m *MockReviewStore) DeleteReviewAuthorResponse(reviewID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReviewAuthorResponse", reviewID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReviewAuthorResponse indicates an expected call of DeleteReviewAuthorResponse.
+func (mr *MockReviewStoreMockRecorder) DeleteReviewAuthorResponse(reviewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReviewAuthorResponse", reflect.TypeOf((*MockReviewStore)(nil).DeleteReviewAuthorResponse), reviewID)
+}
+
+// GetRecipeRatingSummary mocks base method.
+func (m *MockReviewStore) GetRecipeRatingSummary(recipeID int64, opts store.RatingSummaryOptions) (*store.RecipeRatingSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeRatingSummary", recipeID, opts)
+	ret0, _ := ret[0].(*store.RecipeRatingSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeRatingSummary indicates an expected call of GetRecipeRatingSummary.
+func (mr *MockReviewStoreMockRecorder) GetRecipeRatingSummary(recipeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeRatingSummary", reflect.TypeOf((*MockReviewStore)(nil).GetRecipeRatingSummary), recipeID, opts)
+}
+
+// GetRecipeReviewByID mocks base method.
+func (m *MockReviewStore) GetRecipeReviewByID(reviewID int64) (*store.RecipeReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeReviewByID", reviewID)
+	ret0, _ := ret[0].(*store.RecipeReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeReviewByID indicates an expected call of GetRecipeReviewByID.
+func (mr *MockReviewStoreMockRecorder) GetRecipeReviewByID(reviewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeReviewByID", reflect.TypeOf((*MockReviewStore)(nil).GetRecipeReviewByID), reviewID)
+}
+
+// GetRecipeReviews mocks base method.
+func (m *MockReviewStore) GetRecipeReviews(recipeID int64, opts store.ReviewListOptions) ([]*store.RecipeReview, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeReviews", recipeID, opts)
+	ret0, _ := ret[0].([]*store.RecipeReview)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRecipeReviews indicates an expected call of GetRecipeReviews.
+func (mr *MockReviewStoreMockRecorder) GetRecipeReviews(recipeID, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeReviews", reflect.TypeOf((*MockReviewStore)(nil).GetRecipeReviews), recipeID, opts)
+}
+
+// GetReviewsByUserID mocks base method.
+func (m *MockReviewStore) GetReviewsByUserID(userID int64) ([]*store.RecipeReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewsByUserID", userID)
+	ret0, _ := ret[0].([]*store.RecipeReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewsByUserID indicates an expected call of GetReviewsByUserID.
+func (mr *MockReviewStoreMockRecorder) GetReviewsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByUserID", reflect.TypeOf((*MockReviewStore)(nil).GetReviewsByUserID), userID)
+}
+
+// SetReviewAuthorResponse mocks base method.
+func (m *MockReviewStore) SetReviewAuthorResponse(reviewID, userID int64, body string) (*store.ReviewAuthorResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReviewAuthorResponse", reviewID, userID, body)
+	ret0, _ := ret[0].(*store.ReviewAuthorResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReviewAuthorResponse indicates an expected call of SetReviewAuthorResponse.
+func (mr *MockReviewStoreMockRecorder) SetReviewAuthorResponse(reviewID, userID, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReviewAuthorResponse", reflect.TypeOf((*MockReviewStore)(nil).SetReviewAuthorResponse), reviewID, userID, body)
+}
+
+// UpdateRecipeReview mocks base method.
+func (m *MockReviewStore) UpdateRecipeReview(review *store.RecipeReview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecipeReview", review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecipeReview indicates an expected call of UpdateRecipeReview.
+func (mr *MockReviewStoreMockRecorder) UpdateRecipeReview(review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipeReview", reflect.TypeOf((*MockReviewStore)(nil).UpdateRecipeReview), review)
+}
+
+// MockqueryRower is a mock of queryRower interface.
+type MockqueryRower struct {
+	ctrl     *gomock.Controller
+	recorder *MockqueryRowerMockRecorder
+	isgomock struct{}
+}
+
+// MockqueryRowerMockRecorder is the mock recorder for MockqueryRower.
+type MockqueryRowerMockRecorder struct {
+	mock *MockqueryRower
+}
+
+// NewMockqueryRower creates a new mock instance.
+func NewMockqueryRower(ctrl *gomock.Controller) *MockqueryRower {
+	mock := &MockqueryRower{ctrl: ctrl}
+	mock.recorder = &MockqueryRowerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockqueryRower) EXPECT() *MockqueryRowerMockRecorder {
+	return m.recorder
+}
+
+// QueryRow mocks base method.
+func (m *MockqueryRower) QueryRow(query string, args ...any) *sql.Row {
+	m.ctrl.T.Helper()
+	varargs := []any{query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRow", varargs...)
+	ret0, _ := ret[0].(*sql.Row)
+	return ret0
+}
+
+// QueryRow indicates an expected call of QueryRow.
+func (mr *MockqueryRowerMockRecorder) QueryRow(query any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRow", reflect.TypeOf((*MockqueryRower)(nil).QueryRow), varargs...)
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recipe_template_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=recipe_template_store.go -destination=../mocks/store/recipe_template_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecipeTemplateStore is a mock of RecipeTemplateStore interface.
+type MockRecipeTemplateStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecipeTemplateStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRecipeTemplateStoreMockRecorder is the mock recorder for MockRecipeTemplateStore.
+type MockRecipeTemplateStoreMockRecorder struct {
+	mock *MockRecipeTemplateStore
+}
+
+// NewMockRecipeTemplateStore creates a new mock instance.
+func NewMockRecipeTemplateStore(ctrl *gomock.Controller) *MockRecipeTemplateStore {
+	mock := &MockRecipeTemplateStore{ctrl: ctrl}
+	mock.recorder = &MockRecipeTemplateStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecipeTemplateStore) EXPECT() *MockRecipeTemplateStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateTemplate mocks base method.
+func (m *MockRecipeTemplateStore) CreateTemplate(template *store.RecipeTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockRecipeTemplateStoreMockRecorder) CreateTemplate(template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockRecipeTemplateStore)(nil).CreateTemplate), template)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockRecipeTemplateStore) DeleteTemplate(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockRecipeTemplateStoreMockRecorder) DeleteTemplate(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockRecipeTemplateStore)(nil).DeleteTemplate), id)
+}
+
+// GetTemplate mocks base method.
+func (m *MockRecipeTemplateStore) GetTemplate(id int64) (*store.RecipeTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", id)
+	ret0, _ := ret[0].(*store.RecipeTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockRecipeTemplateStoreMockRecorder) GetTemplate(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockRecipeTemplateStore)(nil).GetTemplate), id)
+}
+
+// ListTemplates mocks base method.
+func (m *MockRecipeTemplateStore) ListTemplates() ([]*store.RecipeTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates")
+	ret0, _ := ret[0].([]*store.RecipeTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockRecipeTemplateStoreMockRecorder) ListTemplates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockRecipeTemplateStore)(nil).ListTemplates))
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockRecipeTemplateStore) UpdateTemplate(template *store.RecipeTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockRecipeTemplateStoreMockRecorder) UpdateTemplate(template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockRecipeTemplateStore)(nil).UpdateTemplate), template)
+}
+
+// MockrowScanner is a mock of rowScanner interface.
+type MockrowScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockrowScannerMockRecorder
+	isgomock struct{}
+}
+
+// MockrowScannerMockRecorder is the mock recorder for MockrowScanner.
+type MockrowScannerMockRecorder struct {
+	mock *MockrowScanner
+}
+
+// NewMockrowScanner creates a new mock instance.
+func NewMockrowScanner(ctrl *gomock.Controller) *MockrowScanner {
+	mock := &MockrowScanner{ctrl: ctrl}
+	mock.recorder = &MockrowScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrowScanner) EXPECT() *MockrowScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockrowScanner) Scan(dest ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockrowScannerMockRecorder) Scan(dest ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockrowScanner)(nil).Scan), dest...)
+}
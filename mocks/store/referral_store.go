@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: referral_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=referral_store.go -destination=../mocks/store/referral_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	sql "database/sql"
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReferralStore is a mock of ReferralStore interface.
+type MockReferralStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReferralStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockReferralStoreMockRecorder is the mock recorder for MockReferralStore.
+type MockReferralStoreMockRecorder struct {
+	mock *MockReferralStore
+}
+
+// NewMockReferralStore creates a new mock instance.
+func NewMockReferralStore(ctrl *gomock.Controller) *MockReferralStore {
+	mock := &MockReferralStore{ctrl: ctrl}
+	mock.recorder = &MockReferralStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReferralStore) EXPECT() *MockReferralStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateReferralWithTransaction mocks base method.
+func (m *MockReferralStore) CreateReferralWithTransaction(referrerID int64, referredUserID, source string, tx *sql.Tx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReferralWithTransaction", referrerID, referredUserID, source, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReferralWithTransaction indicates an expected call of CreateReferralWithTransaction.
+func (mr *MockReferralStoreMockRecorder) CreateReferralWithTransaction(referrerID, referredUserID, source, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReferralWithTransaction", reflect.TypeOf((*MockReferralStore)(nil).CreateReferralWithTransaction), referrerID, referredUserID, source, tx)
+}
+
+// GetOrCreateReferralCode mocks base method.
+func (m *MockReferralStore) GetOrCreateReferralCode(userID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCreateReferralCode", userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrCreateReferralCode indicates an expected call of GetOrCreateReferralCode.
+func (mr *MockReferralStoreMockRecorder) GetOrCreateReferralCode(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateReferralCode", reflect.TypeOf((*MockReferralStore)(nil).GetOrCreateReferralCode), userID)
+}
+
+// GetReferralSummary mocks base method.
+func (m *MockReferralStore) GetReferralSummary(referrerID int64) (*store.ReferralSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferralSummary", referrerID)
+	ret0, _ := ret[0].(*store.ReferralSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferralSummary indicates an expected call of GetReferralSummary.
+func (mr *MockReferralStoreMockRecorder) GetReferralSummary(referrerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferralSummary", reflect.TypeOf((*MockReferralStore)(nil).GetReferralSummary), referrerID)
+}
+
+// GetUserIDByReferralCode mocks base method.
+func (m *MockReferralStore) GetUserIDByReferralCode(code string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDByReferralCode", code)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDByReferralCode indicates an expected call of GetUserIDByReferralCode.
+func (mr *MockReferralStoreMockRecorder) GetUserIDByReferralCode(code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDByReferralCode", reflect.TypeOf((*MockReferralStore)(nil).GetUserIDByReferralCode), code)
+}
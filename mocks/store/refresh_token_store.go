@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: refresh_token_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=refresh_token_store.go -destination=../mocks/store/refresh_token_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRefreshTokenStore is a mock of RefreshTokenStore interface.
+type MockRefreshTokenStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRefreshTokenStoreMockRecorder is the mock recorder for MockRefreshTokenStore.
+type MockRefreshTokenStoreMockRecorder struct {
+	mock *MockRefreshTokenStore
+}
+
+// NewMockRefreshTokenStore creates a new mock instance.
+func NewMockRefreshTokenStore(ctrl *gomock.Controller) *MockRefreshTokenStore {
+	mock := &MockRefreshTokenStore{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenStore) EXPECT() *MockRefreshTokenStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MockRefreshTokenStore) CreateRefreshToken(userID string, duration time.Duration, ipAddress, userAgent string) (*store.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", userID, duration, ipAddress, userAgent)
+	ret0, _ := ret[0].(*store.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockRefreshTokenStoreMockRecorder) CreateRefreshToken(userID, duration, ipAddress, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockRefreshTokenStore)(nil).CreateRefreshToken), userID, duration, ipAddress, userAgent)
+}
+
+// CreateRefreshTokenWithTransaction mocks base method.
+func (m *MockRefreshTokenStore) CreateRefreshTokenWithTransaction(userID string, duration time.Duration, ipAddress, userAgent string, tx *sql.Tx) (*store.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshTokenWithTransaction", userID, duration, ipAddress, userAgent, tx)
+	ret0, _ := ret[0].(*store.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRefreshTokenWithTransaction indicates an expected call of CreateRefreshTokenWithTransaction.
+func (mr *MockRefreshTokenStoreMockRecorder) CreateRefreshTokenWithTransaction(userID, duration, ipAddress, userAgent, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshTokenWithTransaction", reflect.TypeOf((*MockRefreshTokenStore)(nil).CreateRefreshTokenWithTransaction), userID, duration, ipAddress, userAgent, tx)
+}
+
+// DeleteExpiredRefreshTokens mocks base method.
+func (m *MockRefreshTokenStore) DeleteExpiredRefreshTokens() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredRefreshTokens")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredRefreshTokens indicates an expected call of DeleteExpiredRefreshTokens.
+func (mr *MockRefreshTokenStoreMockRecorder) DeleteExpiredRefreshTokens() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredRefreshTokens", reflect.TypeOf((*MockRefreshTokenStore)(nil).DeleteExpiredRefreshTokens))
+}
+
+// GetRefreshToken mocks base method.
+func (m *MockRefreshTokenStore) GetRefreshToken(token string) (*store.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshToken", token)
+	ret0, _ := ret[0].(*store.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshToken indicates an expected call of GetRefreshToken.
+func (mr *MockRefreshTokenStoreMockRecorder) GetRefreshToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshToken", reflect.TypeOf((*MockRefreshTokenStore)(nil).GetRefreshToken), token)
+}
+
+// RevokeAllUserRefreshTokens mocks base method.
+func (m *MockRefreshTokenStore) RevokeAllUserRefreshTokens(userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllUserRefreshTokens", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllUserRefreshTokens indicates an expected call of RevokeAllUserRefreshTokens.
+func (mr *MockRefreshTokenStoreMockRecorder) RevokeAllUserRefreshTokens(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllUserRefreshTokens", reflect.TypeOf((*MockRefreshTokenStore)(nil).RevokeAllUserRefreshTokens), userID)
+}
+
+// RevokeAllUserRefreshTokensWithTransaction mocks base method.
+func (m *MockRefreshTokenStore) RevokeAllUserRefreshTokensWithTransaction(userID string, tx *sql.Tx) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllUserRefreshTokensWithTransaction", userID, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllUserRefreshTokensWithTransaction indicates an expected call of RevokeAllUserRefreshTokensWithTransaction.
+func (mr *MockRefreshTokenStoreMockRecorder) RevokeAllUserRefreshTokensWithTransaction(userID, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllUserRefreshTokensWithTransaction", reflect.TypeOf((*MockRefreshTokenStore)(nil).RevokeAllUserRefreshTokensWithTransaction), userID, tx)
+}
+
+// RevokeRefreshToken mocks base method.
+func (m *MockRefreshTokenStore) RevokeRefreshToken(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshToken", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken.
+func (mr *MockRefreshTokenStoreMockRecorder) RevokeRefreshToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockRefreshTokenStore)(nil).RevokeRefreshToken), token)
+}
+
+// RevokeRefreshTokenWithTransaction mocks base method.
+func (m *MockRefreshTokenStore) RevokeRefreshTokenWithTransaction(token string, tx *sql.Tx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokenWithTransaction", token, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokenWithTransaction indicates an expected call of RevokeRefreshTokenWithTransaction.
+func (mr *MockRefreshTokenStoreMockRecorder) RevokeRefreshTokenWithTransaction(token, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokenWithTransaction", reflect.TypeOf((*MockRefreshTokenStore)(nil).RevokeRefreshTokenWithTransaction), token, tx)
+}
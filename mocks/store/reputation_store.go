@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reputation_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reputation_store.go -destination=../mocks/store/reputation_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReputationStore is a mock of ReputationStore interface.
+type MockReputationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReputationStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockReputationStoreMockRecorder is the mock recorder for MockReputationStore.
+type MockReputationStoreMockRecorder struct {
+	mock *MockReputationStore
+}
+
+// NewMockReputationStore creates a new mock instance.
+func NewMockReputationStore(ctrl *gomock.Controller) *MockReputationStore {
+	mock := &MockReputationStore{ctrl: ctrl}
+	mock.recorder = &MockReputationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReputationStore) EXPECT() *MockReputationStoreMockRecorder {
+	return m.recorder
+}
+
+// AddFavorite mocks base method.
+func (m *MockReputationStore) AddFavorite(userID, recipeID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFavorite", userID, recipeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFavorite indicates an expected call of AddFavorite.
+func (mr *MockReputationStoreMockRecorder) AddFavorite(userID, recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavorite", reflect.TypeOf((*MockReputationStore)(nil).AddFavorite), userID, recipeID)
+}
+
+// AddReviewHelpfulVote mocks base method.
+func (m *MockReputationStore) AddReviewHelpfulVote(userID, reviewID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReviewHelpfulVote", userID, reviewID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReviewHelpfulVote indicates an expected call of AddReviewHelpfulVote.
+func (mr *MockReputationStoreMockRecorder) AddReviewHelpfulVote(userID, reviewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReviewHelpfulVote", reflect.TypeOf((*MockReputationStore)(nil).AddReviewHelpfulVote), userID, reviewID)
+}
+
+// GetLeaderboard mocks base method.
+func (m *MockReputationStore) GetLeaderboard(limit int) ([]*store.LeaderboardEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboard", limit)
+	ret0, _ := ret[0].([]*store.LeaderboardEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboard indicates an expected call of GetLeaderboard.
+func (mr *MockReputationStoreMockRecorder) GetLeaderboard(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboard", reflect.TypeOf((*MockReputationStore)(nil).GetLeaderboard), limit)
+}
+
+// GetReputationEvents mocks base method.
+func (m *MockReputationStore) GetReputationEvents(userID int64, limit int) ([]*store.ReputationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReputationEvents", userID, limit)
+	ret0, _ := ret[0].([]*store.ReputationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReputationEvents indicates an expected call of GetReputationEvents.
+func (mr *MockReputationStoreMockRecorder) GetReputationEvents(userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReputationEvents", reflect.TypeOf((*MockReputationStore)(nil).GetReputationEvents), userID, limit)
+}
+
+// GetUserReputation mocks base method.
+func (m *MockReputationStore) GetUserReputation(userID int64) (*store.UserReputation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReputation", userID)
+	ret0, _ := ret[0].(*store.UserReputation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReputation indicates an expected call of GetUserReputation.
+func (mr *MockReputationStoreMockRecorder) GetUserReputation(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReputation", reflect.TypeOf((*MockReputationStore)(nil).GetUserReputation), userID)
+}
+
+// RemoveFavorite mocks base method.
+func (m *MockReputationStore) RemoveFavorite(userID, recipeID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFavorite", userID, recipeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFavorite indicates an expected call of RemoveFavorite.
+func (mr *MockReputationStoreMockRecorder) RemoveFavorite(userID, recipeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavorite", reflect.TypeOf((*MockReputationStore)(nil).RemoveFavorite), userID, recipeID)
+}
+
+// RemoveReviewHelpfulVote mocks base method.
+func (m *MockReputationStore) RemoveReviewHelpfulVote(userID, reviewID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReviewHelpfulVote", userID, reviewID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReviewHelpfulVote indicates an expected call of RemoveReviewHelpfulVote.
+func (mr *MockReputationStoreMockRecorder) RemoveReviewHelpfulVote(userID, reviewID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReviewHelpfulVote", reflect.TypeOf((*MockReputationStore)(nil).RemoveReviewHelpfulVote), userID, reviewID)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: retention_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=retention_store.go -destination=../mocks/store/retention_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRetentionStore is a mock of RetentionStore interface.
+type MockRetentionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRetentionStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRetentionStoreMockRecorder is the mock recorder for MockRetentionStore.
+type MockRetentionStoreMockRecorder struct {
+	mock *MockRetentionStore
+}
+
+// NewMockRetentionStore creates a new mock instance.
+func NewMockRetentionStore(ctrl *gomock.Controller) *MockRetentionStore {
+	mock := &MockRetentionStore{ctrl: ctrl}
+	mock.recorder = &MockRetentionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRetentionStore) EXPECT() *MockRetentionStoreMockRecorder {
+	return m.recorder
+}
+
+// PurgeEmailSends mocks base method.
+func (m *MockRetentionStore) PurgeEmailSends(before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeEmailSends", before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeEmailSends indicates an expected call of PurgeEmailSends.
+func (mr *MockRetentionStoreMockRecorder) PurgeEmailSends(before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeEmailSends", reflect.TypeOf((*MockRetentionStore)(nil).PurgeEmailSends), before, limit)
+}
+
+// PurgeRecipeActivity mocks base method.
+func (m *MockRetentionStore) PurgeRecipeActivity(before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeRecipeActivity", before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeRecipeActivity indicates an expected call of PurgeRecipeActivity.
+func (mr *MockRetentionStoreMockRecorder) PurgeRecipeActivity(before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeRecipeActivity", reflect.TypeOf((*MockRetentionStore)(nil).PurgeRecipeActivity), before, limit)
+}
+
+// PurgeRecipeDailyViews mocks base method.
+func (m *MockRetentionStore) PurgeRecipeDailyViews(before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeRecipeDailyViews", before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeRecipeDailyViews indicates an expected call of PurgeRecipeDailyViews.
+func (mr *MockRetentionStoreMockRecorder) PurgeRecipeDailyViews(before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeRecipeDailyViews", reflect.TypeOf((*MockRetentionStore)(nil).PurgeRecipeDailyViews), before, limit)
+}
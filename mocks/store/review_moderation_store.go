@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: review_moderation_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=review_moderation_store.go -destination=../mocks/store/review_moderation_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReviewModerationStore is a mock of ReviewModerationStore interface.
+type MockReviewModerationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewModerationStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockReviewModerationStoreMockRecorder is the mock recorder for MockReviewModerationStore.
+type MockReviewModerationStoreMockRecorder struct {
+	mock *MockReviewModerationStore
+}
+
+// NewMockReviewModerationStore creates a new mock instance.
+func NewMockReviewModerationStore(ctrl *gomock.Controller) *MockReviewModerationStore {
+	mock := &MockReviewModerationStore{ctrl: ctrl}
+	mock.recorder = &MockReviewModerationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewModerationStore) EXPECT() *MockReviewModerationStoreMockRecorder {
+	return m.recorder
+}
+
+// ApplyReviewModerationBatch mocks base method.
+func (m *MockReviewModerationStore) ApplyReviewModerationBatch(job *store.ReviewModerationJob, afterID int64, limit int) (int, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyReviewModerationBatch", job, afterID, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ApplyReviewModerationBatch indicates an expected call of ApplyReviewModerationBatch.
+func (mr *MockReviewModerationStoreMockRecorder) ApplyReviewModerationBatch(job, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyReviewModerationBatch", reflect.TypeOf((*MockReviewModerationStore)(nil).ApplyReviewModerationBatch), job, afterID, limit)
+}
+
+// ClaimReviewModerationJob mocks base method.
+func (m *MockReviewModerationStore) ClaimReviewModerationJob(staleBefore time.Time) (*store.ReviewModerationJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReviewModerationJob", staleBefore)
+	ret0, _ := ret[0].(*store.ReviewModerationJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimReviewModerationJob indicates an expected call of ClaimReviewModerationJob.
+func (mr *MockReviewModerationStoreMockRecorder) ClaimReviewModerationJob(staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReviewModerationJob", reflect.TypeOf((*MockReviewModerationStore)(nil).ClaimReviewModerationJob), staleBefore)
+}
+
+// CompleteReviewModerationJob mocks base method.
+func (m *MockReviewModerationStore) CompleteReviewModerationJob(id int64, processed int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteReviewModerationJob", id, processed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteReviewModerationJob indicates an expected call of CompleteReviewModerationJob.
+func (mr *MockReviewModerationStoreMockRecorder) CompleteReviewModerationJob(id, processed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteReviewModerationJob", reflect.TypeOf((*MockReviewModerationStore)(nil).CompleteReviewModerationJob), id, processed)
+}
+
+// CountReviewModerationMatches mocks base method.
+func (m *MockReviewModerationStore) CountReviewModerationMatches(job *store.ReviewModerationJob) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReviewModerationMatches", job)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReviewModerationMatches indicates an expected call of CountReviewModerationMatches.
+func (mr *MockReviewModerationStoreMockRecorder) CountReviewModerationMatches(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReviewModerationMatches", reflect.TypeOf((*MockReviewModerationStore)(nil).CountReviewModerationMatches), job)
+}
+
+// CreateReviewModerationJob mocks base method.
+func (m *MockReviewModerationStore) CreateReviewModerationJob(job *store.ReviewModerationJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReviewModerationJob", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReviewModerationJob indicates an expected call of CreateReviewModerationJob.
+func (mr *MockReviewModerationStoreMockRecorder) CreateReviewModerationJob(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReviewModerationJob", reflect.TypeOf((*MockReviewModerationStore)(nil).CreateReviewModerationJob), job)
+}
+
+// FailReviewModerationJob mocks base method.
+func (m *MockReviewModerationStore) FailReviewModerationJob(id int64, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailReviewModerationJob", id, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailReviewModerationJob indicates an expected call of FailReviewModerationJob.
+func (mr *MockReviewModerationStoreMockRecorder) FailReviewModerationJob(id, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailReviewModerationJob", reflect.TypeOf((*MockReviewModerationStore)(nil).FailReviewModerationJob), id, message)
+}
+
+// GetReviewModerationJob mocks base method.
+func (m *MockReviewModerationStore) GetReviewModerationJob(id int64) (*store.ReviewModerationJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewModerationJob", id)
+	ret0, _ := ret[0].(*store.ReviewModerationJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewModerationJob indicates an expected call of GetReviewModerationJob.
+func (mr *MockReviewModerationStoreMockRecorder) GetReviewModerationJob(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewModerationJob", reflect.TypeOf((*MockReviewModerationStore)(nil).GetReviewModerationJob), id)
+}
+
+// GetReviewModerationJobs mocks base method.
+func (m *MockReviewModerationStore) GetReviewModerationJobs(page, limit int) ([]*store.ReviewModerationJob, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewModerationJobs", page, limit)
+	ret0, _ := ret[0].([]*store.ReviewModerationJob)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetReviewModerationJobs indicates an expected call of GetReviewModerationJobs.
+func (mr *MockReviewModerationStoreMockRecorder) GetReviewModerationJobs(page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewModerationJobs", reflect.TypeOf((*MockReviewModerationStore)(nil).GetReviewModerationJobs), page, limit)
+}
+
+// SetReviewModerationProgress mocks base method.
+func (m *MockReviewModerationStore) SetReviewModerationProgress(id int64, matched, processed int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReviewModerationProgress", id, matched, processed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReviewModerationProgress indicates an expected call of SetReviewModerationProgress.
+func (mr *MockReviewModerationStoreMockRecorder) SetReviewModerationProgress(id, matched, processed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReviewModerationProgress", reflect.TypeOf((*MockReviewModerationStore)(nil).SetReviewModerationProgress), id, matched, processed)
+}
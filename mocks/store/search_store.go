@@ -0,0 +1,206 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=search_store.go -destination=../mocks/store/search_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	context "context"
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSearchStore is a mock of SearchStore interface.
+type MockSearchStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchStoreMockRecorder is the mock recorder for MockSearchStore.
+type MockSearchStoreMockRecorder struct {
+	mock *MockSearchStore
+}
+
+// NewMockSearchStore creates a new mock instance.
+func NewMockSearchStore(ctrl *gomock.Controller) *MockSearchStore {
+	mock := &MockSearchStore{ctrl: ctrl}
+	mock.recorder = &MockSearchStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchStore) EXPECT() *MockSearchStoreMockRecorder {
+	return m.recorder
+}
+
+// ClaimSearchIndexVersion mocks base method.
+func (m *MockSearchStore) ClaimSearchIndexVersion(engine string, version int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimSearchIndexVersion", engine, version)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimSearchIndexVersion indicates an expected call of ClaimSearchIndexVersion.
+func (mr *MockSearchStoreMockRecorder) ClaimSearchIndexVersion(engine, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimSearchIndexVersion", reflect.TypeOf((*MockSearchStore)(nil).ClaimSearchIndexVersion), engine, version)
+}
+
+// DeleteSearchSyncs mocks base method.
+func (m *MockSearchStore) DeleteSearchSyncs(ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSearchSyncs", ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSearchSyncs indicates an expected call of DeleteSearchSyncs.
+func (mr *MockSearchStoreMockRecorder) DeleteSearchSyncs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSearchSyncs", reflect.TypeOf((*MockSearchStore)(nil).DeleteSearchSyncs), ids)
+}
+
+// GetCategoryNames mocks base method.
+func (m *MockSearchStore) GetCategoryNames(ctx context.Context, ids []int64) (map[int64]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryNames", ctx, ids)
+	ret0, _ := ret[0].(map[int64]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryNames indicates an expected call of GetCategoryNames.
+func (mr *MockSearchStoreMockRecorder) GetCategoryNames(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryNames", reflect.TypeOf((*MockSearchStore)(nil).GetCategoryNames), ctx, ids)
+}
+
+// GetPendingSearchSyncs mocks base method.
+func (m *MockSearchStore) GetPendingSearchSyncs(limit int) ([]*store.SearchSync, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingSearchSyncs", limit)
+	ret0, _ := ret[0].([]*store.SearchSync)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingSearchSyncs indicates an expected call of GetPendingSearchSyncs.
+func (mr *MockSearchStoreMockRecorder) GetPendingSearchSyncs(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingSearchSyncs", reflect.TypeOf((*MockSearchStore)(nil).GetPendingSearchSyncs), limit)
+}
+
+// GetRecipesByIDs mocks base method.
+func (m *MockSearchStore) GetRecipesByIDs(ctx context.Context, ids []int64) ([]*store.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipesByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*store.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipesByIDs indicates an expected call of GetRecipesByIDs.
+func (mr *MockSearchStoreMockRecorder) GetRecipesByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipesByIDs", reflect.TypeOf((*MockSearchStore)(nil).GetRecipesByIDs), ctx, ids)
+}
+
+// GetSearchDocuments mocks base method.
+func (m *MockSearchStore) GetSearchDocuments(recipeIDs []int64) ([]*store.SearchDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSearchDocuments", recipeIDs)
+	ret0, _ := ret[0].([]*store.SearchDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSearchDocuments indicates an expected call of GetSearchDocuments.
+func (mr *MockSearchStoreMockRecorder) GetSearchDocuments(recipeIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSearchDocuments", reflect.TypeOf((*MockSearchStore)(nil).GetSearchDocuments), recipeIDs)
+}
+
+// GetSearchDocumentsAfter mocks base method.
+func (m *MockSearchStore) GetSearchDocumentsAfter(afterID int64, limit int) ([]*store.SearchDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSearchDocumentsAfter", afterID, limit)
+	ret0, _ := ret[0].([]*store.SearchDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSearchDocumentsAfter indicates an expected call of GetSearchDocumentsAfter.
+func (mr *MockSearchStoreMockRecorder) GetSearchDocumentsAfter(afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSearchDocumentsAfter", reflect.TypeOf((*MockSearchStore)(nil).GetSearchDocumentsAfter), afterID, limit)
+}
+
+// ResetSearchIndexVersion mocks base method.
+func (m *MockSearchStore) ResetSearchIndexVersion(engine string, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetSearchIndexVersion", engine, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetSearchIndexVersion indicates an expected call of ResetSearchIndexVersion.
+func (mr *MockSearchStoreMockRecorder) ResetSearchIndexVersion(engine, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSearchIndexVersion", reflect.TypeOf((*MockSearchStore)(nil).ResetSearchIndexVersion), engine, version)
+}
+
+// SearchFacets mocks base method.
+func (m *MockSearchStore) SearchFacets(ctx context.Context, query string, opts store.RecipeListOptions) (*store.RecipeFacets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFacets", ctx, query, opts)
+	ret0, _ := ret[0].(*store.RecipeFacets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFacets indicates an expected call of SearchFacets.
+func (mr *MockSearchStoreMockRecorder) SearchFacets(ctx, query, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFacets", reflect.TypeOf((*MockSearchStore)(nil).SearchFacets), ctx, query, opts)
+}
+
+// SearchRecipes mocks base method.
+func (m *MockSearchStore) SearchRecipes(ctx context.Context, query string, opts store.RecipeListOptions) ([]*store.RecipeSearchResult, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchRecipes", ctx, query, opts)
+	ret0, _ := ret[0].([]*store.RecipeSearchResult)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchRecipes indicates an expected call of SearchRecipes.
+func (mr *MockSearchStoreMockRecorder) SearchRecipes(ctx, query, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRecipes", reflect.TypeOf((*MockSearchStore)(nil).SearchRecipes), ctx, query, opts)
+}
+
+// Suggest mocks base method.
+func (m *MockSearchStore) Suggest(query string, limit int) ([]*store.SearchSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", query, limit)
+	ret0, _ := ret[0].([]*store.SearchSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSearchStoreMockRecorder) Suggest(query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSearchStore)(nil).Suggest), query, limit)
+}
@@ -0,0 +1,244 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: shopping_list_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=shopping_list_store.go -destination=../mocks/store/shopping_list_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShoppingListStore is a mock of ShoppingListStore interface.
+type MockShoppingListStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockShoppingListStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockShoppingListStoreMockRecorder is the mock recorder for MockShoppingListStore.
+type MockShoppingListStoreMockRecorder struct {
+	mock *MockShoppingListStore
+}
+
+// NewMockShoppingListStore creates a new mock instance.
+func NewMockShoppingListStore(ctrl *gomock.Controller) *MockShoppingListStore {
+	mock := &MockShoppingListStore{ctrl: ctrl}
+	mock.recorder = &MockShoppingListStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShoppingListStore) EXPECT() *MockShoppingListStoreMockRecorder {
+	return m.recorder
+}
+
+// AddShoppingListItem mocks base method.
+func (m *MockShoppingListStore) AddShoppingListItem(item *store.ShoppingListItem, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddShoppingListItem", item, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddShoppingListItem indicates an expected call of AddShoppingListItem.
+func (mr *MockShoppingListStoreMockRecorder) AddShoppingListItem(item, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddShoppingListItem", reflect.TypeOf((*MockShoppingListStore)(nil).AddShoppingListItem), item, userID)
+}
+
+// AddShoppingListMember mocks base method.
+func (m *MockShoppingListStore) AddShoppingListMember(listID int64, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddShoppingListMember", listID, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddShoppingListMember indicates an expected call of AddShoppingListMember.
+func (mr *MockShoppingListStoreMockRecorder) AddShoppingListMember(listID, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddShoppingListMember", reflect.TypeOf((*MockShoppingListStore)(nil).AddShoppingListMember), listID, username)
+}
+
+// CreateShareToken mocks base method.
+func (m *MockShoppingListStore) CreateShareToken(listID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShareToken", listID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShareToken indicates an expected call of CreateShareToken.
+func (mr *MockShoppingListStoreMockRecorder) CreateShareToken(listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShareToken", reflect.TypeOf((*MockShoppingListStore)(nil).CreateShareToken), listID)
+}
+
+// CreateShoppingList mocks base method.
+func (m *MockShoppingListStore) CreateShoppingList(list *store.ShoppingList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShoppingList", list)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShoppingList indicates an expected call of CreateShoppingList.
+func (mr *MockShoppingListStoreMockRecorder) CreateShoppingList(list any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShoppingList", reflect.TypeOf((*MockShoppingListStore)(nil).CreateShoppingList), list)
+}
+
+// DeleteShoppingList mocks base method.
+func (m *MockShoppingListStore) DeleteShoppingList(listID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShoppingList", listID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShoppingList indicates an expected call of DeleteShoppingList.
+func (mr *MockShoppingListStoreMockRecorder) DeleteShoppingList(listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShoppingList", reflect.TypeOf((*MockShoppingListStore)(nil).DeleteShoppingList), listID)
+}
+
+// DeleteShoppingListItem mocks base method.
+func (m *MockShoppingListStore) DeleteShoppingListItem(listID, itemID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShoppingListItem", listID, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShoppingListItem indicates an expected call of DeleteShoppingListItem.
+func (mr *MockShoppingListStoreMockRecorder) DeleteShoppingListItem(listID, itemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShoppingListItem", reflect.TypeOf((*MockShoppingListStore)(nil).DeleteShoppingListItem), listID, itemID)
+}
+
+// GetShoppingList mocks base method.
+func (m *MockShoppingListStore) GetShoppingList(listID, userID int64) (*store.ShoppingList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShoppingList", listID, userID)
+	ret0, _ := ret[0].(*store.ShoppingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShoppingList indicates an expected call of GetShoppingList.
+func (mr *MockShoppingListStoreMockRecorder) GetShoppingList(listID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShoppingList", reflect.TypeOf((*MockShoppingListStore)(nil).GetShoppingList), listID, userID)
+}
+
+// GetShoppingListItems mocks base method.
+func (m *MockShoppingListStore) GetShoppingListItems(listID int64) ([]*store.ShoppingListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShoppingListItems", listID)
+	ret0, _ := ret[0].([]*store.ShoppingListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShoppingListItems indicates an expected call of GetShoppingListItems.
+func (mr *MockShoppingListStoreMockRecorder) GetShoppingListItems(listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShoppingListItems", reflect.TypeOf((*MockShoppingListStore)(nil).GetShoppingListItems), listID)
+}
+
+// GetShoppingListMembers mocks base method.
+func (m *MockShoppingListStore) GetShoppingListMembers(listID int64) ([]*store.ShoppingListMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShoppingListMembers", listID)
+	ret0, _ := ret[0].([]*store.ShoppingListMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShoppingListMembers indicates an expected call of GetShoppingListMembers.
+func (mr *MockShoppingListStoreMockRecorder) GetShoppingListMembers(listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShoppingListMembers", reflect.TypeOf((*MockShoppingListStore)(nil).GetShoppingListMembers), listID)
+}
+
+// GetShoppingListsForUser mocks base method.
+func (m *MockShoppingListStore) GetShoppingListsForUser(userID int64) ([]*store.ShoppingList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShoppingListsForUser", userID)
+	ret0, _ := ret[0].([]*store.ShoppingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShoppingListsForUser indicates an expected call of GetShoppingListsForUser.
+func (mr *MockShoppingListStoreMockRecorder) GetShoppingListsForUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShoppingListsForUser", reflect.TypeOf((*MockShoppingListStore)(nil).GetShoppingListsForUser), userID)
+}
+
+// JoinShoppingListByToken mocks base method.
+func (m *MockShoppingListStore) JoinShoppingListByToken(token string, userID int64) (*store.ShoppingList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinShoppingListByToken", token, userID)
+	ret0, _ := ret[0].(*store.ShoppingList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinShoppingListByToken indicates an expected call of JoinShoppingListByToken.
+func (mr *MockShoppingListStoreMockRecorder) JoinShoppingListByToken(token, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinShoppingListByToken", reflect.TypeOf((*MockShoppingListStore)(nil).JoinShoppingListByToken), token, userID)
+}
+
+// RemoveShoppingListMember mocks base method.
+func (m *MockShoppingListStore) RemoveShoppingListMember(listID int64, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveShoppingListMember", listID, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveShoppingListMember indicates an expected call of RemoveShoppingListMember.
+func (mr *MockShoppingListStoreMockRecorder) RemoveShoppingListMember(listID, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveShoppingListMember", reflect.TypeOf((*MockShoppingListStore)(nil).RemoveShoppingListMember), listID, username)
+}
+
+// RevokeShareToken mocks base method.
+func (m *MockShoppingListStore) RevokeShareToken(listID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShareToken", listID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShareToken indicates an expected call of RevokeShareToken.
+func (mr *MockShoppingListStoreMockRecorder) RevokeShareToken(listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShareToken", reflect.TypeOf((*MockShoppingListStore)(nil).RevokeShareToken), listID)
+}
+
+// SetShoppingListItemChecked mocks base method.
+func (m *MockShoppingListStore) SetShoppingListItemChecked(listID, itemID int64, checked bool, userID int64) (*store.ShoppingListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetShoppingListItemChecked", listID, itemID, checked, userID)
+	ret0, _ := ret[0].(*store.ShoppingListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetShoppingListItemChecked indicates an expected call of SetShoppingListItemChecked.
+func (mr *MockShoppingListStoreMockRecorder) SetShoppingListItemChecked(listID, itemID, checked, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShoppingListItemChecked", reflect.TypeOf((*MockShoppingListStore)(nil).SetShoppingListItemChecked), listID, itemID, checked, userID)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: token_blacklist_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=token_blacklist_store.go -destination=../mocks/store/token_blacklist_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTokenBlacklistStore is a mock of TokenBlacklistStore interface.
+type MockTokenBlacklistStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenBlacklistStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenBlacklistStoreMockRecorder is the mock recorder for MockTokenBlacklistStore.
+type MockTokenBlacklistStoreMockRecorder struct {
+	mock *MockTokenBlacklistStore
+}
+
+// NewMockTokenBlacklistStore creates a new mock instance.
+func NewMockTokenBlacklistStore(ctrl *gomock.Controller) *MockTokenBlacklistStore {
+	mock := &MockTokenBlacklistStore{ctrl: ctrl}
+	mock.recorder = &MockTokenBlacklistStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenBlacklistStore) EXPECT() *MockTokenBlacklistStoreMockRecorder {
+	return m.recorder
+}
+
+// BlacklistToken mocks base method.
+func (m *MockTokenBlacklistStore) BlacklistToken(tokenString string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlacklistToken", tokenString, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlacklistToken indicates an expected call of BlacklistToken.
+func (mr *MockTokenBlacklistStoreMockRecorder) BlacklistToken(tokenString, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlacklistToken", reflect.TypeOf((*MockTokenBlacklistStore)(nil).BlacklistToken), tokenString, expiresAt)
+}
+
+// CleanupExpiredTokens mocks base method.
+func (m *MockTokenBlacklistStore) CleanupExpiredTokens() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupExpiredTokens")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupExpiredTokens indicates an expected call of CleanupExpiredTokens.
+func (mr *MockTokenBlacklistStoreMockRecorder) CleanupExpiredTokens() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupExpiredTokens", reflect.TypeOf((*MockTokenBlacklistStore)(nil).CleanupExpiredTokens))
+}
+
+// IsBlacklisted mocks base method.
+func (m *MockTokenBlacklistStore) IsBlacklisted(tokenString string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlacklisted", tokenString)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlacklisted indicates an expected call of IsBlacklisted.
+func (mr *MockTokenBlacklistStoreMockRecorder) IsBlacklisted(tokenString any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlacklisted", reflect.TypeOf((*MockTokenBlacklistStore)(nil).IsBlacklisted), tokenString)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usage_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=usage_store.go -destination=../mocks/store/usage_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUsageStore is a mock of UsageStore interface.
+type MockUsageStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsageStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockUsageStoreMockRecorder is the mock recorder for MockUsageStore.
+type MockUsageStoreMockRecorder struct {
+	mock *MockUsageStore
+}
+
+// NewMockUsageStore creates a new mock instance.
+func NewMockUsageStore(ctrl *gomock.Controller) *MockUsageStore {
+	mock := &MockUsageStore{ctrl: ctrl}
+	mock.recorder = &MockUsageStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsageStore) EXPECT() *MockUsageStoreMockRecorder {
+	return m.recorder
+}
+
+// AddUsage mocks base method.
+func (m *MockUsageStore) AddUsage(increments []*store.UsageIncrement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUsage", increments)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUsage indicates an expected call of AddUsage.
+func (mr *MockUsageStoreMockRecorder) AddUsage(increments any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUsage", reflect.TypeOf((*MockUsageStore)(nil).AddUsage), increments)
+}
+
+// CountUsage mocks base method.
+func (m *MockUsageStore) CountUsage(userID, metric string, day time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsage", userID, metric, day)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsage indicates an expected call of CountUsage.
+func (mr *MockUsageStoreMockRecorder) CountUsage(userID, metric, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsage", reflect.TypeOf((*MockUsageStore)(nil).CountUsage), userID, metric, day)
+}
+
+// GetUsage mocks base method.
+func (m *MockUsageStore) GetUsage(userID int64, since time.Time) ([]*store.UsageRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", userID, since)
+	ret0, _ := ret[0].([]*store.UsageRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockUsageStoreMockRecorder) GetUsage(userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockUsageStore)(nil).GetUsage), userID, since)
+}
@@ -0,0 +1,349 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=user_store.go -destination=../mocks/store/user_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserStore is a mock of UserStore interface.
+type MockUserStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockUserStoreMockRecorder is the mock recorder for MockUserStore.
+type MockUserStoreMockRecorder struct {
+	mock *MockUserStore
+}
+
+// NewMockUserStore creates a new mock instance.
+func NewMockUserStore(ctrl *gomock.Controller) *MockUserStore {
+	mock := &MockUserStore{ctrl: ctrl}
+	mock.recorder = &MockUserStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserStore) EXPECT() *MockUserStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockUserStore) CreateUser(user *store.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUserStoreMockRecorder) CreateUser(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserStore)(nil).CreateUser), user)
+}
+
+// CreateUserWithTransaction mocks base method.
+func (m *MockUserStore) CreateUserWithTransaction(user *store.User, tx *sql.Tx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserWithTransaction", user, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserWithTransaction indicates an expected call of CreateUserWithTransaction.
+func (mr *MockUserStoreMockRecorder) CreateUserWithTransaction(user, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserWithTransaction", reflect.TypeOf((*MockUserStore)(nil).CreateUserWithTransaction), user, tx)
+}
+
+// DB mocks base method.
+func (m *MockUserStore) DB() *sql.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DB")
+	ret0, _ := ret[0].(*sql.DB)
+	return ret0
+}
+
+// DB indicates an expected call of DB.
+func (mr *MockUserStoreMockRecorder) DB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DB", reflect.TypeOf((*MockUserStore)(nil).DB))
+}
+
+// DisableUnverifiedUsers mocks base method.
+func (m *MockUserStore) DisableUnverifiedUsers(createdBefore time.Time, minWarnings int, warnedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUnverifiedUsers", createdBefore, minWarnings, warnedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableUnverifiedUsers indicates an expected call of DisableUnverifiedUsers.
+func (mr *MockUserStoreMockRecorder) DisableUnverifiedUsers(createdBefore, minWarnings, warnedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUnverifiedUsers", reflect.TypeOf((*MockUserStore)(nil).DisableUnverifiedUsers), createdBefore, minWarnings, warnedBefore)
+}
+
+// GetUnverifiedUsersToWarn mocks base method.
+func (m *MockUserStore) GetUnverifiedUsersToWarn(warning int, createdBefore time.Time, limit int) ([]*store.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnverifiedUsersToWarn", warning, createdBefore, limit)
+	ret0, _ := ret[0].([]*store.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnverifiedUsersToWarn indicates an expected call of GetUnverifiedUsersToWarn.
+func (mr *MockUserStoreMockRecorder) GetUnverifiedUsersToWarn(warning, createdBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnverifiedUsersToWarn", reflect.TypeOf((*MockUserStore)(nil).GetUnverifiedUsersToWarn), warning, createdBefore, limit)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockUserStore) GetUserByEmail(email string) (*store.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", email)
+	ret0, _ := ret[0].(*store.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockUserStoreMockRecorder) GetUserByEmail(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserStore)(nil).GetUserByEmail), email)
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserStore) GetUserByID(userID string) (*store.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", userID)
+	ret0, _ := ret[0].(*store.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserStoreMockRecorder) GetUserByID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserStore)(nil).GetUserByID), userID)
+}
+
+// GetUserInternalID mocks base method.
+func (m *MockUserStore) GetUserInternalID(userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInternalID", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserInternalID indicates an expected call of GetUserInternalID.
+func (mr *MockUserStoreMockRecorder) GetUserInternalID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInternalID", reflect.TypeOf((*MockUserStore)(nil).GetUserInternalID), userID)
+}
+
+// GetUserInternalIDByUsername mocks base method.
+func (m *MockUserStore) GetUserInternalIDByUsername(username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInternalIDByUsername", username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserInternalIDByUsername indicates an expected call of GetUserInternalIDByUsername.
+func (mr *MockUserStoreMockRecorder) GetUserInternalIDByUsername(username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInternalIDByUsername", reflect.TypeOf((*MockUserStore)(nil).GetUserInternalIDByUsername), username)
+}
+
+// GetUserPreferences mocks base method.
+func (m *MockUserStore) GetUserPreferences(userID string) (*store.UserPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPreferences", userID)
+	ret0, _ := ret[0].(*store.UserPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPreferences indicates an expected call of GetUserPreferences.
+func (mr *MockUserStoreMockRecorder) GetUserPreferences(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPreferences", reflect.TypeOf((*MockUserStore)(nil).GetUserPreferences), userID)
+}
+
+// GetUserRole mocks base method.
+func (m *MockUserStore) GetUserRole(userID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRole", userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRole indicates an expected call of GetUserRole.
+func (mr *MockUserStoreMockRecorder) GetUserRole(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRole", reflect.TypeOf((*MockUserStore)(nil).GetUserRole), userID)
+}
+
+// GetUserTimezone mocks base method.
+func (m *MockUserStore) GetUserTimezone(id int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTimezone", id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTimezone indicates an expected call of GetUserTimezone.
+func (mr *MockUserStoreMockRecorder) GetUserTimezone(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTimezone", reflect.TypeOf((*MockUserStore)(nil).GetUserTimezone), id)
+}
+
+// IsUsernameTaken mocks base method.
+func (m *MockUserStore) IsUsernameTaken(username, excludeUserID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUsernameTaken", username, excludeUserID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUsernameTaken indicates an expected call of IsUsernameTaken.
+func (mr *MockUserStoreMockRecorder) IsUsernameTaken(username, excludeUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUsernameTaken", reflect.TypeOf((*MockUserStore)(nil).IsUsernameTaken), username, excludeUserID)
+}
+
+// MarkUnverifiedWarningSent mocks base method.
+func (m *MockUserStore) MarkUnverifiedWarningSent(userID string, warning int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUnverifiedWarningSent", userID, warning)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUnverifiedWarningSent indicates an expected call of MarkUnverifiedWarningSent.
+func (mr *MockUserStoreMockRecorder) MarkUnverifiedWarningSent(userID, warning any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUnverifiedWarningSent", reflect.TypeOf((*MockUserStore)(nil).MarkUnverifiedWarningSent), userID, warning)
+}
+
+// PurgeDisabledUnverifiedUsers mocks base method.
+func (m *MockUserStore) PurgeDisabledUnverifiedUsers(disabledBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDisabledUnverifiedUsers", disabledBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDisabledUnverifiedUsers indicates an expected call of PurgeDisabledUnverifiedUsers.
+func (mr *MockUserStoreMockRecorder) PurgeDisabledUnverifiedUsers(disabledBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDisabledUnverifiedUsers", reflect.TypeOf((*MockUserStore)(nil).PurgeDisabledUnverifiedUsers), disabledBefore)
+}
+
+// SaveUserPreferences mocks base method.
+func (m *MockUserStore) SaveUserPreferences(userID string, prefs *store.UserPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserPreferences", userID, prefs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserPreferences indicates an expected call of SaveUserPreferences.
+func (mr *MockUserStoreMockRecorder) SaveUserPreferences(userID, prefs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserPreferences", reflect.TypeOf((*MockUserStore)(nil).SaveUserPreferences), userID, prefs)
+}
+
+// SetEmailVerified mocks base method.
+func (m *MockUserStore) SetEmailVerified(userID string, verified bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailVerified", userID, verified)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailVerified indicates an expected call of SetEmailVerified.
+func (mr *MockUserStoreMockRecorder) SetEmailVerified(userID, verified any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerified", reflect.TypeOf((*MockUserStore)(nil).SetEmailVerified), userID, verified)
+}
+
+// UpdateLastLogin mocks base method.
+func (m *MockUserStore) UpdateLastLogin(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastLogin", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastLogin indicates an expected call of UpdateLastLogin.
+func (mr *MockUserStoreMockRecorder) UpdateLastLogin(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastLogin", reflect.TypeOf((*MockUserStore)(nil).UpdateLastLogin), userID)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserStore) UpdatePassword(userID, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", userID, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserStoreMockRecorder) UpdatePassword(userID, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserStore)(nil).UpdatePassword), userID, newPassword)
+}
+
+// UpdatePasswordWithTransaction mocks base method.
+func (m *MockUserStore) UpdatePasswordWithTransaction(userID, newPassword string, tx *sql.Tx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasswordWithTransaction", userID, newPassword, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePasswordWithTransaction indicates an expected call of UpdatePasswordWithTransaction.
+func (mr *MockUserStoreMockRecorder) UpdatePasswordWithTransaction(userID, newPassword, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordWithTransaction", reflect.TypeOf((*MockUserStore)(nil).UpdatePasswordWithTransaction), userID, newPassword, tx)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUserStore) UpdateUser(userID string, updates map[string]any) (*store.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", userID, updates)
+	ret0, _ := ret[0].(*store.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserStoreMockRecorder) UpdateUser(userID, updates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserStore)(nil).UpdateUser), userID, updates)
+}
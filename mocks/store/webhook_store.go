@@ -0,0 +1,235 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook_store.go -destination=../mocks/store/webhook_store.go -package=mockstore
+//
+
+// Package mockstore is a generated GoMock package.
+package mockstore
+
+import (
+	reflect "reflect"
+	time "time"
+
+	store "github.com/dapoadedire/chefshare_be/store"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookStore is a mock of WebhookStore interface.
+type MockWebhookStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookStoreMockRecorder is the mock recorder for MockWebhookStore.
+type MockWebhookStoreMockRecorder struct {
+	mock *MockWebhookStore
+}
+
+// NewMockWebhookStore creates a new mock instance.
+func NewMockWebhookStore(ctrl *gomock.Controller) *MockWebhookStore {
+	mock := &MockWebhookStore{ctrl: ctrl}
+	mock.recorder = &MockWebhookStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookStore) EXPECT() *MockWebhookStoreMockRecorder {
+	return m.recorder
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockWebhookStore) ClaimWebhookDeliveries(limit int, lease time.Duration) ([]*store.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", limit, lease)
+	ret0, _ := ret[0].([]*store.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockWebhookStoreMockRecorder) ClaimWebhookDeliveries(limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockWebhookStore)(nil).ClaimWebhookDeliveries), limit, lease)
+}
+
+// CreateWebhookEndpoint mocks base method.
+func (m *MockWebhookStore) CreateWebhookEndpoint(endpoint *store.WebhookEndpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookEndpoint", endpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookEndpoint indicates an expected call of CreateWebhookEndpoint.
+func (mr *MockWebhookStoreMockRecorder) CreateWebhookEndpoint(endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookEndpoint", reflect.TypeOf((*MockWebhookStore)(nil).CreateWebhookEndpoint), endpoint)
+}
+
+// DeleteWebhookEndpoint mocks base method.
+func (m *MockWebhookStore) DeleteWebhookEndpoint(id, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookEndpoint", id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhookEndpoint indicates an expected call of DeleteWebhookEndpoint.
+func (mr *MockWebhookStoreMockRecorder) DeleteWebhookEndpoint(id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookEndpoint", reflect.TypeOf((*MockWebhookStore)(nil).DeleteWebhookEndpoint), id, userID)
+}
+
+// EnqueueWebhookDelivery mocks base method.
+func (m *MockWebhookStore) EnqueueWebhookDelivery(endpointID int64, eventType string, payload []byte, redeliveryOf *int64) (*store.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueWebhookDelivery", endpointID, eventType, payload, redeliveryOf)
+	ret0, _ := ret[0].(*store.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueWebhookDelivery indicates an expected call of EnqueueWebhookDelivery.
+func (mr *MockWebhookStoreMockRecorder) EnqueueWebhookDelivery(endpointID, eventType, payload, redeliveryOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueWebhookDelivery", reflect.TypeOf((*MockWebhookStore)(nil).EnqueueWebhookDelivery), endpointID, eventType, payload, redeliveryOf)
+}
+
+// EnqueueWebhookEvent mocks base method.
+func (m *MockWebhookStore) EnqueueWebhookEvent(userID int64, eventType string, payload []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueWebhookEvent", userID, eventType, payload)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueWebhookEvent indicates an expected call of EnqueueWebhookEvent.
+func (mr *MockWebhookStoreMockRecorder) EnqueueWebhookEvent(userID, eventType, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueWebhookEvent", reflect.TypeOf((*MockWebhookStore)(nil).EnqueueWebhookEvent), userID, eventType, payload)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockWebhookStore) GetWebhookDeliveries(endpointID int64, status string, page, limit int) ([]*store.WebhookDelivery, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", endpointID, status, page, limit)
+	ret0, _ := ret[0].([]*store.WebhookDelivery)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockWebhookStoreMockRecorder) GetWebhookDeliveries(endpointID, status, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockWebhookStore)(nil).GetWebhookDeliveries), endpointID, status, page, limit)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockWebhookStore) GetWebhookDelivery(id, endpointID int64) (*store.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", id, endpointID)
+	ret0, _ := ret[0].(*store.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockWebhookStoreMockRecorder) GetWebhookDelivery(id, endpointID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockWebhookStore)(nil).GetWebhookDelivery), id, endpointID)
+}
+
+// GetWebhookEndpoint mocks base method.
+func (m *MockWebhookStore) GetWebhookEndpoint(id, userID int64) (*store.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookEndpoint", id, userID)
+	ret0, _ := ret[0].(*store.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookEndpoint indicates an expected call of GetWebhookEndpoint.
+func (mr *MockWebhookStoreMockRecorder) GetWebhookEndpoint(id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookEndpoint", reflect.TypeOf((*MockWebhookStore)(nil).GetWebhookEndpoint), id, userID)
+}
+
+// GetWebhookEndpointByID mocks base method.
+func (m *MockWebhookStore) GetWebhookEndpointByID(id int64) (*store.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookEndpointByID", id)
+	ret0, _ := ret[0].(*store.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookEndpointByID indicates an expected call of GetWebhookEndpointByID.
+func (mr *MockWebhookStoreMockRecorder) GetWebhookEndpointByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookEndpointByID", reflect.TypeOf((*MockWebhookStore)(nil).GetWebhookEndpointByID), id)
+}
+
+// GetWebhookEndpoints mocks base method.
+func (m *MockWebhookStore) GetWebhookEndpoints(userID int64) ([]*store.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookEndpoints", userID)
+	ret0, _ := ret[0].([]*store.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookEndpoints indicates an expected call of GetWebhookEndpoints.
+func (mr *MockWebhookStoreMockRecorder) GetWebhookEndpoints(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookEndpoints", reflect.TypeOf((*MockWebhookStore)(nil).GetWebhookEndpoints), userID)
+}
+
+// RecordWebhookAttempt mocks base method.
+func (m *MockWebhookStore) RecordWebhookAttempt(attempt *store.WebhookAttempt) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWebhookAttempt", attempt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordWebhookAttempt indicates an expected call of RecordWebhookAttempt.
+func (mr *MockWebhookStoreMockRecorder) RecordWebhookAttempt(attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookAttempt", reflect.TypeOf((*MockWebhookStore)(nil).RecordWebhookAttempt), attempt)
+}
+
+// RotateWebhookSecret mocks base method.
+func (m *MockWebhookStore) RotateWebhookSecret(id, userID int64, secret string, previousExpiresAt time.Time) (*store.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateWebhookSecret", id, userID, secret, previousExpiresAt)
+	ret0, _ := ret[0].(*store.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateWebhookSecret indicates an expected call of RotateWebhookSecret.
+func (mr *MockWebhookStoreMockRecorder) RotateWebhookSecret(id, userID, secret, previousExpiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateWebhookSecret", reflect.TypeOf((*MockWebhookStore)(nil).RotateWebhookSecret), id, userID, secret, previousExpiresAt)
+}
+
+// UpdateWebhookEndpoint mocks base method.
+func (m *MockWebhookStore) UpdateWebhookEndpoint(endpoint *store.WebhookEndpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookEndpoint", endpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookEndpoint indicates an expected call of UpdateWebhookEndpoint.
+func (mr *MockWebhookStoreMockRecorder) UpdateWebhookEndpoint(endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookEndpoint", reflect.TypeOf((*MockWebhookStore)(nil).UpdateWebhookEndpoint), endpoint)
+}
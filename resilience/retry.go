@@ -0,0 +1,191 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Policy controls how often and how patiently a call is retried
+type Policy struct {
+	// MaxAttempts is the total number of calls, including the first; values below 1 mean 1
+	MaxAttempts int
+
+	// BaseDelay is the upper bound of the first backoff; it doubles after each attempt
+	BaseDelay time.Duration
+
+	// MaxDelay caps a single backoff, including one requested by a Retry-After header
+	MaxDelay time.Duration
+
+	// Idempotent reports whether repeating the call is harmless
+	// Non-idempotent calls are only retried when the request can't have been processed,
+	// such as a failed connection attempt or a 429/503 response
+	Idempotent bool
+}
+
+// DefaultPolicy returns three attempts with 200ms base and 5s maximum backoff
+func DefaultPolicy(idempotent bool) Policy {
+	return Policy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Idempotent:  idempotent,
+	}
+}
+
+// Do calls fn until it succeeds, returns an error that should not be retried, runs out of attempts,
+// or ctx is done. Backoff uses full jitter: a random delay between zero and the exponential bound.
+// The last error is returned.
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error) error {
+	attempts := policy.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if attempt == attempts-1 || !Retryable(err, policy.Idempotent) {
+			break
+		}
+
+		timer := time.NewTimer(policy.Backoff(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+
+	var permanent *permanentError
+	if errors.As(err, &permanent) {
+		return permanent.err
+	}
+	return err
+}
+
+// Backoff returns the delay before the attempt after the given zero-based attempt
+// err is the attempt's failure, whose Retry-After delay takes precedence; it may be nil.
+// Callers that schedule retries themselves, such as durable job queues, use it for the same jittered delays as Do.
+func (p Policy) Backoff(attempt int, err error) time.Duration {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		if p.MaxDelay > 0 && statusErr.RetryAfter > p.MaxDelay {
+			return p.MaxDelay
+		}
+		return statusErr.RetryAfter
+	}
+
+	bound := p.BaseDelay << attempt
+	if bound <= 0 || (p.MaxDelay > 0 && bound > p.MaxDelay) {
+		bound = p.MaxDelay
+	}
+	if bound <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(bound) + 1))
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying; Do returns the original error
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// StatusError is an unsuccessful HTTP response from an outbound call
+type StatusError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *StatusError) Error() string { return e.Err.Error() }
+func (e *StatusError) Unwrap() error { return e.Err }
+
+// NewStatusError wraps err with the response's status code and Retry-After delay
+func NewStatusError(resp *http.Response, err error) *StatusError {
+	if err == nil {
+		err = fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		Err:        err,
+	}
+}
+
+// Retryable reports whether a call that failed with err is worth repeating
+func Retryable(err error, idempotent bool) bool {
+	if err == nil {
+		return false
+	}
+
+	var permanent *permanentError
+	if errors.As(err, &permanent) {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			// The server refused the request without acting on it
+			return true
+		case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusInternalServerError,
+			http.StatusBadGateway, http.StatusGatewayTimeout:
+			return idempotent
+		default:
+			return false
+		}
+	}
+
+	// A connection that was never established can't have delivered the request
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+
+	// Anything else (resets, timeouts mid-request, unknown failures) may have reached the server
+	return idempotent
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
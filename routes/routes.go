@@ -5,6 +5,7 @@ import (
 
 	"github.com/dapoadedire/chefshare_be/app"
 	"github.com/dapoadedire/chefshare_be/middleware"
+	"github.com/dapoadedire/chefshare_be/services"
 	"github.com/gin-gonic/gin"
 )
 
@@ -14,6 +15,14 @@ func SetupRoutes(router *gin.Engine, app *app.Application) *gin.Engine {
 	router.Use(middleware.TokenBlacklistCleanupMiddleware(app.TokenBlacklistStore, 1*time.Hour))
 	setupRefreshTokenCleanup(router, app)
 
+	// Count authenticated requests towards each user's API usage
+	router.Use(middleware.MeterUsage(app.UsageService))
+
+	// Request counts and durations by route template, for per-endpoint dashboards
+	if app.HTTPMetrics != nil {
+		router.Use(middleware.RouteMetrics(app.HTTPMetrics))
+	}
+
 	// Root welcome route
 	// @Summary Welcome endpoint
 	// @Description Returns a welcome message with API version
@@ -28,6 +37,15 @@ func SetupRoutes(router *gin.Engine, app *app.Application) *gin.Engine {
 		})
 	})
 
+	// Request time limits, applied once per route group: Standard uses the read or write limit by method,
+	// Extended the long one. Both buffer the response until the handler finishes or first flushes.
+	timeouts := middleware.DefaultTimeoutConfig()
+
+	// Public reads of published content: signed-in callers pass through, anonymous ones are rate limited
+	// per IP and cached, or refused altogether when PUBLIC_READ_ACCESS=false
+	optionalAuth := middleware.OptionalJWTAuthMiddleware(app.JWTService)
+	publicRead := app.PublicAccess.Handler()
+
 	// Versioned API routes
 	v1 := router.Group("/api/v1")
 	{
@@ -38,7 +56,7 @@ func SetupRoutes(router *gin.Engine, app *app.Application) *gin.Engine {
 		// @Produce json
 		// @Success 200 {object} map[string]interface{} "API is healthy"
 		// @Router /api/v1/health [get]
-		v1.GET("/health", func(c *gin.Context) {
+		v1.GET("/health", timeouts.Standard(), func(c *gin.Context) {
 			// Check database connection status
 			dbStatus := "ok"
 			dbMessage := ""
@@ -63,10 +81,12 @@ func SetupRoutes(router *gin.Engine, app *app.Application) *gin.Engine {
 		})
 		// Public auth routes
 		auth := v1.Group("/auth")
+		auth.Use(timeouts.Standard())
 		{
 			auth.POST("/register", app.AuthHandler.RegisterUser)
 			auth.POST("/login", app.AuthHandler.LoginUser)
 			auth.POST("/token/refresh", app.AuthHandler.RefreshAccessToken)
+			auth.POST("/token/api-key", app.TokenHandler.ExchangeAPIKey)
 
 			// Email verification routes
 			verifyEmail := auth.Group("/verify-email")
@@ -83,23 +103,408 @@ func SetupRoutes(router *gin.Engine, app *app.Application) *gin.Engine {
 				password.POST("/confirm", app.AuthHandler.VerifyOTPAndResetPassword)
 				password.POST("/resend", app.AuthHandler.ResendOTP)
 			}
+
+			// Sign in with OAuth providers; the callback is registered separately as linking needs the signed-in user
+			oauth := auth.Group("/oauth")
+			{
+				oauth.GET("/providers", app.OAuthHandler.GetOAuthProviders)
+				oauth.POST("/:provider/start", app.OAuthHandler.StartOAuthLogin)
+				oauth.POST("/link/confirm", app.OAuthHandler.ConfirmIdentityLink)
+			}
 		}
 
 		// Protected auth routes
 		authProtected := v1.Group("/auth")
-		authProtected.Use(middleware.JWTAuthMiddleware(app.JWTService))
+		authProtected.Use(timeouts.Standard(), middleware.JWTAuthMiddleware(app.JWTService))
 		{
-			authProtected.GET("/me", app.AuthHandler.GetAuthenticatedUser)
+			authProtected.GET("/me", middleware.RequireScopes(services.ScopeProfileRead), app.AuthHandler.GetAuthenticatedUser)
 			authProtected.POST("/logout", app.AuthHandler.LogoutUser)
 			authProtected.POST("/verify-email/request", app.AuthHandler.RequestVerificationEmail)
 		}
 
+		v1.POST("/auth/oauth/:provider/callback", timeouts.Standard(), middleware.OptionalJWTAuthMiddleware(app.JWTService), app.OAuthHandler.OAuthCallback)
+
+		// Delivery events from the email provider, authenticated by their signature
+		if app.EmailSuppressionHandler != nil && app.EmailSuppressionHandler.ResendWebhookVerifier != nil {
+			v1.POST("/email-events/resend", timeouts.Standard(), app.EmailSuppressionHandler.HandleResendWebhook)
+		}
+
+		// Prometheus scrapes, authenticated by METRICS_TOKEN
+		if app.MetricsHandler != nil && app.MetricsHandler.MetricsToken != "" {
+			v1.GET("/metrics", timeouts.Standard(), app.MetricsHandler.GetRouteMetrics)
+		}
+
 		// Protected user profile routes
 		users := v1.Group("/users")
-		users.Use(middleware.JWTAuthMiddleware(app.JWTService))
+		users.Use(
+			timeouts.Standard(),
+			middleware.JWTAuthMiddleware(app.JWTService),
+			middleware.RequireReadWriteScopes(services.ScopeProfileRead, services.ScopeProfileWrite),
+		)
 		{
 			users.PUT("/me", app.UserHandler.UpdateUser)
 			users.PUT("/me/password", app.UserHandler.UpdatePassword)
+			users.GET("/me/identities", app.OAuthHandler.GetMyIdentities)
+			users.POST("/me/identities/:provider", app.OAuthHandler.StartIdentityLink)
+			users.DELETE("/me/identities/:provider", app.OAuthHandler.UnlinkIdentity)
+			users.GET("/me/onboarding", app.UserHandler.GetOnboarding)
+			users.POST("/me/onboarding", app.UserHandler.SaveOnboarding)
+			users.GET("/me/recommendations", app.RecipeHandler.GetRecommendations)
+			users.GET("/me/referrals", app.ReferralHandler.GetMyReferrals)
+			users.GET("/me/reputation", app.ReputationHandler.GetMyReputation)
+			users.GET("/me/usage", app.UsageHandler.GetMyUsage)
+			users.POST("/me/export", app.AccountExportHandler.RequestExport)
+			users.GET("/me/export/:id", app.AccountExportHandler.GetExport)
+
+			users.GET("/me/notifications", app.NotificationHandler.GetNotifications)
+			users.PUT("/me/notifications/read-all", app.NotificationHandler.MarkAllNotificationsRead)
+			users.PUT("/me/notifications/:id/read", app.NotificationHandler.MarkNotificationRead)
+			users.GET("/me/notification-preferences", app.NotificationHandler.GetNotificationPreferences)
+			users.PUT("/me/notification-preferences", app.NotificationHandler.UpdateNotificationPreferences)
+
+			users.GET("/me/blocks", app.BlockHandler.GetBlockedUsers)
+			users.POST("/me/blocks", app.BlockHandler.BlockUser)
+			users.DELETE("/me/blocks/:username", app.BlockHandler.UnblockUser)
+
+			users.GET("/me/chef-application", app.ChefApplicationHandler.GetMyVerification)
+			users.POST("/me/chef-application", app.ChefApplicationHandler.ApplyForVerification)
+
+			users.GET("/me/pantry", app.PantryHandler.GetPantry)
+			users.POST("/me/pantry", app.PantryHandler.AddPantryItem)
+			users.DELETE("/me/pantry/:ingredient_id", app.PantryHandler.RemovePantryItem)
+
+			users.GET("/me/cooked", app.RecipeCookHandler.GetMyCookingHistory)
+			users.GET("/me/cooked/stats", app.RecipeCookHandler.GetMyCookingStats)
+		}
+
+		// Protected user listings that can be downloaded as CSV, with the longer time limit for streaming the export
+		userExports := v1.Group("/users/me")
+		userExports.Use(
+			timeouts.Extended(),
+			middleware.JWTAuthMiddleware(app.JWTService),
+			middleware.RequireReadWriteScopes(services.ScopeProfileRead, services.ScopeProfileWrite),
+		)
+		{
+			userExports.GET("/recipes", app.RecipeHandler.GetMyRecipes)
+			userExports.GET("/favorites", app.ReputationHandler.GetMyFavorites)
+			userExports.GET("/stats/export", app.AccountExportHandler.ExportStats)
+		}
+
+		// API key management, only from a signed-in session so restricted tokens cannot mint new keys
+		apiKeys := v1.Group("/users/me/api-keys")
+		apiKeys.Use(timeouts.Standard(), middleware.JWTAuthMiddleware(app.JWTService), middleware.RequireUnrestrictedToken())
+		{
+			apiKeys.POST("", app.TokenHandler.CreateAPIKey)
+			apiKeys.GET("", app.TokenHandler.GetAPIKeys)
+			apiKeys.DELETE("/:id", app.TokenHandler.RevokeAPIKey)
+		}
+
+		// Webhook management, only from a signed-in session since endpoints receive events about all of a user's recipes
+		webhooks := v1.Group("/users/me/webhooks")
+		webhooks.Use(timeouts.Standard(), middleware.JWTAuthMiddleware(app.JWTService), middleware.RequireUnrestrictedToken())
+		{
+			webhooks.POST("", app.WebhookHandler.CreateWebhook)
+			webhooks.GET("", app.WebhookHandler.GetWebhooks)
+			webhooks.GET("/:id", app.WebhookHandler.GetWebhook)
+			webhooks.PATCH("/:id", app.WebhookHandler.UpdateWebhook)
+			webhooks.DELETE("/:id", app.WebhookHandler.DeleteWebhook)
+			webhooks.POST("/:id/rotate-secret", app.WebhookHandler.RotateWebhookSecret)
+			webhooks.POST("/:id/ping", app.WebhookHandler.PingWebhook)
+			webhooks.GET("/:id/deliveries", app.WebhookHandler.GetWebhookDeliveries)
+			webhooks.POST("/:id/deliveries/:delivery_id/redeliver", app.WebhookHandler.RedeliverWebhook)
+		}
+
+		// Public ingredient catalog routes
+		ingredients := v1.Group("/ingredients")
+		ingredients.Use(timeouts.Standard(), optionalAuth, publicRead)
+		{
+			ingredients.GET("/suggest", app.IngredientHandler.SuggestIngredients)
+		}
+
+		// Public unit conversion routes
+		units := v1.Group("/units")
+		units.Use(timeouts.Standard(), optionalAuth, publicRead)
+		{
+			units.GET("/convert", app.IngredientHandler.ConvertUnits)
+		}
+
+		// Public search routes
+		search := v1.Group("/search")
+		search.Use(timeouts.Standard(), optionalAuth, publicRead)
+		{
+			search.GET("/suggest", app.SearchHandler.Suggest)
+			search.GET("/recipes", app.SearchHandler.SearchRecipes)
+		}
+
+		// Locally stored files, served only through signed URLs
+		v1.GET("/media/*key", timeouts.Extended(), app.MediaHandler.ServeMedia)
+
+		// Public reputation leaderboard
+		v1.GET("/leaderboard", timeouts.Standard(), optionalAuth, publicRead, app.ReputationHandler.GetLeaderboard)
+
+		// Public recipe routes, with drafts visible to their signed-in author
+		publicRecipes := v1.Group("/recipes")
+		publicRecipes.Use(timeouts.Standard(), optionalAuth, publicRead)
+		{
+			publicRecipes.GET("/featured", app.FeaturedRecipeHandler.GetFeaturedRecipes)
+			publicRecipes.GET("/random", app.RecipeHandler.GetRandomRecipe)
+			publicRecipes.GET("/:id", app.RecipeHandler.GetRecipe)
+			publicRecipes.GET("/:id/print", app.RecipeHandler.PrintRecipe)
+			publicRecipes.GET("/:id/export", app.RecipeHandler.ExportRecipeFile)
+			publicRecipes.GET("/:id/photos", app.RecipeHandler.GetRecipePhotos)
+			publicRecipes.GET("/:id/reviews", app.RecipeHandler.GetRecipeReviews)
+			publicRecipes.GET("/:id/reviews/summary", app.RecipeHandler.GetRecipeReviewSummary)
+		}
+
+		// The recipe list can be downloaded as CSV, so it gets the longer timeout
+		v1.GET("/recipes", timeouts.Extended(), optionalAuth, publicRead, app.RecipeHandler.GetRecipes)
+
+		// Social share images are rendered on first request, so they get the longer timeout
+		v1.GET("/recipes/:id/og-image.png", timeouts.Extended(), optionalAuth, publicRead, app.ShareImageHandler.GetRecipeOGImage)
+
+		// Recipe cards for partner sites to iframe or render, limited to EMBED_ALLOWED_ORIGINS when set
+		v1.GET("/embed/recipes/:id", timeouts.Standard(), middleware.EmbedOrigins(), publicRead, app.EmbedHandler.GetRecipeEmbed)
+
+		// Read-only views of drafts for anyone holding a preview link
+		v1.GET("/previews/:token", timeouts.Standard(), app.RecipeHandler.GetRecipePreview)
+
+		// Rate limits and quotas for the caller, anonymous or signed in
+		v1.GET("/limits", timeouts.Standard(), middleware.OptionalJWTAuthMiddleware(app.JWTService), app.UsageHandler.GetLimits)
+
+		// Public listing of a user's published recipes
+		v1.GET("/users/:username/recipes", timeouts.Standard(), optionalAuth, publicRead, app.RecipeHandler.GetUserRecipes)
+
+		// Public category listing with recipe counts
+		v1.GET("/categories", timeouts.Standard(), optionalAuth, publicRead, app.RecipeHandler.GetCategories)
+
+		// Public popular tags, cached briefly
+		v1.GET("/tags/popular", timeouts.Standard(), optionalAuth, publicRead, app.TagHandler.GetPopularTags)
+
+		// Public recipe template routes
+		templates := v1.Group("/recipe-templates")
+		templates.Use(timeouts.Standard(), optionalAuth, publicRead)
+		{
+			templates.GET("", app.RecipeTemplateHandler.ListRecipeTemplates)
+			templates.GET("/:id", app.RecipeTemplateHandler.GetRecipeTemplate)
+		}
+
+		// Public curated collection routes
+		collections := v1.Group("/collections")
+		collections.Use(timeouts.Standard(), optionalAuth, publicRead)
+		{
+			collections.GET("", app.CollectionHandler.ListCollections)
+			collections.GET("/:slug", app.CollectionHandler.GetCollection)
+		}
+
+		// Protected recipe routes
+		recipes := v1.Group("/recipes")
+		recipes.Use(
+			timeouts.Standard(),
+			middleware.JWTAuthMiddleware(app.JWTService),
+			middleware.RequireReadWriteScopes(services.ScopeProfileRead, services.ScopeRecipesWrite),
+		)
+		{
+			recipes.POST("", app.RecipeHandler.CreateRecipe)
+			recipes.POST("/import-file", app.RecipeHandler.ImportRecipeFile)
+			recipes.POST("/from-template/:id", app.RecipeTemplateHandler.CreateRecipeFromTemplate)
+			recipes.PATCH("/:id", app.RecipeHandler.PatchRecipe)
+			recipes.DELETE("/:id", app.RecipeHandler.DeleteRecipe)
+			recipes.GET("/:id/activity", app.RecipeHandler.GetRecipeActivity)
+			recipes.POST("/:id/preview-links", app.RecipeHandler.CreateRecipePreviewLink)
+			recipes.GET("/:id/preview-links", app.RecipeHandler.GetRecipePreviewLinks)
+			recipes.DELETE("/:id/preview-links/:link_id", app.RecipeHandler.RevokeRecipePreviewLink)
+			recipes.POST("/:id/restore", app.RecipeHandler.RestoreRecipe)
+			recipes.POST("/:id/fork", app.RecipeHandler.ForkRecipe)
+			recipes.GET("/cookable", app.PantryHandler.GetCookableRecipes)
+			recipes.POST("/:id/favorite", app.ReputationHandler.FavoriteRecipe)
+			recipes.DELETE("/:id/favorite", app.ReputationHandler.UnfavoriteRecipe)
+			recipes.POST("/:id/steps", app.RecipeHandler.AddRecipeStep)
+			recipes.PUT("/:id/steps/order", app.RecipeHandler.ReorderRecipeSteps)
+			recipes.PUT("/:id/ingredients/order", app.RecipeHandler.ReorderRecipeIngredients)
+			recipes.PUT("/:id/dietary-labels", app.RecipeHandler.SetRecipeDietaryLabels)
+			recipes.PUT("/:id/allergens", app.RecipeHandler.SetRecipeAllergenOverrides)
+			recipes.PUT("/:id/tags", app.RecipeHandler.SetRecipeTags)
+
+			recipes.GET("/:id/note", app.RecipeNoteHandler.GetRecipeNote)
+			recipes.PUT("/:id/note", app.RecipeNoteHandler.SaveRecipeNote)
+			recipes.DELETE("/:id/note", app.RecipeNoteHandler.DeleteRecipeNote)
+
+			recipes.POST("/:id/made", app.RecipeCookHandler.RecordRecipeCook)
+			recipes.PUT("/:id/steps/:step_id", app.RecipeHandler.UpdateRecipeStep)
+		}
+
+		// Protected recipe photo routes, with a longer time limit for uploads
+		recipePhotos := v1.Group("/recipes/:id/photos")
+		recipePhotos.Use(
+			timeouts.Extended(),
+			middleware.JWTAuthMiddleware(app.JWTService),
+			middleware.RequireScopes(services.ScopeRecipesWrite),
+		)
+		{
+			recipePhotos.POST("", app.RecipeHandler.UploadRecipePhoto)
+			recipePhotos.PUT("/order", app.RecipeHandler.ReorderRecipePhotos)
+			recipePhotos.PUT("/:photo_id", app.RecipeHandler.ReplaceRecipePhoto)
+			recipePhotos.DELETE("/:photo_id", app.RecipeHandler.DeleteRecipePhoto)
+		}
+
+		// Protected review routes
+		reviews := v1.Group("/recipes/:id/reviews")
+		reviews.Use(timeouts.Standard(), middleware.JWTAuthMiddleware(app.JWTService), middleware.RequireScopes(services.ScopeReviewsWrite))
+		{
+			reviews.POST("", app.RecipeHandler.AddRecipeReview)
+			reviews.POST("/:review_id/helpful", app.ReputationHandler.MarkReviewHelpful)
+			reviews.DELETE("/:review_id/helpful", app.ReputationHandler.UnmarkReviewHelpful)
+			reviews.PUT("/:review_id/response", app.RecipeHandler.SetReviewAuthorResponse)
+			reviews.DELETE("/:review_id/response", app.RecipeHandler.DeleteReviewAuthorResponse)
+		}
+
+		// Protected invitation routes
+		invitations := v1.Group("/invitations")
+		invitations.Use(
+			timeouts.Standard(),
+			middleware.JWTAuthMiddleware(app.JWTService),
+			middleware.RequireReadWriteScopes(services.ScopeProfileRead, services.ScopeProfileWrite),
+		)
+		{
+			invitations.POST("", app.InvitationHandler.CreateInvitation)
+			invitations.GET("", app.InvitationHandler.GetInvitations)
+		}
+
+		// Protected direct message routes
+		conversations := v1.Group("/conversations")
+		conversations.Use(timeouts.Standard(), middleware.JWTAuthMiddleware(app.JWTService), middleware.RequireScopes(services.ScopeMessages))
+		{
+			conversations.POST("", app.MessageHandler.StartConversation)
+			conversations.GET("", app.MessageHandler.GetConversations)
+			conversations.GET("/:id/messages", app.MessageHandler.GetMessages)
+			conversations.POST("/:id/messages", app.MessageHandler.SendMessage)
+		}
+
+		// Protected shopping list routes, shared between the owner and members
+		shoppingLists := v1.Group("/shopping-lists")
+		shoppingLists.Use(timeouts.Standard(), middleware.JWTAuthMiddleware(app.JWTService), middleware.RequireScopes(services.ScopeShoppingLists))
+		{
+			shoppingLists.POST("", app.ShoppingListHandler.CreateShoppingList)
+			shoppingLists.GET("", app.ShoppingListHandler.GetShoppingLists)
+			shoppingLists.POST("/join/:token", app.ShoppingListHandler.JoinShoppingList)
+			shoppingLists.DELETE("/:id", app.ShoppingListHandler.DeleteShoppingList)
+
+			shoppingLists.POST("/:id/items", app.ShoppingListHandler.AddShoppingListItem)
+			shoppingLists.PUT("/:id/items/:item_id/checked", app.ShoppingListHandler.SetShoppingListItemChecked)
+			shoppingLists.DELETE("/:id/items/:item_id", app.ShoppingListHandler.DeleteShoppingListItem)
+
+			shoppingLists.POST("/:id/members", app.ShoppingListHandler.AddShoppingListMember)
+			shoppingLists.DELETE("/:id/members/:username", app.ShoppingListHandler.RemoveShoppingListMember)
+			shoppingLists.POST("/:id/share-link", app.ShoppingListHandler.CreateShareLink)
+			shoppingLists.DELETE("/:id/share-link", app.ShoppingListHandler.RevokeShareLink)
+		}
+
+		// A shopping list can be downloaded as CSV, so it gets the longer timeout
+		v1.GET("/shopping-lists/:id", timeouts.Extended(), middleware.JWTAuthMiddleware(app.JWTService), middleware.RequireScopes(services.ScopeShoppingLists), app.ShoppingListHandler.GetShoppingList)
+
+		// Calendar feed of a meal plan, for calendar apps holding the plan's subscription token or the owner's access token
+		v1.GET("/meal-plans/:id/ical", timeouts.Standard(), middleware.OptionalJWTAuthMiddleware(app.JWTService), app.MealPlanHandler.GetMealPlanICal)
+
+		// Protected meal plan routes
+		mealPlans := v1.Group("/meal-plans")
+		mealPlans.Use(
+			timeouts.Standard(),
+			middleware.JWTAuthMiddleware(app.JWTService),
+			middleware.RequireReadWriteScopes(services.ScopeProfileRead, services.ScopeProfileWrite),
+		)
+		{
+			mealPlans.POST("", app.MealPlanHandler.CreateMealPlan)
+			mealPlans.GET("", app.MealPlanHandler.GetMealPlans)
+			mealPlans.GET("/:id", app.MealPlanHandler.GetMealPlan)
+			mealPlans.DELETE("/:id", app.MealPlanHandler.DeleteMealPlan)
+
+			mealPlans.POST("/:id/entries", app.MealPlanHandler.AddMealPlanEntry)
+			mealPlans.DELETE("/:id/entries/:entry_id", app.MealPlanHandler.DeleteMealPlanEntry)
+			mealPlans.POST("/:id/ical-link", app.MealPlanHandler.CreateICalLink)
+			mealPlans.DELETE("/:id/ical-link", app.MealPlanHandler.RevokeICalLink)
+		}
+
+		// Admin content routes
+		admin := v1.Group("/admin")
+		admin.Use(
+			timeouts.Standard(),
+			middleware.JWTAuthMiddleware(app.JWTService),
+			middleware.RequireAdminMiddleware(app.UserStore),
+			middleware.RequireScopes(services.ScopeAdminContent),
+		)
+		{
+			admin.PUT("/ingredients/:id/price", app.IngredientHandler.SetIngredientPrice)
+			admin.GET("/ingredients/densities", app.IngredientHandler.GetDensityOverrides)
+			admin.PUT("/ingredients/:id/density", app.IngredientHandler.SetDensityOverride)
+			admin.DELETE("/ingredients/:id/density", app.IngredientHandler.DeleteDensityOverride)
+
+			admin.POST("/recipe-templates", app.RecipeTemplateHandler.CreateRecipeTemplate)
+			admin.PUT("/recipe-templates/:id", app.RecipeTemplateHandler.UpdateRecipeTemplate)
+			admin.DELETE("/recipe-templates/:id", app.RecipeTemplateHandler.DeleteRecipeTemplate)
+
+			admin.GET("/featured-recipes", app.FeaturedRecipeHandler.AdminGetFeaturedRecipes)
+			admin.PUT("/featured-recipes/:recipe_id", app.FeaturedRecipeHandler.FeatureRecipe)
+			admin.DELETE("/featured-recipes/:recipe_id", app.FeaturedRecipeHandler.UnfeatureRecipe)
+
+			admin.GET("/collections", app.CollectionHandler.AdminListCollections)
+			admin.POST("/collections", app.CollectionHandler.CreateCollection)
+			admin.GET("/collections/:id", app.CollectionHandler.AdminGetCollection)
+			admin.PUT("/collections/:id", app.CollectionHandler.UpdateCollection)
+			admin.DELETE("/collections/:id", app.CollectionHandler.DeleteCollection)
+			admin.PUT("/collections/:id/recipes", app.CollectionHandler.SetCollectionRecipes)
+
+			admin.GET("/metrics/daily", app.MetricsHandler.GetDailyMetrics)
+			admin.GET("/retention", app.MetricsHandler.GetRetentionPolicies)
+
+			admin.GET("/reviews/moderation-jobs", app.ReviewModerationHandler.ListReviewModerationJobs)
+			admin.POST("/reviews/moderation-jobs", app.ReviewModerationHandler.CreateReviewModerationJob)
+			admin.GET("/reviews/moderation-jobs/:id", app.ReviewModerationHandler.GetReviewModerationJob)
+		}
+
+		// Admin search maintenance, with a longer time limit for rebuilding the index
+		adminSearch := v1.Group("/admin/search")
+		adminSearch.Use(
+			timeouts.Extended(),
+			middleware.JWTAuthMiddleware(app.JWTService),
+			middleware.RequireAdminMiddleware(app.UserStore),
+			middleware.RequireScopes(services.ScopeAdminContent),
+		)
+		{
+			adminSearch.POST("/reindex", app.SearchHandler.ReindexSearch)
+		}
+
+		// Admin user management routes
+		adminUsers := v1.Group("/admin")
+		adminUsers.Use(
+			timeouts.Standard(),
+			middleware.JWTAuthMiddleware(app.JWTService),
+			middleware.RequireAdminMiddleware(app.UserStore),
+			middleware.RequireScopes(services.ScopeAdminUsers),
+		)
+		{
+			adminUsers.GET("/chef-applications", app.ChefApplicationHandler.ListChefApplications)
+			adminUsers.PUT("/chef-applications/:id", app.ChefApplicationHandler.ReviewChefApplication)
+			adminUsers.POST("/users/:user_id/impersonate", middleware.RequireUnrestrictedToken(), app.TokenHandler.ImpersonateUser)
+
+			adminUsers.GET("/email-suppressions", app.EmailSuppressionHandler.ListEmailSuppressions)
+			adminUsers.POST("/email-suppressions", app.EmailSuppressionHandler.AddEmailSuppression)
+			adminUsers.DELETE("/email-suppressions/:id", app.EmailSuppressionHandler.DeleteEmailSuppression)
+		}
+
+		// Emails captured in dry-run mode, for checking verification links and OTPs on non-production deployments
+		if app.EmailService != nil && app.EmailService.Sandbox() != nil {
+			devEmails := v1.Group("/dev/emails")
+			devEmails.Use(
+				timeouts.Standard(),
+				middleware.JWTAuthMiddleware(app.JWTService),
+				middleware.RequireAdminMiddleware(app.UserStore),
+				middleware.RequireScopes(services.ScopeAdminUsers),
+			)
+			{
+				devEmails.GET("", app.DevEmailHandler.ListDevEmails)
+				devEmails.GET("/:id", app.DevEmailHandler.GetDevEmail)
+				devEmails.DELETE("", app.DevEmailHandler.ClearDevEmails)
+			}
 		}
 	}
 
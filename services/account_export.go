@@ -0,0 +1,614 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+)
+
+// AccountExportConfig controls the background worker that builds account data exports
+type AccountExportConfig struct {
+	PollInterval time.Duration
+
+	// Retention is how long a finished archive can be downloaded before it is deleted from storage
+	Retention time.Duration
+
+	// LinkTTL is how long each download link stays valid; owners can poll for a fresh one until the archive expires
+	LinkTTL time.Duration
+
+	// StaleAfter is how long an export may stay running before another worker takes it over
+	StaleAfter time.Duration
+}
+
+// DefaultAccountExportConfig returns the account export configuration from the environment with sensible defaults
+func DefaultAccountExportConfig() AccountExportConfig {
+	return AccountExportConfig{
+		PollInterval: time.Duration(getEnvIntOrDefault("ACCOUNT_EXPORT_POLL_SECONDS", 5)) * time.Second,
+		Retention:    time.Duration(getEnvIntOrDefault("ACCOUNT_EXPORT_RETENTION_HOURS", 72)) * time.Hour,
+		LinkTTL:      time.Duration(getEnvIntOrDefault("ACCOUNT_EXPORT_LINK_TTL_SECONDS", 900)) * time.Second,
+		StaleAfter:   30 * time.Minute,
+	}
+}
+
+// accountExportRecipeBatch is how many of a user's recipes are read at a time while building their archive
+const accountExportRecipeBatch = 100
+
+// AccountExportService builds archives of everything a user has stored, and CSV files of their recipe statistics, outside of any request
+// Exports are queued in the database, so they survive restarts and are shared out between instances
+type AccountExportService struct {
+	config            AccountExportConfig
+	exportStore       store.AccountExportStore
+	storage           Storage
+	userStore         store.UserStore
+	recipeStore       store.RecipeStore
+	mediaStore        store.RecipeMediaStore
+	reviewStore       store.ReviewStore
+	cookStore         store.RecipeCookStore
+	pantryStore       store.PantryStore
+	shoppingListStore store.ShoppingListStore
+	statsStore        store.RecipeStatsStore
+
+	// wake asks the background loop to look for queued exports before the next poll
+	wake chan struct{}
+}
+
+// NewAccountExportService creates a new account export service
+func NewAccountExportService(
+	config AccountExportConfig,
+	exportStore store.AccountExportStore,
+	storage Storage,
+	userStore store.UserStore,
+	recipeStore store.RecipeStore,
+	mediaStore store.RecipeMediaStore,
+	reviewStore store.ReviewStore,
+	cookStore store.RecipeCookStore,
+	pantryStore store.PantryStore,
+	shoppingListStore store.ShoppingListStore,
+	statsStore store.RecipeStatsStore,
+) *AccountExportService {
+	return &AccountExportService{
+		config:            config,
+		exportStore:       exportStore,
+		storage:           storage,
+		userStore:         userStore,
+		recipeStore:       recipeStore,
+		mediaStore:        mediaStore,
+		reviewStore:       reviewStore,
+		cookStore:         cookStore,
+		pantryStore:       pantryStore,
+		shoppingListStore: shoppingListStore,
+		statsStore:        statsStore,
+		wake:              make(chan struct{}, 1),
+	}
+}
+
+// Start builds queued exports and deletes expired archives in the background every poll interval, or sooner when an export is requested
+func (s *AccountExportService) Start() {
+	go func() {
+		ticker := time.NewTicker(s.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			for {
+				ran, err := s.RunNext()
+				if err != nil {
+					log.Printf("Failed to run account export: %v", err)
+				}
+				if !ran {
+					break
+				}
+			}
+
+			if err := s.DeleteExpired(time.Now()); err != nil {
+				log.Printf("Failed to delete expired account exports: %v", err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-s.wake:
+			}
+		}
+	}()
+}
+
+// Request queues an export for a user and returns it, or returns the export they already have queued or running
+// The second return value reports whether a new export was queued
+func (s *AccountExportService) Request(userID int64) (*store.AccountExport, bool, error) {
+	return s.request(userID, store.AccountExportKindAccount, nil, nil)
+}
+
+// RequestStats queues a CSV of how a user's recipes performed between from and to, like Request
+// A statistics export already queued or running is returned even if it covers another period
+func (s *AccountExportService) RequestStats(userID int64, from, to time.Time) (*store.AccountExport, bool, error) {
+	return s.request(userID, store.AccountExportKindStats, &from, &to)
+}
+
+func (s *AccountExportService) request(userID int64, kind string, from, to *time.Time) (*store.AccountExport, bool, error) {
+	pending, err := s.exportStore.GetPendingAccountExport(userID, kind)
+	if err != nil {
+		return nil, false, err
+	}
+	if pending != nil {
+		return pending, false, nil
+	}
+
+	export, err := s.exportStore.CreateAccountExport(userID, kind, from, to)
+	if err != nil {
+		return nil, false, err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return export, true, nil
+}
+
+// Get returns one of a user's exports, with a short-lived download link while its archive is available
+// Returns nil if the export does not exist or belongs to someone else
+func (s *AccountExportService) Get(id int64, userID int64) (*store.AccountExport, error) {
+	export, err := s.exportStore.GetAccountExport(id, userID)
+	if err != nil || export == nil {
+		return export, err
+	}
+
+	if export.StorageKey == nil || export.ExpiresAt == nil || !time.Now().Before(*export.ExpiresAt) {
+		return export, nil
+	}
+
+	ttl := min(s.config.LinkTTL, time.Until(*export.ExpiresAt))
+	downloadURL, err := s.storage.SignedURL(*export.StorageKey, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign account export download: %w", err)
+	}
+	downloadExpiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	export.DownloadURL = &downloadURL
+	export.DownloadExpiresAt = &downloadExpiresAt
+
+	return export, nil
+}
+
+// RunNext builds the oldest queued export, reporting whether there was one
+// A failed build is recorded on the export so its owner can see it and request another
+func (s *AccountExportService) RunNext() (bool, error) {
+	export, err := s.exportStore.ClaimAccountExport(time.Now().Add(-s.config.StaleAfter))
+	if err != nil {
+		return false, err
+	}
+	if export == nil {
+		return false, nil
+	}
+
+	var key string
+	var size int64
+	if export.Kind == store.AccountExportKindStats {
+		key = fmt.Sprintf("exports/%s/%d.csv", export.UserPublicID, export.ID)
+		size, err = s.buildStats(export, key)
+	} else {
+		key = fmt.Sprintf("exports/%s/%d.zip", export.UserPublicID, export.ID)
+		size, err = s.build(export, key)
+	}
+	if err != nil {
+		log.Printf("Failed to build account export %d: %v", export.ID, err)
+		if failErr := s.exportStore.FailAccountExport(export.ID, "the export could not be completed; please request a new one"); failErr != nil {
+			return true, failErr
+		}
+		return true, nil
+	}
+
+	if err := s.exportStore.CompleteAccountExport(export.ID, key, size, time.Now().Add(s.config.Retention)); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// DeleteExpired removes archives past their expiry from storage
+func (s *AccountExportService) DeleteExpired(now time.Time) error {
+	for {
+		exports, err := s.exportStore.GetExpiredAccountExports(now, 100)
+		if err != nil {
+			return err
+		}
+
+		for _, export := range exports {
+			if err := s.storage.Delete(*export.StorageKey); err != nil {
+				return err
+			}
+			if err := s.exportStore.ClearAccountExportArchive(export.ID); err != nil {
+				return err
+			}
+		}
+
+		if len(exports) < 100 {
+			return nil
+		}
+	}
+}
+
+// exportProgress reports a running export's progress as units of work finish, only writing when the percentage changes
+type exportProgress struct {
+	exportStore store.AccountExportStore
+	id          int64
+	total       int
+	done        int
+	percent     int
+}
+
+func (p *exportProgress) step() {
+	p.done++
+	// 100 is only reported once the archive is stored
+	percent := min(p.done*100/p.total, 99)
+	if percent == p.percent {
+		return
+	}
+	p.percent = percent
+	if err := p.exportStore.SetAccountExportProgress(p.id, percent); err != nil {
+		log.Printf("Failed to update account export progress: %v", err)
+	}
+}
+
+// build writes a user's data into a zip archive in storage under key and returns its size
+func (s *AccountExportService) build(export *store.AccountExport, key string) (int64, error) {
+	user, err := s.userStore.GetUserByID(export.UserPublicID)
+	if err != nil {
+		return 0, err
+	}
+	if user == nil {
+		return 0, errors.New("user not found")
+	}
+
+	recipeCount, err := s.recipeStore.CountRecipesByUserID(export.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	// One unit for each recipe plus one for every other file
+	progress := &exportProgress{exportStore: s.exportStore, id: export.ID, total: recipeCount + 5}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	preferences, err := s.userStore.GetUserPreferences(user.UserID)
+	if err != nil {
+		return 0, err
+	}
+	profile := map[string]any{"user": user, "preferences": preferences}
+	if err := writeArchiveJSON(archive, "profile.json", profile); err != nil {
+		return 0, err
+	}
+	progress.step()
+
+	// A zip archive holds one open file at a time, so photos are copied before recipes.json is streamed
+	photos, err := s.writeArchivePhotos(archive, export.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.writeArchiveRecipes(archive, export.UserID, photos, progress); err != nil {
+		return 0, err
+	}
+
+	reviews, err := s.reviewStore.GetReviewsByUserID(export.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if err := writeArchiveJSON(archive, "reviews.json", reviews); err != nil {
+		return 0, err
+	}
+	progress.step()
+
+	cooks := []*store.RecipeCook{}
+	for page := 1; ; page++ {
+		batch, total, err := s.cookStore.GetUserCooks(export.UserID, page, 100)
+		if err != nil {
+			return 0, err
+		}
+		cooks = append(cooks, batch...)
+		if len(batch) == 0 || len(cooks) >= total {
+			break
+		}
+	}
+	if err := writeArchiveJSON(archive, "cooking_history.json", cooks); err != nil {
+		return 0, err
+	}
+	progress.step()
+
+	pantry, err := s.pantryStore.GetPantryItems(export.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if err := writeArchiveJSON(archive, "pantry.json", pantry); err != nil {
+		return 0, err
+	}
+	progress.step()
+
+	lists, err := s.shoppingListStore.GetShoppingListsForUser(export.UserID)
+	if err != nil {
+		return 0, err
+	}
+	shoppingLists := make([]map[string]any, 0, len(lists))
+	for _, list := range lists {
+		items, err := s.shoppingListStore.GetShoppingListItems(list.ID)
+		if err != nil {
+			return 0, err
+		}
+		shoppingLists = append(shoppingLists, map[string]any{"list": list, "items": items})
+	}
+	if err := writeArchiveJSON(archive, "shopping_lists.json", shoppingLists); err != nil {
+		return 0, err
+	}
+	progress.step()
+
+	if err := archive.Close(); err != nil {
+		return 0, fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	size := int64(buf.Len())
+	if err := s.storage.Put(key, &buf, size, "application/zip"); err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// RecipeStatsCSVHeader lists the columns written by RecipeStatsCSVRecord
+var RecipeStatsCSVHeader = []string{
+	"recipe_id", "title", "status", "published_at", "period_from", "period_to",
+	"views", "favorites", "reviews", "average_rating",
+	"total_favorites", "total_reviews", "overall_average_rating",
+}
+
+// RecipeStatsCSVRecord converts a recipe's statistics over the period from to to a CSV row
+// Values are not neutralized against spreadsheet formulas; see utils.CSVCell
+func RecipeStatsCSVRecord(stats *store.RecipeStats, from, to time.Time) []string {
+	publishedAt := ""
+	if stats.PublishedAt != nil {
+		publishedAt = stats.PublishedAt.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		strconv.FormatInt(stats.RecipeID, 10),
+		stats.Title,
+		string(stats.Status),
+		publishedAt,
+		from.UTC().Format(time.DateOnly),
+		to.UTC().Format(time.DateOnly),
+		strconv.Itoa(stats.Views),
+		strconv.Itoa(stats.Favorites),
+		strconv.Itoa(stats.Reviews),
+		statsRating(stats.AverageRating),
+		strconv.Itoa(stats.TotalFavorites),
+		strconv.Itoa(stats.TotalReviews),
+		statsRating(stats.OverallAverageRating),
+	}
+}
+
+// statsRating formats an average rating to two decimals, or as empty if there were no reviews
+func statsRating(rating *float64) string {
+	if rating == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*rating, 'f', 2, 64)
+}
+
+// buildStats writes a CSV of the statistics of each of a user's recipes over the export's period to storage under key and returns its size
+func (s *AccountExportService) buildStats(export *store.AccountExport, key string) (int64, error) {
+	if export.PeriodFrom == nil || export.PeriodTo == nil {
+		return 0, errors.New("statistics export has no period")
+	}
+	from, to := *export.PeriodFrom, *export.PeriodTo
+
+	total, err := s.statsStore.CountUserRecipes(export.UserID)
+	if err != nil {
+		return 0, err
+	}
+	progress := &exportProgress{exportStore: s.exportStore, id: export.ID, total: max(total, 1)}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(RecipeStatsCSVHeader); err != nil {
+		return 0, fmt.Errorf("failed to write statistics: %w", err)
+	}
+
+	err = s.statsStore.EachRecipeStats(export.UserID, from, to, func(stats *store.RecipeStats) error {
+		record := RecipeStatsCSVRecord(stats, from, to)
+		for i, value := range record {
+			record[i] = utils.CSVCell(value)
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write statistics: %w", err)
+		}
+		progress.step()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return 0, fmt.Errorf("failed to write statistics: %w", err)
+	}
+
+	size := int64(buf.Len())
+	if err := s.storage.Put(key, &buf, size, "text/csv"); err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// eachUserRecipe calls fn with each of a user's recipes, reading them in batches of accountExportRecipeBatch
+func (s *AccountExportService) eachUserRecipe(userID int64, fn func(*store.Recipe) error) error {
+	var afterID int64
+	for {
+		recipes, err := s.recipeStore.GetRecipesByUserID(userID, afterID, accountExportRecipeBatch)
+		if err != nil {
+			return err
+		}
+		if len(recipes) == 0 {
+			return nil
+		}
+		afterID = recipes[len(recipes)-1].ID
+
+		for _, recipe := range recipes {
+			if err := fn(recipe); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeArchivePhotos copies the uploaded photos of a user's recipes into the archive
+// It returns the archive name of each copied photo by storage key
+func (s *AccountExportService) writeArchivePhotos(archive *zip.Writer, userID int64) (map[string]string, error) {
+	names := map[string]string{}
+	err := s.eachUserRecipe(userID, func(recipe *store.Recipe) error {
+		photos, err := s.mediaStore.GetRecipePhotos(recipe.ID)
+		if err != nil {
+			return err
+		}
+		for _, photo := range photos {
+			if photo.StorageKey == nil {
+				continue
+			}
+			if _, ok := names[*photo.StorageKey]; ok {
+				continue
+			}
+			name, err := s.writeArchivePhoto(archive, *photo.StorageKey)
+			if err != nil {
+				return err
+			}
+			if name != "" {
+				names[*photo.StorageKey] = name
+			}
+		}
+		return nil
+	})
+	return names, err
+}
+
+// writeArchiveRecipes streams a user's complete recipes into the archive as recipes.json, one recipe at a time
+// Photos copied into the archive point at their copy instead of the storage URL
+func (s *AccountExportService) writeArchiveRecipes(archive *zip.Writer, userID int64, photos map[string]string, progress *exportProgress) error {
+	w, err := archive.Create("recipes.json")
+	if err != nil {
+		return fmt.Errorf("failed to add recipes.json to archive: %w", err)
+	}
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return fmt.Errorf("failed to add recipes.json to archive: %w", err)
+	}
+
+	// Each recipe is encoded on its own, indented to sit inside the array
+	var entry bytes.Buffer
+	encoder := json.NewEncoder(&entry)
+	encoder.SetIndent("  ", "  ")
+
+	written := 0
+	err = s.eachUserRecipe(userID, func(recipe *store.Recipe) error {
+		defer progress.step()
+
+		full, err := s.recipeStore.GetCompleteRecipe(recipe.ID)
+		if err != nil || full == nil {
+			return err
+		}
+
+		// Other people's reviews are theirs to export; the user's own are in reviews.json
+		full.Reviews = nil
+		for _, photo := range full.Photos {
+			if photo.StorageKey == nil {
+				continue
+			}
+			if name, ok := photos[*photo.StorageKey]; ok {
+				photo.PhotoURL = name
+			}
+			photo.Variants = nil
+		}
+
+		entry.Reset()
+		if err := encoder.Encode(full); err != nil {
+			return err
+		}
+		separator := ",\n  "
+		if written == 0 {
+			separator = "\n  "
+		}
+		if _, err := io.WriteString(w, separator); err != nil {
+			return err
+		}
+		written++
+		_, err = w.Write(bytes.TrimSuffix(entry.Bytes(), []byte("\n")))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add recipes.json to archive: %w", err)
+	}
+
+	closing := "\n]\n"
+	if written == 0 {
+		closing = "]\n"
+	}
+	if _, err := io.WriteString(w, closing); err != nil {
+		return fmt.Errorf("failed to add recipes.json to archive: %w", err)
+	}
+
+	return nil
+}
+
+// writeArchivePhoto copies an uploaded photo into the archive and returns its name there
+// It returns an empty name if the photo is missing or the storage backend cannot read files back
+func (s *AccountExportService) writeArchivePhoto(archive *zip.Writer, storageKey string) (string, error) {
+	reader, ok := s.storage.(StorageReader)
+	if !ok {
+		return "", nil
+	}
+
+	file, err := reader.Open(storageKey)
+	if errors.Is(err, ErrStorageNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	name := path.Join("photos", storageKey)
+	w, err := archive.Create(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	if _, err := io.Copy(w, file); err != nil {
+		return "", fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+
+	return name, nil
+}
+
+// writeArchiveJSON adds value to the archive as an indented JSON file
+func writeArchiveJSON(archive *zip.Writer, name string, value any) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// refreshTokenCookiePath limits the refresh token cookie to the auth routes that use it
+const refreshTokenCookiePath = "/api/v1/auth"
+
+// parseSameSite reads an AUTH_COOKIE_SAMESITE value, falling back to Lax for anything unknown
+func parseSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	case "lax":
+		return http.SameSiteLaxMode
+	default:
+		log.Printf("Ignoring invalid AUTH_COOKIE_SAMESITE %q, using lax", value)
+		return http.SameSiteLaxMode
+	}
+}
+
+// CookieMode reports whether sign-in tokens are sent as cookies, see JWTConfig.CookieMode
+func (s *JWTService) CookieMode() bool {
+	return s.config.CookieMode
+}
+
+// SetSessionCookies sets a sign-in's tokens as HttpOnly cookies, along with a new CSRF token that the
+// frontend can read and must send back in the CSRF header; it returns the CSRF token
+func (s *JWTService) SetSessionCookies(w http.ResponseWriter, accessToken, refreshToken string) string {
+	csrfToken := rand.Text()
+
+	http.SetCookie(w, s.cookie(s.config.AccessTokenCookieName, accessToken, "/", int(s.config.AccessTokenDuration.Seconds()), true))
+	http.SetCookie(w, s.cookie(s.config.RefreshTokenCookieName, refreshToken, refreshTokenCookiePath, int(s.config.RefreshTokenDuration.Seconds()), true))
+	http.SetCookie(w, s.cookie(s.config.CSRFCookieName, csrfToken, "/", int(s.config.RefreshTokenDuration.Seconds()), false))
+
+	return csrfToken
+}
+
+// ClearSessionCookies expires the cookies set by SetSessionCookies
+func (s *JWTService) ClearSessionCookies(w http.ResponseWriter) {
+	http.SetCookie(w, s.cookie(s.config.AccessTokenCookieName, "", "/", -1, true))
+	http.SetCookie(w, s.cookie(s.config.RefreshTokenCookieName, "", refreshTokenCookiePath, -1, true))
+	http.SetCookie(w, s.cookie(s.config.CSRFCookieName, "", "/", -1, false))
+}
+
+// AccessTokenCookie returns the access token cookie sent with a request, or "" if there is none
+func (s *JWTService) AccessTokenCookie(r *http.Request) string {
+	return cookieValue(r, s.config.AccessTokenCookieName)
+}
+
+// RefreshTokenCookie returns the refresh token cookie sent with a request, or "" if there is none
+func (s *JWTService) RefreshTokenCookie(r *http.Request) string {
+	return cookieValue(r, s.config.RefreshTokenCookieName)
+}
+
+// ValidCSRF reports whether a request authenticated by cookie may go ahead
+// GET, HEAD and OPTIONS requests always may; others must send the CSRF cookie's value in the CSRF header,
+// which another site can neither read nor set.
+func (s *JWTService) ValidCSRF(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	cookie := cookieValue(r, s.config.CSRFCookieName)
+	header := r.Header.Get(s.config.CSRFHeaderName)
+	return cookie != "" && subtle.ConstantTimeCompare([]byte(cookie), []byte(header)) == 1
+}
+
+// cookie builds one of the session cookies; maxAge below zero deletes it
+func (s *JWTService) cookie(name, value, path string, maxAge int, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   s.config.CookieDomain,
+		MaxAge:   maxAge,
+		HttpOnly: httpOnly,
+		// Browsers drop SameSite=None cookies that are not also Secure
+		Secure:   s.config.CookieSecure || s.config.CookieSameSite == http.SameSiteNoneMode,
+		SameSite: s.config.CookieSameSite,
+	}
+}
+
+func cookieValue(r *http.Request, name string) string {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// glyphWidth and glyphHeight are the size of a bitmap font glyph in font pixels
+	glyphWidth  = 5
+	glyphHeight = 7
+
+	// glyphAdvance is the horizontal distance between glyphs, including one pixel of spacing
+	glyphAdvance = glyphWidth + 1
+
+	// starRune is drawn with its own glyph for ratings
+	starRune = '★'
+)
+
+// bitmapFont is a 5x7 font covering printable ASCII and a star
+// Each glyph lists its rows from top to bottom; bit 4 is the leftmost pixel.
+var bitmapFont = map[rune][glyphHeight]uint8{
+	' ':      {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+	'!':      {0x04, 0x04, 0x04, 0x04, 0x04, 0x00, 0x04},
+	'"':      {0x0A, 0x0A, 0x00, 0x00, 0x00, 0x00, 0x00},
+	'#':      {0x0A, 0x0A, 0x1F, 0x0A, 0x1F, 0x0A, 0x0A},
+	'$':      {0x04, 0x0F, 0x14, 0x0E, 0x05, 0x1E, 0x04},
+	'%':      {0x18, 0x19, 0x02, 0x04, 0x08, 0x13, 0x03},
+	'&':      {0x0C, 0x12, 0x14, 0x08, 0x15, 0x12, 0x0D},
+	'\'':     {0x04, 0x04, 0x08, 0x00, 0x00, 0x00, 0x00},
+	'(':      {0x02, 0x04, 0x08, 0x08, 0x08, 0x04, 0x02},
+	')':      {0x08, 0x04, 0x02, 0x02, 0x02, 0x04, 0x08},
+	'*':      {0x00, 0x04, 0x15, 0x0E, 0x15, 0x04, 0x00},
+	'+':      {0x00, 0x04, 0x04, 0x1F, 0x04, 0x04, 0x00},
+	',':      {0x00, 0x00, 0x00, 0x00, 0x0C, 0x04, 0x08},
+	'-':      {0x00, 0x00, 0x00, 0x1F, 0x00, 0x00, 0x00},
+	'.':      {0x00, 0x00, 0x00, 0x00, 0x00, 0x0C, 0x0C},
+	'/':      {0x00, 0x01, 0x02, 0x04, 0x08, 0x10, 0x00},
+	'0':      {0x0E, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0E},
+	'1':      {0x04, 0x0C, 0x04, 0x04, 0x04, 0x04, 0x0E},
+	'2':      {0x0E, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1F},
+	'3':      {0x1F, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0E},
+	'4':      {0x02, 0x06, 0x0A, 0x12, 0x1F, 0x02, 0x02},
+	'5':      {0x1F, 0x10, 0x1E, 0x01, 0x01, 0x11, 0x0E},
+	'6':      {0x06, 0x08, 0x10, 0x1E, 0x11, 0x11, 0x0E},
+	'7':      {0x1F, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08},
+	'8':      {0x0E, 0x11, 0x11, 0x0E, 0x11, 0x11, 0x0E},
+	'9':      {0x0E, 0x11, 0x11, 0x0F, 0x01, 0x02, 0x0C},
+	':':      {0x00, 0x0C, 0x0C, 0x00, 0x0C, 0x0C, 0x00},
+	';':      {0x00, 0x0C, 0x0C, 0x00, 0x0C, 0x04, 0x08},
+	'<':      {0x02, 0x04, 0x08, 0x10, 0x08, 0x04, 0x02},
+	'=':      {0x00, 0x00, 0x1F, 0x00, 0x1F, 0x00, 0x00},
+	'>':      {0x08, 0x04, 0x02, 0x01, 0x02, 0x04, 0x08},
+	'?':      {0x0E, 0x11, 0x01, 0x02, 0x04, 0x00, 0x04},
+	'@':      {0x0E, 0x11, 0x01, 0x0D, 0x15, 0x15, 0x0E},
+	'A':      {0x0E, 0x11, 0x11, 0x11, 0x1F, 0x11, 0x11},
+	'B':      {0x1E, 0x11, 0x11, 0x1E, 0x11, 0x11, 0x1E},
+	'C':      {0x0E, 0x11, 0x10, 0x10, 0x10, 0x11, 0x0E},
+	'D':      {0x1C, 0x12, 0x11, 0x11, 0x11, 0x12, 0x1C},
+	'E':      {0x1F, 0x10, 0x10, 0x1E, 0x10, 0x10, 0x1F},
+	'F':      {0x1F, 0x10, 0x10, 0x1E, 0x10, 0x10, 0x10},
+	'G':      {0x0E, 0x11, 0x10, 0x17, 0x11, 0x11, 0x0F},
+	'H':      {0x11, 0x11, 0x11, 0x1F, 0x11, 0x11, 0x11},
+	'I':      {0x0E, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0E},
+	'J':      {0x07, 0x02, 0x02, 0x02, 0x02, 0x12, 0x0C},
+	'K':      {0x11, 0x12, 0x14, 0x18, 0x14, 0x12, 0x11},
+	'L':      {0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1F},
+	'M':      {0x11, 0x1B, 0x15, 0x15, 0x11, 0x11, 0x11},
+	'N':      {0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x11},
+	'O':      {0x0E, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0E},
+	'P':      {0x1E, 0x11, 0x11, 0x1E, 0x10, 0x10, 0x10},
+	'Q':      {0x0E, 0x11, 0x11, 0x11, 0x15, 0x12, 0x0D},
+	'R':      {0x1E, 0x11, 0x11, 0x1E, 0x14, 0x12, 0x11},
+	'S':      {0x0F, 0x10, 0x10, 0x0E, 0x01, 0x01, 0x1E},
+	'T':      {0x1F, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04},
+	'U':      {0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0E},
+	'V':      {0x11, 0x11, 0x11, 0x11, 0x11, 0x0A, 0x04},
+	'W':      {0x11, 0x11, 0x11, 0x15, 0x15, 0x15, 0x0A},
+	'X':      {0x11, 0x11, 0x0A, 0x04, 0x0A, 0x11, 0x11},
+	'Y':      {0x11, 0x11, 0x11, 0x0A, 0x04, 0x04, 0x04},
+	'Z':      {0x1F, 0x01, 0x02, 0x04, 0x08, 0x10, 0x1F},
+	'[':      {0x0E, 0x08, 0x08, 0x08, 0x08, 0x08, 0x0E},
+	'\\':     {0x00, 0x10, 0x08, 0x04, 0x02, 0x01, 0x00},
+	']':      {0x0E, 0x02, 0x02, 0x02, 0x02, 0x02, 0x0E},
+	'^':      {0x04, 0x0A, 0x11, 0x00, 0x00, 0x00, 0x00},
+	'_':      {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1F},
+	'`':      {0x08, 0x04, 0x02, 0x00, 0x00, 0x00, 0x00},
+	'a':      {0x00, 0x00, 0x0E, 0x01, 0x0F, 0x11, 0x0F},
+	'b':      {0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x1E},
+	'c':      {0x00, 0x00, 0x0E, 0x10, 0x10, 0x11, 0x0E},
+	'd':      {0x01, 0x01, 0x0D, 0x13, 0x11, 0x11, 0x0F},
+	'e':      {0x00, 0x00, 0x0E, 0x11, 0x1F, 0x10, 0x0E},
+	'f':      {0x06, 0x09, 0x08, 0x1C, 0x08, 0x08, 0x08},
+	'g':      {0x00, 0x0F, 0x11, 0x11, 0x0F, 0x01, 0x0E},
+	'h':      {0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x11},
+	'i':      {0x04, 0x00, 0x0C, 0x04, 0x04, 0x04, 0x0E},
+	'j':      {0x02, 0x00, 0x06, 0x02, 0x02, 0x12, 0x0C},
+	'k':      {0x10, 0x10, 0x12, 0x14, 0x18, 0x14, 0x12},
+	'l':      {0x0C, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0E},
+	'm':      {0x00, 0x00, 0x1A, 0x15, 0x15, 0x11, 0x11},
+	'n':      {0x00, 0x00, 0x16, 0x19, 0x11, 0x11, 0x11},
+	'o':      {0x00, 0x00, 0x0E, 0x11, 0x11, 0x11, 0x0E},
+	'p':      {0x00, 0x00, 0x1E, 0x11, 0x1E, 0x10, 0x10},
+	'q':      {0x00, 0x00, 0x0D, 0x13, 0x0F, 0x01, 0x01},
+	'r':      {0x00, 0x00, 0x16, 0x19, 0x10, 0x10, 0x10},
+	's':      {0x00, 0x00, 0x0E, 0x10, 0x0E, 0x01, 0x1E},
+	't':      {0x08, 0x08, 0x1C, 0x08, 0x08, 0x09, 0x06},
+	'u':      {0x00, 0x00, 0x11, 0x11, 0x11, 0x13, 0x0D},
+	'v':      {0x00, 0x00, 0x11, 0x11, 0x11, 0x0A, 0x04},
+	'w':      {0x00, 0x00, 0x11, 0x11, 0x15, 0x15, 0x0A},
+	'x':      {0x00, 0x00, 0x11, 0x0A, 0x04, 0x0A, 0x11},
+	'y':      {0x00, 0x00, 0x11, 0x11, 0x0F, 0x01, 0x0E},
+	'z':      {0x00, 0x00, 0x1F, 0x02, 0x04, 0x08, 0x1F},
+	'{':      {0x02, 0x04, 0x04, 0x08, 0x04, 0x04, 0x02},
+	'|':      {0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04},
+	'}':      {0x08, 0x04, 0x04, 0x02, 0x04, 0x04, 0x08},
+	'~':      {0x00, 0x00, 0x08, 0x15, 0x02, 0x00, 0x00},
+	starRune: {0x00, 0x04, 0x04, 0x1F, 0x0E, 0x1B, 0x11},
+}
+
+// fontText folds text to what the bitmap font can draw: accents are dropped and other
+// unsupported characters become "?"
+func fontText(text string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(text) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case unicode.IsSpace(r):
+			r = ' '
+		}
+		if _, ok := bitmapFont[r]; !ok {
+			r = '?'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// textWidth returns the width in pixels of text drawn at scale
+func textWidth(text string, scale int) int {
+	n := len([]rune(text))
+	if n == 0 {
+		return 0
+	}
+	return (n*glyphAdvance - 1) * scale
+}
+
+// drawText draws text with its top left corner at (x, y), each font pixel scale pixels wide
+// Characters must already be folded with fontText.
+func drawText(dst draw.Image, text string, x, y, scale int, c color.Color) {
+	src := image.NewUniform(c)
+	for _, r := range text {
+		glyph := bitmapFont[r]
+		for row, bits := range glyph {
+			for col := 0; col < glyphWidth; col++ {
+				if bits&(0x10>>col) == 0 {
+					continue
+				}
+				px := image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale)
+				draw.Draw(dst, px, src, image.Point{}, draw.Over)
+			}
+		}
+		x += glyphAdvance * scale
+	}
+}
+
+// wrapText splits text into lines of at most width characters, breaking at spaces where possible
+// If more than maxLines are needed, the last line is cut short and ends in "..."
+func wrapText(text string, width, maxLines int) []string {
+	words := strings.Fields(text)
+	lines := []string{}
+	current := []rune{}
+
+	for _, word := range words {
+		w := []rune(word)
+		for len(w) > width {
+			// Split words longer than a line
+			if len(current) > 0 {
+				lines = append(lines, string(current))
+				current = nil
+			}
+			lines = append(lines, string(w[:width]))
+			w = w[width:]
+		}
+
+		switch {
+		case len(current) == 0:
+			current = w
+		case len(current)+1+len(w) <= width:
+			current = append(append(current, ' '), w...)
+		default:
+			lines = append(lines, string(current))
+			current = w
+		}
+	}
+	if len(current) > 0 {
+		lines = append(lines, string(current))
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := []rune(lines[maxLines-1])
+		if len(last) > width-3 {
+			last = last[:width-3]
+		}
+		lines[maxLines-1] = strings.TrimRight(string(last), " ") + "..."
+	}
+	return lines
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/resend/resend-go/v2"
+)
+
+// EmailModeDryRun makes EmailService keep emails in memory instead of sending them through Resend
+const EmailModeDryRun = "dry-run"
+
+// DefaultEmailSandboxCapacity is how many dry-run emails are kept when EMAIL_SANDBOX_CAPACITY is not set
+const DefaultEmailSandboxCapacity = 200
+
+// SandboxEmail is a rendered email captured in dry-run mode
+type SandboxEmail struct {
+	ID        string    `json:"id"`
+	From      string    `json:"from"`
+	To        []string  `json:"to"`
+	ReplyTo   string    `json:"reply_to,omitempty"`
+	Subject   string    `json:"subject"`
+	HTML      string    `json:"html"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EmailSandbox holds the most recent dry-run emails, dropping the oldest once capacity is reached
+type EmailSandbox struct {
+	mu       sync.RWMutex
+	emails   []SandboxEmail
+	capacity int
+}
+
+// NewEmailSandbox creates a sandbox that keeps up to capacity emails
+func NewEmailSandbox(capacity int) *EmailSandbox {
+	if capacity <= 0 {
+		capacity = DefaultEmailSandboxCapacity
+	}
+	return &EmailSandbox{capacity: capacity}
+}
+
+// Record captures an email that would have been sent
+func (s *EmailSandbox) Record(params *resend.SendEmailRequest) SandboxEmail {
+	email := SandboxEmail{
+		ID:        "dryrun_" + uuid.NewString(),
+		From:      params.From,
+		To:        append([]string{}, params.To...),
+		ReplyTo:   params.ReplyTo,
+		Subject:   params.Subject,
+		HTML:      params.Html,
+		CreatedAt: time.Now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emails = append(s.emails, email)
+	if len(s.emails) > s.capacity {
+		s.emails = s.emails[len(s.emails)-s.capacity:]
+	}
+
+	return email
+}
+
+// List returns captured emails newest first, only those addressed to recipient if it is not empty
+func (s *EmailSandbox) List(recipient string) []SandboxEmail {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	emails := []SandboxEmail{}
+	for i := len(s.emails) - 1; i >= 0; i-- {
+		if recipient == "" || addressedTo(s.emails[i], recipient) {
+			emails = append(emails, s.emails[i])
+		}
+	}
+	return emails
+}
+
+// Get returns the captured email with the given ID, or nil if it is unknown or was dropped
+func (s *EmailSandbox) Get(id string) *SandboxEmail {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := range s.emails {
+		if s.emails[i].ID == id {
+			email := s.emails[i]
+			return &email
+		}
+	}
+	return nil
+}
+
+// Clear removes every captured email
+func (s *EmailSandbox) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emails = nil
+}
+
+func addressedTo(email SandboxEmail, recipient string) bool {
+	for _, to := range email.To {
+		if strings.EqualFold(to, recipient) {
+			return true
+		}
+	}
+	return false
+}
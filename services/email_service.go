@@ -3,18 +3,44 @@ package services
 import (
 	"context"
 	"fmt"
+	"html"
 	"log"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/dapoadedire/chefshare_be/resilience"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/google/uuid"
 	"github.com/resend/resend-go/v2"
 )
 
 type EmailService struct {
 	client *resend.Client
+
+	// sandbox is set in dry-run mode, where emails are captured instead of sent
+	sandbox *EmailSandbox
+
+	// metricsStore logs each send for the daily metrics rollup
+	metricsStore store.MetricsStore
+
+	// suppressionStore holds the addresses no email is sent to
+	suppressionStore store.EmailSuppressionStore
 }
 
-func NewEmailService() (*EmailService, error) {
+// NewEmailService creates an email service that sends through Resend
+// With EMAIL_MODE=dry-run no API key is needed: emails are rendered, logged and kept in memory instead
+func NewEmailService(metricsStore store.MetricsStore, suppressionStore store.EmailSuppressionStore) (*EmailService, error) {
+	if os.Getenv("EMAIL_MODE") == EmailModeDryRun {
+		log.Println("Email dry-run mode: emails are logged and kept in memory instead of being sent")
+		return &EmailService{
+			sandbox:          NewEmailSandbox(getEnvIntOrDefault("EMAIL_SANDBOX_CAPACITY", DefaultEmailSandboxCapacity)),
+			metricsStore:     metricsStore,
+			suppressionStore: suppressionStore,
+		}, nil
+	}
+
 	apiKey := os.Getenv("RESEND_API_KEY")
 	if apiKey == "" {
 		return nil, fmt.Errorf("RESEND_API_KEY not set in environment")
@@ -22,10 +48,83 @@ func NewEmailService() (*EmailService, error) {
 
 	client := resend.NewClient(apiKey)
 	return &EmailService{
-		client: client,
+		client:           client,
+		metricsStore:     metricsStore,
+		suppressionStore: suppressionStore,
 	}, nil
 }
 
+// Sandbox returns the captured emails in dry-run mode, or nil when emails are really sent
+func (s *EmailService) Sandbox() *EmailSandbox {
+	return s.sandbox
+}
+
+// send delivers an email, retrying transient failures; in dry-run mode it only captures it
+// Every attempt carries the same idempotency key, so Resend sends the email at most once.
+// Suppressed recipients are dropped first; an email left with none is skipped and reported as sent with no ID.
+func (s *EmailService) send(ctx context.Context, params *resend.SendEmailRequest) (string, error) {
+	if params.To = s.unsuppressed(params.To); len(params.To) == 0 {
+		log.Printf("Skipped email %q: every recipient is suppressed", params.Subject)
+		return "", nil
+	}
+
+	if s.sandbox != nil {
+		email := s.sandbox.Record(params)
+		log.Printf("Dry-run email %s to %s: %q", email.ID, strings.Join(email.To, ", "), email.Subject)
+		s.recordSend()
+		return email.ID, nil
+	}
+
+	options := &resend.SendEmailOptions{IdempotencyKey: uuid.NewString()}
+
+	var id string
+	err := resilience.Do(ctx, resilience.DefaultPolicy(true), func(ctx context.Context) error {
+		sent, err := s.client.Emails.SendWithOptions(ctx, params, options)
+		if err != nil {
+			return err
+		}
+		id = sent.Id
+		return nil
+	})
+	if err == nil {
+		s.recordSend()
+	}
+
+	return id, err
+}
+
+// unsuppressed returns the recipients that are not on the suppression list
+// If the list cannot be read the email goes to everyone, as a missed email is worse than one more bounce
+func (s *EmailService) unsuppressed(recipients []string) []string {
+	if s.suppressionStore == nil {
+		return recipients
+	}
+
+	suppressed, err := s.suppressionStore.GetSuppressedEmails(recipients)
+	if err != nil {
+		log.Printf("Failed to check email suppressions: %v", err)
+		return recipients
+	}
+
+	kept := []string{}
+	for _, recipient := range recipients {
+		if !suppressed[recipient] {
+			kept = append(kept, recipient)
+		}
+	}
+	return kept
+}
+
+// recordSend logs a send for the metrics rollup; a failure only leaves it out of the counts
+func (s *EmailService) recordSend() {
+	if s.metricsStore == nil {
+		return
+	}
+	if err := s.metricsStore.RecordEmailSend(); err != nil {
+		log.Printf("Failed to record email send: %v", err)
+	}
+}
+
 func (s *EmailService) SendWelcomeEmail(email string, name string) (string, error) {
 	ctx := context.Background()
 	currentYear := time.Now().Year()
@@ -124,13 +223,13 @@ func (s *EmailService) SendWelcomeEmail(email string, name string) (string, erro
 		// ScheduledAt: "in 1 hour",
 	}
 
-	sent, err := s.client.Emails.SendWithContext(ctx, params)
+	id, err := s.send(ctx, params)
 	if err != nil {
 		log.Printf("Failed to send welcome email to %s: %v", email, err)
 		return "", err
 	}
 
-	return sent.Id, nil
+	return id, nil
 }
 
 // SendVerificationEmail sends an email with a verification link to verify the user's email address
@@ -240,11 +339,700 @@ func (s *EmailService) SendVerificationEmail(email string, name string, token st
 		ReplyTo: fmt.Sprintf("Chefshare <%s>", replyTo),
 	}
 
-	sent, err := s.client.Emails.SendWithContext(ctx, params)
+	id, err := s.send(ctx, params)
 	if err != nil {
 		log.Printf("Failed to send verification email to %s: %v", email, err)
 		return "", err
 	}
 
-	return sent.Id, nil
+	return id, nil
+}
+
+// SendUnverifiedAccountWarningEmail reminds a user that their account will be disabled on disableAt unless they verify their email
+// It carries a fresh verification link, since the one sent at signup has usually expired by then
+func (s *EmailService) SendUnverifiedAccountWarningEmail(email string, name string, token string, disableAt time.Time) (string, error) {
+	ctx := context.Background()
+	currentYear := time.Now().Year()
+	from := os.Getenv("EMAIL_FROM")
+	replyTo := os.Getenv("EMAIL_REPLY_TO")
+
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000"
+	}
+	verificationURL := fmt.Sprintf("%s/verify-email?token=%s", frontendURL, url.QueryEscape(token))
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<title>Verify Your Email to Keep Your Account</title>
+	<style>
+		@media only screen and (max-width: 600px) {
+			.container {
+				width: 100%% !important;
+				padding: 20px 10px !important;
+			}
+		}
+		body {
+			margin: 0;
+			padding: 0;
+			font-family: Arial, sans-serif;
+			background-color: #f4f4f4;
+		}
+		.container {
+			width: 80%%;
+			max-width: 600px;
+			margin: 0 auto;
+			background: white;
+			padding: 30px;
+			border-radius: 8px;
+			box-shadow: 0 4px 10px rgba(0, 0, 0, 0.1);
+		}
+		.header {
+			text-align: center;
+			padding-bottom: 20px;
+			border-bottom: 1px solid #e0e0e0;
+		}
+		.content {
+			padding: 30px 0;
+		}
+		.cta {
+			text-align: center;
+			margin: 30px 0;
+		}
+		.cta a {
+			display: inline-block;
+			background-color: #27ae60;
+			color: white;
+			padding: 12px 24px;
+			text-decoration: none;
+			border-radius: 5px;
+			font-weight: bold;
+		}
+		.footer {
+			text-align: center;
+			padding-top: 20px;
+			border-top: 1px solid #e0e0e0;
+			color: #7f8c8d;
+			font-size: 12px;
+		}
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2>Verify Your Email to Keep Your Account</h2>
+		</div>
+		<div class="content">
+			<p>Hi %s,</p>
+			<p>You haven't verified the email address for your Chefshare account yet. Unverified accounts are disabled, so you won't be able to sign in after <strong>%s</strong> unless you verify it.</p>
+			<div class="cta">
+				<a href="%s">Verify Email Address</a>
+			</div>
+			<p>This link will expire in 48 hours. Disabled accounts that stay unverified are eventually deleted.</p>
+			<p>If you didn't create this account, you can safely ignore this email.</p>
+		</div>
+		<div class="footer">
+			<p>This is an automated message, please do not reply directly.</p>
+			<p>&copy; %d Chefshare. All rights reserved.</p>
+		</div>
+	</div>
+</body>
+</html>
+`, html.EscapeString(name), disableAt.UTC().Format("January 2, 2006"), verificationURL, currentYear)
+
+	params := &resend.SendEmailRequest{
+		From:    fmt.Sprintf("Chefshare <%s>", from),
+		To:      []string{email},
+		Subject: "Verify your email to keep your Chefshare account",
+		Html:    htmlContent,
+		ReplyTo: fmt.Sprintf("Chefshare <%s>", replyTo),
+	}
+
+	id, err := s.send(ctx, params)
+	if err != nil {
+		log.Printf("Failed to send unverified account warning email to %s: %v", email, err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+// SendInvitationEmail invites someone to join Chefshare on behalf of an existing user
+// The optional personal message is HTML-escaped before being included
+func (s *EmailService) SendInvitationEmail(email string, inviterName string, message string, token string) (string, error) {
+	ctx := context.Background()
+	currentYear := time.Now().Year()
+	from := os.Getenv("EMAIL_FROM")
+	replyTo := os.Getenv("EMAIL_REPLY_TO")
+
+	// Get the frontend URL for signup from environment, default to localhost if not set
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000"
+	}
+
+	// Create signup URL with the invitation token
+	signupURL := fmt.Sprintf("%s/signup?invite=%s", frontendURL, url.QueryEscape(token))
+
+	personalMessage := ""
+	if message != "" {
+		personalMessage = fmt.Sprintf(`<blockquote style="border-left: 3px solid #27ae60; margin: 20px 0; padding-left: 15px; color: #555;">%s</blockquote>`, html.EscapeString(message))
+	}
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<title>You're Invited to Chefshare</title>
+	<style>
+		@media only screen and (max-width: 600px) {
+			.container {
+				width: 100%% !important;
+				padding: 20px 10px !important;
+			}
+		}
+		body {
+			margin: 0;
+			padding: 0;
+			font-family: Arial, sans-serif;
+			background-color: #f4f4f4;
+		}
+		.container {
+			width: 80%%;
+			max-width: 600px;
+			margin: 0 auto;
+			background: white;
+			padding: 30px;
+			border-radius: 8px;
+			box-shadow: 0 4px 10px rgba(0, 0, 0, 0.1);
+		}
+		.header {
+			text-align: center;
+			padding-bottom: 20px;
+			border-bottom: 1px solid #e0e0e0;
+		}
+		.content {
+			padding: 30px 0;
+		}
+		.cta {
+			text-align: center;
+			margin: 30px 0;
+		}
+		.cta a {
+			display: inline-block;
+			background-color: #27ae60;
+			color: white;
+			padding: 12px 24px;
+			text-decoration: none;
+			border-radius: 5px;
+			font-weight: bold;
+		}
+		.footer {
+			text-align: center;
+			padding-top: 20px;
+			border-top: 1px solid #e0e0e0;
+			color: #7f8c8d;
+			font-size: 12px;
+		}
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2>You're Invited to Chefshare</h2>
+		</div>
+		<div class="content">
+			<p>Hi there,</p>
+			<p>%s has invited you to join Chefshare, a community for sharing and discovering recipes.</p>
+			%s
+			<p>This invitation link will expire in 14 days.</p>
+			<div class="cta">
+				<a href="%s">Join Chefshare</a>
+			</div>
+			<p>If you weren't expecting this invitation, you can safely ignore this email.</p>
+			<p>Happy cooking!</p>
+		</div>
+		<div class="footer">
+			<p>This is an automated message, please do not reply directly.</p>
+			<p>&copy; %d Chefshare. All rights reserved.</p>
+		</div>
+	</div>
+</body>
+</html>
+`, html.EscapeString(inviterName), personalMessage, signupURL, currentYear)
+
+	params := &resend.SendEmailRequest{
+		From:    fmt.Sprintf("Chefshare <%s>", from),
+		To:      []string{email},
+		Subject: fmt.Sprintf("%s invited you to Chefshare", inviterName),
+		Html:    htmlContent,
+		ReplyTo: fmt.Sprintf("Chefshare <%s>", replyTo),
+	}
+
+	id, err := s.send(ctx, params)
+	if err != nil {
+		log.Printf("Failed to send invitation email to %s: %v", email, err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+// SendStaleDraftsWarningEmail tells a user that drafts they haven't edited in a long time will be archived on archiveAt
+// Draft titles are HTML-escaped before being included
+func (s *EmailService) SendStaleDraftsWarningEmail(email string, name string, drafts []*store.StaleDraft, archiveAt time.Time) (string, error) {
+	ctx := context.Background()
+	currentYear := time.Now().Year()
+	from := os.Getenv("EMAIL_FROM")
+	replyTo := os.Getenv("EMAIL_REPLY_TO")
+
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000"
+	}
+
+	var list strings.Builder
+	for _, draft := range drafts {
+		title := draft.Title
+		if title == "" {
+			title = "Untitled draft"
+		}
+		fmt.Fprintf(&list, `<li><a href="%s/recipes/%d">%s</a></li>`, frontendURL, draft.RecipeID, html.EscapeString(title))
+	}
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<title>Your Drafts Will Be Archived</title>
+	<style>
+		@media only screen and (max-width: 600px) {
+			.container {
+				width: 100%% !important;
+				padding: 20px 10px !important;
+			}
+		}
+		body {
+			margin: 0;
+			padding: 0;
+			font-family: Arial, sans-serif;
+			background-color: #f4f4f4;
+		}
+		.container {
+			width: 80%%;
+			max-width: 600px;
+			margin: 0 auto;
+			background: white;
+			padding: 30px;
+			border-radius: 8px;
+			box-shadow: 0 4px 10px rgba(0, 0, 0, 0.1);
+		}
+		.header {
+			text-align: center;
+			padding-bottom: 20px;
+			border-bottom: 1px solid #e0e0e0;
+		}
+		.content {
+			padding: 30px 0;
+		}
+		.footer {
+			text-align: center;
+			padding-top: 20px;
+			border-top: 1px solid #e0e0e0;
+			color: #7f8c8d;
+			font-size: 12px;
+		}
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2>Your Drafts Will Be Archived</h2>
+		</div>
+		<div class="content">
+			<p>Hi %s,</p>
+			<p>You haven't worked on these drafts in a long time, so they will be archived on or after <strong>%s</strong>:</p>
+			<ul>%s</ul>
+			<p>Edit a draft before then to keep it. Archived drafts aren't deleted, and you can restore them at any time.</p>
+		</div>
+		<div class="footer">
+			<p>This is an automated message, please do not reply directly.</p>
+			<p>&copy; %d Chefshare. All rights reserved.</p>
+		</div>
+	</div>
+</body>
+</html>
+`, html.EscapeString(name), archiveAt.UTC().Format("January 2, 2006"), list.String(), currentYear)
+
+	subject := "Your Chefshare draft will be archived"
+	if len(drafts) > 1 {
+		subject = fmt.Sprintf("%d of your Chefshare drafts will be archived", len(drafts))
+	}
+
+	params := &resend.SendEmailRequest{
+		From:    fmt.Sprintf("Chefshare <%s>", from),
+		To:      []string{email},
+		Subject: subject,
+		Html:    htmlContent,
+		ReplyTo: fmt.Sprintf("Chefshare <%s>", replyTo),
+	}
+
+	id, err := s.send(ctx, params)
+	if err != nil {
+		log.Printf("Failed to send stale drafts warning email to %s: %v", email, err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+// SendMentionEmail tells a user that someone mentioned them in a review
+// The quoted excerpt is HTML-escaped before being included
+func (s *EmailService) SendMentionEmail(email string, name string, actorUsername string, recipeTitle string, recipeID int64, excerpt string) (string, error) {
+	ctx := context.Background()
+	currentYear := time.Now().Year()
+	from := os.Getenv("EMAIL_FROM")
+	replyTo := os.Getenv("EMAIL_REPLY_TO")
+
+	// Get the frontend URL for the recipe from environment, default to localhost if not set
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000"
+	}
+
+	recipeURL := fmt.Sprintf("%s/recipes/%d", frontendURL, recipeID)
+
+	quote := ""
+	if excerpt != "" {
+		quote = fmt.Sprintf(`<blockquote style="border-left: 3px solid #27ae60; margin: 20px 0; padding-left: 15px; color: #555;">%s</blockquote>`, html.EscapeString(excerpt))
+	}
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<title>You Were Mentioned on Chefshare</title>
+	<style>
+		@media only screen and (max-width: 600px) {
+			.container {
+				width: 100%% !important;
+				padding: 20px 10px !important;
+			}
+		}
+		body {
+			margin: 0;
+			padding: 0;
+			font-family: Arial, sans-serif;
+			background-color: #f4f4f4;
+		}
+		.container {
+			width: 80%%;
+			max-width: 600px;
+			margin: 0 auto;
+			background: white;
+			padding: 30px;
+			border-radius: 8px;
+			box-shadow: 0 4px 10px rgba(0, 0, 0, 0.1);
+		}
+		.header {
+			text-align: center;
+			padding-bottom: 20px;
+			border-bottom: 1px solid #e0e0e0;
+		}
+		.content {
+			padding: 30px 0;
+		}
+		.cta {
+			text-align: center;
+			margin: 30px 0;
+		}
+		.cta a {
+			display: inline-block;
+			background-color: #27ae60;
+			color: white;
+			padding: 12px 24px;
+			text-decoration: none;
+			border-radius: 5px;
+			font-weight: bold;
+		}
+		.footer {
+			text-align: center;
+			padding-top: 20px;
+			border-top: 1px solid #e0e0e0;
+			color: #7f8c8d;
+			font-size: 12px;
+		}
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2>You Were Mentioned on Chefshare</h2>
+		</div>
+		<div class="content">
+			<p>Hi %s,</p>
+			<p>@%s mentioned you in a review of <strong>%s</strong>.</p>
+			%s
+			<div class="cta">
+				<a href="%s">View Recipe</a>
+			</div>
+			<p>Happy cooking!</p>
+		</div>
+		<div class="footer">
+			<p>This is an automated message, please do not reply directly.</p>
+			<p>&copy; %d Chefshare. All rights reserved.</p>
+		</div>
+	</div>
+</body>
+</html>
+`, html.EscapeString(name), html.EscapeString(actorUsername), html.EscapeString(recipeTitle), quote, recipeURL, currentYear)
+
+	params := &resend.SendEmailRequest{
+		From:    fmt.Sprintf("Chefshare <%s>", from),
+		To:      []string{email},
+		Subject: fmt.Sprintf("@%s mentioned you on Chefshare", actorUsername),
+		Html:    htmlContent,
+		ReplyTo: fmt.Sprintf("Chefshare <%s>", replyTo),
+	}
+
+	id, err := s.send(ctx, params)
+	if err != nil {
+		log.Printf("Failed to send mention email to %s: %v", email, err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+// SendNotificationDigestEmail emails a user the notifications that are due, one line each, linking to their recipes
+func (s *EmailService) SendNotificationDigestEmail(email string, name string, notifications []*store.NotificationEmail) (string, error) {
+	ctx := context.Background()
+	currentYear := time.Now().Year()
+	from := os.Getenv("EMAIL_FROM")
+	replyTo := os.Getenv("EMAIL_REPLY_TO")
+
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000"
+	}
+
+	var list strings.Builder
+	for _, notification := range notifications {
+		if notification.RecipeID != nil {
+			fmt.Fprintf(&list, `<li><a href="%s/recipes/%d">%s</a></li>`, frontendURL, *notification.RecipeID, html.EscapeString(notification.Message))
+		} else {
+			fmt.Fprintf(&list, `<li>%s</li>`, html.EscapeString(notification.Message))
+		}
+	}
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<title>What You Missed on Chefshare</title>
+	<style>
+		@media only screen and (max-width: 600px) {
+			.container {
+				width: 100%% !important;
+				padding: 20px 10px !important;
+			}
+		}
+		body {
+			margin: 0;
+			padding: 0;
+			font-family: Arial, sans-serif;
+			background-color: #f4f4f4;
+		}
+		.container {
+			width: 80%%;
+			max-width: 600px;
+			margin: 0 auto;
+			background: white;
+			padding: 30px;
+			border-radius: 8px;
+			box-shadow: 0 4px 10px rgba(0, 0, 0, 0.1);
+		}
+		.header {
+			text-align: center;
+			padding-bottom: 20px;
+			border-bottom: 1px solid #e0e0e0;
+		}
+		.content {
+			padding: 30px 0;
+		}
+		.footer {
+			text-align: center;
+			padding-top: 20px;
+			border-top: 1px solid #e0e0e0;
+			color: #7f8c8d;
+			font-size: 12px;
+		}
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2>What You Missed on Chefshare</h2>
+		</div>
+		<div class="content">
+			<p>Hi %s,</p>
+			<p>Here's what happened since we last wrote:</p>
+			<ul>%s</ul>
+			<p>You can choose which notifications are emailed, and how often, in your notification settings.</p>
+		</div>
+		<div class="footer">
+			<p>This is an automated message, please do not reply directly.</p>
+			<p>&copy; %d Chefshare. All rights reserved.</p>
+		</div>
+	</div>
+</body>
+</html>
+`, html.EscapeString(name), list.String(), currentYear)
+
+	subject := notifications[0].Message
+	if len(notifications) > 1 {
+		subject = fmt.Sprintf("%d new notifications on Chefshare", len(notifications))
+	}
+
+	params := &resend.SendEmailRequest{
+		From:    fmt.Sprintf("Chefshare <%s>", from),
+		To:      []string{email},
+		Subject: subject,
+		Html:    htmlContent,
+		ReplyTo: fmt.Sprintf("Chefshare <%s>", replyTo),
+	}
+
+	id, err := s.send(ctx, params)
+	if err != nil {
+		log.Printf("Failed to send notification digest email to %s: %v", email, err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+// SendIdentityLinkEmail asks the owner of an account to confirm linking a provider identity that signed in with the account's email
+// Nothing is linked unless the owner follows the link, so someone controlling the email at the provider cannot take over the account
+func (s *EmailService) SendIdentityLinkEmail(email string, name string, provider string, token string, expiresAt time.Time) (string, error) {
+	ctx := context.Background()
+	currentYear := time.Now().Year()
+	from := os.Getenv("EMAIL_FROM")
+	replyTo := os.Getenv("EMAIL_REPLY_TO")
+
+	// Get the frontend URL for the confirmation page from environment, default to localhost if not set
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000"
+	}
+
+	confirmURL := fmt.Sprintf("%s/oauth/link/confirm?token=%s", frontendURL, url.QueryEscape(token))
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<title>Confirm Sign-In Method on Chefshare</title>
+	<style>
+		@media only screen and (max-width: 600px) {
+			.container {
+				width: 100%% !important;
+				padding: 20px 10px !important;
+			}
+		}
+		body {
+			margin: 0;
+			padding: 0;
+			font-family: Arial, sans-serif;
+			background-color: #f4f4f4;
+		}
+		.container {
+			width: 80%%;
+			max-width: 600px;
+			margin: 0 auto;
+			background: white;
+			padding: 30px;
+			border-radius: 8px;
+			box-shadow: 0 4px 10px rgba(0, 0, 0, 0.1);
+		}
+		.header {
+			text-align: center;
+			padding-bottom: 20px;
+			border-bottom: 1px solid #e0e0e0;
+		}
+		.content {
+			padding: 30px 0;
+		}
+		.cta {
+			text-align: center;
+			margin: 30px 0;
+		}
+		.cta a {
+			display: inline-block;
+			background-color: #27ae60;
+			color: white;
+			padding: 12px 24px;
+			text-decoration: none;
+			border-radius: 5px;
+			font-weight: bold;
+		}
+		.footer {
+			text-align: center;
+			padding-top: 20px;
+			border-top: 1px solid #e0e0e0;
+			color: #7f8c8d;
+			font-size: 12px;
+		}
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<h2>Confirm Sign-In Method</h2>
+		</div>
+		<div class="content">
+			<p>Hi %s,</p>
+			<p>Someone tried to sign in to Chefshare with a %s account that uses this email address. If it was you, confirm below to link it to your Chefshare account so you can sign in with it from now on.</p>
+			<div class="cta">
+				<a href="%s">Link %s Account</a>
+			</div>
+			<p>This link expires on %s. If you did not try to sign in, ignore this email and nothing will change.</p>
+		</div>
+		<div class="footer">
+			<p>This is an automated message, please do not reply directly.</p>
+			<p>&copy; %d Chefshare. All rights reserved.</p>
+		</div>
+	</div>
+</body>
+</html>
+`, html.EscapeString(name), html.EscapeString(provider), confirmURL, html.EscapeString(provider), expiresAt.UTC().Format("January 2, 2006 15:04 MST"), currentYear)
+
+	params := &resend.SendEmailRequest{
+		From:    fmt.Sprintf("Chefshare <%s>", from),
+		To:      []string{email},
+		Subject: fmt.Sprintf("Confirm signing in to Chefshare with %s", provider),
+		Html:    htmlContent,
+		ReplyTo: fmt.Sprintf("Chefshare <%s>", replyTo),
+	}
+
+	id, err := s.send(ctx, params)
+	if err != nil {
+		log.Printf("Failed to send identity link email to %s: %v", email, err)
+		return "", err
+	}
+
+	return id, nil
 }
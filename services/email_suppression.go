@@ -0,0 +1,118 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// Resend webhook event types that suppress the recipient's address
+const (
+	ResendEventBounced    = "email.bounced"
+	ResendEventComplained = "email.complained"
+)
+
+// resendWebhookTolerance is how far a webhook's timestamp may be from now, to stop old deliveries being replayed
+const resendWebhookTolerance = 5 * time.Minute
+
+// ErrResendWebhookSignature is returned for webhook requests without a valid, recent signature
+var ErrResendWebhookSignature = errors.New("invalid webhook signature")
+
+// ResendEvent is a webhook event from Resend about an email we sent
+type ResendEvent struct {
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      struct {
+		EmailID string   `json:"email_id"`
+		To      []string `json:"to"`
+	} `json:"data"`
+}
+
+// SuppressionReason returns the suppression reason for the event's recipients,
+// or "" for events that do not suppress them
+// Resend only reports permanent bounces as email.bounced, so every bounce suppresses the address.
+func (e *ResendEvent) SuppressionReason() string {
+	switch e.Type {
+	case ResendEventBounced:
+		return store.EmailSuppressionBounce
+	case ResendEventComplained:
+		return store.EmailSuppressionComplaint
+	default:
+		return ""
+	}
+}
+
+// ResendWebhookVerifier checks the signatures Resend puts on its webhook requests
+// Resend signs webhooks with Svix: each request carries svix-id, svix-timestamp and svix-signature headers,
+// the last holding base64 HMAC-SHA256 signatures of "<id>.<timestamp>.<body>" keyed with the endpoint's secret.
+type ResendWebhookVerifier struct {
+	key []byte
+}
+
+// NewResendWebhookVerifierFromEnv returns a verifier for the RESEND_WEBHOOK_SECRET signing secret (whsec_...),
+// or nil if it is not set, in which case bounces and complaints are not received
+func NewResendWebhookVerifierFromEnv() (*ResendWebhookVerifier, error) {
+	secret := strings.TrimSpace(os.Getenv("RESEND_WEBHOOK_SECRET"))
+	if secret == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("RESEND_WEBHOOK_SECRET is not a valid signing secret")
+	}
+	return &ResendWebhookVerifier{key: key}, nil
+}
+
+// Verify checks that body was signed by Resend no more than resendWebhookTolerance from now and decodes the event
+func (v *ResendWebhookVerifier) Verify(header http.Header, body []byte, now time.Time) (*ResendEvent, error) {
+	id := header.Get("svix-id")
+	timestamp := header.Get("svix-timestamp")
+	if id == "" || timestamp == "" {
+		return nil, ErrResendWebhookSignature
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || math.Abs(now.Sub(time.Unix(seconds, 0)).Seconds()) > resendWebhookTolerance.Seconds() {
+		return nil, ErrResendWebhookSignature
+	}
+
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	// The header lists one or more space-separated "v1,<signature>" entries, several while a secret is rotated
+	valid := false
+	for _, entry := range strings.Fields(header.Get("svix-signature")) {
+		version, signature, ok := strings.Cut(entry, ",")
+		if !ok || version != "v1" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(signature)
+		if err == nil && hmac.Equal(decoded, expected) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, ErrResendWebhookSignature
+	}
+
+	event := &ResendEvent{}
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook event: %w", err)
+	}
+	return event, nil
+}
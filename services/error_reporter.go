@@ -0,0 +1,162 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrorReport describes an unexpected failure, such as a recovered panic, to send to the error-reporting service
+type ErrorReport struct {
+	Message   string
+	Stack     string
+	RequestID string
+	Method    string
+	Path      string
+	UserID    string
+}
+
+// ErrorReporter forwards unexpected failures to an external error-reporting service
+type ErrorReporter interface {
+	// Report sends report without blocking the caller
+	Report(report ErrorReport)
+}
+
+// NewErrorReporterFromEnv returns a Sentry reporter when SENTRY_DSN is set, otherwise a reporter that drops reports
+// Recovered panics are always logged by the recovery middleware regardless of the reporter
+func NewErrorReporterFromEnv() ErrorReporter {
+	dsn := os.Getenv("SENTRY_DSN")
+	if dsn == "" {
+		return noopErrorReporter{}
+	}
+
+	reporter, err := NewSentryReporter(SentryConfig{
+		DSN:         dsn,
+		Environment: getEnvOrDefault("SENTRY_ENVIRONMENT", "development"),
+		Timeout:     5 * time.Second,
+	})
+	if err != nil {
+		slog.Warn("invalid SENTRY_DSN, error reporting disabled", "error", err)
+		return noopErrorReporter{}
+	}
+	return reporter
+}
+
+type noopErrorReporter struct{}
+
+func (noopErrorReporter) Report(ErrorReport) {}
+
+// SentryConfig holds configuration for reporting errors to Sentry
+type SentryConfig struct {
+	DSN         string
+	Environment string
+	Timeout     time.Duration
+}
+
+// SentryReporter sends error reports to Sentry's store endpoint
+type SentryReporter struct {
+	config    SentryConfig
+	endpoint  string
+	publicKey string
+	client    *http.Client
+}
+
+// NewSentryReporter creates a reporter for the project named by the DSN
+// (https://<public_key>@<host>/<project_id>)
+func NewSentryReporter(config SentryConfig) (*SentryReporter, error) {
+	parsed, err := url.Parse(config.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DSN: %w", err)
+	}
+
+	projectID := strings.Trim(parsed.Path, "/")
+	if parsed.User == nil || parsed.User.Username() == "" || parsed.Host == "" || projectID == "" {
+		return nil, fmt.Errorf("DSN must look like https://<public_key>@<host>/<project_id>")
+	}
+
+	return &SentryReporter{
+		config:    config,
+		endpoint:  fmt.Sprintf("%s://%s/api/%s/store/", parsed.Scheme, parsed.Host, projectID),
+		publicKey: parsed.User.Username(),
+		client:    &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+// Report implements ErrorReporter, sending the event in the background
+func (r *SentryReporter) Report(report ErrorReport) {
+	go func() {
+		if err := r.send(report); err != nil {
+			slog.Error("failed to send error report", "error", err, "request_id", report.RequestID)
+		}
+	}()
+}
+
+func (r *SentryReporter) send(report ErrorReport) error {
+	eventID := make([]byte, 16)
+	if _, err := rand.Read(eventID); err != nil {
+		return fmt.Errorf("failed to generate event ID: %w", err)
+	}
+
+	event := map[string]interface{}{
+		"event_id":    hex.EncodeToString(eventID),
+		"timestamp":   time.Now().UTC().Format(time.RFC3339),
+		"level":       "fatal",
+		"platform":    "go",
+		"logger":      "recovery",
+		"environment": r.config.Environment,
+		"message":     report.Message,
+		"exception": map[string]interface{}{
+			"values": []map[string]interface{}{
+				{"type": "panic", "value": report.Message},
+			},
+		},
+		"request": map[string]interface{}{
+			"method": report.Method,
+			"url":    report.Path,
+		},
+		"tags": map[string]string{
+			"request_id": report.RequestID,
+		},
+		"user": map[string]string{
+			"id": report.UserID,
+		},
+		"extra": map[string]string{
+			"stack": report.Stack,
+		},
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=chefshare/1.0, sentry_key=%s", r.publicKey))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry returned status %d", resp.StatusCode)
+	}
+	return nil
+}
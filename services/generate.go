@@ -0,0 +1,9 @@
+package services
+
+// Mocks for the storage, search and error reporting interfaces are generated into mocks/services with mockgen (go.uber.org/mock)
+// Run `make mocks` after changing an interface so handler tests keep compiling
+
+//go:generate go run go.uber.org/mock/mockgen -source=cloudinary_storage.go -destination=../mocks/services/cloudinary_storage.go -package=mockservices
+//go:generate go run go.uber.org/mock/mockgen -source=error_reporter.go -destination=../mocks/services/error_reporter.go -package=mockservices
+//go:generate go run go.uber.org/mock/mockgen -source=search_index.go -destination=../mocks/services/search_index.go -package=mockservices
+//go:generate go run go.uber.org/mock/mockgen -source=storage.go -destination=../mocks/services/storage.go -package=mockservices
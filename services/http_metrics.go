@@ -0,0 +1,128 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// httpDurationBuckets are the upper bounds, in seconds, of the request duration histogram, as Prometheus's defaults
+var httpDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// httpRouteKey identifies the requests counted together: one method on one route template
+type httpRouteKey struct {
+	method string
+	route  string
+}
+
+// httpRouteSeries holds the counts for one httpRouteKey
+type httpRouteSeries struct {
+	statuses map[int]uint64
+	buckets  []uint64
+	count    uint64
+	sum      float64
+}
+
+// HTTPMetrics counts requests and their durations per method, route template and status,
+// and writes them in the Prometheus text exposition format
+// Routes are labelled by the template they matched, such as /api/v1/recipes/:id, never the raw path,
+// so each endpoint has a fixed set of series however many IDs are requested.
+type HTTPMetrics struct {
+	mu     sync.Mutex
+	series map[httpRouteKey]*httpRouteSeries
+}
+
+// NewHTTPMetrics creates an empty set of request metrics
+func NewHTTPMetrics() *HTTPMetrics {
+	return &HTTPMetrics{
+		series: map[httpRouteKey]*httpRouteSeries{},
+	}
+}
+
+// Observe records a finished request
+func (m *HTTPMetrics) Observe(method string, route string, status int, duration time.Duration) {
+	seconds := duration.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := httpRouteKey{method: method, route: route}
+	series, ok := m.series[key]
+	if !ok {
+		series = &httpRouteSeries{
+			statuses: map[int]uint64{},
+			buckets:  make([]uint64, len(httpDurationBuckets)),
+		}
+		m.series[key] = series
+	}
+
+	series.statuses[status]++
+	series.count++
+	series.sum += seconds
+	for i, bound := range httpDurationBuckets {
+		if seconds <= bound {
+			series.buckets[i]++
+		}
+	}
+}
+
+// WritePrometheus writes http_requests_total and http_request_duration_seconds, sorted by route and method
+func (m *HTTPMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	keys := make([]httpRouteKey, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].route != keys[j].route {
+			return keys[i].route < keys[j].route
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	var b strings.Builder
+	b.WriteString("# HELP http_requests_total Requests handled, by method, route template and status code.\n")
+	b.WriteString("# TYPE http_requests_total counter\n")
+	for _, key := range keys {
+		series := m.series[key]
+		statuses := make([]int, 0, len(series.statuses))
+		for status := range series.statuses {
+			statuses = append(statuses, status)
+		}
+		sort.Ints(statuses)
+		for _, status := range statuses {
+			fmt.Fprintf(&b, "http_requests_total{method=%s,route=%s,status=\"%d\"} %d\n",
+				quoteLabel(key.method), quoteLabel(key.route), status, series.statuses[status])
+		}
+	}
+
+	b.WriteString("# HELP http_request_duration_seconds Time taken to handle requests, by method and route template.\n")
+	b.WriteString("# TYPE http_request_duration_seconds histogram\n")
+	for _, key := range keys {
+		series := m.series[key]
+		labels := fmt.Sprintf("method=%s,route=%s", quoteLabel(key.method), quoteLabel(key.route))
+		for i, bound := range httpDurationBuckets {
+			fmt.Fprintf(&b, "http_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n",
+				labels, strconv.FormatFloat(bound, 'g', -1, 64), series.buckets[i])
+		}
+		fmt.Fprintf(&b, "http_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, series.count)
+		fmt.Fprintf(&b, "http_request_duration_seconds_sum{%s} %s\n", labels, strconv.FormatFloat(series.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "http_request_duration_seconds_count{%s} %d\n", labels, series.count)
+	}
+	m.mu.Unlock()
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// quoteLabel quotes a label value, escaping backslashes, quotes and newlines as the exposition format requires
+func quoteLabel(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return `"` + value + `"`
+}
@@ -1,9 +1,11 @@
 package services
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"time"
 
@@ -19,6 +21,16 @@ type JWTConfig struct {
 	RefreshTokenDuration   time.Duration
 	AccessTokenCookieName  string
 	RefreshTokenCookieName string
+
+	// CookieMode sends sign-in tokens as HttpOnly cookies instead of in response bodies, for frontends that
+	// would rather not keep them in localStorage. Unsafe requests authenticated by cookie must echo the
+	// CSRF cookie in the CSRF header (double-submit), since browsers attach cookies to cross-site requests too.
+	CookieMode     bool
+	CookieDomain   string
+	CookieSecure   bool
+	CookieSameSite http.SameSite
+	CSRFCookieName string
+	CSRFHeaderName string
 }
 
 // DefaultJWTConfig returns a default JWT configuration
@@ -30,6 +42,12 @@ func DefaultJWTConfig() JWTConfig {
 		RefreshTokenDuration:   7 * 24 * time.Hour, // 7 days
 		AccessTokenCookieName:  "access_token",
 		RefreshTokenCookieName: "refresh_token",
+		CookieMode:             getEnvOrDefault("AUTH_COOKIE_MODE", "false") == "true",
+		CookieDomain:           os.Getenv("AUTH_COOKIE_DOMAIN"),
+		CookieSecure:           getEnvOrDefault("AUTH_COOKIE_SECURE", "true") == "true",
+		CookieSameSite:         parseSameSite(getEnvOrDefault("AUTH_COOKIE_SAMESITE", "lax")),
+		CSRFCookieName:         "csrf_token",
+		CSRFHeaderName:         "X-CSRF-Token",
 	}
 }
 
@@ -43,13 +61,34 @@ func getEnvOrDefault(key, defaultValue string) string {
 }
 
 // CustomClaims extends standard claims with custom user information
+// Scopes limit which route groups the token can call; Role is informational and the
+// admin routes still check the role stored in the database
 type CustomClaims struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	Email    string   `json:"email"`
+	Role     string   `json:"role,omitempty"`
+	Scopes   []string `json:"scopes,omitempty"`
+	// APIKeyID is set on tokens exchanged for an API key
+	APIKeyID int64 `json:"api_key_id,omitempty"`
+	// ImpersonatorID is the public ID of the admin who minted an impersonation token
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsRestricted reports whether the token was minted for an API key or impersonation rather than a sign-in
+func (c *CustomClaims) IsRestricted() bool {
+	return c.APIKeyID != 0 || c.ImpersonatorID != ""
+}
+
+// ScopedTokenOptions describes a restricted access token
+type ScopedTokenOptions struct {
+	Scopes         []string
+	Duration       time.Duration
+	APIKeyID       int64
+	ImpersonatorID string
+}
+
 // JWTService handles JWT token generation and validation
 type JWTService struct {
 	config              JWTConfig
@@ -119,15 +158,56 @@ func (s *JWTService) GenerateTokenPairWithTransaction(user *store.User, ipAddres
 }
 
 // GenerateAccessToken creates a new JWT access token
+// The token carries the user's role and every scope that role allows
 func (s *JWTService) GenerateAccessToken(user *store.User) (string, error) {
+	role, err := s.userStore.GetUserRole(user.UserID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user role: %w", err)
+	}
+
+	return s.signAccessToken(user, role, ScopesForRole(role), s.config.AccessTokenDuration, 0, "")
+}
+
+// GenerateScopedAccessToken creates an access token limited to the given scopes
+// Scopes the user's role does not allow are dropped; it returns the token, its scopes and its expiry
+func (s *JWTService) GenerateScopedAccessToken(user *store.User, opts ScopedTokenOptions) (string, []string, time.Time, error) {
+	role, err := s.userStore.GetUserRole(user.UserID)
+	if err != nil {
+		return "", nil, time.Time{}, fmt.Errorf("failed to get user role: %w", err)
+	}
+
+	scopes, _ := RestrictScopes(opts.Scopes, ScopesForRole(role))
+	if len(scopes) == 0 {
+		return "", nil, time.Time{}, fmt.Errorf("no usable scopes for token")
+	}
+
+	duration := opts.Duration
+	if duration <= 0 || duration > s.config.AccessTokenDuration {
+		duration = s.config.AccessTokenDuration
+	}
+
+	token, err := s.signAccessToken(user, role, scopes, duration, opts.APIKeyID, opts.ImpersonatorID)
+	if err != nil {
+		return "", nil, time.Time{}, err
+	}
+
+	return token, scopes, time.Now().Add(duration), nil
+}
+
+// signAccessToken builds and signs the claims shared by session and scoped tokens
+func (s *JWTService) signAccessToken(user *store.User, role string, scopes []string, duration time.Duration, apiKeyID int64, impersonatorID string) (string, error) {
 	// Set token expiry time
-	expirationTime := time.Now().Add(s.config.AccessTokenDuration)
+	expirationTime := time.Now().Add(duration)
 
 	// Create claims with user information
 	claims := &CustomClaims{
-		UserID:   user.UserID,
-		Username: user.Username,
-		Email:    user.Email,
+		UserID:         user.UserID,
+		Username:       user.Username,
+		Email:          user.Email,
+		Role:           role,
+		Scopes:         scopes,
+		APIKeyID:       apiKeyID,
+		ImpersonatorID: impersonatorID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -174,53 +254,26 @@ func (s *JWTService) RefreshAccessToken(refreshTokenString string) (string, *sto
 		return "", nil, fmt.Errorf("user not found")
 	}
 
-	// Start a database transaction
-	db := s.userStore.DB()
-	tx, err := db.Begin()
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to begin transaction: %w", err)
+	if user.Disabled {
+		return "", nil, fmt.Errorf("account disabled")
 	}
 
-	// Defer a function to handle transaction completion based on success or failure
-	defer func() {
-		if err != nil {
-			// If we encountered an error, roll back the transaction
-			if txErr := tx.Rollback(); txErr != nil {
-				log.Printf("Failed to rollback transaction: %v", txErr)
-			}
+	// Rotate the refresh token atomically so a failure never leaves the user without a valid one
+	var accessToken string
+	var newRefreshToken *store.RefreshToken
+	err = store.WithTx(context.Background(), s.userStore.DB(), func(tx *sql.Tx) error {
+		// Revoke the current refresh token
+		if err := s.refreshTokenStore.RevokeRefreshTokenWithTransaction(refreshTokenString, tx); err != nil {
+			return fmt.Errorf("failed to revoke old refresh token: %w", err)
 		}
-	}()
-
-	// Revoke the current refresh token
-	err = s.refreshTokenStore.RevokeRefreshToken(refreshTokenString)
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to revoke old refresh token: %w", err)
-	}
-
-	// Generate a new refresh token
-	ipAddress := refreshToken.IPAddress
-	userAgent := refreshToken.UserAgent
-
-	// Generate new access token and refresh token
-	accessToken, err := s.GenerateAccessToken(user)
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to generate access token: %w", err)
-	}
 
-	// Create new refresh token
-	newRefreshToken, err := s.refreshTokenStore.CreateRefreshToken(
-		user.UserID,
-		s.config.RefreshTokenDuration,
-		ipAddress,
-		userAgent,
-	)
+		// Generate new access token and refresh token
+		var err error
+		accessToken, newRefreshToken, err = s.GenerateTokenPairWithTransaction(user, refreshToken.IPAddress, refreshToken.UserAgent, tx)
+		return err
+	})
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to create new refresh token: %w", err)
-	}
-
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return "", nil, fmt.Errorf("failed to commit transaction: %w", err)
+		return "", nil, err
 	}
 
 	return accessToken, newRefreshToken, nil
@@ -276,6 +329,11 @@ func (s *JWTService) RevokeAllUserRefreshTokens(userID string) (int64, error) {
 	return s.refreshTokenStore.RevokeAllUserRefreshTokens(userID)
 }
 
+// RevokeAllUserRefreshTokensWithTransaction revokes all refresh tokens for a user within a transaction
+func (s *JWTService) RevokeAllUserRefreshTokensWithTransaction(userID string, tx *sql.Tx) (int64, error) {
+	return s.refreshTokenStore.RevokeAllUserRefreshTokensWithTransaction(userID, tx)
+}
+
 // BlacklistAccessToken adds an access token to the blacklist
 func (s *JWTService) BlacklistAccessToken(tokenString string) error {
 	// Parse the token to get the expiry time
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalMediaPath is the API route that serves locally stored files through signed URLs
+const LocalMediaPath = "/api/v1/media/"
+
+// LocalStorageConfig holds configuration for storing files on local disk
+type LocalStorageConfig struct {
+	Dir     string
+	BaseURL string
+}
+
+// DefaultLocalStorageConfig returns the local storage configuration from the environment with sensible defaults
+func DefaultLocalStorageConfig() LocalStorageConfig {
+	return LocalStorageConfig{
+		Dir:     getEnvOrDefault("STORAGE_LOCAL_DIR", "./uploads"),
+		BaseURL: strings.TrimRight(getEnvOrDefault("STORAGE_LOCAL_BASE_URL", "http://localhost:8080"), "/"),
+	}
+}
+
+// LocalStorage stores files under a directory on the API server
+// Files are served by the media route, which only accepts URLs signed by the URLSigner
+type LocalStorage struct {
+	config LocalStorageConfig
+	signer *URLSigner
+}
+
+// NewLocalStorage creates a new LocalStorage, creating its directory if needed
+func NewLocalStorage(config LocalStorageConfig, signer *URLSigner) (*LocalStorage, error) {
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &LocalStorage{
+		config: config,
+		signer: signer,
+	}, nil
+}
+
+func (s *LocalStorage) Backend() string {
+	return StorageBackendLocal
+}
+
+// Path returns the file path for a key, or an error if the key is invalid
+func (s *LocalStorage) Path(key string) (string, error) {
+	if err := validateStorageKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.config.Dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes the file to a temporary name first so readers never see a partial file
+func (s *LocalStorage) Put(key string, r io.Reader, size int64, contentType string) error {
+	path, err := s.Path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) Delete(key string) error {
+	path, err := s.Path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) Open(key string) (io.ReadCloser, error) {
+	path, err := s.Path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrStorageNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return file, nil
+}
+
+func (s *LocalStorage) SignedURL(key string, ttl time.Duration) (string, error) {
+	if err := validateStorageKey(key); err != nil {
+		return "", err
+	}
+
+	signed, _ := s.signer.Sign(key, ttl)
+	return s.config.BaseURL + LocalMediaPath + signed, nil
+}
+
+// Verify checks a signed media request for key
+func (s *LocalStorage) Verify(key, expires, signature string) error {
+	return s.signer.Verify(key, expires, signature)
+}
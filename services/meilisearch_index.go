@@ -0,0 +1,360 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/resilience"
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// MeilisearchConfig holds the connection settings for a Meilisearch server
+type MeilisearchConfig struct {
+	URL       string
+	APIKey    string
+	IndexName string
+	Timeout   time.Duration
+}
+
+// DefaultMeilisearchConfig returns the Meilisearch configuration from the environment
+func DefaultMeilisearchConfig() MeilisearchConfig {
+	indexName := os.Getenv("MEILISEARCH_INDEX")
+	if indexName == "" {
+		indexName = "recipes"
+	}
+
+	return MeilisearchConfig{
+		URL:       strings.TrimRight(os.Getenv("MEILISEARCH_URL"), "/"),
+		APIKey:    os.Getenv("MEILISEARCH_API_KEY"),
+		IndexName: indexName,
+		Timeout:   5 * time.Second,
+	}
+}
+
+// meilisearchDescriptionCropLength is the number of words kept around matches in description snippets
+const meilisearchDescriptionCropLength = 20
+
+// meilisearchMaxFacetValues is the most distinct values Meilisearch counts per facet
+const meilisearchMaxFacetValues = 1000
+
+// MeilisearchIndex searches recipes with Meilisearch
+// Meilisearch returns ranked IDs and highlights; the recipes themselves are loaded from PostgreSQL
+type MeilisearchIndex struct {
+	config      MeilisearchConfig
+	client      *http.Client
+	searchStore store.SearchStore
+}
+
+// NewMeilisearchIndex creates a new MeilisearchIndex
+func NewMeilisearchIndex(config MeilisearchConfig, searchStore store.SearchStore) (*MeilisearchIndex, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("MEILISEARCH_URL not set in environment")
+	}
+
+	return &MeilisearchIndex{
+		config:      config,
+		client:      &http.Client{Timeout: config.Timeout},
+		searchStore: searchStore,
+	}, nil
+}
+
+func (i *MeilisearchIndex) Engine() string {
+	return SearchEngineMeilisearch
+}
+
+// Setup creates the index if needed and configures which attributes can be searched and filtered
+func (i *MeilisearchIndex) Setup() error {
+	err := i.do(context.Background(), http.MethodPost, "/indexes", map[string]string{
+		"uid":        i.config.IndexName,
+		"primaryKey": "id",
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	return i.do(context.Background(), http.MethodPatch, i.indexPath("/settings"), map[string]interface{}{
+		"searchableAttributes": []string{"title", "description", "category_name"},
+		"filterableAttributes": []string{
+			"category_id", "difficulty_level", "total_time", "serving_size",
+			"estimated_cost_per_serving", "average_rating", "dietary_labels", "language",
+		},
+		// Total time facets add up the count of every distinct total time, so all of them must be returned
+		"faceting": map[string]interface{}{
+			"maxValuesPerFacet": meilisearchMaxFacetValues,
+		},
+	}, nil)
+}
+
+type meilisearchSearchResponse struct {
+	Hits []struct {
+		ID           int64   `json:"id"`
+		RankingScore float64 `json:"_rankingScore"`
+		Formatted    struct {
+			Title       string  `json:"title"`
+			Description *string `json:"description"`
+		} `json:"_formatted"`
+	} `json:"hits"`
+	EstimatedTotalHits int `json:"estimatedTotalHits"`
+}
+
+func (i *MeilisearchIndex) SearchRecipes(ctx context.Context, query string, opts store.RecipeListOptions) ([]*store.RecipeSearchResult, int, error) {
+	request := map[string]interface{}{
+		"q":                     query,
+		"offset":                (opts.Page - 1) * opts.Limit,
+		"limit":                 opts.Limit,
+		"filter":                meilisearchFilters(opts),
+		"attributesToRetrieve":  []string{"id"},
+		"attributesToHighlight": []string{"title"},
+		"attributesToCrop":      []string{"description"},
+		"cropLength":            meilisearchDescriptionCropLength,
+		"highlightPreTag":       store.HighlightStart,
+		"highlightPostTag":      store.HighlightStop,
+		"showRankingScore":      true,
+	}
+
+	var response meilisearchSearchResponse
+	if err := i.do(ctx, http.MethodPost, i.indexPath("/search"), request, &response); err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]int64, len(response.Hits))
+	for j, hit := range response.Hits {
+		ids[j] = hit.ID
+	}
+
+	// The index may briefly hold recipes that were unpublished since the last sync; those are skipped
+	recipes, err := i.searchStore.GetRecipesByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	byID := make(map[int64]*store.Recipe, len(recipes))
+	for _, recipe := range recipes {
+		byID[recipe.ID] = recipe
+	}
+
+	results := make([]*store.RecipeSearchResult, 0, len(recipes))
+	for _, hit := range response.Hits {
+		recipe, ok := byID[hit.ID]
+		if !ok {
+			continue
+		}
+
+		result := &store.RecipeSearchResult{
+			Recipe:         recipe,
+			TitleHighlight: store.HighlightToHTML(hit.Formatted.Title),
+			Rank:           hit.RankingScore,
+		}
+		if hit.Formatted.Description != nil && *hit.Formatted.Description != "" {
+			snippet := store.HighlightToHTML(*hit.Formatted.Description)
+			result.DescriptionSnippet = &snippet
+		}
+		results = append(results, result)
+	}
+
+	return results, response.EstimatedTotalHits, nil
+}
+
+type meilisearchMultiSearchResponse struct {
+	Results []struct {
+		FacetDistribution map[string]map[string]int `json:"facetDistribution"`
+	} `json:"results"`
+}
+
+// SearchFacets counts matches per facet value with Meilisearch's facet distribution
+// Each facet ignores its own filter, so the three facets are requested as separate queries in one multi-search
+func (i *MeilisearchIndex) SearchFacets(ctx context.Context, query string, opts store.RecipeListOptions) (*store.RecipeFacets, error) {
+	withoutDifficulty := opts
+	withoutDifficulty.Difficulty = nil
+	withoutCategory := opts
+	withoutCategory.CategoryID = nil
+	withoutTotalTime := opts
+	withoutTotalTime.MaxTotalTime = nil
+
+	queries := []map[string]interface{}{}
+	for _, facet := range []struct {
+		attribute string
+		opts      store.RecipeListOptions
+	}{
+		{"difficulty_level", withoutDifficulty},
+		{"category_id", withoutCategory},
+		{"total_time", withoutTotalTime},
+	} {
+		queries = append(queries, map[string]interface{}{
+			"indexUid": i.config.IndexName,
+			"q":        query,
+			"filter":   meilisearchFilters(facet.opts),
+			"facets":   []string{facet.attribute},
+			"limit":    0,
+		})
+	}
+
+	var response meilisearchMultiSearchResponse
+	if err := i.do(ctx, http.MethodPost, "/multi-search", map[string]interface{}{"queries": queries}, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Results) != len(queries) {
+		return nil, fmt.Errorf("meilisearch returned %d results for %d facet queries", len(response.Results), len(queries))
+	}
+
+	facets := store.NewRecipeFacets()
+	for _, facet := range facets.Difficulty {
+		facet.Count = response.Results[0].FacetDistribution["difficulty_level"][string(facet.Difficulty)]
+	}
+
+	categoryCounts := map[int64]int{}
+	categoryIDs := []int64{}
+	for value, count := range response.Results[1].FacetDistribution["category_id"] {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		categoryCounts[id] = count
+		categoryIDs = append(categoryIDs, id)
+	}
+	names, err := i.searchStore.GetCategoryNames(ctx, categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range categoryIDs {
+		// Categories deleted since the last sync are skipped
+		if name, ok := names[id]; ok {
+			facets.Category = append(facets.Category, &store.CategoryFacet{CategoryID: id, CategoryName: name, Count: categoryCounts[id]})
+		}
+	}
+	sort.Slice(facets.Category, func(a, b int) bool {
+		if facets.Category[a].Count != facets.Category[b].Count {
+			return facets.Category[a].Count > facets.Category[b].Count
+		}
+		return facets.Category[a].CategoryName < facets.Category[b].CategoryName
+	})
+
+	for value, count := range response.Results[2].FacetDistribution["total_time"] {
+		minutes, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		for _, facet := range facets.TotalTime {
+			if minutes <= float64(facet.MaxTotalTime) {
+				facet.Count += count
+			}
+		}
+	}
+
+	return facets, nil
+}
+
+// meilisearchFilters translates listing filters into Meilisearch filter expressions
+func meilisearchFilters(opts store.RecipeListOptions) []string {
+	filters := []string{}
+	if opts.CategoryID != nil {
+		filters = append(filters, "category_id = "+strconv.FormatInt(*opts.CategoryID, 10))
+	}
+	if opts.Difficulty != nil {
+		filters = append(filters, fmt.Sprintf("difficulty_level = %q", *opts.Difficulty))
+	}
+	if opts.MaxCost != nil {
+		filters = append(filters, "estimated_cost_per_serving <= "+strconv.FormatFloat(*opts.MaxCost, 'f', -1, 64))
+	}
+	if opts.MaxTotalTime != nil {
+		filters = append(filters, "total_time <= "+strconv.Itoa(*opts.MaxTotalTime))
+	}
+	if opts.MinServingSize != nil {
+		filters = append(filters, "serving_size >= "+strconv.Itoa(*opts.MinServingSize))
+	}
+	if opts.MaxServingSize != nil {
+		filters = append(filters, "serving_size <= "+strconv.Itoa(*opts.MaxServingSize))
+	}
+	for _, diet := range opts.Diets {
+		filters = append(filters, fmt.Sprintf("dietary_labels = %q", diet))
+	}
+	if opts.MinRating != nil {
+		filters = append(filters, "average_rating >= "+strconv.FormatFloat(*opts.MinRating, 'f', -1, 64))
+	}
+	if opts.Language != "" {
+		filters = append(filters, fmt.Sprintf("language = %q", opts.Language))
+	}
+	return filters
+}
+
+// IndexRecipes adds or replaces documents; Meilisearch applies the change asynchronously
+func (i *MeilisearchIndex) IndexRecipes(documents []*store.SearchDocument) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	return i.do(context.Background(), http.MethodPut, i.indexPath("/documents"), documents, nil)
+}
+
+// RemoveRecipes deletes documents; Meilisearch applies the change asynchronously
+func (i *MeilisearchIndex) RemoveRecipes(recipeIDs []int64) error {
+	if len(recipeIDs) == 0 {
+		return nil
+	}
+	return i.do(context.Background(), http.MethodPost, i.indexPath("/documents/delete-batch"), recipeIDs, nil)
+}
+
+func (i *MeilisearchIndex) Clear() error {
+	return i.do(context.Background(), http.MethodDelete, i.indexPath("/documents"), nil, nil)
+}
+
+func (i *MeilisearchIndex) indexPath(path string) string {
+	return "/indexes/" + i.config.IndexName + path
+}
+
+// do sends a JSON request to Meilisearch and decodes the response into out if it is not nil
+// Every call the index makes is idempotent (searches, and document writes keyed by recipe ID),
+// so failures are retried with backoff
+func (i *MeilisearchIndex) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode meilisearch request: %w", err)
+		}
+	}
+
+	return resilience.Do(ctx, resilience.DefaultPolicy(true), func(ctx context.Context) error {
+		var reader io.Reader
+		if payload != nil {
+			reader = bytes.NewReader(payload)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, i.config.URL+path, reader)
+		if err != nil {
+			return resilience.Permanent(fmt.Errorf("failed to create meilisearch request: %w", err))
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if i.config.APIKey != "" {
+			req.Header.Set("Authorization", "Bearer "+i.config.APIKey)
+		}
+
+		resp, err := i.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to call meilisearch: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			return resilience.NewStatusError(resp, fmt.Errorf("meilisearch %s %s returned %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(message))))
+		}
+
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return resilience.Permanent(fmt.Errorf("failed to decode meilisearch response: %w", err))
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMeilisearchDoStopsAtDeadline(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	index, err := NewMeilisearchIndex(MeilisearchConfig{URL: server.URL, IndexName: "recipes", Timeout: 30 * time.Second}, nil)
+	if err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	start := time.Now()
+	err = index.do(ctx, http.MethodPost, index.indexPath("/search"), map[string]interface{}{"q": "pasta"}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected do to return promptly, took %s", elapsed)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Fatalf("expected no requests to reach meilisearch, got %d", n)
+	}
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// MetricsRollupConfig controls when the daily metrics rollup runs
+type MetricsRollupConfig struct {
+	// RunAt is how long after midnight UTC the nightly rollup runs
+	RunAt time.Duration
+
+	// BackfillDays is how many past days are rolled up when the rollup is empty or has fallen behind
+	BackfillDays int
+}
+
+// DefaultMetricsRollupConfig returns the rollup configuration from the environment with sensible defaults
+func DefaultMetricsRollupConfig() MetricsRollupConfig {
+	return MetricsRollupConfig{
+		RunAt:        time.Duration(getEnvIntOrDefault("METRICS_ROLLUP_HOUR_UTC", 1)) * time.Hour,
+		BackfillDays: getEnvIntOrDefault("METRICS_ROLLUP_BACKFILL_DAYS", 90),
+	}
+}
+
+// MetricsRollup fills the metrics_daily table with each completed UTC day's activity
+type MetricsRollup struct {
+	config       MetricsRollupConfig
+	metricsStore store.MetricsStore
+}
+
+// NewMetricsRollup creates a new daily metrics rollup
+func NewMetricsRollup(config MetricsRollupConfig, metricsStore store.MetricsStore) *MetricsRollup {
+	return &MetricsRollup{
+		config:       config,
+		metricsStore: metricsStore,
+	}
+}
+
+// Start catches up on missed days right away, then rolls up the previous day every night
+func (r *MetricsRollup) Start() {
+	go func() {
+		for {
+			if err := r.Run(time.Now()); err != nil {
+				log.Printf("Failed to roll up daily metrics: %v", err)
+			}
+			time.Sleep(time.Until(r.nextRun(time.Now())))
+		}
+	}()
+}
+
+// Run rolls up every completed day since the last rolled up one, up to BackfillDays back
+// The last rolled up day is recomputed too, in case it was counted before the day was over
+func (r *MetricsRollup) Run(now time.Time) error {
+	yesterday := utcDay(now).AddDate(0, 0, -1)
+	from := yesterday.AddDate(0, 0, 1-max(r.config.BackfillDays, 1))
+
+	last, err := r.metricsStore.GetLastRolledUpDay()
+	if err != nil {
+		return err
+	}
+	if last != nil && last.After(from) {
+		from = *last
+	}
+	if from.After(yesterday) {
+		from = yesterday
+	}
+
+	return r.metricsStore.RollupDailyMetrics(from, yesterday)
+}
+
+// nextRun returns the next time after now that the nightly rollup is due
+func (r *MetricsRollup) nextRun(now time.Time) time.Time {
+	next := utcDay(now).Add(r.config.RunAt)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
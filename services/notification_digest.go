@@ -0,0 +1,111 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// NotificationDigestConfig controls how often batched notifications are emailed
+type NotificationDigestConfig struct {
+	// CheckInterval is how often closed batches are looked for; it is the most an email can lag behind its batch closing
+	CheckInterval time.Duration
+
+	// BatchSize caps the notifications emailed per check
+	BatchSize int
+
+	// QuietStartHour and QuietEndHour bound the night, in each user's own time zone, when their digests are held back
+	// until it ends; equal hours turn quiet hours off. Users without a time zone are emailed at any hour.
+	QuietStartHour int
+	QuietEndHour   int
+}
+
+// DefaultNotificationDigestConfig returns the digest configuration from the environment
+func DefaultNotificationDigestConfig() NotificationDigestConfig {
+	config := NotificationDigestConfig{
+		CheckInterval:  time.Duration(getEnvIntOrDefault("NOTIFICATION_DIGEST_INTERVAL_SECONDS", 60)) * time.Second,
+		BatchSize:      500,
+		QuietStartHour: getEnvIntOrDefault("NOTIFICATION_DIGEST_QUIET_START_HOUR", 22),
+		QuietEndHour:   getEnvIntOrDefault("NOTIFICATION_DIGEST_QUIET_END_HOUR", 8),
+	}
+	if config.QuietStartHour < 0 || config.QuietStartHour > 23 || config.QuietEndHour < 0 || config.QuietEndHour > 23 {
+		log.Printf("Ignoring digest quiet hours %d-%d; hours must be between 0 and 23", config.QuietStartHour, config.QuietEndHour)
+		config.QuietStartHour, config.QuietEndHour = 0, 0
+	}
+	return config
+}
+
+// NotificationDigest emails notifications whose batch window has closed
+// Each user gets one email covering all of their notifications that are due, rather than one per event
+type NotificationDigest struct {
+	config            NotificationDigestConfig
+	notificationStore store.NotificationStore
+	emailService      *EmailService
+}
+
+// NewNotificationDigest creates the notification digest sender
+func NewNotificationDigest(config NotificationDigestConfig, notificationStore store.NotificationStore, emailService *EmailService) *NotificationDigest {
+	return &NotificationDigest{
+		config:            config,
+		notificationStore: notificationStore,
+		emailService:      emailService,
+	}
+}
+
+// Start sends digests in the background every check interval, unless there is no email service
+func (d *NotificationDigest) Start() {
+	if d.emailService == nil || d.config.CheckInterval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(d.config.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := d.Run(); err != nil {
+				log.Printf("Failed to send notification digests: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// Run emails every user with notifications due, except those in their quiet hours
+// A failed email is logged and retried on the next check
+func (d *NotificationDigest) Run() error {
+	due, err := d.notificationStore.GetDueNotificationEmails(d.config.BatchSize, d.config.QuietStartHour, d.config.QuietEndHour)
+	if err != nil {
+		return err
+	}
+
+	// Notifications come grouped by user
+	for start := 0; start < len(due); {
+		end := start + 1
+		for end < len(due) && due[end].UserID == due[start].UserID {
+			end++
+		}
+		notifications := due[start:end]
+		start = end
+
+		recipient := notifications[0]
+		name := recipient.FirstName
+		if name == "" {
+			name = recipient.Username
+		}
+		if _, err := d.emailService.SendNotificationDigestEmail(recipient.Email, name, notifications); err != nil {
+			continue
+		}
+
+		ids := make([]int64, len(notifications))
+		for i, notification := range notifications {
+			ids[i] = notification.NotificationID
+		}
+		if err := d.notificationStore.MarkNotificationsEmailed(ids); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/dapoadedire/chefshare_be/utils"
+)
+
+const (
+	// MaxMentionsPerReview caps how many users a single review can notify
+	MaxMentionsPerReview = 10
+
+	// mentionExcerptLength is how much of the mentioning text is quoted in a notification email
+	mentionExcerptLength = 280
+)
+
+// NotificationService records @mentions and other activity, and notifies the users concerned in-app and by email
+// Events are batched as each user's notification preferences ask: while a batch is open, events of the same kind
+// about the same thing update one notification instead of adding another
+type NotificationService struct {
+	notificationStore store.NotificationStore
+	mentionStore      store.MentionStore
+	emailService      *EmailService
+}
+
+// NewNotificationService creates a new NotificationService
+// The email service may be nil, in which case only in-app notifications are created
+func NewNotificationService(notificationStore store.NotificationStore, mentionStore store.MentionStore, emailService *EmailService) *NotificationService {
+	return &NotificationService{
+		notificationStore: notificationStore,
+		mentionStore:      mentionStore,
+		emailService:      emailService,
+	}
+}
+
+// NotifyReviewMentions records the users mentioned in a review's comment and notifies each of them once
+// Unknown usernames and the author mentioning themselves are ignored. It returns the users that were mentioned
+func (s *NotificationService) NotifyReviewMentions(author *store.MentionedUser, recipe *store.Recipe, review *store.RecipeReview) ([]*store.MentionedUser, error) {
+	usernames := utils.ExtractMentions(review.Comment, MaxMentionsPerReview)
+	if len(usernames) == 0 {
+		return []*store.MentionedUser{}, nil
+	}
+
+	users, err := s.mentionStore.GetUsersByUsernames(usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	mentioned := []*store.MentionedUser{}
+	userIDs := []int64{}
+	for _, user := range users {
+		if user.ID == author.ID {
+			continue
+		}
+		mentioned = append(mentioned, user)
+		userIDs = append(userIDs, user.ID)
+	}
+
+	newlyMentioned, err := s.mentionStore.SaveReviewMentions(review.ID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	isNew := make(map[int64]bool, len(newlyMentioned))
+	for _, id := range newlyMentioned {
+		isNew[id] = true
+	}
+
+	message := func(count int) string {
+		return batchMessage(author.Username, count, "mentioned you in a review of", "mentioned you in reviews of", recipe.Title)
+	}
+	for _, user := range mentioned {
+		if !isNew[user.ID] {
+			continue
+		}
+
+		notification := &store.Notification{
+			UserID:   user.ID,
+			Type:     store.NotificationTypeMention,
+			ActorID:  &author.ID,
+			RecipeID: &recipe.ID,
+			ReviewID: &review.ID,
+		}
+		pref, err := s.notify(notification, fmt.Sprintf("mention:recipe:%d", recipe.ID), message)
+		if err != nil {
+			log.Printf("Failed to create mention notification for %s: %v", user.Username, err)
+			continue
+		}
+
+		// Unbatched mentions keep their own email quoting the review; batched ones go out in the digest
+		if pref.Email && pref.BatchWindowMinutes == 0 {
+			s.sendMentionEmail(user, author.Username, recipe, review.Comment)
+		}
+	}
+
+	return mentioned, nil
+}
+
+// NotifyFavorite tells a recipe's author that someone favorited it
+// Authors are not notified about favoriting their own recipes
+func (s *NotificationService) NotifyFavorite(actorID int64, actorUsername string, recipe *store.Recipe) {
+	if recipe.UserID == actorID {
+		return
+	}
+
+	notification := &store.Notification{
+		UserID:   recipe.UserID,
+		Type:     store.NotificationTypeFavorite,
+		ActorID:  &actorID,
+		RecipeID: &recipe.ID,
+	}
+	message := func(count int) string {
+		return batchMessage(actorUsername, count, "favorited", "favorited", recipe.Title)
+	}
+	if _, err := s.notify(notification, fmt.Sprintf("favorite:recipe:%d", recipe.ID), message); err != nil {
+		log.Printf("Failed to create favorite notification for recipe %d: %v", recipe.ID, err)
+	}
+}
+
+// NotifyReviewHelpful tells a reviewer that someone found their review of a recipe helpful
+func (s *NotificationService) NotifyReviewHelpful(actorID int64, actorUsername string, recipe *store.Recipe, review *store.RecipeReview) {
+	if review.UserID == actorID {
+		return
+	}
+
+	notification := &store.Notification{
+		UserID:   review.UserID,
+		Type:     store.NotificationTypeReviewHelpful,
+		ActorID:  &actorID,
+		RecipeID: &recipe.ID,
+		ReviewID: &review.ID,
+	}
+	message := func(count int) string {
+		return batchMessage(actorUsername, count, "found your review of", "found your review of", recipe.Title) + " helpful"
+	}
+	if _, err := s.notify(notification, fmt.Sprintf("review_helpful:review:%d", review.ID), message); err != nil {
+		log.Printf("Failed to create helpful review notification for review %d: %v", review.ID, err)
+	}
+}
+
+// NotifyRecipeRemoved tells the users who favorited a recipe that its author deleted it
+// The recipe is gone by the time they read it, so the notification names it rather than linking to it
+func (s *NotificationService) NotifyRecipeRemoved(actorID int64, actorUsername string, recipeTitle string, userIDs []int64) {
+	notifications := make([]*store.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID == actorID {
+			continue
+		}
+		notifications = append(notifications, &store.Notification{
+			UserID:  userID,
+			Type:    store.NotificationTypeRecipeRemoved,
+			ActorID: &actorID,
+			Message: fmt.Sprintf("@%s removed %q, a recipe you favorited", actorUsername, recipeTitle),
+		})
+	}
+
+	if err := s.notificationStore.CreateNotifications(notifications); err != nil {
+		log.Printf("Failed to create recipe removed notifications for %q: %v", recipeTitle, err)
+	}
+}
+
+// notify adds a notification, batched and emailed as its recipient prefers, and returns the preference used
+// Batches that are emailed go out in the recipient's digest once their window closes
+func (s *NotificationService) notify(notification *store.Notification, batchKey string, message func(count int) string) (store.NotificationPreference, error) {
+	pref, err := s.notificationStore.GetNotificationPreference(notification.UserID, notification.Type)
+	if err != nil {
+		// Fall back to the defaults rather than dropping the notification
+		log.Printf("Failed to get notification preference: %v", err)
+	}
+
+	batch := store.NotificationBatch{
+		Key:    batchKey,
+		Window: time.Duration(pref.BatchWindowMinutes) * time.Minute,
+		Email:  pref.Email && s.emailService != nil && !(notification.Type == store.NotificationTypeMention && pref.BatchWindowMinutes == 0),
+	}
+	_, err = s.notificationStore.AddNotification(notification, batch, message)
+	return pref, err
+}
+
+// batchMessage describes count events by different users, naming the latest actor
+// e.g. `@ada favorited "Jollof Rice"` or `@ada and 9 others favorited "Jollof Rice"`
+func batchMessage(actorUsername string, count int, verb string, pluralVerb string, title string) string {
+	switch count {
+	case 1:
+		return fmt.Sprintf("@%s %s %q", actorUsername, verb, title)
+	case 2:
+		return fmt.Sprintf("@%s and 1 other %s %q", actorUsername, pluralVerb, title)
+	default:
+		return fmt.Sprintf("@%s and %d others %s %q", actorUsername, count-1, pluralVerb, title)
+	}
+}
+
+// sendMentionEmail emails a mentioned user in the background
+func (s *NotificationService) sendMentionEmail(user *store.MentionedUser, actorUsername string, recipe *store.Recipe, text string) {
+	if s.emailService == nil {
+		return
+	}
+
+	name := user.FirstName
+	if name == "" {
+		name = user.Username
+	}
+	excerpt := truncateText(text, mentionExcerptLength)
+
+	go func() {
+		emailID, err := s.emailService.SendMentionEmail(user.Email, name, actorUsername, recipe.Title, recipe.ID, excerpt)
+		if err != nil {
+			log.Printf("Failed to send mention email to %s: %v", user.Email, err)
+		} else {
+			log.Printf("Mention email sent to %s with ID: %s", user.Email, emailID)
+		}
+	}()
+}
+
+// truncateText shortens text to at most max runes, ending with an ellipsis when cut
+func truncateText(text string, max int) string {
+	text = strings.TrimSpace(text)
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
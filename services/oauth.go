@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported OAuth providers
+const (
+	OAuthProviderGoogle = "google"
+	OAuthProviderGitHub = "github"
+)
+
+// OAuthProviderName returns the name of a provider as its users know it
+func OAuthProviderName(provider string) string {
+	switch provider {
+	case OAuthProviderGoogle:
+		return "Google"
+	case OAuthProviderGitHub:
+		return "GitHub"
+	default:
+		return provider
+	}
+}
+
+// OAuthClientConfig holds the credentials of an application registered with a provider
+type OAuthClientConfig struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// OAuthConfig holds the settings for signing in with OAuth providers
+// A provider is enabled when both its client ID and secret are set
+type OAuthConfig struct {
+	// RedirectURL is the frontend page providers send users back to; it posts the code and state to the callback endpoint
+	RedirectURL string
+	StateTTL    time.Duration
+	Timeout     time.Duration
+	Google      OAuthClientConfig
+	GitHub      OAuthClientConfig
+}
+
+// DefaultOAuthConfig returns the OAuth configuration from the environment
+func DefaultOAuthConfig() OAuthConfig {
+	frontendURL := getEnvOrDefault("FRONTEND_URL", "http://localhost:3000")
+
+	return OAuthConfig{
+		RedirectURL: getEnvOrDefault("OAUTH_REDIRECT_URL", strings.TrimRight(frontendURL, "/")+"/oauth/callback"),
+		StateTTL:    10 * time.Minute,
+		Timeout:     10 * time.Second,
+		Google: OAuthClientConfig{
+			ClientID:     os.Getenv("OAUTH_GOOGLE_CLIENT_ID"),
+			ClientSecret: os.Getenv("OAUTH_GOOGLE_CLIENT_SECRET"),
+		},
+		GitHub: OAuthClientConfig{
+			ClientID:     os.Getenv("OAUTH_GITHUB_CLIENT_ID"),
+			ClientSecret: os.Getenv("OAUTH_GITHUB_CLIENT_SECRET"),
+		},
+	}
+}
+
+// OAuthProfile is what a provider reports about the user who signed in
+type OAuthProfile struct {
+	// Subject is the provider's stable ID for the user; emails can change, subjects do not
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+	Username      string
+	Picture       string
+}
+
+type oauthProvider struct {
+	client   OAuthClientConfig
+	authURL  string
+	tokenURL string
+	scopes   []string
+	profile  func(s *OAuthService, ctx context.Context, accessToken string) (*OAuthProfile, error)
+}
+
+// OAuthService runs the authorization code flow with PKCE against the configured providers
+type OAuthService struct {
+	config    OAuthConfig
+	client    *http.Client
+	providers map[string]*oauthProvider
+}
+
+// NewOAuthService creates a new OAuthService with the providers that have credentials configured
+func NewOAuthService(config OAuthConfig) *OAuthService {
+	s := &OAuthService{
+		config:    config,
+		client:    &http.Client{Timeout: config.Timeout},
+		providers: map[string]*oauthProvider{},
+	}
+
+	if config.Google.ClientID != "" && config.Google.ClientSecret != "" {
+		s.providers[OAuthProviderGoogle] = &oauthProvider{
+			client:   config.Google,
+			authURL:  "https://accounts.google.com/o/oauth2/v2/auth",
+			tokenURL: "https://oauth2.googleapis.com/token",
+			scopes:   []string{"openid", "email", "profile"},
+			profile:  (*OAuthService).googleProfile,
+		}
+	}
+	if config.GitHub.ClientID != "" && config.GitHub.ClientSecret != "" {
+		s.providers[OAuthProviderGitHub] = &oauthProvider{
+			client:   config.GitHub,
+			authURL:  "https://github.com/login/oauth/authorize",
+			tokenURL: "https://github.com/login/oauth/access_token",
+			scopes:   []string{"read:user", "user:email"},
+			profile:  (*OAuthService).githubProfile,
+		}
+	}
+
+	return s
+}
+
+// Providers returns the names of the enabled providers in alphabetical order
+func (s *OAuthService) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Enabled reports whether a provider is configured
+func (s *OAuthService) Enabled(provider string) bool {
+	_, ok := s.providers[provider]
+	return ok
+}
+
+// StateTTL is how long a user has to complete an authorization request
+func (s *OAuthService) StateTTL() time.Duration {
+	return s.config.StateTTL
+}
+
+// NewCodeVerifier generates a PKCE code verifier to keep with an authorization request's state
+func NewCodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// AuthCodeURL returns the provider page that asks the user to sign in and approve access
+func (s *OAuthService) AuthCodeURL(provider string, state string, codeVerifier string) (string, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return "", fmt.Errorf("oauth provider %q is not configured", provider)
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.client.ClientID},
+		"redirect_uri":          {s.config.RedirectURL},
+		"scope":                 {strings.Join(p.scopes, " ")},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	return p.authURL + "?" + params.Encode(), nil
+}
+
+// Exchange trades an authorization code for an access token and returns the profile of the user who signed in
+func (s *OAuthService) Exchange(ctx context.Context, provider string, code string, codeVerifier string) (*OAuthProfile, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return nil, fmt.Errorf("oauth provider %q is not configured", provider)
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {s.config.RedirectURL},
+		"client_id":     {p.client.ClientID},
+		"client_secret": {p.client.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := s.doJSON(req, &token); err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	// GitHub reports a rejected code with 200 OK and an error field
+	if token.Error != "" || token.AccessToken == "" {
+		return nil, fmt.Errorf("provider rejected authorization code: %s %s", token.Error, token.ErrorDescription)
+	}
+
+	return p.profile(s, ctx, token.AccessToken)
+}
+
+func (s *OAuthService) googleProfile(ctx context.Context, accessToken string) (*OAuthProfile, error) {
+	var info struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		GivenName     string `json:"given_name"`
+		FamilyName    string `json:"family_name"`
+		Picture       string `json:"picture"`
+	}
+	if err := s.getJSON(ctx, "https://openidconnect.googleapis.com/v1/userinfo", accessToken, &info); err != nil {
+		return nil, fmt.Errorf("failed to get google profile: %w", err)
+	}
+	if info.Sub == "" {
+		return nil, fmt.Errorf("google profile has no subject")
+	}
+
+	return &OAuthProfile{
+		Subject:       info.Sub,
+		Email:         strings.ToLower(info.Email),
+		EmailVerified: info.EmailVerified,
+		FirstName:     info.GivenName,
+		LastName:      info.FamilyName,
+		Picture:       info.Picture,
+	}, nil
+}
+
+func (s *OAuthService) githubProfile(ctx context.Context, accessToken string) (*OAuthProfile, error) {
+	var user struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := s.getJSON(ctx, "https://api.github.com/user", accessToken, &user); err != nil {
+		return nil, fmt.Errorf("failed to get github profile: %w", err)
+	}
+	if user.ID == 0 {
+		return nil, fmt.Errorf("github profile has no id")
+	}
+
+	// The profile's public email may be unverified or hidden, so the primary address comes from the emails endpoint
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := s.getJSON(ctx, "https://api.github.com/user/emails", accessToken, &emails); err != nil {
+		return nil, fmt.Errorf("failed to get github emails: %w", err)
+	}
+
+	profile := &OAuthProfile{
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Username: user.Login,
+		Picture:  user.AvatarURL,
+	}
+	profile.FirstName, profile.LastName, _ = strings.Cut(strings.TrimSpace(user.Name), " ")
+	for _, email := range emails {
+		if email.Primary {
+			profile.Email = strings.ToLower(email.Email)
+			profile.EmailVerified = email.Verified
+			break
+		}
+	}
+
+	return profile, nil
+}
+
+func (s *OAuthService) getJSON(ctx context.Context, endpoint string, accessToken string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	return s.doJSON(req, out)
+}
+
+func (s *OAuthService) doJSON(req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %d: %s", req.URL.Host, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
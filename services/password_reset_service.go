@@ -126,13 +126,13 @@ func (s *EmailService) SendPasswordResetEmail(email, name, otp string) (string,
 		ReplyTo: fmt.Sprintf("Chefshare <%s>", replyTo),
 	}
 
-	sent, err := s.client.Emails.SendWithContext(ctx, params)
+	id, err := s.send(ctx, params)
 	if err != nil {
 		log.Printf("Failed to send password reset email to %s: %v", email, err)
 		return "", err
 	}
 
-	return sent.Id, nil
+	return id, nil
 }
 
 // SendPasswordChangedEmail notifies the user that their password has been changed
@@ -234,11 +234,11 @@ func (s *EmailService) SendPasswordChangedEmail(email, name string) (string, err
 		ReplyTo: fmt.Sprintf("Chefshare <%s>", replyTo),
 	}
 
-	sent, err := s.client.Emails.SendWithContext(ctx, params)
+	id, err := s.send(ctx, params)
 	if err != nil {
 		log.Printf("Failed to send password changed email to %s: %v", email, err)
 		return "", err
 	}
 
-	return sent.Id, nil
+	return id, nil
 }
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/google/uuid"
+)
+
+// photoImportRetrySchedule is how long to wait before each retry of a photo that could not be downloaded
+var photoImportRetrySchedule = []time.Duration{time.Minute, 10 * time.Minute, time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+// photoImportExtensions maps the image types accepted for imported photos to file extensions, as for uploads
+var photoImportExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// errPhotoImportAddressBlocked is returned when a photo link would connect to a private address
+var errPhotoImportAddressBlocked = errors.New("photo host resolves to a private address")
+
+// PhotoImportConfig controls the background worker that copies externally linked photos into storage
+type PhotoImportConfig struct {
+	Timeout time.Duration
+
+	// MaxBytes caps the size of an imported photo, as for uploads
+	MaxBytes int64
+
+	// MaxAttempts is how many times a photo is downloaded before it is left on its external link
+	MaxAttempts int
+
+	// AllowPrivateNetworks permits links to private addresses, for local development only
+	AllowPrivateNetworks bool
+
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// DefaultPhotoImportConfig returns the photo import configuration from the environment with sensible defaults
+func DefaultPhotoImportConfig() PhotoImportConfig {
+	return PhotoImportConfig{
+		Timeout:              time.Duration(getEnvIntOrDefault("PHOTO_IMPORT_TIMEOUT_SECONDS", 20)) * time.Second,
+		MaxBytes:             5 << 20,
+		MaxAttempts:          getEnvIntOrDefault("PHOTO_IMPORT_MAX_ATTEMPTS", len(photoImportRetrySchedule)+1),
+		AllowPrivateNetworks: getEnvOrDefault("PHOTO_IMPORT_ALLOW_PRIVATE_NETWORKS", "false") == "true",
+		PollInterval:         time.Duration(getEnvIntOrDefault("PHOTO_IMPORT_POLL_SECONDS", 30)) * time.Second,
+		BatchSize:            10,
+	}
+}
+
+// photoImportError is a failure that retrying will not fix, such as a link to something that is not an image
+type photoImportError struct {
+	message string
+}
+
+func (e *photoImportError) Error() string {
+	return e.message
+}
+
+// PhotoImporter downloads photos that recipes link to on other sites and re-hosts them in our storage,
+// so recipes keep their photos when the original host removes them or blocks hotlinking
+// Photos are queued in the database when added by link, so imports survive restarts and are shared out between instances.
+type PhotoImporter struct {
+	config     PhotoImportConfig
+	mediaStore store.RecipeMediaStore
+	storage    Storage
+	client     *http.Client
+}
+
+// NewPhotoImporter creates a new photo importer
+func NewPhotoImporter(config PhotoImportConfig, mediaStore store.RecipeMediaStore, storage Storage) *PhotoImporter {
+	transport := newPublicOnlyTransport(config.Timeout, config.AllowPrivateNetworks, errPhotoImportAddressBlocked)
+
+	return &PhotoImporter{
+		config:     config,
+		mediaStore: mediaStore,
+		storage:    storage,
+		client: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: transport,
+		},
+	}
+}
+
+// Start imports queued photos in the background every poll interval
+func (p *PhotoImporter) Start() {
+	go func() {
+		ticker := time.NewTicker(p.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			for {
+				imported, err := p.ImportDue()
+				if err != nil {
+					log.Printf("Failed to import recipe photos: %v", err)
+				}
+				if imported < p.config.BatchSize {
+					break
+				}
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// ImportDue imports a batch of queued photos and returns how many were claimed
+// A photo that cannot be imported is retried with backoff, then left on its external link.
+func (p *PhotoImporter) ImportDue() (int, error) {
+	// Hold each photo long enough for every download in the batch to time out
+	imports, err := p.mediaStore.ClaimPhotoImports(p.config.BatchSize, time.Duration(p.config.BatchSize+1)*p.config.Timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, photoImport := range imports {
+		err := p.importPhoto(photoImport)
+		if err == nil {
+			continue
+		}
+
+		var retryAt *time.Time
+		var permanent *photoImportError
+		if !errors.As(err, &permanent) && photoImport.Attempts < p.config.MaxAttempts {
+			next := time.Now().Add(photoImportRetrySchedule[min(photoImport.Attempts, len(photoImportRetrySchedule))-1])
+			retryAt = &next
+		}
+		if retryAt == nil {
+			log.Printf("Giving up importing photo %d from %s after %d attempts: %v", photoImport.PhotoID, photoImport.SourceURL, photoImport.Attempts, err)
+		}
+		if err := p.mediaStore.FailPhotoImport(photoImport.PhotoID, err.Error(), retryAt); err != nil {
+			return len(imports), err
+		}
+	}
+
+	return len(imports), nil
+}
+
+// importPhoto downloads one photo, stores it under a new key and points the photo at it
+func (p *PhotoImporter) importPhoto(photoImport *store.PhotoImport) error {
+	data, contentType, err := p.download(photoImport.SourceURL)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("recipes/%d/%s%s", photoImport.RecipeID, uuid.NewString(), photoImportExtensions[contentType])
+	if err := p.storage.Put(key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return fmt.Errorf("failed to store photo: %w", err)
+	}
+
+	err = p.mediaStore.CompletePhotoImport(photoImport.PhotoID, photoImport.SourceURL, key)
+	if err != nil {
+		if err := p.storage.Delete(key); err != nil {
+			log.Printf("Failed to clean up imported photo %s: %v", key, err)
+		}
+		if errors.Is(err, store.ErrNotFound) {
+			// The photo was deleted or replaced while it was downloaded, so there is nothing left to do
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// download fetches a photo and checks that it is a JPEG, PNG or WebP image within the size limit
+// It returns the photo and its content type, judged from its contents rather than the server's headers.
+func (p *PhotoImporter) download(sourceURL string) ([]byte, string, error) {
+	u, err := url.Parse(sourceURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, "", &photoImportError{"photo link is not a valid http or https URL"}
+	}
+
+	resp, err := p.client.Get(sourceURL)
+	if err != nil {
+		if errors.Is(err, errPhotoImportAddressBlocked) {
+			return nil, "", &photoImportError{errPhotoImportAddressBlocked.Error()}
+		}
+		return nil, "", fmt.Errorf("failed to download photo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return nil, "", &photoImportError{fmt.Sprintf("photo link returned %d", resp.StatusCode)}
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, "", fmt.Errorf("photo link returned %d", resp.StatusCode)
+	case resp.ContentLength > p.config.MaxBytes:
+		return nil, "", &photoImportError{fmt.Sprintf("photo is larger than %d MB", p.config.MaxBytes>>20)}
+	}
+
+	// Read one byte past the limit to tell a photo of exactly the limit from a larger one
+	data, err := io.ReadAll(io.LimitReader(resp.Body, p.config.MaxBytes+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download photo: %w", err)
+	}
+	if int64(len(data)) > p.config.MaxBytes {
+		return nil, "", &photoImportError{fmt.Sprintf("photo is larger than %d MB", p.config.MaxBytes>>20)}
+	}
+
+	contentType := http.DetectContentType(data)
+	if _, ok := photoImportExtensions[contentType]; !ok {
+		return nil, "", &photoImportError{"photo link is not a JPEG, PNG or WebP image"}
+	}
+
+	return data, contentType, nil
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// QuotaConfig holds per-user content creation limits
+// A limit of zero or less disables that check
+type QuotaConfig struct {
+	RecipesPerDay     int
+	ReviewsPerHour    int
+	InvitationsPerDay int
+}
+
+// DefaultQuotaConfig returns the quota configuration from the environment with sensible defaults
+func DefaultQuotaConfig() QuotaConfig {
+	return QuotaConfig{
+		RecipesPerDay:     getEnvIntOrDefault("QUOTA_RECIPES_PER_DAY", 20),
+		ReviewsPerHour:    getEnvIntOrDefault("QUOTA_REVIEWS_PER_HOUR", 10),
+		InvitationsPerDay: getEnvIntOrDefault("QUOTA_INVITATIONS_PER_DAY", 20),
+	}
+}
+
+// getEnvIntOrDefault returns an integer environment variable or the default if unset or invalid
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+// QuotaExceededError is returned when a user has hit a creation limit
+type QuotaExceededError struct {
+	Resource string
+	Limit    int
+	Window   time.Duration
+
+	// RetryAfter is how long until the quota frees up, when that is sooner than Window
+	RetryAfter time.Duration
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s limit reached: at most %d per %s", e.Resource, e.Limit, formatWindow(e.Window))
+}
+
+// formatWindow renders a quota window as a human-friendly unit
+func formatWindow(window time.Duration) string {
+	switch window {
+	case time.Hour:
+		return "hour"
+	case 24 * time.Hour:
+		return "day"
+	default:
+		return window.String()
+	}
+}
+
+// QuotaService enforces per-user creation limits to slow down spam accounts
+type QuotaService struct {
+	config          QuotaConfig
+	recipeStore     store.RecipeStore
+	reviewStore     store.ReviewStore
+	invitationStore store.InvitationStore
+}
+
+// NewQuotaService creates a new quota service with the given configuration
+func NewQuotaService(config QuotaConfig, recipeStore store.RecipeStore, reviewStore store.ReviewStore, invitationStore store.InvitationStore) *QuotaService {
+	return &QuotaService{
+		config:          config,
+		recipeStore:     recipeStore,
+		reviewStore:     reviewStore,
+		invitationStore: invitationStore,
+	}
+}
+
+// CreationQuota describes a user's progress against a creation limit over a rolling window
+type CreationQuota struct {
+	Limit         int `json:"limit"`
+	Used          int `json:"used"`
+	Remaining     int `json:"remaining"`
+	WindowSeconds int `json:"window_seconds"`
+}
+
+// Quotas returns the user's progress against each enabled creation limit, keyed by resource
+func (s *QuotaService) Quotas(userID int64) (map[string]*CreationQuota, error) {
+	limits := []struct {
+		resource string
+		limit    int
+		window   time.Duration
+		count    func(userID int64, since time.Time) (int, error)
+	}{
+		{"recipe", s.config.RecipesPerDay, 24 * time.Hour, s.recipeStore.CountRecipesCreatedSince},
+		{"review", s.config.ReviewsPerHour, time.Hour, s.reviewStore.CountReviewsCreatedSince},
+		{"invitation", s.config.InvitationsPerDay, 24 * time.Hour, s.invitationStore.CountInvitationsSince},
+	}
+
+	quotas := make(map[string]*CreationQuota)
+	for _, l := range limits {
+		if l.limit <= 0 {
+			continue
+		}
+
+		used, err := l.count(userID, time.Now().Add(-l.window))
+		if err != nil {
+			return nil, err
+		}
+
+		quotas[l.resource] = &CreationQuota{
+			Limit:         l.limit,
+			Used:          used,
+			Remaining:     max(l.limit-used, 0),
+			WindowSeconds: int(l.window.Seconds()),
+		}
+	}
+
+	return quotas, nil
+}
+
+// CheckRecipeCreation returns a QuotaExceededError if the user has created too many recipes today
+func (s *QuotaService) CheckRecipeCreation(userID int64) error {
+	if s.config.RecipesPerDay <= 0 {
+		return nil
+	}
+
+	window := 24 * time.Hour
+	count, err := s.recipeStore.CountRecipesCreatedSince(userID, time.Now().Add(-window))
+	if err != nil {
+		return err
+	}
+
+	if count >= s.config.RecipesPerDay {
+		return &QuotaExceededError{Resource: "recipe creation", Limit: s.config.RecipesPerDay, Window: window}
+	}
+
+	return nil
+}
+
+// CheckReviewCreation returns a QuotaExceededError if the user has written too many reviews this hour
+func (s *QuotaService) CheckReviewCreation(userID int64) error {
+	if s.config.ReviewsPerHour <= 0 {
+		return nil
+	}
+
+	window := time.Hour
+	count, err := s.reviewStore.CountReviewsCreatedSince(userID, time.Now().Add(-window))
+	if err != nil {
+		return err
+	}
+
+	if count >= s.config.ReviewsPerHour {
+		return &QuotaExceededError{Resource: "review creation", Limit: s.config.ReviewsPerHour, Window: window}
+	}
+
+	return nil
+}
+
+// CheckInvitationCreation returns a QuotaExceededError if the user has sent too many invitations today
+func (s *QuotaService) CheckInvitationCreation(userID int64) error {
+	if s.config.InvitationsPerDay <= 0 {
+		return nil
+	}
+
+	window := 24 * time.Hour
+	count, err := s.invitationStore.CountInvitationsSince(userID, time.Now().Add(-window))
+	if err != nil {
+		return err
+	}
+
+	if count >= s.config.InvitationsPerDay {
+		return &QuotaExceededError{Resource: "invitation", Limit: s.config.InvitationsPerDay, Window: window}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"log"
+	"sync"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// RecipeEventType describes how a recipe changed
+type RecipeEventType string
+
+const (
+	RecipeCreated RecipeEventType = "created"
+	RecipeUpdated RecipeEventType = "updated"
+	RecipeDeleted RecipeEventType = "deleted"
+)
+
+// RecipeEvent is published after a recipe change has been saved
+type RecipeEvent struct {
+	Type     RecipeEventType
+	RecipeID int64
+
+	// AuthorID is the recipe author's internal ID, or 0 if the change did not carry it
+	AuthorID int64
+}
+
+// RecipeEvents delivers recipe changes to the caches and indexes derived from recipes
+// Listeners run synchronously in the order they subscribed and should return quickly;
+// a listener that panics is logged and does not stop the others.
+type RecipeEvents struct {
+	mu        sync.RWMutex
+	listeners []recipeListener
+}
+
+type recipeListener struct {
+	name string
+	fn   func(RecipeEvent)
+}
+
+// NewRecipeEvents creates an event bus with no listeners
+func NewRecipeEvents() *RecipeEvents {
+	return &RecipeEvents{}
+}
+
+// Subscribe registers fn to be called for every recipe event; name identifies it in logs
+func (e *RecipeEvents) Subscribe(name string, fn func(RecipeEvent)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.listeners = append(e.listeners, recipeListener{name: name, fn: fn})
+}
+
+// Publish delivers event to every listener
+func (e *RecipeEvents) Publish(event RecipeEvent) {
+	e.mu.RLock()
+	listeners := e.listeners
+	e.mu.RUnlock()
+
+	for _, listener := range listeners {
+		e.deliver(listener, event)
+	}
+}
+
+func (e *RecipeEvents) deliver(listener recipeListener, event RecipeEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Recipe event listener %s panicked on %s recipe %d: %v", listener.name, event.Type, event.RecipeID, r)
+		}
+	}()
+	listener.fn(event)
+}
+
+// RecipeEventStore wraps a RecipeStore and RecipeTaxonomyStore and publishes an event after each successful
+// change to a recipe, its tags or its dietary labels, so handlers never need to invalidate derived data themselves
+type RecipeEventStore struct {
+	store.RecipeStore
+	store.RecipeTaxonomyStore
+	events *RecipeEvents
+}
+
+// NewRecipeEventStore wraps recipeStore and taxonomyStore so their changes are published to events
+func NewRecipeEventStore(recipeStore store.RecipeStore, taxonomyStore store.RecipeTaxonomyStore, events *RecipeEvents) *RecipeEventStore {
+	return &RecipeEventStore{
+		RecipeStore:         recipeStore,
+		RecipeTaxonomyStore: taxonomyStore,
+		events:              events,
+	}
+}
+
+func (s *RecipeEventStore) publish(err error, eventType RecipeEventType, recipeID int64) error {
+	return s.publishWithAuthor(err, eventType, recipeID, 0)
+}
+
+func (s *RecipeEventStore) publishWithAuthor(err error, eventType RecipeEventType, recipeID int64, authorID int64) error {
+	if err == nil {
+		s.events.Publish(RecipeEvent{Type: eventType, RecipeID: recipeID, AuthorID: authorID})
+	}
+	return err
+}
+
+func (s *RecipeEventStore) CreateRecipe(recipe *store.Recipe) error {
+	return s.publishWithAuthor(s.RecipeStore.CreateRecipe(recipe), RecipeCreated, recipe.ID, recipe.UserID)
+}
+
+func (s *RecipeEventStore) CreateCompleteRecipe(recipe *store.Recipe, ingredients []*store.RecipeIngredient, steps []*store.RecipeStep) error {
+	return s.publishWithAuthor(s.RecipeStore.CreateCompleteRecipe(recipe, ingredients, steps), RecipeCreated, recipe.ID, recipe.UserID)
+}
+
+func (s *RecipeEventStore) UpdateRecipe(recipe *store.Recipe) error {
+	return s.publishWithAuthor(s.RecipeStore.UpdateRecipe(recipe), RecipeUpdated, recipe.ID, recipe.UserID)
+}
+
+func (s *RecipeEventStore) SetRecipeDietaryLabels(recipeID int64, labels []string) error {
+	return s.publish(s.RecipeStore.SetRecipeDietaryLabels(recipeID, labels), RecipeUpdated, recipeID)
+}
+
+func (s *RecipeEventStore) RestoreRecipe(id int64) error {
+	return s.publish(s.RecipeStore.RestoreRecipe(id), RecipeUpdated, id)
+}
+
+// DeleteRecipe looks up the recipe's author first, since listeners can no longer find it afterwards
+func (s *RecipeEventStore) DeleteRecipe(id int64) error {
+	var authorID int64
+	if recipe, err := s.RecipeStore.GetRecipeByID(id); err == nil && recipe != nil {
+		authorID = recipe.UserID
+	}
+	return s.publishWithAuthor(s.RecipeStore.DeleteRecipe(id), RecipeDeleted, id, authorID)
+}
+
+func (s *RecipeEventStore) AddRecipeTag(recipeID int64, tagID int64) error {
+	return s.publish(s.RecipeTaxonomyStore.AddRecipeTag(recipeID, tagID), RecipeUpdated, recipeID)
+}
+
+func (s *RecipeEventStore) RemoveRecipeTag(recipeID int64, tagID int64) error {
+	return s.publish(s.RecipeTaxonomyStore.RemoveRecipeTag(recipeID, tagID), RecipeUpdated, recipeID)
+}
+
+func (s *RecipeEventStore) SetRecipeTags(recipeID int64, names []string) (*store.RecipeTagsUpdate, error) {
+	update, err := s.RecipeTaxonomyStore.SetRecipeTags(recipeID, names)
+	return update, s.publish(err, RecipeUpdated, recipeID)
+}
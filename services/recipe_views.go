@@ -0,0 +1,102 @@
+package services
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// RecipeViewConfig controls how often recipe views are flushed to the database
+type RecipeViewConfig struct {
+	FlushInterval time.Duration
+}
+
+// DefaultRecipeViewConfig returns the recipe view configuration from the environment
+func DefaultRecipeViewConfig() RecipeViewConfig {
+	return RecipeViewConfig{
+		FlushInterval: time.Duration(getEnvIntOrDefault("RECIPE_VIEWS_FLUSH_INTERVAL_SECONDS", 60)) * time.Second,
+	}
+}
+
+type recipeViewKey struct {
+	recipeID int64
+	day      time.Time
+}
+
+// RecipeViewService counts how often recipes are viewed, per UTC day, for their authors' statistics
+// Like API usage, views are rolled up in memory and added to the database every flush interval;
+// views not yet flushed are lost if the process exits
+type RecipeViewService struct {
+	config     RecipeViewConfig
+	statsStore store.RecipeStatsStore
+
+	mu      sync.Mutex
+	pending map[recipeViewKey]int
+}
+
+// NewRecipeViewService creates a new recipe view service
+func NewRecipeViewService(config RecipeViewConfig, statsStore store.RecipeStatsStore) *RecipeViewService {
+	return &RecipeViewService{
+		config:     config,
+		statsStore: statsStore,
+		pending:    make(map[recipeViewKey]int),
+	}
+}
+
+// Start flushes pending views in the background every flush interval
+func (s *RecipeViewService) Start() {
+	go func() {
+		ticker := time.NewTicker(s.config.FlushInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := s.Flush(); err != nil {
+				log.Printf("Failed to flush recipe views: %v", err)
+			}
+		}
+	}()
+}
+
+// Record counts one view of a recipe
+func (s *RecipeViewService) Record(recipeID int64) {
+	key := recipeViewKey{recipeID: recipeID, day: utcDay(time.Now())}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[key]++
+}
+
+// Flush adds pending views to the database
+// If that fails the views are kept and retried on the next flush
+func (s *RecipeViewService) Flush() error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[recipeViewKey]int)
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	increments := make([]*store.RecipeViewIncrement, 0, len(pending))
+	for key, count := range pending {
+		increments = append(increments, &store.RecipeViewIncrement{
+			RecipeID: key.recipeID,
+			Day:      key.day,
+			Count:    count,
+		})
+	}
+
+	if err := s.statsStore.AddRecipeViews(increments); err != nil {
+		s.mu.Lock()
+		for key, count := range pending {
+			s.pending[key] += count
+		}
+		s.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
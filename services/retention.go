@@ -0,0 +1,188 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// Data retention policies, named after the kind of record they purge
+const (
+	RetentionAuditLog    = "audit_log"
+	RetentionViewEvents  = "view_events"
+	RetentionEmailOutbox = "email_outbox"
+)
+
+// RetentionConfig is how long each kind of record is kept before it is purged; zero or less keeps it forever
+type RetentionConfig struct {
+	// AuditLog covers the recipe change feed
+	AuditLog time.Duration
+
+	// ViewEvents covers the daily view counts behind recipe statistics
+	ViewEvents time.Duration
+
+	// EmailOutbox covers the record of each email sent, once it has been counted in the daily metrics
+	EmailOutbox time.Duration
+
+	// CheckInterval is how often the policies are applied
+	CheckInterval time.Duration
+
+	// BatchSize caps the rows deleted per statement, so purges never hold locks for long
+	BatchSize int
+}
+
+// DefaultRetentionConfig returns the retention policies from the environment
+// Email records are kept for 90 days by default; everything else is kept until a window is set
+func DefaultRetentionConfig() RetentionConfig {
+	day := 24 * time.Hour
+	return RetentionConfig{
+		AuditLog:      time.Duration(getEnvIntOrDefault("RETENTION_AUDIT_LOG_DAYS", 0)) * day,
+		ViewEvents:    time.Duration(getEnvIntOrDefault("RETENTION_VIEW_EVENTS_DAYS", 0)) * day,
+		EmailOutbox:   time.Duration(getEnvIntOrDefault("RETENTION_EMAIL_OUTBOX_DAYS", 90)) * day,
+		CheckInterval: time.Duration(getEnvIntOrDefault("RETENTION_CHECK_INTERVAL_MINUTES", 60)) * time.Minute,
+		BatchSize:     5000,
+	}
+}
+
+// RetentionPolicyStatus describes one retention policy and what it has purged since the server started
+type RetentionPolicyStatus struct {
+	Policy string `json:"policy"`
+	Table  string `json:"table"`
+	// RetentionDays is how long records are kept; 0 keeps them forever
+	RetentionDays int        `json:"retention_days"`
+	RowsPurged    int64      `json:"rows_purged"`
+	LastPurgedAt  *time.Time `json:"last_purged_at,omitempty"`
+}
+
+// retentionPolicy is a retention window and the purge that enforces it
+type retentionPolicy struct {
+	name   string
+	table  string
+	window time.Duration
+	purge  func(before time.Time, limit int) (int64, error)
+}
+
+// RetentionPurger deletes records older than their retention window in the background
+// Counts of rows removed are kept per policy for the admin status endpoint and Prometheus.
+type RetentionPurger struct {
+	config   RetentionConfig
+	policies []retentionPolicy
+
+	mu           sync.Mutex
+	purged       map[string]int64
+	lastPurgedAt map[string]time.Time
+}
+
+// NewRetentionPurger creates the retention purger
+func NewRetentionPurger(config RetentionConfig, retentionStore store.RetentionStore) *RetentionPurger {
+	return &RetentionPurger{
+		config: config,
+		policies: []retentionPolicy{
+			{RetentionAuditLog, "recipe_activity", config.AuditLog, retentionStore.PurgeRecipeActivity},
+			{RetentionViewEvents, "recipe_daily_views", config.ViewEvents, retentionStore.PurgeRecipeDailyViews},
+			{RetentionEmailOutbox, "email_sends", config.EmailOutbox, retentionStore.PurgeEmailSends},
+		},
+		purged:       map[string]int64{},
+		lastPurgedAt: map[string]time.Time{},
+	}
+}
+
+// Start applies the policies in the background every check interval, unless every window is off
+func (p *RetentionPurger) Start() {
+	enabled := false
+	for _, policy := range p.policies {
+		enabled = enabled || policy.window > 0
+	}
+	if !enabled {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(p.config.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := p.Run(time.Now()); err != nil {
+				log.Printf("Failed to apply data retention policies: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// Run purges every record older than its policy's window, in batches
+// A failing policy does not stop the others; the first error is returned.
+func (p *RetentionPurger) Run(now time.Time) error {
+	var firstErr error
+	for _, policy := range p.policies {
+		if policy.window <= 0 {
+			continue
+		}
+
+		total, err := p.runPolicy(policy, now.Add(-policy.window))
+		if total > 0 {
+			log.Printf("Purged %d rows from %s older than %d days", total, policy.table, int(policy.window.Hours()/24))
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// runPolicy deletes a policy's records from before cutoff until none are left, counting them as it goes
+func (p *RetentionPurger) runPolicy(policy retentionPolicy, cutoff time.Time) (int64, error) {
+	var total int64
+	for {
+		deleted, err := policy.purge(cutoff, p.config.BatchSize)
+
+		p.mu.Lock()
+		p.purged[policy.name] += deleted
+		p.lastPurgedAt[policy.name] = time.Now()
+		p.mu.Unlock()
+
+		total += deleted
+		if err != nil || deleted < int64(p.config.BatchSize) {
+			return total, err
+		}
+	}
+}
+
+// Status returns every policy with its window and the rows it has purged since the server started
+func (p *RetentionPurger) Status() []RetentionPolicyStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	statuses := make([]RetentionPolicyStatus, len(p.policies))
+	for i, policy := range p.policies {
+		statuses[i] = RetentionPolicyStatus{
+			Policy:        policy.name,
+			Table:         policy.table,
+			RetentionDays: max(int(policy.window.Hours()/24), 0),
+			RowsPurged:    p.purged[policy.name],
+		}
+		if at, ok := p.lastPurgedAt[policy.name]; ok {
+			statuses[i].LastPurgedAt = &at
+		}
+	}
+	return statuses
+}
+
+// WritePrometheus writes retention_rows_purged_total, the rows each policy has removed since the server started
+func (p *RetentionPurger) WritePrometheus(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# HELP retention_rows_purged_total Rows deleted by data retention policies, by policy and table.\n")
+	b.WriteString("# TYPE retention_rows_purged_total counter\n")
+	for _, status := range p.Status() {
+		fmt.Fprintf(&b, "retention_rows_purged_total{policy=%s,table=%s} %d\n",
+			quoteLabel(status.Policy), quoteLabel(status.Table), status.RowsPurged)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// ReviewModerationConfig controls the background worker that runs bulk review moderation jobs
+type ReviewModerationConfig struct {
+	PollInterval time.Duration
+
+	// BatchSize caps the reviews hidden or deleted per statement, so a large job never holds locks for long
+	BatchSize int
+
+	// StaleAfter is how long a job may stay running before another worker takes it over
+	StaleAfter time.Duration
+}
+
+// DefaultReviewModerationConfig returns the review moderation configuration from the environment with sensible defaults
+func DefaultReviewModerationConfig() ReviewModerationConfig {
+	return ReviewModerationConfig{
+		PollInterval: time.Duration(getEnvIntOrDefault("REVIEW_MODERATION_POLL_SECONDS", 5)) * time.Second,
+		BatchSize:    max(getEnvIntOrDefault("REVIEW_MODERATION_BATCH_SIZE", 500), 1),
+		StaleAfter:   30 * time.Minute,
+	}
+}
+
+// ReviewModerationService hides or deletes every review by a user or matching a piece of text, outside of any request
+// Jobs are queued in the database, so they survive restarts and are shared out between instances
+type ReviewModerationService struct {
+	config          ReviewModerationConfig
+	moderationStore store.ReviewModerationStore
+
+	// wake asks the background loop to look for queued jobs before the next poll
+	wake chan struct{}
+}
+
+// NewReviewModerationService creates a new review moderation service
+func NewReviewModerationService(config ReviewModerationConfig, moderationStore store.ReviewModerationStore) *ReviewModerationService {
+	return &ReviewModerationService{
+		config:          config,
+		moderationStore: moderationStore,
+		wake:            make(chan struct{}, 1),
+	}
+}
+
+// Start runs queued jobs in the background every poll interval, or sooner when a job is queued
+func (s *ReviewModerationService) Start() {
+	go func() {
+		ticker := time.NewTicker(s.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			for {
+				ran, err := s.RunNext()
+				if err != nil {
+					log.Printf("Failed to run review moderation job: %v", err)
+				}
+				if !ran {
+					break
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-s.wake:
+			}
+		}
+	}()
+}
+
+// Request queues a job, filling in its ID and status
+func (s *ReviewModerationService) Request(job *store.ReviewModerationJob) error {
+	if err := s.moderationStore.CreateReviewModerationJob(job); err != nil {
+		return err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// RunNext runs the oldest queued job to completion, reporting whether there was one
+// A failed job is recorded so admins can see it and queue another; reviews it already processed stay that way
+func (s *ReviewModerationService) RunNext() (bool, error) {
+	job, err := s.moderationStore.ClaimReviewModerationJob(time.Now().Add(-s.config.StaleAfter))
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	processed, err := s.run(job)
+	if err != nil {
+		log.Printf("Failed to run review moderation job %d: %v", job.ID, err)
+		if failErr := s.moderationStore.FailReviewModerationJob(job.ID, "the job stopped before processing every matching review; queue it again to finish"); failErr != nil {
+			return true, failErr
+		}
+		return true, nil
+	}
+
+	if err := s.moderationStore.CompleteReviewModerationJob(job.ID, processed); err != nil {
+		return true, err
+	}
+
+	log.Printf("Review moderation job %d: %s %d reviews", job.ID, job.Action, processed)
+	return true, nil
+}
+
+// run applies a job's action to its matching reviews a batch at a time, recording progress after each batch
+func (s *ReviewModerationService) run(job *store.ReviewModerationJob) (int, error) {
+	matched, err := s.moderationStore.CountReviewModerationMatches(job)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.moderationStore.SetReviewModerationProgress(job.ID, matched, 0); err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	var afterID int64
+	for {
+		changed, lastID, err := s.moderationStore.ApplyReviewModerationBatch(job, afterID, s.config.BatchSize)
+		if err != nil {
+			return processed, err
+		}
+		if lastID == afterID {
+			return processed, nil
+		}
+		processed += changed
+		afterID = lastID
+
+		if err := s.moderationStore.SetReviewModerationProgress(job.ID, matched, processed); err != nil {
+			log.Printf("Failed to update review moderation progress: %v", err)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/resilience"
+)
+
+const (
+	// s3Service is the service name used in AWS Signature Version 4 scopes
+	s3Service = "s3"
+
+	// s3UnsignedPayload skips hashing request bodies, which S3 allows over HTTPS
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// s3MaxPresignTTL is the longest expiry S3 accepts for a presigned URL
+	s3MaxPresignTTL = 7 * 24 * time.Hour
+)
+
+// S3StorageConfig holds the connection settings for an S3-compatible bucket
+// Endpoint can point at AWS or at a compatible service such as MinIO or Cloudflare R2
+type S3StorageConfig struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Timeout         time.Duration
+	DefaultTTL      time.Duration
+}
+
+// DefaultS3StorageConfig returns the S3 configuration from the environment
+func DefaultS3StorageConfig() S3StorageConfig {
+	region := getEnvOrDefault("S3_REGION", "us-east-1")
+
+	return S3StorageConfig{
+		Endpoint:        strings.TrimRight(getEnvOrDefault("S3_ENDPOINT", "https://s3."+region+".amazonaws.com"), "/"),
+		Region:          region,
+		Bucket:          os.Getenv("S3_BUCKET"),
+		AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		Timeout:         30 * time.Second,
+		DefaultTTL:      time.Duration(getEnvIntOrDefault("MEDIA_URL_TTL_SECONDS", 900)) * time.Second,
+	}
+}
+
+// S3Storage stores files in an S3-compatible bucket using path-style requests signed with AWS Signature Version 4
+// Files stay private in the bucket; SignedURL returns presigned GET URLs
+type S3Storage struct {
+	config S3StorageConfig
+	client *http.Client
+	host   string
+}
+
+// NewS3Storage creates a new S3Storage
+func NewS3Storage(config S3StorageConfig) (*S3Storage, error) {
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("S3_BUCKET not set in environment")
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY must be set in environment")
+	}
+
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3_ENDPOINT %q", config.Endpoint)
+	}
+
+	return &S3Storage{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		host:   endpoint.Host,
+	}, nil
+}
+
+func (s *S3Storage) Backend() string {
+	return StorageBackendS3
+}
+
+func (s *S3Storage) Put(key string, r io.Reader, size int64, contentType string) error {
+	if err := validateStorageKey(key); err != nil {
+		return err
+	}
+
+	return s.do(http.MethodPut, key, r, size, contentType)
+}
+
+func (s *S3Storage) Delete(key string) error {
+	if err := validateStorageKey(key); err != nil {
+		return err
+	}
+
+	// S3 answers 204 whether or not the object existed
+	return s.do(http.MethodDelete, key, nil, 0, "")
+}
+
+// Open downloads the object through a presigned URL
+// Buckets that deny listing answer 403 rather than 404 for missing objects, so those are reported as errors
+func (s *S3Storage) Open(key string) (io.ReadCloser, error) {
+	signedURL, err := s.SignedURL(key, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	return openSignedURL(s.client, signedURL, key)
+}
+
+// SignedURL returns a presigned GET URL for the object
+// A ttl of zero uses MEDIA_URL_TTL_SECONDS; S3 caps presigned URLs at seven days
+func (s *S3Storage) SignedURL(key string, ttl time.Duration) (string, error) {
+	if err := validateStorageKey(key); err != nil {
+		return "", err
+	}
+	if ttl <= 0 {
+		ttl = s.config.DefaultTTL
+	}
+	if ttl <= 0 || ttl > s3MaxPresignTTL {
+		ttl = s3MaxPresignTTL
+	}
+
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := s.credentialScope(now)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", s.config.AccessKeyID+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		s.objectPath(key),
+		canonicalQueryString(query),
+		"host:" + s.host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", s.signature(now, amzDate, scope, canonicalRequest))
+
+	return s.config.Endpoint + s.objectPath(key) + "?" + canonicalQueryString(query), nil
+}
+
+// do signs and sends a request, treating any non-2xx response as an error
+// PUT and DELETE are idempotent, so failures are retried; a body can only be resent when it is an io.Seeker
+func (s *S3Storage) do(method, key string, body io.Reader, size int64, contentType string) error {
+	policy := resilience.DefaultPolicy(true)
+	seeker, seekable := body.(io.Seeker)
+	if body != nil && !seekable {
+		policy.MaxAttempts = 1
+	}
+
+	return resilience.Do(context.Background(), policy, func(ctx context.Context) error {
+		if seekable {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return resilience.Permanent(fmt.Errorf("failed to rewind upload: %w", err))
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key), nil)
+		if err != nil {
+			return resilience.Permanent(fmt.Errorf("failed to create S3 request: %w", err))
+		}
+		if body != nil {
+			// The client closes request bodies; keep the caller's reader open for another attempt
+			req.Body = io.NopCloser(body)
+			req.ContentLength = size
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		return s.send(req, key)
+	})
+}
+
+// send signs and sends a single request
+func (s *S3Storage) send(req *http.Request, key string) error {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := s.credentialScope(now)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + s.host + "\n" +
+		"x-amz-content-sha256:" + s3UnsignedPayload + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s.objectPath(key),
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, s.signature(now, amzDate, scope, canonicalRequest),
+	))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("S3 request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resilience.NewStatusError(resp, fmt.Errorf("S3 %s %s returned %d: %s", req.Method, key, resp.StatusCode, strings.TrimSpace(string(body))))
+	}
+
+	return nil
+}
+
+// objectPath returns the escaped path-style path of an object
+func (s *S3Storage) objectPath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return "/" + s3Escape(s.config.Bucket) + "/" + strings.Join(segments, "/")
+}
+
+func (s *S3Storage) objectURL(key string) string {
+	return s.config.Endpoint + s.objectPath(key)
+}
+
+func (s *S3Storage) credentialScope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.config.Region + "/" + s3Service + "/aws4_request"
+}
+
+// signature computes the Signature Version 4 signature of a canonical request
+func (s *S3Storage) signature(now time.Time, amzDate, scope, canonicalRequest string) string {
+	hashedRequest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashedRequest[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQueryString encodes query parameters sorted by name, as Signature Version 4 requires
+func canonicalQueryString(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, s3Escape(k)+"="+s3Escape(query.Get(k)))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape percent-encodes everything except the unreserved characters A-Z a-z 0-9 - _ . ~
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
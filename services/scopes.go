@@ -0,0 +1,82 @@
+package services
+
+import "github.com/dapoadedire/chefshare_be/store"
+
+const (
+	// ScopeProfileRead allows reading the authenticated user's own account data
+	ScopeProfileRead = "profile:read"
+
+	// ScopeProfileWrite allows changing the authenticated user's profile, pantry, blocks and invitations
+	ScopeProfileWrite = "profile:write"
+
+	// ScopeRecipesWrite allows creating and editing recipes and recipe activity
+	ScopeRecipesWrite = "recipes:write"
+
+	// ScopeReviewsWrite allows posting reviews and helpful votes
+	ScopeReviewsWrite = "reviews:write"
+
+	// ScopeMessages allows reading and sending direct messages
+	ScopeMessages = "messages"
+
+	// ScopeShoppingLists allows managing shopping lists
+	ScopeShoppingLists = "shopping-lists"
+
+	// ScopeAdminUsers allows administering users (verification reviews, impersonation)
+	ScopeAdminUsers = "admin:users"
+
+	// ScopeAdminContent allows administering catalog content (templates, collections, featured recipes)
+	ScopeAdminContent = "admin:content"
+)
+
+// UserScopes are granted to every signed-in user
+var UserScopes = []string{
+	ScopeProfileRead,
+	ScopeProfileWrite,
+	ScopeRecipesWrite,
+	ScopeReviewsWrite,
+	ScopeMessages,
+	ScopeShoppingLists,
+}
+
+// AdminScopes are granted to administrators in addition to UserScopes
+var AdminScopes = []string{
+	ScopeAdminUsers,
+	ScopeAdminContent,
+}
+
+// ScopesForRole returns every scope a user with the given role may hold
+func ScopesForRole(role string) []string {
+	scopes := append([]string{}, UserScopes...)
+	if role == store.RoleAdmin {
+		scopes = append(scopes, AdminScopes...)
+	}
+	return scopes
+}
+
+// HasScope reports whether scopes contains the given scope
+func HasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// RestrictScopes returns the requested scopes that are also in allowed, without duplicates
+// The second return value lists requested scopes that were not allowed
+func RestrictScopes(requested, allowed []string) ([]string, []string) {
+	granted := []string{}
+	denied := []string{}
+	for _, scope := range requested {
+		if HasScope(granted, scope) {
+			continue
+		}
+		if HasScope(allowed, scope) {
+			granted = append(granted, scope)
+		} else {
+			denied = append(denied, scope)
+		}
+	}
+	return granted, denied
+}
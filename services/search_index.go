@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+const (
+	// SearchEnginePostgres searches with PostgreSQL full-text search
+	SearchEnginePostgres = "postgres"
+
+	// SearchEngineMeilisearch searches with an external Meilisearch server
+	SearchEngineMeilisearch = "meilisearch"
+)
+
+// SearchIndexVersion is the version of the search documents and index settings sent to external engines
+// Bump it whenever store.SearchDocument or an engine's settings change, so indexes are rebuilt on deploy
+const SearchIndexVersion = 1
+
+// SearchIndex is a recipe search backend
+// Engines that keep their own copy of the data receive document changes through IndexRecipes and RemoveRecipes
+type SearchIndex interface {
+	// Engine returns the name of the search engine
+	Engine() string
+
+	// Setup prepares the index, e.g. creating it and configuring filterable attributes
+	Setup() error
+
+	// SearchRecipes returns a page of matching published recipes, best matches first, and the total match count
+	SearchRecipes(ctx context.Context, query string, opts store.RecipeListOptions) ([]*store.RecipeSearchResult, int, error)
+
+	// SearchFacets counts the recipes a search matches for each difficulty, category, and total time limit
+	SearchFacets(ctx context.Context, query string, opts store.RecipeListOptions) (*store.RecipeFacets, error)
+
+	// IndexRecipes adds or replaces recipe documents
+	IndexRecipes(documents []*store.SearchDocument) error
+
+	// RemoveRecipes removes recipes from the index
+	RemoveRecipes(recipeIDs []int64) error
+
+	// Clear removes every document from the index
+	Clear() error
+}
+
+// NewSearchIndexFromEnv creates the search index selected by SEARCH_ENGINE (default postgres)
+func NewSearchIndexFromEnv(searchStore store.SearchStore) (SearchIndex, error) {
+	engine := strings.ToLower(strings.TrimSpace(os.Getenv("SEARCH_ENGINE")))
+
+	switch engine {
+	case "", SearchEnginePostgres:
+		return NewPostgresSearchIndex(searchStore), nil
+	case SearchEngineMeilisearch:
+		return NewMeilisearchIndex(DefaultMeilisearchConfig(), searchStore)
+	default:
+		return nil, fmt.Errorf("unknown SEARCH_ENGINE %q", engine)
+	}
+}
+
+// PostgresSearchIndex searches recipes directly in PostgreSQL
+// The full-text index is maintained by the database, so document changes are no-ops
+type PostgresSearchIndex struct {
+	searchStore store.SearchStore
+}
+
+// NewPostgresSearchIndex creates a new PostgresSearchIndex
+func NewPostgresSearchIndex(searchStore store.SearchStore) *PostgresSearchIndex {
+	return &PostgresSearchIndex{
+		searchStore: searchStore,
+	}
+}
+
+func (i *PostgresSearchIndex) Engine() string {
+	return SearchEnginePostgres
+}
+
+func (i *PostgresSearchIndex) Setup() error {
+	return nil
+}
+
+func (i *PostgresSearchIndex) SearchRecipes(ctx context.Context, query string, opts store.RecipeListOptions) ([]*store.RecipeSearchResult, int, error) {
+	return i.searchStore.SearchRecipes(ctx, query, opts)
+}
+
+func (i *PostgresSearchIndex) SearchFacets(ctx context.Context, query string, opts store.RecipeListOptions) (*store.RecipeFacets, error) {
+	return i.searchStore.SearchFacets(ctx, query, opts)
+}
+
+func (i *PostgresSearchIndex) IndexRecipes(documents []*store.SearchDocument) error {
+	return nil
+}
+
+func (i *PostgresSearchIndex) RemoveRecipes(recipeIDs []int64) error {
+	return nil
+}
+
+func (i *PostgresSearchIndex) Clear() error {
+	return nil
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// ErrReindexInProgress is returned when a reindex is requested while one is already running
+var ErrReindexInProgress = errors.New("a reindex is already in progress")
+
+// SearchIndexerConfig controls how often queued recipe changes are synced to the search index
+type SearchIndexerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+
+	// ReindexOnVersionChange rebuilds an external index in the background on startup when it was built for
+	// an older SearchIndexVersion
+	ReindexOnVersionChange bool
+}
+
+// DefaultSearchIndexerConfig returns the indexer configuration from the environment with sensible defaults
+func DefaultSearchIndexerConfig() SearchIndexerConfig {
+	return SearchIndexerConfig{
+		PollInterval:           time.Duration(getEnvIntOrDefault("SEARCH_SYNC_INTERVAL_SECONDS", 5)) * time.Second,
+		BatchSize:              100,
+		ReindexOnVersionChange: getEnvOrDefault("SEARCH_REINDEX_ON_VERSION_CHANGE", "true") == "true",
+	}
+}
+
+// SearchIndexer keeps a SearchIndex in sync with recipe changes queued in the search outbox
+type SearchIndexer struct {
+	config      SearchIndexerConfig
+	index       SearchIndex
+	searchStore store.SearchStore
+
+	reindexMu  sync.Mutex
+	reindexing bool
+
+	// wake asks the background loop to sync before the next poll
+	wake chan struct{}
+}
+
+// NewSearchIndexer creates a new search indexer
+func NewSearchIndexer(config SearchIndexerConfig, index SearchIndex, searchStore store.SearchStore) *SearchIndexer {
+	return &SearchIndexer{
+		config:      config,
+		index:       index,
+		searchStore: searchStore,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Index returns the search index the indexer writes to
+func (i *SearchIndexer) Index() SearchIndex {
+	return i.index
+}
+
+// Start prepares the index and syncs queued changes in the background every poll interval, or sooner when notified
+// An index built for an older SearchIndexVersion is rebuilt in the background meanwhile.
+func (i *SearchIndexer) Start() {
+	go func() {
+		if err := i.index.Setup(); err != nil {
+			log.Printf("Failed to set up %s search index: %v", i.index.Engine(), err)
+		} else if i.config.ReindexOnVersionChange {
+			i.reindexIfOutdated()
+		}
+
+		ticker := time.NewTicker(i.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-i.wake:
+			}
+			if _, err := i.SyncPending(); err != nil {
+				log.Printf("Failed to sync search index: %v", err)
+			}
+		}
+	}()
+}
+
+// Notify asks the background loop to sync queued changes now instead of at the next poll
+// It never blocks; notifications made while a sync is already pending are merged
+func (i *SearchIndexer) Notify() {
+	select {
+	case i.wake <- struct{}{}:
+	default:
+	}
+}
+
+// SyncPending drains the search outbox, indexing published recipes and removing the rest
+// Entries are only removed from the outbox once the index has accepted the change, so failures are retried
+func (i *SearchIndexer) SyncPending() (int, error) {
+	synced := 0
+	for {
+		syncs, err := i.searchStore.GetPendingSearchSyncs(i.config.BatchSize)
+		if err != nil {
+			return synced, err
+		}
+		if len(syncs) == 0 {
+			return synced, nil
+		}
+
+		syncIDs := make([]int64, len(syncs))
+		recipeIDs := []int64{}
+		seen := make(map[int64]bool)
+		for j, entry := range syncs {
+			syncIDs[j] = entry.ID
+			if !seen[entry.RecipeID] {
+				seen[entry.RecipeID] = true
+				recipeIDs = append(recipeIDs, entry.RecipeID)
+			}
+		}
+
+		documents, err := i.searchStore.GetSearchDocuments(recipeIDs)
+		if err != nil {
+			return synced, err
+		}
+
+		indexed := make(map[int64]bool, len(documents))
+		for _, doc := range documents {
+			indexed[doc.ID] = true
+		}
+		removed := []int64{}
+		for _, id := range recipeIDs {
+			if !indexed[id] {
+				removed = append(removed, id)
+			}
+		}
+
+		if err := i.index.IndexRecipes(documents); err != nil {
+			return synced, err
+		}
+		if err := i.index.RemoveRecipes(removed); err != nil {
+			return synced, err
+		}
+		if err := i.searchStore.DeleteSearchSyncs(syncIDs); err != nil {
+			return synced, err
+		}
+
+		synced += len(recipeIDs)
+		if len(syncs) < i.config.BatchSize {
+			return synced, nil
+		}
+	}
+}
+
+// reindexIfOutdated starts a reindex if the index was last built for another SearchIndexVersion
+// PostgreSQL search reads the recipes tables directly, so it has nothing to rebuild.
+func (i *SearchIndexer) reindexIfOutdated() {
+	engine := i.index.Engine()
+	if engine == SearchEnginePostgres {
+		return
+	}
+
+	claimed, err := i.searchStore.ClaimSearchIndexVersion(engine, SearchIndexVersion)
+	if err != nil {
+		log.Printf("Failed to check %s search index version: %v", engine, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	// Forget the claim if the rebuild does not finish, so the next start tries again
+	reset := func() {
+		if err := i.searchStore.ResetSearchIndexVersion(engine, SearchIndexVersion); err != nil {
+			log.Printf("Failed to reset %s search index version: %v", engine, err)
+		}
+	}
+
+	log.Printf("Search index version changed to %d, reindexing %s search index", SearchIndexVersion, engine)
+	if err := i.startReindex(reset); err != nil {
+		log.Printf("Failed to start %s search reindex: %v", engine, err)
+		reset()
+	}
+}
+
+// StartReindex rebuilds the index from every published recipe in the background
+// Returns ErrReindexInProgress if a reindex is already running
+func (i *SearchIndexer) StartReindex() error {
+	return i.startReindex(nil)
+}
+
+// startReindex is StartReindex, calling onFailure if the reindex fails
+func (i *SearchIndexer) startReindex(onFailure func()) error {
+	i.reindexMu.Lock()
+	defer i.reindexMu.Unlock()
+
+	if i.reindexing {
+		return ErrReindexInProgress
+	}
+	i.reindexing = true
+
+	go func() {
+		defer func() {
+			i.reindexMu.Lock()
+			i.reindexing = false
+			i.reindexMu.Unlock()
+		}()
+
+		count, err := i.Reindex()
+		if err != nil {
+			log.Printf("Failed to reindex %s search index after %d recipes: %v", i.index.Engine(), count, err)
+			if onFailure != nil {
+				onFailure()
+			}
+			return
+		}
+		log.Printf("Reindexed %d recipes into %s search index", count, i.index.Engine())
+	}()
+
+	return nil
+}
+
+// Reindex clears the index and indexes every published recipe, returning how many were indexed
+func (i *SearchIndexer) Reindex() (int, error) {
+	if err := i.index.Setup(); err != nil {
+		return 0, err
+	}
+	if err := i.index.Clear(); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var afterID int64
+	for {
+		documents, err := i.searchStore.GetSearchDocumentsAfter(afterID, i.config.BatchSize)
+		if err != nil {
+			return count, err
+		}
+		if len(documents) == 0 {
+			return count, nil
+		}
+
+		if err := i.index.IndexRecipes(documents); err != nil {
+			return count, err
+		}
+
+		count += len(documents)
+		afterID = documents[len(documents)-1].ID
+	}
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"strconv"
+)
+
+const (
+	// ShareImageWidth and ShareImageHeight are the Open Graph image size recommended by most social networks
+	ShareImageWidth  = 1200
+	ShareImageHeight = 630
+
+	// shareImageVersion is part of every cache key; bump it when the layout changes to regenerate cached images
+	shareImageVersion = "1"
+
+	// maxSharePhotoBytes and maxSharePhotoPixels bound the photos decoded for share images
+	maxSharePhotoBytes  = 10 << 20
+	maxSharePhotoPixels = 40_000_000
+
+	shareImageMargin = 64
+)
+
+var (
+	shareBackground = color.RGBA{0xE8, 0x59, 0x0C, 0xFF}
+	shareText       = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	shareShadow     = color.RGBA{0x00, 0x00, 0x00, 0x99}
+	shareStar       = color.RGBA{0xFF, 0xC1, 0x07, 0xFF}
+)
+
+// ShareCard holds what a recipe's share image shows
+type ShareCard struct {
+	RecipeID      int64
+	Title         string
+	AverageRating *float64
+	ReviewCount   int
+
+	// PhotoKey is the storage key of the recipe photo used as the background, if any
+	PhotoKey *string
+}
+
+// ShareImageService renders Open Graph images for recipes and caches them in storage
+// Cached images are keyed by everything they show, so a changed title, rating or photo produces a new image.
+type ShareImageService struct {
+	Storage Storage
+}
+
+// NewShareImageService creates a ShareImageService that caches images in storage
+func NewShareImageService(storage Storage) *ShareImageService {
+	return &ShareImageService{
+		Storage: storage,
+	}
+}
+
+// Image returns the PNG share image for card, rendering and caching it if needed
+func (s *ShareImageService) Image(card ShareCard) ([]byte, error) {
+	key := shareImageKey(card)
+	reader, canRead := s.Storage.(StorageReader)
+
+	if canRead {
+		if cached, err := readAll(reader, key); err == nil {
+			return cached, nil
+		} else if !errors.Is(err, ErrStorageNotFound) {
+			log.Printf("Failed to read cached share image %s: %v", key, err)
+		}
+	}
+
+	var photo image.Image
+	if card.PhotoKey != nil && canRead {
+		var err error
+		photo, err = decodeSharePhoto(reader, *card.PhotoKey)
+		if err != nil {
+			// Fall back to a plain background rather than failing the share
+			log.Printf("Failed to load photo %s for share image: %v", *card.PhotoKey, err)
+		}
+	}
+
+	data, err := RenderShareImage(card, photo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Storage.Put(key, bytes.NewReader(data), int64(len(data)), "image/png"); err != nil {
+		log.Printf("Failed to cache share image %s: %v", key, err)
+	}
+
+	return data, nil
+}
+
+// shareImageKey derives the storage key of a card's image from everything the image shows
+func shareImageKey(card ShareCard) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%d\x00", shareImageVersion, card.Title, card.ReviewCount)
+	if card.AverageRating != nil {
+		fmt.Fprintf(hash, "%.1f", *card.AverageRating)
+	}
+	hash.Write([]byte{0})
+	if card.PhotoKey != nil {
+		hash.Write([]byte(*card.PhotoKey))
+	}
+	return fmt.Sprintf("share/recipes/%d/%s.png", card.RecipeID, hex.EncodeToString(hash.Sum(nil))[:16])
+}
+
+func readAll(reader StorageReader, key string) ([]byte, error) {
+	file, err := reader.Open(key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// decodeSharePhoto reads and decodes a JPEG, PNG or GIF photo, rejecting oversized images
+func decodeSharePhoto(reader StorageReader, key string) (image.Image, error) {
+	file, err := reader.Open(key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSharePhotoBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxSharePhotoBytes {
+		return nil, fmt.Errorf("photo is larger than %d bytes", maxSharePhotoBytes)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > maxSharePhotoPixels {
+		return nil, fmt.Errorf("photo is %dx%d, too large to decode", config.Width, config.Height)
+	}
+
+	photo, _, err := image.Decode(bytes.NewReader(data))
+	return photo, err
+}
+
+// RenderShareImage draws a card as a PNG: the photo cropped to fill the image, or a plain background
+// without one, with the title and rating over a darkened band at the bottom
+func RenderShareImage(card ShareCard, photo image.Image) ([]byte, error) {
+	canvas := image.NewRGBA(image.Rect(0, 0, ShareImageWidth, ShareImageHeight))
+
+	if photo != nil {
+		drawCover(canvas, photo)
+		darkenBottom(canvas, ShareImageHeight/2)
+	} else {
+		draw.Draw(canvas, canvas.Bounds(), image.NewUniform(shareBackground), image.Point{}, draw.Src)
+	}
+
+	drawShadowedText(canvas, "ChefShare", shareImageMargin, shareImageMargin, 4, shareText)
+
+	// Use large type for short titles and a smaller size when it would need more than two lines
+	title := fontText(card.Title)
+	scale := 8
+	lines := wrapText(title, (ShareImageWidth-2*shareImageMargin)/(glyphAdvance*scale), 3)
+	if len(lines) > 2 {
+		scale = 6
+		lines = wrapText(title, (ShareImageWidth-2*shareImageMargin)/(glyphAdvance*scale), 3)
+	}
+
+	lineHeight := (glyphHeight + 3) * scale
+	ratingScale := 4
+	y := ShareImageHeight - shareImageMargin - glyphHeight*ratingScale - 4*ratingScale - len(lines)*lineHeight
+	for _, line := range lines {
+		drawShadowedText(canvas, line, shareImageMargin, y, scale, shareText)
+		y += lineHeight
+	}
+	y += ratingScale
+
+	x := shareImageMargin
+	if card.AverageRating != nil && card.ReviewCount > 0 {
+		drawShadowedText(canvas, string(starRune), x, y, ratingScale, shareStar)
+		x += 2 * glyphAdvance * ratingScale
+
+		reviews := "reviews"
+		if card.ReviewCount == 1 {
+			reviews = "review"
+		}
+		rating := strconv.FormatFloat(*card.AverageRating, 'f', 1, 64) + " (" + strconv.Itoa(card.ReviewCount) + " " + reviews + ")"
+		drawShadowedText(canvas, rating, x, y, ratingScale, shareText)
+	} else {
+		drawShadowedText(canvas, "New recipe", x, y, ratingScale, shareText)
+	}
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, canvas); err != nil {
+		return nil, fmt.Errorf("failed to encode share image: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+func drawShadowedText(dst draw.Image, text string, x, y, scale int, c color.Color) {
+	offset := scale / 2
+	if offset < 1 {
+		offset = 1
+	}
+	drawText(dst, text, x+offset, y+offset, scale, shareShadow)
+	drawText(dst, text, x, y, scale, c)
+}
+
+// drawCover scales src to cover dst entirely, cropping the overflow around the center
+// Each destination pixel averages the source pixels it covers, which keeps downscaled photos smooth.
+func drawCover(dst *image.RGBA, src image.Image) {
+	sb := src.Bounds()
+	db := dst.Bounds()
+
+	// Pick the crop of src with the destination's aspect ratio
+	cropW, cropH := sb.Dx(), sb.Dx()*db.Dy()/db.Dx()
+	if cropH > sb.Dy() {
+		cropW, cropH = sb.Dy()*db.Dx()/db.Dy(), sb.Dy()
+	}
+	if cropW < 1 || cropH < 1 {
+		return
+	}
+	x0 := sb.Min.X + (sb.Dx()-cropW)/2
+	y0 := sb.Min.Y + (sb.Dy()-cropH)/2
+
+	for dy := 0; dy < db.Dy(); dy++ {
+		sy0 := y0 + dy*cropH/db.Dy()
+		sy1 := max(y0+(dy+1)*cropH/db.Dy(), sy0+1)
+		for dx := 0; dx < db.Dx(); dx++ {
+			sx0 := x0 + dx*cropW/db.Dx()
+			sx1 := max(x0+(dx+1)*cropW/db.Dx(), sx0+1)
+
+			var r, g, b, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, _ := src.At(sx, sy).RGBA()
+					r, g, b, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), n+1
+				}
+			}
+			dst.SetRGBA(db.Min.X+dx, db.Min.Y+dy, color.RGBA{uint8(r / n >> 8), uint8(g / n >> 8), uint8(b / n >> 8), 0xFF})
+		}
+	}
+}
+
+// darkenBottom shades the bottom height pixels with a gradient that deepens toward the edge, so text stays readable
+func darkenBottom(img *image.RGBA, height int) {
+	b := img.Bounds()
+	for y := b.Max.Y - height; y < b.Max.Y; y++ {
+		// Keep between 100% and 30% of the original brightness
+		keep := 256 - (y-(b.Max.Y-height))*180/height
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := img.RGBAAt(x, y)
+			c.R = uint8(int(c.R) * keep / 256)
+			c.G = uint8(int(c.G) * keep / 256)
+			c.B = uint8(int(c.B) * keep / 256)
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// StaleDraftConfig is the policy for drafts their authors have stopped working on
+type StaleDraftConfig struct {
+	// ArchiveAfter is how long a draft must go without edits before it is archived; zero or less turns the policy off
+	ArchiveAfter time.Duration
+
+	// Warning is how long before a draft is archived its author is emailed; zero or less archives without warning
+	Warning time.Duration
+
+	// CheckInterval is how often the policy is applied
+	CheckInterval time.Duration
+
+	// BatchSize caps the drafts warned about per check
+	BatchSize int
+}
+
+// DefaultStaleDraftConfig returns the policy from the environment
+func DefaultStaleDraftConfig() StaleDraftConfig {
+	day := 24 * time.Hour
+	return StaleDraftConfig{
+		ArchiveAfter:  time.Duration(getEnvIntOrDefault("STALE_DRAFT_ARCHIVE_DAYS", 365)) * day,
+		Warning:       time.Duration(getEnvIntOrDefault("STALE_DRAFT_WARNING_DAYS", 14)) * day,
+		CheckInterval: time.Duration(getEnvIntOrDefault("STALE_DRAFT_CHECK_INTERVAL_MINUTES", 60)) * time.Minute,
+		BatchSize:     500,
+	}
+}
+
+// StaleDrafts warns authors about drafts they haven't edited in a long time, then archives them
+// Authors get one email listing all of their drafts that are due; archived recipes can be restored as drafts.
+type StaleDrafts struct {
+	config       StaleDraftConfig
+	recipeStore  store.RecipeStore
+	emailService *EmailService
+}
+
+// NewStaleDrafts creates the stale draft policy
+// Without an email service no warnings are sent, and drafts are archived without them
+func NewStaleDrafts(config StaleDraftConfig, recipeStore store.RecipeStore, emailService *EmailService) *StaleDrafts {
+	return &StaleDrafts{
+		config:       config,
+		recipeStore:  recipeStore,
+		emailService: emailService,
+	}
+}
+
+// Start applies the policy in the background every check interval, unless it is turned off
+func (d *StaleDrafts) Start() {
+	if d.config.ArchiveAfter <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(d.config.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := d.Run(time.Now()); err != nil {
+				log.Printf("Failed to apply stale draft policy: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// Run sends due warnings, then archives drafts whose authors were warned long enough ago
+func (d *StaleDrafts) Run(now time.Time) error {
+	warning := d.config.Warning
+	if d.emailService == nil {
+		warning = 0
+	}
+
+	var warnedBefore time.Time
+	if warning > 0 {
+		if err := d.sendWarnings(now, warning); err != nil {
+			return err
+		}
+		warnedBefore = now.Add(-warning)
+	}
+
+	archived, err := d.recipeStore.ArchiveStaleDrafts(now.Add(-d.config.ArchiveAfter), warnedBefore)
+	if err != nil {
+		return err
+	}
+	if archived > 0 {
+		log.Printf("Archived %d drafts that had not been edited since %s", archived, now.Add(-d.config.ArchiveAfter).Format(time.DateOnly))
+	}
+
+	return nil
+}
+
+// sendWarnings emails each author whose drafts will be due for archiving within warning
+// A failed email is logged and retried on the next check
+func (d *StaleDrafts) sendWarnings(now time.Time, warning time.Duration) error {
+	drafts, err := d.recipeStore.GetStaleDraftsToWarn(now.Add(warning-d.config.ArchiveAfter), d.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	// Drafts come grouped by author
+	for start := 0; start < len(drafts); {
+		end := start + 1
+		for end < len(drafts) && drafts[end].UserID == drafts[start].UserID {
+			end++
+		}
+		authorDrafts := drafts[start:end]
+		start = end
+
+		author := authorDrafts[0]
+		name := author.FirstName
+		if name == "" {
+			name = author.Username
+		}
+		if _, err := d.emailService.SendStaleDraftsWarningEmail(author.Email, name, authorDrafts, now.Add(warning)); err != nil {
+			continue
+		}
+
+		recipeIDs := make([]int64, len(authorDrafts))
+		for i, draft := range authorDrafts {
+			recipeIDs[i] = draft.RecipeID
+		}
+		if err := d.recipeStore.MarkStaleDraftsWarned(recipeIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/resilience"
+)
+
+const (
+	// StorageBackendLocal stores files on the API server's disk
+	StorageBackendLocal = "local"
+
+	// StorageBackendS3 stores files in an S3-compatible bucket
+	StorageBackendS3 = "s3"
+
+	// StorageBackendCloudinary stores images in Cloudinary, which can also resize them on delivery
+	StorageBackendCloudinary = "cloudinary"
+)
+
+// ErrInvalidStorageKey is returned for keys that are empty or could escape the storage root
+var ErrInvalidStorageKey = errors.New("invalid storage key")
+
+// Storage stores uploaded files and hands out short-lived links to them
+// Keys are slash-separated paths such as "recipes/42/photo.jpg"
+type Storage interface {
+	// Backend returns the name of the storage backend
+	Backend() string
+
+	// Put stores the contents of r under key, replacing any existing file
+	Put(key string, r io.Reader, size int64, contentType string) error
+
+	// Delete removes the file stored under key; deleting a missing file is not an error
+	Delete(key string) error
+
+	// SignedURL returns a URL that serves the file under key until ttl has passed; zero uses the backend default
+	SignedURL(key string, ttl time.Duration) (string, error)
+}
+
+// ErrStorageNotFound is returned when reading a file that is not in storage
+var ErrStorageNotFound = errors.New("file not found in storage")
+
+// StorageReader is implemented by storage backends that can read files back
+type StorageReader interface {
+	// Open returns the contents of the file under key; the caller must close it
+	// A missing file returns an error wrapping ErrStorageNotFound
+	Open(key string) (io.ReadCloser, error)
+}
+
+// NewStorageFromEnv returns the storage backend selected by STORAGE_BACKEND
+// Local disk is the default, so uploads work in development without cloud credentials
+func NewStorageFromEnv(signer *URLSigner) (Storage, error) {
+	backend := strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE_BACKEND")))
+
+	switch backend {
+	case "", StorageBackendLocal:
+		return NewLocalStorage(DefaultLocalStorageConfig(), signer)
+	case StorageBackendS3:
+		return NewS3Storage(DefaultS3StorageConfig())
+	case StorageBackendCloudinary:
+		return NewCloudinaryStorage(DefaultCloudinaryConfig())
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q", backend)
+	}
+}
+
+// validateStorageKey rejects keys that are empty, absolute or contain parent directory segments
+func validateStorageKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidStorageKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidStorageKey
+		}
+	}
+	return nil
+}
+
+// openSignedURL downloads a file through a signed URL, retrying transient failures
+func openSignedURL(client *http.Client, signedURL, key string) (io.ReadCloser, error) {
+	var body io.ReadCloser
+	err := resilience.Do(context.Background(), resilience.DefaultPolicy(true), func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, signedURL, nil)
+		if err != nil {
+			return resilience.Permanent(fmt.Errorf("failed to create request for %s: %w", key, err))
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", key, err)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return resilience.Permanent(fmt.Errorf("%w: %s", ErrStorageNotFound, key))
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			resp.Body.Close()
+			return resilience.NewStatusError(resp, fmt.Errorf("fetching %s returned %d", key, resp.StatusCode))
+		}
+
+		body = resp.Body
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
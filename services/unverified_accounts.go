@@ -0,0 +1,183 @@
+package services
+
+import (
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// UnverifiedAccountConfig is the policy for accounts that never verify their email
+type UnverifiedAccountConfig struct {
+	// DisableAfter is how long after signing up an unverified account is disabled; zero or less turns the policy off
+	DisableAfter time.Duration
+
+	// PurgeAfter is how long after being disabled an account that is still unverified is deleted; zero or less never deletes
+	PurgeAfter time.Duration
+
+	// Warnings are how long before an account is disabled its owner is emailed a reminder, furthest first
+	Warnings []time.Duration
+
+	// CheckInterval is how often the policy is applied
+	CheckInterval time.Duration
+
+	// TokenExpiry is how long the verification link in a warning email stays valid
+	TokenExpiry time.Duration
+
+	// BatchSize caps the warning emails sent for each warning per check
+	BatchSize int
+}
+
+// DefaultUnverifiedAccountConfig returns the policy from the environment
+// The policy is off unless UNVERIFIED_ACCOUNT_DISABLE_DAYS is set
+func DefaultUnverifiedAccountConfig() UnverifiedAccountConfig {
+	day := 24 * time.Hour
+	return UnverifiedAccountConfig{
+		DisableAfter:  time.Duration(getEnvIntOrDefault("UNVERIFIED_ACCOUNT_DISABLE_DAYS", 0)) * day,
+		PurgeAfter:    time.Duration(getEnvIntOrDefault("UNVERIFIED_ACCOUNT_PURGE_DAYS", 30)) * day,
+		Warnings:      parseWarningDays(getEnvOrDefault("UNVERIFIED_ACCOUNT_WARNING_DAYS", "7,1")),
+		CheckInterval: time.Duration(getEnvIntOrDefault("UNVERIFIED_ACCOUNT_CHECK_INTERVAL_MINUTES", 60)) * time.Minute,
+		TokenExpiry:   48 * time.Hour,
+		BatchSize:     100,
+	}
+}
+
+// parseWarningDays reads a comma-separated list of days, ignoring invalid entries, and sorts them furthest first
+func parseWarningDays(value string) []time.Duration {
+	warnings := []time.Duration{}
+	for _, part := range strings.Split(value, ",") {
+		days, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || days <= 0 {
+			continue
+		}
+		warning := time.Duration(days) * 24 * time.Hour
+		if !slices.Contains(warnings, warning) {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	slices.SortFunc(warnings, func(a, b time.Duration) int { return int(b - a) })
+	return warnings
+}
+
+// UnverifiedAccounts warns, disables and eventually purges accounts that never verify their email
+// Owners are emailed a fresh verification link with each warning; verifying reactivates a disabled account.
+type UnverifiedAccounts struct {
+	config                 UnverifiedAccountConfig
+	userStore              store.UserStore
+	emailVerificationStore store.EmailVerificationStore
+	emailService           *EmailService
+}
+
+// NewUnverifiedAccounts creates the unverified account policy
+// Without an email service no warnings are sent, and accounts are disabled without them
+func NewUnverifiedAccounts(config UnverifiedAccountConfig, userStore store.UserStore, emailVerificationStore store.EmailVerificationStore, emailService *EmailService) *UnverifiedAccounts {
+	return &UnverifiedAccounts{
+		config:                 config,
+		userStore:              userStore,
+		emailVerificationStore: emailVerificationStore,
+		emailService:           emailService,
+	}
+}
+
+// Start applies the policy in the background every check interval, unless it is turned off
+func (u *UnverifiedAccounts) Start() {
+	if u.config.DisableAfter <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(u.config.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := u.Run(time.Now()); err != nil {
+				log.Printf("Failed to apply unverified account policy: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// Run sends due warnings, then disables and purges accounts whose time is up
+func (u *UnverifiedAccounts) Run(now time.Time) error {
+	warnings := u.config.Warnings
+	if u.emailService == nil {
+		warnings = nil
+	}
+
+	// Send the latest due warning first and count earlier ones as sent, so an account
+	// that is already past several warnings only gets the most recent one
+	for i := len(warnings) - 1; i >= 0; i-- {
+		if err := u.sendWarnings(now, i+1, warnings[i]); err != nil {
+			return err
+		}
+	}
+
+	// The final warning must have been sent at least its lead time ago, so every owner gets the notice promised
+	var warnedBefore time.Time
+	if len(warnings) > 0 {
+		warnedBefore = now.Add(-warnings[len(warnings)-1])
+	}
+	disabled, err := u.userStore.DisableUnverifiedUsers(now.Add(-u.config.DisableAfter), len(warnings), warnedBefore)
+	if err != nil {
+		return err
+	}
+	if disabled > 0 {
+		log.Printf("Disabled %d accounts that never verified their email", disabled)
+	}
+
+	if u.config.PurgeAfter > 0 {
+		purged, err := u.userStore.PurgeDisabledUnverifiedUsers(now.Add(-u.config.PurgeAfter))
+		if err != nil {
+			return err
+		}
+		if purged > 0 {
+			log.Printf("Purged %d disabled accounts that never verified their email", purged)
+		}
+	}
+
+	return nil
+}
+
+// sendWarnings emails the warning-th reminder to accounts that will be disabled within lead
+// A failed email is logged and retried on the next check
+func (u *UnverifiedAccounts) sendWarnings(now time.Time, warning int, lead time.Duration) error {
+	users, err := u.userStore.GetUnverifiedUsersToWarn(warning, now.Add(lead-u.config.DisableAfter), u.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		createdAt, err := time.Parse(time.RFC3339Nano, user.CreatedAt)
+		if err != nil {
+			createdAt = now.Add(-u.config.DisableAfter)
+		}
+		disableAt := createdAt.Add(u.config.DisableAfter)
+		if earliest := now.Add(lead); disableAt.Before(earliest) {
+			disableAt = earliest
+		}
+
+		token, err := u.emailVerificationStore.CreateVerificationToken(user.UserID, u.config.TokenExpiry)
+		if err != nil {
+			return err
+		}
+
+		name := user.FirstName
+		if name == "" {
+			name = user.Username
+		}
+		if _, err := u.emailService.SendUnverifiedAccountWarningEmail(user.Email, name, token.Token, disableAt); err != nil {
+			continue
+		}
+
+		if err := u.userStore.MarkUnverifiedWarningSent(user.UserID, warning); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+)
+
+// Metered operations; every authenticated request counts towards UsageRequest
+const (
+	UsageRequest = "request"
+	UsageExport  = "export"
+	UsageImport  = "import"
+)
+
+// UsageConfig controls how often usage is flushed to the database and the optional daily quotas
+type UsageConfig struct {
+	FlushInterval time.Duration
+
+	// DailyQuotas caps how many times a user may perform a metered operation per UTC day
+	// A limit of zero or less, or a missing entry, disables the quota
+	DailyQuotas map[string]int
+}
+
+// DefaultUsageConfig returns the usage configuration from the environment
+// Quotas are disabled unless configured
+func DefaultUsageConfig() UsageConfig {
+	return UsageConfig{
+		FlushInterval: time.Duration(getEnvIntOrDefault("USAGE_FLUSH_INTERVAL_SECONDS", 60)) * time.Second,
+		DailyQuotas: map[string]int{
+			UsageExport: getEnvIntOrDefault("QUOTA_EXPORTS_PER_DAY", 0),
+			UsageImport: getEnvIntOrDefault("QUOTA_IMPORTS_PER_DAY", 0),
+		},
+	}
+}
+
+// UsageQuota describes a user's progress against one daily quota
+type UsageQuota struct {
+	Limit     int       `json:"limit"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+type usageKey struct {
+	userID   string
+	apiKeyID int64
+	metric   string
+	day      time.Time
+}
+
+// UsageService meters requests per user and API key and enforces daily quotas
+// Counts are rolled up in memory and added to the database every flush interval, so metering
+// costs no query per request; counts not yet flushed are lost if the process exits
+type UsageService struct {
+	config     UsageConfig
+	usageStore store.UsageStore
+
+	mu      sync.Mutex
+	pending map[usageKey]int
+}
+
+// NewUsageService creates a new usage service
+func NewUsageService(config UsageConfig, usageStore store.UsageStore) *UsageService {
+	return &UsageService{
+		config:     config,
+		usageStore: usageStore,
+		pending:    make(map[usageKey]int),
+	}
+}
+
+// Start flushes pending usage in the background every flush interval
+func (s *UsageService) Start() {
+	go func() {
+		ticker := time.NewTicker(s.config.FlushInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := s.Flush(); err != nil {
+				log.Printf("Failed to flush API usage: %v", err)
+			}
+		}
+	}()
+}
+
+// Record counts one use of a metered operation by a user's public ID
+// apiKeyID is the API key the request was made with, or 0 for a sign-in token
+func (s *UsageService) Record(userID string, apiKeyID int64, metric string) {
+	key := usageKey{userID: userID, apiKeyID: apiKeyID, metric: metric, day: utcDay(time.Now())}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[key]++
+}
+
+// Flush adds pending counts to the database
+// If that fails the counts are kept and retried on the next flush
+func (s *UsageService) Flush() error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[usageKey]int)
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	increments := make([]*store.UsageIncrement, 0, len(pending))
+	for key, count := range pending {
+		increments = append(increments, &store.UsageIncrement{
+			UserID:   key.userID,
+			APIKeyID: key.apiKeyID,
+			Metric:   key.metric,
+			Day:      key.day,
+			Count:    count,
+		})
+	}
+
+	if err := s.usageStore.AddUsage(increments); err != nil {
+		s.mu.Lock()
+		for key, count := range pending {
+			s.pending[key] += count
+		}
+		s.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// CheckDailyQuota records one use of a metered operation, or returns a QuotaExceededError without recording it
+// if the user has used up today's quota. Quotas are per user, shared by all of their API keys.
+// Concurrent requests on the same or other instances may briefly exceed a quota by a few uses.
+// It returns the user's progress against the quota including this use, or nil if the metric has no quota.
+func (s *UsageService) CheckDailyQuota(userID string, apiKeyID int64, metric string) (*UsageQuota, error) {
+	var quota *UsageQuota
+	if limit := s.config.DailyQuotas[metric]; limit > 0 {
+		now := time.Now()
+		used, err := s.used(userID, metric, now)
+		if err != nil {
+			return nil, err
+		}
+
+		resetsAt := utcDay(now).Add(24 * time.Hour)
+		if used >= limit {
+			return nil, &QuotaExceededError{Resource: metric, Limit: limit, Window: 24 * time.Hour, RetryAfter: time.Until(resetsAt)}
+		}
+
+		quota = &UsageQuota{Limit: limit, Used: used + 1, Remaining: limit - used - 1, ResetsAt: resetsAt}
+	}
+
+	s.Record(userID, apiKeyID, metric)
+	return quota, nil
+}
+
+// Quotas returns the user's progress against each enabled daily quota, keyed by metric
+func (s *UsageService) Quotas(userID string) (map[string]*UsageQuota, error) {
+	now := time.Now()
+	quotas := make(map[string]*UsageQuota)
+	for metric, limit := range s.config.DailyQuotas {
+		if limit <= 0 {
+			continue
+		}
+
+		used, err := s.used(userID, metric, now)
+		if err != nil {
+			return nil, err
+		}
+
+		quotas[metric] = &UsageQuota{
+			Limit:     limit,
+			Used:      used,
+			Remaining: max(limit-used, 0),
+			ResetsAt:  utcDay(now).Add(24 * time.Hour),
+		}
+	}
+
+	return quotas, nil
+}
+
+// used returns the user's count for a metric on the day of now, including counts not yet flushed
+func (s *UsageService) used(userID string, metric string, now time.Time) (int, error) {
+	day := utcDay(now)
+	used, err := s.usageStore.CountUsage(userID, metric, day)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, count := range s.pending {
+		if key.userID == userID && key.metric == metric && key.day.Equal(day) {
+			used += count
+		}
+	}
+
+	return used, nil
+}
+
+// utcDay returns the start of the UTC day containing t
+func utcDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,467 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/resilience"
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/google/uuid"
+)
+
+// Webhook event types
+const (
+	WebhookRecipeCreated = "recipe.created"
+	WebhookRecipeUpdated = "recipe.updated"
+	WebhookRecipeDeleted = "recipe.deleted"
+
+	// WebhookPing is only sent when an endpoint's owner asks for a test delivery
+	WebhookPing = "ping"
+)
+
+// WebhookEvents lists the event types endpoints can subscribe to
+var WebhookEvents = []string{WebhookRecipeCreated, WebhookRecipeUpdated, WebhookRecipeDeleted}
+
+var (
+	// ErrWebhookURLInvalid is returned when an endpoint URL is not an absolute https URL, or points at a private network
+	ErrWebhookURLInvalid = errors.New("webhook URL must be an absolute https URL on a public host")
+
+	// ErrWebhookEventUnknown is returned when an endpoint subscribes to an event type that does not exist
+	ErrWebhookEventUnknown = errors.New("unknown webhook event")
+
+	// errWebhookAddressBlocked is returned when a delivery would connect to a private address
+	errWebhookAddressBlocked = errors.New("webhook host resolves to a private address")
+)
+
+// webhookRetryPolicy sets the default number of attempts per delivery and the jittered backoff between them
+var webhookRetryPolicy = resilience.Policy{
+	MaxAttempts: 6,
+	BaseDelay:   10 * time.Minute,
+	MaxDelay:    6 * time.Hour,
+}
+
+// webhookResponseBodyLimit caps how much of an endpoint's response is kept in the delivery log
+const webhookResponseBodyLimit = 1024
+
+// WebhookConfig controls how webhook deliveries are sent and retried
+type WebhookConfig struct {
+	Timeout time.Duration
+
+	// MaxAttempts is how many times a delivery is sent before it is given up on
+	MaxAttempts int
+
+	// DisableAfter is how many failed attempts in a row disable an endpoint; zero never disables
+	DisableAfter int
+
+	// AllowPrivateNetworks permits plain http and private addresses, for local development only
+	AllowPrivateNetworks bool
+
+	// SecretGrace is how long a rotated secret keeps signing deliveries alongside the new one
+	SecretGrace time.Duration
+
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// DefaultWebhookConfig returns the webhook configuration from the environment with sensible defaults
+func DefaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		Timeout:              time.Duration(getEnvIntOrDefault("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second,
+		MaxAttempts:          getEnvIntOrDefault("WEBHOOK_MAX_ATTEMPTS", webhookRetryPolicy.MaxAttempts),
+		DisableAfter:         getEnvIntOrDefault("WEBHOOK_DISABLE_AFTER_FAILURES", 20),
+		AllowPrivateNetworks: getEnvOrDefault("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "false") == "true",
+		SecretGrace:          24 * time.Hour,
+		PollInterval:         5 * time.Second,
+		BatchSize:            10,
+	}
+}
+
+// webhookPayload is the body of every delivery
+// Payloads only identify what changed; receivers fetch the current recipe from the API
+type webhookPayload struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type"`
+	CreatedAt time.Time      `json:"created_at"`
+	Data      map[string]any `json:"data"`
+}
+
+// WebhookService sends signed event notifications to the endpoints users register
+// Deliveries are queued in the database and sent by a background worker, retrying with backoff;
+// an endpoint that keeps failing is disabled until its owner re-enables it.
+type WebhookService struct {
+	config       WebhookConfig
+	webhookStore store.WebhookStore
+	recipeStore  store.RecipeStore
+	client       *http.Client
+
+	// wake asks the background loop to send queued deliveries before the next poll
+	wake chan struct{}
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(config WebhookConfig, webhookStore store.WebhookStore, recipeStore store.RecipeStore) *WebhookService {
+	transport := newPublicOnlyTransport(config.Timeout, config.AllowPrivateNetworks, errWebhookAddressBlocked)
+
+	return &WebhookService{
+		config:       config,
+		webhookStore: webhookStore,
+		recipeStore:  recipeStore,
+		client: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: transport,
+			// A redirect is reported as the endpoint's response rather than followed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// Start sends due deliveries in the background every poll interval, or sooner when events are queued
+func (s *WebhookService) Start() {
+	go func() {
+		ticker := time.NewTicker(s.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			for {
+				sent, err := s.SendDue()
+				if err != nil {
+					log.Printf("Failed to send webhook deliveries: %v", err)
+				}
+				if sent < s.config.BatchSize {
+					break
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-s.wake:
+			}
+		}
+	}()
+}
+
+func (s *WebhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// HandleRecipeEvent queues a delivery of a recipe change to its author's subscribed endpoints
+func (s *WebhookService) HandleRecipeEvent(event RecipeEvent) {
+	var eventType string
+	switch event.Type {
+	case RecipeCreated:
+		eventType = WebhookRecipeCreated
+	case RecipeUpdated:
+		eventType = WebhookRecipeUpdated
+	case RecipeDeleted:
+		eventType = WebhookRecipeDeleted
+	default:
+		return
+	}
+
+	authorID := event.AuthorID
+	if authorID == 0 {
+		recipe, err := s.recipeStore.GetRecipeByID(event.RecipeID)
+		if err != nil {
+			log.Printf("Failed to look up recipe %d for webhooks: %v", event.RecipeID, err)
+			return
+		}
+		if recipe == nil {
+			return
+		}
+		authorID = recipe.UserID
+	}
+
+	payload, err := newWebhookPayload(eventType, map[string]any{"recipe_id": event.RecipeID})
+	if err != nil {
+		log.Printf("Failed to build webhook payload: %v", err)
+		return
+	}
+
+	queued, err := s.webhookStore.EnqueueWebhookEvent(authorID, eventType, payload)
+	if err != nil {
+		log.Printf("Failed to queue %s webhooks for recipe %d: %v", eventType, event.RecipeID, err)
+		return
+	}
+	if queued > 0 {
+		s.notify()
+	}
+}
+
+// Ping queues a test delivery to an endpoint, whatever events it subscribes to
+func (s *WebhookService) Ping(endpoint *store.WebhookEndpoint) (*store.WebhookDelivery, error) {
+	payload, err := newWebhookPayload(WebhookPing, map[string]any{"endpoint_id": endpoint.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.webhookStore.EnqueueWebhookDelivery(endpoint.ID, WebhookPing, payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.notify()
+
+	return delivery, nil
+}
+
+// Redeliver queues a new delivery with the same event as an earlier one
+// The payload is sent unchanged, so receivers can recognise a repeat by its event ID
+func (s *WebhookService) Redeliver(delivery *store.WebhookDelivery) (*store.WebhookDelivery, error) {
+	redelivery, err := s.webhookStore.EnqueueWebhookDelivery(delivery.EndpointID, delivery.EventType, delivery.Payload, &delivery.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.notify()
+
+	return redelivery, nil
+}
+
+// NewSecret generates a signing secret for an endpoint
+func (s *WebhookService) NewSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// RotateSecret gives an endpoint a new secret, keeping the old one valid for the grace period
+// Returns nil if the endpoint does not exist or belongs to someone else
+func (s *WebhookService) RotateSecret(id int64, userID int64) (*store.WebhookEndpoint, error) {
+	secret, err := s.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	return s.webhookStore.RotateWebhookSecret(id, userID, secret, time.Now().Add(s.config.SecretGrace))
+}
+
+// ValidateURL checks that an endpoint URL can be delivered to
+// Hostnames are checked again when each delivery connects, since they can be re-pointed at any time
+func (s *WebhookService) ValidateURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" || u.User != nil {
+		return "", ErrWebhookURLInvalid
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !s.config.AllowPrivateNetworks {
+			return "", ErrWebhookURLInvalid
+		}
+	default:
+		return "", ErrWebhookURLInvalid
+	}
+
+	if !s.config.AllowPrivateNetworks {
+		host := u.Hostname()
+		if ip := net.ParseIP(host); ip != nil && isPrivateIP(ip) {
+			return "", ErrWebhookURLInvalid
+		}
+		if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+			return "", ErrWebhookURLInvalid
+		}
+	}
+
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+// NormalizeEvents checks an endpoint's event filter and removes duplicates
+// An empty filter subscribes the endpoint to every event
+func NormalizeEvents(events []string) ([]string, error) {
+	normalized := []string{}
+	for _, event := range events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !slices.Contains(WebhookEvents, event) {
+			return nil, fmt.Errorf("%w: %q", ErrWebhookEventUnknown, event)
+		}
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+	return normalized, nil
+}
+
+// SendDue sends a batch of due deliveries in parallel and returns how many were claimed
+func (s *WebhookService) SendDue() (int, error) {
+	// Leave time for the request to time out and its outcome to be saved before another worker can claim it
+	deliveries, err := s.webhookStore.ClaimWebhookDeliveries(s.config.BatchSize, 2*s.config.Timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	endpoints := map[int64]*store.WebhookEndpoint{}
+	for _, delivery := range deliveries {
+		if _, ok := endpoints[delivery.EndpointID]; ok {
+			continue
+		}
+		endpoint, err := s.webhookStore.GetWebhookEndpointByID(delivery.EndpointID)
+		if err != nil {
+			return len(deliveries), err
+		}
+		endpoints[delivery.EndpointID] = endpoint
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		endpoint := endpoints[delivery.EndpointID]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.send(endpoint, delivery)
+		}()
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// send makes one attempt at a delivery and records the outcome
+func (s *WebhookService) send(endpoint *store.WebhookEndpoint, delivery *store.WebhookDelivery) {
+	// Disabling or deleting an endpoint already fails or removes its pending deliveries
+	if endpoint == nil || !endpoint.Enabled {
+		return
+	}
+
+	attempt := &store.WebhookAttempt{
+		DeliveryID:   delivery.ID,
+		EndpointID:   delivery.EndpointID,
+		DisableAfter: s.config.DisableAfter,
+	}
+
+	start := time.Now()
+	status, body, err := s.post(endpoint, delivery)
+	attempt.Duration = time.Since(start)
+
+	if status != 0 {
+		attempt.ResponseStatus = &status
+		attempt.ResponseBody = &body
+	}
+	if err != nil {
+		message := err.Error()
+		attempt.Error = &message
+	}
+	attempt.Succeeded = err == nil && status >= 200 && status < 300
+
+	if !attempt.Succeeded && delivery.Attempts+1 < s.config.MaxAttempts {
+		retryAt := time.Now().Add(webhookRetryPolicy.Backoff(delivery.Attempts, nil))
+		attempt.RetryAt = &retryAt
+	}
+
+	disabled, err := s.webhookStore.RecordWebhookAttempt(attempt)
+	if err != nil {
+		log.Printf("Failed to record webhook delivery %d: %v", delivery.ID, err)
+		return
+	}
+	if disabled {
+		log.Printf("Disabled webhook endpoint %d after %d failed deliveries in a row", endpoint.ID, s.config.DisableAfter)
+	}
+}
+
+// post sends a delivery's payload to its endpoint and returns the response status and the start of its body
+func (s *WebhookService) post(endpoint *store.WebhookEndpoint, delivery *store.WebhookDelivery) (int, string, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ChefShare-Webhooks/1.0")
+	req.Header.Set("X-Chefshare-Event", delivery.EventType)
+	req.Header.Set("X-Chefshare-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Chefshare-Signature", s.signature(endpoint, delivery.Payload, time.Now()))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyLimit))
+	return resp.StatusCode, strings.ToValidUTF8(string(body), ""), nil
+}
+
+// signature signs a payload with the endpoint's secret, and with its previous secret during the grace period after a rotation
+// The header has the form t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">[,v1=...]
+func (s *WebhookService) signature(endpoint *store.WebhookEndpoint, payload []byte, now time.Time) string {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	parts := []string{"t=" + timestamp, "v1=" + signWebhookPayload(endpoint.Secret, timestamp, payload)}
+	if endpoint.PreviousSecret != nil && endpoint.PreviousSecretExpiresAt != nil && now.Before(*endpoint.PreviousSecretExpiresAt) {
+		parts = append(parts, "v1="+signWebhookPayload(*endpoint.PreviousSecret, timestamp, payload))
+	}
+	return strings.Join(parts, ",")
+}
+
+func signWebhookPayload(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookPayload(eventType string, data map[string]any) ([]byte, error) {
+	payload, err := json.Marshal(webhookPayload{
+		ID:        uuid.NewString(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Data:      data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	return payload, nil
+}
+
+// newPublicOnlyTransport returns an HTTP transport for calls to user-supplied URLs that refuses to connect
+// to private addresses, failing with blockedErr, unless allowPrivate is set. It never uses a proxy.
+func newPublicOnlyTransport(timeout time.Duration, allowPrivate bool, blockedErr error) *http.Transport {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		// Checked on the resolved address at connect time, so a public hostname cannot be pointed at an internal service
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivateIP(ip) {
+				return blockedErr
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// isPrivateIP reports whether ip is loopback, private, link-local or otherwise not a public unicast address
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
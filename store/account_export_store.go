@@ -0,0 +1,285 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	// AccountExportQueued exports are waiting for the background worker
+	AccountExportQueued = "queued"
+
+	// AccountExportRunning exports are being built
+	AccountExportRunning = "running"
+
+	// AccountExportDone exports have an archive ready to download until they expire
+	AccountExportDone = "done"
+
+	// AccountExportFailed exports stopped with an error and can be requested again
+	AccountExportFailed = "failed"
+)
+
+const (
+	// AccountExportKindAccount exports are zip archives of everything a user has stored
+	AccountExportKindAccount = "account"
+
+	// AccountExportKindStats exports are CSV files of how a user's recipes performed over a period
+	AccountExportKindStats = "stats"
+)
+
+// AccountExport is a request for an archive of everything a user has stored, or of their recipe statistics
+// PeriodFrom and PeriodTo are only set for statistics exports
+type AccountExport struct {
+	ID           int64      `json:"id"`
+	UserID       int64      `json:"-"`
+	UserPublicID string     `json:"-"`
+	Kind         string     `json:"kind"`
+	PeriodFrom   *time.Time `json:"period_from,omitempty"`
+	PeriodTo     *time.Time `json:"period_to,omitempty"`
+	Status       string     `json:"status"`
+	Progress     int        `json:"progress"`
+	StorageKey   *string    `json:"-"`
+	SizeBytes    *int64     `json:"size_bytes,omitempty"`
+	Error        *string    `json:"error,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+
+	DownloadURL       *string    `json:"download_url,omitempty"`
+	DownloadExpiresAt *time.Time `json:"download_expires_at,omitempty"`
+}
+
+// AccountExportStore defines the interface for account export jobs
+type AccountExportStore interface {
+	CreateAccountExport(userID int64, kind string, from, to *time.Time) (*AccountExport, error)
+	GetAccountExport(id int64, userID int64) (*AccountExport, error)
+	GetPendingAccountExport(userID int64, kind string) (*AccountExport, error)
+	ClaimAccountExport(staleBefore time.Time) (*AccountExport, error)
+	SetAccountExportProgress(id int64, progress int) error
+	CompleteAccountExport(id int64, storageKey string, size int64, expiresAt time.Time) error
+	FailAccountExport(id int64, message string) error
+	GetExpiredAccountExports(now time.Time, limit int) ([]*AccountExport, error)
+	ClearAccountExportArchive(id int64) error
+}
+
+// PostgresAccountExportStore implements the AccountExportStore interface using PostgreSQL
+type PostgresAccountExportStore struct {
+	db *sql.DB
+}
+
+// NewPostgresAccountExportStore creates a new PostgresAccountExportStore
+func NewPostgresAccountExportStore(db *sql.DB) *PostgresAccountExportStore {
+	return &PostgresAccountExportStore{
+		db: db,
+	}
+}
+
+const accountExportColumns = `e.id, e.user_id, u.user_id, e.kind, e.period_from, e.period_to, e.status, e.progress, e.storage_key, e.size_bytes, e.error,
+	e.created_at, e.started_at, e.completed_at, e.expires_at`
+
+func scanAccountExport(row rowScanner, export *AccountExport) error {
+	return row.Scan(
+		&export.ID,
+		&export.UserID,
+		&export.UserPublicID,
+		&export.Kind,
+		&export.PeriodFrom,
+		&export.PeriodTo,
+		&export.Status,
+		&export.Progress,
+		&export.StorageKey,
+		&export.SizeBytes,
+		&export.Error,
+		&export.CreatedAt,
+		&export.StartedAt,
+		&export.CompletedAt,
+		&export.ExpiresAt,
+	)
+}
+
+// CreateAccountExport queues a new export of the given kind for a user
+// from and to bound the period of a statistics export and are nil for other kinds
+func (s *PostgresAccountExportStore) CreateAccountExport(userID int64, kind string, from, to *time.Time) (*AccountExport, error) {
+	query := `
+		WITH e AS (
+			INSERT INTO account_exports (user_id, kind, period_from, period_to)
+			VALUES ($1, $2, $3::DATE, $4::DATE)
+			RETURNING *
+		)
+		SELECT ` + accountExportColumns + `
+		FROM e
+		JOIN users u ON u.id = e.user_id
+	`
+
+	export := &AccountExport{}
+	if err := scanAccountExport(s.db.QueryRow(query, userID, kind, exportDate(from), exportDate(to)), export); err != nil {
+		return nil, fmt.Errorf("failed to create account export: %w", mapError(err))
+	}
+
+	return export, nil
+}
+
+// GetAccountExport returns one of a user's exports
+// Returns nil if the export does not exist or belongs to someone else
+func (s *PostgresAccountExportStore) GetAccountExport(id int64, userID int64) (*AccountExport, error) {
+	query := `
+		SELECT ` + accountExportColumns + `
+		FROM account_exports e
+		JOIN users u ON u.id = e.user_id
+		WHERE e.id = $1 AND e.user_id = $2
+	`
+
+	export := &AccountExport{}
+	if err := scanAccountExport(s.db.QueryRow(query, id, userID), export); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get account export: %w", err)
+	}
+
+	return export, nil
+}
+
+// GetPendingAccountExport returns a user's queued or running export of the given kind
+// Returns nil if they have none
+func (s *PostgresAccountExportStore) GetPendingAccountExport(userID int64, kind string) (*AccountExport, error) {
+	query := `
+		SELECT ` + accountExportColumns + `
+		FROM account_exports e
+		JOIN users u ON u.id = e.user_id
+		WHERE e.user_id = $1 AND e.kind = $2 AND e.status IN ('queued', 'running')
+		ORDER BY e.created_at DESC
+		LIMIT 1
+	`
+
+	export := &AccountExport{}
+	if err := scanAccountExport(s.db.QueryRow(query, userID, kind), export); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pending account export: %w", err)
+	}
+
+	return export, nil
+}
+
+// ClaimAccountExport marks the oldest queued export as running and returns it
+// Exports left running since before staleBefore, such as by a worker that crashed, are claimed again.
+// Concurrent workers never claim the same export. Returns nil if there is nothing to do.
+func (s *PostgresAccountExportStore) ClaimAccountExport(staleBefore time.Time) (*AccountExport, error) {
+	query := `
+		WITH e AS (
+			UPDATE account_exports
+			SET status = 'running', progress = 0, started_at = NOW()
+			WHERE id = (
+				SELECT id
+				FROM account_exports
+				WHERE status = 'queued' OR (status = 'running' AND started_at < $1)
+				ORDER BY created_at
+				LIMIT 1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT ` + accountExportColumns + `
+		FROM e
+		JOIN users u ON u.id = e.user_id
+	`
+
+	export := &AccountExport{}
+	if err := scanAccountExport(s.db.QueryRow(query, staleBefore), export); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim account export: %w", mapError(err))
+	}
+
+	return export, nil
+}
+
+// SetAccountExportProgress records how far along a running export is, as a percentage
+func (s *PostgresAccountExportStore) SetAccountExportProgress(id int64, progress int) error {
+	query := `UPDATE account_exports SET progress = $2 WHERE id = $1 AND status = 'running'`
+
+	if _, err := s.db.Exec(query, id, min(max(progress, 0), 100)); err != nil {
+		return fmt.Errorf("failed to set account export progress: %w", mapError(err))
+	}
+	return nil
+}
+
+// CompleteAccountExport marks an export as done with its archive in storage until expiresAt
+func (s *PostgresAccountExportStore) CompleteAccountExport(id int64, storageKey string, size int64, expiresAt time.Time) error {
+	query := `
+		UPDATE account_exports
+		SET status = 'done', progress = 100, storage_key = $2, size_bytes = $3, error = NULL,
+			completed_at = NOW(), expires_at = $4
+		WHERE id = $1
+	`
+
+	if _, err := s.db.Exec(query, id, storageKey, size, expiresAt); err != nil {
+		return fmt.Errorf("failed to complete account export: %w", mapError(err))
+	}
+	return nil
+}
+
+// FailAccountExport marks an export as failed with a message for its owner
+func (s *PostgresAccountExportStore) FailAccountExport(id int64, message string) error {
+	query := `UPDATE account_exports SET status = 'failed', error = $2, completed_at = NOW() WHERE id = $1`
+
+	if _, err := s.db.Exec(query, id, message); err != nil {
+		return fmt.Errorf("failed to mark account export failed: %w", mapError(err))
+	}
+	return nil
+}
+
+// GetExpiredAccountExports returns up to limit exports whose archives are past their expiry but still stored
+func (s *PostgresAccountExportStore) GetExpiredAccountExports(now time.Time, limit int) ([]*AccountExport, error) {
+	query := `
+		SELECT ` + accountExportColumns + `
+		FROM account_exports e
+		JOIN users u ON u.id = e.user_id
+		WHERE e.storage_key IS NOT NULL AND e.expires_at <= $1
+		ORDER BY e.expires_at
+		LIMIT $2
+	`
+
+	rows, err := s.db.Query(query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired account exports: %w", err)
+	}
+	defer rows.Close()
+
+	exports := []*AccountExport{}
+	for rows.Next() {
+		export := &AccountExport{}
+		if err := scanAccountExport(rows, export); err != nil {
+			return nil, fmt.Errorf("failed to scan account export: %w", err)
+		}
+		exports = append(exports, export)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over account exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// ClearAccountExportArchive forgets an export's archive once it has been deleted from storage
+// The export record stays so its owner can still see that it expired
+func (s *PostgresAccountExportStore) ClearAccountExportArchive(id int64) error {
+	if _, err := s.db.Exec(`UPDATE account_exports SET storage_key = NULL WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to clear account export archive: %w", mapError(err))
+	}
+	return nil
+}
+
+// exportDate returns the UTC calendar day of t for a DATE column, or nil
+func exportDate(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.DateOnly)
+}
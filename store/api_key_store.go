@@ -0,0 +1,198 @@
+package store
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// APIKeyPrefix marks ChefShare API keys so they are easy to recognise in logs and secret scanners
+	APIKeyPrefix = "csk_"
+
+	// apiKeyDisplayLength is how many leading characters of a key are kept to identify it in listings
+	apiKeyDisplayLength = 12
+)
+
+// APIKey is a long-lived credential that can be exchanged for scope-restricted access tokens
+// The plaintext key is never stored; KeyPrefix identifies it to its owner
+type APIKey struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"-"`
+	PublicID   string     `json:"-"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// APIKeyStore defines the interface for API key operations
+type APIKeyStore interface {
+	CreateAPIKey(userID int64, name string, scopes []string) (*APIKey, string, error)
+	GetAPIKeys(userID int64) ([]*APIKey, error)
+	RevokeAPIKey(id int64, userID int64) error
+	UseAPIKey(key string) (*APIKey, error)
+}
+
+// PostgresAPIKeyStore implements the APIKeyStore interface using PostgreSQL
+type PostgresAPIKeyStore struct {
+	db *sql.DB
+}
+
+// NewPostgresAPIKeyStore creates a new PostgresAPIKeyStore
+func NewPostgresAPIKeyStore(db *sql.DB) *PostgresAPIKeyStore {
+	return &PostgresAPIKeyStore{
+		db: db,
+	}
+}
+
+// generateAPIKey returns a new random API key
+func generateAPIKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+
+	return APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashAPIKey returns the hex SHA-256 digest stored in place of a key
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// apiKeyColumns lists the api_keys (k) columns read by scanAPIKey, in order
+const apiKeyColumns = `k.id, k.user_id, k.name, k.key_prefix, k.scopes, k.created_at, k.last_used_at`
+
+func scanAPIKey(row rowScanner, extra ...interface{}) (*APIKey, error) {
+	apiKey := &APIKey{}
+	var scopes []byte
+	dest := []interface{}{
+		&apiKey.ID,
+		&apiKey.UserID,
+		&apiKey.Name,
+		&apiKey.KeyPrefix,
+		&scopes,
+		&apiKey.CreatedAt,
+		&apiKey.LastUsedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(scopes, &apiKey.Scopes); err != nil {
+		return nil, fmt.Errorf("failed to decode API key scopes: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// CreateAPIKey creates an API key with the given scopes
+// It returns the stored key and the plaintext key, which cannot be recovered later
+func (s *PostgresAPIKeyStore) CreateAPIKey(userID int64, name string, scopes []string) (*APIKey, string, error) {
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	encodedScopes, err := json.Marshal(scopes)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode API key scopes: %w", err)
+	}
+
+	query := `
+		INSERT INTO api_keys AS k (user_id, name, key_prefix, key_hash, scopes)
+		VALUES ($1, $2, $3, $4, $5::JSONB)
+		RETURNING ` + apiKeyColumns
+
+	apiKey, err := scanAPIKey(s.db.QueryRow(query, userID, name, key[:apiKeyDisplayLength], hashAPIKey(key), string(encodedScopes)))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create API key: %w", mapError(err))
+	}
+
+	return apiKey, key, nil
+}
+
+// GetAPIKeys returns a user's active API keys, newest first
+func (s *PostgresAPIKeyStore) GetAPIKeys(userID int64) ([]*APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys k
+		WHERE k.user_id = $1 AND k.revoked_at IS NULL
+		ORDER BY k.created_at DESC
+	`
+
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	defer rows.Close()
+
+	apiKeys := []*APIKey{}
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over API keys: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+// RevokeAPIKey revokes one of a user's API keys
+// Returns ErrNotFound if the key does not exist, belongs to someone else or is already revoked
+func (s *PostgresAPIKeyStore) RevokeAPIKey(id int64, userID int64) error {
+	result, err := s.db.Exec(`
+		UPDATE api_keys SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UseAPIKey looks up an active API key by its plaintext value and records that it was used
+// Returns nil if the key is unknown or revoked
+func (s *PostgresAPIKeyStore) UseAPIKey(key string) (*APIKey, error) {
+	query := `
+		UPDATE api_keys k SET last_used_at = NOW()
+		FROM users u
+		WHERE k.key_hash = $1 AND k.revoked_at IS NULL AND u.id = k.user_id
+		RETURNING ` + apiKeyColumns + `, u.user_id
+	`
+
+	var publicID string
+	apiKey, err := scanAPIKey(s.db.QueryRow(query, hashAPIKey(key)), &publicID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to use API key: %w", mapError(err))
+	}
+	apiKey.PublicID = publicID
+
+	return apiKey, nil
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// BlockedUser is a user someone has blocked
+type BlockedUser struct {
+	UserID         string    `json:"user_id"`
+	Username       string    `json:"username"`
+	ProfilePicture *string   `json:"profile_picture,omitempty"`
+	BlockedAt      time.Time `json:"blocked_at"`
+}
+
+// BlockStore defines the interface for user block list operations
+type BlockStore interface {
+	BlockUser(blockerID int64, blockedID int64) error
+	UnblockUser(blockerID int64, blockedID int64) error
+	GetBlockedUsers(blockerID int64) ([]*BlockedUser, error)
+	IsBlockedEitherWay(userID int64, otherID int64) (bool, error)
+}
+
+// PostgresBlockStore implements the BlockStore interface using PostgreSQL
+type PostgresBlockStore struct {
+	db *sql.DB
+}
+
+// NewPostgresBlockStore creates a new PostgresBlockStore
+func NewPostgresBlockStore(db *sql.DB) *PostgresBlockStore {
+	return &PostgresBlockStore{
+		db: db,
+	}
+}
+
+// BlockUser adds a user to the blocker's block list
+// Blocking a user twice is not an error
+func (s *PostgresBlockStore) BlockUser(blockerID int64, blockedID int64) error {
+	query := `
+		INSERT INTO user_blocks (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`
+
+	if _, err := s.db.Exec(query, blockerID, blockedID); err != nil {
+		return fmt.Errorf("failed to block user: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// UnblockUser removes a user from the blocker's block list
+// Returns ErrNotFound if the user was not blocked
+func (s *PostgresBlockStore) UnblockUser(blockerID int64, blockedID int64) error {
+	result, err := s.db.Exec(`DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2`, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("failed to unblock user: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetBlockedUsers returns the users on a block list, most recently blocked first
+func (s *PostgresBlockStore) GetBlockedUsers(blockerID int64) ([]*BlockedUser, error) {
+	query := `
+		SELECT u.user_id, u.username, u.profile_picture, b.created_at
+		FROM user_blocks b
+		JOIN users u ON u.id = b.blocked_id
+		WHERE b.blocker_id = $1
+		ORDER BY b.created_at DESC
+	`
+
+	rows, err := s.db.Query(query, blockerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*BlockedUser{}
+	for rows.Next() {
+		user := &BlockedUser{}
+		err := rows.Scan(&user.UserID, &user.Username, &user.ProfilePicture, &user.BlockedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan blocked user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over blocked users: %w", err)
+	}
+
+	return users, nil
+}
+
+// IsBlockedEitherWay reports whether either user has blocked the other
+func (s *PostgresBlockStore) IsBlockedEitherWay(userID int64, otherID int64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM user_blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`
+
+	var blocked bool
+	if err := s.db.QueryRow(query, userID, otherID).Scan(&blocked); err != nil {
+		return false, fmt.Errorf("failed to check block list: %w", err)
+	}
+
+	return blocked, nil
+}
@@ -0,0 +1,210 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	// ChefApplicationPending is an application waiting for an admin's decision
+	ChefApplicationPending = "pending"
+
+	// ChefApplicationApproved is an accepted application; the applicant is a verified chef
+	ChefApplicationApproved = "approved"
+
+	// ChefApplicationRejected is a declined application
+	ChefApplicationRejected = "rejected"
+)
+
+// IsValidChefApplicationStatus reports whether status is a known application status
+func IsValidChefApplicationStatus(status string) bool {
+	switch status {
+	case ChefApplicationPending, ChefApplicationApproved, ChefApplicationRejected:
+		return true
+	}
+	return false
+}
+
+// ChefApplication is a request to join the verified chef program
+type ChefApplication struct {
+	ID           int64      `json:"id"`
+	UserID       int64      `json:"-"`
+	Username     string     `json:"username"`
+	Credentials  string     `json:"credentials"`
+	PortfolioURL *string    `json:"portfolio_url,omitempty"`
+	Status       string     `json:"status"`
+	ReviewNote   *string    `json:"review_note,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// ChefApplicationStore defines the interface for verified chef program operations
+type ChefApplicationStore interface {
+	CreateChefApplication(userID int64, credentials string, portfolioURL *string) (*ChefApplication, error)
+	GetLatestChefApplication(userID int64) (*ChefApplication, error)
+	GetChefApplications(status string, page, limit int) ([]*ChefApplication, int, error)
+	ReviewChefApplication(id int64, reviewerID int64, status string, note *string) (*ChefApplication, error)
+}
+
+// PostgresChefApplicationStore implements the ChefApplicationStore interface using PostgreSQL
+type PostgresChefApplicationStore struct {
+	db *sql.DB
+}
+
+// NewPostgresChefApplicationStore creates a new PostgresChefApplicationStore
+func NewPostgresChefApplicationStore(db *sql.DB) *PostgresChefApplicationStore {
+	return &PostgresChefApplicationStore{
+		db: db,
+	}
+}
+
+// chefApplicationColumns lists the chef_applications (a) and users (u) columns read by scanChefApplication, in order
+const chefApplicationColumns = `
+	a.id, a.user_id, u.username, a.credentials, a.portfolio_url, a.status, a.review_note, a.created_at, a.reviewed_at`
+
+func scanChefApplication(row rowScanner, extra ...interface{}) (*ChefApplication, error) {
+	application := &ChefApplication{}
+	dest := []interface{}{
+		&application.ID,
+		&application.UserID,
+		&application.Username,
+		&application.Credentials,
+		&application.PortfolioURL,
+		&application.Status,
+		&application.ReviewNote,
+		&application.CreatedAt,
+		&application.ReviewedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+// CreateChefApplication submits a pending application
+// Returns ErrConflict if the user already has a pending application
+func (s *PostgresChefApplicationStore) CreateChefApplication(userID int64, credentials string, portfolioURL *string) (*ChefApplication, error) {
+	query := `
+		WITH a AS (
+			INSERT INTO chef_applications (user_id, credentials, portfolio_url)
+			VALUES ($1, $2, $3)
+			RETURNING *
+		)
+		SELECT ` + chefApplicationColumns + `
+		FROM a
+		JOIN users u ON u.id = a.user_id
+	`
+
+	application, err := scanChefApplication(s.db.QueryRow(query, userID, credentials, portfolioURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chef application: %w", mapError(err))
+	}
+
+	return application, nil
+}
+
+// GetLatestChefApplication returns a user's most recent application
+// Returns nil if the user has never applied
+func (s *PostgresChefApplicationStore) GetLatestChefApplication(userID int64) (*ChefApplication, error) {
+	query := `
+		SELECT ` + chefApplicationColumns + `
+		FROM chef_applications a
+		JOIN users u ON u.id = a.user_id
+		WHERE a.user_id = $1
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT 1
+	`
+
+	application, err := scanChefApplication(s.db.QueryRow(query, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get chef application: %w", err)
+	}
+
+	return application, nil
+}
+
+// GetChefApplications returns a page of applications with a status, oldest first, and the total count
+func (s *PostgresChefApplicationStore) GetChefApplications(status string, page, limit int) ([]*ChefApplication, int, error) {
+	query := `
+		SELECT ` + chefApplicationColumns + `, COUNT(*) OVER() AS total_count
+		FROM chef_applications a
+		JOIN users u ON u.id = a.user_id
+		WHERE a.status = $1
+		ORDER BY a.created_at ASC, a.id ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.Query(query, status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get chef applications: %w", err)
+	}
+	defer rows.Close()
+
+	applications := []*ChefApplication{}
+	total := 0
+	for rows.Next() {
+		application, err := scanChefApplication(rows, &total)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan chef application: %w", err)
+		}
+		applications = append(applications, application)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over chef applications: %w", err)
+	}
+
+	return applications, total, nil
+}
+
+// ReviewChefApplication approves or rejects a pending application
+// Approving it marks the applicant as a verified chef in the same transaction.
+// Returns ErrNotFound if the application does not exist or has already been reviewed
+func (s *PostgresChefApplicationStore) ReviewChefApplication(id int64, reviewerID int64, status string, note *string) (*ChefApplication, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		WITH a AS (
+			UPDATE chef_applications
+			SET status = $1, reviewed_by = $2, review_note = $3, reviewed_at = NOW()
+			WHERE id = $4 AND status = '` + ChefApplicationPending + `'
+			RETURNING *
+		)
+		SELECT ` + chefApplicationColumns + `
+		FROM a
+		JOIN users u ON u.id = a.user_id
+	`
+
+	application, err := scanChefApplication(tx.QueryRow(query, status, reviewerID, note, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to review chef application: %w", mapError(err))
+	}
+
+	if status == ChefApplicationApproved {
+		_, err = tx.Exec(`
+			UPDATE users
+			SET is_verified_chef = TRUE, verified_chef_at = NOW()
+			WHERE id = $1
+		`, application.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mark user verified: %w", mapError(err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", mapError(err))
+	}
+
+	return application, nil
+}
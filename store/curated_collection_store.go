@@ -0,0 +1,321 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// activeCollectionCondition matches curated collections (cc) whose publish window includes now
+const activeCollectionCondition = `(cc.starts_at IS NULL OR cc.starts_at <= NOW()) AND (cc.ends_at IS NULL OR cc.ends_at > NOW())`
+
+// CuratedCollection is an admin-curated, ordered set of recipes with an optional publish window
+type CuratedCollection struct {
+	ID             int64      `json:"id"`
+	Slug           string     `json:"slug"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	BannerImageURL *string    `json:"banner_image_url,omitempty"`
+	DisplayOrder   int        `json:"display_order"`
+	StartsAt       *time.Time `json:"starts_at,omitempty"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	RecipeCount    int        `json:"recipe_count"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Recipes        []*Recipe  `json:"recipes,omitempty"`
+}
+
+// CuratedCollectionStore defines the interface for curated collection operations
+type CuratedCollectionStore interface {
+	CreateCollection(collection *CuratedCollection) error
+	UpdateCollection(collection *CuratedCollection) error
+	DeleteCollection(id int64) error
+	GetCollection(id int64) (*CuratedCollection, error)
+	ListCollections() ([]*CuratedCollection, error)
+	ListActiveCollections() ([]*CuratedCollection, error)
+	GetActiveCollectionBySlug(slug string) (*CuratedCollection, error)
+	SetCollectionRecipes(collectionID int64, recipeIDs []int64) error
+}
+
+// PostgresCuratedCollectionStore implements the CuratedCollectionStore interface using PostgreSQL
+type PostgresCuratedCollectionStore struct {
+	db *sql.DB
+}
+
+// NewPostgresCuratedCollectionStore creates a new PostgresCuratedCollectionStore
+func NewPostgresCuratedCollectionStore(db *sql.DB) *PostgresCuratedCollectionStore {
+	return &PostgresCuratedCollectionStore{
+		db: db,
+	}
+}
+
+const curatedCollectionColumns = `
+	cc.id, cc.slug, cc.title, cc.description, cc.banner_image_url, cc.display_order,
+	cc.starts_at, cc.ends_at, cc.created_at, cc.updated_at,
+	(SELECT COUNT(*) FROM curated_collection_recipes ccr WHERE ccr.collection_id = cc.id) AS recipe_count`
+
+func scanCuratedCollection(row rowScanner) (*CuratedCollection, error) {
+	collection := &CuratedCollection{}
+	err := row.Scan(
+		&collection.ID,
+		&collection.Slug,
+		&collection.Title,
+		&collection.Description,
+		&collection.BannerImageURL,
+		&collection.DisplayOrder,
+		&collection.StartsAt,
+		&collection.EndsAt,
+		&collection.CreatedAt,
+		&collection.UpdatedAt,
+		&collection.RecipeCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// CreateCollection stores a new curated collection
+func (s *PostgresCuratedCollectionStore) CreateCollection(collection *CuratedCollection) error {
+	query := `
+		INSERT INTO curated_collections (slug, title, description, banner_image_url, display_order, starts_at, ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := s.db.QueryRow(
+		query,
+		collection.Slug,
+		collection.Title,
+		collection.Description,
+		collection.BannerImageURL,
+		collection.DisplayOrder,
+		collection.StartsAt,
+		collection.EndsAt,
+	).Scan(&collection.ID, &collection.CreatedAt, &collection.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create curated collection: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// UpdateCollection replaces a curated collection's details; its recipes are unchanged
+func (s *PostgresCuratedCollectionStore) UpdateCollection(collection *CuratedCollection) error {
+	query := `
+		UPDATE curated_collections
+		SET
+			slug = $1,
+			title = $2,
+			description = $3,
+			banner_image_url = $4,
+			display_order = $5,
+			starts_at = $6,
+			ends_at = $7,
+			updated_at = NOW()
+		WHERE id = $8
+		RETURNING created_at, updated_at
+	`
+
+	err := s.db.QueryRow(
+		query,
+		collection.Slug,
+		collection.Title,
+		collection.Description,
+		collection.BannerImageURL,
+		collection.DisplayOrder,
+		collection.StartsAt,
+		collection.EndsAt,
+		collection.ID,
+	).Scan(&collection.CreatedAt, &collection.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update curated collection: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// DeleteCollection removes a curated collection; the recipes themselves are unaffected
+func (s *PostgresCuratedCollectionStore) DeleteCollection(id int64) error {
+	result, err := s.db.Exec(`DELETE FROM curated_collections WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete curated collection: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetCollection returns a curated collection with all its recipes regardless of publish window or recipe status
+// Returns nil if the collection does not exist
+func (s *PostgresCuratedCollectionStore) GetCollection(id int64) (*CuratedCollection, error) {
+	query := `SELECT ` + curatedCollectionColumns + ` FROM curated_collections cc WHERE cc.id = $1`
+
+	collection, err := scanCuratedCollection(s.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get curated collection: %w", err)
+	}
+
+	collection.Recipes, err = s.getCollectionRecipes(collection.ID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// ListCollections returns every curated collection, including scheduled and expired ones
+func (s *PostgresCuratedCollectionStore) ListCollections() ([]*CuratedCollection, error) {
+	return s.listCollections(`TRUE`)
+}
+
+// ListActiveCollections returns the curated collections currently inside their publish window
+func (s *PostgresCuratedCollectionStore) ListActiveCollections() ([]*CuratedCollection, error) {
+	return s.listCollections(activeCollectionCondition)
+}
+
+func (s *PostgresCuratedCollectionStore) listCollections(condition string) ([]*CuratedCollection, error) {
+	query := `
+		SELECT ` + curatedCollectionColumns + `
+		FROM curated_collections cc
+		WHERE ` + condition + `
+		ORDER BY cc.display_order, cc.id
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list curated collections: %w", err)
+	}
+	defer rows.Close()
+
+	collections := []*CuratedCollection{}
+	for rows.Next() {
+		collection, err := scanCuratedCollection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan curated collection: %w", err)
+		}
+		collections = append(collections, collection)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over curated collections: %w", err)
+	}
+
+	return collections, nil
+}
+
+// GetActiveCollectionBySlug returns a curated collection inside its publish window with its published recipes in order
+// Returns nil if no active collection has the slug
+func (s *PostgresCuratedCollectionStore) GetActiveCollectionBySlug(slug string) (*CuratedCollection, error) {
+	query := `
+		SELECT ` + curatedCollectionColumns + `
+		FROM curated_collections cc
+		WHERE cc.slug = $1 AND ` + activeCollectionCondition
+
+	collection, err := scanCuratedCollection(s.db.QueryRow(query, slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get curated collection: %w", err)
+	}
+
+	collection.Recipes, err = s.getCollectionRecipes(collection.ID, true)
+	if err != nil {
+		return nil, err
+	}
+	collection.RecipeCount = len(collection.Recipes)
+
+	return collection, nil
+}
+
+func (s *PostgresCuratedCollectionStore) getCollectionRecipes(collectionID int64, publishedOnly bool) ([]*Recipe, error) {
+	query := `
+		SELECT ` + recipeSelectColumns + `
+		FROM curated_collection_recipes ccr
+		JOIN recipes r ON r.id = ccr.recipe_id
+		LEFT JOIN categories c ON r.category_id = c.id
+		WHERE ccr.collection_id = $1 AND ($2 = FALSE OR r.status = 'published')
+		ORDER BY ccr.position
+	`
+
+	rows, err := s.db.Query(query, collectionID, publishedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection recipes: %w", err)
+	}
+	defer rows.Close()
+
+	recipes := []*Recipe{}
+	for rows.Next() {
+		recipe := &Recipe{}
+		if err := scanRecipeRow(rows, recipe); err != nil {
+			return nil, fmt.Errorf("failed to scan collection recipe: %w", err)
+		}
+		recipes = append(recipes, recipe)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over collection recipes: %w", err)
+	}
+
+	return recipes, nil
+}
+
+// SetCollectionRecipes replaces a collection's recipes with recipeIDs, in that order, in a single transaction
+// Returns ErrNotFound if the collection does not exist
+func (s *PostgresCuratedCollectionStore) SetCollectionRecipes(collectionID int64, recipeIDs []int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the collection so concurrent edits apply one after the other
+	var id int64
+	err = tx.QueryRow(`SELECT id FROM curated_collections WHERE id = $1 FOR UPDATE`, collectionID).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to lock curated collection: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM curated_collection_recipes WHERE collection_id = $1`, collectionID); err != nil {
+		return fmt.Errorf("failed to clear collection recipes: %w", mapError(err))
+	}
+
+	for i, recipeID := range recipeIDs {
+		_, err := tx.Exec(
+			`INSERT INTO curated_collection_recipes (collection_id, recipe_id, position) VALUES ($1, $2, $3)`,
+			collectionID, recipeID, i+1,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add collection recipe: %w", mapError(err))
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE curated_collections SET updated_at = NOW() WHERE id = $1`, collectionID); err != nil {
+		return fmt.Errorf("failed to touch curated collection: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", mapError(err))
+	}
+
+	return nil
+}
@@ -9,7 +9,8 @@ import (
 
 	"io/fs"
 
-	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
 	"github.com/pressly/goose/v3"
 )
 
@@ -33,11 +34,19 @@ func Open() (*sql.DB, error) {
 	// Construct connection string
 	connStr := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s",
 		dbHost, dbPort, dbUser, dbName, dbPassword, sslMode)
-	// Open a connection to the database
-	db, err := sql.Open("pgx", connStr)
+	config, err := pgx.ParseConfig(connStr)
 	if err != nil {
 		return nil, fmt.Errorf("db: open %w", err)
 	}
+
+	// Log statements slower than SLOW_QUERY_THRESHOLD_MS
+	if threshold := slowQueryThresholdFromEnv(); threshold > 0 {
+		config.Logger = &slowQueryLogger{threshold: threshold}
+		config.LogLevel = pgx.LogLevelInfo
+	}
+
+	// Open a connection to the database
+	db := stdlib.OpenDB(*config)
 	fmt.Println("Connected to database...")
 	return db, nil
 }
@@ -0,0 +1,214 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// EmailSuppressionBounce is an address the email provider reported as permanently undeliverable
+	EmailSuppressionBounce = "bounce"
+
+	// EmailSuppressionComplaint is an address whose owner marked one of our emails as spam
+	EmailSuppressionComplaint = "complaint"
+
+	// EmailSuppressionManual is an address an admin opted out
+	EmailSuppressionManual = "manual"
+)
+
+// IsValidEmailSuppressionReason reports whether reason is a known suppression reason
+func IsValidEmailSuppressionReason(reason string) bool {
+	switch reason {
+	case EmailSuppressionBounce, EmailSuppressionComplaint, EmailSuppressionManual:
+		return true
+	}
+	return false
+}
+
+// EmailSuppression is an address no email is sent to
+type EmailSuppression struct {
+	ID        int64     `json:"id"`
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	Note      *string   `json:"note,omitempty"`
+	CreatedBy *int64    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EmailSuppressionListOptions filters and paginates the suppression list
+type EmailSuppressionListOptions struct {
+	// Query matches addresses containing it, ignoring case
+	Query string
+
+	// Reason only lists suppressions with this reason when set
+	Reason string
+
+	Page  int
+	Limit int
+}
+
+// EmailSuppressionStore defines the interface for the addresses emails are not sent to
+type EmailSuppressionStore interface {
+	AddEmailSuppression(suppression *EmailSuppression) (bool, error)
+	GetSuppressedEmails(emails []string) (map[string]bool, error)
+	GetEmailSuppressions(opts EmailSuppressionListOptions) ([]*EmailSuppression, int, error)
+	DeleteEmailSuppression(id int64) error
+}
+
+// PostgresEmailSuppressionStore implements the EmailSuppressionStore interface using PostgreSQL
+type PostgresEmailSuppressionStore struct {
+	db *sql.DB
+}
+
+// NewPostgresEmailSuppressionStore creates a new PostgresEmailSuppressionStore
+func NewPostgresEmailSuppressionStore(db *sql.DB) *PostgresEmailSuppressionStore {
+	return &PostgresEmailSuppressionStore{
+		db: db,
+	}
+}
+
+// emailSuppressionColumns lists the email_suppressions columns read by scanEmailSuppression, in order
+const emailSuppressionColumns = `id, email, reason, note, created_by, created_at`
+
+func scanEmailSuppression(row rowScanner, extra ...interface{}) (*EmailSuppression, error) {
+	suppression := &EmailSuppression{}
+	dest := []interface{}{
+		&suppression.ID,
+		&suppression.Email,
+		&suppression.Reason,
+		&suppression.Note,
+		&suppression.CreatedBy,
+		&suppression.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return suppression, nil
+}
+
+// AddEmailSuppression suppresses an address and fills in the suppression's ID and creation time
+// An address that is already suppressed keeps its existing entry, which is returned instead;
+// the first return value reports whether a new entry was added.
+func (s *PostgresEmailSuppressionStore) AddEmailSuppression(suppression *EmailSuppression) (bool, error) {
+	query := `
+		WITH inserted AS (
+			INSERT INTO email_suppressions (email, reason, note, created_by)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT ((LOWER(email))) DO NOTHING
+			RETURNING ` + emailSuppressionColumns + `
+		)
+		SELECT ` + emailSuppressionColumns + `, TRUE FROM inserted
+		UNION ALL
+		SELECT ` + emailSuppressionColumns + `, FALSE FROM email_suppressions
+		WHERE LOWER(email) = LOWER($1) AND NOT EXISTS (SELECT 1 FROM inserted)
+	`
+
+	var added bool
+	existing, err := scanEmailSuppression(s.db.QueryRow(query, strings.TrimSpace(suppression.Email), suppression.Reason, suppression.Note, suppression.CreatedBy), &added)
+	if err != nil {
+		return false, fmt.Errorf("failed to add email suppression: %w", mapError(err))
+	}
+
+	*suppression = *existing
+	return added, nil
+}
+
+// GetSuppressedEmails returns which of the given addresses are suppressed, keyed by the address as given
+func (s *PostgresEmailSuppressionStore) GetSuppressedEmails(emails []string) (map[string]bool, error) {
+	suppressed := map[string]bool{}
+	if len(emails) == 0 {
+		return suppressed, nil
+	}
+
+	lowered := make([]string, len(emails))
+	placeholders := make([]string, len(emails))
+	args := make([]interface{}, len(emails))
+	for i, email := range emails {
+		lowered[i] = strings.ToLower(strings.TrimSpace(email))
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = lowered[i]
+	}
+
+	rows, err := s.db.Query(`SELECT LOWER(email) FROM email_suppressions WHERE LOWER(email) IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suppressed emails: %w", err)
+	}
+	defer rows.Close()
+
+	found := map[string]bool{}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan suppressed email: %w", err)
+		}
+		found[email] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over suppressed emails: %w", err)
+	}
+
+	for i, email := range emails {
+		if found[lowered[i]] {
+			suppressed[email] = true
+		}
+	}
+	return suppressed, nil
+}
+
+// GetEmailSuppressions returns a page of suppressions, newest first, with the total number matching
+func (s *PostgresEmailSuppressionStore) GetEmailSuppressions(opts EmailSuppressionListOptions) ([]*EmailSuppression, int, error) {
+	query := `
+		SELECT ` + emailSuppressionColumns + `, COUNT(*) OVER()
+		FROM email_suppressions
+		WHERE ($1 = '' OR email ILIKE '%' || $1 || '%') AND ($2 = '' OR reason = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	// Match the query literally rather than as a LIKE pattern
+	pattern := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(opts.Query)
+	rows, err := s.db.Query(query, pattern, opts.Reason, opts.Limit, (opts.Page-1)*opts.Limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get email suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	suppressions := []*EmailSuppression{}
+	total := 0
+	for rows.Next() {
+		suppression, err := scanEmailSuppression(rows, &total)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan email suppression: %w", err)
+		}
+		suppressions = append(suppressions, suppression)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over email suppressions: %w", err)
+	}
+
+	return suppressions, total, nil
+}
+
+// DeleteEmailSuppression removes a suppression, letting emails to its address through again
+// Returns ErrNotFound if it does not exist
+func (s *PostgresEmailSuppressionStore) DeleteEmailSuppression(id int64) error {
+	result, err := s.db.Exec(`DELETE FROM email_suppressions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete email suppression: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -55,7 +55,7 @@ func (s *PostgresEmailVerificationStore) CreateVerificationToken(userID string,
 	// First, invalidate any existing tokens for this user
 	_, err := s.DeleteUserTokens(userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to invalidate existing tokens: %w", err)
+		return nil, fmt.Errorf("failed to invalidate existing tokens: %w", mapError(err))
 	}
 
 	// Generate a new token
@@ -83,7 +83,7 @@ func (s *PostgresEmailVerificationStore) CreateVerificationToken(userID string,
 		&verificationToken.ID, &verificationToken.CreatedAt)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to create verification token: %w", err)
+		return nil, fmt.Errorf("failed to create verification token: %w", mapError(err))
 	}
 
 	return verificationToken, nil
@@ -122,7 +122,7 @@ func (s *PostgresEmailVerificationStore) DeleteToken(tokenID int64) error {
 
 	_, err := s.db.Exec(query, tokenID)
 	if err != nil {
-		return fmt.Errorf("failed to delete token: %w", err)
+		return fmt.Errorf("failed to delete token: %w", mapError(err))
 	}
 
 	return nil
@@ -134,7 +134,7 @@ func (s *PostgresEmailVerificationStore) DeleteExpiredTokens() (int64, error) {
 
 	result, err := s.db.Exec(query, time.Now())
 	if err != nil {
-		return 0, fmt.Errorf("failed to delete expired tokens: %w", err)
+		return 0, fmt.Errorf("failed to delete expired tokens: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -151,7 +151,7 @@ func (s *PostgresEmailVerificationStore) DeleteUserTokens(userID string) (int64,
 
 	result, err := s.db.Exec(query, userID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to delete user tokens: %w", err)
+		return 0, fmt.Errorf("failed to delete user tokens: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
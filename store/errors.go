@@ -0,0 +1,84 @@
+package store
+
+import (
+	"errors"
+
+	"github.com/jackc/pgconn"
+)
+
+var (
+	// ErrNotFound is returned when a record to update or delete does not exist or is not visible to the caller
+	ErrNotFound = errors.New("record not found")
+
+	// ErrConflict is returned when a write would violate a unique constraint
+	ErrConflict = errors.New("record already exists")
+
+	// ErrForeignKey is returned when a write references a record that does not exist, or deletes one still referenced
+	ErrForeignKey = errors.New("referenced record does not exist")
+
+	// ErrVersionMismatch is returned when a record was changed by someone else since the version being written was read
+	ErrVersionMismatch = errors.New("record was modified concurrently")
+
+	// ErrInvalidInput is returned when the database rejects a value, such as a failed CHECK constraint
+	ErrInvalidInput = errors.New("invalid input")
+)
+
+// PostgreSQL error codes mapped to store errors
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation         = "23505"
+	pgForeignKeyViolation     = "23503"
+	pgCheckViolation          = "23514"
+	pgNotNullViolation        = "23502"
+	pgInvalidTextRepresention = "22P02"
+	pgStringDataRightTrunc    = "22001"
+)
+
+// Error is a database error classified as one of the store errors
+// errors.Is matches both the store error (Kind) and the original driver error
+type Error struct {
+	Kind       error
+	Constraint string
+	Err        error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// mapError classifies PostgreSQL constraint and data errors as store errors
+// Other errors are returned unchanged, so it is safe to call on any error before wrapping it
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	var kind error
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		kind = ErrConflict
+	case pgForeignKeyViolation:
+		kind = ErrForeignKey
+	case pgCheckViolation, pgNotNullViolation, pgInvalidTextRepresention, pgStringDataRightTrunc:
+		kind = ErrInvalidInput
+	default:
+		return err
+	}
+
+	return &Error{Kind: kind, Constraint: pgErr.ConstraintName, Err: err}
+}
+
+// ConstraintName returns the name of the constraint a store error violated, or "" if there is none
+// Handlers use it to tell apart conflicts on different unique columns, such as username and email
+func ConstraintName(err error) string {
+	var storeErr *Error
+	if errors.As(err, &storeErr) {
+		return storeErr.Constraint
+	}
+	return ""
+}
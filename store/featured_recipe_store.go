@@ -0,0 +1,108 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// FeaturedRecipe is a recipe admins have featured, with its placement and optional expiry
+type FeaturedRecipe struct {
+	Recipe       *Recipe    `json:"recipe"`
+	DisplayOrder int        `json:"display_order"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	FeaturedAt   time.Time  `json:"featured_at"`
+}
+
+// FeaturedRecipeStore defines the interface for featured recipe operations
+type FeaturedRecipeStore interface {
+	FeatureRecipe(recipeID int64, displayOrder int, expiresAt *time.Time, featuredBy int64) error
+	UnfeatureRecipe(recipeID int64) error
+	GetFeaturedRecipes(includeInactive bool) ([]*FeaturedRecipe, error)
+}
+
+// PostgresFeaturedRecipeStore implements the FeaturedRecipeStore interface using PostgreSQL
+type PostgresFeaturedRecipeStore struct {
+	db *sql.DB
+}
+
+// NewPostgresFeaturedRecipeStore creates a new PostgresFeaturedRecipeStore
+func NewPostgresFeaturedRecipeStore(db *sql.DB) *PostgresFeaturedRecipeStore {
+	return &PostgresFeaturedRecipeStore{
+		db: db,
+	}
+}
+
+// FeatureRecipe features a recipe, or updates its placement and expiry if it is already featured
+func (s *PostgresFeaturedRecipeStore) FeatureRecipe(recipeID int64, displayOrder int, expiresAt *time.Time, featuredBy int64) error {
+	query := `
+		INSERT INTO featured_recipes (recipe_id, display_order, expires_at, featured_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (recipe_id) DO UPDATE
+		SET display_order = EXCLUDED.display_order,
+			expires_at = EXCLUDED.expires_at,
+			featured_by = EXCLUDED.featured_by
+	`
+
+	if _, err := s.db.Exec(query, recipeID, displayOrder, expiresAt, featuredBy); err != nil {
+		return fmt.Errorf("failed to feature recipe: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// UnfeatureRecipe removes a recipe from the featured list
+func (s *PostgresFeaturedRecipeStore) UnfeatureRecipe(recipeID int64) error {
+	result, err := s.db.Exec(`DELETE FROM featured_recipes WHERE recipe_id = $1`, recipeID)
+	if err != nil {
+		return fmt.Errorf("failed to unfeature recipe: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetFeaturedRecipes returns featured recipes in display order
+// Unless includeInactive is set, expired entries and recipes that are no longer published are left out
+func (s *PostgresFeaturedRecipeStore) GetFeaturedRecipes(includeInactive bool) ([]*FeaturedRecipe, error) {
+	query := `
+		SELECT ` + recipeSelectColumns + `,
+			f.display_order, f.expires_at, f.featured_at
+		FROM featured_recipes f
+		JOIN recipes r ON r.id = f.recipe_id
+		LEFT JOIN categories c ON r.category_id = c.id
+		WHERE $1::BOOLEAN
+			OR (r.status = 'published' AND (f.expires_at IS NULL OR f.expires_at > NOW()))
+		ORDER BY f.display_order, f.featured_at DESC
+	`
+
+	rows, err := s.db.Query(query, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get featured recipes: %w", err)
+	}
+	defer rows.Close()
+
+	featured := []*FeaturedRecipe{}
+	for rows.Next() {
+		item := &FeaturedRecipe{Recipe: &Recipe{}}
+		err := scanRecipeRow(rows, item.Recipe, &item.DisplayOrder, &item.ExpiresAt, &item.FeaturedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan featured recipe: %w", err)
+		}
+		featured = append(featured, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over featured recipes: %w", err)
+	}
+
+	return featured, nil
+}
@@ -0,0 +1,43 @@
+package store
+
+// Mocks for every store interface are generated into mocks/store with mockgen (go.uber.org/mock)
+// Run `make mocks` after changing an interface so handler tests keep compiling
+
+//go:generate go run go.uber.org/mock/mockgen -source=account_export_store.go -destination=../mocks/store/account_export_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=api_key_store.go -destination=../mocks/store/api_key_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=block_store.go -destination=../mocks/store/block_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=chef_application_store.go -destination=../mocks/store/chef_application_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=curated_collection_store.go -destination=../mocks/store/curated_collection_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=email_suppression_store.go -destination=../mocks/store/email_suppression_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=email_verification_store.go -destination=../mocks/store/email_verification_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=featured_recipe_store.go -destination=../mocks/store/featured_recipe_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=identity_store.go -destination=../mocks/store/identity_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=ingredient_store.go -destination=../mocks/store/ingredient_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=invitation_store.go -destination=../mocks/store/invitation_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=meal_plan_store.go -destination=../mocks/store/meal_plan_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=mention_store.go -destination=../mocks/store/mention_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=message_store.go -destination=../mocks/store/message_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=metrics_store.go -destination=../mocks/store/metrics_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=notification_store.go -destination=../mocks/store/notification_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=pantry_store.go -destination=../mocks/store/pantry_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=password_reset_store.go -destination=../mocks/store/password_reset_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=recipe_activity_store.go -destination=../mocks/store/recipe_activity_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=recipe_allergen_store.go -destination=../mocks/store/recipe_allergen_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=recipe_cook_store.go -destination=../mocks/store/recipe_cook_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=recipe_deletion_store.go -destination=../mocks/store/recipe_deletion_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=recipe_note_store.go -destination=../mocks/store/recipe_note_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=recipe_preview_store.go -destination=../mocks/store/recipe_preview_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=recipe_stats_store.go -destination=../mocks/store/recipe_stats_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=recipe_store.go -destination=../mocks/store/recipe_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=recipe_template_store.go -destination=../mocks/store/recipe_template_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=referral_store.go -destination=../mocks/store/referral_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=refresh_token_store.go -destination=../mocks/store/refresh_token_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=reputation_store.go -destination=../mocks/store/reputation_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=retention_store.go -destination=../mocks/store/retention_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=review_moderation_store.go -destination=../mocks/store/review_moderation_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=search_store.go -destination=../mocks/store/search_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=shopping_list_store.go -destination=../mocks/store/shopping_list_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=token_blacklist_store.go -destination=../mocks/store/token_blacklist_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=usage_store.go -destination=../mocks/store/usage_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=user_store.go -destination=../mocks/store/user_store.go -package=mockstore
+//go:generate go run go.uber.org/mock/mockgen -source=webhook_store.go -destination=../mocks/store/webhook_store.go -package=mockstore
@@ -0,0 +1,337 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Intents of an OAuth authorization request
+const (
+	// OAuthIntentLogin signs in with the identity, or creates an account for it
+	OAuthIntentLogin = "login"
+
+	// OAuthIntentLink adds the identity to the account that started the request
+	OAuthIntentLink = "link"
+)
+
+// UserIdentity is an identity at an OAuth provider that can sign in to an account
+type UserIdentity struct {
+	ID           int64      `json:"id"`
+	UserID       int64      `json:"-"`
+	UserPublicID string     `json:"-"`
+	Provider     string     `json:"provider"`
+	Subject      string     `json:"-"`
+	Email        *string    `json:"email,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+}
+
+// OAuthState is an authorization request sent to a provider and not yet completed
+type OAuthState struct {
+	State        string
+	Provider     string
+	Intent       string
+	UserID       *int64
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+// PendingIdentityLink is a provider identity whose email matches an existing account,
+// waiting for the account's owner to confirm the link from their inbox
+type PendingIdentityLink struct {
+	Token        string
+	UserID       int64
+	UserPublicID string
+	Provider     string
+	Subject      string
+	Email        string
+	ExpiresAt    time.Time
+}
+
+// IdentityStore defines the interface for OAuth identity operations
+type IdentityStore interface {
+	CreateOAuthState(state *OAuthState) error
+	ConsumeOAuthState(state string, provider string) (*OAuthState, error)
+
+	GetIdentity(provider string, subject string) (*UserIdentity, error)
+	GetIdentitiesForUser(userID int64) ([]*UserIdentity, error)
+	CreateIdentity(identity *UserIdentity) error
+	DeleteIdentity(userID int64, provider string) error
+	TouchIdentity(identityID int64) error
+	CreateOAuthUserWithTransaction(user *User, identity *UserIdentity, tx *sql.Tx) error
+
+	CreatePendingLink(link *PendingIdentityLink) error
+	ConsumePendingLink(token string) (*PendingIdentityLink, error)
+}
+
+// PostgresIdentityStore implements the IdentityStore interface using PostgreSQL
+type PostgresIdentityStore struct {
+	db *sql.DB
+}
+
+// NewPostgresIdentityStore creates a new PostgresIdentityStore
+func NewPostgresIdentityStore(db *sql.DB) *PostgresIdentityStore {
+	return &PostgresIdentityStore{
+		db: db,
+	}
+}
+
+const identityColumns = `i.id, i.user_id, u.user_id, i.provider, i.subject, i.email, i.created_at, i.last_used_at`
+
+func scanIdentity(row rowScanner) (*UserIdentity, error) {
+	identity := &UserIdentity{}
+	err := row.Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.UserPublicID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastUsedAt,
+	)
+	return identity, err
+}
+
+// CreateOAuthState records an authorization request, generating its state value
+func (s *PostgresIdentityStore) CreateOAuthState(state *OAuthState) error {
+	token, err := generateVerificationToken()
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO oauth_states (state, provider, intent, user_id, code_verifier, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = s.db.Exec(query, token, state.Provider, state.Intent, state.UserID, state.CodeVerifier, state.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create oauth state: %w", mapError(err))
+	}
+
+	state.State = token
+	return nil
+}
+
+// ConsumeOAuthState deletes and returns an unexpired authorization request for a provider,
+// or nil if there is none, so each state can complete at most one sign-in
+func (s *PostgresIdentityStore) ConsumeOAuthState(state string, provider string) (*OAuthState, error) {
+	query := `
+		DELETE FROM oauth_states
+		WHERE state = $1 AND provider = $2 AND expires_at > NOW()
+		RETURNING state, provider, intent, user_id, code_verifier, expires_at
+	`
+
+	result := &OAuthState{}
+	err := s.db.QueryRow(query, state, provider).Scan(
+		&result.State,
+		&result.Provider,
+		&result.Intent,
+		&result.UserID,
+		&result.CodeVerifier,
+		&result.ExpiresAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to consume oauth state: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetIdentity returns the identity a provider knows by subject, or nil if no account has linked it
+func (s *PostgresIdentityStore) GetIdentity(provider string, subject string) (*UserIdentity, error) {
+	query := `
+		SELECT ` + identityColumns + `
+		FROM user_identities i
+		JOIN users u ON u.id = i.user_id
+		WHERE i.provider = $1 AND i.subject = $2
+	`
+
+	identity, err := scanIdentity(s.db.QueryRow(query, provider, subject))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+// GetIdentitiesForUser returns the identities linked to an account, in the order they were linked
+func (s *PostgresIdentityStore) GetIdentitiesForUser(userID int64) ([]*UserIdentity, error) {
+	query := `
+		SELECT ` + identityColumns + `
+		FROM user_identities i
+		JOIN users u ON u.id = i.user_id
+		WHERE i.user_id = $1
+		ORDER BY i.created_at, i.id
+	`
+
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identities: %w", err)
+	}
+	defer rows.Close()
+
+	identities := []*UserIdentity{}
+	for rows.Next() {
+		identity, err := scanIdentity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan identity: %w", err)
+		}
+		identities = append(identities, identity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+// CreateIdentity links an identity to identity.UserID
+// Returns ErrConflict if the identity belongs to an account already or the account has one at the provider
+func (s *PostgresIdentityStore) CreateIdentity(identity *UserIdentity) error {
+	return createIdentity(s.db, identity)
+}
+
+func createIdentity(db DBTX, identity *UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (user_id, provider, subject, email, last_used_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, (SELECT user_id FROM users WHERE id = $1), created_at, last_used_at
+	`
+
+	err := db.QueryRow(query, identity.UserID, identity.Provider, identity.Subject, identity.Email).
+		Scan(&identity.ID, &identity.UserPublicID, &identity.CreatedAt, &identity.LastUsedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create identity: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// DeleteIdentity unlinks an account's identity at a provider
+func (s *PostgresIdentityStore) DeleteIdentity(userID int64, provider string) error {
+	result, err := s.db.Exec(`DELETE FROM user_identities WHERE user_id = $1 AND provider = $2`, userID, provider)
+	if err != nil {
+		return fmt.Errorf("failed to delete identity: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// TouchIdentity records that an identity was just used to sign in
+func (s *PostgresIdentityStore) TouchIdentity(identityID int64) error {
+	_, err := s.db.Exec(`UPDATE user_identities SET last_used_at = NOW() WHERE id = $1`, identityID)
+	if err != nil {
+		return fmt.Errorf("failed to update identity: %w", mapError(err))
+	}
+	return nil
+}
+
+// CreateOAuthUserWithTransaction creates an account that signs in with a provider identity, and links the identity to it
+// The account has no password: it gets a random hash nobody knows until its owner sets a password
+// Returns ErrConflict if the username or email is taken or the identity is linked already
+func (s *PostgresIdentityStore) CreateOAuthUserWithTransaction(user *User, identity *UserIdentity, tx *sql.Tx) error {
+	secret, err := generateVerificationToken()
+	if err != nil {
+		return err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.PasswordHash.hash = hash
+
+	query := `
+		INSERT INTO users (user_id, username, email, password_hash, bio, first_name, last_name, profile_picture, email_verified, has_password)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, FALSE)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = tx.QueryRow(
+		query,
+		user.UserID,
+		user.Username,
+		user.Email,
+		hash,
+		user.Bio,
+		user.FirstName,
+		user.LastName,
+		user.ProfilePicture,
+		user.EmailVerified,
+	).Scan(&identity.UserID, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", mapError(err))
+	}
+
+	return createIdentity(tx, identity)
+}
+
+// CreatePendingLink records an identity waiting for the account's owner to confirm it, generating its token
+func (s *PostgresIdentityStore) CreatePendingLink(link *PendingIdentityLink) error {
+	token, err := generateVerificationToken()
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO pending_identity_links (token, user_id, provider, subject, email, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = s.db.Exec(query, token, link.UserID, link.Provider, link.Subject, link.Email, link.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create pending identity link: %w", mapError(err))
+	}
+
+	link.Token = token
+	return nil
+}
+
+// ConsumePendingLink deletes and returns an unexpired pending link, or nil if there is none
+func (s *PostgresIdentityStore) ConsumePendingLink(token string) (*PendingIdentityLink, error) {
+	query := `
+		DELETE FROM pending_identity_links
+		WHERE token = $1 AND expires_at > NOW()
+		RETURNING token, user_id, (SELECT u.user_id FROM users u WHERE u.id = pending_identity_links.user_id),
+			provider, subject, email, expires_at
+	`
+
+	link := &PendingIdentityLink{}
+	err := s.db.QueryRow(query, token).Scan(
+		&link.Token,
+		&link.UserID,
+		&link.UserPublicID,
+		&link.Provider,
+		&link.Subject,
+		&link.Email,
+		&link.ExpiresAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to consume pending identity link: %w", err)
+	}
+
+	return link, nil
+}
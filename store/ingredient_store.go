@@ -0,0 +1,324 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// PriceSourceAdmin marks prices entered by an administrator
+	PriceSourceAdmin = "admin"
+
+	// PriceSourceAPI marks prices imported from an external pricing API
+	PriceSourceAPI = "api"
+)
+
+// pricedIngredientCondition matches recipe ingredients (ri) whose catalog entry (i) has a usable price
+// A price only applies when the recipe uses the same unit the price is quoted in
+const pricedIngredientCondition = `ri.quantity IS NOT NULL AND i.price_per_unit IS NOT NULL AND LOWER(TRIM(ri.unit)) = i.price_unit`
+
+// Ingredient represents an entry in the canonical ingredient catalog
+type Ingredient struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	UsageCount int    `json:"usage_count"`
+}
+
+// IngredientPrice is the price of a canonical ingredient per unit
+type IngredientPrice struct {
+	IngredientID int64      `json:"ingredient_id"`
+	Name         string     `json:"name"`
+	PricePerUnit *float64   `json:"price_per_unit"`
+	PriceUnit    *string    `json:"price_unit"`
+	PriceSource  *string    `json:"price_source,omitempty"`
+	UpdatedAt    *time.Time `json:"price_updated_at,omitempty"`
+}
+
+// RecipeCost is an estimate of what a recipe costs to make
+// Only ingredients with a known price in the same unit the recipe uses are counted
+type RecipeCost struct {
+	TotalCost         *float64 `json:"total_cost"`
+	CostPerServing    *float64 `json:"cost_per_serving"`
+	PricedIngredients int      `json:"priced_ingredients"`
+	TotalIngredients  int      `json:"total_ingredients"`
+}
+
+// Where the density used for an ingredient came from
+const (
+	// DensitySourceOverride is a density set by an administrator
+	DensitySourceOverride = "override"
+
+	// DensitySourceCatalog is the reference density in the ingredient catalog
+	DensitySourceCatalog = "catalog"
+
+	// DensitySourceFallback is FallbackDensity, used when an ingredient has no known density
+	DensitySourceFallback = "fallback"
+)
+
+// FallbackDensity is the density of water in grams per millilitre, assumed for ingredients without a known density
+const FallbackDensity = 1.0
+
+// IngredientDensity is what is known about the density of a catalog ingredient, in grams per millilitre
+type IngredientDensity struct {
+	IngredientID      int64      `json:"ingredient_id"`
+	Name              string     `json:"name"`
+	CatalogDensity    *float64   `json:"catalog_density_g_per_ml"`
+	OverrideDensity   *float64   `json:"override_density_g_per_ml"`
+	OverrideUpdatedAt *time.Time `json:"override_updated_at,omitempty"`
+}
+
+// Effective returns the density to convert with and its source: the override if set, else the catalog density,
+// else FallbackDensity. d may be nil for ingredients not in the catalog
+func (d *IngredientDensity) Effective() (float64, string) {
+	switch {
+	case d != nil && d.OverrideDensity != nil:
+		return *d.OverrideDensity, DensitySourceOverride
+	case d != nil && d.CatalogDensity != nil:
+		return *d.CatalogDensity, DensitySourceCatalog
+	default:
+		return FallbackDensity, DensitySourceFallback
+	}
+}
+
+// IngredientStore defines the interface for canonical ingredient operations
+type IngredientStore interface {
+	SuggestIngredients(query string, limit int) ([]*Ingredient, error)
+	SetIngredientPrice(ingredientID int64, pricePerUnit *float64, priceUnit *string, source string) (*IngredientPrice, error)
+	GetRecipeCost(recipeID int64) (*RecipeCost, error)
+
+	GetIngredientDensity(name string) (*IngredientDensity, error)
+	GetDensityOverrides() ([]*IngredientDensity, error)
+	SetDensityOverride(ingredientID int64, density float64) (*IngredientDensity, error)
+	DeleteDensityOverride(ingredientID int64) error
+}
+
+// PostgresIngredientStore implements the IngredientStore interface using PostgreSQL
+type PostgresIngredientStore struct {
+	db *sql.DB
+}
+
+// NewPostgresIngredientStore creates a new PostgresIngredientStore
+func NewPostgresIngredientStore(db *sql.DB) *PostgresIngredientStore {
+	return &PostgresIngredientStore{
+		db: db,
+	}
+}
+
+// escapeLikePattern escapes LIKE wildcards so user input is matched literally
+func escapeLikePattern(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(s)
+}
+
+// SuggestIngredients returns catalog ingredients matching the query, ranked by how many recipes use them
+// Prefix matches are ranked ahead of matches elsewhere in the name
+func (s *PostgresIngredientStore) SuggestIngredients(query string, limit int) ([]*Ingredient, error) {
+	sqlQuery := `
+		SELECT i.id, i.name, COUNT(ri.id) AS usage_count
+		FROM ingredients i
+		LEFT JOIN recipe_ingredients ri ON ri.ingredient_id = i.id
+		WHERE i.name LIKE '%' || $1 || '%'
+		GROUP BY i.id, i.name
+		ORDER BY (i.name LIKE $1 || '%') DESC, usage_count DESC, i.name
+		LIMIT $2
+	`
+
+	pattern := escapeLikePattern(strings.ToLower(strings.TrimSpace(query)))
+
+	rows, err := s.db.Query(sqlQuery, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest ingredients: %w", err)
+	}
+	defer rows.Close()
+
+	ingredients := []*Ingredient{}
+	for rows.Next() {
+		ingredient := &Ingredient{}
+		err := rows.Scan(&ingredient.ID, &ingredient.Name, &ingredient.UsageCount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ingredient: %w", err)
+		}
+		ingredients = append(ingredients, ingredient)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over ingredients: %w", err)
+	}
+
+	return ingredients, nil
+}
+
+// SetIngredientPrice sets or clears the price of a catalog ingredient
+// Returns nil if the ingredient does not exist
+func (s *PostgresIngredientStore) SetIngredientPrice(ingredientID int64, pricePerUnit *float64, priceUnit *string, source string) (*IngredientPrice, error) {
+	query := `
+		UPDATE ingredients
+		SET
+			price_per_unit = $1,
+			price_unit = LOWER(TRIM($2)),
+			price_source = CASE WHEN $1::NUMERIC IS NULL THEN NULL ELSE $3 END,
+			price_updated_at = NOW()
+		WHERE id = $4
+		RETURNING id, name, price_per_unit::FLOAT8, price_unit, price_source, price_updated_at
+	`
+
+	price := &IngredientPrice{}
+	err := s.db.QueryRow(query, pricePerUnit, priceUnit, source, ingredientID).Scan(
+		&price.IngredientID,
+		&price.Name,
+		&price.PricePerUnit,
+		&price.PriceUnit,
+		&price.PriceSource,
+		&price.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to set ingredient price: %w", mapError(err))
+	}
+
+	return price, nil
+}
+
+// GetRecipeCost estimates the total and per-serving cost of a recipe from ingredient prices
+func (s *PostgresIngredientStore) GetRecipeCost(recipeID int64) (*RecipeCost, error) {
+	query := `
+		SELECT
+			(SUM(ri.quantity * i.price_per_unit) FILTER (WHERE ` + pricedIngredientCondition + `))::FLOAT8 AS total_cost,
+			COUNT(*) FILTER (WHERE ` + pricedIngredientCondition + `) AS priced_ingredients,
+			COUNT(*) AS total_ingredients,
+			(SELECT serving_size FROM recipes WHERE id = $1) AS serving_size
+		FROM recipe_ingredients ri
+		LEFT JOIN ingredients i ON i.id = ri.ingredient_id
+		WHERE ri.recipe_id = $1
+	`
+
+	cost := &RecipeCost{}
+	var servingSize *int
+	err := s.db.QueryRow(query, recipeID).Scan(
+		&cost.TotalCost,
+		&cost.PricedIngredients,
+		&cost.TotalIngredients,
+		&servingSize,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipe cost: %w", err)
+	}
+
+	if cost.TotalCost != nil && servingSize != nil && *servingSize > 0 {
+		perServing := *cost.TotalCost / float64(*servingSize)
+		cost.CostPerServing = &perServing
+	}
+
+	return cost, nil
+}
+
+// ingredientDensityQuery selects IngredientDensity columns for catalog ingredients (i); callers append conditions
+const ingredientDensityQuery = `
+	SELECT i.id, i.name, i.density_g_per_ml::FLOAT8, o.density_g_per_ml::FLOAT8, o.updated_at
+	FROM ingredients i
+	LEFT JOIN ingredient_density_overrides o ON o.ingredient_id = i.id
+`
+
+func scanIngredientDensity(row rowScanner) (*IngredientDensity, error) {
+	density := &IngredientDensity{}
+	err := row.Scan(
+		&density.IngredientID,
+		&density.Name,
+		&density.CatalogDensity,
+		&density.OverrideDensity,
+		&density.OverrideUpdatedAt,
+	)
+	return density, err
+}
+
+// GetIngredientDensity returns the densities of the catalog ingredient with the given name, matched like recipe
+// ingredients are (case-insensitively, ignoring surrounding spaces), or nil if the catalog has no such ingredient
+func (s *PostgresIngredientStore) GetIngredientDensity(name string) (*IngredientDensity, error) {
+	density, err := scanIngredientDensity(s.db.QueryRow(ingredientDensityQuery+`WHERE i.name = LOWER(TRIM($1))`, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ingredient density: %w", err)
+	}
+
+	return density, nil
+}
+
+// GetDensityOverrides returns every ingredient with a density override, by name
+func (s *PostgresIngredientStore) GetDensityOverrides() ([]*IngredientDensity, error) {
+	rows, err := s.db.Query(ingredientDensityQuery + `WHERE o.ingredient_id IS NOT NULL ORDER BY i.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get density overrides: %w", err)
+	}
+	defer rows.Close()
+
+	densities := []*IngredientDensity{}
+	for rows.Next() {
+		density, err := scanIngredientDensity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan density override: %w", err)
+		}
+		densities = append(densities, density)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over density overrides: %w", err)
+	}
+
+	return densities, nil
+}
+
+// SetDensityOverride sets the density used for an ingredient in place of its catalog density
+// Returns nil if the ingredient does not exist
+func (s *PostgresIngredientStore) SetDensityOverride(ingredientID int64, density float64) (*IngredientDensity, error) {
+	query := `
+		INSERT INTO ingredient_density_overrides (ingredient_id, density_g_per_ml)
+		SELECT id, $2 FROM ingredients WHERE id = $1
+		ON CONFLICT (ingredient_id) DO UPDATE SET density_g_per_ml = EXCLUDED.density_g_per_ml, updated_at = NOW()
+	`
+
+	result, err := s.db.Exec(query, ingredientID, density)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set density override: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, nil
+	}
+
+	updated, err := scanIngredientDensity(s.db.QueryRow(ingredientDensityQuery+`WHERE i.id = $1`, ingredientID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredient density: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteDensityOverride removes an ingredient's density override, so its catalog density is used again
+// Returns ErrNotFound if the ingredient has no override
+func (s *PostgresIngredientStore) DeleteDensityOverride(ingredientID int64) error {
+	result, err := s.db.Exec(`DELETE FROM ingredient_density_overrides WHERE ingredient_id = $1`, ingredientID)
+	if err != nil {
+		return fmt.Errorf("failed to delete density override: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// InvitationStatusSent marks an invitation that has been emailed but not used
+	InvitationStatusSent = "sent"
+
+	// InvitationStatusAccepted marks an invitation used to create an account
+	InvitationStatusAccepted = "accepted"
+)
+
+// Invitation is an email invitation to join ChefShare
+type Invitation struct {
+	ID             int64      `json:"id"`
+	InviterID      int64      `json:"inviter_id"`
+	Email          string     `json:"email"`
+	Token          string     `json:"-"`
+	Status         string     `json:"status"`
+	AcceptedUserID *int64     `json:"accepted_user_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+}
+
+// InvitationStore defines the interface for invitation operations
+type InvitationStore interface {
+	CreateInvitation(inviterID int64, email string, expiryDuration time.Duration) (*Invitation, error)
+	GetInvitationsByInviter(inviterID int64) ([]*Invitation, error)
+	GetInvitationByToken(token string) (*Invitation, error)
+	AcceptInvitationWithTransaction(invitationID int64, userID string, tx *sql.Tx) error
+	CountInvitationsSince(inviterID int64, since time.Time) (int, error)
+	DeleteInvitation(id int64) error
+}
+
+// PostgresInvitationStore implements the InvitationStore interface using PostgreSQL
+type PostgresInvitationStore struct {
+	db *sql.DB
+}
+
+// NewPostgresInvitationStore creates a new PostgresInvitationStore
+func NewPostgresInvitationStore(db *sql.DB) *PostgresInvitationStore {
+	return &PostgresInvitationStore{
+		db: db,
+	}
+}
+
+const invitationColumns = `id, inviter_id, email, token, status, accepted_user_id, created_at, expires_at, accepted_at`
+
+func scanInvitation(row rowScanner, invitation *Invitation) error {
+	return row.Scan(
+		&invitation.ID,
+		&invitation.InviterID,
+		&invitation.Email,
+		&invitation.Token,
+		&invitation.Status,
+		&invitation.AcceptedUserID,
+		&invitation.CreatedAt,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedAt,
+	)
+}
+
+// CreateInvitation records a new invitation with a fresh signup token
+func (s *PostgresInvitationStore) CreateInvitation(inviterID int64, email string, expiryDuration time.Duration) (*Invitation, error) {
+	token, err := generateVerificationToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	query := `
+		INSERT INTO invitations (inviter_id, email, token, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + invitationColumns
+
+	invitation := &Invitation{}
+	err = scanInvitation(s.db.QueryRow(query, inviterID, strings.ToLower(email), token, time.Now().Add(expiryDuration)), invitation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", mapError(err))
+	}
+
+	return invitation, nil
+}
+
+// GetInvitationsByInviter returns the invitations a user has sent, newest first
+func (s *PostgresInvitationStore) GetInvitationsByInviter(inviterID int64) ([]*Invitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM invitations
+		WHERE inviter_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := s.db.Query(query, inviterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []*Invitation{}
+	for rows.Next() {
+		invitation := &Invitation{}
+		if err := scanInvitation(rows, invitation); err != nil {
+			return nil, fmt.Errorf("failed to scan invitation: %w", err)
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// GetInvitationByToken returns the invitation with the given signup token
+// Returns nil if no invitation matches
+func (s *PostgresInvitationStore) GetInvitationByToken(token string) (*Invitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM invitations
+		WHERE token = $1
+	`
+
+	invitation := &Invitation{}
+	err := scanInvitation(s.db.QueryRow(query, token), invitation)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// AcceptInvitationWithTransaction marks an invitation as accepted by a new user and attributes the user to the inviter
+// Returns ErrNotFound if the invitation has already been accepted or has expired
+func (s *PostgresInvitationStore) AcceptInvitationWithTransaction(invitationID int64, userID string, tx *sql.Tx) error {
+	query := `
+		UPDATE invitations
+		SET
+			status = $1,
+			accepted_user_id = (SELECT id FROM users WHERE user_id = $2),
+			accepted_at = NOW()
+		WHERE id = $3 AND status = $4 AND expires_at > NOW()
+		RETURNING inviter_id
+	`
+
+	var inviterID int64
+	err := tx.QueryRow(query, InvitationStatusAccepted, userID, invitationID, InvitationStatusSent).Scan(&inviterID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to accept invitation: %w", mapError(err))
+	}
+
+	_, err = tx.Exec(`UPDATE users SET invited_by = $1 WHERE user_id = $2`, inviterID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to attribute user to inviter: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// CountInvitationsSince returns how many invitations a user has sent after the given time
+func (s *PostgresInvitationStore) CountInvitationsSince(inviterID int64, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM invitations
+		WHERE inviter_id = $1 AND created_at > $2
+	`
+
+	var count int
+	if err := s.db.QueryRow(query, inviterID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count invitations: %w", err)
+	}
+
+	return count, nil
+}
+
+// DeleteInvitation removes an invitation, e.g. when its email could not be sent
+func (s *PostgresInvitationStore) DeleteInvitation(id int64) error {
+	_, err := s.db.Exec(`DELETE FROM invitations WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", mapError(err))
+	}
+
+	return nil
+}
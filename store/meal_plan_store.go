@@ -0,0 +1,329 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Meals a recipe can be planned for
+const (
+	MealBreakfast = "breakfast"
+	MealLunch     = "lunch"
+	MealDinner    = "dinner"
+	MealSnack     = "snack"
+)
+
+// Meals lists the meals an entry can be planned for, in the order they are eaten
+var Meals = []string{MealBreakfast, MealSnack, MealLunch, MealDinner}
+
+// MealPlan is a user's plan of recipes to cook on given days
+type MealPlan struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Name      string    `json:"name"`
+	ICalToken *string   `json:"ical_token,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MealPlanEntry is one planned meal, with the details of its recipe needed to show and schedule it
+type MealPlanEntry struct {
+	ID          int64     `json:"id"`
+	PlanID      int64     `json:"plan_id"`
+	RecipeID    int64     `json:"recipe_id"`
+	PlannedFor  string    `json:"planned_for" example:"2026-10-15"`
+	Meal        string    `json:"meal"`
+	Servings    *int      `json:"servings,omitempty"`
+	Note        *string   `json:"note,omitempty"`
+	RecipeTitle string    `json:"recipe_title"`
+	PrepTime    *int      `json:"prep_time,omitempty"`
+	CookTime    *int      `json:"cook_time,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// MealPlanStore defines the interface for meal plan operations
+type MealPlanStore interface {
+	CreateMealPlan(plan *MealPlan) error
+	GetMealPlansForUser(userID int64) ([]*MealPlan, error)
+	GetMealPlan(planID int64) (*MealPlan, error)
+	GetMealPlanByICalToken(token string) (*MealPlan, error)
+	DeleteMealPlan(planID int64) error
+
+	AddMealPlanEntry(entry *MealPlanEntry) error
+	GetMealPlanEntries(planID int64, from, to string) ([]*MealPlanEntry, error)
+	DeleteMealPlanEntry(planID int64, entryID int64) error
+
+	CreateICalToken(planID int64) (string, error)
+	RevokeICalToken(planID int64) error
+}
+
+// PostgresMealPlanStore implements the MealPlanStore interface using PostgreSQL
+type PostgresMealPlanStore struct {
+	db *sql.DB
+}
+
+// NewPostgresMealPlanStore creates a new PostgresMealPlanStore
+func NewPostgresMealPlanStore(db *sql.DB) *PostgresMealPlanStore {
+	return &PostgresMealPlanStore{
+		db: db,
+	}
+}
+
+const mealPlanColumns = `id, user_id, name, ical_token, created_at, updated_at`
+
+func scanMealPlan(row rowScanner) (*MealPlan, error) {
+	plan := &MealPlan{}
+	err := row.Scan(&plan.ID, &plan.UserID, &plan.Name, &plan.ICalToken, &plan.CreatedAt, &plan.UpdatedAt)
+	return plan, err
+}
+
+// CreateMealPlan creates a new meal plan owned by plan.UserID
+func (s *PostgresMealPlanStore) CreateMealPlan(plan *MealPlan) error {
+	query := `
+		INSERT INTO meal_plans (user_id, name)
+		VALUES ($1, $2)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := s.db.QueryRow(query, plan.UserID, plan.Name).Scan(&plan.ID, &plan.CreatedAt, &plan.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create meal plan: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// GetMealPlansForUser returns a user's meal plans, most recently updated first
+func (s *PostgresMealPlanStore) GetMealPlansForUser(userID int64) ([]*MealPlan, error) {
+	query := `
+		SELECT ` + mealPlanColumns + `
+		FROM meal_plans
+		WHERE user_id = $1
+		ORDER BY updated_at DESC
+	`
+
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get meal plans: %w", err)
+	}
+	defer rows.Close()
+
+	plans := []*MealPlan{}
+	for rows.Next() {
+		plan, err := scanMealPlan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan meal plan: %w", err)
+		}
+		plans = append(plans, plan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over meal plans: %w", err)
+	}
+
+	return plans, nil
+}
+
+// GetMealPlan returns a meal plan by ID, or nil if it does not exist
+func (s *PostgresMealPlanStore) GetMealPlan(planID int64) (*MealPlan, error) {
+	query := `
+		SELECT ` + mealPlanColumns + `
+		FROM meal_plans
+		WHERE id = $1
+	`
+
+	plan, err := scanMealPlan(s.db.QueryRow(query, planID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get meal plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// GetMealPlanByICalToken returns the meal plan with the given calendar token, or nil if no plan has it
+func (s *PostgresMealPlanStore) GetMealPlanByICalToken(token string) (*MealPlan, error) {
+	query := `
+		SELECT ` + mealPlanColumns + `
+		FROM meal_plans
+		WHERE ical_token = $1
+	`
+
+	plan, err := scanMealPlan(s.db.QueryRow(query, token))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get meal plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// DeleteMealPlan deletes a meal plan along with its entries
+func (s *PostgresMealPlanStore) DeleteMealPlan(planID int64) error {
+	result, err := s.db.Exec(`DELETE FROM meal_plans WHERE id = $1`, planID)
+	if err != nil {
+		return fmt.Errorf("failed to delete meal plan: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// touchMealPlan bumps a plan's updated_at so calendar feeds can report when it last changed
+func (s *PostgresMealPlanStore) touchMealPlan(planID int64) error {
+	_, err := s.db.Exec(`UPDATE meal_plans SET updated_at = NOW() WHERE id = $1`, planID)
+	if err != nil {
+		return fmt.Errorf("failed to update meal plan timestamp: %w", mapError(err))
+	}
+	return nil
+}
+
+// AddMealPlanEntry plans a recipe for a meal, filling in the recipe's title and times
+func (s *PostgresMealPlanStore) AddMealPlanEntry(entry *MealPlanEntry) error {
+	query := `
+		WITH inserted AS (
+			INSERT INTO meal_plan_entries (plan_id, recipe_id, planned_for, meal, servings, note)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, recipe_id, planned_for, created_at
+		)
+		SELECT inserted.id, TO_CHAR(inserted.planned_for, 'YYYY-MM-DD'), r.title, r.prep_time, r.cook_time, inserted.created_at
+		FROM inserted
+		JOIN recipes r ON r.id = inserted.recipe_id
+	`
+
+	err := s.db.QueryRow(
+		query,
+		entry.PlanID,
+		entry.RecipeID,
+		entry.PlannedFor,
+		entry.Meal,
+		entry.Servings,
+		entry.Note,
+	).Scan(&entry.ID, &entry.PlannedFor, &entry.RecipeTitle, &entry.PrepTime, &entry.CookTime, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add meal plan entry: %w", mapError(err))
+	}
+
+	return s.touchMealPlan(entry.PlanID)
+}
+
+// GetMealPlanEntries returns a plan's entries between from and to inclusive (YYYY-MM-DD; empty for no bound),
+// in date and meal order
+// Entries whose recipe the plan's owner can no longer see, such as another author's recipe taken back to draft, are left out
+func (s *PostgresMealPlanStore) GetMealPlanEntries(planID int64, from, to string) ([]*MealPlanEntry, error) {
+	query := `
+		SELECT e.id, e.plan_id, e.recipe_id, TO_CHAR(e.planned_for, 'YYYY-MM-DD'), e.meal, e.servings, e.note,
+			r.title, r.prep_time, r.cook_time, e.created_at
+		FROM meal_plan_entries e
+		JOIN meal_plans p ON p.id = e.plan_id
+		JOIN recipes r ON r.id = e.recipe_id
+		WHERE e.plan_id = $1
+			AND (r.status = 'published' OR r.user_id = p.user_id)
+			AND ($2 = '' OR e.planned_for >= $2::DATE)
+			AND ($3 = '' OR e.planned_for <= $3::DATE)
+		ORDER BY e.planned_for,
+			CASE e.meal WHEN 'breakfast' THEN 1 WHEN 'snack' THEN 2 WHEN 'lunch' THEN 3 ELSE 4 END,
+			e.id
+	`
+
+	rows, err := s.db.Query(query, planID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get meal plan entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*MealPlanEntry{}
+	for rows.Next() {
+		entry := &MealPlanEntry{}
+		err := rows.Scan(
+			&entry.ID,
+			&entry.PlanID,
+			&entry.RecipeID,
+			&entry.PlannedFor,
+			&entry.Meal,
+			&entry.Servings,
+			&entry.Note,
+			&entry.RecipeTitle,
+			&entry.PrepTime,
+			&entry.CookTime,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan meal plan entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over meal plan entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// DeleteMealPlanEntry removes a planned meal from a plan
+func (s *PostgresMealPlanStore) DeleteMealPlanEntry(planID int64, entryID int64) error {
+	result, err := s.db.Exec(`DELETE FROM meal_plan_entries WHERE id = $1 AND plan_id = $2`, entryID, planID)
+	if err != nil {
+		return fmt.Errorf("failed to delete meal plan entry: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return s.touchMealPlan(planID)
+}
+
+// CreateICalToken generates a new calendar feed token for a plan, replacing any existing one
+// Calendars subscribed with the old token stop updating
+func (s *PostgresMealPlanStore) CreateICalToken(planID int64) (string, error) {
+	token, err := generateVerificationToken()
+	if err != nil {
+		return "", err
+	}
+
+	result, err := s.db.Exec(`UPDATE meal_plans SET ical_token = $1 WHERE id = $2`, token, planID)
+	if err != nil {
+		return "", fmt.Errorf("failed to create calendar token: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return "", fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return "", ErrNotFound
+	}
+
+	return token, nil
+}
+
+// RevokeICalToken disables a plan's calendar feed
+func (s *PostgresMealPlanStore) RevokeICalToken(planID int64) error {
+	_, err := s.db.Exec(`UPDATE meal_plans SET ical_token = NULL WHERE id = $1`, planID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke calendar token: %w", mapError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// MentionedUser is a user referenced with @username, with the details needed to notify them
+type MentionedUser struct {
+	ID        int64  `json:"-"`
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	Email     string `json:"-"`
+	FirstName string `json:"-"`
+}
+
+// MentionStore defines the interface for @mention operations
+type MentionStore interface {
+	GetUsersByUsernames(usernames []string) ([]*MentionedUser, error)
+	SaveReviewMentions(reviewID int64, userIDs []int64) ([]int64, error)
+}
+
+// PostgresMentionStore implements the MentionStore interface using PostgreSQL
+type PostgresMentionStore struct {
+	db *sql.DB
+}
+
+// NewPostgresMentionStore creates a new PostgresMentionStore
+func NewPostgresMentionStore(db *sql.DB) *PostgresMentionStore {
+	return &PostgresMentionStore{
+		db: db,
+	}
+}
+
+// GetUsersByUsernames returns the users with the given usernames, matched case-insensitively
+// Usernames that do not belong to anyone are left out
+func (s *PostgresMentionStore) GetUsersByUsernames(usernames []string) ([]*MentionedUser, error) {
+	if len(usernames) == 0 {
+		return []*MentionedUser{}, nil
+	}
+
+	placeholders := make([]string, len(usernames))
+	args := make([]interface{}, len(usernames))
+	for i, username := range usernames {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = strings.ToLower(username)
+	}
+
+	query := `
+		SELECT id, user_id, username, email, COALESCE(first_name, '')
+		FROM users
+		WHERE LOWER(username) IN (` + strings.Join(placeholders, ", ") + `)
+	`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users by username: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*MentionedUser{}
+	for rows.Next() {
+		user := &MentionedUser{}
+		err := rows.Scan(&user.ID, &user.UserID, &user.Username, &user.Email, &user.FirstName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan mentioned user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over mentioned users: %w", err)
+	}
+
+	return users, nil
+}
+
+// SaveReviewMentions records the users mentioned in a review
+// It returns the IDs of users who were not already recorded, so they are only notified once
+func (s *PostgresMentionStore) SaveReviewMentions(reviewID int64, userIDs []int64) ([]int64, error) {
+	if len(userIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	placeholders := make([]string, len(userIDs))
+	args := []interface{}{reviewID}
+	for i, userID := range userIDs {
+		placeholders[i] = fmt.Sprintf("($1, $%d)", i+2)
+		args = append(args, userID)
+	}
+
+	query := `
+		INSERT INTO review_mentions (review_id, mentioned_user_id)
+		VALUES ` + strings.Join(placeholders, ", ") + `
+		ON CONFLICT (review_id, mentioned_user_id) DO NOTHING
+		RETURNING mentioned_user_id
+	`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save review mentions: %w", mapError(err))
+	}
+	defer rows.Close()
+
+	recorded := []int64{}
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan review mention: %w", err)
+		}
+		recorded = append(recorded, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over review mentions: %w", err)
+	}
+
+	return recorded, nil
+}
@@ -0,0 +1,309 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ConversationUser is the other participant shown in a conversation
+type ConversationUser struct {
+	UserID         string  `json:"user_id"`
+	Username       string  `json:"username"`
+	ProfilePicture *string `json:"profile_picture,omitempty"`
+}
+
+// Conversation is a one-to-one conversation as seen by one of its participants
+type Conversation struct {
+	ID            int64             `json:"id"`
+	OtherUserID   int64             `json:"-"`
+	OtherUser     *ConversationUser `json:"other_user"`
+	LastMessage   *string           `json:"last_message,omitempty"`
+	LastMessageAt *time.Time        `json:"last_message_at,omitempty"`
+	UnreadCount   int               `json:"unread_count"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// Message is a single direct message
+type Message struct {
+	ID             int64     `json:"id"`
+	ConversationID int64     `json:"conversation_id"`
+	SenderID       int64     `json:"-"`
+	SenderUsername string    `json:"sender_username"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// MessageStore defines the interface for direct message operations
+type MessageStore interface {
+	GetOrCreateConversation(userID int64, otherID int64) (int64, bool, error)
+	GetConversation(conversationID int64, userID int64) (*Conversation, error)
+	GetConversations(userID int64, page, limit int) ([]*Conversation, int, error)
+	CountUnreadMessages(userID int64) (int, error)
+
+	CreateMessage(conversationID int64, senderID int64, body string) (*Message, error)
+	GetMessages(conversationID int64, page, limit int) ([]*Message, int, error)
+	MarkConversationRead(conversationID int64, userID int64) error
+}
+
+// PostgresMessageStore implements the MessageStore interface using PostgreSQL
+type PostgresMessageStore struct {
+	db *sql.DB
+}
+
+// NewPostgresMessageStore creates a new PostgresMessageStore
+func NewPostgresMessageStore(db *sql.DB) *PostgresMessageStore {
+	return &PostgresMessageStore{
+		db: db,
+	}
+}
+
+// unreadMessagesCondition matches messages (m) in a conversation the participant (cp) has not read
+const unreadMessagesCondition = `m.conversation_id = cp.conversation_id AND m.sender_id <> cp.user_id AND (cp.last_read_at IS NULL OR m.created_at > cp.last_read_at)`
+
+// conversationColumns selects a conversation (c) from the point of view of a participant (cp)
+const conversationColumns = `
+	c.id, u.id, u.user_id, u.username, u.profile_picture,
+	(SELECT m.body FROM messages m WHERE m.conversation_id = c.id ORDER BY m.created_at DESC, m.id DESC LIMIT 1),
+	c.last_message_at,
+	(SELECT COUNT(*) FROM messages m WHERE ` + unreadMessagesCondition + `),
+	c.created_at`
+
+// conversationFrom joins each of a participant's conversations with the other participant (u)
+const conversationFrom = `
+	FROM conversation_participants cp
+	JOIN conversations c ON c.id = cp.conversation_id
+	JOIN users u ON u.id = CASE WHEN c.user_low_id = cp.user_id THEN c.user_high_id ELSE c.user_low_id END`
+
+func scanConversation(row rowScanner, extra ...interface{}) (*Conversation, error) {
+	conversation := &Conversation{OtherUser: &ConversationUser{}}
+	dest := []interface{}{
+		&conversation.ID,
+		&conversation.OtherUserID,
+		&conversation.OtherUser.UserID,
+		&conversation.OtherUser.Username,
+		&conversation.OtherUser.ProfilePicture,
+		&conversation.LastMessage,
+		&conversation.LastMessageAt,
+		&conversation.UnreadCount,
+		&conversation.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+// GetOrCreateConversation returns the conversation between two users, creating it if needed
+// The boolean is true if a new conversation was created
+func (s *PostgresMessageStore) GetOrCreateConversation(userID int64, otherID int64) (int64, bool, error) {
+	low, high := userID, otherID
+	if low > high {
+		low, high = high, low
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int64
+	created := true
+	err = tx.QueryRow(`
+		INSERT INTO conversations (user_low_id, user_high_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_low_id, user_high_id) DO NOTHING
+		RETURNING id
+	`, low, high).Scan(&id)
+	if err == sql.ErrNoRows {
+		created = false
+		err = tx.QueryRow(`SELECT id FROM conversations WHERE user_low_id = $1 AND user_high_id = $2`, low, high).Scan(&id)
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get or create conversation: %w", err)
+	}
+
+	if created {
+		_, err = tx.Exec(`
+			INSERT INTO conversation_participants (conversation_id, user_id)
+			VALUES ($1, $2), ($1, $3)
+		`, id, low, high)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to add conversation participants: %w", mapError(err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, false, fmt.Errorf("failed to commit transaction: %w", mapError(err))
+	}
+
+	return id, created, nil
+}
+
+// GetConversation returns a conversation as seen by one of its participants
+// Returns nil if the conversation does not exist or the user is not a participant
+func (s *PostgresMessageStore) GetConversation(conversationID int64, userID int64) (*Conversation, error) {
+	query := `SELECT ` + conversationColumns + conversationFrom + `
+		WHERE cp.conversation_id = $1 AND cp.user_id = $2
+	`
+
+	conversation, err := scanConversation(s.db.QueryRow(query, conversationID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// GetConversations returns a page of a user's conversations, most recently active first, and the total count
+func (s *PostgresMessageStore) GetConversations(userID int64, page, limit int) ([]*Conversation, int, error) {
+	query := `SELECT ` + conversationColumns + `, COUNT(*) OVER() AS total_count` + conversationFrom + `
+		WHERE cp.user_id = $1
+		ORDER BY COALESCE(c.last_message_at, c.created_at) DESC, c.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.Query(query, userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get conversations: %w", err)
+	}
+	defer rows.Close()
+
+	conversations := []*Conversation{}
+	total := 0
+	for rows.Next() {
+		conversation, err := scanConversation(rows, &total)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan conversation: %w", err)
+		}
+		conversations = append(conversations, conversation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over conversations: %w", err)
+	}
+
+	return conversations, total, nil
+}
+
+// CountUnreadMessages returns how many messages sent to a user across all conversations are unread
+func (s *PostgresMessageStore) CountUnreadMessages(userID int64) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM conversation_participants cp
+		JOIN messages m ON ` + unreadMessagesCondition + `
+		WHERE cp.user_id = $1
+	`
+
+	var count int
+	if err := s.db.QueryRow(query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateMessage adds a message to a conversation
+// The sender's own message counts as read by them
+func (s *PostgresMessageStore) CreateMessage(conversationID int64, senderID int64, body string) (*Message, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	message := &Message{ConversationID: conversationID, SenderID: senderID, Body: body}
+	err = tx.QueryRow(`
+		INSERT INTO messages (conversation_id, sender_id, body)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, (SELECT username FROM users WHERE id = $2)
+	`, conversationID, senderID, body).Scan(&message.ID, &message.CreatedAt, &message.SenderUsername)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create message: %w", mapError(err))
+	}
+
+	_, err = tx.Exec(`UPDATE conversations SET last_message_at = $1 WHERE id = $2`, message.CreatedAt, conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update conversation: %w", mapError(err))
+	}
+
+	_, err = tx.Exec(`
+		UPDATE conversation_participants SET last_read_at = $1
+		WHERE conversation_id = $2 AND user_id = $3
+	`, message.CreatedAt, conversationID, senderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update read position: %w", mapError(err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", mapError(err))
+	}
+
+	return message, nil
+}
+
+// GetMessages returns a page of a conversation's messages, newest first, and the total count
+func (s *PostgresMessageStore) GetMessages(conversationID int64, page, limit int) ([]*Message, int, error) {
+	query := `
+		SELECT m.id, m.conversation_id, m.sender_id, u.username, m.body, m.created_at,
+			COUNT(*) OVER() AS total_count
+		FROM messages m
+		JOIN users u ON u.id = m.sender_id
+		WHERE m.conversation_id = $1
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.Query(query, conversationID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []*Message{}
+	total := 0
+	for rows.Next() {
+		message := &Message{}
+		err := rows.Scan(
+			&message.ID,
+			&message.ConversationID,
+			&message.SenderID,
+			&message.SenderUsername,
+			&message.Body,
+			&message.CreatedAt,
+			&total,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over messages: %w", err)
+	}
+
+	return messages, total, nil
+}
+
+// MarkConversationRead marks every message in a conversation as read by the participant
+func (s *PostgresMessageStore) MarkConversationRead(conversationID int64, userID int64) error {
+	query := `
+		UPDATE conversation_participants cp
+		SET last_read_at = latest.created_at
+		FROM (SELECT MAX(created_at) AS created_at FROM messages WHERE conversation_id = $1) latest
+		WHERE cp.conversation_id = $1 AND cp.user_id = $2
+			AND latest.created_at IS NOT NULL
+			AND (cp.last_read_at IS NULL OR cp.last_read_at < latest.created_at)
+	`
+
+	if _, err := s.db.Exec(query, conversationID, userID); err != nil {
+		return fmt.Errorf("failed to mark conversation read: %w", mapError(err))
+	}
+
+	return nil
+}
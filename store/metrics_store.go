@@ -0,0 +1,131 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// DailyMetrics is site-wide activity for one UTC day
+type DailyMetrics struct {
+	Day              string    `json:"day"`
+	Signups          int       `json:"signups"`
+	RecipesCreated   int       `json:"recipes_created"`
+	RecipesPublished int       `json:"recipes_published"`
+	Reviews          int       `json:"reviews"`
+	EmailsSent       int       `json:"emails_sent"`
+	ComputedAt       time.Time `json:"computed_at"`
+}
+
+// MetricsStore defines the interface for the daily metrics rollup
+type MetricsStore interface {
+	RecordEmailSend() error
+	RollupDailyMetrics(from, to time.Time) error
+	GetLastRolledUpDay() (*time.Time, error)
+	GetDailyMetrics(from, to time.Time) ([]*DailyMetrics, error)
+}
+
+// PostgresMetricsStore implements the MetricsStore interface using PostgreSQL
+type PostgresMetricsStore struct {
+	db *sql.DB
+}
+
+// NewPostgresMetricsStore creates a new PostgresMetricsStore
+func NewPostgresMetricsStore(db *sql.DB) *PostgresMetricsStore {
+	return &PostgresMetricsStore{
+		db: db,
+	}
+}
+
+// RecordEmailSend logs that an email was handed to the email provider
+func (s *PostgresMetricsStore) RecordEmailSend() error {
+	if _, err := s.db.Exec(`INSERT INTO email_sends DEFAULT VALUES`); err != nil {
+		return fmt.Errorf("failed to record email send: %w", mapError(err))
+	}
+	return nil
+}
+
+// RollupDailyMetrics counts activity for every UTC day from from to to, inclusive, replacing existing rows
+// Rows removed since, such as deleted users or recipes, drop out of a day's counts when it is rolled up again,
+// so callers should only recompute recent days
+func (s *PostgresMetricsStore) RollupDailyMetrics(from, to time.Time) error {
+	query := `
+		INSERT INTO metrics_daily (day, signups, recipes_created, recipes_published, reviews, emails_sent, computed_at)
+		SELECT
+			(d.start AT TIME ZONE 'UTC')::DATE,
+			(SELECT COUNT(*) FROM users u WHERE u.created_at >= d.start AND u.created_at < d.start + INTERVAL '24 hours'),
+			(SELECT COUNT(*) FROM recipes r WHERE r.created_at >= d.start AND r.created_at < d.start + INTERVAL '24 hours'),
+			(SELECT COUNT(*) FROM recipes r WHERE r.published_at >= d.start AND r.published_at < d.start + INTERVAL '24 hours'),
+			(SELECT COUNT(*) FROM reviews rv WHERE rv.created_at >= d.start AND rv.created_at < d.start + INTERVAL '24 hours'),
+			(SELECT COUNT(*) FROM email_sends e WHERE e.sent_at >= d.start AND e.sent_at < d.start + INTERVAL '24 hours'),
+			NOW()
+		FROM generate_series($1::TIMESTAMPTZ, $2::TIMESTAMPTZ, INTERVAL '24 hours') AS d(start)
+		ON CONFLICT (day) DO UPDATE SET
+			signups = EXCLUDED.signups,
+			recipes_created = EXCLUDED.recipes_created,
+			recipes_published = EXCLUDED.recipes_published,
+			reviews = EXCLUDED.reviews,
+			emails_sent = EXCLUDED.emails_sent,
+			computed_at = EXCLUDED.computed_at
+	`
+
+	from = from.UTC().Truncate(24 * time.Hour)
+	to = to.UTC().Truncate(24 * time.Hour)
+	if _, err := s.db.Exec(query, from, to); err != nil {
+		return fmt.Errorf("failed to roll up daily metrics: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// GetLastRolledUpDay returns the most recent day in the rollup, or nil if it is empty
+func (s *PostgresMetricsStore) GetLastRolledUpDay() (*time.Time, error) {
+	var day sql.NullString
+	if err := s.db.QueryRow(`SELECT to_char(MAX(day), 'YYYY-MM-DD') FROM metrics_daily`).Scan(&day); err != nil {
+		return nil, fmt.Errorf("failed to get last rolled up day: %w", err)
+	}
+
+	if !day.Valid {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.DateOnly, day.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse last rolled up day: %w", err)
+	}
+
+	return &parsed, nil
+}
+
+// GetDailyMetrics returns the rolled up days from from to to, inclusive, oldest first
+// Days that have not been rolled up yet are missing from the result
+func (s *PostgresMetricsStore) GetDailyMetrics(from, to time.Time) ([]*DailyMetrics, error) {
+	query := `
+		SELECT to_char(day, 'YYYY-MM-DD'), signups, recipes_created, recipes_published, reviews, emails_sent, computed_at
+		FROM metrics_daily
+		WHERE day >= $1::DATE AND day <= $2::DATE
+		ORDER BY day
+	`
+
+	rows, err := s.db.Query(query, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily metrics: %w", err)
+	}
+	defer rows.Close()
+
+	metrics := []*DailyMetrics{}
+	for rows.Next() {
+		day := &DailyMetrics{}
+		err := rows.Scan(&day.Day, &day.Signups, &day.RecipesCreated, &day.RecipesPublished, &day.Reviews, &day.EmailsSent, &day.ComputedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan daily metrics: %w", err)
+		}
+		metrics = append(metrics, day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over daily metrics: %w", err)
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,478 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// NotificationTypeMention is sent to a user mentioned with @username in a review
+	NotificationTypeMention = "mention"
+
+	// NotificationTypeChefApplication is sent when an admin approves or rejects a verified chef application
+	NotificationTypeChefApplication = "chef_application"
+
+	// NotificationTypeFavorite is sent to an author when someone favorites one of their recipes
+	NotificationTypeFavorite = "favorite"
+
+	// NotificationTypeReviewHelpful is sent to a reviewer when someone marks their review helpful
+	NotificationTypeReviewHelpful = "review_helpful"
+
+	// NotificationTypeRecipeRemoved is sent to the users who favorited a recipe when its author deletes it
+	NotificationTypeRecipeRemoved = "recipe_removed"
+)
+
+// MaxNotificationBatchWindowMinutes caps how long a user can have notifications of one type batched (one day)
+const MaxNotificationBatchWindowMinutes = 1440
+
+// NotificationPreference is how a user wants one type of notification batched and emailed
+type NotificationPreference struct {
+	Type string `json:"type"`
+	// BatchWindowMinutes is how long after an event others like it are merged into its notification; 0 never merges
+	BatchWindowMinutes int `json:"batch_window_minutes"`
+	// Email sends the notification by email, once its batch window has closed
+	Email bool `json:"email"`
+}
+
+// DefaultNotificationPreferences are the preferences of users who have not changed them, for every configurable type
+var DefaultNotificationPreferences = []NotificationPreference{
+	{Type: NotificationTypeMention, BatchWindowMinutes: 0, Email: true},
+	{Type: NotificationTypeFavorite, BatchWindowMinutes: 60, Email: true},
+	{Type: NotificationTypeReviewHelpful, BatchWindowMinutes: 60, Email: false},
+}
+
+// DefaultNotificationPreference returns the default preference for a notification type, and false if the type is not configurable
+func DefaultNotificationPreference(notificationType string) (NotificationPreference, bool) {
+	for _, pref := range DefaultNotificationPreferences {
+		if pref.Type == notificationType {
+			return pref, true
+		}
+	}
+	return NotificationPreference{}, false
+}
+
+// NotificationBatch says how a new event is grouped with earlier ones
+type NotificationBatch struct {
+	// Key identifies events that are merged, such as favorites of one recipe
+	Key string
+	// Window is how long after the first event of a batch later ones are merged into it; zero never merges
+	Window time.Duration
+	// Email includes the notification in its owner's digest email once the window has closed
+	Email bool
+}
+
+// NotificationEmail is a notification due to be emailed, with its owner's contact details
+type NotificationEmail struct {
+	NotificationID int64
+	UserID         int64
+	Email          string
+	FirstName      string
+	Username       string
+	Type           string
+	Message        string
+	RecipeID       *int64
+	EventCount     int
+}
+
+// Notification is an in-app notification for a user
+type Notification struct {
+	ID            int64      `json:"id"`
+	UserID        int64      `json:"-"`
+	Type          string     `json:"type"`
+	ActorID       *int64     `json:"-"`
+	ActorUsername *string    `json:"actor_username,omitempty"`
+	RecipeID      *int64     `json:"recipe_id,omitempty"`
+	ReviewID      *int64     `json:"review_id,omitempty"`
+	Message       string     `json:"message"`
+	EventCount    int        `json:"event_count"`
+	ReadAt        *time.Time `json:"read_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// NotificationStore defines the interface for in-app notification operations
+type NotificationStore interface {
+	CreateNotification(notification *Notification) error
+	CreateNotifications(notifications []*Notification) error
+	AddNotification(notification *Notification, batch NotificationBatch, message func(count int) string) (bool, error)
+	GetNotifications(userID int64, unreadOnly bool, limit int) ([]*Notification, error)
+	CountUnreadNotifications(userID int64) (int, error)
+	MarkNotificationRead(id int64, userID int64) error
+	MarkAllNotificationsRead(userID int64) (int64, error)
+
+	GetDueNotificationEmails(limit int, quietStartHour, quietEndHour int) ([]*NotificationEmail, error)
+	MarkNotificationsEmailed(ids []int64) error
+
+	GetNotificationPreferences(userID int64) ([]NotificationPreference, error)
+	GetNotificationPreference(userID int64, notificationType string) (NotificationPreference, error)
+	SaveNotificationPreferences(userID int64, prefs []NotificationPreference) error
+}
+
+// PostgresNotificationStore implements the NotificationStore interface using PostgreSQL
+type PostgresNotificationStore struct {
+	db *sql.DB
+}
+
+// NewPostgresNotificationStore creates a new PostgresNotificationStore
+func NewPostgresNotificationStore(db *sql.DB) *PostgresNotificationStore {
+	return &PostgresNotificationStore{
+		db: db,
+	}
+}
+
+// CreateNotification stores a new unread notification
+func (s *PostgresNotificationStore) CreateNotification(notification *Notification) error {
+	query := `
+		INSERT INTO notifications (user_id, type, actor_id, recipe_id, review_id, message)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, event_count, created_at, updated_at
+	`
+
+	err := s.db.QueryRow(
+		query,
+		notification.UserID,
+		notification.Type,
+		notification.ActorID,
+		notification.RecipeID,
+		notification.ReviewID,
+		notification.Message,
+	).Scan(&notification.ID, &notification.EventCount, &notification.CreatedAt, &notification.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// createNotificationsChunk caps how many notifications are inserted per statement, to stay within Postgres's parameter limit
+const createNotificationsChunk = 1000
+
+// CreateNotifications stores many unread notifications at once, such as one event sent to a list of users
+// IDs and timestamps are not read back
+func (s *PostgresNotificationStore) CreateNotifications(notifications []*Notification) error {
+	for start := 0; start < len(notifications); start += createNotificationsChunk {
+		chunk := notifications[start:min(start+createNotificationsChunk, len(notifications))]
+
+		rows := make([]string, len(chunk))
+		args := make([]interface{}, 0, len(chunk)*6)
+		for i, n := range chunk {
+			rows[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", i*6+1, i*6+2, i*6+3, i*6+4, i*6+5, i*6+6)
+			args = append(args, n.UserID, n.Type, n.ActorID, n.RecipeID, n.ReviewID, n.Message)
+		}
+
+		query := `
+			INSERT INTO notifications (user_id, type, actor_id, recipe_id, review_id, message)
+			VALUES ` + strings.Join(rows, ", ")
+		if _, err := s.db.Exec(query, args...); err != nil {
+			return fmt.Errorf("failed to create notifications: %w", mapError(err))
+		}
+	}
+
+	return nil
+}
+
+// AddNotification records an event, merging it into the unread notification of the same batch if its window is still open
+// message builds the notification's text from the number of events it covers. Reports whether the event was merged
+func (s *PostgresNotificationStore) AddNotification(notification *Notification, batch NotificationBatch, message func(count int) string) (bool, error) {
+	merged := false
+	err := WithTx(context.Background(), s.db, func(tx *sql.Tx) error {
+		var count int
+		err := tx.QueryRow(`
+			SELECT id, event_count
+			FROM notifications
+			WHERE user_id = $1 AND batch_key = $2 AND read_at IS NULL AND batch_closes_at > NOW()
+			ORDER BY id DESC
+			LIMIT 1
+			FOR UPDATE
+		`, notification.UserID, batch.Key).Scan(&notification.ID, &count)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to find notification batch: %w", err)
+		}
+
+		if err == nil {
+			merged = true
+			notification.EventCount = count + 1
+			notification.Message = message(notification.EventCount)
+			err = tx.QueryRow(`
+				UPDATE notifications
+				SET event_count = $2, actor_id = $3, review_id = COALESCE($4, review_id), message = $5, updated_at = NOW()
+				WHERE id = $1
+				RETURNING created_at, updated_at
+			`, notification.ID, notification.EventCount, notification.ActorID, notification.ReviewID, notification.Message).
+				Scan(&notification.CreatedAt, &notification.UpdatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to update notification: %w", mapError(err))
+			}
+			return nil
+		}
+
+		notification.Message = message(1)
+		err = tx.QueryRow(`
+			INSERT INTO notifications (user_id, type, actor_id, recipe_id, review_id, message, batch_key, batch_closes_at, email_pending)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + $8 * INTERVAL '1 second', $9)
+			RETURNING id, event_count, created_at, updated_at
+		`,
+			notification.UserID,
+			notification.Type,
+			notification.ActorID,
+			notification.RecipeID,
+			notification.ReviewID,
+			notification.Message,
+			batch.Key,
+			int64(batch.Window/time.Second),
+			batch.Email,
+		).Scan(&notification.ID, &notification.EventCount, &notification.CreatedAt, &notification.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create notification: %w", mapError(err))
+		}
+		return nil
+	})
+
+	return merged, err
+}
+
+// GetNotifications returns a user's most recent notifications, newest first
+func (s *PostgresNotificationStore) GetNotifications(userID int64, unreadOnly bool, limit int) ([]*Notification, error) {
+	query := `
+		SELECT n.id, n.user_id, n.type, n.actor_id, a.username, n.recipe_id, n.review_id, n.message, n.event_count,
+			n.read_at, n.created_at, n.updated_at
+		FROM notifications n
+		LEFT JOIN users a ON a.id = n.actor_id
+		WHERE n.user_id = $1 AND (NOT $2 OR n.read_at IS NULL)
+		ORDER BY n.updated_at DESC, n.id DESC
+		LIMIT $3
+	`
+
+	rows, err := s.db.Query(query, userID, unreadOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []*Notification{}
+	for rows.Next() {
+		notification := &Notification{}
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.ActorID,
+			&notification.ActorUsername,
+			&notification.RecipeID,
+			&notification.ReviewID,
+			&notification.Message,
+			&notification.EventCount,
+			&notification.ReadAt,
+			&notification.CreatedAt,
+			&notification.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// CountUnreadNotifications returns how many of a user's notifications are unread
+func (s *PostgresNotificationStore) CountUnreadNotifications(userID int64) (int, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkNotificationRead marks one of a user's notifications as read
+// A notification read before it is emailed is left out of the digest email
+// Returns ErrNotFound if the notification does not exist or belongs to someone else
+func (s *PostgresNotificationStore) MarkNotificationRead(id int64, userID int64) error {
+	query := `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, NOW()), email_pending = false
+		WHERE id = $1 AND user_id = $2
+	`
+
+	result, err := s.db.Exec(query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// MarkAllNotificationsRead marks all of a user's unread notifications as read and returns how many were updated
+func (s *PostgresNotificationStore) MarkAllNotificationsRead(userID int64) (int64, error) {
+	result, err := s.db.Exec(`UPDATE notifications SET read_at = NOW(), email_pending = false WHERE user_id = $1 AND read_at IS NULL`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// GetDueNotificationEmails returns unread notifications whose batch has closed and that are still to be emailed,
+// grouped by user, oldest first
+// Users with a time zone whose local hour is from quietStartHour up to quietEndHour, wrapping past midnight, are left out
+// until it is over; equal hours leave no one out.
+func (s *PostgresNotificationStore) GetDueNotificationEmails(limit int, quietStartHour, quietEndHour int) ([]*NotificationEmail, error) {
+	query := `
+		SELECT n.id, n.user_id, u.email, COALESCE(u.first_name, ''), u.username, n.type, n.message, n.recipe_id, n.event_count
+		FROM notifications n
+		JOIN users u ON u.id = n.user_id
+		CROSS JOIN LATERAL (
+			SELECT EXTRACT(HOUR FROM NOW() AT TIME ZONE u.timezone)::INT AS hour
+		) local
+		WHERE n.email_pending AND n.batch_closes_at <= NOW()
+			AND (local.hour IS NULL OR NOT CASE
+				WHEN $2::INT <= $3::INT THEN local.hour >= $2 AND local.hour < $3
+				ELSE local.hour >= $2 OR local.hour < $3
+			END)
+		ORDER BY n.user_id, n.id
+		LIMIT $1
+	`
+
+	rows, err := s.db.Query(query, limit, quietStartHour, quietEndHour)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due notification emails: %w", err)
+	}
+	defer rows.Close()
+
+	emails := []*NotificationEmail{}
+	for rows.Next() {
+		email := &NotificationEmail{}
+		err := rows.Scan(
+			&email.NotificationID,
+			&email.UserID,
+			&email.Email,
+			&email.FirstName,
+			&email.Username,
+			&email.Type,
+			&email.Message,
+			&email.RecipeID,
+			&email.EventCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification email: %w", err)
+		}
+		emails = append(emails, email)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over notification emails: %w", err)
+	}
+
+	return emails, nil
+}
+
+// MarkNotificationsEmailed records that notifications were included in a digest email
+func (s *PostgresNotificationStore) MarkNotificationsEmailed(ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	_, err := s.db.Exec(`UPDATE notifications SET email_pending = false, emailed_at = NOW() WHERE id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to mark notifications emailed: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// GetNotificationPreferences returns a user's preference for every configurable notification type,
+// using the default for types they have not changed
+func (s *PostgresNotificationStore) GetNotificationPreferences(userID int64) ([]NotificationPreference, error) {
+	rows, err := s.db.Query(`SELECT type, batch_window_minutes, email FROM notification_preferences WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	saved := map[string]NotificationPreference{}
+	for rows.Next() {
+		var pref NotificationPreference
+		if err := rows.Scan(&pref.Type, &pref.BatchWindowMinutes, &pref.Email); err != nil {
+			return nil, fmt.Errorf("failed to scan notification preference: %w", err)
+		}
+		saved[pref.Type] = pref
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over notification preferences: %w", err)
+	}
+
+	prefs := make([]NotificationPreference, len(DefaultNotificationPreferences))
+	for i, pref := range DefaultNotificationPreferences {
+		if custom, ok := saved[pref.Type]; ok {
+			pref = custom
+		}
+		prefs[i] = pref
+	}
+
+	return prefs, nil
+}
+
+// GetNotificationPreference returns a user's preference for one notification type, or the default if they have not changed it
+func (s *PostgresNotificationStore) GetNotificationPreference(userID int64, notificationType string) (NotificationPreference, error) {
+	pref, _ := DefaultNotificationPreference(notificationType)
+	pref.Type = notificationType
+
+	err := s.db.QueryRow(
+		`SELECT batch_window_minutes, email FROM notification_preferences WHERE user_id = $1 AND type = $2`,
+		userID, notificationType,
+	).Scan(&pref.BatchWindowMinutes, &pref.Email)
+	if err != nil && err != sql.ErrNoRows {
+		return pref, fmt.Errorf("failed to get notification preference: %w", err)
+	}
+
+	return pref, nil
+}
+
+// SaveNotificationPreferences stores a user's preferences for the given notification types, leaving the others unchanged
+func (s *PostgresNotificationStore) SaveNotificationPreferences(userID int64, prefs []NotificationPreference) error {
+	return WithTx(context.Background(), s.db, func(tx *sql.Tx) error {
+		for _, pref := range prefs {
+			_, err := tx.Exec(`
+				INSERT INTO notification_preferences (user_id, type, batch_window_minutes, email)
+				VALUES ($1, $2, $3, $4)
+				ON CONFLICT (user_id, type) DO UPDATE
+				SET batch_window_minutes = EXCLUDED.batch_window_minutes, email = EXCLUDED.email, updated_at = NOW()
+			`, userID, pref.Type, pref.BatchWindowMinutes, pref.Email)
+			if err != nil {
+				return fmt.Errorf("failed to save notification preference: %w", mapError(err))
+			}
+		}
+		return nil
+	})
+}
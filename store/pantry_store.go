@@ -0,0 +1,221 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PantryItem is an ingredient a user has on hand
+type PantryItem struct {
+	ID           int64     `json:"id"`
+	IngredientID int64     `json:"ingredient_id"`
+	Name         string    `json:"name"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CookableRecipe is a recipe ranked by how much of it a user's pantry covers
+type CookableRecipe struct {
+	Recipe             *Recipe  `json:"recipe"`
+	TotalIngredients   int      `json:"total_ingredients"`
+	MatchedIngredients int      `json:"matched_ingredients"`
+	Coverage           float64  `json:"coverage"`
+	MissingIngredients []string `json:"missing_ingredients"`
+}
+
+// PantryStore defines the interface for pantry operations
+type PantryStore interface {
+	AddPantryItem(userID int64, name string) (*PantryItem, error)
+	GetPantryItems(userID int64) ([]*PantryItem, error)
+	RemovePantryItem(userID int64, ingredientID int64) error
+	GetCookableRecipes(userID int64, limit int) ([]*CookableRecipe, error)
+}
+
+// PostgresPantryStore implements the PantryStore interface using PostgreSQL
+type PostgresPantryStore struct {
+	db *sql.DB
+}
+
+// NewPostgresPantryStore creates a new PostgresPantryStore
+func NewPostgresPantryStore(db *sql.DB) *PostgresPantryStore {
+	return &PostgresPantryStore{
+		db: db,
+	}
+}
+
+// AddPantryItem adds an ingredient to a user's pantry, creating the catalog entry if needed
+// Adding an ingredient that is already in the pantry returns the existing item
+func (s *PostgresPantryStore) AddPantryItem(userID int64, name string) (*PantryItem, error) {
+	query := `
+		WITH canonical AS (
+			INSERT INTO ingredients (name)
+			VALUES (LOWER(TRIM($2)))
+			ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id, name
+		), added AS (
+			INSERT INTO pantry_items (user_id, ingredient_id)
+			SELECT $1, canonical.id FROM canonical
+			ON CONFLICT (user_id, ingredient_id) DO UPDATE SET user_id = EXCLUDED.user_id
+			RETURNING id, ingredient_id, created_at
+		)
+		SELECT added.id, added.ingredient_id, canonical.name, added.created_at
+		FROM added
+		JOIN canonical ON canonical.id = added.ingredient_id
+	`
+
+	item := &PantryItem{}
+	err := s.db.QueryRow(query, userID, name).Scan(&item.ID, &item.IngredientID, &item.Name, &item.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add pantry item: %w", mapError(err))
+	}
+
+	return item, nil
+}
+
+// GetPantryItems returns all ingredients in a user's pantry ordered by name
+func (s *PostgresPantryStore) GetPantryItems(userID int64) ([]*PantryItem, error) {
+	query := `
+		SELECT p.id, p.ingredient_id, i.name, p.created_at
+		FROM pantry_items p
+		JOIN ingredients i ON i.id = p.ingredient_id
+		WHERE p.user_id = $1
+		ORDER BY i.name
+	`
+
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pantry items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*PantryItem{}
+	for rows.Next() {
+		item := &PantryItem{}
+		err := rows.Scan(&item.ID, &item.IngredientID, &item.Name, &item.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pantry item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over pantry items: %w", err)
+	}
+
+	return items, nil
+}
+
+// RemovePantryItem removes an ingredient from a user's pantry
+func (s *PostgresPantryStore) RemovePantryItem(userID int64, ingredientID int64) error {
+	query := `
+		DELETE FROM pantry_items
+		WHERE user_id = $1 AND ingredient_id = $2
+	`
+
+	result, err := s.db.Exec(query, userID, ingredientID)
+	if err != nil {
+		return fmt.Errorf("failed to remove pantry item: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetCookableRecipes returns published recipes sharing at least one ingredient with the user's pantry,
+// ranked by the fraction of their ingredients the pantry covers
+func (s *PostgresPantryStore) GetCookableRecipes(userID int64, limit int) ([]*CookableRecipe, error) {
+	query := `
+		WITH pantry AS (
+			SELECT ingredient_id FROM pantry_items WHERE user_id = $1
+		), candidates AS (
+			SELECT DISTINCT ri.recipe_id
+			FROM recipe_ingredients ri
+			JOIN pantry p ON p.ingredient_id = ri.ingredient_id
+		), coverage AS (
+			SELECT
+				ri.recipe_id,
+				COUNT(*) AS total_ingredients,
+				COUNT(p.ingredient_id) AS matched_ingredients,
+				COALESCE(
+					JSON_AGG(ri.name ORDER BY ri.position) FILTER (WHERE p.ingredient_id IS NULL),
+					'[]'
+				) AS missing_ingredients
+			FROM recipe_ingredients ri
+			JOIN candidates cand ON cand.recipe_id = ri.recipe_id
+			LEFT JOIN pantry p ON p.ingredient_id = ri.ingredient_id
+			GROUP BY ri.recipe_id
+		)
+		SELECT
+			r.id, r.title, r.description, r.user_id, r.category_id,
+			r.created_at, r.updated_at, r.published_at, r.status,
+			r.difficulty_level, r.serving_size, r.prep_time, r.cook_time, r.total_time,
+			c.name as category_name,
+			cov.total_ingredients, cov.matched_ingredients, cov.missing_ingredients
+		FROM coverage cov
+		JOIN recipes r ON r.id = cov.recipe_id
+		LEFT JOIN categories c ON r.category_id = c.id
+		WHERE r.status = 'published'
+		ORDER BY cov.matched_ingredients::float / cov.total_ingredients DESC,
+			cov.matched_ingredients DESC,
+			r.id
+		LIMIT $2
+	`
+
+	rows, err := s.db.Query(query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cookable recipes: %w", err)
+	}
+	defer rows.Close()
+
+	cookable := []*CookableRecipe{}
+	for rows.Next() {
+		recipe := &Recipe{}
+		item := &CookableRecipe{Recipe: recipe}
+		var missing []byte
+		err := rows.Scan(
+			&recipe.ID,
+			&recipe.Title,
+			&recipe.Description,
+			&recipe.UserID,
+			&recipe.CategoryID,
+			&recipe.CreatedAt,
+			&recipe.UpdatedAt,
+			&recipe.PublishedAt,
+			&recipe.Status,
+			&recipe.DifficultyLevel,
+			&recipe.ServingSize,
+			&recipe.PrepTime,
+			&recipe.CookTime,
+			&recipe.TotalTime,
+			&recipe.CategoryName,
+			&item.TotalIngredients,
+			&item.MatchedIngredients,
+			&missing,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cookable recipe: %w", err)
+		}
+
+		if err := json.Unmarshal(missing, &item.MissingIngredients); err != nil {
+			return nil, fmt.Errorf("failed to decode missing ingredients: %w", err)
+		}
+		item.Coverage = float64(item.MatchedIngredients) / float64(item.TotalIngredients)
+
+		cookable = append(cookable, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over cookable recipes: %w", err)
+	}
+
+	return cookable, nil
+}
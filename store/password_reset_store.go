@@ -53,7 +53,7 @@ func (s *PostgresPasswordResetStore) CreatePasswordResetToken(userID string, exp
 	// First, invalidate any existing tokens for this user
 	_, err := s.DeleteUserTokens(userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to invalidate existing tokens: %w", err)
+		return nil, fmt.Errorf("failed to invalidate existing tokens: %w", mapError(err))
 	}
 
 	// Generate a new OTP
@@ -81,7 +81,7 @@ func (s *PostgresPasswordResetStore) CreatePasswordResetToken(userID string, exp
 		&passwordResetToken.ID, &passwordResetToken.CreatedAt)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to create password reset token: %w", err)
+		return nil, fmt.Errorf("failed to create password reset token: %w", mapError(err))
 	}
 
 	return passwordResetToken, nil
@@ -121,7 +121,7 @@ func (s *PostgresPasswordResetStore) MarkTokenAsUsed(tokenID int64) error {
 
 	_, err := s.db.Exec(query, tokenID)
 	if err != nil {
-		return fmt.Errorf("failed to mark token as used: %w", err)
+		return fmt.Errorf("failed to mark token as used: %w", mapError(err))
 	}
 
 	return nil
@@ -133,7 +133,7 @@ func (s *PostgresPasswordResetStore) DeleteExpiredTokens() (int64, error) {
 
 	result, err := s.db.Exec(query, time.Now())
 	if err != nil {
-		return 0, fmt.Errorf("failed to delete expired tokens: %w", err)
+		return 0, fmt.Errorf("failed to delete expired tokens: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -150,7 +150,7 @@ func (s *PostgresPasswordResetStore) DeleteUserTokens(userID string) (int64, err
 
 	result, err := s.db.Exec(query, userID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to delete user tokens: %w", err)
+		return 0, fmt.Errorf("failed to delete user tokens: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -190,22 +190,22 @@ func (s *PostgresPasswordResetStore) ResetPasswordTransaction(tokenID int64, use
 	// 1. Update the user's password within the transaction
 	_, err = tx.Exec(`
 		UPDATE users 
-		SET password_hash = $1, updated_at = CURRENT_TIMESTAMP
+		SET password_hash = $1, has_password = TRUE, updated_at = CURRENT_TIMESTAMP
 		WHERE user_id = $2
 	`, hashedPassword, userID)
 	if err != nil {
-		return fmt.Errorf("failed to update password in transaction: %w", err)
+		return fmt.Errorf("failed to update password in transaction: %w", mapError(err))
 	}
 
 	// 2. Mark the token as used within the same transaction
 	_, err = tx.Exec(`UPDATE password_reset_tokens SET used = true WHERE id = $1`, tokenID)
 	if err != nil {
-		return fmt.Errorf("failed to mark token as used in transaction: %w", err)
+		return fmt.Errorf("failed to mark token as used in transaction: %w", mapError(err))
 	}
 
 	// 3. Commit the transaction
 	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return fmt.Errorf("failed to commit transaction: %w", mapError(err))
 	}
 
 	committed = true
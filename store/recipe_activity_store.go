@@ -0,0 +1,147 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Actions recorded in a recipe's activity feed
+const (
+	// RecipeActivityUpdated changes recipe fields; its changes map each field to {"from", "to"}
+	RecipeActivityUpdated = "updated"
+
+	// RecipeActivityRestored turns an archived recipe back into a draft
+	RecipeActivityRestored = "restored"
+
+	// RecipeActivityArchived is a stale draft archived automatically
+	RecipeActivityArchived = "archived"
+
+	RecipeActivityStepAdded            = "step_added"
+	RecipeActivityStepUpdated          = "step_updated"
+	RecipeActivityStepsReordered       = "steps_reordered"
+	RecipeActivityIngredientsReordered = "ingredients_reordered"
+	RecipeActivityPhotoAdded           = "photo_added"
+	RecipeActivityPhotoRemoved         = "photo_removed"
+	RecipeActivityPhotoReplaced        = "photo_replaced"
+	RecipeActivityPhotosReordered      = "photos_reordered"
+)
+
+// RecipeFieldChange is a recipe field's value before and after an update
+type RecipeFieldChange struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// RecipeActivity is one change to a recipe
+// Actor is who made the change, or nil for automatic changes and users who have since been deleted
+type RecipeActivity struct {
+	ID        int64                  `json:"id"`
+	RecipeID  int64                  `json:"recipe_id"`
+	UserID    *int64                 `json:"-"`
+	Actor     *RecipeAuthor          `json:"actor"`
+	Action    string                 `json:"action"`
+	Changes   map[string]interface{} `json:"changes"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// RecipeActivityStore defines the interface for recipe change feed operations
+type RecipeActivityStore interface {
+	AddRecipeActivity(activity *RecipeActivity) error
+	GetRecipeActivity(recipeID int64, page, limit int) ([]*RecipeActivity, int, error)
+}
+
+// PostgresRecipeActivityStore implements the RecipeActivityStore interface using PostgreSQL
+type PostgresRecipeActivityStore struct {
+	db *sql.DB
+}
+
+// NewPostgresRecipeActivityStore creates a new PostgresRecipeActivityStore
+func NewPostgresRecipeActivityStore(db *sql.DB) *PostgresRecipeActivityStore {
+	return &PostgresRecipeActivityStore{
+		db: db,
+	}
+}
+
+// AddRecipeActivity appends an entry to a recipe's activity feed
+func (s *PostgresRecipeActivityStore) AddRecipeActivity(activity *RecipeActivity) error {
+	if activity.Changes == nil {
+		activity.Changes = map[string]interface{}{}
+	}
+	changes, err := json.Marshal(activity.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode recipe activity changes: %w", err)
+	}
+
+	query := `
+		INSERT INTO recipe_activity (recipe_id, user_id, action, changes)
+		VALUES ($1, $2, $3, $4::JSONB)
+		RETURNING id, created_at
+	`
+
+	err = s.db.QueryRow(query, activity.RecipeID, activity.UserID, activity.Action, string(changes)).
+		Scan(&activity.ID, &activity.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add recipe activity: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// GetRecipeActivity returns a page of a recipe's activity, newest first, along with the total number of entries
+func (s *PostgresRecipeActivityStore) GetRecipeActivity(recipeID int64, page, limit int) ([]*RecipeActivity, int, error) {
+	query := `
+		SELECT a.id, a.recipe_id, a.user_id, u.username, u.profile_picture, a.action, a.changes, a.created_at,
+			COUNT(*) OVER() AS total_count
+		FROM recipe_activity a
+		LEFT JOIN users u ON u.id = a.user_id
+		WHERE a.recipe_id = $1
+		ORDER BY a.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.Query(query, recipeID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get recipe activity: %w", err)
+	}
+	defer rows.Close()
+
+	activities := []*RecipeActivity{}
+	total := 0
+	for rows.Next() {
+		activity := &RecipeActivity{}
+		var username sql.NullString
+		var profilePicture *string
+		var changes []byte
+		err := rows.Scan(
+			&activity.ID,
+			&activity.RecipeID,
+			&activity.UserID,
+			&username,
+			&profilePicture,
+			&activity.Action,
+			&changes,
+			&activity.CreatedAt,
+			&total,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan recipe activity: %w", err)
+		}
+
+		if err := json.Unmarshal(changes, &activity.Changes); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode recipe activity changes: %w", err)
+		}
+		if username.Valid {
+			activity.Actor = &RecipeAuthor{Username: username.String, ProfilePicture: profilePicture}
+		}
+
+		activities = append(activities, activity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over recipe activity: %w", err)
+	}
+
+	return activities, total, nil
+}
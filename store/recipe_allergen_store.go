@@ -0,0 +1,175 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Allergens lists the allergens the ingredient catalog records and authors can override
+var Allergens = []string{"dairy", "gluten", "nuts"}
+
+// AllergenRestrictions maps each allergen to the dietary restriction of users who avoid it
+var AllergenRestrictions = map[string]string{
+	"dairy":  "dairy_free",
+	"gluten": "gluten_free",
+	"nuts":   "nut_free",
+}
+
+// IsValidAllergen reports whether a value is one of Allergens
+func IsValidAllergen(allergen string) bool {
+	return slices.Contains(Allergens, allergen)
+}
+
+// InferredAllergen is an allergen found in a recipe's catalog ingredients, with the ingredients it comes from
+type InferredAllergen struct {
+	Allergen    string   `json:"allergen"`
+	Ingredients []string `json:"ingredients"`
+}
+
+// RecipeAllergens is what a recipe is known to contain
+// Contains is the inferred allergens corrected by the author's overrides.
+type RecipeAllergens struct {
+	Contains  []string            `json:"contains"`
+	Inferred  []*InferredAllergen `json:"inferred"`
+	Overrides map[string]bool     `json:"overrides"`
+
+	// Warnings, filled in by handlers, point out allergens that conflict with the recipe's dietary labels or the viewer's restrictions
+	Warnings []string `json:"warnings"`
+}
+
+// RecipeAllergenStore defines the interface for inferring recipe allergens and the author overrides that correct them
+type RecipeAllergenStore interface {
+	GetRecipeAllergens(recipeID int64) (*RecipeAllergens, error)
+	SetRecipeAllergenOverrides(recipeID int64, overrides map[string]bool) error
+}
+
+// PostgresRecipeAllergenStore implements the RecipeAllergenStore interface using PostgreSQL
+type PostgresRecipeAllergenStore struct {
+	db *sql.DB
+}
+
+// NewPostgresRecipeAllergenStore creates a new PostgresRecipeAllergenStore
+func NewPostgresRecipeAllergenStore(db *sql.DB) *PostgresRecipeAllergenStore {
+	return &PostgresRecipeAllergenStore{
+		db: db,
+	}
+}
+
+// GetRecipeAllergens infers a recipe's allergens from the catalog entries of its ingredients and applies its overrides
+// Ingredients that are not in the catalog contribute nothing, so authors can add what inference misses
+func (s *PostgresRecipeAllergenStore) GetRecipeAllergens(recipeID int64) (*RecipeAllergens, error) {
+	query := `
+		SELECT allergen.name, i.name
+		FROM recipe_ingredients ri
+		JOIN ingredients i ON i.id = ri.ingredient_id
+		CROSS JOIN LATERAL jsonb_array_elements_text(i.allergens) AS allergen(name)
+		WHERE ri.recipe_id = $1
+		ORDER BY allergen.name, ri.position, ri.id
+	`
+
+	rows, err := s.db.Query(query, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer recipe allergens: %w", err)
+	}
+	defer rows.Close()
+
+	allergens := &RecipeAllergens{
+		Contains:  []string{},
+		Inferred:  []*InferredAllergen{},
+		Overrides: map[string]bool{},
+		Warnings:  []string{},
+	}
+	var current *InferredAllergen
+	for rows.Next() {
+		var allergen, ingredient string
+		if err := rows.Scan(&allergen, &ingredient); err != nil {
+			return nil, fmt.Errorf("failed to scan recipe allergen: %w", err)
+		}
+		if current == nil || current.Allergen != allergen {
+			current = &InferredAllergen{Allergen: allergen, Ingredients: []string{}}
+			allergens.Inferred = append(allergens.Inferred, current)
+		}
+		if !slices.Contains(current.Ingredients, ingredient) {
+			current.Ingredients = append(current.Ingredients, ingredient)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over recipe allergens: %w", err)
+	}
+
+	overrideRows, err := s.db.Query(`SELECT allergen, contains FROM recipe_allergen_overrides WHERE recipe_id = $1`, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipe allergen overrides: %w", err)
+	}
+	defer overrideRows.Close()
+
+	for overrideRows.Next() {
+		var allergen string
+		var contains bool
+		if err := overrideRows.Scan(&allergen, &contains); err != nil {
+			return nil, fmt.Errorf("failed to scan recipe allergen override: %w", err)
+		}
+		allergens.Overrides[allergen] = contains
+	}
+	if err := overrideRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over recipe allergen overrides: %w", err)
+	}
+
+	for _, inferred := range allergens.Inferred {
+		if contains, ok := allergens.Overrides[inferred.Allergen]; !ok || contains {
+			allergens.Contains = append(allergens.Contains, inferred.Allergen)
+		}
+	}
+	for allergen, contains := range allergens.Overrides {
+		if contains && !slices.Contains(allergens.Contains, allergen) {
+			allergens.Contains = append(allergens.Contains, allergen)
+		}
+	}
+	sort.Strings(allergens.Contains)
+
+	return allergens, nil
+}
+
+// SetRecipeAllergenOverrides replaces a recipe's allergen overrides in a single transaction
+// Returns ErrNotFound if the recipe does not exist
+func (s *PostgresRecipeAllergenStore) SetRecipeAllergenOverrides(recipeID int64, overrides map[string]bool) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Overrides change what the recipe's responses say, so they count as an edit of the recipe
+	result, err := tx.Exec(`UPDATE recipes SET updated_at = NOW(), version = version + 1 WHERE id = $1`, recipeID)
+	if err != nil {
+		return fmt.Errorf("failed to update recipe: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.Exec(`DELETE FROM recipe_allergen_overrides WHERE recipe_id = $1`, recipeID); err != nil {
+		return fmt.Errorf("failed to clear recipe allergen overrides: %w", err)
+	}
+
+	for allergen, contains := range overrides {
+		_, err := tx.Exec(`INSERT INTO recipe_allergen_overrides (recipe_id, allergen, contains) VALUES ($1, $2, $3)`, recipeID, allergen, contains)
+		if err != nil {
+			return fmt.Errorf("failed to add recipe allergen override: %w", mapError(err))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", mapError(err))
+	}
+
+	return nil
+}
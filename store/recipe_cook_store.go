@@ -0,0 +1,280 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// RecipeCook records one occasion a user cooked a recipe
+type RecipeCook struct {
+	ID          int64     `json:"id"`
+	RecipeID    int64     `json:"recipe_id"`
+	RecipeTitle string    `json:"recipe_title,omitempty"`
+	PhotoURL    *string   `json:"photo_url,omitempty"`
+	Note        *string   `json:"note,omitempty"`
+	CookedAt    time.Time `json:"cooked_at"`
+}
+
+// CookPeriodCount is the number of cooks in a calendar week or month
+type CookPeriodCount struct {
+	PeriodStart time.Time `json:"period_start"`
+	Count       int       `json:"count"`
+}
+
+// CookingStats aggregates a user's cooking history
+// Streaks count consecutive UTC days with at least one cook; the current streak
+// stays alive until a full day passes without cooking
+type CookingStats struct {
+	TotalCooks        int                `json:"total_cooks"`
+	DistinctRecipes   int                `json:"distinct_recipes"`
+	CurrentStreakDays int                `json:"current_streak_days"`
+	LongestStreakDays int                `json:"longest_streak_days"`
+	LastCookedAt      *time.Time         `json:"last_cooked_at,omitempty"`
+	Weekly            []*CookPeriodCount `json:"weekly"`
+	Monthly           []*CookPeriodCount `json:"monthly"`
+}
+
+const (
+	// CookStatsWeeks is how many calendar weeks, including the current one, weekly counts cover
+	CookStatsWeeks = 12
+
+	// CookStatsMonths is how many calendar months, including the current one, monthly counts cover
+	CookStatsMonths = 12
+)
+
+// RecipeCookStore defines the interface for "I made this" tracking
+type RecipeCookStore interface {
+	RecordRecipeCook(userID int64, cook *RecipeCook) error
+	CountRecipeCooks(recipeID int64) (int, error)
+	GetUserCooks(userID int64, page, limit int) ([]*RecipeCook, int, error)
+	GetCookingStats(userID int64) (*CookingStats, error)
+}
+
+// PostgresRecipeCookStore implements the RecipeCookStore interface using PostgreSQL
+type PostgresRecipeCookStore struct {
+	db *sql.DB
+}
+
+// NewPostgresRecipeCookStore creates a new PostgresRecipeCookStore
+func NewPostgresRecipeCookStore(db *sql.DB) *PostgresRecipeCookStore {
+	return &PostgresRecipeCookStore{
+		db: db,
+	}
+}
+
+// RecordRecipeCook records that a user cooked a recipe
+// The user's review of the recipe, if any, is linked to the new cook so it shows as verified
+func (s *PostgresRecipeCookStore) RecordRecipeCook(userID int64, cook *RecipeCook) error {
+	query := `
+		WITH cook AS (
+			INSERT INTO recipe_cooks (user_id, recipe_id, photo_url, note)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, cooked_at
+		), linked AS (
+			UPDATE reviews SET cook_id = (SELECT id FROM cook)
+			WHERE user_id = $1 AND recipe_id = $2
+		)
+		SELECT id, cooked_at FROM cook
+	`
+
+	err := s.db.QueryRow(query, userID, cook.RecipeID, cook.PhotoURL, cook.Note).Scan(&cook.ID, &cook.CookedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record recipe cook: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// CountRecipeCooks returns how many times a recipe has been cooked across all users
+func (s *PostgresRecipeCookStore) CountRecipeCooks(recipeID int64) (int, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM recipe_cooks WHERE recipe_id = $1`, recipeID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recipe cooks: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetUserCooks returns a page of a user's cooking history, most recent first, along with the total count
+func (s *PostgresRecipeCookStore) GetUserCooks(userID int64, page, limit int) ([]*RecipeCook, int, error) {
+	query := `
+		SELECT rc.id, rc.recipe_id, r.title, rc.photo_url, rc.note, rc.cooked_at,
+			COUNT(*) OVER() AS total_count
+		FROM recipe_cooks rc
+		JOIN recipes r ON r.id = rc.recipe_id
+		WHERE rc.user_id = $1
+		ORDER BY rc.cooked_at DESC, rc.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.Query(query, userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get cooking history: %w", err)
+	}
+	defer rows.Close()
+
+	cooks := []*RecipeCook{}
+	total := 0
+	for rows.Next() {
+		cook := &RecipeCook{}
+		err := rows.Scan(
+			&cook.ID,
+			&cook.RecipeID,
+			&cook.RecipeTitle,
+			&cook.PhotoURL,
+			&cook.Note,
+			&cook.CookedAt,
+			&total,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan recipe cook: %w", err)
+		}
+		cooks = append(cooks, cook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over recipe cooks: %w", err)
+	}
+
+	return cooks, total, nil
+}
+
+// GetCookingStats aggregates a user's cooking history into totals, streaks, and weekly and monthly counts
+func (s *PostgresRecipeCookStore) GetCookingStats(userID int64) (*CookingStats, error) {
+	stats := &CookingStats{}
+
+	totalsQuery := `
+		SELECT COUNT(*), COUNT(DISTINCT recipe_id), MAX(cooked_at)
+		FROM recipe_cooks
+		WHERE user_id = $1
+	`
+	err := s.db.QueryRow(totalsQuery, userID).Scan(&stats.TotalCooks, &stats.DistinctRecipes, &stats.LastCookedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cooking totals: %w", err)
+	}
+
+	days, err := s.getCookDays(userID)
+	if err != nil {
+		return nil, err
+	}
+	stats.CurrentStreakDays, stats.LongestStreakDays = computeStreaks(days, time.Now().UTC())
+
+	stats.Weekly, err = s.getCookPeriodCounts(userID, "week", CookStatsWeeks)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.Monthly, err = s.getCookPeriodCounts(userID, "month", CookStatsMonths)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// getCookDays returns the distinct UTC days a user cooked on, oldest first
+func (s *PostgresRecipeCookStore) getCookDays(userID int64) ([]time.Time, error) {
+	query := `
+		SELECT DISTINCT (cooked_at AT TIME ZONE 'UTC')::DATE AS cook_day
+		FROM recipe_cooks
+		WHERE user_id = $1
+		ORDER BY cook_day
+	`
+
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cook days: %w", err)
+	}
+	defer rows.Close()
+
+	var days []time.Time
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("failed to scan cook day: %w", err)
+		}
+		days = append(days, day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over cook days: %w", err)
+	}
+
+	return days, nil
+}
+
+// getCookPeriodCounts returns cook counts for the most recent periods of the given unit ("week" or "month"),
+// oldest first and including periods with no cooks
+func (s *PostgresRecipeCookStore) getCookPeriodCounts(userID int64, unit string, periods int) ([]*CookPeriodCount, error) {
+	query := `
+		SELECT period.start, COUNT(rc.id)
+		FROM generate_series(
+			DATE_TRUNC($2, NOW() AT TIME ZONE 'UTC') - $3::INT * ('1 ' || $2)::INTERVAL,
+			DATE_TRUNC($2, NOW() AT TIME ZONE 'UTC'),
+			('1 ' || $2)::INTERVAL
+		) AS period(start)
+		LEFT JOIN recipe_cooks rc
+			ON rc.user_id = $1
+			AND DATE_TRUNC($2, rc.cooked_at AT TIME ZONE 'UTC') = period.start
+		GROUP BY period.start
+		ORDER BY period.start
+	`
+
+	rows, err := s.db.Query(query, userID, unit, periods-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %sly cook counts: %w", unit, err)
+	}
+	defer rows.Close()
+
+	counts := []*CookPeriodCount{}
+	for rows.Next() {
+		count := &CookPeriodCount{}
+		if err := rows.Scan(&count.PeriodStart, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan cook count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over cook counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// computeStreaks returns the current and longest runs of consecutive days in days, which must be sorted ascending
+// The current streak counts only if its last day is today or yesterday
+func computeStreaks(days []time.Time, now time.Time) (int, int) {
+	if len(days) == 0 {
+		return 0, 0
+	}
+
+	longest, run := 0, 0
+	var previous time.Time
+	for i, day := range days {
+		day = truncateToUTCDay(day)
+		if i > 0 && day.Sub(previous) == 24*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+		previous = day
+	}
+
+	if truncateToUTCDay(now).Sub(previous) > 24*time.Hour {
+		return 0, longest
+	}
+
+	return run, longest
+}
+
+// truncateToUTCDay returns midnight UTC of the calendar day t falls on
+func truncateToUTCDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// RecipeEngagement counts how many people a recipe's deletion would affect
+type RecipeEngagement struct {
+	FavoriteCount int `json:"favorite_count"`
+	ReviewCount   int `json:"review_count"`
+}
+
+// Total is the number of favorites and reviews together
+func (e *RecipeEngagement) Total() int {
+	return e.FavoriteCount + e.ReviewCount
+}
+
+// RecipeDeletionStore defines the interface for confirming deletes of recipes other users engage with
+type RecipeDeletionStore interface {
+	GetRecipeEngagement(recipeID int64) (*RecipeEngagement, error)
+	GetRecipeFavoriterIDs(recipeID int64) ([]int64, error)
+	CreateDeleteConfirmation(recipeID int64, ttl time.Duration) (string, time.Time, error)
+	UseDeleteConfirmation(recipeID int64, token string) (bool, error)
+}
+
+// PostgresRecipeDeletionStore implements the RecipeDeletionStore interface using PostgreSQL
+type PostgresRecipeDeletionStore struct {
+	db *sql.DB
+}
+
+// NewPostgresRecipeDeletionStore creates a new PostgresRecipeDeletionStore
+func NewPostgresRecipeDeletionStore(db *sql.DB) *PostgresRecipeDeletionStore {
+	return &PostgresRecipeDeletionStore{
+		db: db,
+	}
+}
+
+// GetRecipeEngagement returns a recipe's favorite and review counts from its summary, zero if it has none
+func (s *PostgresRecipeDeletionStore) GetRecipeEngagement(recipeID int64) (*RecipeEngagement, error) {
+	engagement := &RecipeEngagement{}
+	err := s.db.QueryRow(`
+		SELECT favorite_count, review_count
+		FROM recipe_summaries
+		WHERE recipe_id = $1
+	`, recipeID).Scan(&engagement.FavoriteCount, &engagement.ReviewCount)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get recipe engagement: %w", err)
+	}
+
+	return engagement, nil
+}
+
+// GetRecipeFavoriterIDs returns the users who favorited a recipe, other than its author
+func (s *PostgresRecipeDeletionStore) GetRecipeFavoriterIDs(recipeID int64) ([]int64, error) {
+	rows, err := s.db.Query(`
+		SELECT l.user_id
+		FROM likes l
+		JOIN recipes r ON r.id = l.recipe_id
+		WHERE l.recipe_id = $1 AND l.user_id <> r.user_id
+		ORDER BY l.user_id
+	`, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipe favoriters: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := []int64{}
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan recipe favoriter: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over recipe favoriters: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// CreateDeleteConfirmation starts a pending delete of a recipe that must be confirmed within ttl,
+// replacing any earlier one, and returns its plaintext token and expiry
+func (s *PostgresRecipeDeletionStore) CreateDeleteConfirmation(recipeID int64, ttl time.Duration) (string, time.Time, error) {
+	token, err := generatePreviewToken()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+
+	var expiresAt time.Time
+	err = s.db.QueryRow(`
+		INSERT INTO recipe_delete_confirmations (recipe_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (recipe_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, created_at = NOW(), expires_at = EXCLUDED.expires_at
+		RETURNING expires_at
+	`, recipeID, hashPreviewToken(token), time.Now().Add(ttl)).Scan(&expiresAt)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create delete confirmation: %w", mapError(err))
+	}
+
+	return token, expiresAt, nil
+}
+
+// UseDeleteConfirmation consumes a recipe's pending delete if token matches it and it has not expired
+// Reports false if the token is wrong or expired, or no delete is pending
+func (s *PostgresRecipeDeletionStore) UseDeleteConfirmation(recipeID int64, token string) (bool, error) {
+	result, err := s.db.Exec(`
+		DELETE FROM recipe_delete_confirmations
+		WHERE recipe_id = $1 AND token_hash = $2 AND expires_at > NOW()
+	`, recipeID, hashPreviewToken(token))
+	if err != nil {
+		return false, fmt.Errorf("failed to use delete confirmation: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
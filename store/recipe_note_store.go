@@ -0,0 +1,111 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// RecipeNote is a private note a user keeps on a recipe
+type RecipeNote struct {
+	ID        int64     `json:"id"`
+	RecipeID  int64     `json:"recipe_id"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RecipeNoteStore defines the interface for private recipe note operations
+type RecipeNoteStore interface {
+	GetRecipeNote(userID int64, recipeID int64) (*RecipeNote, error)
+	SaveRecipeNote(userID int64, recipeID int64, note string) (*RecipeNote, error)
+	DeleteRecipeNote(userID int64, recipeID int64) error
+}
+
+// PostgresRecipeNoteStore implements the RecipeNoteStore interface using PostgreSQL
+type PostgresRecipeNoteStore struct {
+	db *sql.DB
+}
+
+// NewPostgresRecipeNoteStore creates a new PostgresRecipeNoteStore
+func NewPostgresRecipeNoteStore(db *sql.DB) *PostgresRecipeNoteStore {
+	return &PostgresRecipeNoteStore{
+		db: db,
+	}
+}
+
+// GetRecipeNote returns a user's note on a recipe
+// Returns nil if the user has no note on the recipe
+func (s *PostgresRecipeNoteStore) GetRecipeNote(userID int64, recipeID int64) (*RecipeNote, error) {
+	query := `
+		SELECT id, recipe_id, note, created_at, updated_at
+		FROM recipe_notes
+		WHERE user_id = $1 AND recipe_id = $2
+	`
+
+	note := &RecipeNote{}
+	err := s.db.QueryRow(query, userID, recipeID).Scan(
+		&note.ID,
+		&note.RecipeID,
+		&note.Note,
+		&note.CreatedAt,
+		&note.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get recipe note: %w", err)
+	}
+
+	return note, nil
+}
+
+// SaveRecipeNote creates a user's note on a recipe or replaces the existing one
+func (s *PostgresRecipeNoteStore) SaveRecipeNote(userID int64, recipeID int64, note string) (*RecipeNote, error) {
+	query := `
+		INSERT INTO recipe_notes (user_id, recipe_id, note)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, recipe_id) DO UPDATE
+		SET note = EXCLUDED.note, updated_at = NOW()
+		RETURNING id, recipe_id, note, created_at, updated_at
+	`
+
+	saved := &RecipeNote{}
+	err := s.db.QueryRow(query, userID, recipeID, note).Scan(
+		&saved.ID,
+		&saved.RecipeID,
+		&saved.Note,
+		&saved.CreatedAt,
+		&saved.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save recipe note: %w", mapError(err))
+	}
+
+	return saved, nil
+}
+
+// DeleteRecipeNote removes a user's note on a recipe
+func (s *PostgresRecipeNoteStore) DeleteRecipeNote(userID int64, recipeID int64) error {
+	query := `
+		DELETE FROM recipe_notes
+		WHERE user_id = $1 AND recipe_id = $2
+	`
+
+	result, err := s.db.Exec(query, userID, recipeID)
+	if err != nil {
+		return fmt.Errorf("failed to delete recipe note: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// RecipePreviewLink lets anyone holding its token read a recipe until it expires or is revoked
+// The plaintext token is never stored; it is only returned when the link is created
+type RecipePreviewLink struct {
+	ID           int64      `json:"id"`
+	RecipeID     int64      `json:"recipe_id"`
+	ViewCount    int        `json:"view_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+}
+
+// RecipePreviewStore defines the interface for recipe preview link operations
+type RecipePreviewStore interface {
+	CreatePreviewLink(recipeID int64, ttl time.Duration) (*RecipePreviewLink, string, error)
+	GetPreviewLinks(recipeID int64) ([]*RecipePreviewLink, error)
+	RevokePreviewLink(id int64, recipeID int64) error
+	UsePreviewLink(token string) (*RecipePreviewLink, error)
+}
+
+// PostgresRecipePreviewStore implements the RecipePreviewStore interface using PostgreSQL
+type PostgresRecipePreviewStore struct {
+	db *sql.DB
+}
+
+// NewPostgresRecipePreviewStore creates a new PostgresRecipePreviewStore
+func NewPostgresRecipePreviewStore(db *sql.DB) *PostgresRecipePreviewStore {
+	return &PostgresRecipePreviewStore{
+		db: db,
+	}
+}
+
+// generatePreviewToken returns a new random preview link token
+func generatePreviewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashPreviewToken returns the hex SHA-256 digest stored in place of a token
+func hashPreviewToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// recipePreviewLinkColumns lists the recipe_preview_links columns read by scanRecipePreviewLink, in order
+const recipePreviewLinkColumns = `id, recipe_id, view_count, created_at, expires_at, last_viewed_at`
+
+func scanRecipePreviewLink(row rowScanner, link *RecipePreviewLink) error {
+	return row.Scan(
+		&link.ID,
+		&link.RecipeID,
+		&link.ViewCount,
+		&link.CreatedAt,
+		&link.ExpiresAt,
+		&link.LastViewedAt,
+	)
+}
+
+// CreatePreviewLink creates a preview link for a recipe that expires after ttl
+// It returns the stored link and the plaintext token, which cannot be recovered later
+func (s *PostgresRecipePreviewStore) CreatePreviewLink(recipeID int64, ttl time.Duration) (*RecipePreviewLink, string, error) {
+	token, err := generatePreviewToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate preview token: %w", err)
+	}
+
+	query := `
+		INSERT INTO recipe_preview_links (recipe_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING ` + recipePreviewLinkColumns
+
+	link := &RecipePreviewLink{}
+	err = scanRecipePreviewLink(s.db.QueryRow(query, recipeID, hashPreviewToken(token), time.Now().Add(ttl)), link)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create preview link: %w", mapError(err))
+	}
+
+	return link, token, nil
+}
+
+// GetPreviewLinks returns a recipe's preview links that still work, newest first
+func (s *PostgresRecipePreviewStore) GetPreviewLinks(recipeID int64) ([]*RecipePreviewLink, error) {
+	query := `
+		SELECT ` + recipePreviewLinkColumns + `
+		FROM recipe_preview_links
+		WHERE recipe_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC
+	`
+
+	rows, err := s.db.Query(query, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preview links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []*RecipePreviewLink{}
+	for rows.Next() {
+		link := &RecipePreviewLink{}
+		if err := scanRecipePreviewLink(rows, link); err != nil {
+			return nil, fmt.Errorf("failed to scan preview link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over preview links: %w", err)
+	}
+
+	return links, nil
+}
+
+// RevokePreviewLink stops one of a recipe's preview links from working
+// Returns ErrNotFound if the link does not exist, belongs to another recipe or is already revoked
+func (s *PostgresRecipePreviewStore) RevokePreviewLink(id int64, recipeID int64) error {
+	result, err := s.db.Exec(`
+		UPDATE recipe_preview_links SET revoked_at = NOW()
+		WHERE id = $1 AND recipe_id = $2 AND revoked_at IS NULL
+	`, id, recipeID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke preview link: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UsePreviewLink looks up a working preview link by its plaintext token and counts the view
+// Returns nil if the token is unknown, expired or revoked
+func (s *PostgresRecipePreviewStore) UsePreviewLink(token string) (*RecipePreviewLink, error) {
+	query := `
+		UPDATE recipe_preview_links
+		SET view_count = view_count + 1, last_viewed_at = NOW()
+		WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		RETURNING ` + recipePreviewLinkColumns
+
+	link := &RecipePreviewLink{}
+	if err := scanRecipePreviewLink(s.db.QueryRow(query, hashPreviewToken(token)), link); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to use preview link: %w", mapError(err))
+	}
+
+	return link, nil
+}
@@ -0,0 +1,149 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// RecipeViewIncrement is a number of views of a recipe on one UTC day, added to its stored count
+type RecipeViewIncrement struct {
+	RecipeID int64
+	Day      time.Time
+	Count    int
+}
+
+// RecipeStats is how one of an author's recipes performed over a period
+// Views, Favorites, Reviews and AverageRating cover the period; the Total fields and OverallAverageRating cover all time.
+// Reviews by the recipe's author are left out, as in review summaries.
+type RecipeStats struct {
+	RecipeID             int64
+	Title                string
+	Status               RecipeStatus
+	PublishedAt          *time.Time
+	Views                int
+	Favorites            int
+	Reviews              int
+	AverageRating        *float64
+	TotalFavorites       int
+	TotalReviews         int
+	OverallAverageRating *float64
+}
+
+// RecipeStatsStore defines the interface for recipe view counts and per-recipe statistics
+type RecipeStatsStore interface {
+	AddRecipeViews(increments []*RecipeViewIncrement) error
+	CountUserRecipes(userID int64) (int, error)
+	EachRecipeStats(userID int64, from, to time.Time, fn func(*RecipeStats) error) error
+}
+
+// PostgresRecipeStatsStore implements the RecipeStatsStore interface using PostgreSQL
+type PostgresRecipeStatsStore struct {
+	db *sql.DB
+}
+
+// NewPostgresRecipeStatsStore creates a new PostgresRecipeStatsStore
+func NewPostgresRecipeStatsStore(db *sql.DB) *PostgresRecipeStatsStore {
+	return &PostgresRecipeStatsStore{
+		db: db,
+	}
+}
+
+// AddRecipeViews adds view counts in a single transaction
+// Views of recipes deleted in the meantime are dropped
+func (s *PostgresRecipeStatsStore) AddRecipeViews(increments []*RecipeViewIncrement) error {
+	if len(increments) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO recipe_daily_views (recipe_id, day, views)
+		SELECT r.id, $2::DATE, $3
+		FROM recipes r
+		WHERE r.id = $1
+		ON CONFLICT (recipe_id, day)
+		DO UPDATE SET views = recipe_daily_views.views + EXCLUDED.views
+	`
+
+	return WithTx(context.Background(), s.db, func(tx *sql.Tx) error {
+		for _, increment := range increments {
+			_, err := tx.Exec(query, increment.RecipeID, increment.Day.Format(time.DateOnly), increment.Count)
+			if err != nil {
+				return fmt.Errorf("failed to add recipe views: %w", mapError(err))
+			}
+		}
+		return nil
+	})
+}
+
+// CountUserRecipes returns how many recipes a user has, whatever their status
+func (s *PostgresRecipeStatsStore) CountUserRecipes(userID int64) (int, error) {
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM recipes WHERE user_id = $1`, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count user recipes: %w", err)
+	}
+	return count, nil
+}
+
+// EachRecipeStats calls fn with the statistics of each of a user's recipes, oldest recipe first, as rows are read
+// The period runs from the start of the UTC day of from to the end of the UTC day of to.
+// Iteration stops at the first error from fn, which is returned as is.
+func (s *PostgresRecipeStatsStore) EachRecipeStats(userID int64, from, to time.Time, fn func(*RecipeStats) error) error {
+	query := `
+		SELECT r.id, r.title, r.status, r.published_at,
+			COALESCE((
+				SELECT SUM(v.views) FROM recipe_daily_views v
+				WHERE v.recipe_id = r.id AND v.day >= $4::DATE AND v.day <= $5::DATE
+			), 0),
+			(SELECT COUNT(*) FROM likes l WHERE l.recipe_id = r.id AND l.created_at >= $2 AND l.created_at < $3),
+			period.review_count, period.average_rating,
+			COALESCE(summary.favorite_count, 0), COALESCE(summary.review_count, 0), summary.average_rating
+		FROM recipes r
+		LEFT JOIN recipe_summaries summary ON summary.recipe_id = r.id
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS review_count, AVG(rv.rating)::FLOAT8 AS average_rating
+			FROM reviews rv
+			WHERE rv.recipe_id = r.id AND rv.user_id <> r.user_id AND rv.hidden_at IS NULL AND rv.created_at >= $2 AND rv.created_at < $3
+		) period ON TRUE
+		WHERE r.user_id = $1
+		ORDER BY r.id
+	`
+
+	start := from.UTC().Truncate(24 * time.Hour)
+	end := to.UTC().Truncate(24 * time.Hour)
+	rows, err := s.db.Query(query, userID, start, end.Add(24*time.Hour), start.Format(time.DateOnly), end.Format(time.DateOnly))
+	if err != nil {
+		return fmt.Errorf("failed to get recipe stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		stats := &RecipeStats{}
+		err := rows.Scan(
+			&stats.RecipeID,
+			&stats.Title,
+			&stats.Status,
+			&stats.PublishedAt,
+			&stats.Views,
+			&stats.Favorites,
+			&stats.Reviews,
+			&stats.AverageRating,
+			&stats.TotalFavorites,
+			&stats.TotalReviews,
+			&stats.OverallAverageRating,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan recipe stats: %w", err)
+		}
+		if err := fn(stats); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over recipe stats: %w", err)
+	}
+
+	return nil
+}
@@ -1,9 +1,17 @@
 package store
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"strings"
 	"time"
+
+	"github.com/dapoadedire/chefshare_be/links"
+	"github.com/dapoadedire/chefshare_be/utils"
 )
 
 type RecipeStatus string
@@ -17,8 +25,77 @@ const (
 	DifficultyEasy   DifficultyLevel = "easy"
 	DifficultyMedium DifficultyLevel = "medium"
 	DifficultyHard   DifficultyLevel = "hard"
+
+	SortNewest = "newest"
+	SortOldest = "oldest"
+	SortTitle  = "title"
+	SortCost   = "cost"
+
+	// SortUpdated lists the most recently edited recipes first, which suits an author's own drafts
+	SortUpdated = "updated"
 )
 
+// ErrReorderMismatch is returned when a reorder request does not list every item of a recipe exactly once
+var ErrReorderMismatch = errors.New("ids must list every item of the recipe exactly once")
+
+// recipeSortOrders maps supported sort names to ORDER BY clauses for recipe listings
+var recipeSortOrders = map[string]string{
+	SortNewest: "r.published_at DESC NULLS LAST, r.id DESC",
+	SortOldest: "r.published_at ASC NULLS LAST, r.id ASC",
+	SortTitle:  "r.title ASC, r.id ASC",
+	SortCost:   "cost_per_serving ASC NULLS LAST, r.id ASC",
+
+	SortUpdated: "r.updated_at DESC, r.id DESC",
+}
+
+// IsValidRecipeSort reports whether sort is a supported recipe listing order
+func IsValidRecipeSort(sort string) bool {
+	_, ok := recipeSortOrders[sort]
+	return ok
+}
+
+// IsValidRecipeStatus reports whether status is a known recipe status
+func IsValidRecipeStatus(status RecipeStatus) bool {
+	return status == StatusDraft || status == StatusPublished || status == StatusArchived
+}
+
+// IsValidDifficultyLevel reports whether level is a known difficulty level
+func IsValidDifficultyLevel(level DifficultyLevel) bool {
+	return level == DifficultyEasy || level == DifficultyMedium || level == DifficultyHard
+}
+
+// RecipeListOptions controls filtering, sorting, and pagination of recipe listings
+type RecipeListOptions struct {
+	Page           int
+	Limit          int
+	Sort           string
+	CategoryID     *int64
+	Difficulty     *DifficultyLevel
+	MaxCost        *float64
+	MaxTotalTime   *int
+	MinServingSize *int
+	MaxServingSize *int
+	MinRating      *float64
+	Diets          []string
+
+	// AuthorID limits the listing to one author's recipes. Only then are unpublished recipes
+	// included: all statuses by default, or just Status when it is set
+	AuthorID *int64
+	Status   *RecipeStatus
+
+	// FavoritedBy limits the listing to recipes the user has favorited
+	FavoritedBy *int64
+
+	// Query limits the listing to recipes matching a full-text query, as in search, or containing it in their title
+	Query string
+
+	// Tags limits the listing to recipes with every one of these tags, matched case-insensitively
+	Tags []string
+
+	// Language limits the listing to recipes detected as written in this language, an ISO 639-1 code
+	Language string
+}
+
 type Recipe struct {
 	ID              int64           `json:"id"`
 	Title           string          `json:"title"`
@@ -28,13 +105,44 @@ type Recipe struct {
 	CategoryName    *string         `json:"category_name,omitempty"`
 	CreatedAt       time.Time       `json:"created_at"`
 	UpdatedAt       time.Time       `json:"updated_at"`
+	Version         int             `json:"version"`
 	PublishedAt     *time.Time      `json:"published_at,omitempty"`
 	Status          RecipeStatus    `json:"status"`
+	ArchivedAt      *time.Time      `json:"archived_at,omitempty"`
 	DifficultyLevel DifficultyLevel `json:"difficulty_level"`
 	ServingSize     *int            `json:"serving_size,omitempty"`
 	PrepTime        *int            `json:"prep_time,omitempty"`
 	CookTime        *int            `json:"cook_time,omitempty"`
 	TotalTime       *int            `json:"total_time,omitempty"`
+	DietaryLabels   []string        `json:"dietary_labels"`
+	AuthorVerified  bool            `json:"author_verified"`
+
+	// Language is detected from the title and description whenever the recipe is saved; nil if it could not be told
+	Language *string `json:"language,omitempty"`
+
+	EstimatedCostPerServing *float64 `json:"estimated_cost_per_serving,omitempty"`
+	MadeCount               *int     `json:"made_count,omitempty"`
+
+	// AverageRating, ReviewCount and FavoriteCount are filled in recipe listings from the recipe_summaries table
+	AverageRating *float64 `json:"average_rating,omitempty"`
+	ReviewCount   *int     `json:"review_count,omitempty"`
+	FavoriteCount *int     `json:"favorite_count,omitempty"`
+
+	// Author and PrimaryPhoto are filled in recipe listings so clients can render cards without further requests
+	Author       *RecipeAuthor `json:"author,omitempty"`
+	PrimaryPhoto *RecipePhoto  `json:"primary_photo,omitempty"`
+
+	// AuthorUsername is always read, so links to the author can be built outside listings too
+	AuthorUsername string `json:"-"`
+
+	// Links to related resources, filled in by handlers before responding
+	Links links.Links `json:"_links,omitempty"`
+}
+
+// RecipeAuthor is the public profile of a recipe's author shown in listings
+type RecipeAuthor struct {
+	Username       string  `json:"username"`
+	ProfilePicture *string `json:"profile_picture,omitempty"`
 }
 
 type RecipePhoto struct {
@@ -43,29 +151,79 @@ type RecipePhoto struct {
 	PhotoURL  string    `json:"photo_url"`
 	IsPrimary bool      `json:"is_primary"`
 	CreatedAt time.Time `json:"created_at"`
+
+	// Position is where the photo appears in the recipe's gallery, starting at 1
+	Position int `json:"position"`
+
+	// StorageKey locates an uploaded photo in the storage backend; PhotoURL is then filled with a signed URL when served
+	StorageKey *string `json:"-"`
+
+	// Variants holds resized versions of an uploaded photo when the storage backend can transform images
+	Variants map[string]string `json:"variants,omitempty"`
+}
+
+// PhotoImport is a photo added by external link that is waiting to be downloaded into storage
+type PhotoImport struct {
+	PhotoID   int64
+	RecipeID  int64
+	SourceURL string
+
+	// Attempts counts this attempt
+	Attempts int
 }
 
 type RecipeIngredient struct {
-	ID       int64    `json:"id"`
-	RecipeID int64    `json:"recipe_id"`
-	Name     string   `json:"name"`
-	Image    *string  `json:"image,omitempty"`
-	Quantity *float64 `json:"quantity,omitempty"`
-	Unit     *string  `json:"unit,omitempty"`
-	Position *int     `json:"position,omitempty"`
+	ID           int64    `json:"id"`
+	RecipeID     int64    `json:"recipe_id"`
+	IngredientID *int64   `json:"ingredient_id,omitempty"`
+	Name         string   `json:"name"`
+	Image        *string  `json:"image,omitempty"`
+	Quantity     *float64 `json:"quantity,omitempty"`
+	Unit         *string  `json:"unit,omitempty"`
+	Position     *int     `json:"position,omitempty"`
+
+	// FormattedQuantity is a display string such as "1½ cup", set only when a client asks for formatted output
+	FormattedQuantity *string `json:"formatted_quantity,omitempty"`
 }
 
 type RecipeStep struct {
-	ID                int64  `json:"id"`
-	RecipeID          int64  `json:"recipe_id"`
-	StepNumber        int    `json:"step_number"`
-	Instruction       string `json:"instruction"`
-	DurationInMinutes *int   `json:"duration_in_minutes,omitempty"`
+	ID                int64       `json:"id"`
+	RecipeID          int64       `json:"recipe_id"`
+	StepNumber        int         `json:"step_number"`
+	Instruction       string      `json:"instruction"`
+	DurationInMinutes *int        `json:"duration_in_minutes,omitempty"`
+	Timers            []StepTimer `json:"timers"`
+}
+
+// StepTimer is a labeled countdown a cook can start while following a step
+type StepTimer struct {
+	Label           string `json:"label"`
+	DurationSeconds int    `json:"duration_seconds"`
+}
+
+// marshalStepTimers encodes step timers for the timers JSONB column
+func marshalStepTimers(timers []StepTimer) (string, error) {
+	if timers == nil {
+		timers = []StepTimer{}
+	}
+
+	data, err := json.Marshal(timers)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode step timers: %w", err)
+	}
+
+	return string(data), nil
 }
 
 type Category struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
+
+	// RecipeCount is the number of published recipes in the category
+	RecipeCount int `json:"recipe_count"`
+
+	// Photo is a representative photo from one of the category's published recipes, when requested
+	Photo *RecipePhoto `json:"photo,omitempty"`
 }
 
 type Tag struct {
@@ -73,6 +231,19 @@ type Tag struct {
 	Name string `json:"name"`
 }
 
+// RecipeTagsUpdate is the outcome of replacing a recipe's tags: the tags it now has, and which of them were added or removed
+type RecipeTagsUpdate struct {
+	Tags    []*Tag `json:"tags"`
+	Added   []*Tag `json:"added"`
+	Removed []*Tag `json:"removed"`
+}
+
+// PopularTag is a tag with the number of published recipes using it
+type PopularTag struct {
+	Tag
+	RecipeCount int `json:"recipe_count"`
+}
+
 type RecipeReview struct {
 	ID        int64     `json:"id"`
 	RecipeID  int64     `json:"recipe_id"`
@@ -80,6 +251,111 @@ type RecipeReview struct {
 	Rating    int       `json:"rating"`
 	Comment   string    `json:"comment,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
+
+	// CookID links the review to the author's latest "I made this" record; CookedIt is set when it does
+	CookID   *int64 `json:"cook_id,omitempty"`
+	CookedIt bool   `json:"cooked_it"`
+
+	// AuthorResponse is the recipe author's pinned response, if they wrote one
+	AuthorResponse *ReviewAuthorResponse `json:"author_response,omitempty"`
+}
+
+// ReviewAuthorResponse is the one response a recipe's author can pin to a review of it
+type ReviewAuthorResponse struct {
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// reviewSelectColumns are the columns scanned by scanReviewRow; queries alias reviews as rv
+// and LEFT JOIN review_author_responses as ar
+const reviewSelectColumns = `rv.id, rv.recipe_id, rv.user_id, rv.rating, rv.comment, rv.created_at, rv.cook_id,
+	ar.body, ar.created_at, ar.updated_at`
+
+// reviewAuthorResponseJoin attaches each review's author response, if any
+const reviewAuthorResponseJoin = `LEFT JOIN review_author_responses ar ON ar.review_id = rv.id`
+
+// scanReviewRow scans reviewSelectColumns, followed by any extra destinations
+func scanReviewRow(row rowScanner, extra ...any) (*RecipeReview, error) {
+	review := &RecipeReview{}
+	var responseBody *string
+	var responseCreatedAt, responseUpdatedAt *time.Time
+	dest := append([]any{
+		&review.ID,
+		&review.RecipeID,
+		&review.UserID,
+		&review.Rating,
+		&review.Comment,
+		&review.CreatedAt,
+		&review.CookID,
+		&responseBody,
+		&responseCreatedAt,
+		&responseUpdatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	review.CookedIt = review.CookID != nil
+	if responseBody != nil {
+		review.AuthorResponse = &ReviewAuthorResponse{
+			Body:      *responseBody,
+			CreatedAt: *responseCreatedAt,
+			UpdatedAt: *responseUpdatedAt,
+		}
+	}
+	return review, nil
+}
+
+// ReviewListOptions filters and paginates a recipe's reviews
+type ReviewListOptions struct {
+	VerifiedOnly bool
+	Page         int
+	Limit        int
+}
+
+// RatingSummaryOptions configures how a recipe's ratings are aggregated
+type RatingSummaryOptions struct {
+	// RecentSince separates recent reviews from earlier ones when computing the trend
+	RecentSince time.Time
+
+	// VerifiedWeight counts each verified review this many times in WeightedAverageRating; 1 or less disables weighting
+	VerifiedWeight float64
+}
+
+// Rating trends compare recent reviews of a recipe with earlier ones
+const (
+	RatingTrendUp     = "up"
+	RatingTrendDown   = "down"
+	RatingTrendSteady = "steady"
+
+	// ratingTrendMinReviews is how many reviews each period needs before a trend is reported
+	ratingTrendMinReviews = 3
+
+	// ratingTrendThreshold is how far the recent average must move from the earlier one to count as a trend
+	ratingTrendThreshold = 0.25
+)
+
+// RecipeRatingSummary aggregates the reviews of a recipe
+type RecipeRatingSummary struct {
+	AverageRating *float64 `json:"average_rating"`
+	ReviewCount   int      `json:"review_count"`
+
+	// VerifiedReviewCount counts reviews by users who have cooked the recipe
+	VerifiedReviewCount int `json:"verified_review_count"`
+
+	// WeightedAverageRating counts verified reviews more heavily; it is only set when weighting is configured
+	WeightedAverageRating *float64 `json:"weighted_average_rating,omitempty"`
+
+	// Histogram counts the reviews with each rating, keyed 1 to 5
+	Histogram map[int]int `json:"histogram"`
+
+	// RecentAverageRating and RecentReviewCount cover reviews written since the summary's cutoff
+	RecentAverageRating *float64 `json:"recent_average_rating"`
+	RecentReviewCount   int      `json:"recent_review_count"`
+
+	// Trend is up, down or steady; nil when there are too few recent or earlier reviews to compare
+	Trend *string `json:"trend"`
 }
 
 type CompleteRecipe struct {
@@ -89,48 +365,105 @@ type CompleteRecipe struct {
 	Photos      []*RecipePhoto      `json:"photos"`
 	Tags        []*Tag              `json:"tags"`
 	Reviews     []*RecipeReview     `json:"reviews"`
+
+	EstimatedCost *RecipeCost      `json:"estimated_cost,omitempty"`
+	Allergens     *RecipeAllergens `json:"allergens,omitempty"`
+	PersonalNote  *RecipeNote      `json:"personal_note,omitempty"`
 }
 
+// StaleDraft is a draft that has not been edited for long enough to be archived, with its author's contact details
+type StaleDraft struct {
+	RecipeID  int64
+	Title     string
+	UpdatedAt time.Time
+	UserID    string
+	Username  string
+	FirstName string
+	Email     string
+}
+
+// RecipeStore covers recipes with their ingredients and steps
+// Photos, tags and categories, and reviews have their own interfaces; PostgresRecipeStore implements all of them
 type RecipeStore interface {
 	GetCompleteRecipe(id int64) (*CompleteRecipe, error)
 
 	CreateRecipe(recipe *Recipe) error
+	CreateCompleteRecipe(recipe *Recipe, ingredients []*RecipeIngredient, steps []*RecipeStep) error
 	GetRecipeByID(id int64) (*Recipe, error)
-	GetRecipesByUserID(userID int64) ([]*Recipe, error)
+	GetRecipesByUserID(userID int64, afterID int64, limit int) ([]*Recipe, error)
+	CountRecipesByUserID(userID int64) (int, error)
+	GetRecipes(ctx context.Context, opts RecipeListOptions) ([]*Recipe, int, error)
+	GetRecipeFacets(ctx context.Context, opts RecipeListOptions) (*RecipeFacets, error)
+	EachRecipe(ctx context.Context, opts RecipeListOptions, fn func(*Recipe) error) error
+	GetRandomRecipe(ctx context.Context, opts RecipeListOptions) (*Recipe, error)
+	GetRecommendedRecipes(userID int64, prefs *UserPreferences, limit int) ([]*Recipe, error)
 	UpdateRecipe(recipe *Recipe) error
+	SetRecipeDietaryLabels(recipeID int64, labels []string) error
 	DeleteRecipe(id int64) error
 
-	AddRecipePhoto(photo *RecipePhoto) error
-	GetRecipePhotos(recipeID int64) ([]*RecipePhoto, error)
-	SetPrimaryPhoto(photoID int64, recipeID int64) error
-	DeleteRecipePhoto(photoID int64) error
-
 	AddRecipeIngredient(ingredient *RecipeIngredient) error
 	GetRecipeIngredients(recipeID int64) ([]*RecipeIngredient, error)
 	UpdateRecipeIngredient(ingredient *RecipeIngredient) error
 	DeleteRecipeIngredient(ingredientID int64) error
+	ReorderRecipeIngredients(recipeID int64, ingredientIDs []int64) error
 
 	AddRecipeStep(step *RecipeStep) error
 	GetRecipeSteps(recipeID int64) ([]*RecipeStep, error)
 	UpdateRecipeStep(step *RecipeStep) error
 	DeleteRecipeStep(stepID int64) error
+	ReorderRecipeSteps(recipeID int64, stepIDs []int64) error
+
+	CountRecipesCreatedSince(userID int64, since time.Time) (int, error)
+
+	GetStaleDraftsToWarn(untouchedBefore time.Time, limit int) ([]*StaleDraft, error)
+	MarkStaleDraftsWarned(recipeIDs []int64) error
+	ArchiveStaleDrafts(untouchedBefore time.Time, warnedBefore time.Time) (int64, error)
+	RestoreRecipe(id int64) error
+}
+
+// RecipeMediaStore covers the photos attached to recipes
+type RecipeMediaStore interface {
+	AddRecipePhoto(photo *RecipePhoto) error
+	GetRecipePhotos(recipeID int64) ([]*RecipePhoto, error)
+	GetRecipePhotoByID(photoID int64) (*RecipePhoto, error)
+	SetPrimaryPhoto(photoID int64, recipeID int64) error
+	ReorderRecipePhotos(recipeID int64, photoIDs []int64) error
+	ReplaceRecipePhotoFile(photoID int64, recipeID int64, storageKey string) error
+	DeleteRecipePhoto(photoID int64) error
 
+	ClaimPhotoImports(limit int, lease time.Duration) ([]*PhotoImport, error)
+	CompletePhotoImport(photoID int64, sourceURL string, storageKey string) error
+	FailPhotoImport(photoID int64, message string, retryAt *time.Time) error
+}
+
+// RecipeTaxonomyStore covers categories and tags, and which tags a recipe has
+type RecipeTaxonomyStore interface {
 	AddRecipeTag(recipeID int64, tagID int64) error
 	RemoveRecipeTag(recipeID int64, tagID int64) error
+	SetRecipeTags(recipeID int64, names []string) (*RecipeTagsUpdate, error)
 	GetRecipeTags(recipeID int64) ([]*Tag, error)
 
-	GetAllCategories() ([]*Category, error)
+	GetAllCategories(includePhotos bool) ([]*Category, error)
 	GetAllTags() ([]*Tag, error)
+	GetPopularTags(since *time.Time, limit int) ([]*PopularTag, error)
 	CreateTag(name string) (*Tag, error)
 	CreateCategory(name string) (*Category, error)
+}
 
-	AddRecipeReview(recipeID int64, userID int64, rating int, comment string) error
-	GetRecipeReviews(recipeID int64) ([]*RecipeReview, error)
+// ReviewStore covers recipe reviews and the ratings aggregated from them
+type ReviewStore interface {
+	AddRecipeReview(recipeID int64, userID int64, rating int, comment string) (*RecipeReview, error)
+	GetRecipeReviews(recipeID int64, opts ReviewListOptions) ([]*RecipeReview, int, error)
+	GetRecipeReviewByID(reviewID int64) (*RecipeReview, error)
+	GetReviewsByUserID(userID int64) ([]*RecipeReview, error)
+	GetRecipeRatingSummary(recipeID int64, opts RatingSummaryOptions) (*RecipeRatingSummary, error)
 	UpdateRecipeReview(review *RecipeReview) error
 	DeleteRecipeReview(reviewID int64) error
-}
-
+	SetReviewAuthorResponse(reviewID int64, userID int64, body string) (*ReviewAuthorResponse, error)
+	DeleteReviewAuthorResponse(reviewID int64) error
 
+	CountReviewsCreatedSince(userID int64, since time.Time) (int, error)
+}
 
 type PostgresRecipeStore struct {
 	db *sql.DB
@@ -152,35 +485,15 @@ func (s *PostgresRecipeStore) GetCompleteRecipe(id int64) (*CompleteRecipe, erro
 
 	// Get the main recipe with category name
 	recipeQuery := `
-        SELECT 
-            r.id, r.title, r.description, r.user_id, r.category_id,
-            r.created_at, r.updated_at, r.published_at, r.status, 
-            r.difficulty_level, r.serving_size, r.prep_time, r.cook_time, r.total_time,
-            c.name as category_name
-        FROM recipes r
-        LEFT JOIN categories c ON r.category_id = c.id
-        WHERE r.id = $1
-    `
+		SELECT ` + recipeSelectColumns + `,
+			(SELECT COUNT(*) FROM recipe_cooks rc WHERE rc.recipe_id = r.id) AS made_count
+		FROM recipes r
+		LEFT JOIN categories c ON r.category_id = c.id
+		WHERE r.id = $1
+	`
 
 	recipe := &Recipe{}
-	err = tx.QueryRow(recipeQuery, id).Scan(
-		&recipe.ID,
-		&recipe.Title,
-		&recipe.Description,
-		&recipe.UserID,
-		&recipe.CategoryID,
-		&recipe.CreatedAt,
-		&recipe.UpdatedAt,
-		&recipe.PublishedAt,
-		&recipe.Status,
-		&recipe.DifficultyLevel,
-		&recipe.ServingSize,
-		&recipe.PrepTime,
-		&recipe.CookTime,
-		&recipe.TotalTime,
-		&recipe.CategoryName,
-	)
-
+	err = scanRecipeRow(tx.QueryRow(recipeQuery, id), recipe, &recipe.MadeCount)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -216,7 +529,7 @@ func (s *PostgresRecipeStore) GetCompleteRecipe(id int64) (*CompleteRecipe, erro
 
 	// Commit the transaction
 	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", mapError(err))
 	}
 
 	return &CompleteRecipe{
@@ -230,17 +543,66 @@ func (s *PostgresRecipeStore) GetCompleteRecipe(id int64) (*CompleteRecipe, erro
 }
 
 func (s *PostgresRecipeStore) CreateRecipe(recipe *Recipe) error {
+	return insertRecipe(s.db, recipe)
+}
+
+// CreateCompleteRecipe creates a recipe together with its ingredients and steps in a single transaction
+func (s *PostgresRecipeStore) CreateCompleteRecipe(recipe *Recipe, ingredients []*RecipeIngredient, steps []*RecipeStep) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertRecipe(tx, recipe); err != nil {
+		return err
+	}
+
+	for _, ingredient := range ingredients {
+		ingredient.RecipeID = recipe.ID
+		if err := insertRecipeIngredient(tx, ingredient); err != nil {
+			return err
+		}
+	}
+
+	for _, step := range steps {
+		step.RecipeID = recipe.ID
+		if err := insertRecipeStep(tx, step); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// queryRower is satisfied by both *sql.DB and *sql.Tx so inserts can run inside or outside a transaction
+type queryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func insertRecipe(q queryRower, recipe *Recipe) error {
 	query := `
         INSERT INTO recipes(
             title, description, user_id, category_id, 
             status, difficulty_level, serving_size, 
-            prep_time, cook_time, total_time
+            prep_time, cook_time, total_time, published_at,
+            dietary_labels, language
         ) 
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-        RETURNING id, created_at, updated_at
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12::JSONB, $13)
+        RETURNING id, created_at, updated_at, version, (SELECT username FROM users WHERE id = $3)
     `
 
-	err := s.db.QueryRow(
+	dietaryLabels, err := marshalDietaryLabels(recipe.DietaryLabels)
+	if err != nil {
+		return err
+	}
+	recipe.Language = detectRecipeLanguage(recipe)
+
+	err = q.QueryRow(
 		query,
 		recipe.Title,
 		recipe.Description,
@@ -252,14 +614,19 @@ func (s *PostgresRecipeStore) CreateRecipe(recipe *Recipe) error {
 		recipe.PrepTime,
 		recipe.CookTime,
 		recipe.TotalTime,
+		recipe.PublishedAt,
+		dietaryLabels,
+		recipe.Language,
 	).Scan(
 		&recipe.ID,
 		&recipe.CreatedAt,
 		&recipe.UpdatedAt,
+		&recipe.Version,
+		&recipe.AuthorUsername,
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to create recipe: %w", err)
+		return fmt.Errorf("failed to create recipe: %w", mapError(err))
 	}
 
 	return nil
@@ -267,33 +634,13 @@ func (s *PostgresRecipeStore) CreateRecipe(recipe *Recipe) error {
 
 func (s *PostgresRecipeStore) GetRecipeByID(id int64) (*Recipe, error) {
 	query := `
-		SELECT 
-			r.id, r.title, r.description, r.user_id, r.category_id,
-			r.created_at, r.updated_at, r.published_at, r.status, 
-			r.difficulty_level, r.serving_size, r.prep_time, r.cook_time, r.total_time,
-			c.name as category_name
+		SELECT ` + recipeSelectColumns + `
 		FROM recipes r
 		LEFT JOIN categories c ON r.category_id = c.id
 		WHERE r.id = $1
 	`
 	recipe := &Recipe{}
-	err := s.db.QueryRow(query, id).Scan(
-		&recipe.ID,
-		&recipe.Title,
-		&recipe.Description,
-		&recipe.UserID,
-		&recipe.CategoryID,
-		&recipe.CategoryName,
-		&recipe.CreatedAt,
-		&recipe.UpdatedAt,
-		&recipe.PublishedAt,
-		&recipe.Status,
-		&recipe.DifficultyLevel,
-		&recipe.ServingSize,
-		&recipe.PrepTime,
-		&recipe.CookTime,
-		&recipe.TotalTime,
-	)
+	err := scanRecipeRow(s.db.QueryRow(query, id), recipe)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -305,19 +652,19 @@ func (s *PostgresRecipeStore) GetRecipeByID(id int64) (*Recipe, error) {
 	return recipe, nil
 }
 
-func (s *PostgresRecipeStore) GetRecipesByUserID(userID int64) ([]*Recipe, error) {
+// GetRecipesByUserID returns up to limit of a user's recipes with IDs greater than afterID, in ID order, whatever their status
+// Callers page through an author's whole catalog by passing the last ID they got, starting from 0
+func (s *PostgresRecipeStore) GetRecipesByUserID(userID int64, afterID int64, limit int) ([]*Recipe, error) {
 	query := `
-		SELECT 
-			r.id, r.title, r.description, r.user_id, r.category_id,
-			r.created_at, r.updated_at, r.published_at, r.status, 
-			r.difficulty_level, r.serving_size, r.prep_time, r.cook_time, r.total_time,
-			c.name as category_name
+		SELECT ` + recipeSelectColumns + `
 		FROM recipes r
 		LEFT JOIN categories c ON r.category_id = c.id
-		WHERE r.user_id = $1
+		WHERE r.user_id = $1 AND r.id > $2
+		ORDER BY r.id
+		LIMIT $3
 	`
 
-	rows, err := s.db.Query(query, userID)
+	rows, err := s.db.Query(query, userID, afterID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recipes by user ID: %w", err)
 	}
@@ -326,28 +673,360 @@ func (s *PostgresRecipeStore) GetRecipesByUserID(userID int64) ([]*Recipe, error
 	var recipes []*Recipe
 	for rows.Next() {
 		recipe := &Recipe{}
-		err := rows.Scan(
-			&recipe.ID,
-			&recipe.Title,
-			&recipe.Description,
-			&recipe.UserID,
-			&recipe.CategoryID,
-			&recipe.CategoryName,
-			&recipe.CreatedAt,
-			&recipe.UpdatedAt,
-			&recipe.PublishedAt,
-			&recipe.Status,
-			&recipe.DifficultyLevel,
-			&recipe.ServingSize,
-			&recipe.PrepTime,
-			&recipe.CookTime,
-			&recipe.TotalTime,
-		)
+		if err := scanRecipeRow(rows, recipe); err != nil {
+			return nil, fmt.Errorf("failed to scan recipe: %w", err)
+		}
+
+		recipes = append(recipes, recipe)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over recipes: %w", err)
+	}
+
+	return recipes, nil
+}
+
+// recipeListQuery collects the WHERE conditions and bind arguments for a filtered recipe listing
+type recipeListQuery struct {
+	conditions []string
+	args       []interface{}
+}
+
+// newRecipeListQuery translates listing filters into conditions on recipeListFrom
+func newRecipeListQuery(opts RecipeListOptions) *recipeListQuery {
+	q := &recipeListQuery{}
+
+	if opts.AuthorID != nil {
+		q.where("r.user_id = " + q.addArg(*opts.AuthorID))
+		if opts.Status != nil {
+			q.where("r.status = " + q.addArg(*opts.Status))
+		}
+	} else {
+		q.where("r.status = 'published'")
+	}
+
+	if opts.FavoritedBy != nil {
+		q.where("EXISTS (SELECT 1 FROM likes l WHERE l.recipe_id = r.id AND l.user_id = " + q.addArg(*opts.FavoritedBy) + ")")
+	}
+	if opts.CategoryID != nil {
+		q.where("r.category_id = " + q.addArg(*opts.CategoryID))
+	}
+	if opts.Difficulty != nil {
+		q.where("r.difficulty_level = " + q.addArg(*opts.Difficulty))
+	}
+	if opts.MaxCost != nil {
+		q.where(costPerServingExpr + " <= " + q.addArg(*opts.MaxCost))
+	}
+	if opts.MaxTotalTime != nil {
+		q.where("r.total_time <= " + q.addArg(*opts.MaxTotalTime))
+	}
+	if opts.MinServingSize != nil {
+		q.where("r.serving_size >= " + q.addArg(*opts.MinServingSize))
+	}
+	if opts.MaxServingSize != nil {
+		q.where("r.serving_size <= " + q.addArg(*opts.MaxServingSize))
+	}
+	if len(opts.Diets) > 0 {
+		// Marshalling a []string cannot fail
+		diets, _ := json.Marshal(opts.Diets)
+		q.where("r.dietary_labels @> " + q.addArg(string(diets)) + "::JSONB")
+	}
+	if opts.MinRating != nil {
+		// Unreviewed recipes have no average and are excluded
+		q.where(averageRatingExpr + " >= " + q.addArg(*opts.MinRating))
+	}
+	if opts.Query != "" {
+		// The title match finds recipes by a word still being typed, which full-text search misses
+		query := q.addArg(opts.Query)
+		q.where("(" + recipeSearchDocument + " @@ websearch_to_tsquery('english', " + query + ") OR r.title ILIKE '%' || " + query + " || '%')")
+	}
+	for _, tag := range opts.Tags {
+		q.where("EXISTS (SELECT 1 FROM recipe_tags rt JOIN tags t ON t.id = rt.tag_id WHERE rt.recipe_id = r.id AND LOWER(t.name) = LOWER(" + q.addArg(tag) + "))")
+	}
+	if opts.Language != "" {
+		q.where("r.language = " + q.addArg(opts.Language))
+	}
+
+	return q
+}
+
+// addArg appends a bind argument and returns its placeholder
+func (q *recipeListQuery) addArg(value interface{}) string {
+	q.args = append(q.args, value)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+func (q *recipeListQuery) where(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+func (q *recipeListQuery) whereClause() string {
+	return strings.Join(q.conditions, " AND ")
+}
+
+// recipeListColumns are read by scanRecipeListRow
+const recipeListColumns = recipeSelectColumns + `,
+	` + costPerServingExpr + ` AS cost_per_serving,
+	summary.made_count, summary.average_rating, summary.review_count, summary.favorite_count,
+	author.username, author.profile_picture,
+	photo.id, COALESCE(photo.photo_url, ''), photo.is_primary, photo.created_at, photo.storage_key, photo.position`
+
+// recipeListFrom joins each recipe (r) with its category (c), estimated cost, author, and summary
+// The summary's primary photo is the one marked primary, or else the first one in the gallery
+const recipeListFrom = `
+	FROM recipes r
+	JOIN users author ON author.id = r.user_id
+	LEFT JOIN categories c ON r.category_id = c.id
+	LEFT JOIN LATERAL (
+		SELECT SUM(ri.quantity * i.price_per_unit) FILTER (WHERE ` + pricedIngredientCondition + `) AS total_cost
+		FROM recipe_ingredients ri
+		JOIN ingredients i ON i.id = ri.ingredient_id
+		WHERE ri.recipe_id = r.id
+	) cost ON TRUE
+	LEFT JOIN recipe_summaries summary ON summary.recipe_id = r.id
+	LEFT JOIN recipe_photos photo ON photo.id = summary.primary_photo_id`
+
+// scanRecipeListRow scans the recipeListColumns into recipe, followed by any extra columns
+func scanRecipeListRow(row rowScanner, recipe *Recipe, extra ...interface{}) error {
+	author := &RecipeAuthor{}
+	var (
+		photoID         sql.NullInt64
+		photoURL        sql.NullString
+		photoIsPrimary  sql.NullBool
+		photoCreatedAt  sql.NullTime
+		photoStorageKey *string
+		photoPosition   sql.NullInt64
+	)
+
+	dest := []interface{}{
+		&recipe.EstimatedCostPerServing,
+		&recipe.MadeCount,
+		&recipe.AverageRating,
+		&recipe.ReviewCount,
+		&recipe.FavoriteCount,
+		&author.Username,
+		&author.ProfilePicture,
+		&photoID,
+		&photoURL,
+		&photoIsPrimary,
+		&photoCreatedAt,
+		&photoStorageKey,
+		&photoPosition,
+	}
+	if err := scanRecipeRow(row, recipe, append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	recipe.Author = author
+	if photoID.Valid {
+		recipe.PrimaryPhoto = &RecipePhoto{
+			ID:         photoID.Int64,
+			RecipeID:   recipe.ID,
+			PhotoURL:   photoURL.String,
+			IsPrimary:  photoIsPrimary.Bool,
+			CreatedAt:  photoCreatedAt.Time,
+			StorageKey: photoStorageKey,
+			Position:   int(photoPosition.Int64),
+		}
+	}
+	return nil
+}
+
+// GetRecipes returns a page of recipes matching the options, along with the total match count
+// Only published recipes are listed unless opts.AuthorID is set
+func (s *PostgresRecipeStore) GetRecipes(ctx context.Context, opts RecipeListOptions) ([]*Recipe, int, error) {
+	q := newRecipeListQuery(opts)
+
+	order, ok := recipeSortOrders[opts.Sort]
+	if !ok {
+		order = recipeSortOrders[SortNewest]
+	}
+
+	query := `
+		SELECT ` + recipeListColumns + `,
+			COUNT(*) OVER() AS total_count
+		` + recipeListFrom + `
+		WHERE ` + q.whereClause() + `
+		ORDER BY ` + order + `
+		LIMIT ` + q.addArg(opts.Limit) + ` OFFSET ` + q.addArg((opts.Page-1)*opts.Limit)
+
+	rows, err := s.db.QueryContext(ctx, query, q.args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get recipes: %w", err)
+	}
+	defer rows.Close()
+
+	recipes := []*Recipe{}
+	total := 0
+	for rows.Next() {
+		recipe := &Recipe{}
+		if err := scanRecipeListRow(rows, recipe, &total); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan recipe: %w", err)
+		}
+
+		recipes = append(recipes, recipe)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over recipes: %w", err)
+	}
 
+	// A page past the end has no rows to carry the window count, so the matches are counted separately
+	if len(recipes) == 0 && opts.Page > 1 {
+		countQuery := newRecipeListQuery(opts)
+		err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) `+recipeListFrom+` WHERE `+countQuery.whereClause(), countQuery.args...).Scan(&total)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan recipe: %w", err)
+			return nil, 0, fmt.Errorf("failed to count recipes: %w", err)
 		}
+	}
+
+	return recipes, total, nil
+}
 
+// GetRecipeFacets counts the recipes GetRecipes would list for each difficulty, category, and total time limit
+func (s *PostgresRecipeStore) GetRecipeFacets(ctx context.Context, opts RecipeListOptions) (*RecipeFacets, error) {
+	return getRecipeFacets(ctx, s.db, opts, nil)
+}
+
+// EachRecipe calls fn with every recipe matching the options, in sort order, as rows are read
+// Pagination is ignored, but a positive opts.Limit caps the number of recipes. Iteration stops at the first error from fn,
+// which is returned as is.
+func (s *PostgresRecipeStore) EachRecipe(ctx context.Context, opts RecipeListOptions, fn func(*Recipe) error) error {
+	q := newRecipeListQuery(opts)
+
+	order, ok := recipeSortOrders[opts.Sort]
+	if !ok {
+		order = recipeSortOrders[SortNewest]
+	}
+
+	query := `
+		SELECT ` + recipeListColumns + `
+		` + recipeListFrom + `
+		WHERE ` + q.whereClause() + `
+		ORDER BY ` + order
+	if opts.Limit > 0 {
+		query += `
+		LIMIT ` + q.addArg(opts.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, q.args...)
+	if err != nil {
+		return fmt.Errorf("failed to get recipes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		recipe := &Recipe{}
+		if err := scanRecipeListRow(rows, recipe); err != nil {
+			return fmt.Errorf("failed to scan recipe: %w", err)
+		}
+		if err := fn(recipe); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over recipes: %w", err)
+	}
+
+	return nil
+}
+
+// GetRandomRecipe returns a random published recipe matching the options
+// Rather than sorting the whole table by random(), it picks a random ID within the published range
+// and takes the first match at or after it, wrapping around to the start if needed, so each lookup
+// is an index range scan. Recipes that follow gaps in the ID sequence are slightly more likely to be picked.
+// Returns nil if no recipe matches
+func (s *PostgresRecipeStore) GetRandomRecipe(ctx context.Context, opts RecipeListOptions) (*Recipe, error) {
+	var minID, maxID sql.NullInt64
+	err := s.db.QueryRowContext(ctx, `SELECT MIN(id), MAX(id) FROM recipes WHERE status = 'published'`).Scan(&minID, &maxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipe ID range: %w", err)
+	}
+
+	if !minID.Valid {
+		return nil, nil
+	}
+
+	pivot := minID.Int64 + rand.Int63n(maxID.Int64-minID.Int64+1)
+
+	for _, comparison := range []string{">=", "<"} {
+		q := newRecipeListQuery(opts)
+		q.where("r.id " + comparison + " " + q.addArg(pivot))
+
+		query := `
+			SELECT ` + recipeListColumns + `
+			` + recipeListFrom + `
+			WHERE ` + q.whereClause() + `
+			ORDER BY r.id
+			LIMIT 1`
+
+		recipe := &Recipe{}
+		err := scanRecipeListRow(s.db.QueryRowContext(ctx, query, q.args...), recipe)
+		if err == nil {
+			return recipe, nil
+		}
+		if err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to get random recipe: %w", err)
+		}
+	}
+
+	return nil, nil
+}
+
+// GetRecommendedRecipes returns published recipes suited to a user's preferences
+// Recipes are limited to difficulties matching the user's skill level, must carry every one of the user's
+// dietary restrictions as a label, and exclude the user's own recipes and those they have already cooked. Recipes in a category named after a preferred cuisine rank first,
+// then better-rated and newer recipes
+func (s *PostgresRecipeStore) GetRecommendedRecipes(userID int64, prefs *UserPreferences, limit int) ([]*Recipe, error) {
+	q := newRecipeListQuery(RecipeListOptions{Diets: prefs.DietaryRestrictions})
+
+	skillLevel := ""
+	if prefs.SkillLevel != nil {
+		skillLevel = *prefs.SkillLevel
+	}
+	difficulties := []string{}
+	for _, difficulty := range DifficultiesForSkill(skillLevel) {
+		difficulties = append(difficulties, q.addArg(difficulty))
+	}
+	q.where("r.difficulty_level IN (" + strings.Join(difficulties, ", ") + ")")
+
+	userArg := q.addArg(userID)
+	q.where("r.user_id <> " + userArg)
+	q.where("NOT EXISTS (SELECT 1 FROM recipe_cooks rc WHERE rc.recipe_id = r.id AND rc.user_id = " + userArg + ")")
+
+	cuisineMatch := "FALSE"
+	if len(prefs.Cuisines) > 0 {
+		cuisines := make([]string, len(prefs.Cuisines))
+		for i, cuisine := range prefs.Cuisines {
+			cuisines[i] = q.addArg(cuisine)
+		}
+		cuisineMatch = "LOWER(c.name) IN (" + strings.Join(cuisines, ", ") + ")"
+	}
+
+	query := `
+		SELECT ` + recipeListColumns + `
+		` + recipeListFrom + `
+		WHERE ` + q.whereClause() + `
+		ORDER BY
+			COALESCE(` + cuisineMatch + `, FALSE) DESC,
+			` + averageRatingExpr + ` DESC NULLS LAST,
+			r.published_at DESC
+		LIMIT ` + q.addArg(limit)
+
+	rows, err := s.db.Query(query, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommended recipes: %w", err)
+	}
+	defer rows.Close()
+
+	recipes := []*Recipe{}
+	for rows.Next() {
+		recipe := &Recipe{}
+		if err := scanRecipeListRow(rows, recipe); err != nil {
+			return nil, fmt.Errorf("failed to scan recipe: %w", err)
+		}
 		recipes = append(recipes, recipe)
 	}
 
@@ -358,6 +1037,87 @@ func (s *PostgresRecipeStore) GetRecipesByUserID(userID int64) ([]*Recipe, error
 	return recipes, nil
 }
 
+// recipeSelectColumns lists the recipes (r) and categories (c) columns read by scanRecipeRow, in order
+// along with whether the author is a verified chef and their username
+const recipeSelectColumns = `
+	r.id, r.title, r.description, r.user_id, r.category_id,
+	r.created_at, r.updated_at, r.version, r.published_at, r.status, r.archived_at,
+	r.difficulty_level, r.serving_size, r.prep_time, r.cook_time, r.total_time,
+	c.name AS category_name, r.dietary_labels, r.language,
+	(SELECT au.is_verified_chef FROM users au WHERE au.id = r.user_id) AS author_verified,
+	(SELECT au.username FROM users au WHERE au.id = r.user_id) AS author_username`
+
+// scanRecipeRow scans the recipeSelectColumns into recipe, followed by any extra columns
+func scanRecipeRow(row rowScanner, recipe *Recipe, extra ...interface{}) error {
+	var dietaryLabels []byte
+	dest := []interface{}{
+		&recipe.ID,
+		&recipe.Title,
+		&recipe.Description,
+		&recipe.UserID,
+		&recipe.CategoryID,
+		&recipe.CreatedAt,
+		&recipe.UpdatedAt,
+		&recipe.Version,
+		&recipe.PublishedAt,
+		&recipe.Status,
+		&recipe.ArchivedAt,
+		&recipe.DifficultyLevel,
+		&recipe.ServingSize,
+		&recipe.PrepTime,
+		&recipe.CookTime,
+		&recipe.TotalTime,
+		&recipe.CategoryName,
+		&dietaryLabels,
+		&recipe.Language,
+		&recipe.AuthorVerified,
+		&recipe.AuthorUsername,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(dietaryLabels, &recipe.DietaryLabels); err != nil {
+		return fmt.Errorf("failed to decode dietary labels: %w", err)
+	}
+
+	return nil
+}
+
+// marshalDietaryLabels encodes dietary labels for the dietary_labels JSONB column
+func marshalDietaryLabels(labels []string) (string, error) {
+	if labels == nil {
+		labels = []string{}
+	}
+
+	encoded, err := json.Marshal(labels)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode dietary labels: %w", err)
+	}
+
+	return string(encoded), nil
+}
+
+// detectRecipeLanguage detects the language of a recipe from the text full-text search matches
+func detectRecipeLanguage(recipe *Recipe) *string {
+	language := utils.DetectLanguage(recipe.Title + "\n" + recipe.Description)
+	if language == "" {
+		return nil
+	}
+	return &language
+}
+
+// costPerServingExpr computes a recipe's estimated cost per serving from the lateral cost join
+// It is NULL when no ingredient is priced or the serving size is unknown
+const costPerServingExpr = `(cost.total_cost / NULLIF(r.serving_size, 0))::FLOAT8`
+
+// averageRatingExpr is a recipe's average rating from the summary joined by recipeListFrom, NULL without reviews
+// Reviews by the recipe's author are left out, including any written before self-reviews were rejected
+const averageRatingExpr = `summary.average_rating`
+
+// UpdateRecipe saves a recipe loaded at recipe.Version and sets recipe.Version to the new version
+// Returns ErrVersionMismatch if the recipe was changed since it was loaded, and ErrNotFound if it no longer exists
 func (s *PostgresRecipeStore) UpdateRecipe(recipe *Recipe) error {
 	query := `
 		UPDATE recipes
@@ -371,11 +1131,23 @@ func (s *PostgresRecipeStore) UpdateRecipe(recipe *Recipe) error {
 			prep_time = $7, 
 			cook_time = $8, 
 			total_time = $9,
-			updated_at = NOW()
-		WHERE id = $10
+			dietary_labels = $10::JSONB,
+			published_at = $11,
+			language = $14,
+			archived_at = CASE WHEN $4 = 'archived' THEN COALESCE(archived_at, NOW()) END,
+			updated_at = NOW(),
+			version = version + 1
+		WHERE id = $12 AND version = $13
+		RETURNING version
 	`
 
-	result, err := s.db.Exec(
+	dietaryLabels, err := marshalDietaryLabels(recipe.DietaryLabels)
+	if err != nil {
+		return err
+	}
+	recipe.Language = detectRecipeLanguage(recipe)
+
+	err = s.db.QueryRow(
 		query,
 		recipe.Title,
 		recipe.Description,
@@ -386,11 +1158,41 @@ func (s *PostgresRecipeStore) UpdateRecipe(recipe *Recipe) error {
 		recipe.PrepTime,
 		recipe.CookTime,
 		recipe.TotalTime,
+		dietaryLabels,
+		recipe.PublishedAt,
 		recipe.ID,
-	)
+		recipe.Version,
+		recipe.Language,
+	).Scan(&recipe.Version)
+
+	if err == sql.ErrNoRows {
+		var exists bool
+		if err := s.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM recipes WHERE id = $1)`, recipe.ID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check recipe: %w", err)
+		}
+		if exists {
+			return ErrVersionMismatch
+		}
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update recipe: %w", mapError(err))
+	}
 
+	return nil
+}
+
+// SetRecipeDietaryLabels replaces the dietary labels of a recipe
+// Returns ErrNotFound if the recipe does not exist
+func (s *PostgresRecipeStore) SetRecipeDietaryLabels(recipeID int64, labels []string) error {
+	dietaryLabels, err := marshalDietaryLabels(labels)
 	if err != nil {
-		return fmt.Errorf("failed to update recipe: %w", err)
+		return err
+	}
+
+	result, err := s.db.Exec(`UPDATE recipes SET dietary_labels = $1::JSONB, updated_at = NOW(), version = version + 1 WHERE id = $2`, dietaryLabels, recipeID)
+	if err != nil {
+		return fmt.Errorf("failed to set recipe dietary labels: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -399,11 +1201,12 @@ func (s *PostgresRecipeStore) UpdateRecipe(recipe *Recipe) error {
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return ErrNotFound
 	}
 
 	return nil
 }
+
 func (s *PostgresRecipeStore) DeleteRecipe(id int64) error {
 	query := `
 		DELETE FROM recipes
@@ -412,7 +1215,7 @@ func (s *PostgresRecipeStore) DeleteRecipe(id int64) error {
 
 	result, err := s.db.Exec(query, id)
 	if err != nil {
-		return fmt.Errorf("failed to delete recipe: %w", err)
+		return fmt.Errorf("failed to delete recipe: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -421,17 +1224,21 @@ func (s *PostgresRecipeStore) DeleteRecipe(id int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return ErrNotFound
 	}
 
 	return nil
 }
 
+// AddRecipePhoto adds a photo to the end of its recipe's gallery
 func (s *PostgresRecipeStore) AddRecipePhoto(photo *RecipePhoto) error {
 	query := `
-		INSERT INTO recipe_photos (recipe_id, photo_url, is_primary)
-		VALUES ($1, $2, $3)
-		RETURNING id, created_at
+		INSERT INTO recipe_photos (recipe_id, photo_url, is_primary, storage_key, import_status, position)
+		VALUES (
+			$1, NULLIF($2, ''), $3, $4, CASE WHEN $4::VARCHAR IS NULL THEN 'pending' END,
+			(SELECT COALESCE(MAX(position), 0) + 1 FROM recipe_photos WHERE recipe_id = $1)
+		)
+		RETURNING id, created_at, position
 	`
 
 	err := s.db.QueryRow(
@@ -439,20 +1246,23 @@ func (s *PostgresRecipeStore) AddRecipePhoto(photo *RecipePhoto) error {
 		photo.RecipeID,
 		photo.PhotoURL,
 		photo.IsPrimary,
-	).Scan(&photo.ID, &photo.CreatedAt)
+		photo.StorageKey,
+	).Scan(&photo.ID, &photo.CreatedAt, &photo.Position)
 
 	if err != nil {
-		return fmt.Errorf("failed to add recipe photo: %w", err)
+		return fmt.Errorf("failed to add recipe photo: %w", mapError(err))
 	}
 
 	return nil
 }
 
+// GetRecipePhotos returns a recipe's photos in gallery order
 func (s *PostgresRecipeStore) GetRecipePhotos(recipeID int64) ([]*RecipePhoto, error) {
 	query := `
-		SELECT id, recipe_id, photo_url, is_primary, created_at
+		SELECT id, recipe_id, COALESCE(photo_url, ''), is_primary, created_at, storage_key, position
 		FROM recipe_photos
 		WHERE recipe_id = $1
+		ORDER BY position, id
 	`
 
 	rows, err := s.db.Query(query, recipeID)
@@ -464,7 +1274,7 @@ func (s *PostgresRecipeStore) GetRecipePhotos(recipeID int64) ([]*RecipePhoto, e
 	var photos []*RecipePhoto
 	for rows.Next() {
 		photo := &RecipePhoto{}
-		err := rows.Scan(&photo.ID, &photo.RecipeID, &photo.PhotoURL, &photo.IsPrimary, &photo.CreatedAt)
+		err := rows.Scan(&photo.ID, &photo.RecipeID, &photo.PhotoURL, &photo.IsPrimary, &photo.CreatedAt, &photo.StorageKey, &photo.Position)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan recipe photo: %w", err)
 		}
@@ -477,6 +1287,28 @@ func (s *PostgresRecipeStore) GetRecipePhotos(recipeID int64) ([]*RecipePhoto, e
 
 	return photos, nil
 }
+
+// GetRecipePhotoByID returns a single photo
+// Returns nil if the photo does not exist
+func (s *PostgresRecipeStore) GetRecipePhotoByID(photoID int64) (*RecipePhoto, error) {
+	query := `
+		SELECT id, recipe_id, COALESCE(photo_url, ''), is_primary, created_at, storage_key, position
+		FROM recipe_photos
+		WHERE id = $1
+	`
+
+	photo := &RecipePhoto{}
+	err := s.db.QueryRow(query, photoID).Scan(&photo.ID, &photo.RecipeID, &photo.PhotoURL, &photo.IsPrimary, &photo.CreatedAt, &photo.StorageKey, &photo.Position)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get recipe photo: %w", err)
+	}
+
+	return photo, nil
+}
+
 func (s *PostgresRecipeStore) SetPrimaryPhoto(photoID int64, recipeID int64) error {
 	query := `
 		UPDATE recipe_photos
@@ -486,7 +1318,97 @@ func (s *PostgresRecipeStore) SetPrimaryPhoto(photoID int64, recipeID int64) err
 
 	result, err := s.db.Exec(query, photoID, recipeID)
 	if err != nil {
-		return fmt.Errorf("failed to set primary photo: %w", err)
+		return fmt.Errorf("failed to set primary photo: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ReplaceRecipePhotoFile points one of a recipe's photos at a newly uploaded file, keeping its ID and primary status
+// A photo added by external link becomes an uploaded one. Returns ErrNotFound if the photo is not the recipe's.
+func (s *PostgresRecipeStore) ReplaceRecipePhotoFile(photoID int64, recipeID int64, storageKey string) error {
+	query := `
+		UPDATE recipe_photos
+		SET storage_key = $3, photo_url = NULL, import_status = NULL, import_retry_at = NULL, import_error = NULL
+		WHERE id = $1 AND recipe_id = $2
+	`
+
+	result, err := s.db.Exec(query, photoID, recipeID, storageKey)
+	if err != nil {
+		return fmt.Errorf("failed to replace recipe photo: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ClaimPhotoImports returns up to limit photos waiting to be imported whose retry time has come, oldest first,
+// counting an attempt for each and holding them for lease so other instances skip them meanwhile
+func (s *PostgresRecipeStore) ClaimPhotoImports(limit int, lease time.Duration) ([]*PhotoImport, error) {
+	query := `
+		UPDATE recipe_photos
+		SET import_attempts = import_attempts + 1, import_retry_at = NOW() + $2 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM recipe_photos
+			WHERE import_status = 'pending' AND (import_retry_at IS NULL OR import_retry_at <= NOW())
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, recipe_id, photo_url, import_attempts
+	`
+
+	rows, err := s.db.Query(query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim photo imports: %w", err)
+	}
+	defer rows.Close()
+
+	imports := []*PhotoImport{}
+	for rows.Next() {
+		photoImport := &PhotoImport{}
+		if err := rows.Scan(&photoImport.PhotoID, &photoImport.RecipeID, &photoImport.SourceURL, &photoImport.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan photo import: %w", err)
+		}
+		imports = append(imports, photoImport)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over photo imports: %w", err)
+	}
+
+	return imports, nil
+}
+
+// CompletePhotoImport points an imported photo at its copy in storage, making it an uploaded photo
+// Returns ErrNotFound if the photo was deleted, replaced or given another link in the meantime
+func (s *PostgresRecipeStore) CompletePhotoImport(photoID int64, sourceURL string, storageKey string) error {
+	query := `
+		UPDATE recipe_photos
+		SET storage_key = $3, photo_url = NULL, import_status = NULL, import_retry_at = NULL, import_error = NULL
+		WHERE id = $1 AND photo_url = $2 AND storage_key IS NULL
+	`
+
+	result, err := s.db.Exec(query, photoID, sourceURL, storageKey)
+	if err != nil {
+		return fmt.Errorf("failed to complete photo import: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -495,11 +1417,29 @@ func (s *PostgresRecipeStore) SetPrimaryPhoto(photoID int64, recipeID int64) err
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// FailPhotoImport records why importing a photo failed and schedules the next attempt at retryAt
+// A nil retryAt gives up, leaving the photo on its external link
+func (s *PostgresRecipeStore) FailPhotoImport(photoID int64, message string, retryAt *time.Time) error {
+	query := `
+		UPDATE recipe_photos
+		SET import_status = CASE WHEN $3::TIMESTAMPTZ IS NULL THEN 'failed' ELSE 'pending' END,
+			import_retry_at = $3, import_error = $2
+		WHERE id = $1 AND import_status = 'pending'
+	`
+
+	if _, err := s.db.Exec(query, photoID, message, retryAt); err != nil {
+		return fmt.Errorf("failed to record photo import failure: %w", mapError(err))
 	}
 
 	return nil
 }
+
 func (s *PostgresRecipeStore) DeleteRecipePhoto(photoID int64) error {
 	query := `
 		DELETE FROM recipe_photos
@@ -508,7 +1448,7 @@ func (s *PostgresRecipeStore) DeleteRecipePhoto(photoID int64) error {
 
 	result, err := s.db.Exec(query, photoID)
 	if err != nil {
-		return fmt.Errorf("failed to delete recipe photo: %w", err)
+		return fmt.Errorf("failed to delete recipe photo: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -517,20 +1457,32 @@ func (s *PostgresRecipeStore) DeleteRecipePhoto(photoID int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return ErrNotFound
 	}
 
 	return nil
 }
 
 func (s *PostgresRecipeStore) AddRecipeIngredient(ingredient *RecipeIngredient) error {
+	return insertRecipeIngredient(s.db, ingredient)
+}
+
+func insertRecipeIngredient(q queryRower, ingredient *RecipeIngredient) error {
+	// Link the ingredient to its canonical catalog entry, creating it on first use
 	query := `
-		INSERT INTO recipe_ingredients (recipe_id, name, image, quantity, unit, position)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id
+		WITH canonical AS (
+			INSERT INTO ingredients (name)
+			VALUES (LOWER(TRIM($2)))
+			ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id
+		)
+		INSERT INTO recipe_ingredients (recipe_id, ingredient_id, name, image, quantity, unit, position)
+		SELECT $1, canonical.id, $2, $3, $4, $5, $6
+		FROM canonical
+		RETURNING id, ingredient_id
 	`
 
-	err := s.db.QueryRow(
+	err := q.QueryRow(
 		query,
 		ingredient.RecipeID,
 		ingredient.Name,
@@ -538,17 +1490,18 @@ func (s *PostgresRecipeStore) AddRecipeIngredient(ingredient *RecipeIngredient)
 		ingredient.Quantity,
 		ingredient.Unit,
 		ingredient.Position,
-	).Scan(&ingredient.ID)
+	).Scan(&ingredient.ID, &ingredient.IngredientID)
 
 	if err != nil {
-		return fmt.Errorf("failed to add recipe ingredient: %w", err)
+		return fmt.Errorf("failed to add recipe ingredient: %w", mapError(err))
 	}
 
 	return nil
 }
+
 func (s *PostgresRecipeStore) GetRecipeIngredients(recipeID int64) ([]*RecipeIngredient, error) {
 	query := `
-		SELECT id, recipe_id, name, image, quantity, unit, position
+		SELECT id, recipe_id, ingredient_id, name, image, quantity, unit, position
 		FROM recipe_ingredients
 		WHERE recipe_id = $1
 		ORDER BY position
@@ -563,7 +1516,7 @@ func (s *PostgresRecipeStore) GetRecipeIngredients(recipeID int64) ([]*RecipeIng
 	var ingredients []*RecipeIngredient
 	for rows.Next() {
 		ingredient := &RecipeIngredient{}
-		err := rows.Scan(&ingredient.ID, &ingredient.RecipeID, &ingredient.Name, &ingredient.Image, &ingredient.Quantity, &ingredient.Unit, &ingredient.Position)
+		err := rows.Scan(&ingredient.ID, &ingredient.RecipeID, &ingredient.IngredientID, &ingredient.Name, &ingredient.Image, &ingredient.Quantity, &ingredient.Unit, &ingredient.Position)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan recipe ingredient: %w", err)
 		}
@@ -578,18 +1531,28 @@ func (s *PostgresRecipeStore) GetRecipeIngredients(recipeID int64) ([]*RecipeIng
 }
 
 func (s *PostgresRecipeStore) UpdateRecipeIngredient(ingredient *RecipeIngredient) error {
+	// The catalog entry is only added when the row being updated exists, so a missing ingredient leaves nothing behind
 	query := `
+		WITH canonical AS (
+			INSERT INTO ingredients (name)
+			SELECT LOWER(TRIM($1))
+			WHERE EXISTS (SELECT 1 FROM recipe_ingredients WHERE id = $6 AND recipe_id = $7)
+			ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id
+		)
 		UPDATE recipe_ingredients
 		SET 
 			name = $1, 
+			ingredient_id = (SELECT id FROM canonical),
 			image = $2, 
 			quantity = $3, 
 			unit = $4, 
 			position = $5
 		WHERE id = $6 AND recipe_id = $7
+		RETURNING ingredient_id
 	`
 
-	result, err := s.db.Exec(
+	err := s.db.QueryRow(
 		query,
 		ingredient.Name,
 		ingredient.Image,
@@ -598,19 +1561,13 @@ func (s *PostgresRecipeStore) UpdateRecipeIngredient(ingredient *RecipeIngredien
 		ingredient.Position,
 		ingredient.ID,
 		ingredient.RecipeID,
-	)
+	).Scan(&ingredient.IngredientID)
 
 	if err != nil {
-		return fmt.Errorf("failed to update recipe ingredient: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update recipe ingredient: %w", mapError(err))
 	}
 
 	return nil
@@ -623,7 +1580,7 @@ func (s *PostgresRecipeStore) DeleteRecipeIngredient(ingredientID int64) error {
 
 	result, err := s.db.Exec(query, ingredientID)
 	if err != nil {
-		return fmt.Errorf("failed to delete recipe ingredient: %w", err)
+		return fmt.Errorf("failed to delete recipe ingredient: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -632,35 +1589,46 @@ func (s *PostgresRecipeStore) DeleteRecipeIngredient(ingredientID int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return ErrNotFound
 	}
 
 	return nil
 }
 func (s *PostgresRecipeStore) AddRecipeStep(step *RecipeStep) error {
+	return insertRecipeStep(s.db, step)
+}
+
+func insertRecipeStep(q queryRower, step *RecipeStep) error {
 	query := `
-		INSERT INTO recipe_steps (recipe_id, step_number, instruction, duration_in_minutes)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO recipe_steps (recipe_id, step_number, instruction, duration_in_minutes, timers)
+		VALUES ($1, $2, $3, $4, $5::JSONB)
 		RETURNING id
 	`
 
-	err := s.db.QueryRow(
+	timers, err := marshalStepTimers(step.Timers)
+	if err != nil {
+		return err
+	}
+
+	err = q.QueryRow(
 		query,
 		step.RecipeID,
 		step.StepNumber,
 		step.Instruction,
 		step.DurationInMinutes,
+		timers,
 	).Scan(&step.ID)
 
 	if err != nil {
-		return fmt.Errorf("failed to add recipe step: %w", err)
+		return fmt.Errorf("failed to add recipe step: %w", mapError(err))
 	}
 
 	return nil
 }
+
 func (s *PostgresRecipeStore) GetRecipeSteps(recipeID int64) ([]*RecipeStep, error) {
 	query := `
-		SELECT id, recipe_id, step_number, instruction, duration_in_minutes
+		SELECT id, recipe_id, step_number, instruction, duration_in_minutes, timers
 		FROM recipe_steps
 		WHERE recipe_id = $1
 		ORDER BY step_number
@@ -675,10 +1643,14 @@ func (s *PostgresRecipeStore) GetRecipeSteps(recipeID int64) ([]*RecipeStep, err
 	var steps []*RecipeStep
 	for rows.Next() {
 		step := &RecipeStep{}
-		err := rows.Scan(&step.ID, &step.RecipeID, &step.StepNumber, &step.Instruction, &step.DurationInMinutes)
+		var timers []byte
+		err := rows.Scan(&step.ID, &step.RecipeID, &step.StepNumber, &step.Instruction, &step.DurationInMinutes, &timers)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan recipe step: %w", err)
 		}
+		if err := json.Unmarshal(timers, &step.Timers); err != nil {
+			return nil, fmt.Errorf("failed to decode step timers: %w", err)
+		}
 		steps = append(steps, step)
 	}
 
@@ -694,21 +1666,28 @@ func (s *PostgresRecipeStore) UpdateRecipeStep(step *RecipeStep) error {
 		SET 
 			step_number = $1, 
 			instruction = $2, 
-			duration_in_minutes = $3
-		WHERE id = $4 AND recipe_id = $5
+			duration_in_minutes = $3,
+			timers = $4::JSONB
+		WHERE id = $5 AND recipe_id = $6
 	`
 
+	timers, err := marshalStepTimers(step.Timers)
+	if err != nil {
+		return err
+	}
+
 	result, err := s.db.Exec(
 		query,
 		step.StepNumber,
 		step.Instruction,
 		step.DurationInMinutes,
+		timers,
 		step.ID,
 		step.RecipeID,
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update recipe step: %w", err)
+		return fmt.Errorf("failed to update recipe step: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -717,7 +1696,7 @@ func (s *PostgresRecipeStore) UpdateRecipeStep(step *RecipeStep) error {
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return ErrNotFound
 	}
 
 	return nil
@@ -730,7 +1709,7 @@ func (s *PostgresRecipeStore) DeleteRecipeStep(stepID int64) error {
 
 	result, err := s.db.Exec(query, stepID)
 	if err != nil {
-		return fmt.Errorf("failed to delete recipe step: %w", err)
+		return fmt.Errorf("failed to delete recipe step: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -739,11 +1718,83 @@ func (s *PostgresRecipeStore) DeleteRecipeStep(stepID int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return ErrNotFound
 	}
 
 	return nil
 }
+
+// ReorderRecipeSteps renumbers a recipe's steps to follow the given order in a single transaction
+// Returns ErrReorderMismatch unless stepIDs contains exactly the recipe's steps
+func (s *PostgresRecipeStore) ReorderRecipeSteps(recipeID int64, stepIDs []int64) error {
+	return s.reorderRecipeItems("recipe_steps", "step_number", recipeID, stepIDs)
+}
+
+// ReorderRecipePhotos rewrites the gallery positions of a recipe's photos to follow the given order in a single transaction
+// Returns ErrReorderMismatch unless photoIDs contains exactly the recipe's photos
+func (s *PostgresRecipeStore) ReorderRecipePhotos(recipeID int64, photoIDs []int64) error {
+	return s.reorderRecipeItems("recipe_photos", "position", recipeID, photoIDs)
+}
+
+// ReorderRecipeIngredients rewrites the positions of a recipe's ingredients to follow the given order in a single transaction
+// Returns ErrReorderMismatch unless ingredientIDs contains exactly the recipe's ingredients
+func (s *PostgresRecipeStore) ReorderRecipeIngredients(recipeID int64, ingredientIDs []int64) error {
+	return s.reorderRecipeItems("recipe_ingredients", "position", recipeID, ingredientIDs)
+}
+
+// reorderRecipeItems rewrites an ordering column of a recipe's child rows to match ids
+// The rows are locked first so concurrent edits cannot interleave with the renumbering
+func (s *PostgresRecipeStore) reorderRecipeItems(table, orderColumn string, recipeID int64, ids []int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT id FROM `+table+` WHERE recipe_id = $1 FOR UPDATE`, recipeID)
+	if err != nil {
+		return fmt.Errorf("failed to lock %s: %w", table, err)
+	}
+
+	existing := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan %s id: %w", table, err)
+		}
+		existing[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating over %s: %w", table, err)
+	}
+
+	if len(ids) != len(existing) {
+		return ErrReorderMismatch
+	}
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if !existing[id] || seen[id] {
+			return ErrReorderMismatch
+		}
+		seen[id] = true
+	}
+
+	query := `UPDATE ` + table + ` SET ` + orderColumn + ` = $1 WHERE id = $2 AND recipe_id = $3`
+	for i, id := range ids {
+		if _, err := tx.Exec(query, i+1, id, recipeID); err != nil {
+			return fmt.Errorf("failed to reorder %s: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", mapError(err))
+	}
+
+	return nil
+}
+
 func (s *PostgresRecipeStore) AddRecipeTag(recipeID int64, tagID int64) error {
 	query := `
 		INSERT INTO recipe_tags (recipe_id, tag_id)
@@ -752,7 +1803,7 @@ func (s *PostgresRecipeStore) AddRecipeTag(recipeID int64, tagID int64) error {
 
 	_, err := s.db.Exec(query, recipeID, tagID)
 	if err != nil {
-		return fmt.Errorf("failed to add recipe tag: %w", err)
+		return fmt.Errorf("failed to add recipe tag: %w", mapError(err))
 	}
 
 	return nil
@@ -765,7 +1816,7 @@ func (s *PostgresRecipeStore) RemoveRecipeTag(recipeID int64, tagID int64) error
 
 	result, err := s.db.Exec(query, recipeID, tagID)
 	if err != nil {
-		return fmt.Errorf("failed to remove recipe tag: %w", err)
+		return fmt.Errorf("failed to remove recipe tag: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -774,11 +1825,107 @@ func (s *PostgresRecipeStore) RemoveRecipeTag(recipeID int64, tagID int64) error
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return ErrNotFound
 	}
 
 	return nil
 }
+
+// SetRecipeTags replaces a recipe's tags with the named ones in a single transaction, creating tags that do not exist yet
+// Names match existing tags case-insensitively and should already be trimmed and free of duplicates.
+// Returns ErrNotFound if the recipe does not exist
+func (s *PostgresRecipeStore) SetRecipeTags(recipeID int64, names []string) (*RecipeTagsUpdate, error) {
+	update := &RecipeTagsUpdate{Tags: []*Tag{}, Added: []*Tag{}, Removed: []*Tag{}}
+
+	err := WithTx(context.Background(), s.db, func(tx *sql.Tx) error {
+		// Lock the recipe so concurrent replacements apply one after the other
+		var exists int
+		err := tx.QueryRow(`SELECT 1 FROM recipes WHERE id = $1 FOR UPDATE`, recipeID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock recipe: %w", mapError(err))
+		}
+
+		current, err := s.GetRecipeTagsTx(tx, recipeID)
+		if err != nil {
+			return err
+		}
+
+		wanted := make(map[int64]bool, len(names))
+		for _, name := range names {
+			tag, err := findOrCreateTagTx(tx, name)
+			if err != nil {
+				return err
+			}
+			if wanted[tag.ID] {
+				continue
+			}
+			wanted[tag.ID] = true
+			update.Tags = append(update.Tags, tag)
+		}
+
+		had := make(map[int64]bool, len(current))
+		for _, tag := range current {
+			had[tag.ID] = true
+			if wanted[tag.ID] {
+				continue
+			}
+			if _, err := tx.Exec(`DELETE FROM recipe_tags WHERE recipe_id = $1 AND tag_id = $2`, recipeID, tag.ID); err != nil {
+				return fmt.Errorf("failed to remove recipe tag: %w", mapError(err))
+			}
+			update.Removed = append(update.Removed, tag)
+		}
+
+		for _, tag := range update.Tags {
+			if had[tag.ID] {
+				continue
+			}
+			if _, err := tx.Exec(`INSERT INTO recipe_tags (recipe_id, tag_id) VALUES ($1, $2)`, recipeID, tag.ID); err != nil {
+				return fmt.Errorf("failed to add recipe tag: %w", mapError(err))
+			}
+			update.Added = append(update.Added, tag)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return update, nil
+}
+
+// findOrCreateTagTx returns the tag with the given name, ignoring case, creating it if there is none
+func findOrCreateTagTx(tx *sql.Tx, name string) (*Tag, error) {
+	query := `
+		WITH existing AS (
+			SELECT id, name FROM tags WHERE LOWER(name) = LOWER($1) ORDER BY id LIMIT 1
+		), created AS (
+			INSERT INTO tags (name)
+			SELECT $1 WHERE NOT EXISTS (SELECT 1 FROM existing)
+			ON CONFLICT (name) DO NOTHING
+			RETURNING id, name
+		)
+		SELECT id, name FROM existing
+		UNION ALL
+		SELECT id, name FROM created
+	`
+
+	tag := &Tag{}
+	err := tx.QueryRow(query, name).Scan(&tag.ID, &tag.Name)
+	if err == sql.ErrNoRows {
+		// Created by a concurrent transaction since this one started
+		err = tx.QueryRow(`SELECT id, name FROM tags WHERE name = $1`, name).Scan(&tag.ID, &tag.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find or create tag: %w", mapError(err))
+	}
+
+	return tag, nil
+}
+
 func (s *PostgresRecipeStore) GetRecipeTags(recipeID int64) ([]*Tag, error) {
 	query := `
 		SELECT t.id, t.name
@@ -809,10 +1956,37 @@ func (s *PostgresRecipeStore) GetRecipeTags(recipeID int64) ([]*Tag, error) {
 
 	return tags, nil
 }
-func (s *PostgresRecipeStore) GetAllCategories() ([]*Category, error) {
+
+// GetAllCategories returns every category by name with its number of published recipes.
+// With includePhotos, each category also carries a primary photo from its most recently published recipe that has one.
+func (s *PostgresRecipeStore) GetAllCategories(includePhotos bool) ([]*Category, error) {
+	photoColumns := ""
+	photoJoin := ""
+	if includePhotos {
+		photoColumns = `,
+			p.id, p.recipe_id, p.photo_url, p.is_primary, p.created_at, p.storage_key, p.position`
+		photoJoin = `
+		LEFT JOIN LATERAL (
+			SELECT rp.id, rp.recipe_id, COALESCE(rp.photo_url, '') AS photo_url, rp.is_primary, rp.created_at, rp.storage_key, rp.position
+			FROM recipe_photos rp
+			JOIN recipes pr ON pr.id = rp.recipe_id
+			WHERE pr.category_id = c.id AND pr.status = 'published'
+			ORDER BY rp.is_primary DESC, pr.published_at DESC NULLS LAST, rp.position, rp.id
+			LIMIT 1
+		) p ON TRUE`
+	}
+
 	query := `
-		SELECT id, name
-		FROM categories
+		WITH recipe_counts AS (
+			SELECT category_id, COUNT(*) AS recipe_count
+			FROM recipes
+			WHERE status = 'published' AND category_id IS NOT NULL
+			GROUP BY category_id
+		)
+		SELECT c.id, c.name, COALESCE(n.recipe_count, 0)` + photoColumns + `
+		FROM categories c
+		LEFT JOIN recipe_counts n ON n.category_id = c.id` + photoJoin + `
+		ORDER BY c.name
 	`
 
 	rows, err := s.db.Query(query)
@@ -824,10 +1998,34 @@ func (s *PostgresRecipeStore) GetAllCategories() ([]*Category, error) {
 	var categories []*Category
 	for rows.Next() {
 		category := &Category{}
-		err := rows.Scan(&category.ID, &category.Name)
-		if err != nil {
+		dest := []interface{}{&category.ID, &category.Name, &category.RecipeCount}
+
+		var (
+			photoID, photoRecipeID sql.NullInt64
+			photoURL               sql.NullString
+			photoIsPrimary         sql.NullBool
+			photoCreatedAt         sql.NullTime
+			photoStorageKey        *string
+			photoPosition          sql.NullInt64
+		)
+		if includePhotos {
+			dest = append(dest, &photoID, &photoRecipeID, &photoURL, &photoIsPrimary, &photoCreatedAt, &photoStorageKey, &photoPosition)
+		}
+
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("failed to scan category: %w", err)
 		}
+		if photoID.Valid {
+			category.Photo = &RecipePhoto{
+				ID:         photoID.Int64,
+				RecipeID:   photoRecipeID.Int64,
+				PhotoURL:   photoURL.String,
+				IsPrimary:  photoIsPrimary.Bool,
+				CreatedAt:  photoCreatedAt.Time,
+				StorageKey: photoStorageKey,
+				Position:   int(photoPosition.Int64),
+			}
+		}
 		categories = append(categories, category)
 	}
 	if err := rows.Err(); err != nil {
@@ -861,6 +2059,42 @@ func (s *PostgresRecipeStore) GetAllTags() ([]*Tag, error) {
 	}
 	return tags, nil
 }
+
+// GetPopularTags returns the tags used by the most published recipes, most used first.
+// With since set, only recipes published at or after it are counted. Unused tags are left out.
+func (s *PostgresRecipeStore) GetPopularTags(since *time.Time, limit int) ([]*PopularTag, error) {
+	query := `
+		SELECT t.id, t.name, COUNT(*) AS recipe_count
+		FROM recipe_tags rt
+		JOIN recipes r ON r.id = rt.recipe_id
+		JOIN tags t ON t.id = rt.tag_id
+		WHERE r.status = 'published'
+			AND ($2::timestamptz IS NULL OR r.published_at >= $2)
+		GROUP BY t.id, t.name
+		ORDER BY recipe_count DESC, t.name
+		LIMIT $1
+	`
+
+	rows, err := s.db.Query(query, limit, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get popular tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []*PopularTag{}
+	for rows.Next() {
+		tag := &PopularTag{}
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.RecipeCount); err != nil {
+			return nil, fmt.Errorf("failed to scan popular tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over popular tags: %w", err)
+	}
+	return tags, nil
+}
+
 func (s *PostgresRecipeStore) CreateTag(name string) (*Tag, error) {
 	query := `
 		INSERT INTO tags (name)
@@ -871,7 +2105,7 @@ func (s *PostgresRecipeStore) CreateTag(name string) (*Tag, error) {
 	tag := &Tag{Name: name}
 	err := s.db.QueryRow(query, name).Scan(&tag.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create tag: %w", err)
+		return nil, fmt.Errorf("failed to create tag: %w", mapError(err))
 	}
 
 	return tag, nil
@@ -886,16 +2120,22 @@ func (s *PostgresRecipeStore) CreateCategory(name string) (*Category, error) {
 	category := &Category{Name: name}
 	err := s.db.QueryRow(query, name).Scan(&category.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create category: %w", err)
+		return nil, fmt.Errorf("failed to create category: %w", mapError(err))
 	}
 
 	return category, nil
 }
-func (s *PostgresRecipeStore) AddRecipeReview(recipeID int64, userID int64, rating int, comment string) error {
+func (s *PostgresRecipeStore) AddRecipeReview(recipeID int64, userID int64, rating int, comment string) (*RecipeReview, error) {
+	// Link the review to the author's latest cook of the recipe, if any
 	query := `
-		INSERT INTO recipe_reviews (recipe_id, user_id, rating, comment)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, created_at
+		INSERT INTO reviews (recipe_id, user_id, rating, comment, cook_id)
+		VALUES ($1, $2, $3, $4, (
+			SELECT id FROM recipe_cooks
+			WHERE user_id = $2 AND recipe_id = $1
+			ORDER BY cooked_at DESC
+			LIMIT 1
+		))
+		RETURNING id, created_at, cook_id
 	`
 
 	review := &RecipeReview{
@@ -911,31 +2151,91 @@ func (s *PostgresRecipeStore) AddRecipeReview(recipeID int64, userID int64, rati
 		review.UserID,
 		review.Rating,
 		review.Comment,
-	).Scan(&review.ID, &review.CreatedAt)
+	).Scan(&review.ID, &review.CreatedAt, &review.CookID)
 
 	if err != nil {
-		return fmt.Errorf("failed to add recipe review: %w", err)
+		return nil, fmt.Errorf("failed to add recipe review: %w", mapError(err))
 	}
+	review.CookedIt = review.CookID != nil
 
-	return nil
+	return review, nil
 }
-func (s *PostgresRecipeStore) GetRecipeReviews(recipeID int64) ([]*RecipeReview, error) {
+
+// GetRecipeReviews returns a page of a recipe's reviews, newest first, with the total number matching
+// VerifiedOnly restricts the list to reviews by users who have cooked the recipe
+func (s *PostgresRecipeStore) GetRecipeReviews(recipeID int64, opts ReviewListOptions) ([]*RecipeReview, int, error) {
 	query := `
-		SELECT id, recipe_id, user_id, rating, comment, created_at
-		FROM recipe_reviews
-		WHERE recipe_id = $1
+		SELECT ` + reviewSelectColumns + `, COUNT(*) OVER()
+		FROM reviews rv
+		` + reviewAuthorResponseJoin + `
+		WHERE rv.recipe_id = $1 AND rv.hidden_at IS NULL AND ($2 = FALSE OR rv.cook_id IS NOT NULL)
+		ORDER BY rv.created_at DESC, rv.id DESC
+		LIMIT $3 OFFSET $4
 	`
 
-	rows, err := s.db.Query(query, recipeID)
+	rows, err := s.db.Query(query, recipeID, opts.VerifiedOnly, opts.Limit, (opts.Page-1)*opts.Limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get recipe reviews: %w", err)
+		return nil, 0, fmt.Errorf("failed to get recipe reviews: %w", err)
 	}
 	defer rows.Close()
 
-	var reviews []*RecipeReview
+	reviews := []*RecipeReview{}
+	total := 0
+	for rows.Next() {
+		review, err := scanReviewRow(rows, &total)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan recipe review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over recipe reviews: %w", err)
+	}
+
+	return reviews, total, nil
+}
+
+// GetRecipeReviewByID returns a single review
+// Returns nil if the review does not exist or has been hidden by a moderator
+func (s *PostgresRecipeStore) GetRecipeReviewByID(reviewID int64) (*RecipeReview, error) {
+	query := `
+		SELECT ` + reviewSelectColumns + `
+		FROM reviews rv
+		` + reviewAuthorResponseJoin + `
+		WHERE rv.id = $1 AND rv.hidden_at IS NULL
+	`
+
+	review, err := scanReviewRow(s.db.QueryRow(query, reviewID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get recipe review: %w", err)
+	}
+
+	return review, nil
+}
+
+// GetReviewsByUserID returns every review a user has written, newest first, including any hidden by moderators
+func (s *PostgresRecipeStore) GetReviewsByUserID(userID int64) ([]*RecipeReview, error) {
+	query := `
+		SELECT ` + reviewSelectColumns + `
+		FROM reviews rv
+		` + reviewAuthorResponseJoin + `
+		WHERE rv.user_id = $1
+		ORDER BY rv.created_at DESC, rv.id DESC
+	`
+
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews by user ID: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*RecipeReview{}
 	for rows.Next() {
-		review := &RecipeReview{}
-		err := rows.Scan(&review.ID, &review.RecipeID, &review.UserID, &review.Rating, &review.Comment, &review.CreatedAt)
+		review, err := scanReviewRow(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan recipe review: %w", err)
 		}
@@ -948,9 +2248,77 @@ func (s *PostgresRecipeStore) GetRecipeReviews(recipeID int64) ([]*RecipeReview,
 
 	return reviews, nil
 }
+
+// GetRecipeRatingSummary returns the rating histogram and averages of a recipe in a single aggregate query, ignoring the author's own reviews
+// Reviews written after opts.RecentSince are compared with earlier ones to report a trend; averages are nil without reviews
+func (s *PostgresRecipeStore) GetRecipeRatingSummary(recipeID int64, opts RatingSummaryOptions) (*RecipeRatingSummary, error) {
+	query := `
+		SELECT
+			AVG(rating)::FLOAT8,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE cook_id IS NOT NULL),
+			(SUM(rating * CASE WHEN cook_id IS NOT NULL THEN $3::FLOAT8 ELSE 1 END)
+				/ NULLIF(SUM(CASE WHEN cook_id IS NOT NULL THEN $3::FLOAT8 ELSE 1 END), 0))::FLOAT8,
+			COUNT(*) FILTER (WHERE rating = 1),
+			COUNT(*) FILTER (WHERE rating = 2),
+			COUNT(*) FILTER (WHERE rating = 3),
+			COUNT(*) FILTER (WHERE rating = 4),
+			COUNT(*) FILTER (WHERE rating = 5),
+			(AVG(rating) FILTER (WHERE created_at > $2))::FLOAT8,
+			COUNT(*) FILTER (WHERE created_at > $2),
+			(AVG(rating) FILTER (WHERE created_at <= $2))::FLOAT8,
+			COUNT(*) FILTER (WHERE created_at <= $2)
+		FROM reviews
+		WHERE recipe_id = $1 AND hidden_at IS NULL AND user_id <> (SELECT user_id FROM recipes WHERE id = $1)
+	`
+
+	summary := &RecipeRatingSummary{}
+	var stars [5]int
+	var earlierAverage *float64
+	var earlierCount int
+	var weightedAverage *float64
+	weight := max(opts.VerifiedWeight, 1)
+	err := s.db.QueryRow(query, recipeID, opts.RecentSince, weight).Scan(
+		&summary.AverageRating,
+		&summary.ReviewCount,
+		&summary.VerifiedReviewCount,
+		&weightedAverage,
+		&stars[0], &stars[1], &stars[2], &stars[3], &stars[4],
+		&summary.RecentAverageRating,
+		&summary.RecentReviewCount,
+		&earlierAverage,
+		&earlierCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipe rating summary: %w", err)
+	}
+
+	if weight > 1 {
+		summary.WeightedAverageRating = weightedAverage
+	}
+
+	summary.Histogram = make(map[int]int, len(stars))
+	for i, count := range stars {
+		summary.Histogram[i+1] = count
+	}
+
+	if summary.RecentReviewCount >= ratingTrendMinReviews && earlierCount >= ratingTrendMinReviews {
+		trend := RatingTrendSteady
+		switch delta := *summary.RecentAverageRating - *earlierAverage; {
+		case delta >= ratingTrendThreshold:
+			trend = RatingTrendUp
+		case delta <= -ratingTrendThreshold:
+			trend = RatingTrendDown
+		}
+		summary.Trend = &trend
+	}
+
+	return summary, nil
+}
+
 func (s *PostgresRecipeStore) UpdateRecipeReview(review *RecipeReview) error {
 	query := `
-		UPDATE recipe_reviews
+		UPDATE reviews
 		SET 
 			rating = $1, 
 			comment = $2, 
@@ -968,7 +2336,7 @@ func (s *PostgresRecipeStore) UpdateRecipeReview(review *RecipeReview) error {
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update recipe review: %w", err)
+		return fmt.Errorf("failed to update recipe review: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -977,20 +2345,60 @@ func (s *PostgresRecipeStore) UpdateRecipeReview(review *RecipeReview) error {
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return ErrNotFound
 	}
 
 	return nil
 }
 func (s *PostgresRecipeStore) DeleteRecipeReview(reviewID int64) error {
 	query := `
-		DELETE FROM recipe_reviews
+		DELETE FROM reviews
 		WHERE id = $1
 	`
 
 	result, err := s.db.Exec(query, reviewID)
 	if err != nil {
-		return fmt.Errorf("failed to delete recipe review: %w", err)
+		return fmt.Errorf("failed to delete recipe review: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SetReviewAuthorResponse pins a response by the recipe's author to a review, replacing any earlier one
+// The caller checks that userID is the author of the review's recipe
+func (s *PostgresRecipeStore) SetReviewAuthorResponse(reviewID int64, userID int64, body string) (*ReviewAuthorResponse, error) {
+	query := `
+		INSERT INTO review_author_responses (review_id, user_id, body)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (review_id)
+		DO UPDATE SET user_id = EXCLUDED.user_id, body = EXCLUDED.body, updated_at = CURRENT_TIMESTAMP
+		RETURNING body, created_at, updated_at
+	`
+
+	response := &ReviewAuthorResponse{}
+	err := s.db.QueryRow(query, reviewID, userID, body).Scan(&response.Body, &response.CreatedAt, &response.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set review author response: %w", mapError(err))
+	}
+
+	return response, nil
+}
+
+// DeleteReviewAuthorResponse removes the author response pinned to a review
+// Returns ErrNotFound if the review has none
+func (s *PostgresRecipeStore) DeleteReviewAuthorResponse(reviewID int64) error {
+	result, err := s.db.Exec(`DELETE FROM review_author_responses WHERE review_id = $1`, reviewID)
+	if err != nil {
+		return fmt.Errorf("failed to delete review author response: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -999,14 +2407,177 @@ func (s *PostgresRecipeStore) DeleteRecipeReview(reviewID int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CountRecipesCreatedSince returns how many recipes a user has created after the given time
+func (s *PostgresRecipeStore) CountRecipesCreatedSince(userID int64, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM recipes
+		WHERE user_id = $1 AND created_at > $2
+	`
+
+	var count int
+	err := s.db.QueryRow(query, userID, since).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recipes created since: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountRecipesByUserID returns how many recipes a user has, whatever their status
+func (s *PostgresRecipeStore) CountRecipesByUserID(userID int64) (int, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM recipes WHERE user_id = $1`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recipes by user ID: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetStaleDraftsToWarn returns up to limit drafts last edited before untouchedBefore whose authors have not
+// been warned about them since, grouped by author
+func (s *PostgresRecipeStore) GetStaleDraftsToWarn(untouchedBefore time.Time, limit int) ([]*StaleDraft, error) {
+	query := `
+		SELECT r.id, r.title, r.updated_at, u.user_id, u.username, COALESCE(u.first_name, ''), u.email
+		FROM recipes r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.status = 'draft' AND r.updated_at < $1
+		  AND (r.stale_warned_at IS NULL OR r.stale_warned_at < r.updated_at)
+		ORDER BY r.user_id, r.updated_at
+		LIMIT $2
+	`
+
+	rows, err := s.db.Query(query, untouchedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stale drafts: %w", err)
+	}
+	defer rows.Close()
+
+	drafts := []*StaleDraft{}
+	for rows.Next() {
+		draft := &StaleDraft{}
+		err := rows.Scan(&draft.RecipeID, &draft.Title, &draft.UpdatedAt, &draft.UserID, &draft.Username, &draft.FirstName, &draft.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stale draft: %w", err)
+		}
+		drafts = append(drafts, draft)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over stale drafts: %w", err)
+	}
+
+	return drafts, nil
+}
+
+// MarkStaleDraftsWarned records that the authors of the given drafts have been warned they will be archived
+func (s *PostgresRecipeStore) MarkStaleDraftsWarned(recipeIDs []int64) error {
+	if len(recipeIDs) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(recipeIDs))
+	args := make([]interface{}, len(recipeIDs))
+	for i, id := range recipeIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	_, err := s.db.Exec(`UPDATE recipes SET stale_warned_at = NOW() WHERE id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to mark stale drafts warned: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// ArchiveStaleDrafts archives drafts last edited before untouchedBefore whose authors were warned before warnedBefore
+// and have not edited them since. A zero warnedBefore archives them without a warning. Returns the number archived
+// Drafts are in no listing or index, so archiving them is not published as a recipe change
+func (s *PostgresRecipeStore) ArchiveStaleDrafts(untouchedBefore time.Time, warnedBefore time.Time) (int64, error) {
+	// Each archived draft gets an entry in its activity feed, with no user since nobody archived it by hand
+	query := `
+		WITH archived AS (
+			UPDATE recipes SET status = 'archived', archived_at = NOW(), version = version + 1
+			WHERE status = 'draft' AND updated_at < $1
+			  AND ($2::TIMESTAMPTZ IS NULL OR (stale_warned_at >= updated_at AND stale_warned_at < $2))
+			RETURNING id
+		)
+		INSERT INTO recipe_activity (recipe_id, action, changes)
+		SELECT id, '` + RecipeActivityArchived + `', '{"status": {"from": "draft", "to": "archived"}}'::JSONB
+		FROM archived
+	`
+
+	var warned *time.Time
+	if !warnedBefore.IsZero() {
+		warned = &warnedBefore
+	}
+
+	result, err := s.db.Exec(query, untouchedBefore, warned)
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive stale drafts: %w", mapError(err))
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return count, nil
+}
+
+// RestoreRecipe turns an archived recipe back into a draft that its author can edit and publish again
+// Returns ErrNotFound if the recipe does not exist or is not archived
+func (s *PostgresRecipeStore) RestoreRecipe(id int64) error {
+	query := `
+		UPDATE recipes
+		SET status = 'draft', archived_at = NULL, stale_warned_at = NULL, updated_at = NOW(), version = version + 1
+		WHERE id = $1 AND status = 'archived'
+	`
+
+	result, err := s.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore recipe: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
 	}
 
 	return nil
 }
+
+// CountReviewsCreatedSince returns how many reviews a user has written after the given time
+func (s *PostgresRecipeStore) CountReviewsCreatedSince(userID int64, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM reviews
+		WHERE user_id = $1 AND created_at > $2
+	`
+
+	var count int
+	err := s.db.QueryRow(query, userID, since).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reviews created since: %w", err)
+	}
+
+	return count, nil
+}
+
 func (s *PostgresRecipeStore) GetRecipeIngredientsTx(tx *sql.Tx, recipeID int64) ([]*RecipeIngredient, error) {
 	query := `
-		SELECT id, recipe_id, name, image, quantity, unit, position
+		SELECT id, recipe_id, ingredient_id, name, image, quantity, unit, position
 		FROM recipe_ingredients
 		WHERE recipe_id = $1
 		ORDER BY position
@@ -1024,6 +2595,7 @@ func (s *PostgresRecipeStore) GetRecipeIngredientsTx(tx *sql.Tx, recipeID int64)
 		err := rows.Scan(
 			&ingredient.ID,
 			&ingredient.RecipeID,
+			&ingredient.IngredientID,
 			&ingredient.Name,
 			&ingredient.Image,
 			&ingredient.Quantity,
@@ -1044,7 +2616,7 @@ func (s *PostgresRecipeStore) GetRecipeIngredientsTx(tx *sql.Tx, recipeID int64)
 }
 func (s *PostgresRecipeStore) GetRecipeStepsTx(tx *sql.Tx, recipeID int64) ([]*RecipeStep, error) {
 	query := `
-		SELECT id, recipe_id, step_number, instruction, duration_in_minutes
+		SELECT id, recipe_id, step_number, instruction, duration_in_minutes, timers
 		FROM recipe_steps
 		WHERE recipe_id = $1
 		ORDER BY step_number
@@ -1059,16 +2631,21 @@ func (s *PostgresRecipeStore) GetRecipeStepsTx(tx *sql.Tx, recipeID int64) ([]*R
 	var steps []*RecipeStep
 	for rows.Next() {
 		step := &RecipeStep{}
+		var timers []byte
 		err := rows.Scan(
 			&step.ID,
 			&step.RecipeID,
 			&step.StepNumber,
 			&step.Instruction,
 			&step.DurationInMinutes,
+			&timers,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan recipe step: %w", err)
 		}
+		if err := json.Unmarshal(timers, &step.Timers); err != nil {
+			return nil, fmt.Errorf("failed to decode step timers: %w", err)
+		}
 		steps = append(steps, step)
 	}
 
@@ -1080,9 +2657,10 @@ func (s *PostgresRecipeStore) GetRecipeStepsTx(tx *sql.Tx, recipeID int64) ([]*R
 }
 func (s *PostgresRecipeStore) GetRecipePhotosTx(tx *sql.Tx, recipeID int64) ([]*RecipePhoto, error) {
 	query := `
-		SELECT id, recipe_id, photo_url, is_primary, created_at
+		SELECT id, recipe_id, COALESCE(photo_url, ''), is_primary, created_at, storage_key, position
 		FROM recipe_photos
 		WHERE recipe_id = $1
+		ORDER BY position, id
 	`
 
 	rows, err := tx.Query(query, recipeID)
@@ -1100,6 +2678,8 @@ func (s *PostgresRecipeStore) GetRecipePhotosTx(tx *sql.Tx, recipeID int64) ([]*
 			&photo.PhotoURL,
 			&photo.IsPrimary,
 			&photo.CreatedAt,
+			&photo.StorageKey,
+			&photo.Position,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan recipe photo: %w", err)
@@ -1145,9 +2725,10 @@ func (s *PostgresRecipeStore) GetRecipeTagsTx(tx *sql.Tx, recipeID int64) ([]*Ta
 }
 func (s *PostgresRecipeStore) GetRecipeReviewsTx(tx *sql.Tx, recipeID int64) ([]*RecipeReview, error) {
 	query := `
-		SELECT id, recipe_id, user_id, rating, comment, created_at
-		FROM recipe_reviews
-		WHERE recipe_id = $1
+		SELECT ` + reviewSelectColumns + `
+		FROM reviews rv
+		` + reviewAuthorResponseJoin + `
+		WHERE rv.recipe_id = $1 AND rv.hidden_at IS NULL
 	`
 
 	rows, err := tx.Query(query, recipeID)
@@ -1158,15 +2739,7 @@ func (s *PostgresRecipeStore) GetRecipeReviewsTx(tx *sql.Tx, recipeID int64) ([]
 
 	var reviews []*RecipeReview
 	for rows.Next() {
-		review := &RecipeReview{}
-		err := rows.Scan(
-			&review.ID,
-			&review.RecipeID,
-			&review.UserID,
-			&review.Rating,
-			&review.Comment,
-			&review.CreatedAt,
-		)
+		review, err := scanReviewRow(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan recipe review: %w", err)
 		}
@@ -0,0 +1,100 @@
+package store_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dapoadedire/chefshare_be/store"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+)
+
+// silentDB returns a database handle whose server accepts connections but never answers, so a
+// query hangs until its context is done
+func silentDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	config, err := pgx.ParseConfig("host=" + host + " port=" + port + " user=chefshare dbname=chefshare sslmode=disable connect_timeout=30")
+	if err != nil {
+		t.Fatalf("failed to parse connection config: %v", err)
+	}
+
+	db := stdlib.OpenDB(*config)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// deadlineCases are the contexts a query is run with: one already expired, so it is rejected
+// before connecting, and one that expires while the query waits on the server
+var deadlineCases = []struct {
+	name    string
+	timeout time.Duration
+}{
+	{"expired", -time.Second},
+	{"mid-query", 100 * time.Millisecond},
+}
+
+// assertStopsAtDeadline runs query with each deadline case and checks it returns
+// context.DeadlineExceeded soon after the deadline
+func assertStopsAtDeadline(t *testing.T, query func(ctx context.Context, db *sql.DB) error) {
+	t.Helper()
+
+	for _, tc := range deadlineCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := query(ctx, silentDB(t))
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > max(tc.timeout, 0)+time.Second {
+				t.Fatalf("expected the query to stop at the deadline, took %s", elapsed)
+			}
+		})
+	}
+}
+
+func TestGetRecipesStopsAtDeadline(t *testing.T) {
+	assertStopsAtDeadline(t, func(ctx context.Context, db *sql.DB) error {
+		_, _, err := store.NewPostgresRecipeStore(db).GetRecipes(ctx, store.RecipeListOptions{Page: 1, Limit: 10})
+		return err
+	})
+}
+
+func TestEachRecipeStopsAtDeadline(t *testing.T) {
+	assertStopsAtDeadline(t, func(ctx context.Context, db *sql.DB) error {
+		return store.NewPostgresRecipeStore(db).EachRecipe(ctx, store.RecipeListOptions{}, func(*store.Recipe) error {
+			return nil
+		})
+	})
+}
+
+func TestSearchRecipesStopsAtDeadline(t *testing.T) {
+	assertStopsAtDeadline(t, func(ctx context.Context, db *sql.DB) error {
+		_, _, err := store.NewPostgresSearchStore(db).SearchRecipes(ctx, "pasta", store.RecipeListOptions{Page: 1, Limit: 10})
+		return err
+	})
+}
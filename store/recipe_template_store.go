@@ -0,0 +1,252 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RecipeTemplate is a starter recipe structure users can create recipes from
+type RecipeTemplate struct {
+	ID              int64                 `json:"id"`
+	Name            string                `json:"name"`
+	Description     string                `json:"description"`
+	CategoryID      *int64                `json:"category_id,omitempty"`
+	DifficultyLevel DifficultyLevel       `json:"difficulty_level"`
+	Ingredients     []*TemplateIngredient `json:"ingredients"`
+	Steps           []*TemplateStep       `json:"steps"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+// TemplateIngredient is an ingredient a template pre-fills
+type TemplateIngredient struct {
+	Name     string   `json:"name"`
+	Quantity *float64 `json:"quantity,omitempty"`
+	Unit     *string  `json:"unit,omitempty"`
+}
+
+// TemplateStep is a step a template pre-fills
+type TemplateStep struct {
+	Instruction       string      `json:"instruction"`
+	DurationInMinutes *int        `json:"duration_in_minutes,omitempty"`
+	Timers            []StepTimer `json:"timers"`
+}
+
+// RecipeTemplateStore defines the interface for recipe template operations
+type RecipeTemplateStore interface {
+	CreateTemplate(template *RecipeTemplate) error
+	GetTemplate(id int64) (*RecipeTemplate, error)
+	ListTemplates() ([]*RecipeTemplate, error)
+	UpdateTemplate(template *RecipeTemplate) error
+	DeleteTemplate(id int64) error
+}
+
+// PostgresRecipeTemplateStore implements the RecipeTemplateStore interface using PostgreSQL
+type PostgresRecipeTemplateStore struct {
+	db *sql.DB
+}
+
+// NewPostgresRecipeTemplateStore creates a new PostgresRecipeTemplateStore
+func NewPostgresRecipeTemplateStore(db *sql.DB) *PostgresRecipeTemplateStore {
+	return &PostgresRecipeTemplateStore{
+		db: db,
+	}
+}
+
+// encodeTemplateContent encodes a template's ingredients and steps for their JSONB columns
+func encodeTemplateContent(template *RecipeTemplate) (string, string, error) {
+	ingredients := template.Ingredients
+	if ingredients == nil {
+		ingredients = []*TemplateIngredient{}
+	}
+	steps := template.Steps
+	if steps == nil {
+		steps = []*TemplateStep{}
+	}
+
+	ingredientsJSON, err := json.Marshal(ingredients)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode template ingredients: %w", err)
+	}
+
+	stepsJSON, err := json.Marshal(steps)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode template steps: %w", err)
+	}
+
+	return string(ingredientsJSON), string(stepsJSON), nil
+}
+
+// CreateTemplate stores a new recipe template
+func (s *PostgresRecipeTemplateStore) CreateTemplate(template *RecipeTemplate) error {
+	ingredients, steps, err := encodeTemplateContent(template)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO recipe_templates (name, description, category_id, difficulty_level, ingredients, steps)
+		VALUES ($1, $2, $3, $4, $5::JSONB, $6::JSONB)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = s.db.QueryRow(
+		query,
+		template.Name,
+		template.Description,
+		template.CategoryID,
+		template.DifficultyLevel,
+		ingredients,
+		steps,
+	).Scan(&template.ID, &template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create recipe template: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// GetTemplate returns a recipe template by ID
+// Returns nil if the template does not exist
+func (s *PostgresRecipeTemplateStore) GetTemplate(id int64) (*RecipeTemplate, error) {
+	query := `
+		SELECT id, name, description, category_id, difficulty_level, ingredients, steps, created_at, updated_at
+		FROM recipe_templates
+		WHERE id = $1
+	`
+
+	template, err := scanRecipeTemplate(s.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get recipe template: %w", err)
+	}
+
+	return template, nil
+}
+
+// ListTemplates returns all recipe templates ordered by name
+func (s *PostgresRecipeTemplateStore) ListTemplates() ([]*RecipeTemplate, error) {
+	query := `
+		SELECT id, name, description, category_id, difficulty_level, ingredients, steps, created_at, updated_at
+		FROM recipe_templates
+		ORDER BY name
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recipe templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*RecipeTemplate{}
+	for rows.Next() {
+		template, err := scanRecipeTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recipe template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over recipe templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// UpdateTemplate replaces a recipe template's content
+func (s *PostgresRecipeTemplateStore) UpdateTemplate(template *RecipeTemplate) error {
+	ingredients, steps, err := encodeTemplateContent(template)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE recipe_templates
+		SET
+			name = $1,
+			description = $2,
+			category_id = $3,
+			difficulty_level = $4,
+			ingredients = $5::JSONB,
+			steps = $6::JSONB,
+			updated_at = NOW()
+		WHERE id = $7
+		RETURNING created_at, updated_at
+	`
+
+	err = s.db.QueryRow(
+		query,
+		template.Name,
+		template.Description,
+		template.CategoryID,
+		template.DifficultyLevel,
+		ingredients,
+		steps,
+		template.ID,
+	).Scan(&template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update recipe template: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// DeleteTemplate removes a recipe template; recipes created from it are unaffected
+func (s *PostgresRecipeTemplateStore) DeleteTemplate(id int64) error {
+	result, err := s.db.Exec(`DELETE FROM recipe_templates WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete recipe template: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRecipeTemplate(row rowScanner) (*RecipeTemplate, error) {
+	template := &RecipeTemplate{}
+	var ingredients, steps []byte
+	err := row.Scan(
+		&template.ID,
+		&template.Name,
+		&template.Description,
+		&template.CategoryID,
+		&template.DifficultyLevel,
+		&ingredients,
+		&steps,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(ingredients, &template.Ingredients); err != nil {
+		return nil, fmt.Errorf("failed to decode template ingredients: %w", err)
+	}
+	if err := json.Unmarshal(steps, &template.Steps); err != nil {
+		return nil, fmt.Errorf("failed to decode template steps: %w", err)
+	}
+
+	return template, nil
+}
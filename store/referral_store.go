@@ -0,0 +1,187 @@
+package store
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ReferralSourceCode marks a signup that used the referrer's referral code
+	ReferralSourceCode = "code"
+
+	// ReferralSourceInvitation marks a signup through an invitation email
+	ReferralSourceInvitation = "invitation"
+)
+
+// referralCodeAlphabet leaves out characters that are easily confused (0/O, 1/I/L)
+const referralCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// referralCodeLength is the number of characters in a generated referral code
+const referralCodeLength = 8
+
+// Referral is a user who signed up through someone else's code or invitation
+type Referral struct {
+	Username         string     `json:"username"`
+	Source           string     `json:"source"`
+	SignedUpAt       time.Time  `json:"signed_up_at"`
+	FirstPublishedAt *time.Time `json:"first_published_at,omitempty"`
+}
+
+// ReferralSummary counts how far a user's referrals have progressed
+type ReferralSummary struct {
+	SignedUp         int         `json:"signed_up"`
+	PublishedARecipe int         `json:"published_a_recipe"`
+	Referrals        []*Referral `json:"referrals"`
+}
+
+// ReferralStore defines the interface for referral operations
+type ReferralStore interface {
+	GetOrCreateReferralCode(userID int64) (string, error)
+	GetUserIDByReferralCode(code string) (int64, error)
+	CreateReferralWithTransaction(referrerID int64, referredUserID string, source string, tx *sql.Tx) error
+	GetReferralSummary(referrerID int64) (*ReferralSummary, error)
+}
+
+// PostgresReferralStore implements the ReferralStore interface using PostgreSQL
+type PostgresReferralStore struct {
+	db *sql.DB
+}
+
+// NewPostgresReferralStore creates a new PostgresReferralStore
+func NewPostgresReferralStore(db *sql.DB) *PostgresReferralStore {
+	return &PostgresReferralStore{
+		db: db,
+	}
+}
+
+// generateReferralCode creates a random, human-friendly referral code
+func generateReferralCode() (string, error) {
+	b := make([]byte, referralCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+
+	code := make([]byte, referralCodeLength)
+	for i := range b {
+		code[i] = referralCodeAlphabet[int(b[i])%len(referralCodeAlphabet)]
+	}
+
+	return string(code), nil
+}
+
+// NormalizeReferralCode uppercases a referral code and strips surrounding whitespace
+func NormalizeReferralCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// GetOrCreateReferralCode returns a user's referral code, generating one the first time it is requested
+func (s *PostgresReferralStore) GetOrCreateReferralCode(userID int64) (string, error) {
+	var code sql.NullString
+	err := s.db.QueryRow(`SELECT referral_code FROM users WHERE id = $1`, userID).Scan(&code)
+	if err != nil {
+		return "", fmt.Errorf("failed to get referral code: %w", err)
+	}
+	if code.Valid {
+		return code.String, nil
+	}
+
+	// Retry on the rare collision with another user's code
+	for attempt := 0; attempt < 3; attempt++ {
+		generated, err := generateReferralCode()
+		if err != nil {
+			return "", err
+		}
+
+		query := `
+			UPDATE users
+			SET referral_code = COALESCE(referral_code, $1)
+			WHERE id = $2
+			RETURNING referral_code
+		`
+		err = s.db.QueryRow(query, generated, userID).Scan(&code)
+		if err == nil {
+			return code.String, nil
+		}
+		if err = mapError(err); !errors.Is(err, ErrConflict) {
+			return "", fmt.Errorf("failed to set referral code: %w", err)
+		}
+	}
+
+	return "", fmt.Errorf("failed to generate a unique referral code")
+}
+
+// GetUserIDByReferralCode returns the internal ID of the user who owns a referral code
+// Returns 0 if no user has the code
+func (s *PostgresReferralStore) GetUserIDByReferralCode(code string) (int64, error) {
+	var id int64
+	err := s.db.QueryRow(`SELECT id FROM users WHERE referral_code = $1`, NormalizeReferralCode(code)).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get user by referral code: %w", err)
+	}
+
+	return id, nil
+}
+
+// CreateReferralWithTransaction records that a new user signed up through a referrer
+func (s *PostgresReferralStore) CreateReferralWithTransaction(referrerID int64, referredUserID string, source string, tx *sql.Tx) error {
+	query := `
+		INSERT INTO referrals (referrer_id, referred_user_id, source)
+		SELECT $1, id, $2
+		FROM users
+		WHERE user_id = $3
+		ON CONFLICT (referred_user_id) DO NOTHING
+	`
+
+	if _, err := tx.Exec(query, referrerID, source, referredUserID); err != nil {
+		return fmt.Errorf("failed to create referral: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// GetReferralSummary returns a user's referrals, newest first, with counts of signups and of
+// referred users who have published at least one recipe
+func (s *PostgresReferralStore) GetReferralSummary(referrerID int64) (*ReferralSummary, error) {
+	query := `
+		SELECT u.username, rf.source, rf.created_at,
+			(SELECT MIN(r.published_at) FROM recipes r WHERE r.user_id = u.id)
+		FROM referrals rf
+		JOIN users u ON u.id = rf.referred_user_id
+		WHERE rf.referrer_id = $1
+		ORDER BY rf.created_at DESC
+	`
+
+	rows, err := s.db.Query(query, referrerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referrals: %w", err)
+	}
+	defer rows.Close()
+
+	summary := &ReferralSummary{Referrals: []*Referral{}}
+	for rows.Next() {
+		referral := &Referral{}
+		err := rows.Scan(&referral.Username, &referral.Source, &referral.SignedUpAt, &referral.FirstPublishedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan referral: %w", err)
+		}
+
+		summary.SignedUp++
+		if referral.FirstPublishedAt != nil {
+			summary.PublishedARecipe++
+		}
+		summary.Referrals = append(summary.Referrals, referral)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over referrals: %w", err)
+	}
+
+	return summary, nil
+}
@@ -26,7 +26,9 @@ type RefreshTokenStore interface {
 	CreateRefreshTokenWithTransaction(userID string, duration time.Duration, ipAddress, userAgent string, tx *sql.Tx) (*RefreshToken, error)
 	GetRefreshToken(token string) (*RefreshToken, error)
 	RevokeRefreshToken(token string) error
+	RevokeRefreshTokenWithTransaction(token string, tx *sql.Tx) error
 	RevokeAllUserRefreshTokens(userID string) (int64, error)
+	RevokeAllUserRefreshTokensWithTransaction(userID string, tx *sql.Tx) (int64, error)
 	DeleteExpiredRefreshTokens() (int64, error)
 }
 
@@ -72,7 +74,7 @@ func (s *PostgresRefreshTokenStore) CreateRefreshToken(userID string, duration t
 	).Scan(&refreshToken.ID, &refreshToken.IssuedAt)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+		return nil, fmt.Errorf("failed to create refresh token: %w", mapError(err))
 	}
 
 	return refreshToken, nil
@@ -108,7 +110,7 @@ func (s *PostgresRefreshTokenStore) CreateRefreshTokenWithTransaction(userID str
 	).Scan(&refreshToken.ID, &refreshToken.IssuedAt)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to create refresh token in transaction: %w", err)
+		return nil, fmt.Errorf("failed to create refresh token in transaction: %w", mapError(err))
 	}
 
 	return refreshToken, nil
@@ -151,14 +153,23 @@ func (s *PostgresRefreshTokenStore) GetRefreshToken(token string) (*RefreshToken
 
 // RevokeRefreshToken deletes a refresh token from the database
 func (s *PostgresRefreshTokenStore) RevokeRefreshToken(token string) error {
+	return revokeRefreshToken(s.db, token)
+}
+
+// RevokeRefreshTokenWithTransaction deletes a refresh token within a transaction
+func (s *PostgresRefreshTokenStore) RevokeRefreshTokenWithTransaction(token string, tx *sql.Tx) error {
+	return revokeRefreshToken(tx, token)
+}
+
+func revokeRefreshToken(db DBTX, token string) error {
 	query := `
 		DELETE FROM refresh_tokens
 		WHERE token = $1
 	`
 
-	result, err := s.db.Exec(query, token)
+	result, err := db.Exec(query, token)
 	if err != nil {
-		return fmt.Errorf("failed to delete refresh token: %w", err)
+		return fmt.Errorf("failed to delete refresh token: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -175,14 +186,23 @@ func (s *PostgresRefreshTokenStore) RevokeRefreshToken(token string) error {
 
 // RevokeAllUserRefreshTokens deletes all refresh tokens for a specific user
 func (s *PostgresRefreshTokenStore) RevokeAllUserRefreshTokens(userID string) (int64, error) {
+	return revokeAllUserRefreshTokens(s.db, userID)
+}
+
+// RevokeAllUserRefreshTokensWithTransaction deletes all refresh tokens for a user within a transaction
+func (s *PostgresRefreshTokenStore) RevokeAllUserRefreshTokensWithTransaction(userID string, tx *sql.Tx) (int64, error) {
+	return revokeAllUserRefreshTokens(tx, userID)
+}
+
+func revokeAllUserRefreshTokens(db DBTX, userID string) (int64, error) {
 	query := `
 		DELETE FROM refresh_tokens
 		WHERE user_id = $1
 	`
 
-	result, err := s.db.Exec(query, userID)
+	result, err := db.Exec(query, userID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to delete user refresh tokens: %w", err)
+		return 0, fmt.Errorf("failed to delete user refresh tokens: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -199,7 +219,7 @@ func (s *PostgresRefreshTokenStore) DeleteExpiredRefreshTokens() (int64, error)
 
 	result, err := s.db.Exec(query, time.Now())
 	if err != nil {
-		return 0, fmt.Errorf("failed to delete expired refresh tokens: %w", err)
+		return 0, fmt.Errorf("failed to delete expired refresh tokens: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -0,0 +1,235 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Reputation ledger reasons, recorded by database triggers
+const (
+	ReputationRecipePublished      = "recipe_published"
+	ReputationFavoriteReceived     = "favorite_received"
+	ReputationFavoriteRemoved      = "favorite_removed"
+	ReputationReviewHelpful        = "review_helpful"
+	ReputationReviewHelpfulRemoved = "review_helpful_removed"
+)
+
+// ReputationEvent is a single entry in a user's reputation ledger
+type ReputationEvent struct {
+	ID        int64     `json:"id"`
+	Points    int       `json:"points"`
+	Reason    string    `json:"reason"`
+	RecipeID  *int64    `json:"recipe_id,omitempty"`
+	ReviewID  *int64    `json:"review_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserReputation is a user's reputation score and their position among all users
+// Users with the same score share a rank
+type UserReputation struct {
+	Score int `json:"score"`
+	Rank  int `json:"rank"`
+}
+
+// LeaderboardEntry is a user's standing on the reputation leaderboard
+type LeaderboardEntry struct {
+	Rank           int     `json:"rank"`
+	UserID         string  `json:"user_id"`
+	Username       string  `json:"username"`
+	ProfilePicture *string `json:"profile_picture,omitempty"`
+	Reputation     int     `json:"reputation"`
+}
+
+// ReputationStore defines the interface for reputation, favorite, and helpful vote operations
+type ReputationStore interface {
+	AddFavorite(userID int64, recipeID int64) (bool, error)
+	RemoveFavorite(userID int64, recipeID int64) error
+	AddReviewHelpfulVote(userID int64, reviewID int64) (bool, error)
+	RemoveReviewHelpfulVote(userID int64, reviewID int64) error
+
+	GetUserReputation(userID int64) (*UserReputation, error)
+	GetReputationEvents(userID int64, limit int) ([]*ReputationEvent, error)
+	GetLeaderboard(limit int) ([]*LeaderboardEntry, error)
+}
+
+// PostgresReputationStore implements the ReputationStore interface using PostgreSQL
+type PostgresReputationStore struct {
+	db *sql.DB
+}
+
+// NewPostgresReputationStore creates a new PostgresReputationStore
+func NewPostgresReputationStore(db *sql.DB) *PostgresReputationStore {
+	return &PostgresReputationStore{
+		db: db,
+	}
+}
+
+// AddFavorite marks a recipe as one of the user's favorites and reports whether it was not one already
+// Favoriting a recipe twice is not an error
+func (s *PostgresReputationStore) AddFavorite(userID int64, recipeID int64) (bool, error) {
+	query := `
+		INSERT INTO likes (user_id, recipe_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, recipe_id) DO NOTHING
+	`
+
+	result, err := s.db.Exec(query, userID, recipeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to add favorite: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RemoveFavorite removes a recipe from the user's favorites
+// Returns ErrNotFound if the recipe was not a favorite
+func (s *PostgresReputationStore) RemoveFavorite(userID int64, recipeID int64) error {
+	result, err := s.db.Exec(`DELETE FROM likes WHERE user_id = $1 AND recipe_id = $2`, userID, recipeID)
+	if err != nil {
+		return fmt.Errorf("failed to remove favorite: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AddReviewHelpfulVote records that a user found a review helpful and reports whether they had not voted for it already
+// Voting for the same review twice is not an error
+func (s *PostgresReputationStore) AddReviewHelpfulVote(userID int64, reviewID int64) (bool, error) {
+	query := `
+		INSERT INTO review_helpful_votes (review_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (review_id, user_id) DO NOTHING
+	`
+
+	result, err := s.db.Exec(query, reviewID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to add helpful vote: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RemoveReviewHelpfulVote withdraws a user's helpful vote on a review
+// Returns ErrNotFound if the user had not voted for the review
+func (s *PostgresReputationStore) RemoveReviewHelpfulVote(userID int64, reviewID int64) error {
+	result, err := s.db.Exec(`DELETE FROM review_helpful_votes WHERE review_id = $1 AND user_id = $2`, reviewID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove helpful vote: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetUserReputation returns a user's reputation score and rank
+func (s *PostgresReputationStore) GetUserReputation(userID int64) (*UserReputation, error) {
+	query := `
+		SELECT u.reputation,
+			(SELECT COUNT(*) FROM users other WHERE other.reputation > u.reputation) + 1
+		FROM users u
+		WHERE u.id = $1
+	`
+
+	reputation := &UserReputation{}
+	err := s.db.QueryRow(query, userID).Scan(&reputation.Score, &reputation.Rank)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user reputation: %w", err)
+	}
+
+	return reputation, nil
+}
+
+// GetReputationEvents returns a user's most recent reputation ledger entries, newest first
+func (s *PostgresReputationStore) GetReputationEvents(userID int64, limit int) ([]*ReputationEvent, error) {
+	query := `
+		SELECT id, points, reason, recipe_id, review_id, created_at
+		FROM reputation_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := s.db.Query(query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reputation events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*ReputationEvent{}
+	for rows.Next() {
+		event := &ReputationEvent{}
+		err := rows.Scan(&event.ID, &event.Points, &event.Reason, &event.RecipeID, &event.ReviewID, &event.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reputation event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over reputation events: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetLeaderboard returns the users with the highest reputation
+// Users with the same score share a rank; among them older accounts are listed first
+func (s *PostgresReputationStore) GetLeaderboard(limit int) ([]*LeaderboardEntry, error) {
+	query := `
+		SELECT RANK() OVER (ORDER BY u.reputation DESC), u.user_id, u.username, u.profile_picture, u.reputation
+		FROM users u
+		WHERE u.reputation > 0
+		ORDER BY u.reputation DESC, u.created_at ASC
+		LIMIT $1
+	`
+
+	rows, err := s.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*LeaderboardEntry{}
+	for rows.Next() {
+		entry := &LeaderboardEntry{}
+		err := rows.Scan(&entry.Rank, &entry.UserID, &entry.Username, &entry.ProfilePicture, &entry.Reputation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over leaderboard: %w", err)
+	}
+
+	return entries, nil
+}
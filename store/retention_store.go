@@ -0,0 +1,84 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// RetentionStore defines the interface for purging old records under the data retention policies
+// Each purge removes at most limit rows, oldest first, so callers can delete in short batches
+type RetentionStore interface {
+	PurgeRecipeActivity(before time.Time, limit int) (int64, error)
+	PurgeRecipeDailyViews(before time.Time, limit int) (int64, error)
+	PurgeEmailSends(before time.Time, limit int) (int64, error)
+}
+
+// PostgresRetentionStore implements the RetentionStore interface using PostgreSQL
+type PostgresRetentionStore struct {
+	db *sql.DB
+}
+
+// NewPostgresRetentionStore creates a new PostgresRetentionStore
+func NewPostgresRetentionStore(db *sql.DB) *PostgresRetentionStore {
+	return &PostgresRetentionStore{
+		db: db,
+	}
+}
+
+// PurgeRecipeActivity deletes recipe change feed entries recorded before the given time
+func (s *PostgresRetentionStore) PurgeRecipeActivity(before time.Time, limit int) (int64, error) {
+	return s.purge("recipe activity", `
+		DELETE FROM recipe_activity
+		WHERE id IN (
+			SELECT id FROM recipe_activity
+			WHERE created_at < $1
+			ORDER BY id
+			LIMIT $2
+		)
+	`, before, limit)
+}
+
+// PurgeRecipeDailyViews deletes daily view counts for days before the given time's UTC date
+func (s *PostgresRetentionStore) PurgeRecipeDailyViews(before time.Time, limit int) (int64, error) {
+	return s.purge("recipe daily views", `
+		DELETE FROM recipe_daily_views
+		WHERE ctid IN (
+			SELECT ctid FROM recipe_daily_views
+			WHERE day < $1::DATE
+			ORDER BY day
+			LIMIT $2
+		)
+	`, before.UTC().Format(time.DateOnly), limit)
+}
+
+// PurgeEmailSends deletes records of emails sent before the given time
+// Sends are only deleted once their day has been rolled up into metrics_daily, and the last rolled up day
+// is kept because the rollup recounts it, so daily email counts are unaffected.
+func (s *PostgresRetentionStore) PurgeEmailSends(before time.Time, limit int) (int64, error) {
+	return s.purge("email sends", `
+		DELETE FROM email_sends
+		WHERE id IN (
+			SELECT id FROM email_sends
+			WHERE sent_at < $1
+			AND sent_at < (SELECT MAX(day) FROM metrics_daily)::TIMESTAMP AT TIME ZONE 'UTC'
+			ORDER BY id
+			LIMIT $2
+		)
+	`, before, limit)
+}
+
+// purge runs a batched delete and returns how many rows it removed
+func (s *PostgresRetentionStore) purge(what string, query string, args ...interface{}) (int64, error) {
+	result, err := s.db.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge %s: %w", what, mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,323 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ReviewModerationHide hides matching reviews from listings and ratings without deleting them
+	ReviewModerationHide = "hide"
+
+	// ReviewModerationDelete deletes matching reviews, along with their votes, mentions and author responses
+	ReviewModerationDelete = "delete"
+)
+
+const (
+	// ReviewModerationQueued jobs are waiting for the background worker
+	ReviewModerationQueued = "queued"
+
+	// ReviewModerationRunning jobs are working through their matching reviews
+	ReviewModerationRunning = "running"
+
+	// ReviewModerationDone jobs have applied their action to every matching review
+	ReviewModerationDone = "done"
+
+	// ReviewModerationFailed jobs stopped with an error; reviews already processed stay hidden or deleted
+	ReviewModerationFailed = "failed"
+)
+
+// IsValidReviewModerationAction reports whether action is hide or delete
+func IsValidReviewModerationAction(action string) bool {
+	return action == ReviewModerationHide || action == ReviewModerationDelete
+}
+
+// ReviewModerationJob is an admin's request to hide or delete every review by a user, every review whose
+// comment contains a piece of text, or every review matching both
+// Matched is counted when the job starts, so reviews written while it runs may be caught without being counted.
+type ReviewModerationJob struct {
+	ID           int64      `json:"id"`
+	Action       string     `json:"action"`
+	UserID       *int64     `json:"-"`
+	UserPublicID *string    `json:"user_id,omitempty"`
+	Pattern      *string    `json:"pattern,omitempty"`
+	Status       string     `json:"status"`
+	Progress     int        `json:"progress"`
+	Matched      int        `json:"matched"`
+	Processed    int        `json:"processed"`
+	Error        *string    `json:"error,omitempty"`
+	CreatedBy    *int64     `json:"created_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
+
+// ReviewModerationStore defines the interface for bulk review moderation jobs and the batches they run
+type ReviewModerationStore interface {
+	CreateReviewModerationJob(job *ReviewModerationJob) error
+	GetReviewModerationJob(id int64) (*ReviewModerationJob, error)
+	GetReviewModerationJobs(page, limit int) ([]*ReviewModerationJob, int, error)
+	ClaimReviewModerationJob(staleBefore time.Time) (*ReviewModerationJob, error)
+	CountReviewModerationMatches(job *ReviewModerationJob) (int, error)
+	ApplyReviewModerationBatch(job *ReviewModerationJob, afterID int64, limit int) (int, int64, error)
+	SetReviewModerationProgress(id int64, matched, processed int) error
+	CompleteReviewModerationJob(id int64, processed int) error
+	FailReviewModerationJob(id int64, message string) error
+}
+
+// PostgresReviewModerationStore implements the ReviewModerationStore interface using PostgreSQL
+type PostgresReviewModerationStore struct {
+	db *sql.DB
+}
+
+// NewPostgresReviewModerationStore creates a new PostgresReviewModerationStore
+func NewPostgresReviewModerationStore(db *sql.DB) *PostgresReviewModerationStore {
+	return &PostgresReviewModerationStore{
+		db: db,
+	}
+}
+
+const reviewModerationJobColumns = `j.id, j.action, j.user_id, u.user_id, j.pattern, j.status, j.progress, j.matched, j.processed, j.error,
+	j.created_by, j.created_at, j.started_at, j.completed_at`
+
+func scanReviewModerationJob(row rowScanner, extra ...interface{}) (*ReviewModerationJob, error) {
+	job := &ReviewModerationJob{}
+	dest := []interface{}{
+		&job.ID,
+		&job.Action,
+		&job.UserID,
+		&job.UserPublicID,
+		&job.Pattern,
+		&job.Status,
+		&job.Progress,
+		&job.Matched,
+		&job.Processed,
+		&job.Error,
+		&job.CreatedBy,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.CompletedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// reviewModerationMatch selects the reviews a job applies to, given its user as $1 and its escaped pattern as $2
+// Hide jobs skip reviews that are already hidden.
+const reviewModerationMatch = `($1::BIGINT IS NULL OR rv.user_id = $1)
+	AND ($2::TEXT IS NULL OR rv.comment ILIKE '%' || $2 || '%')
+	AND ($3 = 'delete' OR rv.hidden_at IS NULL)`
+
+// reviewModerationArgs returns the arguments of reviewModerationMatch for a job
+// The pattern is matched literally rather than as a LIKE pattern
+func reviewModerationArgs(job *ReviewModerationJob) []interface{} {
+	var pattern *string
+	if job.Pattern != nil {
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(*job.Pattern)
+		pattern = &escaped
+	}
+	return []interface{}{job.UserID, pattern, job.Action}
+}
+
+// CreateReviewModerationJob queues a job and fills in its ID, status and creation time
+func (s *PostgresReviewModerationStore) CreateReviewModerationJob(job *ReviewModerationJob) error {
+	query := `
+		WITH j AS (
+			INSERT INTO review_moderation_jobs (action, user_id, pattern, created_by)
+			VALUES ($1, $2, $3, $4)
+			RETURNING *
+		)
+		SELECT ` + reviewModerationJobColumns + `
+		FROM j
+		LEFT JOIN users u ON u.id = j.user_id
+	`
+
+	created, err := scanReviewModerationJob(s.db.QueryRow(query, job.Action, job.UserID, job.Pattern, job.CreatedBy))
+	if err != nil {
+		return fmt.Errorf("failed to create review moderation job: %w", mapError(err))
+	}
+
+	*job = *created
+	return nil
+}
+
+// GetReviewModerationJob returns a job
+// Returns nil if the job does not exist
+func (s *PostgresReviewModerationStore) GetReviewModerationJob(id int64) (*ReviewModerationJob, error) {
+	query := `
+		SELECT ` + reviewModerationJobColumns + `
+		FROM review_moderation_jobs j
+		LEFT JOIN users u ON u.id = j.user_id
+		WHERE j.id = $1
+	`
+
+	job, err := scanReviewModerationJob(s.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get review moderation job: %w", err)
+	}
+
+	return job, nil
+}
+
+// GetReviewModerationJobs returns a page of jobs, newest first, with the total number of jobs
+func (s *PostgresReviewModerationStore) GetReviewModerationJobs(page, limit int) ([]*ReviewModerationJob, int, error) {
+	query := `
+		SELECT ` + reviewModerationJobColumns + `, COUNT(*) OVER()
+		FROM review_moderation_jobs j
+		LEFT JOIN users u ON u.id = j.user_id
+		ORDER BY j.created_at DESC, j.id DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := s.db.Query(query, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get review moderation jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*ReviewModerationJob{}
+	total := 0
+	for rows.Next() {
+		job, err := scanReviewModerationJob(rows, &total)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan review moderation job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over review moderation jobs: %w", err)
+	}
+
+	return jobs, total, nil
+}
+
+// ClaimReviewModerationJob marks the oldest queued job as running and returns it
+// Jobs left running since before staleBefore, such as by a worker that crashed, are claimed again and start
+// counting afresh; reviews they already processed no longer match a delete, and are skipped by a hide.
+// Concurrent workers never claim the same job. Returns nil if there is nothing to do.
+func (s *PostgresReviewModerationStore) ClaimReviewModerationJob(staleBefore time.Time) (*ReviewModerationJob, error) {
+	query := `
+		WITH j AS (
+			UPDATE review_moderation_jobs
+			SET status = 'running', progress = 0, matched = 0, processed = 0, started_at = NOW()
+			WHERE id = (
+				SELECT id
+				FROM review_moderation_jobs
+				WHERE status = 'queued' OR (status = 'running' AND started_at < $1)
+				ORDER BY created_at
+				LIMIT 1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT ` + reviewModerationJobColumns + `
+		FROM j
+		LEFT JOIN users u ON u.id = j.user_id
+	`
+
+	job, err := scanReviewModerationJob(s.db.QueryRow(query, staleBefore))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim review moderation job: %w", mapError(err))
+	}
+
+	return job, nil
+}
+
+// CountReviewModerationMatches returns how many reviews a job would hide or delete
+func (s *PostgresReviewModerationStore) CountReviewModerationMatches(job *ReviewModerationJob) (int, error) {
+	query := `SELECT COUNT(*) FROM reviews rv WHERE ` + reviewModerationMatch
+
+	var count int
+	if err := s.db.QueryRow(query, reviewModerationArgs(job)...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count review moderation matches: %w", err)
+	}
+
+	return count, nil
+}
+
+// ApplyReviewModerationBatch hides or deletes up to limit of a job's matching reviews with IDs above afterID
+// It returns how many reviews were changed and the highest ID looked at, which is afterID once none are left.
+// Working in ID order keeps each batch short and lets a pattern match scan the table only once.
+func (s *PostgresReviewModerationStore) ApplyReviewModerationBatch(job *ReviewModerationJob, afterID int64, limit int) (int, int64, error) {
+	change := `UPDATE reviews SET hidden_at = NOW() WHERE id IN (SELECT id FROM batch) RETURNING id`
+	if job.Action == ReviewModerationDelete {
+		change = `DELETE FROM reviews WHERE id IN (SELECT id FROM batch) RETURNING id`
+	}
+
+	query := `
+		WITH batch AS (
+			SELECT rv.id
+			FROM reviews rv
+			WHERE rv.id > $4 AND ` + reviewModerationMatch + `
+			ORDER BY rv.id
+			LIMIT $5
+		), changed AS (
+			` + change + `
+		)
+		SELECT (SELECT COUNT(*) FROM changed), COALESCE((SELECT MAX(id) FROM batch), $4)
+	`
+
+	var changed int
+	var lastID int64
+	args := append(reviewModerationArgs(job), afterID, limit)
+	if err := s.db.QueryRow(query, args...).Scan(&changed, &lastID); err != nil {
+		return 0, afterID, fmt.Errorf("failed to apply review moderation batch: %w", mapError(err))
+	}
+
+	return changed, lastID, nil
+}
+
+// SetReviewModerationProgress records how many reviews a running job matched and has processed so far
+func (s *PostgresReviewModerationStore) SetReviewModerationProgress(id int64, matched, processed int) error {
+	// 100 is only reported once the job completes, as reviews written since it started may still match
+	progress := 0
+	if matched > 0 {
+		progress = min(processed*100/matched, 99)
+	}
+
+	query := `
+		UPDATE review_moderation_jobs
+		SET matched = $2, processed = $3, progress = $4
+		WHERE id = $1 AND status = 'running'
+	`
+
+	if _, err := s.db.Exec(query, id, matched, processed, progress); err != nil {
+		return fmt.Errorf("failed to set review moderation progress: %w", mapError(err))
+	}
+	return nil
+}
+
+// CompleteReviewModerationJob marks a job as done after processing its last review
+func (s *PostgresReviewModerationStore) CompleteReviewModerationJob(id int64, processed int) error {
+	query := `
+		UPDATE review_moderation_jobs
+		SET status = 'done', progress = 100, processed = $2, error = NULL, completed_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := s.db.Exec(query, id, processed); err != nil {
+		return fmt.Errorf("failed to complete review moderation job: %w", mapError(err))
+	}
+	return nil
+}
+
+// FailReviewModerationJob marks a job as failed with a message for the admins
+func (s *PostgresReviewModerationStore) FailReviewModerationJob(id int64, message string) error {
+	query := `UPDATE review_moderation_jobs SET status = 'failed', error = $2, completed_at = NOW() WHERE id = $1`
+
+	if _, err := s.db.Exec(query, id, message); err != nil {
+		return fmt.Errorf("failed to mark review moderation job failed: %w", mapError(err))
+	}
+	return nil
+}
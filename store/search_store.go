@@ -0,0 +1,624 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+const (
+	// SuggestionTypeRecipe is a published recipe title
+	SuggestionTypeRecipe = "recipe"
+
+	// SuggestionTypeTag is a recipe tag
+	SuggestionTypeTag = "tag"
+
+	// SuggestionTypeChef is the username of a user with at least one published recipe
+	SuggestionTypeChef = "chef"
+)
+
+// SearchSuggestion is a single typeahead match
+// ID is the recipe ID, tag ID, or the chef's public user ID, depending on Type
+type SearchSuggestion struct {
+	Type  string  `json:"type"`
+	ID    string  `json:"id"`
+	Text  string  `json:"text"`
+	Score float64 `json:"score"`
+}
+
+// RecipeSearchResult is a recipe matching a full-text query
+// TitleHighlight and DescriptionSnippet are HTML-escaped with matched terms wrapped in <mark> tags
+type RecipeSearchResult struct {
+	Recipe             *Recipe `json:"recipe"`
+	TitleHighlight     string  `json:"title_highlight"`
+	DescriptionSnippet *string `json:"description_snippet,omitempty"`
+	Rank               float64 `json:"rank"`
+}
+
+// TotalTimeFacetLimits are the max_total_time values, in minutes, that total time facets count recipes for
+var TotalTimeFacetLimits = []int{15, 30, 60, 120}
+
+// RecipeFacets counts the recipes matching a listing or search for each value of a filter, so filter sidebars can show
+// how many recipes each option leaves. Each facet ignores its own filter: with difficulty=easy applied, the difficulty
+// facet still counts medium and hard recipes, while the other facets count only easy ones
+type RecipeFacets struct {
+	Difficulty []*DifficultyFacet `json:"difficulty"`
+	Category   []*CategoryFacet   `json:"category"`
+	TotalTime  []*TotalTimeFacet  `json:"total_time"`
+}
+
+// DifficultyFacet is the number of matching recipes of a difficulty
+type DifficultyFacet struct {
+	Difficulty DifficultyLevel `json:"difficulty"`
+	Count      int             `json:"count"`
+}
+
+// CategoryFacet is the number of matching recipes in a category
+type CategoryFacet struct {
+	CategoryID   int64  `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	Count        int    `json:"count"`
+}
+
+// TotalTimeFacet is the number of matching recipes that take at most MaxTotalTime minutes
+// The buckets overlap, just like the max_total_time filter they correspond to
+type TotalTimeFacet struct {
+	MaxTotalTime int `json:"max_total_time"`
+	Count        int `json:"count"`
+}
+
+// NewRecipeFacets returns facets with every difficulty and total time limit counted as zero and no categories
+func NewRecipeFacets() *RecipeFacets {
+	facets := &RecipeFacets{Category: []*CategoryFacet{}}
+	for _, difficulty := range []DifficultyLevel{DifficultyEasy, DifficultyMedium, DifficultyHard} {
+		facets.Difficulty = append(facets.Difficulty, &DifficultyFacet{Difficulty: difficulty})
+	}
+	for _, limit := range TotalTimeFacetLimits {
+		facets.TotalTime = append(facets.TotalTime, &TotalTimeFacet{MaxTotalTime: limit})
+	}
+	return facets
+}
+
+// SearchDocument is the denormalized view of a published recipe sent to an external search engine
+type SearchDocument struct {
+	ID                      int64            `json:"id"`
+	Title                   string           `json:"title"`
+	Description             *string          `json:"description"`
+	CategoryID              *int64           `json:"category_id"`
+	CategoryName            *string          `json:"category_name"`
+	DifficultyLevel         *DifficultyLevel `json:"difficulty_level"`
+	ServingSize             *int             `json:"serving_size"`
+	TotalTime               *int             `json:"total_time"`
+	EstimatedCostPerServing *float64         `json:"estimated_cost_per_serving"`
+	AverageRating           *float64         `json:"average_rating"`
+	DietaryLabels           []string         `json:"dietary_labels"`
+	Language                *string          `json:"language"`
+	PublishedAt             *time.Time       `json:"published_at"`
+}
+
+// SearchSync is a queued change to a recipe's search document, written by database triggers
+type SearchSync struct {
+	ID       int64     `json:"id"`
+	RecipeID int64     `json:"recipe_id"`
+	QueuedAt time.Time `json:"queued_at"`
+}
+
+// SearchStore defines the interface for search operations
+type SearchStore interface {
+	Suggest(query string, limit int) ([]*SearchSuggestion, error)
+	SearchRecipes(ctx context.Context, query string, opts RecipeListOptions) ([]*RecipeSearchResult, int, error)
+	SearchFacets(ctx context.Context, query string, opts RecipeListOptions) (*RecipeFacets, error)
+	GetRecipesByIDs(ctx context.Context, ids []int64) ([]*Recipe, error)
+	GetCategoryNames(ctx context.Context, ids []int64) (map[int64]string, error)
+
+	GetSearchDocuments(recipeIDs []int64) ([]*SearchDocument, error)
+	GetSearchDocumentsAfter(afterID int64, limit int) ([]*SearchDocument, error)
+	GetPendingSearchSyncs(limit int) ([]*SearchSync, error)
+	DeleteSearchSyncs(ids []int64) error
+
+	ClaimSearchIndexVersion(engine string, version int) (bool, error)
+	ResetSearchIndexVersion(engine string, version int) error
+}
+
+// PostgresSearchStore implements the SearchStore interface using PostgreSQL
+type PostgresSearchStore struct {
+	db *sql.DB
+}
+
+// NewPostgresSearchStore creates a new PostgresSearchStore
+func NewPostgresSearchStore(db *sql.DB) *PostgresSearchStore {
+	return &PostgresSearchStore{
+		db: db,
+	}
+}
+
+// Suggest returns recipe titles, tags, and chef usernames containing the query, best matches first
+// Prefix matches outrank matches elsewhere in the text; ties are broken by trigram similarity, then chef reputation.
+// Substring matching is served by the trigram indexes on each source
+func (s *PostgresSearchStore) Suggest(query string, limit int) ([]*SearchSuggestion, error) {
+	sqlQuery := `
+		(
+			SELECT '` + SuggestionTypeRecipe + `' AS type, r.id::TEXT AS id, r.title AS text,
+				(LOWER(r.title) LIKE $1 || '%')::INT + similarity(LOWER(r.title), $2) AS score
+			FROM recipes r
+			WHERE r.status = 'published' AND LOWER(r.title) LIKE '%' || $1 || '%'
+			ORDER BY score DESC
+			LIMIT $3
+		)
+		UNION ALL
+		(
+			SELECT '` + SuggestionTypeTag + `', t.id::TEXT, t.name,
+				(LOWER(t.name) LIKE $1 || '%')::INT + similarity(LOWER(t.name), $2) AS score
+			FROM tags t
+			WHERE LOWER(t.name) LIKE '%' || $1 || '%'
+			ORDER BY score DESC
+			LIMIT $3
+		)
+		UNION ALL
+		(
+			SELECT '` + SuggestionTypeChef + `', u.user_id, u.username,
+				(LOWER(u.username) LIKE $1 || '%')::INT + similarity(LOWER(u.username), $2) AS score
+			FROM users u
+			WHERE LOWER(u.username) LIKE '%' || $1 || '%'
+				AND EXISTS (SELECT 1 FROM recipes r WHERE r.user_id = u.id AND r.status = 'published')
+			ORDER BY score DESC, u.reputation DESC
+			LIMIT $3
+		)
+		ORDER BY score DESC, text
+		LIMIT $3
+	`
+
+	normalized := strings.ToLower(strings.TrimSpace(query))
+
+	rows, err := s.db.Query(sqlQuery, escapeLikePattern(normalized), normalized, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := []*SearchSuggestion{}
+	for rows.Next() {
+		suggestion := &SearchSuggestion{}
+		err := rows.Scan(&suggestion.Type, &suggestion.ID, &suggestion.Text, &suggestion.Score)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search suggestion: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over search suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// recipeSearchDocument is the text searched for each recipe (r)
+// It must match the expression of the idx_recipes_published_search index
+const recipeSearchDocument = `to_tsvector('english', r.title || ' ' || COALESCE(r.description, ''))`
+
+// HighlightStart and HighlightStop wrap matched terms in raw highlights from a search engine
+// Control characters are used so the text can be HTML-escaped before the markers are turned into <mark> tags
+const (
+	HighlightStart = "\x02"
+	HighlightStop  = "\x03"
+)
+
+// ts_headline options for titles (whole text) and descriptions (up to two short fragments)
+const (
+	highlightSelectors          = `StartSel="` + HighlightStart + `", StopSel="` + HighlightStop + `"`
+	titleHighlightOptions       = highlightSelectors + `, HighlightAll=true`
+	descriptionHighlightOptions = highlightSelectors + `, MaxFragments=2, MinWords=5, MaxWords=20, FragmentDelimiter=" … "`
+)
+
+var highlightReplacer = strings.NewReplacer(HighlightStart, "<mark>", HighlightStop, "</mark>")
+
+// HighlightToHTML escapes a raw highlight and converts its markers to <mark> tags
+func HighlightToHTML(headline string) string {
+	return highlightReplacer.Replace(html.EscapeString(headline))
+}
+
+// SearchRecipes returns a page of published recipes matching a full-text query, best matches first, along with the total match count
+// The query accepts web search syntax (quoted phrases, OR, -exclusions). Listing filters in opts are applied; Sort is ignored.
+// Equally ranked matches are ordered by their author's reputation
+func (s *PostgresSearchStore) SearchRecipes(ctx context.Context, query string, opts RecipeListOptions) ([]*RecipeSearchResult, int, error) {
+	q := newRecipeListQuery(opts)
+	tsQuery := "websearch_to_tsquery('english', " + q.addArg(query) + ")"
+	q.where(recipeSearchDocument + " @@ " + tsQuery)
+
+	sqlQuery := `
+		SELECT ` + recipeListColumns + `,
+			ts_headline('english', r.title, ` + tsQuery + `, ` + q.addArg(titleHighlightOptions) + `),
+			CASE WHEN r.description IS NULL OR r.description = '' THEN NULL
+				ELSE ts_headline('english', r.description, ` + tsQuery + `, ` + q.addArg(descriptionHighlightOptions) + `)
+			END,
+			ts_rank(` + recipeSearchDocument + `, ` + tsQuery + `)::FLOAT8 AS rank,
+			COUNT(*) OVER() AS total_count
+		` + recipeListFrom + `
+		WHERE ` + q.whereClause() + `
+		ORDER BY rank DESC, (SELECT u.reputation FROM users u WHERE u.id = r.user_id) DESC, r.id DESC
+		LIMIT ` + q.addArg(opts.Limit) + ` OFFSET ` + q.addArg((opts.Page-1)*opts.Limit)
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery, q.args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search recipes: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*RecipeSearchResult{}
+	total := 0
+	for rows.Next() {
+		result := &RecipeSearchResult{Recipe: &Recipe{}}
+		err := scanRecipeListRow(rows, result.Recipe,
+			&result.TitleHighlight,
+			&result.DescriptionSnippet,
+			&result.Rank,
+			&total,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan recipe search result: %w", err)
+		}
+
+		result.TitleHighlight = HighlightToHTML(result.TitleHighlight)
+		if result.DescriptionSnippet != nil {
+			snippet := HighlightToHTML(*result.DescriptionSnippet)
+			result.DescriptionSnippet = &snippet
+		}
+
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over recipe search results: %w", err)
+	}
+
+	return results, total, nil
+}
+
+// SearchFacets counts the recipes SearchRecipes would match for each difficulty, category, and total time limit
+func (s *PostgresSearchStore) SearchFacets(ctx context.Context, query string, opts RecipeListOptions) (*RecipeFacets, error) {
+	return getRecipeFacets(ctx, s.db, opts, func(q *recipeListQuery) {
+		q.where(recipeSearchDocument + " @@ websearch_to_tsquery('english', " + q.addArg(query) + ")")
+	})
+}
+
+// getRecipeFacets counts the recipes matching opts for each facet value, with one grouped query per facet
+// restrict adds conditions shared by every facet, such as a full-text match
+func getRecipeFacets(ctx context.Context, db *sql.DB, opts RecipeListOptions, restrict func(q *recipeListQuery)) (*RecipeFacets, error) {
+	facets := NewRecipeFacets()
+
+	newQuery := func(opts RecipeListOptions) *recipeListQuery {
+		q := newRecipeListQuery(opts)
+		if restrict != nil {
+			restrict(q)
+		}
+		return q
+	}
+
+	withoutDifficulty := opts
+	withoutDifficulty.Difficulty = nil
+	q := newQuery(withoutDifficulty)
+	rows, err := db.QueryContext(ctx, `
+		SELECT r.difficulty_level, COUNT(*)
+		`+recipeListFrom+`
+		WHERE `+q.whereClause()+`
+		GROUP BY r.difficulty_level`, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count difficulty facets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var difficulty DifficultyLevel
+		var count int
+		if err := rows.Scan(&difficulty, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan difficulty facet: %w", err)
+		}
+		for _, facet := range facets.Difficulty {
+			if facet.Difficulty == difficulty {
+				facet.Count = count
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over difficulty facets: %w", err)
+	}
+
+	withoutCategory := opts
+	withoutCategory.CategoryID = nil
+	q = newQuery(withoutCategory)
+	rows, err = db.QueryContext(ctx, `
+		SELECT c.id, c.name, COUNT(*) AS count
+		`+recipeListFrom+`
+		WHERE `+q.whereClause()+` AND c.id IS NOT NULL
+		GROUP BY c.id, c.name
+		ORDER BY count DESC, c.name`, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count category facets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		facet := &CategoryFacet{}
+		if err := rows.Scan(&facet.CategoryID, &facet.CategoryName, &facet.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan category facet: %w", err)
+		}
+		facets.Category = append(facets.Category, facet)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over category facets: %w", err)
+	}
+
+	withoutTotalTime := opts
+	withoutTotalTime.MaxTotalTime = nil
+	q = newQuery(withoutTotalTime)
+	counts := make([]string, len(facets.TotalTime))
+	dest := make([]interface{}, len(facets.TotalTime))
+	for i, facet := range facets.TotalTime {
+		counts[i] = "COUNT(*) FILTER (WHERE r.total_time <= " + q.addArg(facet.MaxTotalTime) + ")"
+		dest[i] = &facet.Count
+	}
+	err = db.QueryRowContext(ctx, `
+		SELECT `+strings.Join(counts, ", ")+`
+		`+recipeListFrom+`
+		WHERE `+q.whereClause(), q.args...).Scan(dest...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count total time facets: %w", err)
+	}
+
+	return facets, nil
+}
+
+// whereIDIn restricts the query to recipes with the given IDs
+func (q *recipeListQuery) whereIDIn(ids []int64) {
+	placeholders := make([]string, len(ids))
+	for i, id := range ids {
+		placeholders[i] = q.addArg(id)
+	}
+	q.where("r.id IN (" + strings.Join(placeholders, ", ") + ")")
+}
+
+// GetRecipesByIDs returns the published recipes with the given IDs, in the same order
+// IDs that do not match a published recipe are skipped. The query is cancelled along with ctx
+func (s *PostgresSearchStore) GetRecipesByIDs(ctx context.Context, ids []int64) ([]*Recipe, error) {
+	if len(ids) == 0 {
+		return []*Recipe{}, nil
+	}
+
+	q := newRecipeListQuery(RecipeListOptions{})
+	q.whereIDIn(ids)
+
+	query := `
+		SELECT ` + recipeListColumns + `
+		` + recipeListFrom + `
+		WHERE ` + q.whereClause()
+
+	rows, err := s.db.QueryContext(ctx, query, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipes by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[int64]*Recipe, len(ids))
+	for rows.Next() {
+		recipe := &Recipe{}
+		if err := scanRecipeListRow(rows, recipe); err != nil {
+			return nil, fmt.Errorf("failed to scan recipe: %w", err)
+		}
+		byID[recipe.ID] = recipe
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over recipes: %w", err)
+	}
+
+	recipes := make([]*Recipe, 0, len(byID))
+	for _, id := range ids {
+		if recipe, ok := byID[id]; ok {
+			recipes = append(recipes, recipe)
+		}
+	}
+
+	return recipes, nil
+}
+
+// GetCategoryNames returns the names of the categories with the given IDs, keyed by ID
+func (s *PostgresSearchStore) GetCategoryNames(ctx context.Context, ids []int64) (map[int64]string, error) {
+	names := make(map[int64]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name FROM categories WHERE id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category names: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan category name: %w", err)
+		}
+		names[id] = name
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over category names: %w", err)
+	}
+
+	return names, nil
+}
+
+// searchDocumentQuery selects SearchDocument columns for published recipes (r); callers append conditions
+const searchDocumentQuery = `
+	SELECT
+		r.id, r.title, r.description, r.category_id, c.name,
+		r.difficulty_level, r.serving_size, r.total_time,
+		` + costPerServingExpr + `,
+		` + averageRatingExpr + `,
+		r.published_at, r.dietary_labels, r.language
+	` + recipeListFrom + `
+	WHERE r.status = 'published'`
+
+func (s *PostgresSearchStore) querySearchDocuments(query string, args ...interface{}) ([]*SearchDocument, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search documents: %w", err)
+	}
+	defer rows.Close()
+
+	documents := []*SearchDocument{}
+	for rows.Next() {
+		doc := &SearchDocument{}
+		var dietaryLabels []byte
+		err := rows.Scan(
+			&doc.ID,
+			&doc.Title,
+			&doc.Description,
+			&doc.CategoryID,
+			&doc.CategoryName,
+			&doc.DifficultyLevel,
+			&doc.ServingSize,
+			&doc.TotalTime,
+			&doc.EstimatedCostPerServing,
+			&doc.AverageRating,
+			&doc.PublishedAt,
+			&dietaryLabels,
+			&doc.Language,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search document: %w", err)
+		}
+		if err := json.Unmarshal(dietaryLabels, &doc.DietaryLabels); err != nil {
+			return nil, fmt.Errorf("failed to decode dietary labels: %w", err)
+		}
+		documents = append(documents, doc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over search documents: %w", err)
+	}
+
+	return documents, nil
+}
+
+// GetSearchDocuments returns search documents for the given recipes
+// Recipes that no longer exist or are not published are omitted and should be removed from the index
+func (s *PostgresSearchStore) GetSearchDocuments(recipeIDs []int64) ([]*SearchDocument, error) {
+	if len(recipeIDs) == 0 {
+		return []*SearchDocument{}, nil
+	}
+
+	q := &recipeListQuery{}
+	q.whereIDIn(recipeIDs)
+
+	return s.querySearchDocuments(searchDocumentQuery+` AND `+q.whereClause(), q.args...)
+}
+
+// GetSearchDocumentsAfter returns up to limit search documents with IDs greater than afterID, in ID order
+// It is used to page through every published recipe when rebuilding an index
+func (s *PostgresSearchStore) GetSearchDocumentsAfter(afterID int64, limit int) ([]*SearchDocument, error) {
+	return s.querySearchDocuments(searchDocumentQuery+` AND r.id > $1 ORDER BY r.id LIMIT $2`, afterID, limit)
+}
+
+// GetPendingSearchSyncs returns up to limit queued search document changes, oldest first
+func (s *PostgresSearchStore) GetPendingSearchSyncs(limit int) ([]*SearchSync, error) {
+	query := `
+		SELECT id, recipe_id, queued_at
+		FROM search_outbox
+		ORDER BY id
+		LIMIT $1
+	`
+
+	rows, err := s.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending search syncs: %w", err)
+	}
+	defer rows.Close()
+
+	syncs := []*SearchSync{}
+	for rows.Next() {
+		sync := &SearchSync{}
+		if err := rows.Scan(&sync.ID, &sync.RecipeID, &sync.QueuedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan search sync: %w", err)
+		}
+		syncs = append(syncs, sync)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over search syncs: %w", err)
+	}
+
+	return syncs, nil
+}
+
+// DeleteSearchSyncs removes queued changes once they have been synced
+func (s *PostgresSearchStore) DeleteSearchSyncs(ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	_, err := s.db.Exec(`DELETE FROM search_outbox WHERE id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete search syncs: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// ClaimSearchIndexVersion records that an engine's index is being rebuilt for version
+// Reports true only to the one caller that changed the recorded version, so concurrently starting instances
+// rebuild the index once; false means the index is already at version or another instance claimed it
+func (s *PostgresSearchStore) ClaimSearchIndexVersion(engine string, version int) (bool, error) {
+	var claimed string
+	err := s.db.QueryRow(`
+		INSERT INTO search_index_versions (engine, version)
+		VALUES ($1, $2)
+		ON CONFLICT (engine) DO UPDATE
+		SET version = EXCLUDED.version, updated_at = NOW()
+		WHERE search_index_versions.version <> EXCLUDED.version
+		RETURNING engine
+	`, engine, version).Scan(&claimed)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim search index version: %w", mapError(err))
+	}
+
+	return true, nil
+}
+
+// ResetSearchIndexVersion forgets a claimed version after its rebuild failed, so the next start tries again
+// It leaves the record alone if another version has been claimed since
+func (s *PostgresSearchStore) ResetSearchIndexVersion(engine string, version int) error {
+	_, err := s.db.Exec(`
+		DELETE FROM search_index_versions
+		WHERE engine = $1 AND version = $2
+	`, engine, version)
+	if err != nil {
+		return fmt.Errorf("failed to reset search index version: %w", mapError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,496 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	// ShoppingListRoleOwner is the role of the user who created a shopping list
+	ShoppingListRoleOwner = "owner"
+
+	// ShoppingListRoleMember is the role of a user a shopping list has been shared with
+	ShoppingListRoleMember = "member"
+)
+
+// ShoppingList is a named list of items that can be shared for collaborative check-off
+type ShoppingList struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	Name       string    `json:"name"`
+	ShareToken *string   `json:"share_token,omitempty"`
+	Role       string    `json:"role"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ShoppingListItem is a single entry on a shopping list
+type ShoppingListItem struct {
+	ID                int64     `json:"id"`
+	ListID            int64     `json:"list_id"`
+	Name              string    `json:"name"`
+	Quantity          *float64  `json:"quantity,omitempty"`
+	Unit              *string   `json:"unit,omitempty"`
+	Checked           bool      `json:"checked"`
+	UpdatedBy         *int64    `json:"updated_by,omitempty"`
+	UpdatedByUsername *string   `json:"updated_by_username,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// ShoppingListMember is a user a shopping list has been shared with
+type ShoppingListMember struct {
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ShoppingListStore defines the interface for shopping list operations
+type ShoppingListStore interface {
+	CreateShoppingList(list *ShoppingList) error
+	GetShoppingListsForUser(userID int64) ([]*ShoppingList, error)
+	GetShoppingList(listID int64, userID int64) (*ShoppingList, error)
+	DeleteShoppingList(listID int64) error
+
+	AddShoppingListItem(item *ShoppingListItem, userID int64) error
+	GetShoppingListItems(listID int64) ([]*ShoppingListItem, error)
+	SetShoppingListItemChecked(listID int64, itemID int64, checked bool, userID int64) (*ShoppingListItem, error)
+	DeleteShoppingListItem(listID int64, itemID int64) error
+
+	AddShoppingListMember(listID int64, username string) error
+	RemoveShoppingListMember(listID int64, username string) error
+	GetShoppingListMembers(listID int64) ([]*ShoppingListMember, error)
+
+	CreateShareToken(listID int64) (string, error)
+	RevokeShareToken(listID int64) error
+	JoinShoppingListByToken(token string, userID int64) (*ShoppingList, error)
+}
+
+// PostgresShoppingListStore implements the ShoppingListStore interface using PostgreSQL
+type PostgresShoppingListStore struct {
+	db *sql.DB
+}
+
+// NewPostgresShoppingListStore creates a new PostgresShoppingListStore
+func NewPostgresShoppingListStore(db *sql.DB) *PostgresShoppingListStore {
+	return &PostgresShoppingListStore{
+		db: db,
+	}
+}
+
+// CreateShoppingList creates a new shopping list owned by list.UserID
+func (s *PostgresShoppingListStore) CreateShoppingList(list *ShoppingList) error {
+	query := `
+		INSERT INTO shopping_lists (user_id, name)
+		VALUES ($1, $2)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := s.db.QueryRow(query, list.UserID, list.Name).Scan(&list.ID, &list.CreatedAt, &list.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create shopping list: %w", mapError(err))
+	}
+	list.Role = ShoppingListRoleOwner
+
+	return nil
+}
+
+// GetShoppingListsForUser returns the lists a user owns or has been shared, most recently updated first
+func (s *PostgresShoppingListStore) GetShoppingListsForUser(userID int64) ([]*ShoppingList, error) {
+	query := `
+		SELECT l.id, l.user_id, l.name, l.share_token, l.created_at, l.updated_at,
+			CASE WHEN l.user_id = $1 THEN 'owner' ELSE 'member' END AS role
+		FROM shopping_lists l
+		LEFT JOIN shopping_list_members m ON m.list_id = l.id AND m.user_id = $1
+		WHERE l.user_id = $1 OR m.user_id IS NOT NULL
+		ORDER BY l.updated_at DESC
+	`
+
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shopping lists: %w", err)
+	}
+	defer rows.Close()
+
+	lists := []*ShoppingList{}
+	for rows.Next() {
+		list := &ShoppingList{}
+		err := rows.Scan(&list.ID, &list.UserID, &list.Name, &list.ShareToken, &list.CreatedAt, &list.UpdatedAt, &list.Role)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shopping list: %w", err)
+		}
+		// Only the owner may see the share token
+		if list.Role != ShoppingListRoleOwner {
+			list.ShareToken = nil
+		}
+		lists = append(lists, list)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over shopping lists: %w", err)
+	}
+
+	return lists, nil
+}
+
+// GetShoppingList returns a list if the user owns it or is a member
+// Returns nil if the list does not exist or the user has no access
+func (s *PostgresShoppingListStore) GetShoppingList(listID int64, userID int64) (*ShoppingList, error) {
+	query := `
+		SELECT l.id, l.user_id, l.name, l.share_token, l.created_at, l.updated_at,
+			CASE WHEN l.user_id = $2 THEN 'owner' ELSE 'member' END AS role
+		FROM shopping_lists l
+		LEFT JOIN shopping_list_members m ON m.list_id = l.id AND m.user_id = $2
+		WHERE l.id = $1 AND (l.user_id = $2 OR m.user_id IS NOT NULL)
+	`
+
+	list := &ShoppingList{}
+	err := s.db.QueryRow(query, listID, userID).Scan(
+		&list.ID,
+		&list.UserID,
+		&list.Name,
+		&list.ShareToken,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+		&list.Role,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get shopping list: %w", err)
+	}
+
+	if list.Role != ShoppingListRoleOwner {
+		list.ShareToken = nil
+	}
+
+	return list, nil
+}
+
+// DeleteShoppingList deletes a list along with its items and members
+func (s *PostgresShoppingListStore) DeleteShoppingList(listID int64) error {
+	query := `
+		DELETE FROM shopping_lists
+		WHERE id = $1
+	`
+
+	result, err := s.db.Exec(query, listID)
+	if err != nil {
+		return fmt.Errorf("failed to delete shopping list: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// touchShoppingList bumps a list's updated_at so collaborators can detect changes
+func (s *PostgresShoppingListStore) touchShoppingList(listID int64) error {
+	_, err := s.db.Exec(`UPDATE shopping_lists SET updated_at = NOW() WHERE id = $1`, listID)
+	if err != nil {
+		return fmt.Errorf("failed to update shopping list timestamp: %w", mapError(err))
+	}
+	return nil
+}
+
+// AddShoppingListItem adds an item to a list, recording who added it
+func (s *PostgresShoppingListStore) AddShoppingListItem(item *ShoppingListItem, userID int64) error {
+	query := `
+		INSERT INTO shopping_list_items (list_id, name, quantity, unit, updated_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, checked, updated_by, created_at, updated_at
+	`
+
+	err := s.db.QueryRow(
+		query,
+		item.ListID,
+		item.Name,
+		item.Quantity,
+		item.Unit,
+		userID,
+	).Scan(&item.ID, &item.Checked, &item.UpdatedBy, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add shopping list item: %w", mapError(err))
+	}
+
+	return s.touchShoppingList(item.ListID)
+}
+
+// GetShoppingListItems returns a list's items with unchecked items first
+func (s *PostgresShoppingListStore) GetShoppingListItems(listID int64) ([]*ShoppingListItem, error) {
+	query := `
+		SELECT i.id, i.list_id, i.name, i.quantity, i.unit, i.checked,
+			i.updated_by, u.username, i.created_at, i.updated_at
+		FROM shopping_list_items i
+		LEFT JOIN users u ON u.id = i.updated_by
+		WHERE i.list_id = $1
+		ORDER BY i.checked, i.created_at
+	`
+
+	rows, err := s.db.Query(query, listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shopping list items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*ShoppingListItem{}
+	for rows.Next() {
+		item := &ShoppingListItem{}
+		err := rows.Scan(
+			&item.ID,
+			&item.ListID,
+			&item.Name,
+			&item.Quantity,
+			&item.Unit,
+			&item.Checked,
+			&item.UpdatedBy,
+			&item.UpdatedByUsername,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shopping list item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over shopping list items: %w", err)
+	}
+
+	return items, nil
+}
+
+// SetShoppingListItemChecked sets an item's checked state to an explicit value
+// Setting the desired state rather than flipping it keeps concurrent check-offs from undoing each other
+// Returns nil if the item does not exist on the list
+func (s *PostgresShoppingListStore) SetShoppingListItemChecked(listID int64, itemID int64, checked bool, userID int64) (*ShoppingListItem, error) {
+	query := `
+		WITH updated AS (
+			UPDATE shopping_list_items
+			SET
+				checked = $3,
+				updated_by = CASE WHEN checked = $3 THEN updated_by ELSE $4 END,
+				updated_at = CASE WHEN checked = $3 THEN updated_at ELSE NOW() END
+			WHERE id = $2 AND list_id = $1
+			RETURNING id, list_id, name, quantity, unit, checked, updated_by, created_at, updated_at
+		)
+		SELECT updated.id, updated.list_id, updated.name, updated.quantity, updated.unit, updated.checked,
+			updated.updated_by, u.username, updated.created_at, updated.updated_at
+		FROM updated
+		LEFT JOIN users u ON u.id = updated.updated_by
+	`
+
+	item := &ShoppingListItem{}
+	err := s.db.QueryRow(query, listID, itemID, checked, userID).Scan(
+		&item.ID,
+		&item.ListID,
+		&item.Name,
+		&item.Quantity,
+		&item.Unit,
+		&item.Checked,
+		&item.UpdatedBy,
+		&item.UpdatedByUsername,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update shopping list item: %w", mapError(err))
+	}
+
+	if err := s.touchShoppingList(listID); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// DeleteShoppingListItem removes an item from a list
+func (s *PostgresShoppingListStore) DeleteShoppingListItem(listID int64, itemID int64) error {
+	query := `
+		DELETE FROM shopping_list_items
+		WHERE id = $1 AND list_id = $2
+	`
+
+	result, err := s.db.Exec(query, itemID, listID)
+	if err != nil {
+		return fmt.Errorf("failed to delete shopping list item: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return s.touchShoppingList(listID)
+}
+
+// AddShoppingListMember shares a list with the user with the given username
+// Returns ErrNotFound if no such user exists; sharing with the owner or an existing member is a no-op
+func (s *PostgresShoppingListStore) AddShoppingListMember(listID int64, username string) error {
+	var memberID int64
+	err := s.db.QueryRow(`SELECT id FROM users WHERE username = $1`, username).Scan(&memberID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to look up user: %w", err)
+	}
+
+	query := `
+		INSERT INTO shopping_list_members (list_id, user_id)
+		SELECT id, $2 FROM shopping_lists WHERE id = $1 AND user_id <> $2
+		ON CONFLICT (list_id, user_id) DO NOTHING
+	`
+
+	_, err = s.db.Exec(query, listID, memberID)
+	if err != nil {
+		return fmt.Errorf("failed to add shopping list member: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// RemoveShoppingListMember revokes a user's access to a list
+func (s *PostgresShoppingListStore) RemoveShoppingListMember(listID int64, username string) error {
+	query := `
+		DELETE FROM shopping_list_members m
+		USING users u
+		WHERE m.user_id = u.id AND m.list_id = $1 AND u.username = $2
+	`
+
+	result, err := s.db.Exec(query, listID, username)
+	if err != nil {
+		return fmt.Errorf("failed to remove shopping list member: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetShoppingListMembers returns the users a list has been shared with
+func (s *PostgresShoppingListStore) GetShoppingListMembers(listID int64) ([]*ShoppingListMember, error) {
+	query := `
+		SELECT u.user_id, u.username, m.created_at
+		FROM shopping_list_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.list_id = $1
+		ORDER BY m.created_at
+	`
+
+	rows, err := s.db.Query(query, listID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shopping list members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []*ShoppingListMember{}
+	for rows.Next() {
+		member := &ShoppingListMember{}
+		if err := rows.Scan(&member.UserID, &member.Username, &member.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan shopping list member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over shopping list members: %w", err)
+	}
+
+	return members, nil
+}
+
+// CreateShareToken generates a new share link token for a list, replacing any existing one
+func (s *PostgresShoppingListStore) CreateShareToken(listID int64) (string, error) {
+	token, err := generateVerificationToken()
+	if err != nil {
+		return "", err
+	}
+
+	query := `
+		UPDATE shopping_lists
+		SET share_token = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	result, err := s.db.Exec(query, token, listID)
+	if err != nil {
+		return "", fmt.Errorf("failed to create share token: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return "", fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return "", ErrNotFound
+	}
+
+	return token, nil
+}
+
+// RevokeShareToken disables a list's share link; existing members keep access
+func (s *PostgresShoppingListStore) RevokeShareToken(listID int64) error {
+	query := `
+		UPDATE shopping_lists
+		SET share_token = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := s.db.Exec(query, listID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke share token: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// JoinShoppingListByToken adds the user as a member of the list with the given share token
+// Returns nil if the token does not match any list
+func (s *PostgresShoppingListStore) JoinShoppingListByToken(token string, userID int64) (*ShoppingList, error) {
+	var listID int64
+	err := s.db.QueryRow(`SELECT id FROM shopping_lists WHERE share_token = $1`, token).Scan(&listID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to look up share token: %w", err)
+	}
+
+	query := `
+		INSERT INTO shopping_list_members (list_id, user_id)
+		SELECT id, $2 FROM shopping_lists WHERE id = $1 AND user_id <> $2
+		ON CONFLICT (list_id, user_id) DO NOTHING
+	`
+
+	if _, err := s.db.Exec(query, listID, userID); err != nil {
+		return nil, fmt.Errorf("failed to join shopping list: %w", mapError(err))
+	}
+
+	return s.GetShoppingList(listID, userID)
+}
@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// DefaultSlowQueryThreshold is used when SLOW_QUERY_THRESHOLD_MS is not set
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// maxLoggedStatementLength keeps very large statements (bulk inserts) from flooding the log
+const maxLoggedStatementLength = 1000
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the request ID, so slow queries run with it can be traced to the request
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// slowQueryThresholdFromEnv reads SLOW_QUERY_THRESHOLD_MS; 0 or a negative value disables slow query logging
+func slowQueryThresholdFromEnv() time.Duration {
+	value := os.Getenv("SLOW_QUERY_THRESHOLD_MS")
+	if value == "" {
+		return DefaultSlowQueryThreshold
+	}
+
+	ms, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Warning: invalid SLOW_QUERY_THRESHOLD_MS %q, using %s", value, DefaultSlowQueryThreshold)
+		return DefaultSlowQueryThreshold
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// slowQueryLogger is a pgx logger that only reports statements slower than threshold
+type slowQueryLogger struct {
+	threshold time.Duration
+}
+
+// Log implements pgx.Logger
+func (l *slowQueryLogger) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	if msg != "Query" && msg != "Exec" {
+		return
+	}
+
+	elapsed, ok := data["time"].(time.Duration)
+	if !ok || elapsed < l.threshold {
+		return
+	}
+
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		requestID = "-"
+	}
+
+	log.Printf("Slow query (%s) request_id=%s args=%s sql=%s",
+		elapsed.Round(time.Millisecond), requestID, summarizeQueryArgs(data["args"]), compactStatement(data["sql"]))
+}
+
+// summarizeQueryArgs describes query arguments without logging string contents,
+// which may hold emails, tokens or password hashes
+func summarizeQueryArgs(args interface{}) string {
+	values, ok := args.([]interface{})
+	if !ok || len(values) == 0 {
+		return "[]"
+	}
+
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			parts = append(parts, "NULL")
+		case string:
+			parts = append(parts, fmt.Sprintf("string(len=%d)", len(v)))
+		case []byte:
+			parts = append(parts, fmt.Sprintf("bytes(len=%d)", len(v)))
+		case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			parts = append(parts, fmt.Sprint(v))
+		case time.Time:
+			parts = append(parts, v.UTC().Format(time.RFC3339))
+		default:
+			parts = append(parts, fmt.Sprintf("%T", v))
+		}
+	}
+
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// compactStatement collapses whitespace so multi-line statements fit on one log line
+func compactStatement(sql interface{}) string {
+	statement, _ := sql.(string)
+	statement = strings.Join(strings.Fields(statement), " ")
+	if len(statement) > maxLoggedStatementLength {
+		statement = statement[:maxLoggedStatementLength] + "..."
+	}
+	return statement
+}
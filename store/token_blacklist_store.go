@@ -43,7 +43,7 @@ func (s *PostgresTokenBlacklistStore) BlacklistToken(tokenString string, expires
 
 	_, err := s.db.Exec(query, tokenString, expiresAt)
 	if err != nil {
-		return fmt.Errorf("failed to blacklist token: %w", err)
+		return fmt.Errorf("failed to blacklist token: %w", mapError(err))
 	}
 
 	return nil
@@ -73,7 +73,7 @@ func (s *PostgresTokenBlacklistStore) CleanupExpiredTokens() (int64, error) {
 
 	result, err := s.db.Exec(query, time.Now())
 	if err != nil {
-		return 0, fmt.Errorf("failed to delete expired blacklisted tokens: %w", err)
+		return 0, fmt.Errorf("failed to delete expired blacklisted tokens: %w", mapError(err))
 	}
 
 	rowsAffected, err := result.RowsAffected()
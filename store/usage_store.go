@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// UsageIncrement is a number of requests to add to a user's usage for one day
+type UsageIncrement struct {
+	// UserID is the user's public ID
+	UserID string
+	// APIKeyID is the API key the requests were made with, or 0 for a sign-in token
+	APIKeyID int64
+	Metric   string
+	Day      time.Time
+	Count    int
+}
+
+// UsageRecord is a user's request count for one day, metric and API key
+type UsageRecord struct {
+	Day        string  `json:"day"`
+	Metric     string  `json:"metric"`
+	APIKeyID   *int64  `json:"api_key_id,omitempty"`
+	APIKeyName *string `json:"api_key_name,omitempty"`
+	Count      int     `json:"count"`
+}
+
+// UsageStore defines the interface for API usage rollups
+type UsageStore interface {
+	AddUsage(increments []*UsageIncrement) error
+	GetUsage(userID int64, since time.Time) ([]*UsageRecord, error)
+	CountUsage(userID string, metric string, day time.Time) (int, error)
+}
+
+// PostgresUsageStore implements the UsageStore interface using PostgreSQL
+type PostgresUsageStore struct {
+	db *sql.DB
+}
+
+// NewPostgresUsageStore creates a new PostgresUsageStore
+func NewPostgresUsageStore(db *sql.DB) *PostgresUsageStore {
+	return &PostgresUsageStore{
+		db: db,
+	}
+}
+
+// AddUsage adds request counts to the daily rollups in one transaction
+// Increments for users that no longer exist are dropped
+func (s *PostgresUsageStore) AddUsage(increments []*UsageIncrement) error {
+	if len(increments) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO api_usage (user_id, api_key_id, metric, day, request_count)
+		SELECT u.id, $2, $3, $4::DATE, $5
+		FROM users u
+		WHERE u.user_id = $1
+		ON CONFLICT (user_id, day, metric, api_key_id)
+		DO UPDATE SET request_count = api_usage.request_count + EXCLUDED.request_count
+	`
+
+	return WithTx(context.Background(), s.db, func(tx *sql.Tx) error {
+		for _, increment := range increments {
+			_, err := tx.Exec(query, increment.UserID, increment.APIKeyID, increment.Metric, increment.Day.Format(time.DateOnly), increment.Count)
+			if err != nil {
+				return fmt.Errorf("failed to add usage: %w", mapError(err))
+			}
+		}
+		return nil
+	})
+}
+
+// GetUsage returns a user's daily request counts since the given day, newest first
+func (s *PostgresUsageStore) GetUsage(userID int64, since time.Time) ([]*UsageRecord, error) {
+	query := `
+		SELECT to_char(a.day, 'YYYY-MM-DD'), a.metric, NULLIF(a.api_key_id, 0), k.name, a.request_count
+		FROM api_usage a
+		LEFT JOIN api_keys k ON k.id = a.api_key_id
+		WHERE a.user_id = $1 AND a.day >= $2::DATE
+		ORDER BY a.day DESC, a.metric, a.api_key_id
+	`
+
+	rows, err := s.db.Query(query, userID, since.Format(time.DateOnly))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+	defer rows.Close()
+
+	records := []*UsageRecord{}
+	for rows.Next() {
+		record := &UsageRecord{}
+		if err := rows.Scan(&record.Day, &record.Metric, &record.APIKeyID, &record.APIKeyName, &record.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan usage: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over usage: %w", err)
+	}
+
+	return records, nil
+}
+
+// CountUsage returns a user's stored request count for a metric on one day, across all their API keys
+func (s *PostgresUsageStore) CountUsage(userID string, metric string, day time.Time) (int, error) {
+	query := `
+		SELECT COALESCE(SUM(a.request_count), 0)
+		FROM api_usage a
+		JOIN users u ON u.id = a.user_id
+		WHERE u.user_id = $1 AND a.metric = $2 AND a.day = $3::DATE
+	`
+
+	var count int
+	if err := s.db.QueryRow(query, userID, metric, day.Format(time.DateOnly)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count usage: %w", err)
+	}
+
+	return count, nil
+}
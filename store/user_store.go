@@ -2,11 +2,73 @@ package store
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	// RoleUser is the default role for registered users
+	RoleUser = "user"
+
+	// RoleAdmin grants access to administrative endpoints
+	RoleAdmin = "admin"
+)
+
+const (
+	// SkillBeginner users are recommended easy recipes
+	SkillBeginner = "beginner"
+
+	// SkillIntermediate users are recommended easy and medium recipes
+	SkillIntermediate = "intermediate"
+
+	// SkillAdvanced users are recommended recipes of any difficulty
+	SkillAdvanced = "advanced"
+)
+
+// DietaryRestrictions lists the dietary restrictions users can choose from
+var DietaryRestrictions = []string{
+	"vegetarian", "vegan", "pescatarian", "gluten_free", "dairy_free", "nut_free", "halal", "kosher",
+}
+
+// IsValidDietaryRestriction reports whether a value is one of DietaryRestrictions
+func IsValidDietaryRestriction(restriction string) bool {
+	for _, r := range DietaryRestrictions {
+		if r == restriction {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidSkillLevel reports whether a value is a supported skill level
+func IsValidSkillLevel(level string) bool {
+	return level == SkillBeginner || level == SkillIntermediate || level == SkillAdvanced
+}
+
+// DifficultiesForSkill returns the recipe difficulties suited to a skill level
+// An unknown or empty skill level allows every difficulty
+func DifficultiesForSkill(level string) []DifficultyLevel {
+	switch level {
+	case SkillBeginner:
+		return []DifficultyLevel{DifficultyEasy}
+	case SkillIntermediate:
+		return []DifficultyLevel{DifficultyEasy, DifficultyMedium}
+	default:
+		return []DifficultyLevel{DifficultyEasy, DifficultyMedium, DifficultyHard}
+	}
+}
+
+// UserPreferences are the cooking preferences a user gives during onboarding
+type UserPreferences struct {
+	Cuisines            []string   `json:"cuisines"`
+	DietaryRestrictions []string   `json:"dietary_restrictions"`
+	SkillLevel          *string    `json:"skill_level"`
+	OnboardedAt         *time.Time `json:"onboarded_at"`
+}
+
 type password struct {
 	hash      []byte
 	plainText *string
@@ -23,8 +85,14 @@ type User struct {
 	ProfilePicture string   `json:"profile_picture"`
 	LastLogin      *string  `json:"last_login"`
 	EmailVerified  bool     `json:"email_verified"`
+	VerifiedChef   bool     `json:"verified_chef"`
+	Disabled       bool     `json:"-"`
+	HasPassword    bool     `json:"-"`
 	CreatedAt      string   `json:"created_at"`
 	UpdatedAt      string   `json:"updated_at"`
+
+	// Timezone is the IANA time zone the user is in, such as Europe/Paris; nil means UTC
+	Timezone *string `json:"timezone"`
 }
 
 func (password *password) SetPassword(plaintextPassword string) error {
@@ -53,7 +121,7 @@ func (s *PostgresUserStore) CreateUser(user *User) error {
 	err := s.db.QueryRow(query, user.UserID, user.Username, user.Email, user.PasswordHash.hash, user.Bio, user.FirstName, user.LastName, user.ProfilePicture).Scan(&user.UserID, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 	return nil
 }
@@ -66,7 +134,7 @@ func (s *PostgresUserStore) CreateUserWithTransaction(user *User, tx *sql.Tx) er
 	err := tx.QueryRow(query, user.UserID, user.Username, user.Email, user.PasswordHash.hash, user.Bio, user.FirstName, user.LastName, user.ProfilePicture).Scan(&user.UserID, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 	return nil
 }
@@ -74,7 +142,7 @@ func (s *PostgresUserStore) CreateUserWithTransaction(user *User, tx *sql.Tx) er
 func (s *PostgresUserStore) GetUserByEmail(email string) (*User, error) {
 	query := `
 		SELECT user_id, username, email, password_hash, bio, first_name, last_name, profile_picture, 
-		       last_login, email_verified, created_at, updated_at
+		       last_login, email_verified, is_verified_chef, disabled_at IS NOT NULL, has_password, created_at, updated_at, timezone
 		FROM users
 		WHERE email = $1
 	`
@@ -93,8 +161,12 @@ func (s *PostgresUserStore) GetUserByEmail(email string) (*User, error) {
 		&user.ProfilePicture,
 		&user.LastLogin,
 		&user.EmailVerified,
+		&user.VerifiedChef,
+		&user.Disabled,
+		&user.HasPassword,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Timezone,
 	)
 
 	if err != nil {
@@ -111,7 +183,7 @@ func (s *PostgresUserStore) GetUserByEmail(email string) (*User, error) {
 func (s *PostgresUserStore) GetUserByID(userID string) (*User, error) {
 	query := `
 		SELECT user_id, username, email, password_hash, bio, first_name, last_name, profile_picture, 
-		       last_login, email_verified, created_at, updated_at
+		       last_login, email_verified, is_verified_chef, disabled_at IS NOT NULL, has_password, created_at, updated_at, timezone
 		FROM users
 		WHERE user_id = $1
 	`
@@ -130,8 +202,12 @@ func (s *PostgresUserStore) GetUserByID(userID string) (*User, error) {
 		&user.ProfilePicture,
 		&user.LastLogin,
 		&user.EmailVerified,
+		&user.VerifiedChef,
+		&user.Disabled,
+		&user.HasPassword,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Timezone,
 	)
 
 	if err != nil {
@@ -151,15 +227,35 @@ type UserStore interface {
 	GetUserByEmail(email string) (*User, error)
 	GetUserByID(userID string) (*User, error)
 	UpdatePassword(userID string, newPassword string) error
+	UpdatePasswordWithTransaction(userID string, newPassword string, tx *sql.Tx) error
 	UpdateUser(userID string, updates map[string]interface{}) (*User, error)
 	UpdateLastLogin(userID string) error
 	IsUsernameTaken(username string, excludeUserID string) (bool, error)
 	SetEmailVerified(userID string, verified bool) error
+	GetUnverifiedUsersToWarn(warning int, createdBefore time.Time, limit int) ([]*User, error)
+	MarkUnverifiedWarningSent(userID string, warning int) error
+	DisableUnverifiedUsers(createdBefore time.Time, minWarnings int, warnedBefore time.Time) (int64, error)
+	PurgeDisabledUnverifiedUsers(disabledBefore time.Time) (int64, error)
+	GetUserInternalID(userID string) (int64, error)
+	GetUserInternalIDByUsername(username string) (int64, error)
+	GetUserRole(userID string) (string, error)
+	GetUserTimezone(id int64) (string, error)
+	GetUserPreferences(userID string) (*UserPreferences, error)
+	SaveUserPreferences(userID string, prefs *UserPreferences) error
 	DB() *sql.DB
 }
 
 // UpdatePassword updates a user's password
 func (s *PostgresUserStore) UpdatePassword(userID string, newPassword string) error {
+	return updatePassword(s.db, userID, newPassword)
+}
+
+// UpdatePasswordWithTransaction updates a user's password within a transaction
+func (s *PostgresUserStore) UpdatePasswordWithTransaction(userID string, newPassword string, tx *sql.Tx) error {
+	return updatePassword(tx, userID, newPassword)
+}
+
+func updatePassword(db DBTX, userID string, newPassword string) error {
 	// Create a temporary password struct to generate the hash
 	var pass password
 	if err := pass.SetPassword(newPassword); err != nil {
@@ -169,13 +265,13 @@ func (s *PostgresUserStore) UpdatePassword(userID string, newPassword string) er
 	// Update the password in the database
 	query := `
 		UPDATE users 
-		SET password_hash = $1, updated_at = CURRENT_TIMESTAMP
+		SET password_hash = $1, has_password = TRUE, updated_at = CURRENT_TIMESTAMP
 		WHERE user_id = $2
 	`
 
-	_, err := s.db.Exec(query, pass.hash, userID)
+	_, err := db.Exec(query, pass.hash, userID)
 	if err != nil {
-		return fmt.Errorf("failed to update password: %w", err)
+		return fmt.Errorf("failed to update password: %w", mapError(err))
 	}
 
 	return nil
@@ -203,7 +299,7 @@ func (s *PostgresUserStore) UpdateUser(userID string, updates map[string]interfa
 	}
 
 	// Add RETURNING clause to get the updated user data
-	query += " WHERE user_id = $" + fmt.Sprint(i) + " RETURNING user_id, username, email, password_hash, bio, first_name, last_name, profile_picture, last_login, created_at, updated_at"
+	query += " WHERE user_id = $" + fmt.Sprint(i) + " RETURNING user_id, username, email, password_hash, bio, first_name, last_name, profile_picture, last_login, email_verified, is_verified_chef, disabled_at IS NOT NULL, has_password, created_at, updated_at, timezone"
 	params = append(params, userID)
 
 	// Execute the query and scan results directly into a User object
@@ -220,12 +316,17 @@ func (s *PostgresUserStore) UpdateUser(userID string, updates map[string]interfa
 		&user.LastName,
 		&user.ProfilePicture,
 		&user.LastLogin,
+		&user.EmailVerified,
+		&user.VerifiedChef,
+		&user.Disabled,
+		&user.HasPassword,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Timezone,
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to update user: %w", err)
+		return nil, fmt.Errorf("failed to update user: %w", mapError(err))
 	}
 
 	user.PasswordHash.hash = passwordHash
@@ -242,7 +343,7 @@ func (s *PostgresUserStore) UpdateLastLogin(userID string) error {
 
 	_, err := s.db.Exec(query, userID)
 	if err != nil {
-		return fmt.Errorf("failed to update last_login: %w", err)
+		return fmt.Errorf("failed to update last_login: %w", mapError(err))
 	}
 
 	return nil
@@ -278,17 +379,257 @@ func (s *PostgresUserStore) IsUsernameTaken(username string, excludeUserID strin
 }
 
 // SetEmailVerified updates the email_verified status for a user
+// Verifying reactivates an account that was disabled for never verifying its email
 func (s *PostgresUserStore) SetEmailVerified(userID string, verified bool) error {
 	query := `
 		UPDATE users 
-		SET email_verified = $1, updated_at = CURRENT_TIMESTAMP
+		SET email_verified = $1,
+		    disabled_at = CASE WHEN $1 THEN NULL ELSE disabled_at END,
+		    updated_at = CURRENT_TIMESTAMP
 		WHERE user_id = $2
 	`
 
 	_, err := s.db.Exec(query, verified, userID)
 	if err != nil {
-		return fmt.Errorf("failed to update email verification status: %w", err)
+		return fmt.Errorf("failed to update email verification status: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// GetUserInternalID returns the numeric primary key for a user
+// Recipe-related tables reference users by this ID rather than the public user_id
+// Returns 0 if the user does not exist
+func (s *PostgresUserStore) GetUserInternalID(userID string) (int64, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE user_id = $1
+	`
+
+	var id int64
+	err := s.db.QueryRow(query, userID).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get user internal ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetUserInternalIDByUsername returns the numeric primary key for the user with a username, matched case-insensitively
+// Returns 0 if no user has the username
+func (s *PostgresUserStore) GetUserInternalIDByUsername(username string) (int64, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE LOWER(username) = LOWER($1)
+	`
+
+	var id int64
+	err := s.db.QueryRow(query, username).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get user internal ID by username: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetUserRole returns the role of a user
+// Returns an empty string if the user does not exist
+func (s *PostgresUserStore) GetUserRole(userID string) (string, error) {
+	query := `
+		SELECT role
+		FROM users
+		WHERE user_id = $1
+	`
+
+	var role string
+	err := s.db.QueryRow(query, userID).Scan(&role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get user role: %w", err)
+	}
+
+	return role, nil
+}
+
+// GetUserTimezone returns the time zone of the user with the given internal ID
+// Returns an empty string if the user has not set one or does not exist
+func (s *PostgresUserStore) GetUserTimezone(id int64) (string, error) {
+	var timezone sql.NullString
+	err := s.db.QueryRow(`SELECT timezone FROM users WHERE id = $1`, id).Scan(&timezone)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get user timezone: %w", err)
+	}
+
+	return timezone.String, nil
+}
+
+// GetUserPreferences returns a user's onboarding preferences
+// Returns nil if the user does not exist
+func (s *PostgresUserStore) GetUserPreferences(userID string) (*UserPreferences, error) {
+	query := `
+		SELECT preferred_cuisines, dietary_restrictions, skill_level, onboarded_at
+		FROM users
+		WHERE user_id = $1
+	`
+
+	prefs := &UserPreferences{}
+	var cuisines, restrictions []byte
+	err := s.db.QueryRow(query, userID).Scan(&cuisines, &restrictions, &prefs.SkillLevel, &prefs.OnboardedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user preferences: %w", err)
+	}
+
+	if err := json.Unmarshal(cuisines, &prefs.Cuisines); err != nil {
+		return nil, fmt.Errorf("failed to decode preferred cuisines: %w", err)
+	}
+	if err := json.Unmarshal(restrictions, &prefs.DietaryRestrictions); err != nil {
+		return nil, fmt.Errorf("failed to decode dietary restrictions: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// SaveUserPreferences replaces a user's onboarding preferences and marks onboarding as complete
+// Returns ErrNotFound if the user does not exist
+func (s *PostgresUserStore) SaveUserPreferences(userID string, prefs *UserPreferences) error {
+	cuisines, err := json.Marshal(prefs.Cuisines)
+	if err != nil {
+		return fmt.Errorf("failed to encode preferred cuisines: %w", err)
+	}
+	restrictions, err := json.Marshal(prefs.DietaryRestrictions)
+	if err != nil {
+		return fmt.Errorf("failed to encode dietary restrictions: %w", err)
+	}
+
+	query := `
+		UPDATE users
+		SET
+			preferred_cuisines = $1::JSONB,
+			dietary_restrictions = $2::JSONB,
+			skill_level = $3,
+			onboarded_at = COALESCE(onboarded_at, NOW()),
+			updated_at = NOW()
+		WHERE user_id = $4
+		RETURNING onboarded_at
+	`
+
+	err = s.db.QueryRow(query, string(cuisines), string(restrictions), prefs.SkillLevel, userID).Scan(&prefs.OnboardedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to save user preferences: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// GetUnverifiedUsersToWarn returns active users created before createdBefore who never verified their email
+// and have received fewer than warning expiry warnings, oldest first
+func (s *PostgresUserStore) GetUnverifiedUsersToWarn(warning int, createdBefore time.Time, limit int) ([]*User, error) {
+	query := `
+		SELECT user_id, username, email, first_name, created_at
+		FROM users
+		WHERE email_verified = false AND disabled_at IS NULL
+		  AND created_at < $1 AND unverified_warnings_sent < $2
+		ORDER BY created_at
+		LIMIT $3
+	`
+
+	rows, err := s.db.Query(query, createdBefore, warning, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unverified users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*User{}
+	for rows.Next() {
+		user := &User{}
+		var firstName sql.NullString
+		if err := rows.Scan(&user.UserID, &user.Username, &user.Email, &firstName, &user.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan unverified user: %w", err)
+		}
+		user.FirstName = firstName.String
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over unverified users: %w", err)
+	}
+
+	return users, nil
+}
+
+// MarkUnverifiedWarningSent records that a user has been sent their warning-th expiry warning
+func (s *PostgresUserStore) MarkUnverifiedWarningSent(userID string, warning int) error {
+	_, err := s.db.Exec(`
+		UPDATE users SET unverified_warnings_sent = $1, unverified_warned_at = NOW()
+		WHERE user_id = $2
+	`, warning, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark unverified warning sent: %w", mapError(err))
 	}
 
 	return nil
 }
+
+// DisableUnverifiedUsers disables active accounts created before createdBefore whose email was never verified,
+// and revokes their refresh tokens. Accounts must have been sent at least minWarnings warnings, the last of them
+// before warnedBefore. Returns the number of accounts disabled
+func (s *PostgresUserStore) DisableUnverifiedUsers(createdBefore time.Time, minWarnings int, warnedBefore time.Time) (int64, error) {
+	query := `
+		WITH disabled AS (
+			UPDATE users SET disabled_at = NOW(), updated_at = NOW()
+			WHERE email_verified = false AND disabled_at IS NULL AND created_at < $1
+			  AND unverified_warnings_sent >= $2
+			  AND ($2 = 0 OR unverified_warned_at < $3)
+			RETURNING user_id
+		), revoked AS (
+			UPDATE refresh_tokens SET revoked = true
+			WHERE user_id IN (SELECT user_id FROM disabled) AND revoked = false
+		)
+		SELECT COUNT(*) FROM disabled
+	`
+
+	var count int64
+	if err := s.db.QueryRow(query, createdBefore, minWarnings, warnedBefore).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to disable unverified users: %w", mapError(err))
+	}
+
+	return count, nil
+}
+
+// PurgeDisabledUnverifiedUsers deletes accounts disabled before disabledBefore that are still unverified
+// Everything the users created is removed with them. Returns the number of accounts deleted
+func (s *PostgresUserStore) PurgeDisabledUnverifiedUsers(disabledBefore time.Time) (int64, error) {
+	result, err := s.db.Exec(`
+		DELETE FROM users
+		WHERE email_verified = false AND disabled_at < $1
+	`, disabledBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge unverified users: %w", mapError(err))
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return count, nil
+}
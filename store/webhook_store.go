@@ -0,0 +1,543 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// WebhookDeliveryPending deliveries are waiting for their next attempt
+	WebhookDeliveryPending = "pending"
+
+	// WebhookDeliverySucceeded deliveries were accepted by the endpoint with a 2xx response
+	WebhookDeliverySucceeded = "succeeded"
+
+	// WebhookDeliveryFailed deliveries ran out of attempts, or their endpoint was disabled or deleted first
+	WebhookDeliveryFailed = "failed"
+)
+
+// WebhookEndpoint is a URL a user has registered to receive events about their recipes
+// Secret signs every delivery and is only shown when the endpoint is created or its secret rotated
+type WebhookEndpoint struct {
+	ID                      int64      `json:"id"`
+	UserID                  int64      `json:"-"`
+	URL                     string     `json:"url"`
+	Description             string     `json:"description"`
+	Events                  []string   `json:"events"`
+	Secret                  string     `json:"-"`
+	PreviousSecret          *string    `json:"-"`
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+	Enabled                 bool       `json:"enabled"`
+	DisabledReason          *string    `json:"disabled_reason,omitempty"`
+	ConsecutiveFailures     int        `json:"consecutive_failures"`
+	CreatedAt               time.Time  `json:"created_at"`
+	UpdatedAt               time.Time  `json:"updated_at"`
+}
+
+// WebhookDelivery is one event sent to one endpoint, with the outcome of its latest attempt
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	EndpointID     int64           `json:"endpoint_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	ResponseBody   *string         `json:"response_body,omitempty"`
+	Error          *string         `json:"error,omitempty"`
+	DurationMS     *int            `json:"duration_ms,omitempty"`
+	RedeliveryOf   *int64          `json:"redelivery_of,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+}
+
+// WebhookAttempt is the outcome of sending a delivery once
+type WebhookAttempt struct {
+	DeliveryID     int64
+	EndpointID     int64
+	Succeeded      bool
+	ResponseStatus *int
+	ResponseBody   *string
+	Error          *string
+	Duration       time.Duration
+
+	// RetryAt schedules another attempt after a failure; nil marks the delivery as failed for good
+	RetryAt *time.Time
+
+	// DisableAfter disables the endpoint once this many attempts in a row have failed; zero never disables it
+	DisableAfter int
+}
+
+// WebhookStore defines the interface for webhook endpoints and their deliveries
+type WebhookStore interface {
+	CreateWebhookEndpoint(endpoint *WebhookEndpoint) error
+	GetWebhookEndpoints(userID int64) ([]*WebhookEndpoint, error)
+	GetWebhookEndpoint(id int64, userID int64) (*WebhookEndpoint, error)
+	GetWebhookEndpointByID(id int64) (*WebhookEndpoint, error)
+	UpdateWebhookEndpoint(endpoint *WebhookEndpoint) error
+	RotateWebhookSecret(id int64, userID int64, secret string, previousExpiresAt time.Time) (*WebhookEndpoint, error)
+	DeleteWebhookEndpoint(id int64, userID int64) error
+
+	EnqueueWebhookEvent(userID int64, eventType string, payload []byte) (int64, error)
+	EnqueueWebhookDelivery(endpointID int64, eventType string, payload []byte, redeliveryOf *int64) (*WebhookDelivery, error)
+	GetWebhookDeliveries(endpointID int64, status string, page, limit int) ([]*WebhookDelivery, int, error)
+	GetWebhookDelivery(id int64, endpointID int64) (*WebhookDelivery, error)
+	ClaimWebhookDeliveries(limit int, lease time.Duration) ([]*WebhookDelivery, error)
+	RecordWebhookAttempt(attempt *WebhookAttempt) (bool, error)
+}
+
+// PostgresWebhookStore implements the WebhookStore interface using PostgreSQL
+type PostgresWebhookStore struct {
+	db *sql.DB
+}
+
+// NewPostgresWebhookStore creates a new PostgresWebhookStore
+func NewPostgresWebhookStore(db *sql.DB) *PostgresWebhookStore {
+	return &PostgresWebhookStore{
+		db: db,
+	}
+}
+
+// webhookEndpointColumns lists the webhook_endpoints columns read by scanWebhookEndpoint, in order
+const webhookEndpointColumns = `id, user_id, url, description, events, secret, previous_secret, previous_secret_expires_at,
+	enabled, disabled_reason, consecutive_failures, created_at, updated_at`
+
+func scanWebhookEndpoint(row rowScanner) (*WebhookEndpoint, error) {
+	endpoint := &WebhookEndpoint{}
+	var events []byte
+	err := row.Scan(
+		&endpoint.ID,
+		&endpoint.UserID,
+		&endpoint.URL,
+		&endpoint.Description,
+		&events,
+		&endpoint.Secret,
+		&endpoint.PreviousSecret,
+		&endpoint.PreviousSecretExpiresAt,
+		&endpoint.Enabled,
+		&endpoint.DisabledReason,
+		&endpoint.ConsecutiveFailures,
+		&endpoint.CreatedAt,
+		&endpoint.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(events, &endpoint.Events); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook events: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+// webhookDeliveryColumns lists the webhook_deliveries columns read by scanWebhookDelivery, in order
+const webhookDeliveryColumns = `id, endpoint_id, event_type, payload, status, attempts,
+	CASE WHEN status = 'pending' THEN next_attempt_at END, response_status, response_body, error, duration_ms,
+	redelivery_of, created_at, last_attempt_at`
+
+func scanWebhookDelivery(row rowScanner, extra ...interface{}) (*WebhookDelivery, error) {
+	delivery := &WebhookDelivery{}
+	var payload []byte
+	dest := []interface{}{
+		&delivery.ID,
+		&delivery.EndpointID,
+		&delivery.EventType,
+		&payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.ResponseStatus,
+		&delivery.ResponseBody,
+		&delivery.Error,
+		&delivery.DurationMS,
+		&delivery.RedeliveryOf,
+		&delivery.CreatedAt,
+		&delivery.LastAttemptAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	delivery.Payload = json.RawMessage(payload)
+
+	return delivery, nil
+}
+
+// CreateWebhookEndpoint stores a new endpoint, filling in its ID and timestamps
+func (s *PostgresWebhookStore) CreateWebhookEndpoint(endpoint *WebhookEndpoint) error {
+	events, err := json.Marshal(endpoint.Events)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook events: %w", err)
+	}
+
+	query := `
+		INSERT INTO webhook_endpoints (user_id, url, description, events, secret)
+		VALUES ($1, $2, $3, $4::JSONB, $5)
+		RETURNING id, enabled, created_at, updated_at
+	`
+
+	err = s.db.QueryRow(query, endpoint.UserID, endpoint.URL, endpoint.Description, string(events), endpoint.Secret).
+		Scan(&endpoint.ID, &endpoint.Enabled, &endpoint.CreatedAt, &endpoint.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", mapError(err))
+	}
+
+	return nil
+}
+
+// GetWebhookEndpoints returns a user's endpoints, newest first
+func (s *PostgresWebhookStore) GetWebhookEndpoints(userID int64) ([]*WebhookEndpoint, error) {
+	query := `
+		SELECT ` + webhookEndpointColumns + `
+		FROM webhook_endpoints
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	endpoints := []*WebhookEndpoint{}
+	for rows.Next() {
+		endpoint, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook endpoint: %w", err)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over webhook endpoints: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+// GetWebhookEndpoint returns one of a user's endpoints
+// Returns nil if the endpoint does not exist or belongs to someone else
+func (s *PostgresWebhookStore) GetWebhookEndpoint(id int64, userID int64) (*WebhookEndpoint, error) {
+	query := `
+		SELECT ` + webhookEndpointColumns + `
+		FROM webhook_endpoints
+		WHERE id = $1 AND user_id = $2
+	`
+
+	endpoint, err := scanWebhookEndpoint(s.db.QueryRow(query, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+// GetWebhookEndpointByID returns an endpoint whoever owns it, for sending deliveries
+// Returns nil if the endpoint does not exist
+func (s *PostgresWebhookStore) GetWebhookEndpointByID(id int64) (*WebhookEndpoint, error) {
+	query := `
+		SELECT ` + webhookEndpointColumns + `
+		FROM webhook_endpoints
+		WHERE id = $1
+	`
+
+	endpoint, err := scanWebhookEndpoint(s.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+// UpdateWebhookEndpoint saves an endpoint's URL, description, events and enabled flag
+// Enabling an endpoint clears its failure count and the reason it was disabled; disabling it fails its pending deliveries
+// Returns ErrNotFound if the endpoint does not exist or belongs to someone else
+func (s *PostgresWebhookStore) UpdateWebhookEndpoint(endpoint *WebhookEndpoint) error {
+	events, err := json.Marshal(endpoint.Events)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook events: %w", err)
+	}
+
+	query := `
+		UPDATE webhook_endpoints
+		SET url = $3, description = $4, events = $5::JSONB, enabled = $6,
+			disabled_reason = CASE WHEN $6 THEN NULL ELSE disabled_reason END,
+			consecutive_failures = CASE WHEN $6 AND NOT enabled THEN 0 ELSE consecutive_failures END,
+			updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING disabled_reason, consecutive_failures, updated_at
+	`
+
+	return WithTx(context.Background(), s.db, func(tx *sql.Tx) error {
+		err := tx.QueryRow(query, endpoint.ID, endpoint.UserID, endpoint.URL, endpoint.Description, string(events), endpoint.Enabled).
+			Scan(&endpoint.DisabledReason, &endpoint.ConsecutiveFailures, &endpoint.UpdatedAt)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update webhook endpoint: %w", mapError(err))
+		}
+
+		if !endpoint.Enabled {
+			if err := failPendingWebhookDeliveries(tx, endpoint.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// failPendingWebhookDeliveries gives up on an endpoint's pending deliveries once it is disabled
+func failPendingWebhookDeliveries(tx *sql.Tx, endpointID int64) error {
+	_, err := tx.Exec(`
+		UPDATE webhook_deliveries
+		SET status = 'failed', error = 'endpoint disabled'
+		WHERE endpoint_id = $1 AND status = 'pending'
+	`, endpointID)
+	if err != nil {
+		return fmt.Errorf("failed to fail pending webhook deliveries: %w", mapError(err))
+	}
+	return nil
+}
+
+// RotateWebhookSecret replaces an endpoint's secret, keeping the old one signing deliveries until previousExpiresAt
+// Returns nil if the endpoint does not exist or belongs to someone else
+func (s *PostgresWebhookStore) RotateWebhookSecret(id int64, userID int64, secret string, previousExpiresAt time.Time) (*WebhookEndpoint, error) {
+	query := `
+		UPDATE webhook_endpoints
+		SET previous_secret = secret, previous_secret_expires_at = $4, secret = $3, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING ` + webhookEndpointColumns
+
+	endpoint, err := scanWebhookEndpoint(s.db.QueryRow(query, id, userID, secret, previousExpiresAt))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to rotate webhook secret: %w", mapError(err))
+	}
+
+	return endpoint, nil
+}
+
+// DeleteWebhookEndpoint deletes one of a user's endpoints along with its delivery log
+// Returns ErrNotFound if the endpoint does not exist or belongs to someone else
+func (s *PostgresWebhookStore) DeleteWebhookEndpoint(id int64, userID int64) error {
+	result, err := s.db.Exec(`DELETE FROM webhook_endpoints WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", mapError(err))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// EnqueueWebhookEvent queues a delivery of an event to each of a user's enabled endpoints subscribed to it
+// Returns the number of deliveries queued
+func (s *PostgresWebhookStore) EnqueueWebhookEvent(userID int64, eventType string, payload []byte) (int64, error) {
+	query := `
+		INSERT INTO webhook_deliveries (endpoint_id, event_type, payload)
+		SELECT id, $2, $3::JSONB
+		FROM webhook_endpoints
+		WHERE user_id = $1 AND enabled
+		  AND (jsonb_array_length(events) = 0 OR events @> jsonb_build_array($2::TEXT))
+	`
+
+	result, err := s.db.Exec(query, userID, eventType, string(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook event: %w", mapError(err))
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return count, nil
+}
+
+// EnqueueWebhookDelivery queues a delivery to a single endpoint, such as a test event or a redelivery
+func (s *PostgresWebhookStore) EnqueueWebhookDelivery(endpointID int64, eventType string, payload []byte, redeliveryOf *int64) (*WebhookDelivery, error) {
+	query := `
+		INSERT INTO webhook_deliveries (endpoint_id, event_type, payload, redelivery_of)
+		VALUES ($1, $2, $3::JSONB, $4)
+		RETURNING ` + webhookDeliveryColumns
+
+	delivery, err := scanWebhookDelivery(s.db.QueryRow(query, endpointID, eventType, string(payload), redeliveryOf))
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue webhook delivery: %w", mapError(err))
+	}
+
+	return delivery, nil
+}
+
+// GetWebhookDeliveries returns a page of an endpoint's deliveries, newest first, with the total number matching
+// An empty status returns deliveries with any status
+func (s *PostgresWebhookStore) GetWebhookDeliveries(endpointID int64, status string, page, limit int) ([]*WebhookDelivery, int, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `, COUNT(*) OVER()
+		FROM webhook_deliveries
+		WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.db.Query(query, endpointID, status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*WebhookDelivery{}
+	total := 0
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows, &total)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over webhook deliveries: %w", err)
+	}
+
+	return deliveries, total, nil
+}
+
+// GetWebhookDelivery returns one of an endpoint's deliveries
+// Returns nil if the delivery does not exist or was sent to another endpoint
+func (s *PostgresWebhookStore) GetWebhookDelivery(id int64, endpointID int64) (*WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE id = $1 AND endpoint_id = $2
+	`
+
+	delivery, err := scanWebhookDelivery(s.db.QueryRow(query, id, endpointID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// ClaimWebhookDeliveries returns up to limit pending deliveries that are due, oldest first
+// Claimed deliveries are not due again until lease has passed, so concurrent workers never send the same one
+// and a worker that crashes mid-send leaves them to be retried
+func (s *PostgresWebhookStore) ClaimWebhookDeliveries(limit int, lease time.Duration) ([]*WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	rows, err := s.db.Query(query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", mapError(err))
+	}
+	defer rows.Close()
+
+	deliveries := []*WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// RecordWebhookAttempt saves the outcome of an attempt on the delivery and its endpoint's failure count
+// It reports whether this attempt disabled the endpoint, in which case its other pending deliveries are failed too
+func (s *PostgresWebhookStore) RecordWebhookAttempt(attempt *WebhookAttempt) (bool, error) {
+	status := WebhookDeliveryPending
+	switch {
+	case attempt.Succeeded:
+		status = WebhookDeliverySucceeded
+	case attempt.RetryAt == nil:
+		status = WebhookDeliveryFailed
+	}
+
+	disabled := false
+	err := WithTx(context.Background(), s.db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			UPDATE webhook_deliveries
+			SET status = $2, attempts = attempts + 1, next_attempt_at = COALESCE($3, next_attempt_at),
+				response_status = $4, response_body = $5, error = $6, duration_ms = $7, last_attempt_at = NOW()
+			WHERE id = $1
+		`, attempt.DeliveryID, status, attempt.RetryAt, attempt.ResponseStatus, attempt.ResponseBody, attempt.Error, attempt.Duration.Milliseconds())
+		if err != nil {
+			return fmt.Errorf("failed to record webhook attempt: %w", mapError(err))
+		}
+
+		if attempt.Succeeded {
+			_, err := tx.Exec(`UPDATE webhook_endpoints SET consecutive_failures = 0 WHERE id = $1 AND consecutive_failures > 0`, attempt.EndpointID)
+			if err != nil {
+				return fmt.Errorf("failed to reset webhook failures: %w", mapError(err))
+			}
+			return nil
+		}
+
+		err = tx.QueryRow(`
+			UPDATE webhook_endpoints
+			SET consecutive_failures = consecutive_failures + 1,
+				enabled = enabled AND NOT ($2 > 0 AND consecutive_failures + 1 >= $2),
+				disabled_reason = CASE
+					WHEN enabled AND $2 > 0 AND consecutive_failures + 1 >= $2
+					THEN 'disabled after ' || (consecutive_failures + 1) || ' failed deliveries in a row'
+					ELSE disabled_reason
+				END,
+				updated_at = NOW()
+			WHERE id = $1
+			RETURNING enabled = false AND consecutive_failures = $2
+		`, attempt.EndpointID, attempt.DisableAfter).Scan(&disabled)
+		if err != nil {
+			return fmt.Errorf("failed to record webhook failure: %w", mapError(err))
+		}
+
+		if disabled {
+			return failPendingWebhookDeliveries(tx, attempt.EndpointID)
+		}
+
+		return nil
+	})
+
+	return disabled, err
+}
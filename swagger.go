@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/dapoadedire/chefshare_be/docs"
+	"github.com/dapoadedire/chefshare_be/links"
+	"github.com/gin-gonic/gin"
+	swaggerfiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// swaggerEnabled reports whether the Swagger UI and spec are served
+// They are on unless SWAGGER_ENABLED is false, so production deployments can hide them
+func swaggerEnabled() bool {
+	switch strings.ToLower(os.Getenv("SWAGGER_ENABLED")) {
+	case "false", "0", "no", "off":
+		return false
+	default:
+		return true
+	}
+}
+
+// swaggerHosts returns the hosts the API is documented as reachable at, from the comma-separated SWAGGER_HOSTS
+// The first is the default; without SWAGGER_HOSTS the host of API_BASE_URL is used
+func swaggerHosts(base *url.URL) []string {
+	if hosts := splitList(os.Getenv("SWAGGER_HOSTS")); len(hosts) > 0 {
+		return hosts
+	}
+	return []string{base.Host}
+}
+
+// swaggerBaseURL parses API_BASE_URL, falling back to links.DefaultBaseURL if it is unset or invalid
+func swaggerBaseURL() *url.URL {
+	if value := os.Getenv("API_BASE_URL"); value != "" {
+		base, err := url.Parse(value)
+		if err == nil && base.Scheme != "" && base.Host != "" {
+			return base
+		}
+		log.Printf("Ignoring invalid API_BASE_URL %q for Swagger", value)
+	}
+	base, _ := url.Parse(links.DefaultBaseURL)
+	return base
+}
+
+// setupSwaggerInfo fills in where the documented API is served from the environment
+// The host and base path come from API_BASE_URL unless SWAGGER_HOSTS is set, and the schemes from
+// the comma-separated SWAGGER_SCHEMES, defaulting to the scheme of API_BASE_URL.
+func setupSwaggerInfo() {
+	base := swaggerBaseURL()
+
+	docs.SwaggerInfo.Host = swaggerHosts(base)[0]
+	docs.SwaggerInfo.BasePath = strings.TrimRight(base.Path, "/") + links.APIPrefix
+	docs.SwaggerInfo.Schemes = splitList(getEnvOrDefault("SWAGGER_SCHEMES", base.Scheme))
+
+	fmt.Printf("Swagger configured for %s://%s%s\n", strings.Join(docs.SwaggerInfo.Schemes, ","), docs.SwaggerInfo.Host, docs.SwaggerInfo.BasePath)
+}
+
+// registerSwagger serves the Swagger UI under /swagger unless it is disabled
+// Requests for the spec that arrive on one of the other SWAGGER_HOSTS get it with that host,
+// so a deployment reachable under several names can try requests out against whichever was used.
+func registerSwagger(router *gin.Engine) {
+	if !swaggerEnabled() {
+		fmt.Println("Swagger UI disabled")
+		return
+	}
+
+	ui := ginSwagger.WrapHandler(swaggerfiles.Handler,
+		ginSwagger.URL("/swagger/doc.json"),
+		ginSwagger.DefaultModelsExpandDepth(-1),
+		ginSwagger.DocExpansion("list"),
+		ginSwagger.DeepLinking(true))
+	hosts := swaggerHosts(swaggerBaseURL())
+
+	router.GET("/swagger/*any", func(c *gin.Context) {
+		if c.Param("any") != "/doc.json" {
+			ui(c)
+			return
+		}
+
+		spec := *docs.SwaggerInfo
+		for _, host := range hosts {
+			if strings.EqualFold(host, c.Request.Host) {
+				spec.Host = host
+				break
+			}
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(spec.ReadDoc()))
+	})
+}
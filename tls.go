@@ -0,0 +1,128 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	// tlsModeAutocert obtains and renews certificates from Let's Encrypt
+	tlsModeAutocert = "autocert"
+
+	// tlsModeFiles serves the certificate and key named by TLS_CERT_FILE and TLS_KEY_FILE
+	tlsModeFiles = "files"
+)
+
+// serve starts server according to TLS_MODE
+// Without TLS_MODE the server listens for plain HTTP on PORT, as when running behind a proxy.
+// With "autocert" or "files" it serves HTTPS (and HTTP/2) on HTTPS_PORT and redirects HTTP_PORT to HTTPS,
+// so self-hosted deployments do not need a fronting proxy.
+func serve(server *http.Server, port string) error {
+	mode := strings.ToLower(os.Getenv("TLS_MODE"))
+	if mode == "" || mode == "off" {
+		server.Addr = ":" + port
+		log.Printf("Starting server on port %s...\n", port)
+		return server.ListenAndServe()
+	}
+
+	httpsPort := getEnvOrDefault("HTTPS_PORT", "443")
+	httpPort := getEnvOrDefault("HTTP_PORT", "80")
+	server.Addr = ":" + httpsPort
+
+	var redirect http.Handler = httpsRedirectHandler(httpsPort)
+	var certFile, keyFile string
+
+	switch mode {
+	case tlsModeAutocert:
+		domains := splitList(os.Getenv("TLS_DOMAINS"))
+		if len(domains) == 0 {
+			return fmt.Errorf("TLS_DOMAINS is required when TLS_MODE is %s", tlsModeAutocert)
+		}
+
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(domains...),
+			Cache:      autocert.DirCache(getEnvOrDefault("TLS_CACHE_DIR", "certs")),
+			Email:      os.Getenv("TLS_EMAIL"),
+		}
+		// The manager's config advertises h2 and answers TLS-ALPN-01 challenges
+		server.TLSConfig = manager.TLSConfig()
+		server.TLSConfig.MinVersion = tls.VersionTLS12
+		// HTTP-01 challenges are answered on the HTTP port; everything else is redirected
+		redirect = manager.HTTPHandler(redirect)
+
+	case tlsModeFiles:
+		certFile = os.Getenv("TLS_CERT_FILE")
+		keyFile = os.Getenv("TLS_KEY_FILE")
+		if certFile == "" || keyFile == "" {
+			return fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE are required when TLS_MODE is %s", tlsModeFiles)
+		}
+		// Leaving NextProtos unset lets net/http enable HTTP/2 automatically
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+	default:
+		return fmt.Errorf("unknown TLS_MODE %q (expected %s or %s)", mode, tlsModeAutocert, tlsModeFiles)
+	}
+
+	redirectServer := &http.Server{
+		Addr:              ":" + httpPort,
+		Handler:           redirect,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		log.Printf("Redirecting HTTP on port %s to HTTPS...\n", httpPort)
+		if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("HTTP redirect server stopped: %v", err)
+		}
+	}()
+
+	log.Printf("Starting HTTPS server on port %s (%s)...\n", httpsPort, mode)
+	return server.ListenAndServeTLS(certFile, keyFile)
+}
+
+// httpsRedirectHandler permanently redirects every request to the same URL over HTTPS
+func httpsRedirectHandler(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		// 308 keeps the method and body for non-GET requests
+		status := http.StatusPermanentRedirect
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			status = http.StatusMovedPermanently
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), status)
+	})
+}
+
+// splitList splits a comma-separated environment value, dropping blanks
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,11 @@
+package utils
+
+import "strings"
+
+// CSVCell neutralizes values a spreadsheet would otherwise evaluate as a formula
+func CSVCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is used when a request does not name a supported language
+const DefaultLocale = "en"
+
+// commaDecimalLocales are languages that write decimals with a comma (1,5 rather than 1.5)
+var commaDecimalLocales = map[string]struct{}{
+	"de": {}, "fr": {}, "es": {}, "it": {}, "pt": {}, "nl": {}, "ru": {}, "pl": {},
+	"tr": {}, "sv": {}, "da": {}, "nb": {}, "no": {}, "fi": {}, "cs": {}, "id": {},
+	"ro": {}, "hu": {}, "el": {}, "uk": {},
+}
+
+// quantityFractions are the vulgar fractions used for common cooking amounts
+var quantityFractions = []struct {
+	value  float64
+	symbol string
+}{
+	{1.0 / 8, "⅛"},
+	{1.0 / 4, "¼"},
+	{1.0 / 3, "⅓"},
+	{3.0 / 8, "⅜"},
+	{1.0 / 2, "½"},
+	{5.0 / 8, "⅝"},
+	{2.0 / 3, "⅔"},
+	{3.0 / 4, "¾"},
+	{7.0 / 8, "⅞"},
+}
+
+// fractionTolerance is how close a quantity must be to a fraction to be written as one
+const fractionTolerance = 0.01
+
+// ParseAcceptLanguage returns the primary language of the highest-weighted entry in an Accept-Language header
+// It returns DefaultLocale if the header is empty or only contains wildcards
+func ParseAcceptLanguage(header string) string {
+	best := ""
+	bestWeight := -1.0
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		weight := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					weight = q
+				}
+			}
+		}
+
+		if weight > bestWeight {
+			best = tag
+			bestWeight = weight
+		}
+	}
+
+	if best == "" {
+		return DefaultLocale
+	}
+
+	// Only the language matters for number formatting, so drop any region (e.g. de-AT -> de)
+	if i := strings.IndexAny(best, "-_"); i > 0 {
+		best = best[:i]
+	}
+
+	return best
+}
+
+// FormatQuantity renders a quantity and optional unit for display in the given locale
+// Common cooking fractions are written as fraction characters ("1½ cup"); other values use
+// the locale's decimal separator with at most two decimal places
+func FormatQuantity(quantity float64, unit *string, locale string) string {
+	formatted := formatNumber(quantity, locale)
+	if unit != nil && strings.TrimSpace(*unit) != "" {
+		formatted += " " + strings.TrimSpace(*unit)
+	}
+	return formatted
+}
+
+func formatNumber(value float64, locale string) string {
+	whole, frac := math.Modf(value)
+
+	if frac < fractionTolerance {
+		return strconv.FormatFloat(whole, 'f', 0, 64)
+	}
+	if frac > 1-fractionTolerance {
+		return strconv.FormatFloat(whole+1, 'f', 0, 64)
+	}
+
+	for _, fraction := range quantityFractions {
+		if math.Abs(frac-fraction.value) < fractionTolerance {
+			if whole == 0 {
+				return fraction.symbol
+			}
+			return strconv.FormatFloat(whole, 'f', 0, 64) + fraction.symbol
+		}
+	}
+
+	formatted := strconv.FormatFloat(value, 'f', 2, 64)
+	formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+	if _, ok := commaDecimalLocales[locale]; ok {
+		formatted = strings.Replace(formatted, ".", ",", 1)
+	}
+
+	return formatted
+}
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SupportedLanguages are the ISO 639-1 codes DetectLanguage can return, in alphabetical order
+var SupportedLanguages = []string{"ar", "de", "el", "en", "es", "fr", "he", "hi", "it", "ja", "ko", "nl", "pt", "ru", "th", "zh"}
+
+// IsSupportedLanguage reports whether code is one of the SupportedLanguages
+func IsSupportedLanguage(code string) bool {
+	for _, supported := range SupportedLanguages {
+		if code == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// languageScripts map writing systems used by a single supported language to that language
+// Han is left out: it is shared by Chinese and Japanese and told apart by kana
+var languageScripts = []struct {
+	table    *unicode.RangeTable
+	language string
+}{
+	{unicode.Arabic, "ar"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Greek, "el"},
+	{unicode.Hangul, "ko"},
+	{unicode.Hebrew, "he"},
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Thai, "th"},
+}
+
+// languageWords are frequent function words and everyday cooking words of each language written in Latin script
+// Words shared by several languages count for each of them; the ones that are not tell them apart.
+var languageWords = map[string][]string{
+	"en": {"the", "and", "with", "of", "for", "to", "in", "a", "an", "is", "are", "until", "into", "on", "your", "this", "it",
+		"from", "or", "add", "bake", "stir", "mix", "heat", "serve", "chicken", "beef", "easy", "quick", "homemade", "best",
+		"recipe", "cake", "bread", "cheese", "sweet", "soup", "sugar", "flour", "eggs"},
+	"fr": {"le", "la", "les", "et", "de", "des", "du", "au", "aux", "avec", "pour", "un", "une", "en", "est", "dans", "sur", "à",
+		"ou", "poulet", "gâteau", "sucre", "beurre", "farine", "oeufs", "œufs", "recette", "facile", "maison", "cuire", "ajouter"},
+	"es": {"el", "la", "los", "las", "y", "de", "con", "para", "un", "una", "en", "es", "del", "al", "por", "pollo", "azúcar",
+		"mantequilla", "harina", "huevos", "receta", "fácil", "casera", "cocinar", "añadir", "minutos", "sopa", "tarta", "queso"},
+	"de": {"der", "die", "das", "und", "mit", "für", "ein", "eine", "in", "ist", "den", "dem", "zu", "von", "auf", "oder",
+		"hähnchen", "zucker", "mehl", "eier", "rezept", "einfach", "kuchen", "suppe", "minuten", "käse", "backen"},
+	"it": {"il", "lo", "la", "i", "gli", "le", "e", "di", "con", "per", "un", "una", "in", "è", "del", "della", "al", "alla",
+		"pollo", "zucchero", "burro", "farina", "uova", "ricetta", "facile", "torta", "minuti", "formaggio", "cuocere", "zuppa"},
+	"pt": {"o", "a", "os", "as", "e", "de", "com", "para", "um", "uma", "em", "é", "do", "da", "dos", "das", "no", "na",
+		"frango", "açúcar", "manteiga", "farinha", "ovos", "receita", "fácil", "bolo", "minutos", "queijo", "sopa", "cozinhar"},
+	"nl": {"de", "het", "een", "en", "met", "voor", "van", "in", "is", "op", "te", "of", "kip", "suiker", "boter", "bloem",
+		"eieren", "recept", "makkelijk", "taart", "minuten", "kaas", "soep", "koken", "toevoegen"},
+}
+
+// languagesByWord indexes languageWords by word
+var languagesByWord = func() map[string][]string {
+	index := map[string][]string{}
+	for language, words := range languageWords {
+		for _, word := range words {
+			index[word] = append(index[word], language)
+		}
+	}
+	return index
+}()
+
+// minLanguageWordHits is how many known words text in Latin script needs before its language is trusted
+const minLanguageWordHits = 2
+
+// DetectLanguage guesses the language text is written in, returning an ISO 639-1 code from SupportedLanguages,
+// or "" when the text is too short or too mixed to tell
+// Text mostly in a script only one supported language uses is taken to be in that language; Latin text is
+// scored by the common words of each language it contains and must have a clear winner.
+func DetectLanguage(text string) string {
+	letters, latin, han, kana := 0, 0, 0, 0
+	scripts := map[string]int{}
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Han, r):
+			han++
+		default:
+			for _, script := range languageScripts {
+				if unicode.Is(script.table, r) {
+					scripts[script.language]++
+					if script.language == "ja" {
+						kana++
+					}
+					break
+				}
+			}
+		}
+	}
+
+	if letters == 0 {
+		return ""
+	}
+	if latin*2 >= letters {
+		return detectLatinLanguage(text)
+	}
+
+	// Japanese mixes kanji with kana, while Chinese uses Han characters alone
+	if han > 0 {
+		if kana > 0 {
+			scripts["ja"] += han
+		} else {
+			scripts["zh"] += han
+		}
+	}
+
+	best, bestCount := "", 0
+	for language, count := range scripts {
+		if count > bestCount || (count == bestCount && language < best) {
+			best, bestCount = language, count
+		}
+	}
+	return best
+}
+
+// detectLatinLanguage scores text against languageWords and returns the language with the most hits,
+// or "" if it has fewer than minLanguageWordHits or shares the top score with another language
+func detectLatinLanguage(text string) string {
+	scores := map[string]int{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		for _, language := range languagesByWord[word] {
+			scores[language]++
+		}
+	}
+
+	best, bestScore, runnerUp := "", 0, 0
+	for language, score := range scores {
+		if score > bestScore {
+			best, bestScore, runnerUp = language, score, bestScore
+		} else if score > runnerUp {
+			runnerUp = score
+		}
+	}
+
+	if bestScore < minLanguageWordHits || bestScore == runnerUp {
+		return ""
+	}
+	return best
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionRegex matches @username where the @ does not follow a word character, so email addresses are skipped
+var mentionRegex = regexp.MustCompile(`(?:^|[^A-Za-z0-9_@])@([A-Za-z0-9_]+)`)
+
+// ExtractMentions returns the distinct usernames mentioned with @username in text, in order of first appearance
+// Names that are not valid usernames are skipped, matching is case-insensitive, and at most max names are returned
+func ExtractMentions(text string, max int) []string {
+	mentions := []string{}
+	seen := make(map[string]struct{})
+
+	for _, match := range mentionRegex.FindAllStringSubmatch(text, -1) {
+		if len(mentions) >= max {
+			break
+		}
+
+		username := match[1]
+		if !IsValidUsername(username) {
+			continue
+		}
+
+		key := strings.ToLower(username)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		mentions = append(mentions, username)
+	}
+
+	return mentions
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"time"
+
+	// Embed the time zone database so user time zones work on hosts without one
+	_ "time/tzdata"
+)
+
+// IsValidTimezone reports whether name is an IANA time zone name such as Europe/Paris or UTC
+func IsValidTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// LocationOrUTC returns the named time zone, or UTC if name is empty or not a valid time zone
+func LocationOrUTC(name string) *time.Location {
+	if !IsValidTimezone(name) {
+		return time.UTC
+	}
+	location, _ := time.LoadLocation(name)
+	return location
+}
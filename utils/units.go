@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// UnitKind is what a unit measures
+type UnitKind string
+
+const (
+	UnitKindVolume UnitKind = "volume"
+	UnitKindWeight UnitKind = "weight"
+)
+
+// Unit is a cooking unit of volume or weight
+// Factor converts a quantity in the unit to millilitres (volume) or grams (weight)
+type Unit struct {
+	Name   string
+	Kind   UnitKind
+	Factor float64
+}
+
+// units are the supported units by canonical name; US customary measures are used for cups, ounces and pints
+var units = map[string]Unit{
+	"ml":     {"ml", UnitKindVolume, 1},
+	"l":      {"l", UnitKindVolume, 1000},
+	"tsp":    {"tsp", UnitKindVolume, 4.92892},
+	"tbsp":   {"tbsp", UnitKindVolume, 14.7868},
+	"fl oz":  {"fl oz", UnitKindVolume, 29.5735},
+	"cup":    {"cup", UnitKindVolume, 236.588},
+	"pint":   {"pint", UnitKindVolume, 473.176},
+	"quart":  {"quart", UnitKindVolume, 946.353},
+	"gallon": {"gallon", UnitKindVolume, 3785.41},
+	"mg":     {"mg", UnitKindWeight, 0.001},
+	"g":      {"g", UnitKindWeight, 1},
+	"kg":     {"kg", UnitKindWeight, 1000},
+	"oz":     {"oz", UnitKindWeight, 28.3495},
+	"lb":     {"lb", UnitKindWeight, 453.592},
+}
+
+// unitAliases maps other spellings of units to their canonical names
+var unitAliases = map[string]string{
+	"milliliter": "ml", "milliliters": "ml", "millilitre": "ml", "millilitres": "ml",
+	"liter": "l", "liters": "l", "litre": "l", "litres": "l",
+	"teaspoon": "tsp", "teaspoons": "tsp", "tsps": "tsp",
+	"tablespoon": "tbsp", "tablespoons": "tbsp", "tbsps": "tbsp", "tbs": "tbsp", "tbl": "tbsp",
+	"fluid ounce": "fl oz", "fluid ounces": "fl oz", "floz": "fl oz", "fl. oz": "fl oz",
+	"cups": "cup", "pints": "pint", "pt": "pint",
+	"quarts": "quart", "qt": "quart",
+	"gallons": "gallon", "gal": "gallon",
+	"milligram": "mg", "milligrams": "mg",
+	"gram": "g", "grams": "g", "gr": "g",
+	"kilogram": "kg", "kilograms": "kg", "kgs": "kg",
+	"ounce": "oz", "ounces": "oz",
+	"pound": "lb", "pounds": "lb", "lbs": "lb",
+}
+
+// ParseUnit looks up a unit by name or common spelling, ignoring case and a trailing period ("Tbsp.")
+func ParseUnit(name string) (Unit, bool) {
+	name = strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	name = strings.TrimSuffix(name, ".")
+	if canonical, ok := unitAliases[name]; ok {
+		name = canonical
+	}
+	unit, ok := units[name]
+	return unit, ok
+}
+
+// UnitNames returns the canonical names of the supported units in alphabetical order
+func UnitNames() []string {
+	names := make([]string, 0, len(units))
+	for name := range units {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConvertQuantity converts a quantity between units
+// Converting between volume and weight uses density, in grams per millilitre, which is ignored otherwise
+func ConvertQuantity(quantity float64, from, to Unit, density float64) float64 {
+	base := quantity * from.Factor
+	switch {
+	case from.Kind == UnitKindVolume && to.Kind == UnitKindWeight:
+		base *= density
+	case from.Kind == UnitKindWeight && to.Kind == UnitKindVolume:
+		base /= density
+	}
+	return base / to.Factor
+}